@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kostyay/netmon/internal/agent"
+	"github.com/kostyay/netmon/internal/audit"
+	"github.com/kostyay/netmon/internal/collector"
+	"github.com/kostyay/netmon/internal/config"
+	"github.com/kostyay/netmon/internal/geoip"
+)
+
+var agentInterval time.Duration
+
+var agentCmd = &cobra.Command{
+	Use:   "agent",
+	Short: "Stream snapshots and accept commands as JSON lines on stdio, for embedding",
+	Long: `Run netmon's collection engine as a subprocess speaking JSON Lines on stdio.
+
+Every line on stdout is an event ("hello", "snapshot", "result", "error");
+snapshots have the same shape as --json. Every line on stdin is a command:
+  {"id":"1","cmd":"filter","filter":"chrome"}
+  {"id":"2","cmd":"kill","pid":4242,"signal":"SIGTERM"}
+  {"id":"3","cmd":"refresh"}
+  {"id":"4","cmd":"quit"}
+
+Filters use the TUI's search syntax, including ~fuzzy, tree:PID, iface:NAME
+and, with geoipDatabase set, geo:CC and asn:N.
+
+The agent exits when stdin closes. Kills are limited to PIDs in the latest
+snapshot and recorded in the audit log. Killing a system service (the
+criticalProcesses setting) needs its executable name in "confirm".`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		a := agent.New(collector.New(), collector.NewNetIOCollector(), agentInterval)
+		a.Critical = config.CurrentSettings.CriticalProcesses
+		if path := config.CurrentSettings.GeoIPDatabase; path != "" {
+			db, err := geoip.LoadFile(config.ExpandHome(path))
+			if err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "warning: %v\n", err) // geo: and asn: then match nothing
+			} else {
+				a.Geo = db
+			}
+		}
+		a.Audit = func(e audit.Entry) {
+			e.Source = "agent"
+			recordCLIAudit(cmd.ErrOrStderr(), e) // stderr: stdout is the protocol
+		}
+		return a.Run(ctx, cmd.InOrStdin(), cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	agentCmd.Flags().DurationVar(&agentInterval, "interval", agent.DefaultInterval, "How often to emit a snapshot")
+	rootCmd.AddCommand(agentCmd)
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kostyay/netmon/internal/allowlist"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// allowResolveTimeout bounds the DNS lookups of allowlist domains at startup.
+const allowResolveTimeout = 5 * time.Second
+
+// loadAllowList loads the --allow-file allowlist and resolves its domains.
+// Domains that do not resolve are a warning: they can still match by reverse
+// DNS name.
+func loadAllowList(path string) (*allowlist.List, error) {
+	l, err := allowlist.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), allowResolveTimeout)
+	defer cancel()
+	if err := l.Resolve(ctx, lookupNetIP); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: allow file: %v\n", err)
+	}
+	return l, nil
+}
+
+// lookupNetIP resolves host to its IPv4 and IPv6 addresses.
+func lookupNetIP(ctx context.Context, host string) ([]netip.Addr, error) {
+	return net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+}
+
+// filterSnapshotByAllowlist keeps the outbound connections to destinations
+// outside allowed. Remote hosts are reverse resolved first when the list has
+// wildcard domains, which only match by name.
+func filterSnapshotByAllowlist(ctx context.Context, snapshot *model.NetworkSnapshot, allowed *allowlist.List) *model.NetworkSnapshot {
+	var names map[string]string
+	if allowed.HasWildcards() {
+		names = reverseNames(ctx, allowlist.Violations(snapshot, allowed, nil))
+	}
+	outside := make(map[string]bool)
+	for _, v := range allowlist.Violations(snapshot, allowed, names) {
+		outside[violationKey(v.PID, v.Protocol, v.LocalAddr, v.RemoteAddr)] = true
+	}
+	return filterSnapshotConns(snapshot, func(conn model.Connection) bool {
+		return outside[violationKey(conn.PID, string(conn.Protocol), conn.LocalAddr, conn.RemoteAddr)]
+	})
+}
+
+// reverseNames looks up the DNS names of the violations' remote hosts.
+func reverseNames(ctx context.Context, violations []allowlist.Violation) map[string]string {
+	ctx, cancel := context.WithTimeout(ctx, allowResolveTimeout)
+	defer cancel()
+	names := make(map[string]string)
+	for _, v := range violations {
+		host := allowlist.RemoteHost(v.RemoteAddr)
+		if _, ok := names[host]; ok {
+			continue
+		}
+		names[host] = ""
+		if found, err := net.DefaultResolver.LookupAddr(ctx, host); err == nil && len(found) > 0 {
+			names[host] = strings.TrimSuffix(found[0], ".")
+		}
+	}
+	return names
+}
+
+// violationKey identifies a connection across a snapshot and its violations.
+func violationKey(pid int32, protocol, local, remote string) string {
+	return fmt.Sprintf("%d/%s/%s/%s", pid, protocol, local, remote)
+}
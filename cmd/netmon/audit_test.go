@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kostyay/netmon/internal/audit"
+)
+
+func TestWriteAudit(t *testing.T) {
+	entries := []audit.Entry{
+		{Time: time.Now(), User: "alice", Source: "tui", Action: "kill", Target: "nginx", PIDs: []int32{42, 43}, Signal: "SIGTERM", Result: audit.ResultOK},
+		{Time: time.Now(), User: "root (sudo: bob)", Source: "tui", Action: "stop", Target: "web", ContainerID: "abc123", Result: audit.ResultFailed, Error: "timeout"},
+	}
+	var buf bytes.Buffer
+	if err := writeAudit(&buf, "/state/audit.log", entries); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{"USER", "alice", "42,43", "SIGTERM", "web [abc123]", "failed: timeout", "root (sudo: bob)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	_ = writeAudit(&buf, "/state/audit.log", nil)
+	if !strings.Contains(buf.String(), "No actions recorded in /state/audit.log") {
+		t.Errorf("empty output = %q", buf.String())
+	}
+}
+
+func TestWriteAuditJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeAuditJSON(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	var got []audit.Entry
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil || got == nil {
+		t.Errorf("empty JSON = %q, want []", buf.String())
+	}
+}
+
+func TestRecordCLIAudit(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	var warn bytes.Buffer
+	recordCLIAudit(&warn, audit.Entry{Action: "kill", Target: "nginx on port 80", Result: audit.ResultOK})
+	if warn.Len() != 0 {
+		t.Fatalf("warning = %q", warn.String())
+	}
+
+	var buf bytes.Buffer
+	auditCmd.SetOut(&buf)
+	t.Cleanup(func() { auditCmd.SetOut(nil) })
+	if err := auditCmd.RunE(auditCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	if out := buf.String(); !strings.Contains(out, "nginx on port 80") || !strings.Contains(out, "cli") {
+		t.Errorf("netmon audit output:\n%s", out)
+	}
+}
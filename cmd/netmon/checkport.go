@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kostyay/netmon/internal/collector"
+	"github.com/kostyay/netmon/internal/docker"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+var checkPortCmd = &cobra.Command{
+	Use:   "check-port PORT...",
+	Short: "Report whether ports are free, and who holds them if not",
+	Long: `Check whether local ports are free before starting a stack. A port is taken
+when a process has a socket bound to it (a TCP listener or a bound UDP socket)
+or a running container publishes it.
+
+Exits with status 1 when any port is taken, so it can guard a script:
+  netmon check-port 3000 8080
+  netmon check-port 5432 && docker compose up
+  netmon check-port 3000 --json`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ports, err := parsePorts(args)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		snapshot, err := collector.New().Collect(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to collect network data: %w", err)
+		}
+		// Docker is optional: Resolve returns no containers when it is unavailable
+		var containers []model.VirtualContainer
+		if res, err := docker.NewResolver().Resolve(ctx); err == nil {
+			containers = res.Containers
+		}
+
+		checks := checkPorts(snapshot, containers, ports)
+		if jsonOutput {
+			err = writePortChecksJSON(cmd.OutOrStdout(), checks)
+		} else {
+			err = writePortChecks(cmd.OutOrStdout(), checks)
+		}
+		if err != nil {
+			return err
+		}
+		if taken := takenCount(checks); taken > 0 {
+			return fmt.Errorf("%d of %d port(s) in use", taken, len(checks))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(checkPortCmd)
+}
+
+// portOwner is a process socket bound to a checked port.
+type portOwner struct {
+	Process  string `json:"process"`
+	PID      int32  `json:"pid"`
+	Protocol string `json:"protocol"`
+	Addr     string `json:"addr"`
+}
+
+// portPublisher is a container publishing a checked port on the host.
+type portPublisher struct {
+	Container     string `json:"container"`
+	Image         string `json:"image"`
+	HostIP        string `json:"host_ip,omitempty"`
+	ContainerPort int    `json:"container_port"`
+	Protocol      string `json:"protocol"`
+}
+
+// portCheck is the result for one port. Containers are listed separately from
+// owners: with Docker's userland proxy the port also shows up as docker-proxy,
+// without it (iptables only) the mapping is the only trace.
+type portCheck struct {
+	Port       int             `json:"port"`
+	Free       bool            `json:"free"`
+	Owners     []portOwner     `json:"owners,omitempty"`
+	Containers []portPublisher `json:"containers,omitempty"`
+}
+
+// parsePorts parses port arguments, rejecting anything outside 1-65535.
+func parsePorts(args []string) ([]int, error) {
+	ports := make([]int, 0, len(args))
+	for _, arg := range args {
+		port, err := strconv.Atoi(arg)
+		if err != nil || port < 1 || port > 65535 {
+			return nil, fmt.Errorf("invalid port: %s", arg)
+		}
+		ports = append(ports, port)
+	}
+	return ports, nil
+}
+
+// checkPorts reports, for each port, the bound sockets and container mappings
+// holding it. Established connections using the port locally do not count:
+// they do not stop a server from binding it.
+func checkPorts(snapshot *model.NetworkSnapshot, containers []model.VirtualContainer, ports []int) []portCheck {
+	checks := make([]portCheck, len(ports))
+	index := make(map[int]int, len(ports))
+	for i, port := range ports {
+		checks[i] = portCheck{Port: port}
+		index[port] = i
+	}
+
+	for _, app := range snapshot.Applications {
+		for _, conn := range app.Connections {
+			i, ok := index[model.ExtractPort(conn.LocalAddr)]
+			if !ok || !conn.IsBound() {
+				continue
+			}
+			checks[i].Owners = append(checks[i].Owners, portOwner{
+				Process:  app.Name,
+				PID:      conn.PID,
+				Protocol: string(conn.Protocol),
+				Addr:     conn.LocalAddr,
+			})
+		}
+	}
+
+	for _, vc := range containers {
+		for _, pm := range vc.PortMappings {
+			i, ok := index[pm.HostPort]
+			if !ok {
+				continue
+			}
+			checks[i].Containers = append(checks[i].Containers, portPublisher{
+				Container:     vc.Info.Name,
+				Image:         vc.Info.Image,
+				HostIP:        pm.HostIP,
+				ContainerPort: pm.ContainerPort,
+				Protocol:      pm.Protocol,
+			})
+		}
+	}
+
+	for i := range checks {
+		checks[i].Free = len(checks[i].Owners) == 0 && len(checks[i].Containers) == 0
+	}
+	return checks
+}
+
+// takenCount returns how many checked ports are in use.
+func takenCount(checks []portCheck) int {
+	n := 0
+	for _, c := range checks {
+		if !c.Free {
+			n++
+		}
+	}
+	return n
+}
+
+// writePortChecks prints one line per port, plus a line per extra holder.
+func writePortChecks(w io.Writer, checks []portCheck) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PORT\tSTATUS\tHELD BY\tBIND")
+	for _, c := range checks {
+		if c.Free {
+			fmt.Fprintf(tw, "%d\tfree\t\t\n", c.Port)
+			continue
+		}
+		var holders [][2]string
+		for _, o := range c.Owners {
+			holders = append(holders, [2]string{
+				fmt.Sprintf("%s (PID %d)", o.Process, o.PID),
+				o.Protocol + " " + o.Addr,
+			})
+		}
+		for _, p := range c.Containers {
+			hostIP := p.HostIP
+			if hostIP == "" {
+				hostIP = "*"
+			}
+			holders = append(holders, [2]string{
+				fmt.Sprintf("container %s (%s)", p.Container, p.Image),
+				fmt.Sprintf("%s:%d → %d/%s", hostIP, c.Port, p.ContainerPort, p.Protocol),
+			})
+		}
+		for i, h := range holders {
+			port, status := "", ""
+			if i == 0 {
+				port, status = strconv.Itoa(c.Port), "in use"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", port, status, h[0], h[1])
+		}
+	}
+	return tw.Flush()
+}
+
+// writePortChecksJSON prints the checks as a JSON array.
+func writePortChecksJSON(w io.Writer, checks []portCheck) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(checks)
+}
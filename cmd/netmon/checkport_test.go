@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+func checkPortSnapshot() *model.NetworkSnapshot {
+	return &model.NetworkSnapshot{Applications: []model.Application{
+		{Name: "node", Connections: []model.Connection{
+			{PID: 4242, Protocol: model.ProtocolTCP, LocalAddr: "0.0.0.0:8080", RemoteAddr: "*", State: model.StateListen},
+			{PID: 4242, Protocol: model.ProtocolTCP, LocalAddr: "192.168.1.5:3000", RemoteAddr: "10.0.0.1:443", State: model.StateEstablished},
+		}},
+		{Name: "dnsmasq", Connections: []model.Connection{
+			{PID: 99, Protocol: model.ProtocolUDP, LocalAddr: "127.0.0.1:53", RemoteAddr: "*", State: model.StateNone},
+		}},
+	}}
+}
+
+func checkPortContainers() []model.VirtualContainer {
+	return []model.VirtualContainer{{
+		Info:         model.ContainerInfo{Name: "db", Image: "postgres:16"},
+		PortMappings: []model.PortMapping{{HostPort: 5432, ContainerPort: 5432, Protocol: "tcp", HostIP: "0.0.0.0"}},
+	}}
+}
+
+func TestParsePorts(t *testing.T) {
+	ports, err := parsePorts([]string{"3000", "65535"})
+	if err != nil || len(ports) != 2 || ports[0] != 3000 {
+		t.Errorf("parsePorts = %v, %v", ports, err)
+	}
+	for _, bad := range []string{"0", "65536", "http", "-1"} {
+		if _, err := parsePorts([]string{bad}); err == nil {
+			t.Errorf("parsePorts(%q) should fail", bad)
+		}
+	}
+}
+
+func TestCheckPorts(t *testing.T) {
+	checks := checkPorts(checkPortSnapshot(), checkPortContainers(), []int{3000, 8080, 53, 5432})
+
+	if !checks[0].Free {
+		t.Errorf("3000 should be free: an established connection from it does not hold it, got %+v", checks[0])
+	}
+	if checks[1].Free || len(checks[1].Owners) != 1 || checks[1].Owners[0].PID != 4242 {
+		t.Errorf("8080 = %+v, want held by node", checks[1])
+	}
+	if checks[2].Free || checks[2].Owners[0].Protocol != "UDP" {
+		t.Errorf("53 = %+v, want held by a bound UDP socket", checks[2])
+	}
+	if checks[3].Free || len(checks[3].Containers) != 1 || checks[3].Containers[0].Container != "db" {
+		t.Errorf("5432 = %+v, want published by container db", checks[3])
+	}
+	if got := takenCount(checks); got != 3 {
+		t.Errorf("takenCount = %d, want 3", got)
+	}
+}
+
+func TestWritePortChecks(t *testing.T) {
+	checks := checkPorts(checkPortSnapshot(), checkPortContainers(), []int{3000, 8080, 5432})
+
+	var buf bytes.Buffer
+	if err := writePortChecks(&buf, checks); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{"free", "node (PID 4242)", "TCP 0.0.0.0:8080", "container db (postgres:16)", "0.0.0.0:5432 → 5432/tcp"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
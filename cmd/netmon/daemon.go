@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kostyay/netmon/internal/collector"
+	"github.com/kostyay/netmon/internal/daemon"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+var (
+	daemonSocket     string
+	daemonInterval   time.Duration
+	daemonSocketMode string
+	daemonGroup      string
+	attachSocket     string
+)
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Collect continuously and serve snapshots on a unix socket for `netmon --attach`",
+	Long: `Run collection in the background and expose it on a local unix socket.
+
+Start the daemon once with the privileges needed for full visibility, then
+attach any number of unprivileged viewers:
+  sudo netmon daemon --socket-group staff
+  netmon --attach`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mode, err := strconv.ParseUint(daemonSocketMode, 8, 32)
+		if err != nil {
+			return fmt.Errorf("invalid --socket-mode %q: %w", daemonSocketMode, err)
+		}
+		ln, err := daemon.Listen(daemonSocket, os.FileMode(mode), daemonGroup)
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		rec, releaseStore, err := startRecorder(ctx)
+		if err != nil {
+			_ = ln.Close()
+			return err
+		}
+		defer releaseStore()
+		srv := daemon.NewServer(daemonInterval)
+		if rec != nil {
+			srv.OnCollect(func(snap *model.NetworkSnapshot) {
+				if err := rec.Observe(snap); err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "warning: %v\n", err)
+				}
+			})
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "netmon daemon listening on %s\n", daemonSocket)
+		return srv.Serve(ctx, ln)
+	},
+}
+
+func init() {
+	daemonCmd.Flags().StringVar(&daemonSocket, "socket", daemon.DefaultSocket, "Unix socket to listen on")
+	daemonCmd.Flags().DurationVar(&daemonInterval, "interval", daemon.DefaultInterval, "Collection interval")
+	daemonCmd.Flags().StringVar(&daemonSocketMode, "socket-mode", "0660", "Permissions of the socket file (octal)")
+	daemonCmd.Flags().StringVar(&daemonGroup, "socket-group", "", "Group that owns the socket, so its members can attach without root")
+	rootCmd.AddCommand(daemonCmd)
+
+	rootCmd.Flags().StringVar(&attachSocket, "attach", "", "Read from a running `netmon daemon` instead of collecting locally")
+	rootCmd.Flags().Lookup("attach").NoOptDefVal = daemon.DefaultSocket
+}
+
+// attachCollectors connects to the daemon at socketPath and returns collectors
+// reading from it, failing fast if the daemon is not running.
+func attachCollectors(socketPath string) (collector.Collector, collector.NetIOCollector, error) {
+	c := daemon.Dial(socketPath)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := c.Ping(ctx); err != nil {
+		return nil, nil, fmt.Errorf("attach to %s: %w (is `netmon daemon` running?)", socketPath, err)
+	}
+	return c, c.NetIO(), nil
+}
+
+// collectAttached is collector.CollectOnce against a running daemon.
+func collectAttached(ctx context.Context, socketPath string) (*model.NetworkSnapshot, map[int32]*model.NetIOStats, error) {
+	c, netIO, err := attachCollectors(socketPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	snapshot, err := c.Collect(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	ioStats, err := netIO.Collect(ctx)
+	if err != nil {
+		return snapshot, nil, err
+	}
+	return snapshot, ioStats, nil
+}
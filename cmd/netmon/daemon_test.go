@@ -0,0 +1,31 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAttachCollectors_NoDaemon(t *testing.T) {
+	dir, err := os.MkdirTemp("", "nm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	_, _, err = attachCollectors(filepath.Join(dir, "missing.sock"))
+	if err == nil || !strings.Contains(err.Error(), "netmon daemon") {
+		t.Errorf("attachCollectors() err = %v, want hint to start the daemon", err)
+	}
+}
+
+func TestAttachFlag_DefaultsToDaemonSocket(t *testing.T) {
+	f := rootCmd.Flags().Lookup("attach")
+	if f == nil {
+		t.Fatal("--attach flag not registered")
+	}
+	if f.NoOptDefVal == "" {
+		t.Error("bare --attach should default to the daemon socket")
+	}
+}
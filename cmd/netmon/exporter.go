@@ -0,0 +1,49 @@
+package main
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kostyay/netmon/internal/metrics"
+)
+
+var exporterCmd = &cobra.Command{
+	Use:   "exporter",
+	Short: "Grafana dashboard and reference for the metrics of --format prometheus",
+	Long: `Helpers for the Prometheus metrics netmon writes with --format prometheus
+(for node_exporter's textfile collector).
+
+Examples:
+  netmon exporter dashboard > netmon.json   # Import in Grafana
+  netmon exporter metrics                   # Metric names, labels and help`,
+}
+
+var exporterDashboardCmd = &cobra.Command{
+	Use:   "dashboard",
+	Short: "Print a Grafana dashboard (JSON) charting every exported metric",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := metrics.Dashboard()
+		if err != nil {
+			return err
+		}
+		_, err = cmd.OutOrStdout().Write(append(data, '\n'))
+		return err
+	},
+}
+
+var exporterMetricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Print the exported metrics as a Markdown table",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := io.WriteString(cmd.OutOrStdout(), metrics.Reference())
+		return err
+	},
+}
+
+func init() {
+	exporterCmd.AddCommand(exporterDashboardCmd, exporterMetricsCmd)
+	rootCmd.AddCommand(exporterCmd)
+}
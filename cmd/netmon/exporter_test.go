@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/kostyay/netmon/internal/model"
+	"github.com/kostyay/netmon/internal/output"
+)
+
+// exportedMetrics returns the metric names --format prometheus writes.
+func exportedMetrics(t *testing.T) map[string]bool {
+	t.Helper()
+	r, err := output.Lookup("prometheus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := r.Render(&buf, output.Input{Snapshot: &model.NetworkSnapshot{}}); err != nil {
+		t.Fatal(err)
+	}
+	names := make(map[string]bool)
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if fields := strings.Fields(line); len(fields) == 4 && fields[1] == "TYPE" {
+			names[fields[2]] = true
+		}
+	}
+	if len(names) == 0 {
+		t.Fatalf("no metrics exported:\n%s", buf.String())
+	}
+	return names
+}
+
+func TestExporterDashboard_QueriesExportedMetrics(t *testing.T) {
+	var buf bytes.Buffer
+	exporterDashboardCmd.SetOut(&buf)
+	defer exporterDashboardCmd.SetOut(nil)
+	if err := exporterDashboardCmd.RunE(exporterDashboardCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var d struct {
+		Panels []struct {
+			Title   string `json:"title"`
+			Targets []struct {
+				Expr string `json:"expr"`
+			} `json:"targets"`
+		} `json:"panels"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &d); err != nil {
+		t.Fatalf("dashboard is not valid JSON: %v", err)
+	}
+
+	exported := exportedMetrics(t)
+	charted := make(map[string]bool)
+	metricRef := regexp.MustCompile(`netmon_[a-z_]+`)
+	for _, p := range d.Panels {
+		for _, tgt := range p.Targets {
+			for _, name := range metricRef.FindAllString(tgt.Expr, -1) {
+				if !exported[name] {
+					t.Errorf("panel %q queries %s, which --format prometheus does not write", p.Title, name)
+				}
+				charted[name] = true
+			}
+		}
+	}
+	for name := range exported {
+		if !charted[name] {
+			t.Errorf("exported metric %s has no panel", name)
+		}
+	}
+}
+
+func TestExporterMetrics_ListsExportedMetrics(t *testing.T) {
+	var buf bytes.Buffer
+	exporterMetricsCmd.SetOut(&buf)
+	defer exporterMetricsCmd.SetOut(nil)
+	if err := exporterMetricsCmd.RunE(exporterMetricsCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	for name := range exportedMetrics(t) {
+		if !strings.Contains(buf.String(), "`"+name+"`") {
+			t.Errorf("reference missing %s:\n%s", name, buf.String())
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kostyay/netmon/internal/config"
+	"github.com/kostyay/netmon/internal/history"
+)
+
+// defaultStore is what a bare --store means: history.db in the state directory.
+const defaultStore = "default"
+
+var (
+	storePath      string
+	storeRetention string
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{rootCmd, daemonCmd} {
+		cmd.Flags().StringVar(&storePath, "store", "", "Record flows to this SQLite database for `netmon query` (bare --store: history.db in the state directory)")
+		cmd.Flags().Lookup("store").NoOptDefVal = defaultStore
+		cmd.Flags().StringVar(&storeRetention, "retention", "7d", "With --store, delete flows last seen longer ago than this (e.g. 7d, 36h)")
+	}
+}
+
+// resolveStore returns the database path for a --store value.
+func resolveStore(path string) (string, error) {
+	if path != "" && path != defaultStore {
+		return path, nil
+	}
+	paths, err := config.ResolvePaths()
+	if err != nil {
+		return "", err
+	}
+	return paths.HistoryFile(), nil
+}
+
+// startRecorder opens the --store database and records into it until ctx is
+// done. It returns a nil recorder when --store is not set; release closes the
+// database.
+func startRecorder(ctx context.Context) (rec *history.Recorder, release func(), err error) {
+	if storePath == "" {
+		return nil, func() {}, nil
+	}
+	retention, err := history.ParseRetention(storeRetention)
+	if err != nil {
+		return nil, nil, fmt.Errorf("--retention: %w", err)
+	}
+	path, err := resolveStore(storePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	store, err := history.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("--store: %w", err)
+	}
+	rec = history.NewRecorder(store, history.DefaultInterval, retention)
+	go rec.Run(ctx)
+	return rec, func() { _ = store.Close() }, nil
+}
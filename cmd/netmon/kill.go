@@ -10,7 +10,9 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/kostyay/netmon/internal/audit"
 	"github.com/kostyay/netmon/internal/collector"
+	"github.com/kostyay/netmon/internal/config"
 	"github.com/kostyay/netmon/internal/model"
 	"github.com/kostyay/netmon/internal/process"
 )
@@ -45,6 +47,7 @@ func init() {
 type processInfo struct {
 	pid  int32
 	name string
+	exe  string
 	port int
 }
 
@@ -86,6 +89,7 @@ func runKill(cmd *cobra.Command, args []string) error {
 			targets = append(targets, processInfo{
 				pid:  conn.PID,
 				name: app.Name,
+				exe:  app.Exe,
 				port: port,
 			})
 		}
@@ -104,9 +108,9 @@ func runKill(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Signal: %s\n", killSignal)
 
 	// Confirm unless --yes
+	reader := bufio.NewReader(os.Stdin)
 	if !killYes {
 		fmt.Print("\nProceed? [y/N] ")
-		reader := bufio.NewReader(os.Stdin)
 		answer, _ := reader.ReadString('\n')
 		answer = strings.TrimSpace(strings.ToLower(answer))
 		if answer != "y" && answer != "yes" {
@@ -115,16 +119,34 @@ func runKill(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Kill processes
+	// Kill processes. System services need their name typed, even with --yes.
+	signaller := process.Signaller{Critical: config.CurrentSettings.CriticalProcesses, Kill: syscall.Kill}
+	confirmed := make(map[string]string)
 	var killed, failed int
 	for _, t := range targets {
-		if err := syscall.Kill(int(t.pid), sig); err != nil {
+		target := process.Target{PID: t.pid, Name: t.name, Exe: t.exe}
+		name := target.CriticalName()
+		if _, asked := confirmed[name]; !asked && process.IsCritical(signaller.Critical, target) {
+			fmt.Printf("%s is a system service; type %q to kill it: ", t.name, name)
+			answer, _ := reader.ReadString('\n')
+			confirmed[name] = strings.TrimSpace(answer)
+		}
+		e := audit.Entry{
+			Action: "kill",
+			Target: fmt.Sprintf("%s on port %d", t.name, t.port),
+			PIDs:   []int32{t.pid},
+			Signal: strings.ToUpper(killSignal),
+			Result: audit.ResultOK,
+		}
+		if err := signaller.Signal(target, sig, confirmed[name]); err != nil {
 			fmt.Printf("Failed to kill PID %d (%s): %v\n", t.pid, t.name, err)
 			failed++
+			e.Result, e.Error = audit.ResultFailed, err.Error()
 		} else {
 			fmt.Printf("Killed PID %d (%s)\n", t.pid, t.name)
 			killed++
 		}
+		recordCLIAudit(os.Stderr, e)
 	}
 
 	fmt.Printf("\nKilled: %d, Failed: %d\n", killed, failed)
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kostyay/netmon/internal/leak"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// growingCollector returns one more CLOSE_WAIT socket for "api" on every call.
+type growingCollector struct{ calls int }
+
+func (c *growingCollector) Collect(ctx context.Context) (*model.NetworkSnapshot, error) {
+	c.calls++
+	app := model.Application{Name: "api", PIDs: []int32{42}}
+	for range c.calls {
+		app.Connections = append(app.Connections, model.Connection{Protocol: model.ProtocolTCP,
+			LocalAddr: "10.0.0.2:41000", RemoteAddr: "10.0.0.9:5432", State: model.StateCloseWait})
+	}
+	return &model.NetworkSnapshot{Applications: []model.Application{app}}, nil
+}
+
+func TestSampleLeaks(t *testing.T) {
+	c := &growingCollector{}
+	tracker, err := sampleLeaks(context.Background(), c, time.Millisecond, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.calls != 5 {
+		t.Errorf("collected %d times, want 5", c.calls)
+	}
+	suspects := tracker.Suspects()
+	if len(suspects) != 1 || suspects[0].First != 1 || suspects[0].Current != 5 {
+		t.Fatalf("suspects = %+v, want api 1 -> 5", suspects)
+	}
+
+	var buf bytes.Buffer
+	if err := writeLeaks(&buf, suspects); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{"PROCESS", "api", "42", "1 → 5", "10.0.0.9:5432 (5)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteLeaksJSON_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeLeaksJSON(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	var got []leak.Suspect
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil || got == nil {
+		t.Errorf("output = %s, want an empty array", buf.String())
+	}
+}
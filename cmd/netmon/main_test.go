@@ -1,12 +1,17 @@
 package main
 
 import (
+	"context"
 	"os"
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kostyay/netmon/internal/allowlist"
+	"github.com/kostyay/netmon/internal/matchlist"
 	"github.com/kostyay/netmon/internal/model"
 	"github.com/kostyay/netmon/internal/ui"
+	"github.com/spf13/cobra"
 )
 
 // TestNewModel_CanBeCreated verifies that the UI model can be created.
@@ -225,6 +230,7 @@ func TestFilterSnapshotByPort_StateCountsUpdated(t *testing.T) {
 func TestFilterSnapshotByPort_PreservesMetadata(t *testing.T) {
 	snapshot := &model.NetworkSnapshot{
 		SkippedCount: 5,
+		HiddenCount:  7,
 		Applications: []model.Application{
 			{
 				Name: "App1",
@@ -242,6 +248,9 @@ func TestFilterSnapshotByPort_PreservesMetadata(t *testing.T) {
 	if result.SkippedCount != 5 {
 		t.Errorf("SkippedCount should be preserved, got %d", result.SkippedCount)
 	}
+	if result.HiddenCount != 7 {
+		t.Errorf("HiddenCount should be preserved, got %d", result.HiddenCount)
+	}
 	if result.Applications[0].Exe != "/usr/bin/app1" {
 		t.Errorf("Exe should be preserved, got %s", result.Applications[0].Exe)
 	}
@@ -334,6 +343,87 @@ func TestFilterSnapshotByPort_ListenState(t *testing.T) {
 	}
 }
 
+// Tests for filterSnapshotByMatch
+
+func TestFilterSnapshotByMatch(t *testing.T) {
+	targets, err := matchlist.Parse(strings.NewReader("203.0.113.0/24\n4444\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := &model.NetworkSnapshot{
+		Applications: []model.Application{
+			{
+				Name: "curl",
+				PIDs: []int32{100, 101},
+				Connections: []model.Connection{
+					{PID: 100, LocalAddr: "10.0.0.1:50000", RemoteAddr: "203.0.113.9:443", State: model.StateEstablished},
+					{PID: 101, LocalAddr: "10.0.0.1:50001", RemoteAddr: "1.1.1.1:443", State: model.StateEstablished},
+				},
+			},
+			{
+				Name:        "nc",
+				PIDs:        []int32{200},
+				Connections: []model.Connection{{PID: 200, LocalAddr: "0.0.0.0:4444", RemoteAddr: "*", State: model.StateListen}},
+			},
+			{
+				Name:        "sshd",
+				PIDs:        []int32{300},
+				Connections: []model.Connection{{PID: 300, LocalAddr: "0.0.0.0:22", RemoteAddr: "*", State: model.StateListen}},
+			},
+		},
+	}
+
+	result := filterSnapshotByMatch(snapshot, targets)
+
+	if len(result.Applications) != 2 {
+		t.Fatalf("expected 2 apps, got %d", len(result.Applications))
+	}
+	curl := result.Applications[0]
+	if len(curl.Connections) != 1 || len(curl.PIDs) != 1 || curl.PIDs[0] != 100 || curl.EstablishedCount != 1 {
+		t.Errorf("curl = %+v, want only the connection to 203.0.113.9 and its PID", curl)
+	}
+	if nc := result.Applications[1]; nc.Name != "nc" || nc.ListenCount != 1 {
+		t.Errorf("second app = %+v, want the nc listener on 4444", nc)
+	}
+}
+
+func TestFilterSnapshotByAllowlist(t *testing.T) {
+	allowed, err := allowlist.Parse(strings.NewReader("10.0.0.0/8\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := &model.NetworkSnapshot{
+		Applications: []model.Application{
+			{
+				Name: "curl",
+				PIDs: []int32{100, 101},
+				Connections: []model.Connection{
+					{PID: 100, Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.1:50000", RemoteAddr: "10.2.3.4:443", State: model.StateEstablished},
+					{PID: 101, Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.1:50001", RemoteAddr: "1.1.1.1:443", State: model.StateEstablished},
+				},
+			},
+			{
+				Name: "sshd",
+				PIDs: []int32{300},
+				Connections: []model.Connection{
+					{PID: 300, Protocol: model.ProtocolTCP, LocalAddr: "0.0.0.0:22", RemoteAddr: "*", State: model.StateListen},
+					{PID: 300, Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.1:22", RemoteAddr: "198.51.100.7:61000", State: model.StateEstablished},
+				},
+			},
+		},
+	}
+
+	result := filterSnapshotByAllowlist(context.Background(), snapshot, allowed)
+
+	if len(result.Applications) != 1 {
+		t.Fatalf("expected only curl, got %+v", result.Applications)
+	}
+	curl := result.Applications[0]
+	if len(curl.Connections) != 1 || curl.Connections[0].RemoteAddr != "1.1.1.1:443" || len(curl.PIDs) != 1 || curl.PIDs[0] != 101 {
+		t.Errorf("curl = %+v, want only the connection to 1.1.1.1", curl)
+	}
+}
+
 // Tests for filterSnapshotByPID
 
 func TestFilterSnapshotByPID_BasicMatch(t *testing.T) {
@@ -528,3 +618,80 @@ func TestWithPID_SetsTargetPID(t *testing.T) {
 		t.Error("WithPID should return a valid model")
 	}
 }
+
+func TestInteractive(t *testing.T) {
+	tests := []struct {
+		name      string
+		stdinTTY  bool
+		stdoutTTY bool
+		term      string
+		want      bool
+	}{
+		{"terminal", true, true, "xterm-256color", true},
+		{"stdout piped", true, false, "xterm-256color", false},
+		{"stdin redirected (cron)", false, true, "xterm-256color", false},
+		{"dumb terminal", true, true, "dumb", false},
+	}
+	orig := isTerminal
+	t.Cleanup(func() { isTerminal = orig })
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isTerminal = func(fd int) bool {
+				if fd == int(os.Stdin.Fd()) {
+					return tt.stdinTTY
+				}
+				return tt.stdoutTTY
+			}
+			t.Setenv("TERM", tt.term)
+			if got := interactive(); got != tt.want {
+				t.Errorf("interactive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPageOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantNil bool
+		wantErr bool
+		want    string // fields joined
+		limit   int
+		offset  int
+	}{
+		{name: "no paging flags", args: nil, wantNil: true},
+		{name: "fields and window", args: []string{"--fields", "process,laddr,state", "--limit", "500", "--offset", "500"},
+			want: "process,laddr,state", limit: 500, offset: 500},
+		{name: "limit alone selects all fields", args: []string{"--limit", "10"}, limit: 10},
+		{name: "unknown field", args: []string{"--fields", "process,uid"}, wantErr: true},
+		{name: "negative offset", args: []string{"--offset", "-1"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().StringVar(&pageFields, "fields", "", "")
+			cmd.Flags().IntVar(&pageLimit, "limit", 0, "")
+			cmd.Flags().IntVar(&pageOffset, "offset", 0, "")
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags: %v", err)
+			}
+			page, err := pageOptions(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("pageOptions() err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if (page == nil) != tt.wantNil {
+				t.Fatalf("pageOptions() = %+v, wantNil %v", page, tt.wantNil)
+			}
+			if page == nil {
+				return
+			}
+			if got := strings.Join(page.Fields, ","); got != tt.want || page.Limit != tt.limit || page.Offset != tt.offset {
+				t.Errorf("pageOptions() = %+v, want fields %q limit %d offset %d", page, tt.want, tt.limit, tt.offset)
+			}
+		})
+	}
+}
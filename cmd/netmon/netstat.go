@@ -0,0 +1,188 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kostyay/netmon/internal/collector"
+	"github.com/kostyay/netmon/internal/docker"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+var (
+	netstatTCP       bool
+	netstatUDP       bool
+	netstatListening bool
+)
+
+var netstatCmd = &cobra.Command{
+	Use:   "netstat",
+	Short: "Print sockets in the netstat -tunap format",
+	Long: `Print TCP and UDP sockets in the same columns as netstat -tunap (Proto,
+Recv-Q, Send-Q, Local Address, Foreign Address, State, PID/Program name), so
+scripts that parse netstat keep working. Process names come from netmon's
+resolution, and sockets holding a port a Docker container publishes name the
+container after the program.
+
+Addresses are always numeric and the PID/Program column is always filled
+(run as root to see every process); -n, -a and -p are accepted for muscle
+memory. Recv-Q and Send-Q are the accept queue and backlog of listening TCP
+sockets on Linux, 0 otherwise:
+  netmon netstat
+  netmon netstat -tunap | grep :443
+  netmon netstat -lt`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		snapshot, err := collector.New().Collect(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to collect network data: %w", err)
+		}
+		// Docker is optional: Resolve returns no containers when it is unavailable
+		var containers []model.VirtualContainer
+		if res, err := docker.NewResolver().Resolve(ctx); err == nil {
+			containers = res.Containers
+		}
+		return writeNetstat(cmd.OutOrStdout(), netstatRows(snapshot, containers, netstatFilter{
+			tcp:       netstatTCP || !netstatUDP,
+			udp:       netstatUDP || !netstatTCP,
+			listening: netstatListening,
+		}), netstatListening)
+	},
+}
+
+func init() {
+	netstatCmd.Flags().BoolVarP(&netstatTCP, "tcp", "t", false, "TCP sockets (default both TCP and UDP)")
+	netstatCmd.Flags().BoolVarP(&netstatUDP, "udp", "u", false, "UDP sockets")
+	netstatCmd.Flags().BoolVarP(&netstatListening, "listening", "l", false, "Only listening TCP and unconnected UDP sockets")
+	for _, f := range []struct{ name, short string }{{"numeric", "n"}, {"all", "a"}, {"program", "p"}} {
+		netstatCmd.Flags().BoolP(f.name, f.short, false, "Accepted for netstat compatibility (always on)")
+	}
+	rootCmd.AddCommand(netstatCmd)
+}
+
+// netstatFilter selects the sockets to print.
+type netstatFilter struct {
+	tcp, udp  bool
+	listening bool // only bound sockets
+}
+
+// netstatRow is one socket in netstat's columns.
+type netstatRow struct {
+	proto          string // tcp, tcp6, udp, udp6
+	recvQ, sendQ   uint32
+	local, foreign string
+	state          string // "" for UDP
+	program        string // "PID/name", "-" when unknown
+}
+
+// netstatRows converts the snapshot's TCP and UDP sockets to netstat rows,
+// sorted by protocol, then local port and address. Other protocols (ICMP,
+// raw) are left out, as netstat -tu does.
+func netstatRows(snapshot *model.NetworkSnapshot, containers []model.VirtualContainer, f netstatFilter) []netstatRow {
+	published := make(map[string]string) // "tcp/8080" -> container name
+	for _, vc := range containers {
+		for _, pm := range vc.PortMappings {
+			published[strings.ToLower(pm.Protocol)+"/"+strconv.Itoa(pm.HostPort)] = vc.Info.Name
+		}
+	}
+
+	var rows []netstatRow
+	for _, app := range snapshot.Applications {
+		for _, conn := range app.Connections {
+			proto := strings.ToLower(string(conn.Protocol))
+			switch {
+			case conn.Protocol == model.ProtocolTCP && !f.tcp,
+				conn.Protocol == model.ProtocolUDP && !f.udp,
+				conn.Protocol != model.ProtocolTCP && conn.Protocol != model.ProtocolUDP,
+				f.listening && !conn.IsBound():
+				continue
+			}
+
+			row := netstatRow{
+				proto:   proto,
+				local:   conn.LocalAddr,
+				foreign: conn.RemoteAddr,
+				program: netstatProgram(conn.PID, app.Name),
+			}
+			ipv6 := strings.Contains(netstatHost(conn.LocalAddr), ":")
+			if ipv6 {
+				row.proto += "6"
+			}
+			if row.foreign == "*" || row.foreign == "" {
+				row.foreign = "0.0.0.0:*"
+				if ipv6 {
+					row.foreign = ":::*"
+				}
+			}
+			if conn.State != model.StateNone {
+				row.state = string(conn.State)
+			}
+			if q := conn.Accept; q != nil {
+				row.recvQ, row.sendQ = q.Queued, q.Backlog
+			}
+			if conn.Container != nil {
+				row.program += " (" + conn.Container.Name + ")"
+			} else if name, ok := published[proto+"/"+strconv.Itoa(model.ExtractPort(conn.LocalAddr))]; ok && conn.IsBound() {
+				row.program += " (" + name + ")"
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	slices.SortStableFunc(rows, func(a, b netstatRow) int {
+		return cmp.Or(
+			cmp.Compare(a.proto, b.proto),
+			cmp.Compare(model.ExtractPort(a.local), model.ExtractPort(b.local)),
+			cmp.Compare(a.local, b.local),
+			cmp.Compare(a.foreign, b.foreign),
+		)
+	})
+	return rows
+}
+
+// netstatHost returns the host part of an "ip:port" address.
+func netstatHost(addr string) string {
+	if i := strings.LastIndex(addr, ":"); i >= 0 {
+		return addr[:i]
+	}
+	return addr
+}
+
+// netstatProgram formats the PID/Program name column.
+func netstatProgram(pid int32, name string) string {
+	if pid <= 0 {
+		return "-"
+	}
+	return strconv.Itoa(int(pid)) + "/" + name
+}
+
+// writeNetstat prints the rows with netstat's title, header and column widths.
+func writeNetstat(w io.Writer, rows []netstatRow, listening bool) error {
+	title := "Active Internet connections (servers and established)"
+	if listening {
+		title = "Active Internet connections (only servers)"
+	}
+	if _, err := fmt.Fprintln(w, title); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "%-5s %6s %6s %-23s %-23s %-11s %s\n",
+		"Proto", "Recv-Q", "Send-Q", "Local Address", "Foreign Address", "State", "PID/Program name")
+	for _, r := range rows {
+		if _, err := fmt.Fprintf(w, "%-5s %6d %6d %-23s %-23s %-11s %s\n",
+			r.proto, r.recvQ, r.sendQ, r.local, r.foreign, r.state, r.program); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+func netstatSnapshot() *model.NetworkSnapshot {
+	return &model.NetworkSnapshot{Applications: []model.Application{
+		{Name: "nginx", Connections: []model.Connection{
+			{PID: 812, Protocol: model.ProtocolTCP, LocalAddr: "0.0.0.0:80", RemoteAddr: "*", State: model.StateListen,
+				Accept: &model.AcceptQueue{Queued: 2, Backlog: 511}},
+			{PID: 812, Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.5:80", RemoteAddr: "10.0.0.9:51234", State: model.StateEstablished},
+			{PID: 812, Protocol: model.ProtocolTCP, LocalAddr: ":::80", RemoteAddr: "*", State: model.StateListen},
+		}},
+		{Name: "docker-proxy", Connections: []model.Connection{
+			{PID: 2001, Protocol: model.ProtocolTCP, LocalAddr: "0.0.0.0:5432", RemoteAddr: "*", State: model.StateListen},
+		}},
+		{Name: "avahi-daemon", Connections: []model.Connection{
+			{PID: 640, Protocol: model.ProtocolUDP, LocalAddr: "0.0.0.0:5353", RemoteAddr: "*", State: model.StateNone},
+			{PID: 640, Protocol: model.ProtocolICMP, LocalAddr: "0.0.0.0:0", RemoteAddr: "*", State: model.StateNone},
+		}},
+	}}
+}
+
+func TestNetstat_Format(t *testing.T) {
+	rows := netstatRows(netstatSnapshot(), checkPortContainers(), netstatFilter{tcp: true, udp: true})
+	var buf bytes.Buffer
+	if err := writeNetstat(&buf, rows, false); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `Active Internet connections (servers and established)
+Proto Recv-Q Send-Q Local Address           Foreign Address         State       PID/Program name
+tcp        2    511 0.0.0.0:80              0.0.0.0:*               LISTEN      812/nginx
+tcp        0      0 10.0.0.5:80             10.0.0.9:51234          ESTABLISHED 812/nginx
+tcp        0      0 0.0.0.0:5432            0.0.0.0:*               LISTEN      2001/docker-proxy (db)
+tcp6       0      0 :::80                   :::*                    LISTEN      812/nginx
+udp        0      0 0.0.0.0:5353            0.0.0.0:*                           640/avahi-daemon
+`
+	if got := buf.String(); got != want {
+		t.Errorf("output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestNetstat_Filters(t *testing.T) {
+	rows := netstatRows(netstatSnapshot(), nil, netstatFilter{udp: true})
+	if len(rows) != 1 || rows[0].proto != "udp" {
+		t.Errorf("-u rows = %+v", rows)
+	}
+
+	rows = netstatRows(netstatSnapshot(), nil, netstatFilter{tcp: true, listening: true})
+	for _, r := range rows {
+		if r.state != "LISTEN" {
+			t.Errorf("-lt listed %+v", r)
+		}
+	}
+	if len(rows) != 3 {
+		t.Errorf("-lt rows = %d, want 3 listeners", len(rows))
+	}
+
+	var buf bytes.Buffer
+	if err := writeNetstat(&buf, rows, true); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), "Active Internet connections (only servers)\n") {
+		t.Errorf("listening title:\n%s", buf.String())
+	}
+}
+
+func TestNetstatProgram_UnknownPID(t *testing.T) {
+	if got := netstatProgram(0, "kernel"); got != "-" {
+		t.Errorf("netstatProgram(0) = %q, want -", got)
+	}
+}
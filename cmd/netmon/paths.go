@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kostyay/netmon/internal/config"
+)
+
+var pathsCmd = &cobra.Command{
+	Use:   "paths",
+	Short: "Print where netmon stores its config, cache and state files",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		paths, err := config.ResolvePaths()
+		if err != nil {
+			return err
+		}
+		return writePaths(cmd.OutOrStdout(), paths)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pathsCmd)
+}
+
+// writePaths prints each netmon location and whether it exists yet.
+func writePaths(w io.Writer, p config.Paths) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	rows := []struct{ name, path string }{
+		{"config", p.ConfigDir},
+		{"settings", p.SettingsFile()},
+		{"theme", p.ThemeFile()},
+		{"cache", p.CacheDir},
+		{"dns cache", p.DNSCacheFile()},
+		{"state", p.StateDir},
+		{"recordings", p.RecordingsDir()},
+		{"audit log", p.AuditLogFile()},
+		{"history", p.HistoryFile()},
+	}
+	for _, r := range rows {
+		status := ""
+		if _, err := os.Stat(r.path); err != nil {
+			status = "(not created)"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", r.name, r.path, status)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kostyay/netmon/internal/config"
+)
+
+func TestWritePaths(t *testing.T) {
+	dir := t.TempDir()
+	p := config.Paths{
+		ConfigDir: filepath.Join(dir, "config"),
+		CacheDir:  filepath.Join(dir, "cache"),
+		StateDir:  filepath.Join(dir, "state"),
+	}
+	if err := os.MkdirAll(p.ConfigDir, 0750); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := writePaths(&buf, p); err != nil {
+		t.Fatalf("writePaths: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{p.SettingsFile(), p.ThemeFile(), p.DNSCacheFile(), p.RecordingsDir(), p.AuditLogFile(), p.HistoryFile()} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %s:\n%s", want, out)
+		}
+	}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		exists := strings.HasPrefix(line, "config ")
+		if exists == strings.Contains(line, "(not created)") {
+			t.Errorf("unexpected existence marker: %q", line)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kostyay/netmon/internal/history"
+)
+
+var (
+	queryStore   string
+	queryRemote  string
+	queryProcess string
+	queryWindow  string
+)
+
+var queryCmd = &cobra.Command{
+	Use:   "query",
+	Short: "Show which processes talked to a peer, from the --store flow history",
+	Long: `Show the flows recorded by 'netmon --store' or 'netmon daemon --store': which
+process and PID talked to which peer, first and last seen. Flows are sampled
+once a minute, so connections shorter than that may be missing.
+
+Examples:
+  netmon query --remote 10.1.2.3 --window "yesterday 14:00-15:00"
+  netmon query --process curl --window 09:00-17:00
+  netmon query --remote 10.1.2.3 --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		q := history.Query{Remote: queryRemote, Process: queryProcess}
+		if queryWindow != "" {
+			var err error
+			if q.From, q.To, err = history.ParseWindow(queryWindow, time.Now()); err != nil {
+				return fmt.Errorf("--window: %w", err)
+			}
+		}
+		path, err := resolveStore(queryStore)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("no flow history at %s (record one with --store): %w", path, err)
+		}
+		store, err := history.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = store.Close() }()
+		flows, err := store.Query(context.Background(), q)
+		if err != nil {
+			return err
+		}
+		if jsonOutput {
+			return writeQueryJSON(cmd.OutOrStdout(), flows)
+		}
+		return writeQuery(cmd.OutOrStdout(), path, flows)
+	},
+}
+
+func init() {
+	queryCmd.Flags().StringVar(&queryStore, "store", "", "Flow history database (default history.db in the state directory)")
+	queryCmd.Flags().StringVar(&queryRemote, "remote", "", "Only flows to this peer IP")
+	queryCmd.Flags().StringVar(&queryProcess, "process", "", "Only flows of this process name")
+	queryCmd.Flags().StringVar(&queryWindow, "window", "", `Only flows open during this window, e.g. "yesterday 14:00-15:00" (default: all retained)`)
+	rootCmd.AddCommand(queryCmd)
+}
+
+// writeQuery prints flows as a table, in the order they were first seen.
+func writeQuery(w io.Writer, path string, flows []history.Flow) error {
+	if len(flows) == 0 {
+		_, err := fmt.Fprintf(w, "No flows recorded in %s match\n", path)
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PROCESS\tPID\tPROTO\tLOCAL\tREMOTE\tFIRST SEEN\tLAST SEEN")
+	for _, f := range flows {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\t%s\n",
+			f.Process, f.PID, f.Protocol, f.LocalAddr, f.RemoteAddr,
+			f.FirstSeen.Local().Format("2006-01-02 15:04:05"), f.LastSeen.Local().Format("2006-01-02 15:04:05"))
+	}
+	return tw.Flush()
+}
+
+// writeQueryJSON prints flows as a JSON array.
+func writeQueryJSON(w io.Writer, flows []history.Flow) error {
+	if flows == nil {
+		flows = []history.Flow{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(flows)
+}
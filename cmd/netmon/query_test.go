@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kostyay/netmon/internal/history"
+)
+
+func TestWriteQuery(t *testing.T) {
+	at := time.Date(2026, 10, 14, 14, 5, 0, 0, time.Local)
+	flows := []history.Flow{
+		{Process: "curl", PID: 10, Protocol: "TCP", LocalAddr: "192.168.1.5:50000", RemoteAddr: "10.1.2.3:443", FirstSeen: at, LastSeen: at.Add(3 * time.Minute)},
+	}
+	var buf bytes.Buffer
+	if err := writeQuery(&buf, "/state/history.db", flows); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"PROCESS", "curl", "10.1.2.3:443", "2026-10-14 14:05:00", "2026-10-14 14:08:00"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	_ = writeQuery(&buf, "/state/history.db", nil)
+	if !strings.Contains(buf.String(), "No flows recorded in /state/history.db") {
+		t.Errorf("empty output = %q", buf.String())
+	}
+
+	buf.Reset()
+	if err := writeQueryJSON(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	var got []history.Flow
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil || got == nil {
+		t.Errorf("empty JSON = %q, want []", buf.String())
+	}
+}
+
+func TestQueryCmd_ReadsStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.db")
+	store, err := history.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	flows := []history.Flow{
+		{Process: "curl", PID: 10, Protocol: "TCP", LocalAddr: "192.168.1.5:50000", RemoteAddr: "10.1.2.3:443", RemoteHost: "10.1.2.3", FirstSeen: now, LastSeen: now},
+		{Process: "ssh", PID: 20, Protocol: "TCP", LocalAddr: "192.168.1.5:50001", RemoteAddr: "10.9.9.9:22", RemoteHost: "10.9.9.9", FirstSeen: now, LastSeen: now},
+	}
+	if err := store.Record(flows, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	_ = store.Close()
+
+	queryStore, queryRemote = path, "10.1.2.3"
+	t.Cleanup(func() { queryStore, queryRemote = "", "" })
+	var buf bytes.Buffer
+	queryCmd.SetOut(&buf)
+	t.Cleanup(func() { queryCmd.SetOut(nil) })
+	if err := queryCmd.RunE(queryCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	if out := buf.String(); !strings.Contains(out, "curl") || strings.Contains(out, "ssh") {
+		t.Errorf("query --remote 10.1.2.3 =\n%s\nwant curl's flow only", out)
+	}
+
+	queryStore = filepath.Join(t.TempDir(), "missing.db")
+	if err := queryCmd.RunE(queryCmd, nil); err == nil || !strings.Contains(err.Error(), "--store") {
+		t.Errorf("missing store err = %v, want a hint to record with --store", err)
+	}
+}
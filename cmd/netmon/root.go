@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
@@ -12,24 +13,66 @@ import (
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 
+	"github.com/kostyay/netmon/internal/allowlist"
+	"github.com/kostyay/netmon/internal/capture"
 	"github.com/kostyay/netmon/internal/collector"
 	"github.com/kostyay/netmon/internal/config"
+	"github.com/kostyay/netmon/internal/debugserver"
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/matchlist"
 	"github.com/kostyay/netmon/internal/model"
 	"github.com/kostyay/netmon/internal/output"
+	"github.com/kostyay/netmon/internal/privilege"
+	"github.com/kostyay/netmon/internal/services"
 	"github.com/kostyay/netmon/internal/ui"
+	"github.com/kostyay/netmon/internal/web"
 )
 
 // Version is set via ldflags at build time
 var Version = "dev"
 
 var (
-	jsonOutput bool
-	pidFilter  int
+	jsonOutput      bool
+	pidFilter       int
+	checkPrivileges bool
+	debugListen     string
+	webListen       string
+	plainRender     bool
+	matchFile       string
+	allowFile       string
+	scriptFile      string
+	watchPorts      []int
+	proxyAPI        string
+	captureHosts    bool
+	viewSpec        ui.ViewSpec
+	pageFields      string
+	pageLimit       int
+	pageOffset      int
+	outputFormat    string
 )
 
 func init() {
 	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output in JSON format (for scripting/agent consumption)")
 	rootCmd.PersistentFlags().IntVar(&pidFilter, "pid", 0, "Filter to specific process ID and drill into its connections")
+	rootCmd.Flags().BoolVar(&checkPrivileges, "check", false, "Print what is hidden or unavailable at the current privilege level and exit")
+	rootCmd.Flags().StringVar(&matchFile, "match-file", "", "Only show connections touching the IPs, CIDRs or ports listed in this file (- for stdin)")
+	rootCmd.Flags().StringVar(&allowFile, "allow-file", "", "Highlight and count outbound connections to destinations outside the IPs, CIDRs and domains in this file; output modes print only those")
+	rootCmd.Flags().StringVar(&viewSpec.View, "view", "", "Open on this view ("+strings.Join(ui.ViewNames(), ", ")+")")
+	rootCmd.Flags().StringVar(&viewSpec.Process, "process", "", "Open the connections of this process (by name)")
+	rootCmd.Flags().StringArrayVar(&viewSpec.Filters, "filter", nil, "Open with this filter chip, as typed after / (repeatable)")
+	rootCmd.Flags().StringVar(&viewSpec.Sort, "sort", "", "Sort the opened view by COLUMN or COLUMN:asc|desc, e.g. remote:desc")
+	rootCmd.Flags().IntSliceVar(&watchPorts, "watch-port", nil, "Ring the bell and notify when these ports start or stop listening")
+	rootCmd.Flags().StringVar(&proxyAPI, "proxy-api", "", "Show the original destinations of connections through mitmproxy, read from this mitmweb URL (e.g. http://127.0.0.1:8081)")
+	rootCmd.Flags().BoolVar(&captureHosts, "capture", false, "Label new connections with the hostname their client asked for (TLS SNI, HTTP Host) from captured packets (Linux, needs root or CAP_NET_RAW)")
+	rootCmd.Flags().StringVar(&scriptFile, "script", "", "Run the actions in this file (filter, sort, export, key, command ids) once the first snapshot arrives")
+	rootCmd.Flags().StringVar(&pageFields, "fields", "", "JSON: flat connection list with only these fields ("+strings.Join(output.FieldNames(), ",")+")")
+	rootCmd.Flags().IntVar(&pageLimit, "limit", 0, "JSON: flat connection list, at most this many rows")
+	rootCmd.Flags().IntVar(&pageOffset, "offset", 0, "JSON: flat connection list, skip this many rows")
+	rootCmd.Flags().StringVar(&outputFormat, "format", "json", "Print once in this format instead of running the TUI ("+strings.Join(output.Formats(), ", ")+")")
+	rootCmd.Flags().BoolVar(&plainRender, "plain", false, "Minimal rendering: ASCII frames, no colors or animations (serial consoles, copy-paste)")
+	rootCmd.Flags().StringVar(&webListen, "web", "", "Serve a read-only live view in the browser on this address (e.g. :8787)")
+	rootCmd.Flags().StringVar(&debugListen, "debug-listen", "", "Serve pprof and a status page on this address (e.g. localhost:6060)")
+	_ = rootCmd.Flags().MarkHidden("debug-listen")
 }
 
 var rootCmd = &cobra.Command{
@@ -39,7 +82,14 @@ var rootCmd = &cobra.Command{
 
 Optionally pass a port number to filter connections:
   netmon 8080        # TUI filtered to port 8080
-  netmon 8080 --json # JSON output filtered to port 8080`,
+  netmon 8080 --json # JSON output filtered to port 8080
+
+Paginate the JSON output as a flat connection list:
+  netmon --fields process,laddr,state --limit 500 --offset 500
+
+Print in another format (--fields, --limit and --offset apply to csv, jsonl and table):
+  netmon --format csv --fields process,laddr,raddr,state
+  netmon --format prometheus > /var/lib/node_exporter/netmon.prom`,
 	Args: cobra.MaximumNArgs(1),
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		// Load user settings and theme from config files
@@ -49,9 +99,21 @@ Optionally pass a port number to filter connections:
 		if err := config.InitTheme(); err != nil {
 			return fmt.Errorf("failed to load theme: %w", err)
 		}
+		i18n.SetLocale(i18n.Detect(config.CurrentSettings.Locale))
+		if err := services.SetCustom(config.CurrentSettings.PortLabels); err != nil {
+			return fmt.Errorf("settings portLabels: %w", err)
+		}
 		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
+		if checkPrivileges {
+			if err := privilege.Check().Write(os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+
 		var portFilter string
 		if len(args) > 0 {
 			// Validate it's a number
@@ -67,6 +129,11 @@ Optionally pass a port number to filter connections:
 			fmt.Fprintf(os.Stderr, "Error: cannot specify both --pid and port filter\n")
 			os.Exit(1)
 		}
+		deepLink := viewSpec.View != "" || viewSpec.Process != "" || len(viewSpec.Filters) > 0 || viewSpec.Sort != ""
+		if pidFilter != 0 && deepLink {
+			fmt.Fprintf(os.Stderr, "Error: cannot combine --pid with --view, --process, --filter or --sort\n")
+			os.Exit(1)
+		}
 
 		// Validate PID exists if specified
 		if pidFilter != 0 {
@@ -76,20 +143,149 @@ Optionally pass a port number to filter connections:
 			}
 		}
 
-		// JSON mode: explicit flag or non-TTY stdout
-		if jsonOutput || !term.IsTerminal(int(os.Stdout.Fd())) {
-			runJSONMode(portFilter, int32(pidFilter))
+		var targets *matchlist.List
+		if matchFile != "" {
+			var err error
+			if targets, err = matchlist.Load(matchFile); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: match file: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		var allowed *allowlist.List
+		if allowFile != "" {
+			var err error
+			if allowed, err = loadAllowList(allowFile); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: allow file: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		var script *ui.Script
+		if scriptFile != "" {
+			var err error
+			if script, err = ui.LoadScript(scriptFile); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: script: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		for _, p := range watchPorts {
+			if p < 1 || p > 65535 {
+				fmt.Fprintf(os.Stderr, "Error: --watch-port %d is not a valid port\n", p)
+				os.Exit(1)
+			}
+		}
+
+		if proxyAPI != "" {
+			if u, err := url.Parse(proxyAPI); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				fmt.Fprintf(os.Stderr, "Error: --proxy-api %q is not an http(s) URL\n", proxyAPI)
+				os.Exit(1)
+			}
+		}
+
+		page, err := pageOptions(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		renderer, err := output.Lookup(outputFormat)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --format: %v\n", err)
+			os.Exit(1)
+		}
+		if jsonOutput && !strings.EqualFold(outputFormat, "json") {
+			fmt.Fprintf(os.Stderr, "Error: --json conflicts with --format %s\n", outputFormat)
+			os.Exit(1)
+		}
+
+		// Output mode: explicit flag, paging flags, or nowhere to run the TUI (pipe, cron, dumb terminal)
+		if jsonOutput || page != nil || cmd.Flags().Changed("format") || !interactive() {
+			runOutputMode(renderer, portFilter, int32(pidFilter), targets, allowed, page)
 			return
 		}
 
 		// Default behavior: launch TUI
-		m := ui.NewModel().WithVersion(Version)
+		ui.SetPlain(plainRender)
+		m := ui.NewModel().WithVersion(Version).WithPrivilege(privilege.Check())
+		if attachSocket != "" {
+			c, netIO, err := attachCollectors(attachSocket)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			m = m.WithCollectors(c, netIO)
+		}
 		if portFilter != "" {
 			m = m.WithFilter(portFilter)
 		}
 		if pidFilter != 0 {
 			m = m.WithPID(int32(pidFilter))
 		}
+		if deepLink {
+			if m, err = m.WithView(viewSpec); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		if targets != nil {
+			m = m.WithMatchList(targets, matchFile)
+		}
+		if allowed != nil {
+			m = m.WithAllowList(allowed, allowFile)
+		}
+		if script != nil {
+			m = m.WithScript(script)
+		}
+		if len(watchPorts) > 0 {
+			m = m.WithWatchedPorts(watchPorts)
+		}
+		if proxyAPI != "" {
+			m = m.WithProxyAPI(proxyAPI)
+		}
+		if captureHosts {
+			sniffer, err := capture.Open()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: --capture: %v (needs root or CAP_NET_RAW)\n", err)
+				os.Exit(1)
+			}
+			defer func() { _ = sniffer.Close() }()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			table := capture.NewTable()
+			go func() { _ = sniffer.Run(ctx, table) }() // a failure shows in diagnostics via table.Err
+			m = m.WithCapture(table)
+		}
+		if debugListen != "" {
+			status := debugserver.NewStatus()
+			srv, err := debugserver.Start(debugListen, status)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: debug server: %v\n", err)
+				os.Exit(1)
+			}
+			defer srv.Close()
+			m = m.WithDebugStatus(status)
+		}
+		if webListen != "" {
+			hub := web.NewHub()
+			srv, err := web.Start(webListen, hub)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: web server: %v\n", err)
+				os.Exit(1)
+			}
+			defer srv.Close()
+			m = m.WithWebHub(hub)
+		}
+		recCtx, stopRecorder := context.WithCancel(context.Background())
+		defer stopRecorder()
+		rec, releaseStore, err := startRecorder(recCtx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer releaseStore()
+		m = m.WithHistory(rec)
 		p := tea.NewProgram(m, tea.WithAltScreen())
 		if _, err := p.Run(); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -98,9 +294,51 @@ Optionally pass a port number to filter connections:
 	},
 }
 
-func runJSONMode(portFilter string, pidFilter int32) {
+// isTerminal reports whether fd is a terminal (replaced in tests).
+var isTerminal = term.IsTerminal
+
+// interactive reports whether the TUI can run: it reads keys from stdin and
+// draws on stdout, so both must be terminals, and the terminal must handle
+// cursor movement (not TERM=dumb, as in Emacs shell buffers).
+func interactive() bool {
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return isTerminal(int(os.Stdin.Fd())) && isTerminal(int(os.Stdout.Fd()))
+}
+
+// pageOptions returns the flat connection list options when any of --fields,
+// --limit or --offset is set, or nil for the default nested JSON.
+func pageOptions(cmd *cobra.Command) (*output.PageOptions, error) {
+	flags := cmd.Flags()
+	if !flags.Changed("fields") && !flags.Changed("limit") && !flags.Changed("offset") {
+		return nil, nil
+	}
+	if pageLimit < 0 || pageOffset < 0 {
+		return nil, fmt.Errorf("--limit and --offset must not be negative")
+	}
+	fields, err := output.ParseFields(pageFields)
+	if err != nil {
+		return nil, fmt.Errorf("--fields: %w", err)
+	}
+	return &output.PageOptions{Fields: fields, Limit: pageLimit, Offset: pageOffset}, nil
+}
+
+// runOutputMode collects once and prints the snapshot with renderer. For
+// JSON that is the nested per-application snapshot, or one page of the flat
+// connection list when page is set.
+func runOutputMode(renderer output.Renderer, portFilter string, pidFilter int32, targets *matchlist.List, allowed *allowlist.List, page *output.PageOptions) {
 	ctx := context.Background()
-	snapshot, ioStats, err := collector.CollectOnce(ctx)
+	var (
+		snapshot *model.NetworkSnapshot
+		ioStats  map[int32]*model.NetIOStats
+		err      error
+	)
+	if attachSocket != "" {
+		snapshot, ioStats, err = collectAttached(ctx, attachSocket)
+	} else {
+		snapshot, ioStats, err = collector.CollectOnce(ctx)
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error collecting data: %v\n", err)
 		os.Exit(1)
@@ -116,26 +354,52 @@ func runJSONMode(portFilter string, pidFilter int32) {
 		snapshot = filterSnapshotByPID(snapshot, pidFilter)
 	}
 
-	if err := output.RenderJSON(os.Stdout, snapshot, ioStats); err != nil {
-		fmt.Fprintf(os.Stderr, "Error rendering JSON: %v\n", err)
+	// Filter by target list if specified
+	if targets != nil {
+		snapshot = filterSnapshotByMatch(snapshot, targets)
+	}
+
+	// Keep only allowlist violations if specified
+	if allowed != nil {
+		snapshot = filterSnapshotByAllowlist(ctx, snapshot, allowed)
+	}
+
+	if err := renderer.Render(os.Stdout, output.Input{Snapshot: snapshot, IOStats: ioStats, Page: page}); err != nil {
+		fmt.Fprintf(os.Stderr, "Error rendering output: %v\n", err)
 		os.Exit(1)
 	}
 }
 
 func filterSnapshotByPort(snapshot *model.NetworkSnapshot, port string) *model.NetworkSnapshot {
+	// Check if port appears in local or remote address
+	return filterSnapshotConns(snapshot, func(conn model.Connection) bool {
+		return strings.HasSuffix(conn.LocalAddr, ":"+port) ||
+			strings.HasSuffix(conn.RemoteAddr, ":"+port)
+	})
+}
+
+// filterSnapshotByMatch keeps connections touching any target in the list.
+func filterSnapshotByMatch(snapshot *model.NetworkSnapshot, targets *matchlist.List) *model.NetworkSnapshot {
+	return filterSnapshotConns(snapshot, func(conn model.Connection) bool {
+		return targets.Match(conn.LocalAddr, conn.RemoteAddr)
+	})
+}
+
+// filterSnapshotConns keeps the connections for which keep returns true,
+// dropping applications left without any.
+func filterSnapshotConns(snapshot *model.NetworkSnapshot, keep func(model.Connection) bool) *model.NetworkSnapshot {
 	filtered := &model.NetworkSnapshot{
 		Timestamp:    snapshot.Timestamp,
 		Applications: make([]model.Application, 0),
 		SkippedCount: snapshot.SkippedCount,
+		HiddenCount:  snapshot.HiddenCount,
 	}
 
 	for _, app := range snapshot.Applications {
 		var matchingConns []model.Connection
 		matchingPIDs := make(map[int32]bool)
 		for _, conn := range app.Connections {
-			// Check if port appears in local or remote address
-			if strings.HasSuffix(conn.LocalAddr, ":"+port) ||
-				strings.HasSuffix(conn.RemoteAddr, ":"+port) {
+			if keep(conn) {
 				matchingConns = append(matchingConns, conn)
 				matchingPIDs[conn.PID] = true
 			}
@@ -175,6 +439,7 @@ func filterSnapshotByPID(snapshot *model.NetworkSnapshot, pid int32) *model.Netw
 		Timestamp:    snapshot.Timestamp,
 		Applications: make([]model.Application, 0),
 		SkippedCount: snapshot.SkippedCount,
+		HiddenCount:  snapshot.HiddenCount,
 	}
 
 	for _, app := range snapshot.Applications {
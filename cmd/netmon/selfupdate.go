@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kostyay/netmon/internal/release"
+)
+
+var selfUpdateYes bool
+
+var selfUpdateCmd = &cobra.Command{
+	Use:   "self-update",
+	Short: "Replace this binary with the latest release",
+	Long: `Download the latest netmon release for this platform, verify it against the
+release's checksums.txt (sha256) and replace the running binary.
+
+Use sudo if netmon is installed in a root-owned directory.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSelfUpdate(cmd.Context(), cmd.OutOrStdout(), os.Stdin, selfUpdateYes)
+	},
+}
+
+func init() {
+	selfUpdateCmd.Flags().BoolVarP(&selfUpdateYes, "yes", "y", false, "Skip confirmation prompt")
+	rootCmd.AddCommand(selfUpdateCmd)
+}
+
+func runSelfUpdate(ctx context.Context, out io.Writer, in io.Reader, yes bool) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	u, err := release.FindUpdate(ctx, "kostyay", "netmon", Version, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return err
+	}
+	if u == nil {
+		fmt.Fprintf(out, "netmon %s is up to date\n", Version)
+		return nil
+	}
+	exe, err := release.Executable()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Update netmon %s → %s\n", Version, u.Version)
+	fmt.Fprintf(out, "Binary:  %s\n", exe)
+	fmt.Fprintf(out, "Archive: %s\n", u.Archive.Name)
+	if !yes {
+		fmt.Fprint(out, "\nProceed? [y/N] ")
+		answer, _ := bufio.NewReader(in).ReadString('\n')
+		answer = strings.TrimSpace(strings.ToLower(answer))
+		if answer != "y" && answer != "yes" {
+			fmt.Fprintln(out, "Aborted")
+			return nil
+		}
+	}
+
+	if err := release.Apply(ctx, u, exe); err != nil {
+		if errors.Is(err, os.ErrPermission) {
+			return fmt.Errorf("%w (try: sudo netmon self-update)", err)
+		}
+		return err
+	}
+	fmt.Fprintf(out, "Updated to %s (checksum verified)\n", u.Version)
+	return nil
+}
@@ -0,0 +1,343 @@
+// Package agent runs netmon's collection engine behind a line-delimited JSON
+// protocol on stdio (`netmon agent`), so editors and web UIs can embed netmon
+// as a subprocess instead of scraping the TUI.
+//
+// Each line netmon writes is one event:
+//
+//	{"type":"hello","version":1,"interval":"1s"}
+//	{"type":"snapshot","snapshot":{...same shape as --json...}}
+//	{"type":"result","id":"7"}
+//	{"type":"error","id":"7","error":"no process with PID 42 in the snapshot"}
+//
+// Each line read is one command; id is optional and echoed in the reply:
+//
+//	{"id":"1","cmd":"filter","filter":"chrome"}   only report matching connections ("" clears)
+//	{"id":"2","cmd":"kill","pid":4242,"signal":"SIGTERM"}
+//	{"id":"3","cmd":"refresh"}                    collect and emit a snapshot now
+//	{"id":"4","cmd":"quit"}
+//
+// Filters use the TUI's search syntax (internal/filter): "~chr", "tree:4242",
+// "iface:utun3", and with Geo set "geo:CN" or "asn:16509".
+//
+// Killing a system service (sshd, systemd, ...) also needs its executable
+// name: {"cmd":"kill","pid":1,"confirm":"systemd"}. The agent exits when stdin
+// closes.
+package agent
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/kostyay/netmon/internal/audit"
+	"github.com/kostyay/netmon/internal/collector"
+	"github.com/kostyay/netmon/internal/filter"
+	"github.com/kostyay/netmon/internal/geoip"
+	"github.com/kostyay/netmon/internal/model"
+	"github.com/kostyay/netmon/internal/netif"
+	"github.com/kostyay/netmon/internal/output"
+	"github.com/kostyay/netmon/internal/process"
+)
+
+// ProtocolVersion is sent in the hello event; it changes on incompatible
+// protocol changes.
+const ProtocolVersion = 1
+
+// DefaultInterval is how often the agent emits a snapshot.
+const DefaultInterval = time.Second
+
+// Command is one request read from stdin.
+type Command struct {
+	ID     string `json:"id,omitempty"`
+	Cmd    string `json:"cmd"`
+	Filter string `json:"filter,omitempty"`
+	PID    int32  `json:"pid,omitempty"`
+	Signal string `json:"signal,omitempty"`
+	// Confirm must be the executable name of a critical process (sshd,
+	// systemd, ...) for a kill of it to go through.
+	Confirm string `json:"confirm,omitempty"`
+}
+
+// Event is one line written to stdout.
+type Event struct {
+	Type     string             `json:"type"` // hello, snapshot, result or error
+	ID       string             `json:"id,omitempty"`
+	Version  int                `json:"version,omitempty"`
+	Interval string             `json:"interval,omitempty"`
+	Snapshot *output.JSONOutput `json:"snapshot,omitempty"`
+	Error    string             `json:"error,omitempty"`
+}
+
+// Agent collects every interval and answers commands.
+type Agent struct {
+	interval time.Duration
+	c        collector.Collector
+	netio    collector.NetIOCollector
+
+	// Audit, when set, is called with every kill the agent performs.
+	Audit func(audit.Entry)
+	// Critical are the process name patterns whose kill must be confirmed;
+	// nil uses process.DefaultCritical.
+	Critical []string
+	// Geo, when set, maps remote IPs to the countries and networks geo:CC
+	// and asn:N filters match.
+	Geo *geoip.DB
+
+	kill     func(pid int, sig syscall.Signal) error // replaced in tests
+	parents  func() (map[int32]int32, error)         // process table for tree:PID, replaced in tests
+	ifaces   func() (*netif.Table, error)            // interface table for iface:NAME, replaced in tests
+	filter   string
+	snapshot *model.NetworkSnapshot // latest, unfiltered
+}
+
+// New returns an Agent reading from the given collectors. netio may be nil.
+func New(c collector.Collector, netio collector.NetIOCollector, interval time.Duration) *Agent {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Agent{interval: interval, c: c, netio: netio, kill: syscall.Kill, parents: process.Parents, ifaces: netif.Load}
+}
+
+// Run emits snapshots to w and executes commands read from r until ctx is
+// done, r is closed, or a quit command arrives.
+func (a *Agent) Run(ctx context.Context, r io.Reader, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(Event{Type: "hello", Version: ProtocolVersion, Interval: a.interval.String()}); err != nil {
+		return err
+	}
+
+	lines := make(chan string)
+	readErr := make(chan error, 1)
+	go func() {
+		sc := bufio.NewScanner(r)
+		sc.Buffer(make([]byte, 64*1024), 1024*1024)
+		for sc.Scan() {
+			select {
+			case lines <- sc.Text():
+			case <-ctx.Done():
+				return
+			}
+		}
+		readErr <- sc.Err()
+	}()
+
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+	if err := a.emitSnapshot(ctx, enc); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-readErr:
+			return err
+		case <-ticker.C:
+			if err := a.emitSnapshot(ctx, enc); err != nil {
+				return err
+			}
+		case line := <-lines:
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			quit, err := a.handle(ctx, enc, line)
+			if err != nil || quit {
+				return err
+			}
+		}
+	}
+}
+
+// handle runs one command line and writes its reply. It reports whether
+// the agent should stop.
+func (a *Agent) handle(ctx context.Context, enc *json.Encoder, line string) (bool, error) {
+	var cmd Command
+	if err := json.Unmarshal([]byte(line), &cmd); err != nil {
+		return false, enc.Encode(Event{Type: "error", Error: fmt.Sprintf("invalid command: %v", err)})
+	}
+	var err error
+	switch cmd.Cmd {
+	case "filter":
+		a.filter = cmd.Filter
+	case "kill":
+		err = a.killPID(cmd.PID, cmd.Signal, cmd.Confirm)
+	case "refresh", "quit":
+	default:
+		err = fmt.Errorf("unknown command %q", cmd.Cmd)
+	}
+	if err != nil {
+		return false, enc.Encode(Event{Type: "error", ID: cmd.ID, Error: err.Error()})
+	}
+	if err := enc.Encode(Event{Type: "result", ID: cmd.ID}); err != nil {
+		return false, err
+	}
+	switch {
+	case cmd.Cmd == "quit":
+		return true, nil
+	case cmd.Cmd == "refresh":
+		return false, a.emitSnapshot(ctx, enc)
+	case cmd.Cmd == "filter" && a.snapshot != nil:
+		return false, a.emitCached(ctx, enc) // show the new filter without waiting for the tick
+	}
+	return false, nil
+}
+
+// killPID signals pid, which must own a connection in the latest snapshot so
+// the agent cannot be used to signal arbitrary processes. A critical process
+// is only signalled when confirm is its executable name.
+func (a *Agent) killPID(pid int32, signal, confirm string) error {
+	if signal == "" {
+		signal = "SIGTERM"
+	}
+	sig, ok := process.SignalMap[strings.ToUpper(signal)]
+	if !ok {
+		return fmt.Errorf("unknown signal: %s", signal)
+	}
+	target, ok := a.processTarget(pid)
+	if !ok {
+		return fmt.Errorf("no process with PID %d in the snapshot", pid)
+	}
+	s := process.Signaller{Critical: a.Critical, Kill: a.kill}
+	err := s.Signal(target, sig, confirm)
+	var unconfirmed *process.UnconfirmedError
+	if errors.As(err, &unconfirmed) {
+		return err // refused before anything was sent
+	}
+	if a.Audit != nil {
+		e := audit.Entry{Action: "kill", Target: target.Name, PIDs: []int32{pid}, Signal: strings.ToUpper(signal), Result: audit.ResultOK}
+		if err != nil {
+			e.Result, e.Error = audit.ResultFailed, err.Error()
+		}
+		a.Audit(e)
+	}
+	if err != nil {
+		return fmt.Errorf("kill PID %d (%s): %w", pid, target.Name, err)
+	}
+	return nil
+}
+
+// processTarget returns the process owning pid in the latest snapshot.
+func (a *Agent) processTarget(pid int32) (process.Target, bool) {
+	if a.snapshot == nil {
+		return process.Target{}, false
+	}
+	for _, app := range a.snapshot.Applications {
+		if slices.Contains(app.PIDs, pid) {
+			return process.Target{PID: pid, Name: app.Name, Exe: app.Exe}, true
+		}
+	}
+	return process.Target{}, false
+}
+
+// emitSnapshot collects and writes a snapshot event. Collection errors are
+// reported as error events; only write errors stop the agent.
+func (a *Agent) emitSnapshot(ctx context.Context, enc *json.Encoder) error {
+	snap, err := a.c.Collect(ctx)
+	if err != nil {
+		return enc.Encode(Event{Type: "error", Error: fmt.Sprintf("collect: %v", err)})
+	}
+	a.snapshot = snap
+	return a.emitCached(ctx, enc)
+}
+
+// emitCached writes the latest snapshot, filtered, as a snapshot event.
+func (a *Agent) emitCached(ctx context.Context, enc *json.Encoder) error {
+	var ioStats map[int32]*model.NetIOStats
+	if a.netio != nil {
+		ioStats, _ = a.netio.Collect(ctx) // optional, as in the TUI
+	}
+	out := output.BuildJSON(a.filterSnapshot(a.snapshot, a.filter), ioStats)
+	return enc.Encode(Event{Type: "snapshot", Snapshot: &out})
+}
+
+// filterSnapshot keeps the connections matching filter, with the TUI's search
+// syntax: a substring of the process name, PID, address, protocol or state,
+// or ~fuzzy, tree:PID, iface:NAME, geo:CC, asn:N and domain:NAME. A process
+// whose name or PIDs match keeps all its connections.
+func (a *Agent) filterSnapshot(snap *model.NetworkSnapshot, f string) *model.NetworkSnapshot {
+	if f == "" {
+		return snap
+	}
+	// The process and interface tables are only read for filters that need them.
+	var parents map[int32]int32
+	if _, ok := filter.TreeRoot(f); ok {
+		parents, _ = a.parents()
+	}
+	var ifaces *netif.Table
+	if strings.HasPrefix(strings.ToLower(f), filter.IfacePrefix) {
+		ifaces, _ = a.ifaces()
+	}
+
+	filtered := &model.NetworkSnapshot{
+		Timestamp:    snap.Timestamp,
+		SkippedCount: snap.SkippedCount,
+		HiddenCount:  snap.HiddenCount,
+	}
+	for _, app := range snap.Applications {
+		var lineage []int32
+		if parents != nil {
+			lineage = process.Lineage(parents, app.PIDs)
+		}
+		appMatch := filter.Match(f, filter.Fields{ProcessName: app.Name, PIDs: app.PIDs, Lineage: lineage}, false)
+		var conns []model.Connection
+		for _, conn := range app.Connections {
+			if appMatch || filter.Match(f, a.connFields(app, conn, lineage, ifaces), false) {
+				conns = append(conns, conn)
+			}
+		}
+		if len(conns) == 0 {
+			continue
+		}
+		kept := model.Application{Name: app.Name, Exe: app.Exe, PIDs: app.PIDs, Connections: conns}
+		for _, conn := range conns {
+			switch conn.State {
+			case model.StateEstablished:
+				kept.EstablishedCount++
+			case model.StateListen:
+				kept.ListenCount++
+			}
+		}
+		filtered.Applications = append(filtered.Applications, kept)
+	}
+	return filtered
+}
+
+// connFields returns the filterable fields of one connection. The agent
+// resolves no names, so domain:NAME matches remote IPs only.
+func (a *Agent) connFields(app model.Application, conn model.Connection, lineage []int32, ifaces *netif.Table) filter.Fields {
+	fields := filter.Fields{
+		ProcessName: app.Name,
+		PIDs:        app.PIDs,
+		LocalAddr:   conn.LocalAddr,
+		RemoteAddr:  conn.RemoteAddr,
+		Protocol:    string(conn.Protocol),
+		State:       string(conn.State),
+		Lineage:     lineage,
+	}
+	if ifaces != nil {
+		fields.Interface = ifaces.Interface(model.AddrHost(conn.LocalAddr), model.AddrHost(conn.RemoteAddr))
+	}
+	ip, err := netip.ParseAddr(strings.Trim(model.AddrHost(conn.RemoteAddr), "[]"))
+	if err != nil || ip.IsUnspecified() {
+		return fields
+	}
+	fields.Host = ip.String()
+	if a.Geo != nil {
+		fields.Country = filter.GeoUnknown
+		if cc, ok := a.Geo.Country(ip); ok {
+			fields.Country = cc
+		}
+		if info, ok := a.Geo.ASN(ip); ok {
+			fields.ASN = info.Number
+		}
+	}
+	return fields
+}
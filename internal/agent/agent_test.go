@@ -0,0 +1,208 @@
+package agent
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/kostyay/netmon/internal/audit"
+	"github.com/kostyay/netmon/internal/geoip"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+type fakeCollector struct{ snap *model.NetworkSnapshot }
+
+func (f fakeCollector) Collect(ctx context.Context) (*model.NetworkSnapshot, error) {
+	return f.snap, nil
+}
+
+func testSnapshot() *model.NetworkSnapshot {
+	return &model.NetworkSnapshot{
+		Timestamp: time.Now(),
+		Applications: []model.Application{
+			{Name: "chrome", PIDs: []int32{10}, Connections: []model.Connection{
+				{PID: 10, Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.1:5000", RemoteAddr: "1.1.1.1:443", State: model.StateEstablished},
+			}},
+			{Name: "postgres", PIDs: []int32{20}, Connections: []model.Connection{
+				{PID: 20, Protocol: model.ProtocolTCP, LocalAddr: "*:5432", RemoteAddr: "*", State: model.StateListen},
+			}},
+		},
+	}
+}
+
+// runAgent feeds commands to an agent and returns the events it wrote.
+// The ticker never fires within the test.
+func runAgent(t *testing.T, a *Agent, commands ...string) []Event {
+	t.Helper()
+	a.interval = time.Hour
+	var out strings.Builder
+	if err := a.Run(context.Background(), strings.NewReader(strings.Join(commands, "\n")), &out); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	var events []Event
+	sc := bufio.NewScanner(strings.NewReader(out.String()))
+	sc.Buffer(nil, 1024*1024)
+	for sc.Scan() {
+		var e Event
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			t.Fatalf("line %q is not an event: %v", sc.Text(), err)
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+func TestRun_HelloThenSnapshot(t *testing.T) {
+	events := runAgent(t, New(fakeCollector{testSnapshot()}, nil, 0))
+	if len(events) != 2 || events[0].Type != "hello" || events[0].Version != ProtocolVersion {
+		t.Fatalf("events = %+v, want hello then snapshot", events)
+	}
+	if events[1].Type != "snapshot" || len(events[1].Snapshot.Applications) != 2 {
+		t.Errorf("snapshot = %+v, want both applications", events[1].Snapshot)
+	}
+}
+
+func TestRun_Filter(t *testing.T) {
+	events := runAgent(t, New(fakeCollector{testSnapshot()}, nil, 0),
+		`{"id":"1","cmd":"filter","filter":"5432"}`)
+	// hello, snapshot, result, filtered snapshot
+	if len(events) != 4 || events[2].Type != "result" || events[2].ID != "1" {
+		t.Fatalf("events = %+v, want result for id 1", events)
+	}
+	apps := events[3].Snapshot.Applications
+	if len(apps) != 1 || apps[0].Name != "postgres" || apps[0].ListenCount != 1 {
+		t.Errorf("filtered apps = %+v, want postgres only", apps)
+	}
+}
+
+func TestFilterSnapshot_SharedSyntax(t *testing.T) {
+	db, err := geoip.Load(strings.NewReader("1.1.1.0\t1.1.1.255\t13335\tAU\tCLOUDFLARENET\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := New(fakeCollector{testSnapshot()}, nil, 0)
+	a.Geo = db
+	a.parents = func() (map[int32]int32, error) { return map[int32]int32{10: 5, 20: 1}, nil }
+
+	for filter, want := range map[string]string{
+		"~chr":           "chrome",
+		"tree:5":         "chrome",
+		"geo:au":         "chrome",
+		"asn:13335":      "chrome",
+		"5432":           "postgres",
+		"domain:1.1.1.1": "chrome",
+	} {
+		apps := a.filterSnapshot(testSnapshot(), filter).Applications
+		if len(apps) != 1 || apps[0].Name != want {
+			t.Errorf("filter %q kept %+v, want %s only", filter, apps, want)
+		}
+	}
+	if apps := a.filterSnapshot(testSnapshot(), "geo:CN").Applications; len(apps) != 0 {
+		t.Errorf("geo:CN kept %+v, want none", apps)
+	}
+}
+
+func TestRun_Kill(t *testing.T) {
+	a := New(fakeCollector{testSnapshot()}, nil, 0)
+	var killed []int
+	a.kill = func(pid int, sig syscall.Signal) error {
+		if sig != syscall.SIGKILL {
+			t.Errorf("signal = %v, want SIGKILL", sig)
+		}
+		killed = append(killed, pid)
+		return nil
+	}
+	var audited []audit.Entry
+	a.Audit = func(e audit.Entry) { audited = append(audited, e) }
+
+	events := runAgent(t, a,
+		`{"id":"k1","cmd":"kill","pid":20,"signal":"KILL"}`,
+		`{"id":"k2","cmd":"kill","pid":999}`)
+
+	if len(killed) != 1 || killed[0] != 20 {
+		t.Errorf("killed = %v, want only PID 20", killed)
+	}
+	if len(audited) != 1 || audited[0].Target != "postgres" || audited[0].Result != audit.ResultOK {
+		t.Errorf("audit = %+v, want one ok kill of postgres", audited)
+	}
+	last := events[len(events)-1]
+	if last.Type != "error" || last.ID != "k2" || !strings.Contains(last.Error, "no process") {
+		t.Errorf("last event = %+v, want error for PID outside the snapshot", last)
+	}
+}
+
+func TestRun_KillFailureIsAudited(t *testing.T) {
+	a := New(fakeCollector{testSnapshot()}, nil, 0)
+	a.kill = func(int, syscall.Signal) error { return syscall.EPERM }
+	var audited []audit.Entry
+	a.Audit = func(e audit.Entry) { audited = append(audited, e) }
+
+	events := runAgent(t, a, `{"cmd":"kill","pid":10}`)
+
+	if len(audited) != 1 || audited[0].Result != audit.ResultFailed || audited[0].Signal != "SIGTERM" {
+		t.Errorf("audit = %+v, want failed SIGTERM", audited)
+	}
+	if last := events[len(events)-1]; last.Type != "error" || !strings.Contains(last.Error, "operation not permitted") {
+		t.Errorf("last event = %+v, want kill error", last)
+	}
+}
+
+func TestRun_KillCriticalNeedsConfirm(t *testing.T) {
+	snap := testSnapshot()
+	snap.Applications[1].Name, snap.Applications[1].Exe = "sshd (sbin)", "/usr/sbin/sshd"
+	a := New(fakeCollector{snap}, nil, 0)
+	var killed int
+	a.kill = func(int, syscall.Signal) error { killed++; return nil }
+	var audited []audit.Entry
+	a.Audit = func(e audit.Entry) { audited = append(audited, e) }
+
+	events := runAgent(t, a,
+		`{"id":"k1","cmd":"kill","pid":20}`,
+		`{"id":"k2","cmd":"kill","pid":20,"confirm":"sshd"}`)
+
+	if e := events[2]; e.Type != "error" || e.ID != "k1" || !strings.Contains(e.Error, "system service") {
+		t.Errorf("event = %+v, want the unconfirmed kill refused", e)
+	}
+	if e := events[3]; e.Type != "result" || e.ID != "k2" {
+		t.Errorf("event = %+v, want the confirmed kill to succeed", e)
+	}
+	if killed != 1 || len(audited) != 1 {
+		t.Errorf("killed %d, audited %d; want one kill, the refusal not sent", killed, len(audited))
+	}
+}
+
+func TestRun_QuitAndBadCommands(t *testing.T) {
+	events := runAgent(t, New(fakeCollector{testSnapshot()}, nil, 0),
+		`not json`,
+		`{"id":"x","cmd":"dance"}`,
+		`{"id":"q","cmd":"quit"}`,
+		`{"id":"never","cmd":"refresh"}`)
+
+	var types []string
+	for _, e := range events {
+		types = append(types, e.Type+":"+e.ID)
+	}
+	want := "hello: snapshot: error: error:x result:q"
+	if got := strings.Join(types, " "); got != want {
+		t.Errorf("events = %s, want %s", got, want)
+	}
+}
+
+// errWriter fails every write.
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) { return 0, errors.New("broken pipe") }
+
+func TestRun_StopsOnWriteError(t *testing.T) {
+	a := New(fakeCollector{testSnapshot()}, nil, time.Hour)
+	r, _ := io.Pipe() // never closes
+	if err := a.Run(context.Background(), r, errWriter{}); err == nil {
+		t.Error("Run should fail when stdout is gone")
+	}
+}
@@ -0,0 +1,290 @@
+// Package allowlist parses the destinations a host is expected to talk to
+// (IPs, CIDRs and domains) and finds the outbound connections that go
+// anywhere else, for egress-control audits before a firewall is locked down.
+package allowlist
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"strings"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// List is a parsed allowlist. A destination is allowed when its address is
+// inside one of the prefixes, including the addresses domains resolved to, or
+// its reverse DNS name is one of the domains.
+type List struct {
+	prefixes []netip.Prefix
+	domains  []string // lower case; "*.example.com" is kept as ".example.com"
+	resolved []netip.Prefix
+}
+
+// Parse reads entries separated by newlines, spaces or commas. Each entry is
+// an IP ("10.0.3.7"), a CIDR ("10.0.0.0/8"), a domain ("api.github.com") or
+// a wildcard domain ("*.amazonaws.com"). Anything after '#' is a comment.
+func Parse(r io.Reader) (*List, error) {
+	l := &List{}
+	sc := bufio.NewScanner(r)
+	for lineNo := 1; sc.Scan(); lineNo++ {
+		line, _, _ := strings.Cut(sc.Text(), "#")
+		fields := strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || r == ';' || r == ' ' || r == '\t' || r == '\r'
+		})
+		for _, f := range fields {
+			if err := l.add(f); err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if len(l.prefixes)+len(l.domains) == 0 {
+		return nil, errors.New("no entries found")
+	}
+	return l, nil
+}
+
+// Load parses the allowlist file at path; "-" reads standard input.
+func Load(path string) (*List, error) {
+	if path == "-" {
+		return Parse(os.Stdin)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	l, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return l, nil
+}
+
+// add parses one entry into l.
+func (l *List) add(entry string) error {
+	if strings.Contains(entry, "/") {
+		p, err := netip.ParsePrefix(entry)
+		if err != nil {
+			return fmt.Errorf("invalid CIDR %q", entry)
+		}
+		l.prefixes = append(l.prefixes, p.Masked())
+		return nil
+	}
+	if addr, err := netip.ParseAddr(entry); err == nil {
+		addr = addr.Unmap().WithZone("")
+		l.prefixes = append(l.prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+		return nil
+	}
+	domain := strings.TrimSuffix(strings.ToLower(entry), ".")
+	if rest, ok := strings.CutPrefix(domain, "*."); ok {
+		if !validDomain(rest) {
+			return fmt.Errorf("invalid wildcard domain %q", entry)
+		}
+		l.domains = append(l.domains, "."+rest)
+		return nil
+	}
+	if !validDomain(domain) {
+		return fmt.Errorf("invalid entry %q (want IP, CIDR or domain)", entry)
+	}
+	l.domains = append(l.domains, domain)
+	return nil
+}
+
+// validDomain reports whether s looks like a DNS name: dot-separated labels of
+// letters, digits and hyphens, with at least one letter so that mistyped IPs
+// are rejected rather than treated as names.
+func validDomain(s string) bool {
+	if s == "" || len(s) > 253 || !strings.ContainsAny(s, "abcdefghijklmnopqrstuvwxyz") {
+		return false
+	}
+	for label := range strings.SplitSeq(s, ".") {
+		if label == "" || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, r := range label {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Resolve looks up the addresses of the plain domains with lookup and allows
+// them, so that connections match before (or without) a reverse lookup.
+// Wildcard domains match on reverse DNS names only. Domains that fail to
+// resolve are reported in the returned error; the others are still added.
+func (l *List) Resolve(ctx context.Context, lookup func(ctx context.Context, host string) ([]netip.Addr, error)) error {
+	var errs []error
+	for _, d := range l.domains {
+		if strings.HasPrefix(d, ".") {
+			continue
+		}
+		addrs, err := lookup(ctx, d)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, a := range addrs {
+			a = a.Unmap().WithZone("")
+			l.resolved = append(l.resolved, netip.PrefixFrom(a, a.BitLen()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// HasWildcards reports whether any entry is a wildcard domain, which can only
+// match once remote addresses have been reverse resolved.
+func (l *List) HasWildcards() bool {
+	for _, d := range l.domains {
+		if strings.HasPrefix(d, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Summary describes the list, e.g. "12 IPs/CIDRs, 3 domains".
+func (l *List) Summary() string {
+	var parts []string
+	if n := len(l.prefixes); n > 0 {
+		parts = append(parts, plural(n, "IP/CIDR", "IPs/CIDRs"))
+	}
+	if n := len(l.domains); n > 0 {
+		parts = append(parts, plural(n, "domain", "domains"))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return "1 " + one
+	}
+	return fmt.Sprintf("%d %s", n, many)
+}
+
+// Allowed reports whether the remote host (an IP without the port) with the
+// reverse DNS name hostname ("" if unknown) is an expected destination.
+// Loopback traffic never leaves the host and is always allowed.
+func (l *List) Allowed(host, hostname string) bool {
+	addr, err := netip.ParseAddr(strings.Trim(host, "[]"))
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap().WithZone("")
+	if addr.IsLoopback() {
+		return true
+	}
+	for _, set := range [][]netip.Prefix{l.prefixes, l.resolved} {
+		for _, p := range set {
+			if p.Contains(addr) {
+				return true
+			}
+		}
+	}
+	if hostname == "" {
+		return false
+	}
+	hostname = strings.TrimSuffix(strings.ToLower(hostname), ".")
+	for _, d := range l.domains {
+		if hostname == d || (strings.HasPrefix(d, ".") && strings.HasSuffix(hostname, d)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Violation is an outbound connection to a destination outside the allowlist.
+type Violation struct {
+	Process    string `json:"process"`
+	PID        int32  `json:"pid"`
+	Protocol   string `json:"protocol"`
+	LocalAddr  string `json:"localAddr"`
+	RemoteAddr string `json:"remoteAddr"`
+	Hostname   string `json:"hostname,omitempty"` // reverse DNS name of the remote host
+	State      string `json:"state,omitempty"`
+}
+
+// Violations returns the snapshot's outbound connections whose remote host is
+// not allowed, in snapshot order. names maps remote IPs to reverse DNS names
+// (nil if none are known). Listeners, sockets without a peer and inbound
+// connections, whose local port a listener of the same protocol holds, are
+// not egress and are left out.
+func Violations(snap *model.NetworkSnapshot, l *List, names map[string]string) []Violation {
+	if snap == nil || l == nil {
+		return nil
+	}
+	listening := make(map[string]bool) // "TCP/8080"
+	for _, app := range snap.Applications {
+		for _, conn := range app.Connections {
+			if conn.State == model.StateListen {
+				listening[portKey(conn)] = true
+			}
+		}
+	}
+
+	var out []Violation
+	for _, app := range snap.Applications {
+		for _, conn := range app.Connections {
+			host := RemoteHost(conn.RemoteAddr)
+			if host == "" || conn.State == model.StateListen || listening[portKey(conn)] {
+				continue
+			}
+			if l.Allowed(host, names[host]) {
+				continue
+			}
+			v := Violation{
+				Process:    app.Name,
+				PID:        conn.PID,
+				Protocol:   string(conn.Protocol),
+				LocalAddr:  conn.LocalAddr,
+				RemoteAddr: conn.RemoteAddr,
+				Hostname:   names[host],
+			}
+			if conn.State != model.StateNone {
+				v.State = string(conn.State)
+			}
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// portKey identifies the local port of conn for the listener lookup.
+func portKey(conn model.Connection) string {
+	return fmt.Sprintf("%s/%d", conn.Protocol, model.ExtractPort(conn.LocalAddr))
+}
+
+// RemoteHost returns the host of a remote "ip:port" address, or "" when there
+// is no peer ("", "*", "*:*", "0.0.0.0:0").
+func RemoteHost(addr string) string {
+	host := addr
+	if i := strings.LastIndex(addr, ":"); i >= 0 {
+		host = addr[:i]
+	}
+	host = strings.Trim(host, "[]")
+	switch host {
+	case "", "*", "0.0.0.0", "::":
+		return ""
+	}
+	return host
+}
+
+// WriteJSON writes violations as an indented JSON array ("[]" for none).
+func WriteJSON(w io.Writer, violations []Violation) error {
+	if violations == nil {
+		violations = []Violation{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(violations)
+}
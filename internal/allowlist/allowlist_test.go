@@ -0,0 +1,124 @@
+package allowlist
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+func TestParse(t *testing.T) {
+	l, err := Parse(strings.NewReader(`# egress allowlist
+10.0.0.0/8, 192.168.1.5
+api.github.com   *.amazonaws.com  # CDNs
+2001:db8::/32
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := l.Summary(), "3 IPs/CIDRs, 2 domains"; got != want {
+		t.Errorf("Summary() = %q, want %q", got, want)
+	}
+	if !l.HasWildcards() {
+		t.Error("HasWildcards() = false with *.amazonaws.com")
+	}
+
+	for _, bad := range []string{"", "# nothing\n", "10.0.0.0/33", "*.", "300.1.2.3", "exa_mple.com", "-bad.com"} {
+		if _, err := Parse(strings.NewReader(bad)); err == nil {
+			t.Errorf("Parse(%q) should fail", bad)
+		}
+	}
+}
+
+func TestAllowed(t *testing.T) {
+	l, err := Parse(strings.NewReader("10.0.0.0/8 api.github.com *.amazonaws.com"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		host, name string
+		want       bool
+	}{
+		{"10.1.2.3", "", true},
+		{"::ffff:10.1.2.3", "", true},
+		{"127.0.0.1", "", true},
+		{"::1", "", true},
+		{"8.8.8.8", "", false},
+		{"140.82.112.6", "api.github.com.", true},
+		{"140.82.112.6", "github.com", false},
+		{"52.1.2.3", "ec2-52-1-2-3.compute-1.AMAZONAWS.com", true},
+		{"52.1.2.3", "amazonaws.com.evil.net", false},
+		{"not-an-ip", "api.github.com", false},
+	}
+	for _, tt := range tests {
+		if got := l.Allowed(tt.host, tt.name); got != tt.want {
+			t.Errorf("Allowed(%q, %q) = %v, want %v", tt.host, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	l, err := Parse(strings.NewReader("api.github.com gone.example.com *.amazonaws.com"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var looked []string
+	err = l.Resolve(context.Background(), func(_ context.Context, host string) ([]netip.Addr, error) {
+		looked = append(looked, host)
+		if host == "gone.example.com" {
+			return nil, errors.New("no such host")
+		}
+		return []netip.Addr{netip.MustParseAddr("140.82.112.6")}, nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "no such host") {
+		t.Errorf("Resolve error = %v, want the failed lookup", err)
+	}
+	if strings.Join(looked, " ") != "api.github.com gone.example.com" {
+		t.Errorf("looked up %v, want only the plain domains", looked)
+	}
+	if !l.Allowed("140.82.112.6", "") {
+		t.Error("resolved address should be allowed without a reverse name")
+	}
+}
+
+func TestViolations(t *testing.T) {
+	l, err := Parse(strings.NewReader("10.0.0.0/8"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	snap := &model.NetworkSnapshot{Applications: []model.Application{
+		{Name: "nginx", Connections: []model.Connection{
+			{PID: 1, Protocol: model.ProtocolTCP, LocalAddr: "0.0.0.0:443", RemoteAddr: "*", State: model.StateListen},
+			{PID: 1, Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.5:443", RemoteAddr: "203.0.113.9:51000", State: model.StateEstablished},
+		}},
+		{Name: "curl", Connections: []model.Connection{
+			{PID: 2, Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.5:40000", RemoteAddr: "10.2.0.1:443", State: model.StateEstablished},
+			{PID: 2, Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.5:40001", RemoteAddr: "93.184.216.34:443", State: model.StateEstablished},
+			{PID: 2, Protocol: model.ProtocolUDP, LocalAddr: "10.0.0.5:5353", RemoteAddr: "*:*"},
+			{PID: 2, Protocol: model.ProtocolUDP, LocalAddr: "10.0.0.5:41000", RemoteAddr: "8.8.8.8:53"},
+		}},
+	}}
+
+	got := Violations(snap, l, map[string]string{"93.184.216.34": "example.com"})
+	want := []Violation{
+		{Process: "curl", PID: 2, Protocol: "TCP", LocalAddr: "10.0.0.5:40001", RemoteAddr: "93.184.216.34:443", Hostname: "example.com", State: "ESTABLISHED"},
+		{Process: "curl", PID: 2, Protocol: "UDP", LocalAddr: "10.0.0.5:41000", RemoteAddr: "8.8.8.8:53"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Violations = %+v, want %+v (inbound to nginx excluded)", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("violation %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, nil); err != nil || strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("WriteJSON(nil) = %q, %v", buf.String(), err)
+	}
+}
@@ -0,0 +1,57 @@
+// Package asn aggregates connection counts per autonomous system (ASN and
+// owning organization). The IP to AS mapping comes from the GeoIP table when
+// it is ip2asn-combined (geoip.DB.ASN).
+package asn
+
+import (
+	"net/netip"
+	"sort"
+)
+
+// Info identifies an autonomous system.
+type Info struct {
+	Number uint32
+	Org    string
+}
+
+// Unknown is the summary bucket for IPs without an AS (private, loopback or
+// missing from the table).
+var Unknown = Info{Org: "Unknown"}
+
+// Summary is the connection count for one AS.
+type Summary struct {
+	Info
+	Hosts int // distinct remote IPs
+	Count int // total connections
+}
+
+// Summarize folds per-IP connection counts into per-AS summaries, ordered by
+// descending count then organization. lookup maps an IP to its AS.
+func Summarize(lookup func(netip.Addr) (Info, bool), counts map[netip.Addr]int) []Summary {
+	byAS := make(map[Info]*Summary)
+	for ip, n := range counts {
+		info, ok := lookup(ip)
+		if !ok {
+			info = Unknown
+		}
+		s, ok := byAS[info]
+		if !ok {
+			s = &Summary{Info: info}
+			byAS[info] = s
+		}
+		s.Hosts++
+		s.Count += n
+	}
+
+	summaries := make([]Summary, 0, len(byAS))
+	for _, s := range byAS {
+		summaries = append(summaries, *s)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Count != summaries[j].Count {
+			return summaries[i].Count > summaries[j].Count
+		}
+		return summaries[i].Org < summaries[j].Org
+	})
+	return summaries
+}
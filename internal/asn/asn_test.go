@@ -0,0 +1,41 @@
+package asn
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestSummarize(t *testing.T) {
+	amazon := Info{16509, "AMAZON-02"}
+	google := Info{15169, "GOOGLE"}
+	table := map[netip.Addr]Info{
+		netip.MustParseAddr("3.5.140.2"):            amazon,
+		netip.MustParseAddr("3.6.0.1"):              amazon,
+		netip.MustParseAddr("8.8.8.8"):              google,
+		netip.MustParseAddr("2001:4860:4860::8888"): google,
+	}
+	lookup := func(ip netip.Addr) (Info, bool) {
+		info, ok := table[ip]
+		return info, ok
+	}
+	got := Summarize(lookup, map[netip.Addr]int{
+		netip.MustParseAddr("3.5.140.2"):            80,
+		netip.MustParseAddr("3.6.0.1"):              40,
+		netip.MustParseAddr("8.8.8.8"):              30,
+		netip.MustParseAddr("2001:4860:4860::8888"): 13,
+		netip.MustParseAddr("192.168.1.1"):          2,
+	})
+	want := []Summary{
+		{Info: amazon, Hosts: 2, Count: 120},
+		{Info: google, Hosts: 2, Count: 43},
+		{Info: Unknown, Hosts: 1, Count: 2},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Summarize = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
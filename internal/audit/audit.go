@@ -0,0 +1,123 @@
+// Package audit records process-affecting actions taken through netmon (kill,
+// container stop, suspend, renice, ...) in an append-only JSON Lines file, so
+// that on a shared host it is clear who did what and whether it worked.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/user"
+	"path/filepath"
+	"time"
+)
+
+// Results of an action.
+const (
+	ResultOK      = "ok"
+	ResultFailed  = "failed"
+	ResultPartial = "partial" // some PIDs failed
+)
+
+// Entry is one recorded action.
+type Entry struct {
+	Time        time.Time `json:"time"`
+	User        string    `json:"user"`
+	Source      string    `json:"source"` // "tui", "cli" or "agent"
+	Action      string    `json:"action"` // kill, stop, suspend, resume, renice, close
+	Target      string    `json:"target"` // process or container name, or connection
+	PIDs        []int32   `json:"pids,omitempty"`
+	ContainerID string    `json:"containerId,omitempty"`
+	Signal      string    `json:"signal,omitempty"` // signal name, or nice value for renice
+	Result      string    `json:"result"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// Outcome returns the Result and Error fields for an action applied to
+// done+failed targets, lastErr being the last failure.
+func Outcome(done, failed int, lastErr error) (result, errText string) {
+	if lastErr != nil {
+		errText = lastErr.Error()
+	}
+	switch {
+	case failed == 0:
+		return ResultOK, ""
+	case done == 0:
+		return ResultFailed, errText
+	default:
+		return ResultPartial, errText
+	}
+}
+
+// CurrentUser names who is acting: the login name, plus the invoking user
+// under sudo ("root (sudo: alice)").
+func CurrentUser() string {
+	name := "unknown"
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+	if sudoUser := os.Getenv("SUDO_USER"); sudoUser != "" && sudoUser != name {
+		name = fmt.Sprintf("%s (sudo: %s)", name, sudoUser)
+	}
+	return name
+}
+
+// Append writes e to the log at path, creating it (0600) and its directory as
+// needed. Time and User default to now and CurrentUser.
+func Append(path string, e Entry) error {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	if e.User == "" {
+		e.User = CurrentUser()
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	// #nosec G304 - path comes from settings or the state directory
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Read returns the last limit entries of the log at path, oldest first (all
+// of them when limit <= 0). A missing log has no entries; malformed lines are
+// skipped.
+func Read(path string, limit int) ([]Entry, error) {
+	// #nosec G304 - path comes from settings or the state directory
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Entry
+		if json.Unmarshal(scanner.Bytes(), &e) != nil {
+			continue
+		}
+		entries = append(entries, e)
+		if limit > 0 && len(entries) > limit {
+			entries = entries[1:]
+		}
+	}
+	return entries, scanner.Err()
+}
@@ -0,0 +1,97 @@
+package audit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAppendRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "audit.log")
+	at := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	for i, action := range []string{"kill", "suspend", "stop"} {
+		err := Append(path, Entry{Time: at.Add(time.Duration(i) * time.Minute), User: "alice", Source: "tui", Action: action, Target: "nginx", PIDs: []int32{42}, Result: ResultOK})
+		if err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	all, err := Read(path, 0)
+	if err != nil || len(all) != 3 {
+		t.Fatalf("Read = %d entries, %v; want 3", len(all), err)
+	}
+	if all[0].Action != "kill" || all[0].User != "alice" || all[0].PIDs[0] != 42 || !all[0].Time.Equal(at) {
+		t.Errorf("first entry = %+v", all[0])
+	}
+
+	last, _ := Read(path, 2)
+	if len(last) != 2 || last[0].Action != "suspend" || last[1].Action != "stop" {
+		t.Errorf("Read(limit 2) = %+v, want the last two oldest first", last)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("perm = %o, want 600", perm)
+	}
+}
+
+func TestAppend_DefaultsTimeAndUser(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	if err := Append(path, Entry{Action: "kill", Result: ResultOK}); err != nil {
+		t.Fatal(err)
+	}
+	entries, _ := Read(path, 0)
+	if len(entries) != 1 || entries[0].Time.IsZero() || entries[0].User == "" {
+		t.Errorf("entries = %+v, want time and user filled in", entries)
+	}
+}
+
+func TestRead_MissingAndMalformed(t *testing.T) {
+	dir := t.TempDir()
+	if entries, err := Read(filepath.Join(dir, "none.log"), 0); entries != nil || err != nil {
+		t.Errorf("missing log = %v, %v; want nothing", entries, err)
+	}
+
+	path := filepath.Join(dir, "audit.log")
+	content := "{\"action\":\"kill\",\"result\":\"ok\"}\nnot json\n{\"action\":\"stop\",\"result\":\"failed\"}\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := Read(path, 0)
+	if err != nil || len(entries) != 2 {
+		t.Errorf("Read = %+v, %v; want malformed line skipped", entries, err)
+	}
+}
+
+func TestOutcome(t *testing.T) {
+	boom := errors.New("operation not permitted")
+	tests := []struct {
+		done, failed int
+		err          error
+		result, text string
+	}{
+		{2, 0, nil, ResultOK, ""},
+		{0, 1, boom, ResultFailed, "operation not permitted"},
+		{1, 1, boom, ResultPartial, "operation not permitted"},
+	}
+	for _, tt := range tests {
+		result, text := Outcome(tt.done, tt.failed, tt.err)
+		if result != tt.result || text != tt.text {
+			t.Errorf("Outcome(%d, %d) = %q, %q; want %q, %q", tt.done, tt.failed, result, text, tt.result, tt.text)
+		}
+	}
+}
+
+func TestCurrentUser_Sudo(t *testing.T) {
+	t.Setenv("SUDO_USER", "someone-else")
+	if got := CurrentUser(); !strings.HasSuffix(got, " (sudo: someone-else)") {
+		t.Errorf("CurrentUser = %q, want the invoking sudo user noted", got)
+	}
+}
@@ -0,0 +1,164 @@
+// Package capture watches the first payload bytes of new TCP connections and
+// records the hostname each client asked for (TLS SNI or HTTP Host, parsed by
+// internal/hostsniff). Only hostnames are kept; payload bytes are dropped as
+// soon as a flow is decided.
+//
+// Packets come from an AF_PACKET socket on Linux (Open); other platforms
+// return ErrUnsupported. The Table is platform independent and fed raw IP
+// packets, so it is tested without privileges.
+package capture
+
+import (
+	"errors"
+	"maps"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/kostyay/netmon/internal/hostsniff"
+)
+
+// ErrUnsupported is returned by Open where packet capture is not implemented.
+var ErrUnsupported = errors.New("packet capture not supported on this platform")
+
+const (
+	// maxPayload caps the bytes buffered per flow while the headers are
+	// incomplete; a ClientHello or request head beyond this is given up on.
+	maxPayload = 4 << 10
+	// maxPending caps flows waiting for their first payload.
+	maxPending = 4096
+	// pendingTTL is how long a flow may stay silent after its SYN.
+	pendingTTL = 10 * time.Second
+	// nameGrace keeps a name for a flow not (yet) in the socket table, since
+	// the capture usually sees a connection before the next snapshot does.
+	nameGrace = 30 * time.Second
+)
+
+// Flow is a TCP connection seen from its client: Src opened it to Dst.
+type Flow struct {
+	Src netip.AddrPort
+	Dst netip.AddrPort
+}
+
+// Name is the hostname a flow's client asked for.
+type Name struct {
+	Host   string
+	Source hostsniff.Source
+}
+
+// pending is a flow whose SYN was seen and whose first payload is awaited.
+type pending struct {
+	next uint32 // sequence number of the next in-order byte
+	buf  []byte
+	at   time.Time
+}
+
+type entry struct {
+	Name
+	at time.Time
+}
+
+// Table tracks TCP flows opened while capturing and the hostnames their
+// clients asked for. It is safe for concurrent use: the capture loop feeds
+// packets, the UI reads names.
+type Table struct {
+	mu      sync.Mutex
+	pending map[Flow]*pending
+	names   map[Flow]entry
+	gen     uint64
+	err     error // why the capture stopped
+}
+
+// NewTable returns an empty Table.
+func NewTable() *Table {
+	return &Table{pending: make(map[Flow]*pending), names: make(map[Flow]entry)}
+}
+
+// Packet feeds one IPv4 or IPv6 packet captured at at. A SYN starts tracking
+// its flow; the flow's first payload segments, appended in sequence order,
+// are parsed until they name a host or cannot. Everything else is ignored.
+func (t *Table) Packet(pkt []byte, at time.Time) {
+	seg, ok := decode(pkt)
+	if !ok {
+		return
+	}
+	flow := Flow{Src: seg.src, Dst: seg.dst}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if seg.flags&(flagSYN|flagACK) == flagSYN {
+		if len(t.pending) < maxPending {
+			t.pending[flow] = &pending{next: seg.seq + 1, at: at}
+		}
+		return
+	}
+	p := t.pending[flow]
+	if p == nil || len(seg.payload) == 0 || seg.seq != p.next {
+		return // not a tracked flow, or a retransmission / out of order
+	}
+	if seg.flags&(flagRST|flagFIN) != 0 {
+		delete(t.pending, flow)
+		return
+	}
+	p.buf = append(p.buf, seg.payload...)
+	p.next += uint32(len(seg.payload))
+	host, source, err := hostsniff.Hostname(p.buf)
+	if errors.Is(err, hostsniff.ErrTruncated) && len(p.buf) < maxPayload {
+		return
+	}
+	delete(t.pending, flow)
+	if err == nil {
+		t.names[flow] = entry{Name: Name{Host: host, Source: source}, at: at}
+		t.gen++
+	}
+}
+
+// Gen returns a counter that changes whenever a name is added or dropped.
+func (t *Table) Gen() uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.gen
+}
+
+// Names returns a copy of the known names and the matching Gen.
+func (t *Table) Names() (map[Flow]Name, uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	names := make(map[Flow]Name, len(t.names))
+	for f, e := range t.names {
+		names[f] = e.Name
+	}
+	return names, t.gen
+}
+
+// Err returns the error that stopped the capture feeding t, or nil.
+func (t *Table) Err() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.err
+}
+
+// stop records why the capture stopped.
+func (t *Table) stop(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.err = err
+}
+
+// Retain drops the names of flows that are no longer open (open returns
+// false) and were learned more than a grace period before now, and flows
+// that never sent a payload.
+func (t *Table) Retain(open func(Flow) bool, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	n := len(t.names)
+	maps.DeleteFunc(t.names, func(f Flow, e entry) bool {
+		return !open(f) && now.Sub(e.at) > nameGrace
+	})
+	if len(t.names) != n {
+		t.gen++
+	}
+	maps.DeleteFunc(t.pending, func(_ Flow, p *pending) bool {
+		return now.Sub(p.at) > pendingTTL
+	})
+}
@@ -0,0 +1,88 @@
+//go:build linux
+
+package capture
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// skfAdProtocol loads skb->protocol (the EtherType) in a socket filter:
+// SKF_AD_OFF + SKF_AD_PROTOCOL from linux/filter.h.
+const skfAdProtocol = 0xfffff000
+
+// tcpFilter is a classic BPF program passing only TCP over IPv4 and IPv6 to
+// the socket, so the capture loop never wakes up for other traffic. Packets
+// start at the IP header (SOCK_DGRAM).
+var tcpFilter = []unix.SockFilter{
+	{Code: unix.BPF_LD | unix.BPF_H | unix.BPF_ABS, K: skfAdProtocol},
+	{Code: unix.BPF_JMP | unix.BPF_JEQ | unix.BPF_K, Jt: 1, Jf: 0, K: unix.ETH_P_IP},
+	{Code: unix.BPF_JMP | unix.BPF_JEQ | unix.BPF_K, Jt: 2, Jf: 5, K: unix.ETH_P_IPV6},
+	{Code: unix.BPF_LD | unix.BPF_B | unix.BPF_ABS, K: 9}, // IPv4 protocol
+	{Code: unix.BPF_JMP | unix.BPF_JEQ | unix.BPF_K, Jt: 2, Jf: 3, K: protoTCP},
+	{Code: unix.BPF_LD | unix.BPF_B | unix.BPF_ABS, K: 6}, // IPv6 next header
+	{Code: unix.BPF_JMP | unix.BPF_JEQ | unix.BPF_K, Jt: 0, Jf: 1, K: protoTCP},
+	{Code: unix.BPF_RET | unix.BPF_K, K: 0xffff},
+	{Code: unix.BPF_RET | unix.BPF_K, K: 0},
+}
+
+// readTimeout bounds each read so Run notices cancellation.
+const readTimeout = 200 * time.Millisecond
+
+// Sniffer reads TCP packets on every interface from an AF_PACKET socket.
+type Sniffer struct {
+	fd int
+}
+
+// Open creates the capture socket. It needs root or CAP_NET_RAW.
+func Open() (*Sniffer, error) {
+	fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, int(htons(unix.ETH_P_ALL)))
+	if err != nil {
+		return nil, err
+	}
+	prog := unix.SockFprog{Len: uint16(len(tcpFilter)), Filter: &tcpFilter[0]}
+	if err := unix.SetsockoptSockFprog(fd, unix.SOL_SOCKET, unix.SO_ATTACH_FILTER, &prog); err != nil {
+		_ = unix.Close(fd)
+		return nil, err
+	}
+	tv := unix.NsecToTimeval(readTimeout.Nanoseconds())
+	if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv); err != nil {
+		_ = unix.Close(fd)
+		return nil, err
+	}
+	return &Sniffer{fd: fd}, nil
+}
+
+// Run feeds captured packets to t until ctx is done or a read fails; the
+// error is also kept in t (Table.Err).
+func (s *Sniffer) Run(ctx context.Context, t *Table) error {
+	buf := make([]byte, 1<<16)
+	for ctx.Err() == nil {
+		n, _, err := unix.Recvfrom(s.fd, buf, 0)
+		if err != nil {
+			if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+				continue
+			}
+			t.stop(err)
+			return err
+		}
+		t.Packet(buf[:n], time.Now())
+	}
+	return nil
+}
+
+// Close closes the capture socket.
+func (s *Sniffer) Close() error {
+	return unix.Close(s.fd)
+}
+
+// htons converts a uint16 to network byte order.
+func htons(v uint16) uint16 {
+	var b [2]byte
+	binary.BigEndian.PutUint16(b[:], v)
+	return binary.NativeEndian.Uint16(b[:])
+}
@@ -0,0 +1,24 @@
+//go:build !linux
+
+package capture
+
+import "context"
+
+// Sniffer is a packet capture source; only implemented on Linux.
+type Sniffer struct{}
+
+// Open is only supported on Linux.
+func Open() (*Sniffer, error) {
+	return nil, ErrUnsupported
+}
+
+// Run is only supported on Linux.
+func (s *Sniffer) Run(ctx context.Context, t *Table) error {
+	t.stop(ErrUnsupported)
+	return ErrUnsupported
+}
+
+// Close is a no-op.
+func (s *Sniffer) Close() error {
+	return nil
+}
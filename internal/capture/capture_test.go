@@ -0,0 +1,134 @@
+package capture
+
+import (
+	"encoding/binary"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/kostyay/netmon/internal/hostsniff"
+)
+
+var (
+	client = netip.MustParseAddrPort("192.168.1.5:50000")
+	server = netip.MustParseAddrPort("93.184.216.34:80")
+)
+
+// tcpPacket builds an IPv4 or IPv6 packet (by the address family) carrying
+// one TCP segment from src to dst.
+func tcpPacket(src, dst netip.AddrPort, seq uint32, flags uint8, payload string) []byte {
+	tcp := make([]byte, 20+len(payload))
+	binary.BigEndian.PutUint16(tcp[0:2], src.Port())
+	binary.BigEndian.PutUint16(tcp[2:4], dst.Port())
+	binary.BigEndian.PutUint32(tcp[4:8], seq)
+	tcp[12] = 5 << 4
+	tcp[13] = flags
+	copy(tcp[20:], payload)
+
+	if src.Addr().Is4() {
+		ip := make([]byte, 20)
+		ip[0] = 0x45
+		binary.BigEndian.PutUint16(ip[2:4], uint16(20+len(tcp)))
+		ip[9] = protoTCP
+		s, d := src.Addr().As4(), dst.Addr().As4()
+		copy(ip[12:16], s[:])
+		copy(ip[16:20], d[:])
+		return append(ip, tcp...)
+	}
+	ip := make([]byte, 40)
+	ip[0] = 0x60
+	binary.BigEndian.PutUint16(ip[4:6], uint16(len(tcp)))
+	ip[6] = protoTCP
+	s, d := src.Addr().As16(), dst.Addr().As16()
+	copy(ip[8:24], s[:])
+	copy(ip[24:40], d[:])
+	return append(ip, tcp...)
+}
+
+func TestDecode(t *testing.T) {
+	seg, ok := decode(tcpPacket(client, server, 7, flagACK, "GET /"))
+	if !ok || seg.src != client || seg.dst != server || seg.seq != 7 || seg.flags != flagACK || string(seg.payload) != "GET /" {
+		t.Errorf("decode IPv4 = %+v, %v", seg, ok)
+	}
+
+	src, dst := netip.MustParseAddrPort("[2001:db8::1]:50000"), netip.MustParseAddrPort("[2001:db8::2]:443")
+	if seg, ok := decode(tcpPacket(src, dst, 1, flagSYN, "")); !ok || seg.src != src || seg.dst != dst || len(seg.payload) != 0 {
+		t.Errorf("decode IPv6 = %+v, %v", seg, ok)
+	}
+
+	pkt := tcpPacket(client, server, 1, flagACK, "x")
+	pkt[9] = 17 // UDP
+	if _, ok := decode(pkt); ok {
+		t.Error("decode accepted a UDP packet")
+	}
+	if _, ok := decode(pkt[:30]); ok {
+		t.Error("decode accepted a truncated packet")
+	}
+}
+
+func TestTable_HTTPHostAcrossSegments(t *testing.T) {
+	tbl := NewTable()
+	now := time.Now()
+	tbl.Packet(tcpPacket(client, server, 100, flagSYN, ""), now)
+	tbl.Packet(tcpPacket(server, client, 900, flagSYN|flagACK, ""), now)
+	tbl.Packet(tcpPacket(client, server, 101, flagACK, "GET / HTTP/1.1\r\nHo"), now)
+	if names, _ := tbl.Names(); len(names) != 0 {
+		t.Fatalf("names after a partial header = %v", names)
+	}
+	// A retransmission of the first segment is ignored
+	tbl.Packet(tcpPacket(client, server, 101, flagACK, "GET / HTTP/1.1\r\nHo"), now)
+	tbl.Packet(tcpPacket(client, server, 119, flagACK, "st: Example.COM:80\r\n\r\n"), now)
+
+	names, gen := tbl.Names()
+	want := Name{Host: "example.com", Source: hostsniff.SourceHTTP}
+	if got := names[Flow{Src: client, Dst: server}]; got != want || len(names) != 1 {
+		t.Errorf("names = %v, want %v for the client's flow", names, want)
+	}
+	if gen == 0 || tbl.Gen() != gen {
+		t.Errorf("gen = %d, Gen() = %d", gen, tbl.Gen())
+	}
+}
+
+func TestTable_IgnoresFlowsWithoutSYN(t *testing.T) {
+	tbl := NewTable()
+	tbl.Packet(tcpPacket(client, server, 101, flagACK, "GET / HTTP/1.1\r\nHost: example.com\r\n\r\n"), time.Now())
+	if names, _ := tbl.Names(); len(names) != 0 {
+		t.Errorf("names = %v; a flow opened before the capture has no first payload to parse", names)
+	}
+}
+
+func TestTable_GivesUpOnOtherProtocols(t *testing.T) {
+	tbl := NewTable()
+	now := time.Now()
+	tbl.Packet(tcpPacket(client, server, 100, flagSYN, ""), now)
+	tbl.Packet(tcpPacket(client, server, 101, flagACK, "SSH-2.0-OpenSSH_9.6\r\n"), now)
+	tbl.Packet(tcpPacket(client, server, 122, flagACK, "GET / HTTP/1.1\r\nHost: late.example\r\n\r\n"), now)
+	if names, _ := tbl.Names(); len(names) != 0 {
+		t.Errorf("names = %v; only a flow's first payload names its host", names)
+	}
+}
+
+func TestTable_Retain(t *testing.T) {
+	tbl := NewTable()
+	start := time.Now()
+	other := netip.MustParseAddrPort("192.168.1.5:50001")
+	for _, src := range []netip.AddrPort{client, other} {
+		tbl.Packet(tcpPacket(src, server, 100, flagSYN, ""), start)
+		tbl.Packet(tcpPacket(src, server, 101, flagACK, "GET / HTTP/1.1\r\nHost: example.com\r\n\r\n"), start)
+	}
+	tbl.Packet(tcpPacket(netip.MustParseAddrPort("192.168.1.5:50002"), server, 100, flagSYN, ""), start)
+
+	open := func(f Flow) bool { return f.Src == client }
+	tbl.Retain(open, start.Add(time.Second))
+	if names, _ := tbl.Names(); len(names) != 2 {
+		t.Errorf("names within the grace period = %v, want both kept", names)
+	}
+	gen := tbl.Gen()
+	tbl.Retain(open, start.Add(time.Minute))
+	if names, _ := tbl.Names(); len(names) != 1 || tbl.Gen() == gen {
+		t.Errorf("names after the grace period = %v, want only the open flow, and a new gen", names)
+	}
+	if len(tbl.pending) != 0 {
+		t.Errorf("pending = %v, want the silent flow dropped", tbl.pending)
+	}
+}
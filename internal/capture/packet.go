@@ -0,0 +1,77 @@
+package capture
+
+import (
+	"encoding/binary"
+	"net/netip"
+)
+
+// TCP flags.
+const (
+	flagFIN = 0x01
+	flagSYN = 0x02
+	flagRST = 0x04
+	flagACK = 0x10
+)
+
+const protoTCP = 6
+
+// segment is the part of a TCP/IP packet the Table needs.
+type segment struct {
+	src, dst netip.AddrPort
+	seq      uint32
+	flags    uint8
+	payload  []byte
+}
+
+// decode parses an IPv4 or IPv6 packet carrying TCP, starting at the IP
+// header. Fragments and IPv6 extension headers are not followed.
+func decode(pkt []byte) (segment, bool) {
+	if len(pkt) < 1 {
+		return segment{}, false
+	}
+	var src, dst netip.Addr
+	var tcp []byte
+	switch pkt[0] >> 4 {
+	case 4:
+		if len(pkt) < 20 {
+			return segment{}, false
+		}
+		ihl := int(pkt[0]&0x0f) * 4
+		total := int(binary.BigEndian.Uint16(pkt[2:4]))
+		fragment := binary.BigEndian.Uint16(pkt[6:8]) & 0x3fff // MF flag and offset
+		if ihl < 20 || total < ihl || len(pkt) < total || fragment != 0 || pkt[9] != protoTCP {
+			return segment{}, false
+		}
+		src = netip.AddrFrom4([4]byte(pkt[12:16]))
+		dst = netip.AddrFrom4([4]byte(pkt[16:20]))
+		tcp = pkt[ihl:total]
+	case 6:
+		if len(pkt) < 40 {
+			return segment{}, false
+		}
+		plen := int(binary.BigEndian.Uint16(pkt[4:6]))
+		if pkt[6] != protoTCP || len(pkt) < 40+plen {
+			return segment{}, false
+		}
+		src = netip.AddrFrom16([16]byte(pkt[8:24]))
+		dst = netip.AddrFrom16([16]byte(pkt[24:40]))
+		tcp = pkt[40 : 40+plen]
+	default:
+		return segment{}, false
+	}
+
+	if len(tcp) < 20 {
+		return segment{}, false
+	}
+	off := int(tcp[12]>>4) * 4
+	if off < 20 || len(tcp) < off {
+		return segment{}, false
+	}
+	return segment{
+		src:     netip.AddrPortFrom(src, binary.BigEndian.Uint16(tcp[0:2])),
+		dst:     netip.AddrPortFrom(dst, binary.BigEndian.Uint16(tcp[2:4])),
+		seq:     binary.BigEndian.Uint32(tcp[4:8]),
+		flags:   tcp[13],
+		payload: tcp[off:],
+	}, true
+}
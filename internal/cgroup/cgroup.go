@@ -0,0 +1,112 @@
+// Package cgroup reads the control group a process belongs to and names the
+// systemd unit or container it implies. On servers that is often more
+// telling than the executable: a dozen "python3" processes become
+// "api.service", "worker@2.service" and "docker:3f2a9c1b7d40".
+package cgroup
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrUnsupported is returned on platforms without cgroups.
+var ErrUnsupported = errors.New("not supported on this platform")
+
+// Info is a process's cgroup.
+type Info struct {
+	Path string // e.g. "/system.slice/nginx.service"
+	Unit string // systemd unit or container, e.g. "nginx.service", "docker:3f2a9c1b7d40"
+}
+
+// containerScopes are the scope prefixes container runtimes create under
+// systemd, mapped to the runtime shown in the unit.
+var containerScopes = []struct{ prefix, runtime string }{
+	{"docker-", "docker"},
+	{"cri-containerd-", "containerd"},
+	{"crio-", "crio"},
+	{"libpod-", "podman"},
+}
+
+// shortID is how much of a container ID is shown, as in `docker ps`.
+const shortID = 12
+
+// Parse reads the contents of /proc/<pid>/cgroup. The cgroup v2 entry
+// ("0::/path") is used when present, else the v1 name=systemd hierarchy,
+// else the first entry.
+func Parse(data string) Info {
+	var path, first string
+	for _, line := range strings.Split(strings.TrimSpace(data), "\n") {
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if first == "" {
+			first = parts[2]
+		}
+		if (parts[0] == "0" && parts[1] == "") || parts[1] == "name=systemd" {
+			path = parts[2]
+			break
+		}
+	}
+	if path == "" {
+		path = first
+	}
+	return Info{Path: path, Unit: unitOf(path)}
+}
+
+// unitOf names the unit a cgroup path belongs to: a container, else the
+// innermost systemd service or scope (user services nest under
+// user@UID.service), else the last path element.
+func unitOf(path string) string {
+	elems := strings.Split(strings.Trim(path, "/"), "/")
+	for i := len(elems) - 1; i >= 0; i-- {
+		if c := container(elems[i], elems[:i]); c != "" {
+			return c
+		}
+	}
+	for i := len(elems) - 1; i >= 0; i-- {
+		if strings.HasSuffix(elems[i], ".service") || strings.HasSuffix(elems[i], ".scope") {
+			return elems[i]
+		}
+	}
+	if last := elems[len(elems)-1]; last != "" {
+		return last
+	}
+	return "/"
+}
+
+// container returns "runtime:shortid" when elem is a container's cgroup,
+// either a systemd scope ("docker-<id>.scope") or a cgroupfs directory
+// ("/docker/<id>"); parents are the elements above it.
+func container(elem string, parents []string) string {
+	for _, s := range containerScopes {
+		if id, ok := strings.CutPrefix(elem, s.prefix); ok && strings.HasSuffix(id, ".scope") {
+			return s.runtime + ":" + short(strings.TrimSuffix(id, ".scope"))
+		}
+	}
+	if len(parents) > 0 && parents[len(parents)-1] == "docker" && isHex(elem) {
+		return "docker:" + short(elem)
+	}
+	return ""
+}
+
+// short truncates a container ID to shortID characters.
+func short(id string) string {
+	if len(id) > shortID {
+		return id[:shortID]
+	}
+	return id
+}
+
+// isHex reports whether s is a non-empty lower-case hex string.
+func isHex(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,17 @@
+//go:build linux
+
+package cgroup
+
+import (
+	"fmt"
+	"os"
+)
+
+// Lookup reads the cgroup of pid from /proc.
+func Lookup(pid int32) (Info, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil {
+		return Info{}, err
+	}
+	return Parse(string(data)), nil
+}
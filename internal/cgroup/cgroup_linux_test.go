@@ -0,0 +1,24 @@
+//go:build linux
+
+package cgroup
+
+import (
+	"os"
+	"testing"
+)
+
+func TestLookup_Self(t *testing.T) {
+	info, err := Lookup(int32(os.Getpid()))
+	if err != nil {
+		t.Fatalf("Lookup(self) error: %v", err)
+	}
+	if info.Unit == "" {
+		t.Errorf("Lookup(self) = %+v, want a unit", info)
+	}
+}
+
+func TestLookup_MissingPID(t *testing.T) {
+	if _, err := Lookup(-1); err == nil {
+		t.Error("expected error for nonexistent PID")
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package cgroup
+
+// Lookup is unsupported: cgroups are Linux only.
+func Lookup(pid int32) (Info, error) {
+	return Info{}, ErrUnsupported
+}
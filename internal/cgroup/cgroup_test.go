@@ -0,0 +1,31 @@
+package cgroup
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	for _, tt := range []struct {
+		name, data string
+		want       Info
+	}{
+		{"v2 service", "0::/system.slice/nginx.service\n",
+			Info{Path: "/system.slice/nginx.service", Unit: "nginx.service"}},
+		{"v2 user service", "0::/user.slice/user-1000.slice/user@1000.service/app.slice/syncthing.service\n",
+			Info{Path: "/user.slice/user-1000.slice/user@1000.service/app.slice/syncthing.service", Unit: "syncthing.service"}},
+		{"v2 login session", "0::/user.slice/user-1000.slice/session-3.scope\n",
+			Info{Path: "/user.slice/user-1000.slice/session-3.scope", Unit: "session-3.scope"}},
+		{"v2 docker scope", "0::/system.slice/docker-3f2a9c1b7d40e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b0c1d2e3f4a5b6c7d8e9f0.scope\n",
+			Info{Path: "/system.slice/docker-3f2a9c1b7d40e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b0c1d2e3f4a5b6c7d8e9f0.scope", Unit: "docker:3f2a9c1b7d40"}},
+		{"kubernetes pod", "0::/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod1234.slice/cri-containerd-abcdef0123456789.scope\n",
+			Info{Path: "/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod1234.slice/cri-containerd-abcdef0123456789.scope", Unit: "containerd:abcdef012345"}},
+		{"v1 cgroupfs docker", "12:pids:/docker/0123456789abcdef0123\n1:name=systemd:/docker/0123456789abcdef0123\n",
+			Info{Path: "/docker/0123456789abcdef0123", Unit: "docker:0123456789ab"}},
+		{"v1 prefers name=systemd", "4:memory:/\n1:name=systemd:/system.slice/sshd.service\n",
+			Info{Path: "/system.slice/sshd.service", Unit: "sshd.service"}},
+		{"root", "0::/\n", Info{Path: "/", Unit: "/"}},
+		{"init.scope", "0::/init.scope\n", Info{Path: "/init.scope", Unit: "init.scope"}},
+	} {
+		if got := Parse(tt.data); got != tt.want {
+			t.Errorf("%s: Parse = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -13,6 +13,19 @@ type Collector interface {
 	Collect(ctx context.Context) (*model.NetworkSnapshot, error)
 }
 
+// Options tunes how a Collector groups connections into applications.
+type Options struct {
+	GroupByExe   bool // Group by executable path instead of process name
+	HideLoopback bool // Drop connections whose remote end is a loopback address
+	TCPStats     bool // Attach RTT/retransmit stats to TCP connections (Linux)
+	SampleCap    int  // Keep a sample of about this many connections above it (0 = keep all)
+}
+
+// Configurable is implemented by collectors whose Options can change at runtime.
+type Configurable interface {
+	SetOptions(opts Options)
+}
+
 // NetIOCollector is the interface for collecting network I/O statistics.
 // Implementations are platform-specific.
 type NetIOCollector interface {
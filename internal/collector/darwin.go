@@ -23,6 +23,9 @@ type processInfo struct {
 type darwinCollector struct {
 	processCache map[int32]processInfo
 	cacheMu      sync.RWMutex
+
+	opts   Options
+	optsMu sync.RWMutex
 }
 
 func newPlatformCollector() Collector {
@@ -31,7 +34,18 @@ func newPlatformCollector() Collector {
 	}
 }
 
+// SetOptions updates the grouping options used by subsequent Collect calls.
+func (c *darwinCollector) SetOptions(opts Options) {
+	c.optsMu.Lock()
+	c.opts = opts
+	c.optsMu.Unlock()
+}
+
 func (c *darwinCollector) Collect(ctx context.Context) (*model.NetworkSnapshot, error) {
+	c.optsMu.RLock()
+	opts := c.opts
+	c.optsMu.RUnlock()
+
 	// Clear process cache at the start of each cycle to prevent stale entries
 	// when PIDs are reused by different processes
 	c.cacheMu.Lock()
@@ -44,9 +58,15 @@ func (c *darwinCollector) Collect(ctx context.Context) (*model.NetworkSnapshot,
 		return nil, fmt.Errorf("failed to get connections: %w", err)
 	}
 
-	// Group connections by process name
+	// Decide the sample while enumerating, so dropped connections are never built
+	candidates, listeners := sampleCandidates(connections, opts.HideLoopback)
+	sample := newSampler(candidates, listeners, opts.SampleCap)
+
+	// Group connections by process name (or executable path when GroupByExe is set)
 	appMap := make(map[string]*model.Application)
 	skippedCount := 0
+	hiddenCount := 0
+	loopbackCount := 0
 
 	for _, conn := range connections {
 		// Check for context cancellation
@@ -54,8 +74,18 @@ func (c *darwinCollector) Collect(ctx context.Context) (*model.NetworkSnapshot,
 			return nil, err
 		}
 
-		// Skip connections without PID (kernel/system)
+		// Skip connections without PID: kernel sockets, or other users'
+		// sockets when unprivileged. TIME_WAIT never has an owner.
 		if conn.Pid == 0 {
+			if conn.Status != "TIME_WAIT" {
+				hiddenCount++
+			}
+			continue
+		}
+
+		// Local-only chatter (IDEs, daemons) when hidden by the user
+		if opts.HideLoopback && isLoopback(conn.Raddr.IP) {
+			loopbackCount++
 			continue
 		}
 
@@ -67,13 +97,14 @@ func (c *darwinCollector) Collect(ctx context.Context) (*model.NetworkSnapshot,
 		}
 
 		// Create or get application entry
-		app, exists := appMap[info.name]
+		key := groupKey(info.name, info.exe, opts.GroupByExe)
+		app, exists := appMap[key]
 		if !exists {
 			app = &model.Application{
 				Name: info.name,
 				Exe:  info.exe,
 			}
-			appMap[info.name] = app
+			appMap[key] = app
 		}
 
 		// Add PID if not already present
@@ -88,33 +119,33 @@ func (c *darwinCollector) Collect(ctx context.Context) (*model.NetworkSnapshot,
 			LocalAddr:  formatAddr(conn.Laddr.IP, conn.Laddr.Port),
 			RemoteAddr: c.formatRemoteAddr(conn),
 			State:      c.getState(conn),
+			FD:         conn.Fd,
+		}
+		if sample.keep(app, mc) {
+			app.Connections = append(app.Connections, mc)
 		}
-		app.Connections = append(app.Connections, mc)
 	}
 
-	// Convert map to slice, calculate state counts, and sort PIDs
+	// Convert map to slice and sort PIDs (state counts were kept by the sampler)
 	apps := make([]model.Application, 0, len(appMap))
 	for _, app := range appMap {
 		sort.Slice(app.PIDs, func(i, j int) bool {
 			return app.PIDs[i] < app.PIDs[j]
 		})
-		// Calculate connection state counts
-		for _, conn := range app.Connections {
-			switch conn.State {
-			case model.StateEstablished:
-				app.EstablishedCount++
-			case model.StateListen:
-				app.ListenCount++
-			}
-		}
 		apps = append(apps, *app)
 	}
+	if opts.GroupByExe {
+		disambiguateNames(apps)
+	}
 
 	snapshot := &model.NetworkSnapshot{
-		Applications: apps,
-		Timestamp:    time.Now(),
-		SkippedCount: skippedCount,
+		Applications:  apps,
+		Timestamp:     time.Now(),
+		SkippedCount:  skippedCount,
+		HiddenCount:   hiddenCount,
+		LoopbackCount: loopbackCount,
 	}
+	sample.finish(snapshot)
 	snapshot.SortByConnectionCount()
 
 	return snapshot, nil
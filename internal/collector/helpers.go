@@ -1,6 +1,14 @@
 package collector
 
-import "fmt"
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"path/filepath"
+	"strings"
+
+	"github.com/kostyay/netmon/internal/model"
+)
 
 // formatAddr formats an IP address and port as "ip:port".
 func formatAddr(ip string, port uint32) string {
@@ -10,6 +18,28 @@ func formatAddr(ip string, port uint32) string {
 	return fmt.Sprintf("%s:%d", ip, port)
 }
 
+// tcpStatsKey identifies a TCP socket by its endpoints for joining netlink
+// stats onto gopsutil connections. IPv4-mapped IPv6 addresses are unmapped so
+// both sources agree.
+func tcpStatsKey(localIP string, localPort uint32, remoteIP string, remotePort uint32) string {
+	return fmt.Sprintf("%s:%d|%s:%d", canonicalIP(localIP), localPort, canonicalIP(remoteIP), remotePort)
+}
+
+// canonicalIP returns ip in its canonical form (unmapped, compressed), or ip unchanged if unparsable.
+func canonicalIP(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ip
+	}
+	return addr.Unmap().String()
+}
+
+// isLoopback reports whether ip is a loopback address (127.0.0.0/8 or ::1).
+func isLoopback(ip string) bool {
+	parsed := net.ParseIP(ip)
+	return parsed != nil && parsed.IsLoopback()
+}
+
 // containsPID checks if a PID is in the slice.
 func containsPID(pids []int32, pid int32) bool {
 	for _, p := range pids {
@@ -19,3 +49,88 @@ func containsPID(pids []int32, pid int32) bool {
 	}
 	return false
 }
+
+// groupKey returns the key used to group a process's connections into an application.
+// Falls back to the process name when grouping by executable but the path is unknown.
+func groupKey(name, exe string, byExe bool) string {
+	if byExe && exe != "" {
+		return exe
+	}
+	return name
+}
+
+// disambiguateNames renames applications that share a process name but run different
+// executables, appending the directory segment that tells them apart
+// (e.g. "python3 (proj-a)" and "python3 (proj-b)").
+func disambiguateNames(apps []model.Application) {
+	byName := make(map[string][]int)
+	for i, app := range apps {
+		byName[app.Name] = append(byName[app.Name], i)
+	}
+	for name, idxs := range byName {
+		if len(idxs) < 2 {
+			continue
+		}
+		exes := make([]string, len(idxs))
+		for j, idx := range idxs {
+			exes[j] = apps[idx].Exe
+		}
+		segments := distinguishingSegments(exes)
+		for j, idx := range idxs {
+			apps[idx].Name = fmt.Sprintf("%s (%s)", name, segments[j])
+		}
+	}
+}
+
+// distinguishingSegments returns, for each path, the deepest directory component
+// that differs between the paths. Falls back to the full path when no single
+// directory component differs (e.g. different nesting depths).
+func distinguishingSegments(paths []string) []string {
+	dirs := make([][]string, len(paths))
+	for i, p := range paths {
+		if p == "" {
+			continue // unknown exe has no directory components
+		}
+		dir := filepath.Dir(p)
+		dirs[i] = strings.Split(strings.Trim(dir, string(filepath.Separator)), string(filepath.Separator))
+	}
+
+	result := make([]string, len(paths))
+	for depth := 1; ; depth++ {
+		var seen []string
+		ok := true
+		for _, d := range dirs {
+			if len(d) < depth {
+				ok = false
+				break
+			}
+			seen = append(seen, d[len(d)-depth])
+		}
+		if !ok {
+			break
+		}
+		if !allEqual(seen) {
+			copy(result, seen)
+			return result
+		}
+	}
+
+	for i, p := range paths {
+		if p == "" {
+			result[i] = "?"
+		} else {
+			result[i] = p
+		}
+	}
+	return result
+}
+
+// allEqual reports whether all strings in the slice are identical.
+func allEqual(ss []string) bool {
+	for _, s := range ss[1:] {
+		if s != ss[0] {
+			return false
+		}
+	}
+	return true
+}
@@ -2,6 +2,8 @@ package collector
 
 import (
 	"testing"
+
+	"github.com/kostyay/netmon/internal/model"
 )
 
 func TestFormatAddr_WithIP(t *testing.T) {
@@ -77,3 +79,82 @@ func TestNew(t *testing.T) {
 		t.Error("New() returned nil")
 	}
 }
+
+func TestGroupKey(t *testing.T) {
+	tests := []struct {
+		name, exe string
+		byExe     bool
+		want      string
+	}{
+		{"python3", "/a/bin/python3", false, "python3"},
+		{"python3", "/a/bin/python3", true, "/a/bin/python3"},
+		{"python3", "", true, "python3"},
+	}
+	for _, tt := range tests {
+		if got := groupKey(tt.name, tt.exe, tt.byExe); got != tt.want {
+			t.Errorf("groupKey(%q, %q, %v) = %q, want %q", tt.name, tt.exe, tt.byExe, got, tt.want)
+		}
+	}
+}
+
+func TestDisambiguateNames_Collision(t *testing.T) {
+	apps := []model.Application{
+		{Name: "python3", Exe: "/home/u/proj-a/.venv/bin/python3"},
+		{Name: "python3", Exe: "/home/u/proj-b/.venv/bin/python3"},
+		{Name: "nginx", Exe: "/usr/sbin/nginx"},
+	}
+	disambiguateNames(apps)
+
+	if apps[0].Name != "python3 (proj-a)" {
+		t.Errorf("apps[0].Name = %q, want %q", apps[0].Name, "python3 (proj-a)")
+	}
+	if apps[1].Name != "python3 (proj-b)" {
+		t.Errorf("apps[1].Name = %q, want %q", apps[1].Name, "python3 (proj-b)")
+	}
+	if apps[2].Name != "nginx" {
+		t.Errorf("apps[2].Name = %q, want unchanged", apps[2].Name)
+	}
+}
+
+func TestDistinguishingSegments_DifferentDepths(t *testing.T) {
+	got := distinguishingSegments([]string{"/opt/bin/tool", "/bin/tool"})
+	// Deepest component "bin" is shared, next level differs in existence only
+	if got[0] != "/opt/bin/tool" || got[1] != "/bin/tool" {
+		t.Errorf("distinguishingSegments() = %v, want full paths", got)
+	}
+}
+
+func TestDistinguishingSegments_UnknownExe(t *testing.T) {
+	got := distinguishingSegments([]string{"", "/usr/bin/app"})
+	if got[0] != "?" {
+		t.Errorf("distinguishingSegments()[0] = %q, want %q", got[0], "?")
+	}
+}
+
+func TestIsLoopback(t *testing.T) {
+	tests := map[string]bool{
+		"127.0.0.1":   true,
+		"127.10.0.5":  true,
+		"::1":         true,
+		"10.0.0.1":    false,
+		"::":          false,
+		"":            false,
+		"not-an-addr": false,
+	}
+	for ip, want := range tests {
+		if got := isLoopback(ip); got != want {
+			t.Errorf("isLoopback(%q) = %v, want %v", ip, got, want)
+		}
+	}
+}
+
+func TestTCPStatsKey_UnmapsIPv4(t *testing.T) {
+	mapped := tcpStatsKey("::ffff:10.0.0.1", 5000, "::ffff:1.1.1.1", 443)
+	plain := tcpStatsKey("10.0.0.1", 5000, "1.1.1.1", 443)
+	if mapped != plain {
+		t.Errorf("tcpStatsKey mapped = %q, plain = %q; want equal", mapped, plain)
+	}
+	if got := tcpStatsKey("0:0::1", 22, "", 0); got != "::1:22|:0" {
+		t.Errorf("tcpStatsKey = %q, want canonical IPv6 and empty remote kept", got)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/kostyay/netmon/internal/model"
+	"github.com/kostyay/netmon/internal/netlink"
 	"github.com/shirou/gopsutil/v3/net"
 	"github.com/shirou/gopsutil/v3/process"
 )
@@ -23,6 +24,9 @@ type processInfo struct {
 type linuxCollector struct {
 	processCache map[int32]processInfo
 	cacheMu      sync.RWMutex
+
+	opts   Options
+	optsMu sync.RWMutex
 }
 
 func newPlatformCollector() Collector {
@@ -31,7 +35,18 @@ func newPlatformCollector() Collector {
 	}
 }
 
+// SetOptions updates the grouping options used by subsequent Collect calls.
+func (c *linuxCollector) SetOptions(opts Options) {
+	c.optsMu.Lock()
+	c.opts = opts
+	c.optsMu.Unlock()
+}
+
 func (c *linuxCollector) Collect(ctx context.Context) (*model.NetworkSnapshot, error) {
+	c.optsMu.RLock()
+	opts := c.opts
+	c.optsMu.RUnlock()
+
 	c.cacheMu.Lock()
 	c.processCache = make(map[int32]processInfo)
 	c.cacheMu.Unlock()
@@ -41,35 +56,73 @@ func (c *linuxCollector) Collect(ctx context.Context) (*model.NetworkSnapshot, e
 		return nil, fmt.Errorf("failed to get connections: %w", err)
 	}
 
+	var tcpStats map[string]*model.TCPStats
+	if opts.TCPStats {
+		tcpStats = collectTCPStats()
+	}
+	acceptQueues := collectAcceptQueues()
+	rawSockets, owners := listRawSockets()
+
+	// Decide the sample while enumerating, so dropped connections are never built
+	candidates, listeners := sampleCandidates(connections, opts.HideLoopback)
+	for _, rs := range rawSockets {
+		if owners[rs.inode].pid != 0 && !(opts.HideLoopback && isLoopback(rs.remoteIP)) {
+			candidates++
+		}
+	}
+	sample := newSampler(candidates, listeners, opts.SampleCap)
+
 	appMap := make(map[string]*model.Application)
 	skippedCount := 0
+	hiddenCount := 0
+	loopbackCount := 0
+
+	// appFor returns the application pid belongs to, creating it on first use,
+	// or nil if the process name is unknown.
+	appFor := func(pid int32) *model.Application {
+		info := c.getProcessInfo(ctx, pid)
+		if info.name == "" {
+			return nil
+		}
+		key := groupKey(info.name, info.exe, opts.GroupByExe)
+		app, exists := appMap[key]
+		if !exists {
+			app = &model.Application{
+				Name: info.name,
+				Exe:  info.exe,
+			}
+			appMap[key] = app
+		}
+		if !containsPID(app.PIDs, pid) {
+			app.PIDs = append(app.PIDs, pid)
+		}
+		return app
+	}
 
 	for _, conn := range connections {
 		if err := ctx.Err(); err != nil {
 			return nil, err
 		}
 
+		// Skip connections without PID: kernel sockets, or other users'
+		// sockets when unprivileged. TIME_WAIT never has an owner.
 		if conn.Pid == 0 {
+			if conn.Status != "TIME_WAIT" {
+				hiddenCount++
+			}
 			continue
 		}
 
-		info := c.getProcessInfo(ctx, conn.Pid)
-		if info.name == "" {
-			skippedCount++
+		// Local-only chatter (IDEs, daemons) when hidden by the user
+		if opts.HideLoopback && isLoopback(conn.Raddr.IP) {
+			loopbackCount++
 			continue
 		}
 
-		app, exists := appMap[info.name]
-		if !exists {
-			app = &model.Application{
-				Name: info.name,
-				Exe:  info.exe,
-			}
-			appMap[info.name] = app
-		}
-
-		if !containsPID(app.PIDs, conn.Pid) {
-			app.PIDs = append(app.PIDs, conn.Pid)
+		app := appFor(conn.Pid)
+		if app == nil {
+			skippedCount++
+			continue
 		}
 
 		mc := model.Connection{
@@ -78,31 +131,69 @@ func (c *linuxCollector) Collect(ctx context.Context) (*model.NetworkSnapshot, e
 			LocalAddr:  formatAddr(conn.Laddr.IP, conn.Laddr.Port),
 			RemoteAddr: c.formatRemoteAddr(conn),
 			State:      c.getState(conn),
+			FD:         conn.Fd,
+		}
+		if !sample.keep(app, mc) {
+			continue
+		}
+		if tcpStats != nil && mc.Protocol == model.ProtocolTCP {
+			mc.TCP = tcpStats[tcpStatsKey(conn.Laddr.IP, conn.Laddr.Port, conn.Raddr.IP, conn.Raddr.Port)]
+		}
+		if mc.Protocol == model.ProtocolTCP && mc.State == model.StateListen {
+			mc.Accept = acceptQueues[tcpStatsKey(conn.Laddr.IP, conn.Laddr.Port, "", 0)]
 		}
 		app.Connections = append(app.Connections, mc)
 	}
 
+	// Raw and ICMP sockets (ping, traceroute, monitoring agents)
+	for _, rs := range rawSockets {
+		owner := owners[rs.inode]
+		pid := owner.pid
+		if pid == 0 {
+			hiddenCount++
+			continue
+		}
+		if opts.HideLoopback && isLoopback(rs.remoteIP) {
+			loopbackCount++
+			continue
+		}
+		app := appFor(pid)
+		if app == nil {
+			skippedCount++
+			continue
+		}
+		mc := model.Connection{
+			PID:        pid,
+			Protocol:   rs.protocol,
+			LocalAddr:  rs.localAddr,
+			RemoteAddr: rs.remoteAddr,
+			State:      model.StateNone,
+			FD:         owner.fd,
+		}
+		if sample.keep(app, mc) {
+			app.Connections = append(app.Connections, mc)
+		}
+	}
+
 	apps := make([]model.Application, 0, len(appMap))
 	for _, app := range appMap {
 		sort.Slice(app.PIDs, func(i, j int) bool {
 			return app.PIDs[i] < app.PIDs[j]
 		})
-		for _, conn := range app.Connections {
-			switch conn.State {
-			case model.StateEstablished:
-				app.EstablishedCount++
-			case model.StateListen:
-				app.ListenCount++
-			}
-		}
 		apps = append(apps, *app)
 	}
+	if opts.GroupByExe {
+		disambiguateNames(apps)
+	}
 
 	snapshot := &model.NetworkSnapshot{
-		Applications: apps,
-		Timestamp:    time.Now(),
-		SkippedCount: skippedCount,
+		Applications:  apps,
+		Timestamp:     time.Now(),
+		SkippedCount:  skippedCount,
+		HiddenCount:   hiddenCount,
+		LoopbackCount: loopbackCount,
 	}
+	sample.finish(snapshot)
 	snapshot.SortByConnectionCount()
 
 	return snapshot, nil
@@ -161,3 +252,43 @@ func (c *linuxCollector) getState(conn net.ConnectionStat) model.ConnectionState
 	}
 	return model.ConnectionState(conn.Status)
 }
+
+// listTCPInfo dumps kernel TCP stats. Replaced in tests.
+var listTCPInfo = netlink.ListTCPInfo
+
+// collectTCPStats returns TCP stats keyed by tcpStatsKey, or nil if the dump
+// fails (stats are optional; connections are still listed without them).
+func collectTCPStats() map[string]*model.TCPStats {
+	sockets, err := listTCPInfo()
+	if err != nil {
+		return nil
+	}
+	stats := make(map[string]*model.TCPStats, len(sockets))
+	for _, s := range sockets {
+		if s.TCP == nil {
+			continue
+		}
+		key := tcpStatsKey(s.ID.Local.Addr().String(), uint32(s.ID.Local.Port()), s.ID.Remote.Addr().String(), uint32(s.ID.Remote.Port()))
+		stats[key] = &model.TCPStats{RTT: s.TCP.RTT, RTTVar: s.TCP.RTTVar, Retrans: s.TCP.TotalRetrans}
+	}
+	return stats
+}
+
+// listListeners dumps TCP LISTEN sockets. Replaced in tests.
+var listListeners = netlink.ListListeners
+
+// collectAcceptQueues returns the accept queue of each listening TCP socket,
+// keyed by tcpStatsKey of its local address with an empty remote, or nil if the
+// dump fails.
+func collectAcceptQueues() map[string]*model.AcceptQueue {
+	sockets, err := listListeners()
+	if err != nil {
+		return nil
+	}
+	queues := make(map[string]*model.AcceptQueue, len(sockets))
+	for _, s := range sockets {
+		key := tcpStatsKey(s.ID.Local.Addr().String(), uint32(s.ID.Local.Port()), "", 0)
+		queues[key] = &model.AcceptQueue{Queued: s.RecvQ, Backlog: s.SendQ}
+	}
+	return queues
+}
@@ -3,7 +3,14 @@
 package collector
 
 import (
+	"context"
+	"net"
+	"net/netip"
+	"os"
 	"testing"
+	"time"
+
+	"github.com/kostyay/netmon/internal/netlink"
 )
 
 func TestLinuxCollector_GetProtocol_TCP(t *testing.T) {
@@ -53,3 +60,88 @@ func TestNewPlatformCollector_Linux(t *testing.T) {
 		t.Error("linuxCollector.processCache is nil")
 	}
 }
+
+func TestCollectTCPStats(t *testing.T) {
+	orig := listTCPInfo
+	t.Cleanup(func() { listTCPInfo = orig })
+
+	listTCPInfo = func() ([]netlink.Socket, error) {
+		return []netlink.Socket{
+			{
+				ID:  netlink.SocketID{Local: netip.MustParseAddrPort("10.0.0.1:5000"), Remote: netip.MustParseAddrPort("1.1.1.1:443")},
+				TCP: &netlink.TCPInfo{RTT: 12 * time.Millisecond, RTTVar: 3 * time.Millisecond, TotalRetrans: 4},
+			},
+			{ID: netlink.SocketID{Local: netip.MustParseAddrPort("10.0.0.1:5001"), Remote: netip.MustParseAddrPort("1.1.1.1:443")}},
+		}, nil
+	}
+	stats := collectTCPStats()
+	got := stats[tcpStatsKey("10.0.0.1", 5000, "1.1.1.1", 443)]
+	if got == nil || got.RTT != 12*time.Millisecond || got.Retrans != 4 {
+		t.Errorf("stats = %+v, want RTT 12ms, 4 retrans", got)
+	}
+	if len(stats) != 1 {
+		t.Errorf("sockets without tcp_info should be skipped, got %d entries", len(stats))
+	}
+
+	listTCPInfo = func() ([]netlink.Socket, error) { return nil, netlink.ErrUnsupported }
+	if collectTCPStats() != nil {
+		t.Error("dump failure should yield nil stats")
+	}
+}
+
+func TestCollectAcceptQueues(t *testing.T) {
+	orig := listListeners
+	t.Cleanup(func() { listListeners = orig })
+
+	listListeners = func() ([]netlink.Socket, error) {
+		return []netlink.Socket{
+			{ID: netlink.SocketID{Local: netip.MustParseAddrPort("0.0.0.0:8080"), Remote: netip.MustParseAddrPort("0.0.0.0:0")}, RecvQ: 3, SendQ: 128},
+			{ID: netlink.SocketID{Local: netip.MustParseAddrPort("[::]:443"), Remote: netip.MustParseAddrPort("[::]:0")}, RecvQ: 0, SendQ: 4096},
+		}, nil
+	}
+	queues := collectAcceptQueues()
+	if got := queues[tcpStatsKey("0.0.0.0", 8080, "", 0)]; got == nil || got.Queued != 3 || got.Backlog != 128 {
+		t.Errorf("8080 queue = %+v, want 3/128", got)
+	}
+	if got := queues[tcpStatsKey("::", 443, "", 0)]; got == nil || got.Backlog != 4096 {
+		t.Errorf("443 queue = %+v, want backlog 4096", got)
+	}
+
+	listListeners = func() ([]netlink.Socket, error) { return nil, netlink.ErrUnsupported }
+	if collectAcceptQueues() != nil {
+		t.Error("dump failure should yield nil queues")
+	}
+}
+
+func TestLinuxCollector_RecordsSocketFD(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	raw, err := ln.(*net.TCPListener).SyscallConn()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wantFD uint32
+	if err := raw.Control(func(fd uintptr) { wantFD = uint32(fd) }); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot, err := newPlatformCollector().Collect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	for _, app := range snapshot.Applications {
+		for _, conn := range app.Connections {
+			if conn.PID == int32(os.Getpid()) && conn.LocalAddr == addr {
+				if conn.FD != wantFD {
+					t.Errorf("listener FD = %d, want %d", conn.FD, wantFD)
+				}
+				return
+			}
+		}
+	}
+	t.Skipf("listener %s not visible to the collector", addr)
+}
@@ -0,0 +1,190 @@
+//go:build linux
+
+package collector
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// procRoot is the procfs mount point. Replaced in tests.
+var procRoot = "/proc"
+
+// rawSocket is a raw IP or ICMP ("ping") socket read from /proc/net, which
+// gopsutil does not enumerate.
+type rawSocket struct {
+	protocol   model.Protocol
+	localAddr  string
+	remoteAddr string
+	remoteIP   string // for loopback filtering
+	inode      uint64
+}
+
+// rawTables lists the /proc/net tables holding raw and ping sockets.
+// In raw tables the "port" column is the IP protocol number; in ping tables
+// it is the ICMP echo identifier.
+var rawTables = []struct {
+	name string
+	v6   bool
+	ping bool
+}{
+	{"raw", false, false},
+	{"raw6", true, false},
+	{"icmp", false, true},
+	{"icmp6", true, true},
+}
+
+// IP protocol numbers shown as ICMP when opened as raw sockets.
+const (
+	ipProtoICMP   = 1
+	ipProtoICMPv6 = 58
+)
+
+// socketOwner is the process and file descriptor holding a socket.
+type socketOwner struct {
+	pid int32
+	fd  uint32
+}
+
+// listRawSockets returns raw and ping sockets with their owners (missing when
+// the owner is not visible, e.g. another user's process without root).
+// Missing tables (IPv6 disabled, old kernels) are skipped.
+func listRawSockets() ([]rawSocket, map[uint64]socketOwner) {
+	var sockets []rawSocket
+	for _, t := range rawTables {
+		f, err := os.Open(filepath.Join(procRoot, "net", t.name))
+		if err != nil {
+			continue
+		}
+		parsed, _ := parseRawTable(f, t.v6, t.ping)
+		f.Close()
+		sockets = append(sockets, parsed...)
+	}
+	if len(sockets) == 0 {
+		return nil, nil
+	}
+	inodes := make(map[uint64]bool, len(sockets))
+	for _, s := range sockets {
+		inodes[s.inode] = true
+	}
+	return sockets, socketOwners(inodes)
+}
+
+// parseRawTable parses a /proc/net/{raw,icmp}[6] table. Lines that do not
+// parse are skipped.
+func parseRawTable(r io.Reader, v6, ping bool) ([]rawSocket, error) {
+	var sockets []rawSocket
+	scanner := bufio.NewScanner(r)
+	scanner.Scan() // header
+	for scanner.Scan() {
+		// sl local_address rem_address st tx_queue:rx_queue tr:tm->when retrnsmt uid timeout inode ...
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 {
+			continue
+		}
+		localIP, localPort, err := parseHexAddr(fields[1], v6)
+		if err != nil {
+			continue
+		}
+		remoteIP, remotePort, err := parseHexAddr(fields[2], v6)
+		if err != nil {
+			continue
+		}
+		inode, err := strconv.ParseUint(fields[9], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		protocol := model.ProtocolICMP
+		if !ping && localPort != ipProtoICMP && localPort != ipProtoICMPv6 {
+			protocol = model.ProtocolRaw
+		}
+		remote := "*"
+		if !remoteIP.IsUnspecified() {
+			remote = formatAddr(remoteIP.String(), remotePort)
+		}
+		sockets = append(sockets, rawSocket{
+			protocol:   protocol,
+			localAddr:  formatAddr(localIP.String(), localPort),
+			remoteAddr: remote,
+			remoteIP:   remoteIP.String(),
+			inode:      inode,
+		})
+	}
+	return sockets, scanner.Err()
+}
+
+// parseHexAddr parses a /proc/net "IP:PORT" pair. The IP is hex in host byte
+// order per 32-bit word (little-endian on all supported platforms).
+func parseHexAddr(s string, v6 bool) (net.IP, uint32, error) {
+	ipHex, portHex, ok := strings.Cut(s, ":")
+	if !ok {
+		return nil, 0, fmt.Errorf("malformed address %q", s)
+	}
+	raw, err := hex.DecodeString(ipHex)
+	if err != nil {
+		return nil, 0, err
+	}
+	if (v6 && len(raw) != net.IPv6len) || (!v6 && len(raw) != net.IPv4len) {
+		return nil, 0, fmt.Errorf("malformed address %q", s)
+	}
+	ip := make(net.IP, len(raw))
+	for i := 0; i < len(raw); i += 4 {
+		binary.BigEndian.PutUint32(ip[i:], binary.LittleEndian.Uint32(raw[i:]))
+	}
+	port, err := strconv.ParseUint(portHex, 16, 16)
+	if err != nil {
+		return nil, 0, err
+	}
+	return ip, uint32(port), nil
+}
+
+// socketOwners maps socket inodes to the PID and fd holding them by scanning
+// /proc/<pid>/fd. Unreadable processes are skipped; the scan stops once every
+// inode is found.
+func socketOwners(inodes map[uint64]bool) map[uint64]socketOwner {
+	owners := make(map[uint64]socketOwner, len(inodes))
+	entries, err := os.ReadDir(procRoot)
+	if err != nil {
+		return owners
+	}
+	for _, e := range entries {
+		pid, err := strconv.ParseInt(e.Name(), 10, 32)
+		if err != nil {
+			continue
+		}
+		fdDir := filepath.Join(procRoot, e.Name(), "fd")
+		fds, err := os.ReadDir(fdDir)
+		if err != nil {
+			continue
+		}
+		for _, fd := range fds {
+			link, err := os.Readlink(filepath.Join(fdDir, fd.Name()))
+			if err != nil || !strings.HasPrefix(link, "socket:[") {
+				continue
+			}
+			inode, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(link, "socket:["), "]"), 10, 64)
+			if err != nil || !inodes[inode] {
+				continue
+			}
+			if _, seen := owners[inode]; !seen {
+				fdNum, _ := strconv.ParseUint(fd.Name(), 10, 32)
+				owners[inode] = socketOwner{pid: int32(pid), fd: uint32(fdNum)}
+			}
+		}
+		if len(owners) == len(inodes) {
+			break
+		}
+	}
+	return owners
+}
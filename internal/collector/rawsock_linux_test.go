@@ -0,0 +1,130 @@
+//go:build linux
+
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+const rawHeader = "  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode ref pointer drops\n"
+
+func TestParseHexAddr(t *testing.T) {
+	tests := []struct {
+		in   string
+		v6   bool
+		ip   string
+		port uint32
+	}{
+		{"0100007F:0001", false, "127.0.0.1", 1},
+		{"00000000:003A", false, "0.0.0.0", 58},
+		{"0000000000000000FFFF00000100007F:1F90", true, "127.0.0.1", 8080},
+		{"00000000000000000000000001000000:0000", true, "::1", 0},
+	}
+	for _, tt := range tests {
+		ip, port, err := parseHexAddr(tt.in, tt.v6)
+		if err != nil {
+			t.Errorf("parseHexAddr(%q): %v", tt.in, err)
+			continue
+		}
+		if ip.String() != tt.ip || port != tt.port {
+			t.Errorf("parseHexAddr(%q) = %s:%d, want %s:%d", tt.in, ip, port, tt.ip, tt.port)
+		}
+	}
+
+	for _, bad := range []string{"0100007F", "zz00007F:0001", "0100007F:zz", "01:0001"} {
+		if _, _, err := parseHexAddr(bad, false); err == nil {
+			t.Errorf("parseHexAddr(%q) should fail", bad)
+		}
+	}
+}
+
+func TestParseRawTable(t *testing.T) {
+	table := rawHeader +
+		"   1: 00000000:0001 00000000:0000 07 00000000:00000000 00:00000000 00000000     0        0 1111 2 0000000000000000 0\n" +
+		"   2: 00000000:0059 00000000:0000 07 00000000:00000000 00:00000000 00000000     0        0 2222 2 0000000000000000 0\n" +
+		"   3: garbage\n"
+
+	sockets, err := parseRawTable(strings.NewReader(table), false, false)
+	if err != nil {
+		t.Fatalf("parseRawTable: %v", err)
+	}
+	if len(sockets) != 2 {
+		t.Fatalf("len = %d, want 2 (malformed line skipped)", len(sockets))
+	}
+	if s := sockets[0]; s.protocol != model.ProtocolICMP || s.localAddr != "0.0.0.0:1" || s.remoteAddr != "*" || s.inode != 1111 {
+		t.Errorf("raw ICMP socket = %+v", s)
+	}
+	if s := sockets[1]; s.protocol != model.ProtocolRaw || s.localAddr != "0.0.0.0:89" {
+		t.Errorf("raw OSPF socket = %+v, want RAW with protocol 89", s)
+	}
+
+	ping := rawHeader +
+		"   5: 00000000:04D2 0202A8C0:0000 07 00000000:00000000 00:00000000 00000000  1000        0 3333 2 0000000000000000 0\n"
+	sockets, _ = parseRawTable(strings.NewReader(ping), false, true)
+	if len(sockets) != 1 || sockets[0].protocol != model.ProtocolICMP || sockets[0].remoteAddr != "192.168.2.2:0" {
+		t.Errorf("ping socket = %+v, want ICMP to 192.168.2.2", sockets)
+	}
+}
+
+// fakeProc builds a procfs tree with the given /proc/net tables and
+// /proc/<pid>/fd symlinks to "socket:[inode]".
+func fakeProc(t *testing.T, tables map[string]string, fds map[string][]string) {
+	t.Helper()
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "net"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range tables {
+		if err := os.WriteFile(filepath.Join(root, "net", name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for pid, links := range fds {
+		dir := filepath.Join(root, pid, "fd")
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		for i, link := range links {
+			if err := os.Symlink(link, filepath.Join(dir, string(rune('0'+i)))); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	orig := procRoot
+	procRoot = root
+	t.Cleanup(func() { procRoot = orig })
+}
+
+func TestListRawSockets_ResolvesOwners(t *testing.T) {
+	fakeProc(t, map[string]string{
+		"icmp": rawHeader +
+			"   5: 00000000:04D2 08080808:0000 07 00000000:00000000 00:00000000 00000000  1000        0 3333 2 0000000000000000 0\n" +
+			"   6: 00000000:04D3 08080808:0000 07 00000000:00000000 00:00000000 00000000     0        0 4444 2 0000000000000000 0\n",
+	}, map[string][]string{
+		"42":   {"/dev/null", "socket:[9999]", "socket:[3333]"},
+		"self": {"socket:[4444]"}, // not a PID directory
+	})
+
+	sockets, owners := listRawSockets()
+	if len(sockets) != 2 {
+		t.Fatalf("len = %d, want 2", len(sockets))
+	}
+	if got := owners[3333]; got != (socketOwner{pid: 42, fd: 2}) {
+		t.Errorf("owner of 3333 = %+v, want PID 42 fd 2", got)
+	}
+	if _, ok := owners[4444]; ok {
+		t.Error("socket without a visible owner should be unmapped")
+	}
+}
+
+func TestListRawSockets_NoTables(t *testing.T) {
+	fakeProc(t, nil, map[string][]string{"1": {"socket:[1]"}})
+	if sockets, owners := listRawSockets(); sockets != nil || owners != nil {
+		t.Errorf("got %v, %v; want nothing without raw sockets", sockets, owners)
+	}
+}
@@ -0,0 +1,102 @@
+package collector
+
+import (
+	"hash/maphash"
+	"math"
+	"strconv"
+
+	"github.com/kostyay/netmon/internal/model"
+	"github.com/shirou/gopsutil/v3/net"
+)
+
+// sampleSeed keys the sampling hash. It is fixed for the life of the process,
+// so a connection that is in the sample stays in it from one refresh to the
+// next (as long as the sampling rate holds) instead of flickering in and out.
+var sampleSeed = maphash.MakeSeed()
+
+// sampler thins a snapshot to about limit connections while a collector
+// enumerates them, so the connections it drops are never built or stored.
+// Listening sockets are always kept. Every other connection is kept with
+// the same probability, which is decided by a hash of its endpoints, so the
+// sample is uniform across processes and states.
+// Every connection is counted, so the per-application counts and the
+// per-state totals in the snapshot's Sample stay exact.
+type sampler struct {
+	sampling bool
+	cutoff   uint64 // keep a connection when its hash falls below this
+	total    int
+	dropped  int
+	byState  map[model.ConnectionState]int
+}
+
+// newSampler returns a sampler for about candidates connections, listeners
+// of them listening. A limit of 0 or less, or candidates within it, keeps
+// everything.
+func newSampler(candidates, listeners, limit int) *sampler {
+	s := &sampler{byState: make(map[model.ConnectionState]int)}
+	if limit > 0 && candidates > limit {
+		budget := max(0, limit-listeners)
+		// Keep a connection when its hash falls below this fraction of the hash range.
+		s.cutoff = uint64(float64(budget) / float64(candidates-listeners) * math.MaxUint64)
+		s.sampling = true
+	}
+	return s
+}
+
+// sampleCandidates counts the connections Collect lists from conns (those
+// with an owning PID, less loopback ones when hidden) and the listening ones
+// among them, for newSampler.
+func sampleCandidates(conns []net.ConnectionStat, hideLoopback bool) (candidates, listeners int) {
+	for _, conn := range conns {
+		if conn.Pid == 0 || hideLoopback && isLoopback(conn.Raddr.IP) {
+			continue
+		}
+		candidates++
+		if conn.Status == "LISTEN" {
+			listeners++
+		}
+	}
+	return candidates, listeners
+}
+
+// keep counts conn as one of app's connections and reports whether the
+// sample keeps it; the caller appends it only then.
+func (s *sampler) keep(app *model.Application, conn model.Connection) bool {
+	s.total++
+	s.byState[conn.State]++
+	app.SampledTotal++
+	switch conn.State {
+	case model.StateEstablished:
+		app.EstablishedCount++
+	case model.StateListen:
+		app.ListenCount++
+	}
+	if !s.sampling || conn.State == model.StateListen || sampleHash(conn) < s.cutoff {
+		return true
+	}
+	s.dropped++
+	return false
+}
+
+// finish marks snap as sampled when connections were dropped. Otherwise the
+// snapshot is complete, and its applications carry no SampledTotal.
+func (s *sampler) finish(snap *model.NetworkSnapshot) {
+	if s.dropped == 0 {
+		for i := range snap.Applications {
+			snap.Applications[i].SampledTotal = 0
+		}
+		return
+	}
+	snap.Sample = &model.Sample{Total: s.total, ByState: s.byState}
+}
+
+// sampleHash hashes the fields that identify a connection.
+func sampleHash(conn model.Connection) uint64 {
+	var h maphash.Hash
+	h.SetSeed(sampleSeed)
+	h.WriteString(string(conn.Protocol))
+	h.WriteString(conn.LocalAddr)
+	h.WriteString(conn.RemoteAddr)
+	h.WriteString(strconv.Itoa(int(conn.PID)))
+	return h.Sum64()
+}
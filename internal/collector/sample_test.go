@@ -0,0 +1,115 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// busySnapshot returns a load balancer with n established connections and
+// n/4 in TIME_WAIT, plus a listener, and a small sidecar process.
+func busySnapshot(n int) *model.NetworkSnapshot {
+	lb := model.Application{Name: "haproxy", Connections: []model.Connection{
+		{PID: 1, Protocol: model.ProtocolTCP, LocalAddr: "0.0.0.0:443", RemoteAddr: "*:*", State: model.StateListen},
+	}}
+	for i := range n {
+		state := model.StateEstablished
+		if i%5 == 4 {
+			state = model.StateTimeWait
+		}
+		lb.Connections = append(lb.Connections, model.Connection{
+			PID: 1, Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.1:443",
+			RemoteAddr: fmt.Sprintf("198.51.%d.%d:%d", i/250%250, i%250, 40000+i%20000), State: state,
+		})
+	}
+	sidecar := model.Application{Name: "envoy", Connections: []model.Connection{
+		{PID: 2, Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.1:9901", RemoteAddr: "10.0.0.9:5000", State: model.StateEstablished},
+	}}
+	return &model.NetworkSnapshot{Applications: []model.Application{lb, sidecar}}
+}
+
+// sampleSnapshot runs snap's connections through a sampler the way Collect
+// does while enumerating them.
+func sampleSnapshot(snap *model.NetworkSnapshot, limit int) {
+	candidates, listeners := 0, 0
+	for _, app := range snap.Applications {
+		for _, conn := range app.Connections {
+			candidates++
+			if conn.State == model.StateListen {
+				listeners++
+			}
+		}
+	}
+	s := newSampler(candidates, listeners, limit)
+	for i := range snap.Applications {
+		app := &snap.Applications[i]
+		conns := app.Connections
+		app.Connections, app.EstablishedCount, app.ListenCount, app.SampledTotal = nil, 0, 0, 0
+		for _, conn := range conns {
+			if s.keep(app, conn) {
+				app.Connections = append(app.Connections, conn)
+			}
+		}
+	}
+	s.finish(snap)
+}
+
+func TestSampleSnapshot(t *testing.T) {
+	snap := busySnapshot(20000)
+	sampleSnapshot(snap, 2000)
+
+	if snap.Sample == nil || snap.Sample.Total != 20002 {
+		t.Fatalf("Sample = %+v, want total 20002", snap.Sample)
+	}
+	if got := snap.Sample.ByState; got[model.StateEstablished] != 16001 || got[model.StateTimeWait] != 4000 || got[model.StateListen] != 1 {
+		t.Errorf("ByState = %v, want exact counts", got)
+	}
+	if kept := snap.TotalConnections(); kept < 1800 || kept > 2200 {
+		t.Errorf("kept %d connections, want about 2000", kept)
+	}
+	if got := snap.HostConnections(); got != 20002 {
+		t.Errorf("HostConnections() = %d, want the exact total", got)
+	}
+
+	lb := snap.Applications[0]
+	if lb.ConnectionCount() != 20001 || lb.Connections[0].State != model.StateListen {
+		t.Errorf("haproxy count = %d, first = %v; want exact count and the listener kept", lb.ConnectionCount(), lb.Connections[0])
+	}
+	if lb.EstablishedCount != 16000 || lb.ListenCount != 1 {
+		t.Errorf("haproxy established/listen = %d/%d, want the exact 16000/1", lb.EstablishedCount, lb.ListenCount)
+	}
+	// The sample keeps the state mix: about one in five in TIME_WAIT.
+	timeWait := 0
+	for _, c := range lb.Connections {
+		if c.State == model.StateTimeWait {
+			timeWait++
+		}
+	}
+	if share := float64(timeWait) / float64(len(lb.Connections)); share < 0.15 || share > 0.25 {
+		t.Errorf("TIME_WAIT share of sample = %.2f, want about 0.20", share)
+	}
+}
+
+func TestSampleSnapshot_Stable(t *testing.T) {
+	a, b := busySnapshot(5000), busySnapshot(5000)
+	sampleSnapshot(a, 500)
+	sampleSnapshot(b, 500)
+	if len(a.Applications[0].Connections) != len(b.Applications[0].Connections) {
+		t.Fatal("the same connections should sample the same way")
+	}
+	for i, c := range a.Applications[0].Connections {
+		if b.Applications[0].Connections[i] != c {
+			t.Fatalf("sample differs at %d: %v vs %v", i, c, b.Applications[0].Connections[i])
+		}
+	}
+}
+
+func TestSampleSnapshot_UnderLimit(t *testing.T) {
+	snap := busySnapshot(100)
+	sampleSnapshot(snap, 1000)
+	sampleSnapshot(snap, 0)
+	if snap.Sample != nil || snap.TotalConnections() != 102 || snap.Applications[0].SampledTotal != 0 || snap.Applications[0].EstablishedCount != 80 {
+		t.Errorf("snapshot under the limit should be untouched: %d conns, %+v", snap.TotalConnections(), snap.Sample)
+	}
+}
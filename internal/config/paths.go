@@ -0,0 +1,189 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const appName = "netmon"
+
+// Paths holds the base directories netmon stores files in.
+//
+// Linux and other Unix follow the XDG Base Directory spec. macOS uses
+// ~/Library/Application Support and ~/Library/Caches unless the XDG variables
+// are set explicitly.
+type Paths struct {
+	ConfigDir string // settings.yaml, skin.yaml
+	CacheDir  string // disposable data (DNS cache)
+	StateDir  string // data worth keeping across runs (session recordings)
+}
+
+// SettingsFile returns the path to settings.yaml.
+func (p Paths) SettingsFile() string { return filepath.Join(p.ConfigDir, "settings.yaml") }
+
+// ThemeFile returns the path to the user theme (skin.yaml).
+func (p Paths) ThemeFile() string { return filepath.Join(p.ConfigDir, "skin.yaml") }
+
+// DNSCacheFile returns the path to the persisted reverse DNS cache.
+func (p Paths) DNSCacheFile() string { return filepath.Join(p.CacheDir, "dns.json") }
+
+// RecordingsDir returns the directory for session recordings.
+func (p Paths) RecordingsDir() string { return filepath.Join(p.StateDir, "recordings") }
+
+// AuditLogFile returns the default path to the audit log of kill/stop actions.
+func (p Paths) AuditLogFile() string { return filepath.Join(p.StateDir, "audit.log") }
+
+// HistoryFile returns the default path to the flow history database.
+func (p Paths) HistoryFile() string { return filepath.Join(p.StateDir, "history.db") }
+
+// AuditLogPath returns where actions are audited: the auditLog setting if set,
+// otherwise AuditLogFile.
+func AuditLogPath(s *Settings) (string, error) {
+	if s != nil && s.AuditLog != "" {
+		return s.AuditLog, nil
+	}
+	paths, err := ResolvePaths()
+	if err != nil {
+		return "", err
+	}
+	return paths.AuditLogFile(), nil
+}
+
+// ExpandHome expands a leading "~/" in a path setting to the user's home
+// directory.
+func ExpandHome(path string) string {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		return path
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, rest)
+	}
+	return path
+}
+
+// ResolvePaths returns the netmon directories for the current user and platform.
+func ResolvePaths() (Paths, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return Paths{}, err
+	}
+	return resolvePaths(runtime.GOOS, home, os.Getenv), nil
+}
+
+// resolvePaths computes the directories for goos. Absolute XDG variables always win;
+// relative ones are ignored as the spec requires.
+func resolvePaths(goos, home string, getenv func(string) string) Paths {
+	xdg := func(env, fallback string) string {
+		if dir := getenv(env); filepath.IsAbs(dir) {
+			return filepath.Join(dir, appName)
+		}
+		return filepath.Join(fallback, appName)
+	}
+
+	if goos == "darwin" {
+		support := filepath.Join(home, "Library", "Application Support")
+		return Paths{
+			ConfigDir: xdg("XDG_CONFIG_HOME", support),
+			CacheDir:  xdg("XDG_CACHE_HOME", filepath.Join(home, "Library", "Caches")),
+			StateDir:  xdg("XDG_STATE_HOME", support),
+		}
+	}
+
+	return Paths{
+		ConfigDir: xdg("XDG_CONFIG_HOME", filepath.Join(home, ".config")),
+		CacheDir:  xdg("XDG_CACHE_HOME", filepath.Join(home, ".cache")),
+		StateDir:  xdg("XDG_STATE_HOME", filepath.Join(home, ".local", "state")),
+	}
+}
+
+// Migration records a file moved from a legacy location.
+type Migration struct {
+	From string
+	To   string
+}
+
+// legacyConfigDirs returns older config directories that may still hold
+// settings.yaml or skin.yaml, most preferred first.
+func legacyConfigDirs(goos, home string, getenv func(string) string) []string {
+	dirs := []string{filepath.Join(home, "."+appName)}
+	// On macOS, hand-made ~/.config/netmon setups predate Application Support.
+	if goos == "darwin" && getenv("XDG_CONFIG_HOME") == "" {
+		dirs = append(dirs, filepath.Join(home, ".config", appName))
+	}
+	return dirs
+}
+
+// MigrateLegacy moves config files from legacy locations into the current
+// config directory. Files already present at the new location are never
+// overwritten.
+func MigrateLegacy() ([]Migration, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	paths := resolvePaths(runtime.GOOS, home, os.Getenv)
+	return migrateLegacy(paths, legacyConfigDirs(runtime.GOOS, home, os.Getenv))
+}
+
+func migrateLegacy(paths Paths, legacyDirs []string) ([]Migration, error) {
+	var moved []Migration
+	var errs []error
+	for _, target := range []string{paths.SettingsFile(), paths.ThemeFile()} {
+		if _, err := os.Stat(target); err == nil {
+			continue
+		}
+		for _, dir := range legacyDirs {
+			src := filepath.Join(dir, filepath.Base(target))
+			if src == target {
+				continue
+			}
+			if _, err := os.Stat(src); err != nil {
+				continue
+			}
+			if err := moveFile(src, target); err != nil {
+				errs = append(errs, fmt.Errorf("migrate %s: %w", src, err))
+				break
+			}
+			moved = append(moved, Migration{From: src, To: target})
+			break
+		}
+	}
+	return moved, errors.Join(errs...)
+}
+
+// moveFile renames src to dst, falling back to copy and remove across filesystems.
+func moveFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0750); err != nil {
+		return err
+	}
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+
+	// #nosec G304 - src is a fixed file name under a legacy config directory
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	return os.Remove(src)
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func env(vars map[string]string) func(string) string {
+	return func(k string) string { return vars[k] }
+}
+
+func TestResolvePaths_Linux(t *testing.T) {
+	p := resolvePaths("linux", "/home/u", env(nil))
+	want := Paths{
+		ConfigDir: "/home/u/.config/netmon",
+		CacheDir:  "/home/u/.cache/netmon",
+		StateDir:  "/home/u/.local/state/netmon",
+	}
+	if p != want {
+		t.Errorf("resolvePaths = %+v, want %+v", p, want)
+	}
+	if p.SettingsFile() != "/home/u/.config/netmon/settings.yaml" {
+		t.Errorf("SettingsFile = %s", p.SettingsFile())
+	}
+}
+
+func TestResolvePaths_XDGOverrides(t *testing.T) {
+	p := resolvePaths("linux", "/home/u", env(map[string]string{
+		"XDG_CONFIG_HOME": "/xdg/config",
+		"XDG_CACHE_HOME":  "relative/cache", // relative paths are ignored per spec
+		"XDG_STATE_HOME":  "/xdg/state",
+	}))
+	if p.ConfigDir != "/xdg/config/netmon" {
+		t.Errorf("ConfigDir = %s", p.ConfigDir)
+	}
+	if p.CacheDir != "/home/u/.cache/netmon" {
+		t.Errorf("CacheDir = %s, relative XDG_CACHE_HOME should be ignored", p.CacheDir)
+	}
+	if p.StateDir != "/xdg/state/netmon" {
+		t.Errorf("StateDir = %s", p.StateDir)
+	}
+}
+
+func TestResolvePaths_Darwin(t *testing.T) {
+	p := resolvePaths("darwin", "/Users/u", env(nil))
+	want := Paths{
+		ConfigDir: "/Users/u/Library/Application Support/netmon",
+		CacheDir:  "/Users/u/Library/Caches/netmon",
+		StateDir:  "/Users/u/Library/Application Support/netmon",
+	}
+	if p != want {
+		t.Errorf("resolvePaths = %+v, want %+v", p, want)
+	}
+
+	p = resolvePaths("darwin", "/Users/u", env(map[string]string{"XDG_CONFIG_HOME": "/Users/u/.config"}))
+	if p.ConfigDir != "/Users/u/.config/netmon" {
+		t.Errorf("ConfigDir = %s, explicit XDG_CONFIG_HOME should win on macOS", p.ConfigDir)
+	}
+}
+
+func TestLegacyConfigDirs(t *testing.T) {
+	if dirs := legacyConfigDirs("linux", "/home/u", env(nil)); len(dirs) != 1 || dirs[0] != "/home/u/.netmon" {
+		t.Errorf("linux legacy dirs = %v", dirs)
+	}
+	if dirs := legacyConfigDirs("darwin", "/Users/u", env(nil)); len(dirs) != 2 || dirs[1] != "/Users/u/.config/netmon" {
+		t.Errorf("darwin legacy dirs = %v", dirs)
+	}
+}
+
+func TestMigrateLegacy(t *testing.T) {
+	dir := t.TempDir()
+	legacy := filepath.Join(dir, "legacy")
+	paths := Paths{ConfigDir: filepath.Join(dir, "config", "netmon")}
+
+	if err := os.MkdirAll(legacy, 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(legacy, "settings.yaml"), []byte("dnsEnabled: false\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(legacy, "skin.yaml"), []byte("legacy"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	// An existing theme at the new location must not be overwritten.
+	if err := os.MkdirAll(paths.ConfigDir, 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(paths.ThemeFile(), []byte("current"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	moved, err := migrateLegacy(paths, []string{legacy})
+	if err != nil {
+		t.Fatalf("migrateLegacy: %v", err)
+	}
+	if len(moved) != 1 || moved[0].To != paths.SettingsFile() {
+		t.Fatalf("moved = %+v, want settings.yaml only", moved)
+	}
+	if data, _ := os.ReadFile(paths.SettingsFile()); string(data) != "dnsEnabled: false\n" {
+		t.Errorf("migrated settings = %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(legacy, "settings.yaml")); !os.IsNotExist(err) {
+		t.Error("legacy settings.yaml should be removed after migration")
+	}
+	if data, _ := os.ReadFile(paths.ThemeFile()); string(data) != "current" {
+		t.Errorf("theme overwritten: %q", data)
+	}
+
+	// Running again is a no-op.
+	if moved, err := migrateLegacy(paths, []string{legacy}); err != nil || len(moved) != 0 {
+		t.Errorf("second run moved %v, err %v", moved, err)
+	}
+}
+
+func TestAuditLogPath(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/xdg/state")
+	if got, err := AuditLogPath(&Settings{}); err != nil || got != "/xdg/state/netmon/audit.log" {
+		t.Errorf("default AuditLogPath = %q, %v", got, err)
+	}
+	if got, _ := AuditLogPath(&Settings{AuditLog: "/var/log/netmon-audit.log"}); got != "/var/log/netmon-audit.log" {
+		t.Errorf("AuditLogPath = %q, want the setting", got)
+	}
+}
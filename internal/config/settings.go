@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -12,8 +13,127 @@ type Settings struct {
 	DNSEnabled       bool `yaml:"dnsEnabled"`
 	ServiceNames     bool `yaml:"serviceNames"`
 	HighlightChanges bool `yaml:"highlightChanges"`
-	Animations       bool `yaml:"animations"`        // Enable UI animations (live pulse, spinners)
-	DockerContainers bool `yaml:"dockerContainers"`   // Show Docker containers as virtual rows
+	Animations       bool `yaml:"animations"`       // Enable UI animations (live pulse, spinners)
+	DockerContainers bool `yaml:"dockerContainers"` // Show Docker containers as virtual rows
+	GroupByExe       bool `yaml:"groupByExe"`       // Group processes by executable path instead of name
+	SecurityContext  bool `yaml:"securityContext"`  // Show AppArmor/SELinux/codesign context per process
+	ChurnColumns     bool `yaml:"churnColumns"`     // Show new/closed connections per second per process
+	HideLoopback     bool `yaml:"hideLoopback"`     // Drop loopback-to-loopback connections at collection time
+	InstantRefresh   bool `yaml:"instantRefresh"`   // Refresh as soon as the socket table changes (Linux)
+	EphemeralFlows   bool `yaml:"ephemeralFlows"`   // Log connections that opened and closed between refreshes (Linux)
+	TCPStats         bool `yaml:"tcpStats"`         // Show RTT/retransmit columns for TCP connections (Linux)
+	ProcessEnv       bool `yaml:"processEnv"`       // Allow showing a process's cwd and whitelisted env vars ('e')
+	ProtocolDetail   bool `yaml:"protocolDetail"`   // Show the detected application protocol (TLS, SSH, ...) per connection
+	ProcessUptime    bool `yaml:"processUptime"`    // Show how long each process has been running
+	OpenFiles        bool `yaml:"openFiles"`        // Show open file descriptors against RLIMIT_NOFILE per process (Linux)
+	Cgroups          bool `yaml:"cgroups"`          // Show the systemd unit or container owning each process (Linux)
+	Interfaces       bool `yaml:"interfaces"`       // Show the network interface each connection goes through
+	WrapNavigation   bool `yaml:"wrapNavigation"`   // Up/down wrap around at the ends of a list
+	RememberPins     bool `yaml:"rememberPins"`     // Keep pinned processes across sessions
+	GroupDomains     bool `yaml:"groupDomains"`     // Group the remote hosts panel by registrable domain (needs DNS)
+
+	// ChangeStyle is how highlighted changes are shown: "flash" (default),
+	// "fade", "gutter" (+/-/~ markers) or "count" (header totals only).
+	ChangeStyle string `yaml:"changeStyle,omitempty"`
+
+	// Locale selects the UI language, e.g. "de". Empty means from the
+	// environment (LC_ALL, LC_MESSAGES, LANG); unknown locales use English.
+	Locale string `yaml:"locale,omitempty"`
+
+	// LargeHostThreshold is the connection count that switches on large host
+	// mode (no highlights or DNS, slower refresh). 0 = default (10000), -1 = never.
+	LargeHostThreshold int `yaml:"largeHostThreshold,omitempty"`
+
+	// SampleCap is the most connections a snapshot keeps. Above it, a uniform
+	// sample of that size is kept, with exact per-process and per-state
+	// counts. 0 = default (50000), -1 = never sample.
+	SampleCap int `yaml:"sampleCap,omitempty"`
+
+	// SaturationThreshold is the interface utilization (percent of link
+	// speed) that warns. 0 = default (80), -1 = never.
+	SaturationThreshold int `yaml:"saturationThreshold,omitempty"`
+
+	// LinkSpeeds set interface speeds in Mbit/s, e.g. "en0": 500, for links the
+	// OS reports no speed for (Wi-Fi, tunnels) or as a bandwidth budget below
+	// the NIC speed. They override the reported speed.
+	LinkSpeeds map[string]int `yaml:"linkSpeeds,omitempty"`
+
+	// CriticalProcesses are executable name patterns (globs, e.g. "systemd-*")
+	// whose kill or suspend must be confirmed by typing the name; empty means
+	// the built-in list (sshd, systemd, launchd, docker, ...).
+	CriticalProcesses []string `yaml:"criticalProcesses,omitempty"`
+
+	// GeoIPDatabase is an IP range to country table (iptoasn.com ip2country or
+	// ip2asn-combined TSV, DB-IP country CSV) enabling the country breakdown ('C')
+	// and geo:CC filters.
+	GeoIPDatabase string `yaml:"geoipDatabase,omitempty"`
+
+	// UnexpectedCountries are country codes whose connections are highlighted,
+	// e.g. ["CN", "RU"]. Needs GeoIPDatabase.
+	UnexpectedCountries []string `yaml:"unexpectedCountries,omitempty"`
+
+	// EnvKeys are the environment variables shown by Process Env; empty means the built-in list.
+	EnvKeys []string `yaml:"envKeys,omitempty"`
+
+	// Sort holds the last used sort per view level (keyed by view name, e.g. "processList").
+	Sort map[string]SortPref `yaml:"sort,omitempty"`
+
+	// FilterPresets are named filter expressions recalled from the presets picker ('F').
+	FilterPresets []FilterPreset `yaml:"filterPresets,omitempty"`
+
+	// AuditLog overrides where kill/stop actions are logged (default: audit.log
+	// in the state directory). Point it at a shared path on multi-user hosts.
+	AuditLog string `yaml:"auditLog,omitempty"`
+
+	// Notes are user annotations keyed by process name or remote IP ('n'),
+	// e.g. "10.0.3.7": "staging DB".
+	Notes map[string]string `yaml:"notes,omitempty"`
+
+	// Pins are process names kept at the top of the process list ('*'),
+	// saved while RememberPins is on.
+	Pins []string `yaml:"pins,omitempty"`
+
+	// PortLabels name internal services by port, shown instead of the port
+	// number like the built-in service names: "9200": "elasticsearch" for TCP
+	// and UDP, "5353/udp": "mdns-relay" for one protocol.
+	PortLabels map[string]string `yaml:"portLabels,omitempty"`
+
+	// Refresh holds the refresh interval per view level (same keys as Sort, e.g. "connections": 500ms).
+	// "processList" is the default for levels without an entry.
+	Refresh map[string]time.Duration `yaml:"refresh,omitempty"`
+}
+
+// SortPref is a remembered sort column and direction for one view level.
+type SortPref struct {
+	Column    string `yaml:"column"` // column name, e.g. "Established"
+	Ascending bool   `yaml:"ascending"`
+}
+
+// FilterPreset is a saved filter expression. A single filter is stored in
+// Filter; several filter chips (combined with AND) in Filters.
+type FilterPreset struct {
+	Name    string   `yaml:"name"`
+	Filter  string   `yaml:"filter,omitempty"`
+	Filters []string `yaml:"filters,omitempty"`
+}
+
+// NewFilterPreset returns a preset named name for the given filter chips.
+func NewFilterPreset(name string, filters []string) FilterPreset {
+	if len(filters) == 1 {
+		return FilterPreset{Name: name, Filter: filters[0]}
+	}
+	return FilterPreset{Name: name, Filters: append([]string(nil), filters...)}
+}
+
+// Chips returns the preset's filters, whichever field holds them.
+func (p FilterPreset) Chips() []string {
+	if len(p.Filters) > 0 {
+		return p.Filters
+	}
+	if p.Filter != "" {
+		return []string{p.Filter}
+	}
+	return nil
 }
 
 // DefaultSettings returns the default settings.
@@ -24,16 +144,32 @@ func DefaultSettings() *Settings {
 		HighlightChanges: true, // On by default
 		Animations:       true, // On by default
 		DockerContainers: true, // On by default
+		GroupByExe:       false,
+		SecurityContext:  false,
+		ChurnColumns:     false,
+		HideLoopback:     false,
+		InstantRefresh:   false,
+		EphemeralFlows:   false,
+		TCPStats:         false,
+		ProcessEnv:       false, // Off by default: environments can be sensitive
+		ProtocolDetail:   false,
+		ProcessUptime:    false,
+		OpenFiles:        false,
+		Cgroups:          false,
+		Interfaces:       false,
+		WrapNavigation:   false,
+		RememberPins:     false,
+		GroupDomains:     false,
 	}
 }
 
 // settingsPath returns the path to the settings file.
 func settingsPath() (string, error) {
-	configDir, err := os.UserConfigDir()
+	paths, err := ResolvePaths()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(configDir, "netmon", "settings.yaml"), nil
+	return paths.SettingsFile(), nil
 }
 
 // LoadSettings loads settings from disk, returning defaults if not found.
@@ -85,8 +221,11 @@ func SaveSettings(s *Settings) error {
 // CurrentSettings holds the loaded settings (singleton).
 var CurrentSettings *Settings
 
-// InitSettings initializes the global settings.
+// InitSettings initializes the global settings, first moving any config files
+// left in legacy locations. Migration is best-effort and never blocks startup.
 func InitSettings() error {
+	_, _ = MigrateLegacy()
+
 	settings, err := LoadSettings()
 	if err != nil {
 		return err
@@ -3,7 +3,9 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -22,6 +24,27 @@ func TestDefaultSettings(t *testing.T) {
 	if !s.HighlightChanges {
 		t.Error("HighlightChanges should be true by default")
 	}
+	if s.GroupByExe {
+		t.Error("GroupByExe should be false by default")
+	}
+	if s.SecurityContext {
+		t.Error("SecurityContext should be false by default")
+	}
+	if s.ChurnColumns {
+		t.Error("ChurnColumns should be false by default")
+	}
+	if s.HideLoopback {
+		t.Error("HideLoopback should be false by default")
+	}
+	if s.InstantRefresh {
+		t.Error("InstantRefresh should be false by default")
+	}
+	if s.TCPStats {
+		t.Error("TCPStats should be false by default")
+	}
+	if s.ProcessEnv {
+		t.Error("ProcessEnv should be false by default")
+	}
 }
 
 func TestLoadSettings_ReturnsDefaultsWhenNoFile(t *testing.T) {
@@ -274,3 +297,45 @@ func TestSettings_ZeroValuesAreValid(t *testing.T) {
 		t.Errorf("HighlightChanges mismatch: got %v, want %v", loaded.HighlightChanges, s.HighlightChanges)
 	}
 }
+
+func TestSettings_RefreshDurationsRoundtrip(t *testing.T) {
+	in := &Settings{Refresh: map[string]time.Duration{"connections": 500 * time.Millisecond}}
+	data, err := yaml.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if !strings.Contains(string(data), "connections: 500ms") {
+		t.Errorf("refresh should be written as a duration string, got:\n%s", data)
+	}
+
+	var out Settings
+	if err := yaml.Unmarshal([]byte("refresh:\n  processList: 3s\n  connections: 500ms\n"), &out); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if out.Refresh["processList"] != 3*time.Second || out.Refresh["connections"] != 500*time.Millisecond {
+		t.Errorf("Refresh = %v", out.Refresh)
+	}
+}
+
+func TestFilterPreset_Chips(t *testing.T) {
+	single := NewFilterPreset("db", []string{"5432"})
+	if single.Filter != "5432" || single.Filters != nil {
+		t.Errorf("single chip preset = %+v, want Filter only", single)
+	}
+	multi := NewFilterPreset("tls", []string{"443", "chrome"})
+	if multi.Filter != "" || len(multi.Chips()) != 2 {
+		t.Errorf("multi chip preset = %+v, want Filters only", multi)
+	}
+
+	var out Settings
+	yml := "filterPresets:\n  - name: old\n    filter: \"8080\"\n  - name: new\n    filters: [\"443\", chrome]\n"
+	if err := yaml.Unmarshal([]byte(yml), &out); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got := out.FilterPresets[0].Chips(); len(got) != 1 || got[0] != "8080" {
+		t.Errorf("legacy preset chips = %q", got)
+	}
+	if got := out.FilterPresets[1].Chips(); len(got) != 2 || got[1] != "chrome" {
+		t.Errorf("multi preset chips = %q", got)
+	}
+}
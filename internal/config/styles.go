@@ -3,7 +3,6 @@ package config
 import (
 	"embed"
 	"os"
-	"path/filepath"
 
 	"gopkg.in/yaml.v3"
 )
@@ -134,10 +133,10 @@ func DefaultTheme() *Theme {
 // LoadTheme loads a theme from the user's config directory or returns the default.
 func LoadTheme() (*Theme, error) {
 	// Try user config first
-	configDir, err := os.UserConfigDir()
+	paths, err := ResolvePaths()
 	if err == nil {
-		userSkinPath := filepath.Join(configDir, "netmon", "skin.yaml")
-		// #nosec G304 - userSkinPath is constructed from trusted sources (UserConfigDir + hardcoded path)
+		userSkinPath := paths.ThemeFile()
+		// #nosec G304 - userSkinPath is constructed from trusted sources (config dir + hardcoded path)
 		if data, err := os.ReadFile(userSkinPath); err == nil {
 			var theme Theme
 			if err := yaml.Unmarshal(data, &theme); err == nil {
@@ -0,0 +1,142 @@
+// Package conntrack reads the kernel connection tracking table, which records
+// both the original and reply direction of every tracked flow. Comparing the two
+// reveals NAT (e.g. Docker port publishing, masquerading on routers).
+package conntrack
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// ErrUnsupported is returned on platforms without a conntrack table.
+var ErrUnsupported = errors.New("conntrack is only supported on Linux")
+
+// Reader reads the current conntrack table.
+type Reader interface {
+	Read(ctx context.Context) ([]Entry, error)
+}
+
+// Tuple is one direction of a tracked flow.
+type Tuple struct {
+	Src     string
+	Dst     string
+	SrcPort int // 0 for port-less protocols (icmp, gre)
+	DstPort int
+}
+
+// SrcAddr returns the source as "ip:port" ("[ip]:port" for IPv6), or just the IP without a port.
+func (t Tuple) SrcAddr() string { return joinAddr(t.Src, t.SrcPort) }
+
+// DstAddr returns the destination as "ip:port" ("[ip]:port" for IPv6), or just the IP without a port.
+func (t Tuple) DstAddr() string { return joinAddr(t.Dst, t.DstPort) }
+
+// String returns "src -> dst".
+func (t Tuple) String() string { return t.SrcAddr() + " -> " + t.DstAddr() }
+
+func joinAddr(ip string, port int) string {
+	if port == 0 {
+		return ip
+	}
+	return net.JoinHostPort(ip, strconv.Itoa(port))
+}
+
+// Entry is a single conntrack table row.
+type Entry struct {
+	Family    string // "ipv4" or "ipv6"
+	Protocol  string // "tcp", "udp", "icmp", ...
+	State     string // TCP state (e.g. "ESTABLISHED"); empty for stateless protocols
+	Timeout   int    // seconds until the entry expires
+	Original  Tuple  // direction of the first packet
+	Reply     Tuple  // expected direction of replies
+	Assured   bool   // traffic seen in both directions
+	Unreplied bool   // no reply seen yet
+}
+
+// NAT describes how the reply tuple differs from the inverted original tuple:
+// "SNAT" (source rewritten), "DNAT" (destination rewritten), "SNAT+DNAT", or "" if untranslated.
+func (e Entry) NAT() string {
+	dnat := e.Reply.Src != e.Original.Dst || e.Reply.SrcPort != e.Original.DstPort
+	snat := e.Reply.Dst != e.Original.Src || e.Reply.DstPort != e.Original.SrcPort
+	switch {
+	case snat && dnat:
+		return "SNAT+DNAT"
+	case snat:
+		return "SNAT"
+	case dnat:
+		return "DNAT"
+	default:
+		return ""
+	}
+}
+
+// Parse reads entries in /proc/net/nf_conntrack format. Malformed lines are skipped.
+func Parse(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if e, ok := parseLine(scanner.Text()); ok {
+			entries = append(entries, e)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// parseLine parses a single line such as:
+//
+//	ipv4 2 tcp 6 431999 ESTABLISHED src=10.0.0.2 dst=1.2.3.4 sport=5000 dport=443 src=1.2.3.4 dst=192.168.1.5 sport=443 dport=5000 [ASSURED] mark=0 use=2
+func parseLine(line string) (Entry, bool) {
+	fields := strings.Fields(line)
+	// family, family num, proto, proto num, timeout
+	if len(fields) < 6 {
+		return Entry{}, false
+	}
+	timeout, err := strconv.Atoi(fields[4])
+	if err != nil {
+		return Entry{}, false
+	}
+	e := Entry{Family: fields[0], Protocol: fields[2], Timeout: timeout}
+
+	// The first src/dst/sport/dport group is the original tuple, the second the reply.
+	tuple := &e.Original
+	seenSrc := false
+	for _, f := range fields[5:] {
+		switch f {
+		case "[ASSURED]":
+			e.Assured = true
+			continue
+		case "[UNREPLIED]":
+			e.Unreplied = true
+			continue
+		}
+		key, val, ok := strings.Cut(f, "=")
+		if !ok {
+			if !seenSrc && e.State == "" {
+				e.State = f
+			}
+			continue
+		}
+		switch key {
+		case "src":
+			if seenSrc {
+				tuple = &e.Reply
+			}
+			seenSrc = true
+			tuple.Src = val
+		case "dst":
+			tuple.Dst = val
+		case "sport":
+			tuple.SrcPort, _ = strconv.Atoi(val)
+		case "dport":
+			tuple.DstPort, _ = strconv.Atoi(val)
+		}
+	}
+	if e.Original.Src == "" || e.Reply.Src == "" {
+		return Entry{}, false
+	}
+	return e, true
+}
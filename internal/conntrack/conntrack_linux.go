@@ -0,0 +1,44 @@
+//go:build linux
+
+package conntrack
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+)
+
+// procPaths lists conntrack table locations, newest kernel interface first.
+var procPaths = []string{"/proc/net/nf_conntrack", "/proc/net/ip_conntrack"}
+
+// procReader reads conntrack entries from procfs.
+type procReader struct {
+	paths []string
+}
+
+// NewReader returns a Reader backed by /proc/net/nf_conntrack.
+func NewReader() Reader {
+	return &procReader{paths: procPaths}
+}
+
+// Read parses the first available conntrack table.
+// Reading usually requires root; the nf_conntrack module must be loaded.
+func (r *procReader) Read(ctx context.Context) ([]Entry, error) {
+	for _, path := range r.paths {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		f, err := os.Open(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries, err := Parse(f)
+		_ = f.Close()
+		return entries, err
+	}
+	return nil, errors.New("conntrack table not found (is nf_conntrack loaded?)")
+}
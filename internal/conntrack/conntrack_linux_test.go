@@ -0,0 +1,35 @@
+//go:build linux
+
+package conntrack
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProcReader_FallsBackToSecondPath(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ip_conntrack")
+	line := "ipv4 2 tcp 6 100 ESTABLISHED src=10.0.0.1 dst=10.0.0.2 sport=1 dport=2 src=10.0.0.2 dst=10.0.0.1 sport=2 dport=1 mark=0 use=1\n"
+	if err := os.WriteFile(path, []byte(line), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &procReader{paths: []string{filepath.Join(dir, "missing"), path}}
+	entries, err := r.Read(context.Background())
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(entries))
+	}
+}
+
+func TestProcReader_NoTable(t *testing.T) {
+	r := &procReader{paths: []string{filepath.Join(t.TempDir(), "missing")}}
+	if _, err := r.Read(context.Background()); err == nil {
+		t.Error("expected error when no conntrack table exists")
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !linux
+
+package conntrack
+
+import "context"
+
+// unsupportedReader is used on platforms without conntrack.
+type unsupportedReader struct{}
+
+// NewReader returns a Reader that always fails with ErrUnsupported.
+func NewReader() Reader {
+	return unsupportedReader{}
+}
+
+// Read always returns ErrUnsupported.
+func (unsupportedReader) Read(ctx context.Context) ([]Entry, error) {
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,75 @@
+package conntrack
+
+import (
+	"strings"
+	"testing"
+)
+
+const sampleTable = `ipv4     2 tcp      6 431999 ESTABLISHED src=172.17.0.2 dst=1.2.3.4 sport=40000 dport=443 src=1.2.3.4 dst=192.168.1.5 sport=443 dport=40000 [ASSURED] mark=0 zone=0 use=2
+ipv4     2 tcp      6 86399 ESTABLISHED src=192.168.1.9 dst=192.168.1.5 sport=51000 dport=8080 src=172.17.0.3 dst=192.168.1.9 sport=80 dport=51000 [ASSURED] mark=0 zone=0 use=2
+ipv4     2 udp      17 28 src=192.168.1.5 dst=8.8.8.8 sport=5353 dport=53 [UNREPLIED] src=8.8.8.8 dst=192.168.1.5 sport=53 dport=5353 mark=0 zone=0 use=2
+ipv6     10 icmpv6   58 29 src=fe80::1 dst=ff02::1 type=128 code=0 id=7 src=ff02::1 dst=fe80::1 type=129 code=0 id=7 mark=0 zone=0 use=2
+garbage line
+`
+
+func TestParse(t *testing.T) {
+	entries, err := Parse(strings.NewReader(sampleTable))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("got %d entries, want 4", len(entries))
+	}
+
+	e := entries[0]
+	if e.Family != "ipv4" || e.Protocol != "tcp" || e.State != "ESTABLISHED" || e.Timeout != 431999 {
+		t.Errorf("header fields = %+v", e)
+	}
+	if !e.Assured || e.Unreplied {
+		t.Errorf("flags: assured=%v unreplied=%v", e.Assured, e.Unreplied)
+	}
+	if got := e.Original.String(); got != "172.17.0.2:40000 -> 1.2.3.4:443" {
+		t.Errorf("Original = %q", got)
+	}
+	if got := e.Reply.String(); got != "1.2.3.4:443 -> 192.168.1.5:40000" {
+		t.Errorf("Reply = %q", got)
+	}
+
+	udp := entries[2]
+	if udp.State != "" || !udp.Unreplied {
+		t.Errorf("udp entry = %+v, want no state and unreplied", udp)
+	}
+
+	icmp := entries[3]
+	if icmp.Original.SrcAddr() != "fe80::1" || icmp.Original.SrcPort != 0 {
+		t.Errorf("icmp original = %+v", icmp.Original)
+	}
+}
+
+func TestEntryNAT(t *testing.T) {
+	entries, err := Parse(strings.NewReader(sampleTable))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	want := []string{"SNAT", "DNAT", "", ""}
+	for i, w := range want {
+		if got := entries[i].NAT(); got != w {
+			t.Errorf("entries[%d].NAT() = %q, want %q", i, got, w)
+		}
+	}
+
+	both := Entry{
+		Original: Tuple{Src: "10.0.0.2", Dst: "192.168.1.5", SrcPort: 1000, DstPort: 80},
+		Reply:    Tuple{Src: "172.17.0.2", Dst: "192.168.1.1", SrcPort: 8080, DstPort: 1000},
+	}
+	if got := both.NAT(); got != "SNAT+DNAT" {
+		t.Errorf("NAT() = %q, want SNAT+DNAT", got)
+	}
+}
+
+func TestTupleAddrIPv6(t *testing.T) {
+	tup := Tuple{Src: "::1", Dst: "2001:db8::1", SrcPort: 5000, DstPort: 443}
+	if got := tup.String(); got != "[::1]:5000 -> [2001:db8::1]:443" {
+		t.Errorf("String() = %q", got)
+	}
+}
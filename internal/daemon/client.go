@@ -0,0 +1,123 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kostyay/netmon/internal/collector"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// Client reads snapshots from a running daemon. It implements collector.Collector
+// and collector.Configurable, so the TUI can use it in place of a local collector.
+type Client struct {
+	http *http.Client
+
+	mu   sync.Mutex
+	opts collector.Options
+}
+
+var (
+	_ collector.Collector    = (*Client)(nil)
+	_ collector.Configurable = (*Client)(nil)
+)
+
+// Dial returns a client for the daemon listening on socketPath. No connection
+// is made until the first request; use Ping to check the daemon is up.
+func Dial(socketPath string) *Client {
+	return &Client{http: &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socketPath)
+			},
+		},
+	}}
+}
+
+// Ping checks that the daemon is reachable.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.get(ctx, "/v1/health", nil, nil)
+}
+
+// SetOptions sets the options sent with subsequent Collect calls.
+func (c *Client) SetOptions(opts collector.Options) {
+	c.mu.Lock()
+	c.opts = opts
+	c.mu.Unlock()
+}
+
+// Collect returns the daemon's latest snapshot for the current options.
+func (c *Client) Collect(ctx context.Context) (*model.NetworkSnapshot, error) {
+	c.mu.Lock()
+	opts := c.opts
+	c.mu.Unlock()
+
+	q := url.Values{}
+	for name, on := range map[string]bool{"groupByExe": opts.GroupByExe, "hideLoopback": opts.HideLoopback, "tcpStats": opts.TCPStats} {
+		if on {
+			q.Set(name, "1")
+		}
+	}
+	if opts.SampleCap != 0 {
+		q.Set("sampleCap", strconv.Itoa(opts.SampleCap))
+	}
+	var snap model.NetworkSnapshot
+	if err := c.get(ctx, "/v1/snapshot", q, &snap); err != nil {
+		return nil, err
+	}
+	return &snap, nil
+}
+
+// NetIO returns a NetIOCollector that reads the daemon's per-process I/O stats.
+func (c *Client) NetIO() collector.NetIOCollector {
+	return netIOClient{c}
+}
+
+type netIOClient struct{ c *Client }
+
+func (n netIOClient) Collect(ctx context.Context) (map[int32]*model.NetIOStats, error) {
+	var stats map[int32]*model.NetIOStats
+	if err := n.c.get(ctx, "/v1/netio", nil, &stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// get performs a GET against the daemon and decodes the JSON body into out (if non-nil).
+func (c *Client) get(ctx context.Context, path string, q url.Values, out any) error {
+	u := "http://netmon" + path
+	if len(q) > 0 {
+		u += "?" + q.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("daemon: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("daemon: %s", strings.TrimSpace(string(body)))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("daemon: decode %s: %w", path, err)
+	}
+	return nil
+}
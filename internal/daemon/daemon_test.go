@@ -0,0 +1,332 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kostyay/netmon/internal/collector"
+	"github.com/kostyay/netmon/internal/model"
+	"github.com/kostyay/netmon/internal/output"
+)
+
+// fakeCollector returns a snapshot tagged with the options it was configured with.
+type fakeCollector struct {
+	mu    sync.Mutex
+	opts  collector.Options
+	calls int
+	err   error
+}
+
+func (f *fakeCollector) SetOptions(opts collector.Options) {
+	f.mu.Lock()
+	f.opts = opts
+	f.mu.Unlock()
+}
+
+func (f *fakeCollector) Collect(ctx context.Context) (*model.NetworkSnapshot, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	name := "default"
+	if f.opts.GroupByExe {
+		name = "grouped"
+	}
+	return &model.NetworkSnapshot{
+		Applications: []model.Application{{Name: name, PIDs: []int32{int32(f.calls)}}},
+		Timestamp:    time.Unix(1700000000, 0).UTC(),
+	}, nil
+}
+
+type fakeNetIO struct{}
+
+func (fakeNetIO) Collect(ctx context.Context) (map[int32]*model.NetIOStats, error) {
+	return map[int32]*model.NetIOStats{42: {BytesSent: 100, BytesRecv: 200}}, nil
+}
+
+// socketPath returns a short socket path; unix socket paths are limited to ~104 bytes,
+// which t.TempDir() can exceed on macOS.
+func socketPath(t *testing.T) string {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "nm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	return filepath.Join(dir, "d.sock")
+}
+
+// startServer serves s on a fresh socket and returns a client for it.
+func startServer(t *testing.T, s *Server) *Client {
+	t.Helper()
+	path := socketPath(t)
+	ln, err := Listen(path, 0o600, "")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Serve(ctx, ln) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Serve: %v", err)
+		}
+	})
+	return Dial(path)
+}
+
+func newFakeServer(fcs *[]*fakeCollector, interval time.Duration) *Server {
+	var mu sync.Mutex
+	return newServer(interval, func() collector.Collector {
+		mu.Lock()
+		defer mu.Unlock()
+		fc := &fakeCollector{}
+		*fcs = append(*fcs, fc)
+		return fc
+	}, fakeNetIO{})
+}
+
+func TestClient_CollectRoundTrip(t *testing.T) {
+	var fcs []*fakeCollector
+	c := startServer(t, newFakeServer(&fcs, time.Hour))
+	ctx := context.Background()
+
+	if err := c.Ping(ctx); err != nil {
+		t.Fatalf("Ping: %v", err)
+	}
+	snap, err := c.Collect(ctx)
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	if len(snap.Applications) != 1 || snap.Applications[0].Name != "default" {
+		t.Errorf("Collect() apps = %+v, want one default app", snap.Applications)
+	}
+	if !snap.Timestamp.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("Timestamp = %v, want preserved through JSON", snap.Timestamp)
+	}
+}
+
+func TestClient_OptionsSelectView(t *testing.T) {
+	var fcs []*fakeCollector
+	c := startServer(t, newFakeServer(&fcs, time.Hour))
+	ctx := context.Background()
+
+	c.SetOptions(collector.Options{GroupByExe: true})
+	snap, err := c.Collect(ctx)
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	if got := snap.Applications[0].Name; got != "grouped" {
+		t.Errorf("Collect() with GroupByExe = %q, want grouped", got)
+	}
+
+	// A second request with the same options reuses the cached view.
+	before := len(fcs)
+	if _, err := c.Collect(ctx); err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	if len(fcs) != before {
+		t.Errorf("collectors created = %d, want %d (view reused)", len(fcs), before)
+	}
+}
+
+func TestClient_OptionsRoundTrip(t *testing.T) {
+	var fcs []*fakeCollector
+	c := startServer(t, newFakeServer(&fcs, time.Hour))
+
+	want := collector.Options{GroupByExe: true, HideLoopback: true, TCPStats: true, SampleCap: 50000}
+	c.SetOptions(want)
+	if _, err := c.Collect(context.Background()); err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	fc := fcs[len(fcs)-1]
+	fc.mu.Lock()
+	got := fc.opts
+	fc.mu.Unlock()
+	if got != want {
+		t.Errorf("daemon collector options = %+v, want %+v", got, want)
+	}
+
+	if err := c.get(context.Background(), "/v1/snapshot", url.Values{"sampleCap": {"lots"}}, nil); err == nil {
+		t.Error("an invalid sampleCap should be rejected")
+	}
+}
+
+func TestClient_CollectError(t *testing.T) {
+	s := newServer(time.Hour, func() collector.Collector {
+		return &fakeCollector{err: errors.New("permission denied")}
+	}, fakeNetIO{})
+	c := startServer(t, s)
+
+	_, err := c.Collect(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "permission denied") {
+		t.Errorf("Collect() err = %v, want daemon error passed through", err)
+	}
+}
+
+func TestClient_NetIO(t *testing.T) {
+	var fcs []*fakeCollector
+	c := startServer(t, newFakeServer(&fcs, time.Hour))
+
+	var stats map[int32]*model.NetIOStats
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		var err error
+		if stats, err = c.NetIO().Collect(context.Background()); err == nil && stats != nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if stats[42] == nil || stats[42].BytesRecv != 200 {
+		t.Errorf("NetIO stats = %+v, want pid 42 with 200 bytes received", stats)
+	}
+}
+
+// connsCollector returns a snapshot with n connections of one process.
+type connsCollector struct{ n int }
+
+func (c connsCollector) Collect(ctx context.Context) (*model.NetworkSnapshot, error) {
+	app := model.Application{Name: "nginx", PIDs: []int32{7}}
+	for i := range c.n {
+		app.Connections = append(app.Connections, model.Connection{
+			PID: 7, Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.1:" + strconv.Itoa(10000+i),
+			RemoteAddr: "10.0.0.2:443", State: model.StateEstablished,
+		})
+	}
+	return &model.NetworkSnapshot{Applications: []model.Application{app}}, nil
+}
+
+func TestServer_ConnectionsPage(t *testing.T) {
+	s := newServer(time.Hour, func() collector.Collector { return connsCollector{n: 5} }, fakeNetIO{})
+	c := startServer(t, s)
+
+	var page output.JSONPage
+	q := url.Values{"fields": {"process,laddr"}, "limit": {"2"}, "offset": {"3"}}
+	if err := c.get(context.Background(), "/v1/connections", q, &page); err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if page.Total != 5 || page.Offset != 3 || page.Limit != 2 {
+		t.Errorf("page = total %d offset %d limit %d, want 5/3/2", page.Total, page.Offset, page.Limit)
+	}
+	if len(page.Connections) != 2 {
+		t.Fatalf("rows = %d, want 2", len(page.Connections))
+	}
+	row := page.Connections[0]
+	if len(row) != 2 || row["process"] != "nginx" || row["laddr"] != "10.0.0.1:10003" {
+		t.Errorf("row = %v, want process and laddr of the 4th connection only", row)
+	}
+}
+
+func TestServer_ConnectionsPageBadQuery(t *testing.T) {
+	var fcs []*fakeCollector
+	c := startServer(t, newFakeServer(&fcs, time.Hour))
+
+	for _, q := range []url.Values{{"fields": {"process,bogus"}}, {"limit": {"-1"}}, {"offset": {"x"}}} {
+		err := c.get(context.Background(), "/v1/connections", q, nil)
+		if err == nil {
+			t.Errorf("get(%v) err = nil, want rejected query", q)
+		}
+	}
+}
+
+func TestServer_RefreshDropsIdleViews(t *testing.T) {
+	var fcs []*fakeCollector
+	s := newFakeServer(&fcs, time.Hour)
+	ctx := context.Background()
+	if _, err := s.view(ctx, collector.Options{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.view(ctx, collector.Options{TCPStats: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.refresh(ctx, time.Now().Add(idleViewTTL+time.Second))
+
+	if len(s.views) != 1 {
+		t.Fatalf("views = %d, want only the default view kept", len(s.views))
+	}
+	if fcs[0].calls != 2 {
+		t.Errorf("default collector calls = %d, want 2 (initial + refresh)", fcs[0].calls)
+	}
+}
+
+func TestServer_OnCollectSeesDefaultViewOnly(t *testing.T) {
+	var fcs []*fakeCollector
+	s := newFakeServer(&fcs, time.Hour)
+	var seen []string
+	s.OnCollect(func(snap *model.NetworkSnapshot) { seen = append(seen, snap.Applications[0].Name) })
+	ctx := context.Background()
+	for _, opts := range []collector.Options{{}, {GroupByExe: true}} {
+		if _, err := s.view(ctx, opts); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s.refresh(ctx, time.Now())
+	fcs[0].err = errors.New("collect failed")
+	s.refresh(ctx, time.Now())
+
+	if len(seen) != 1 || seen[0] != "default" {
+		t.Errorf("OnCollect saw %v, want the one successful default collection", seen)
+	}
+}
+
+func TestListen_ReplacesStaleSocket(t *testing.T) {
+	path := socketPath(t)
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Simulate a crashed daemon: the socket file remains but nobody listens.
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+	_ = ln.Close()
+
+	ln, err = Listen(path, 0o660, "")
+	if err != nil {
+		t.Fatalf("Listen over stale socket: %v", err)
+	}
+	defer func() { _ = ln.Close() }()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o660 {
+		t.Errorf("socket mode = %o, want 660", perm)
+	}
+}
+
+func TestListen_RefusesLiveSocket(t *testing.T) {
+	path := socketPath(t)
+	ln, err := Listen(path, 0o600, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = ln.Close() }()
+
+	if _, err := Listen(path, 0o600, ""); err == nil || !strings.Contains(err.Error(), "already listening") {
+		t.Errorf("second Listen() err = %v, want already listening", err)
+	}
+}
+
+func TestListen_RefusesNonSocket(t *testing.T) {
+	path := socketPath(t)
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Listen(path, 0o600, ""); err == nil {
+		t.Error("Listen() over a regular file should fail")
+	}
+}
@@ -0,0 +1,260 @@
+// Package daemon serves collected snapshots over a local unix socket so a
+// privileged `netmon daemon` can feed unprivileged TUI clients (`netmon --attach`).
+//
+// The protocol is HTTP/JSON over the socket:
+//
+//	GET /v1/snapshot?groupByExe=1&hideLoopback=1&tcpStats=1  -> model.NetworkSnapshot
+//	GET /v1/connections?fields=process,laddr&limit=500&offset=0 -> output.JSONPage
+//	GET /v1/netio                                           -> map[pid]model.NetIOStats
+//	GET /v1/health                                          -> 200 "ok"
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kostyay/netmon/internal/collector"
+	"github.com/kostyay/netmon/internal/model"
+	"github.com/kostyay/netmon/internal/output"
+)
+
+// DefaultInterval is how often the daemon collects.
+const DefaultInterval = time.Second
+
+// idleViewTTL is how long a collection variant (option set) keeps being
+// refreshed after the last client asked for it.
+const idleViewTTL = 30 * time.Second
+
+// view is the latest collection for one option set.
+type view struct {
+	c        collector.Collector
+	snapshot *model.NetworkSnapshot
+	err      error
+	lastUsed time.Time
+}
+
+// Server collects continuously and answers client requests from the latest results,
+// so any number of viewers share one collection loop.
+type Server struct {
+	interval     time.Duration
+	newCollector func() collector.Collector
+	netio        collector.NetIOCollector
+	onCollect    func(*model.NetworkSnapshot)
+
+	mu       sync.Mutex
+	views    map[collector.Options]*view
+	netStats map[int32]*model.NetIOStats
+	netErr   error
+}
+
+// NewServer returns a Server using the platform collectors.
+func NewServer(interval time.Duration) *Server {
+	return newServer(interval, collector.New, collector.NewNetIOCollector())
+}
+
+func newServer(interval time.Duration, newCollector func() collector.Collector, netio collector.NetIOCollector) *Server {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Server{
+		interval:     interval,
+		newCollector: newCollector,
+		netio:        netio,
+		views:        make(map[collector.Options]*view),
+	}
+}
+
+// OnCollect calls fn with every successful collection of the default option
+// set, from the collection loop. Set it before Run.
+func (s *Server) OnCollect(fn func(*model.NetworkSnapshot)) {
+	s.onCollect = fn
+}
+
+// Run collects every interval until ctx is done. The default option set is
+// always collected; other sets only while clients keep asking for them.
+func (s *Server) Run(ctx context.Context) {
+	s.view(ctx, collector.Options{}) // prime the default view
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		s.collectNetIO(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		s.refresh(ctx, time.Now())
+	}
+}
+
+// refresh recollects every active view and drops idle ones.
+func (s *Server) refresh(ctx context.Context, now time.Time) {
+	s.mu.Lock()
+	active := make(map[collector.Options]*view, len(s.views))
+	for opts, v := range s.views {
+		if opts != (collector.Options{}) && now.Sub(v.lastUsed) > idleViewTTL {
+			delete(s.views, opts)
+			continue
+		}
+		active[opts] = v
+	}
+	s.mu.Unlock()
+
+	for opts, v := range active {
+		snap, err := v.c.Collect(ctx)
+		s.mu.Lock()
+		v.snapshot, v.err = snap, err
+		s.mu.Unlock()
+		if err == nil && opts == (collector.Options{}) && s.onCollect != nil {
+			s.onCollect(snap)
+		}
+	}
+}
+
+// view returns the view for opts, creating and collecting it on first use.
+func (s *Server) view(ctx context.Context, opts collector.Options) (*model.NetworkSnapshot, error) {
+	s.mu.Lock()
+	v, ok := s.views[opts]
+	if ok {
+		v.lastUsed = time.Now()
+		snap, err := v.snapshot, v.err
+		s.mu.Unlock()
+		return snap, err
+	}
+	s.mu.Unlock()
+
+	c := s.newCollector()
+	if cfg, ok := c.(collector.Configurable); ok {
+		cfg.SetOptions(opts)
+	}
+	snap, err := c.Collect(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.views[opts]; ok { // a concurrent request won the race
+		existing.lastUsed = time.Now()
+		return existing.snapshot, existing.err
+	}
+	s.views[opts] = &view{c: c, snapshot: snap, err: err, lastUsed: time.Now()}
+	return snap, err
+}
+
+func (s *Server) collectNetIO(ctx context.Context) {
+	stats, err := s.netio.Collect(ctx)
+	s.mu.Lock()
+	s.netStats, s.netErr = stats, err
+	s.mu.Unlock()
+}
+
+// Handler returns the HTTP handler for the daemon API.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/health", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok\n"))
+	})
+	mux.HandleFunc("GET /v1/snapshot", func(w http.ResponseWriter, r *http.Request) {
+		collect, err := optionsFromQuery(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		snap, err := s.view(r.Context(), collect)
+		writeJSON(w, snap, err)
+	})
+	mux.HandleFunc("GET /v1/connections", func(w http.ResponseWriter, r *http.Request) {
+		opts, err := pageFromQuery(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		collect, err := optionsFromQuery(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		snap, err := s.view(r.Context(), collect)
+		if err != nil {
+			writeJSON(w, nil, err)
+			return
+		}
+		writeJSON(w, output.Page(snap, opts), nil)
+	})
+	mux.HandleFunc("GET /v1/netio", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		stats, err := s.netStats, s.netErr
+		s.mu.Unlock()
+		writeJSON(w, stats, err)
+	})
+	return mux
+}
+
+// Serve runs the collection loop and serves the API on ln until ctx is done.
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	srv := &http.Server{Handler: s.Handler(), ReadHeaderTimeout: 5 * time.Second}
+	go s.Run(ctx)
+	go func() {
+		<-ctx.Done()
+		_ = srv.Close()
+	}()
+	if err := srv.Serve(ln); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// optionsFromQuery decodes collection options from request query flags and
+// the sampleCap parameter.
+func optionsFromQuery(r *http.Request) (collector.Options, error) {
+	q := r.URL.Query()
+	opts := collector.Options{
+		GroupByExe:   q.Get("groupByExe") == "1",
+		HideLoopback: q.Get("hideLoopback") == "1",
+		TCPStats:     q.Get("tcpStats") == "1",
+	}
+	if v := q.Get("sampleCap"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return collector.Options{}, fmt.Errorf("invalid sampleCap %q", v)
+		}
+		opts.SampleCap = n
+	}
+	return opts, nil
+}
+
+// pageFromQuery decodes the fields, limit and offset query parameters.
+func pageFromQuery(r *http.Request) (output.PageOptions, error) {
+	q := r.URL.Query()
+	fields, err := output.ParseFields(q.Get("fields"))
+	if err != nil {
+		return output.PageOptions{}, err
+	}
+	opts := output.PageOptions{Fields: fields}
+	for name, dst := range map[string]*int{"limit": &opts.Limit, "offset": &opts.Offset} {
+		v := q.Get(name)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return output.PageOptions{}, fmt.Errorf("invalid %s %q", name, v)
+		}
+		*dst = n
+	}
+	return opts, nil
+}
+
+// writeJSON writes v, or err as a 502 with a plain-text body.
+func writeJSON(w http.ResponseWriter, v any, err error) {
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
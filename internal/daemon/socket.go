@@ -0,0 +1,80 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/user"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// DefaultSocket is where the daemon listens and clients attach by default.
+// It is outside any user's home so a root daemon and user clients agree on it.
+const DefaultSocket = "/var/run/netmon.sock"
+
+// Listen creates the daemon's unix socket at path with the given permissions,
+// optionally owned by group (so its members can attach without sudo).
+// A stale socket left by a crashed daemon is replaced; a live one is an error.
+func Listen(path string, mode os.FileMode, group string) (net.Listener, error) {
+	if err := removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		_ = ln.Close()
+		return nil, err
+	}
+	if group != "" {
+		gid, err := lookupGID(group)
+		if err != nil {
+			_ = ln.Close()
+			return nil, err
+		}
+		if err := os.Chown(path, -1, gid); err != nil {
+			_ = ln.Close()
+			return nil, fmt.Errorf("chown socket to group %s: %w", group, err)
+		}
+	}
+	return ln, nil
+}
+
+// removeStaleSocket deletes path if it is a socket nobody is listening on.
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%s exists and is not a socket", path)
+	}
+	conn, err := net.DialTimeout("unix", path, time.Second)
+	if err == nil {
+		_ = conn.Close()
+		return fmt.Errorf("another daemon is already listening on %s", path)
+	}
+	if !errors.Is(err, syscall.ECONNREFUSED) {
+		return fmt.Errorf("check existing socket %s: %w", path, err)
+	}
+	return os.Remove(path)
+}
+
+// lookupGID resolves a group name or numeric ID.
+func lookupGID(group string) (int, error) {
+	if gid, err := strconv.Atoi(group); err == nil {
+		return gid, nil
+	}
+	g, err := user.LookupGroup(group)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(g.Gid)
+}
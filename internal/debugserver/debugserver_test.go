@@ -0,0 +1,80 @@
+package debugserver
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStatus_ObserveAndGauges(t *testing.T) {
+	s := NewStatus()
+	s.Observe("collect", 10*time.Millisecond)
+	s.Observe("collect", 30*time.Millisecond)
+	s.SetGauge("dns_cache", 42)
+
+	timing := s.timings["collect"]
+	if timing.Count != 2 || timing.Last != 30*time.Millisecond || timing.Max != 30*time.Millisecond {
+		t.Errorf("timing = %+v", timing)
+	}
+	if timing.Avg() != 20*time.Millisecond {
+		t.Errorf("Avg() = %v, want 20ms", timing.Avg())
+	}
+
+	var b strings.Builder
+	if err := s.Write(&b); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	out := b.String()
+	for _, want := range []string{"goroutines", "collect", "20ms", "dns_cache", "42"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("status page missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestStatus_NilSafe(t *testing.T) {
+	var s *Status
+	s.Observe("render", time.Millisecond)
+	s.SetGauge("rows", 1)
+}
+
+func TestHandler(t *testing.T) {
+	s := NewStatus()
+	s.SetGauge("connections", 7)
+	srv := httptest.NewServer(Handler(s))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/debug/status")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "connections") {
+		t.Errorf("/debug/status = %d %q", resp.StatusCode, body)
+	}
+
+	resp, err = http.Get(srv.URL + "/debug/pprof/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("/debug/pprof/ = %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestStart_ReportsListenError(t *testing.T) {
+	srv, err := Start("127.0.0.1:0", NewStatus())
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer srv.Close()
+
+	if _, err := Start("not-an-address", NewStatus()); err == nil {
+		t.Error("Start should fail for an invalid address")
+	}
+}
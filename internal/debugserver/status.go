@@ -0,0 +1,130 @@
+// Package debugserver exposes pprof and a plain-text status page for
+// profiling netmon itself (hidden --debug-listen flag).
+package debugserver
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Timing aggregates durations of a repeated operation (collect, render).
+type Timing struct {
+	Count int
+	Last  time.Duration
+	Max   time.Duration
+	Total time.Duration
+}
+
+// Avg returns the mean duration, or 0 if nothing was observed.
+func (t Timing) Avg() time.Duration {
+	if t.Count == 0 {
+		return 0
+	}
+	return t.Total / time.Duration(t.Count)
+}
+
+// Status collects runtime metrics published by the UI. All methods are safe
+// for concurrent use and no-ops on a nil *Status, so callers need not check
+// whether the debug server is enabled.
+type Status struct {
+	mu      sync.Mutex
+	started time.Time
+	timings map[string]Timing
+	gauges  map[string]int
+}
+
+// NewStatus returns an empty Status; uptime is measured from now.
+func NewStatus() *Status {
+	return &Status{
+		started: time.Now(),
+		timings: make(map[string]Timing),
+		gauges:  make(map[string]int),
+	}
+}
+
+// Observe records one duration for the named operation.
+func (s *Status) Observe(name string, d time.Duration) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := s.timings[name]
+	t.Count++
+	t.Last = d
+	t.Total += d
+	if d > t.Max {
+		t.Max = d
+	}
+	s.timings[name] = t
+}
+
+// SetGauge sets the current value of the named gauge (cache sizes, row counts).
+func (s *Status) SetGauge(name string, v int) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gauges[name] = v
+}
+
+// Write renders the status page: process runtime stats, timings and gauges.
+func (s *Status) Write(w io.Writer) error {
+	s.mu.Lock()
+	timings := make(map[string]Timing, len(s.timings))
+	for k, v := range s.timings {
+		timings[k] = v
+	}
+	gauges := make(map[string]int, len(s.gauges))
+	for k, v := range s.gauges {
+		gauges[k] = v
+	}
+	uptime := time.Since(s.started).Round(time.Second)
+	s.mu.Unlock()
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	var err error
+	printf := func(format string, args ...any) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	printf("netmon status\n\n")
+	printf("uptime      %s\n", uptime)
+	printf("goroutines  %d\n", runtime.NumGoroutine())
+	printf("heap        %.1f MiB (%d objects)\n", float64(mem.HeapAlloc)/(1<<20), mem.HeapObjects)
+	printf("gc cycles   %d\n", mem.NumGC)
+
+	printf("\ntimings     %8s %10s %10s %10s\n", "count", "last", "avg", "max")
+	for _, name := range sortedKeys(timings) {
+		t := timings[name]
+		printf("  %-9s %8d %10s %10s %10s\n", name, t.Count, round(t.Last), round(t.Avg()), round(t.Max))
+	}
+
+	printf("\ngauges\n")
+	for _, name := range sortedKeys(gauges) {
+		printf("  %-16s %d\n", name, gauges[name])
+	}
+	return err
+}
+
+func round(d time.Duration) time.Duration {
+	return d.Round(10 * time.Microsecond)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
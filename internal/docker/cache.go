@@ -0,0 +1,148 @@
+package docker
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultCacheInterval is how often CachedResolver re-resolves without events.
+const DefaultCacheInterval = 5 * time.Second
+
+// eventDebounce coalesces a burst of container events (compose up/down) into
+// a single refresh.
+const eventDebounce = 500 * time.Millisecond
+
+// refreshTimeout bounds one background refresh, stats included.
+const refreshTimeout = 5 * time.Second
+
+// eventSource is implemented by resolvers that can report container changes.
+type eventSource interface {
+	watchEvents(ctx context.Context) (<-chan struct{}, error)
+}
+
+// CachedResolver answers Resolve from memory and keeps the answer current in
+// the background: it re-resolves every interval and shortly after Docker
+// reports a container starting, stopping or being renamed. The first Resolve
+// resolves synchronously and starts the background loop, so nothing talks to
+// Docker until container data is needed.
+type CachedResolver struct {
+	inner    Resolver
+	interval time.Duration
+	debounce time.Duration
+
+	mu      sync.Mutex
+	result  *ResolveResult
+	err     error
+	started bool
+	cancel  context.CancelFunc
+}
+
+// NewCachedResolver wraps inner in a background-refreshed cache.
+func NewCachedResolver(inner Resolver, interval time.Duration) *CachedResolver {
+	if interval <= 0 {
+		interval = DefaultCacheInterval
+	}
+	return &CachedResolver{inner: inner, interval: interval, debounce: eventDebounce}
+}
+
+// Resolve returns the latest resolution, resolving first if there is none yet.
+// Result.At tells callers whether it changed since they last asked.
+func (c *CachedResolver) Resolve(ctx context.Context) (*ResolveResult, error) {
+	c.mu.Lock()
+	result, err := c.result, c.err
+	c.mu.Unlock()
+	if result != nil {
+		return result, err
+	}
+
+	if err := c.refresh(ctx); err != nil {
+		return nil, err
+	}
+	c.start()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.result, c.err
+}
+
+// Close stops the background refresh. The cached result stays available.
+func (c *CachedResolver) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cancel != nil {
+		c.cancel()
+	}
+}
+
+// refresh re-resolves and stores the result. A failed refresh keeps the
+// previous result and records the error until the next success.
+func (c *CachedResolver) refresh(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, refreshTimeout)
+	defer cancel()
+	result, err := c.inner.Resolve(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = err
+	if result != nil {
+		c.result = result
+	}
+	return err
+}
+
+// start launches the background loop once.
+func (c *CachedResolver) start() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.started {
+		return
+	}
+	c.started = true
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	go c.run(ctx)
+}
+
+func (c *CachedResolver) run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	events := c.subscribe(ctx)
+	var settle <-chan time.Time // pending debounced refresh
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if events == nil {
+				events = c.subscribe(ctx) // resubscribe after Docker restarts
+			}
+			settle = nil
+			_ = c.refresh(ctx)
+		case _, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			if settle == nil {
+				settle = time.After(c.debounce)
+			}
+		case <-settle:
+			settle = nil
+			_ = c.refresh(ctx)
+		}
+	}
+}
+
+// subscribe returns the inner resolver's container events, or nil when it
+// has none or Docker is unreachable (interval refreshes still run).
+func (c *CachedResolver) subscribe(ctx context.Context) <-chan struct{} {
+	src, ok := c.inner.(eventSource)
+	if !ok {
+		return nil
+	}
+	events, err := src.watchEvents(ctx)
+	if err != nil {
+		return nil
+	}
+	return events
+}
@@ -0,0 +1,156 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+)
+
+// countingResolver counts Resolve calls and optionally emits container events.
+type countingResolver struct {
+	mu     sync.Mutex
+	calls  int
+	err    error
+	events chan struct{} // nil: no event support used
+}
+
+func (r *countingResolver) Resolve(ctx context.Context) (*ResolveResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls++
+	if r.err != nil {
+		return nil, r.err
+	}
+	return &ResolveResult{Ports: map[int]*ContainerPort{}, At: time.Now()}, nil
+}
+
+func (r *countingResolver) watchEvents(ctx context.Context) (<-chan struct{}, error) {
+	if r.events == nil {
+		return nil, errors.New("no events")
+	}
+	return r.events, nil
+}
+
+func (r *countingResolver) callCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.calls
+}
+
+// waitFor polls cond until it holds or a second passes.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within 1s")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestCachedResolver_ServesFromCache(t *testing.T) {
+	inner := &countingResolver{}
+	c := NewCachedResolver(inner, time.Hour)
+	defer c.Close()
+
+	first, err := c.Resolve(context.Background())
+	if err != nil || first == nil {
+		t.Fatalf("Resolve = %v, %v", first, err)
+	}
+	second, _ := c.Resolve(context.Background())
+	if second != first || inner.callCount() != 1 {
+		t.Errorf("second Resolve hit Docker (%d calls), want the cached result", inner.callCount())
+	}
+}
+
+func TestCachedResolver_RefreshesOnInterval(t *testing.T) {
+	inner := &countingResolver{}
+	c := NewCachedResolver(inner, 10*time.Millisecond)
+	defer c.Close()
+
+	first, _ := c.Resolve(context.Background())
+	waitFor(t, func() bool { return inner.callCount() >= 2 })
+	waitFor(t, func() bool {
+		r, _ := c.Resolve(context.Background())
+		return r.At.After(first.At)
+	})
+}
+
+func TestCachedResolver_RefreshesOnEventsDebounced(t *testing.T) {
+	inner := &countingResolver{events: make(chan struct{}, 3)}
+	c := NewCachedResolver(inner, time.Hour)
+	c.debounce = 20 * time.Millisecond
+	defer c.Close()
+
+	if _, err := c.Resolve(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	for range 3 {
+		inner.events <- struct{}{}
+	}
+	waitFor(t, func() bool { return inner.callCount() == 2 })
+	time.Sleep(50 * time.Millisecond)
+	if n := inner.callCount(); n != 2 {
+		t.Errorf("calls = %d, want a burst of events coalesced into one refresh", n)
+	}
+}
+
+func TestCachedResolver_KeepsResultOnFailedRefresh(t *testing.T) {
+	inner := &countingResolver{}
+	c := NewCachedResolver(inner, time.Hour)
+	defer c.Close()
+
+	first, _ := c.Resolve(context.Background())
+	inner.mu.Lock()
+	inner.err = context.DeadlineExceeded
+	inner.mu.Unlock()
+	_ = c.refresh(context.Background())
+
+	r, err := c.Resolve(context.Background())
+	if r != first || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Resolve = %v, %v; want the previous result with the refresh error", r, err)
+	}
+}
+
+func TestCachedResolver_FirstResolveError(t *testing.T) {
+	inner := &countingResolver{err: context.Canceled}
+	c := NewCachedResolver(inner, time.Hour)
+	defer c.Close()
+
+	if r, err := c.Resolve(context.Background()); r != nil || err == nil {
+		t.Errorf("Resolve = %v, %v; want the error", r, err)
+	}
+}
+
+func TestWatchEvents_ForwardsUntilError(t *testing.T) {
+	mock := &mockDockerAPI{
+		containers: []container.Summary{},
+		events:     make(chan events.Message),
+		eventErrs:  make(chan error, 1),
+	}
+	r := newTestResolver(mock)
+
+	ch, err := r.watchEvents(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.events <- events.Message{Type: events.ContainerEventType, Action: events.ActionStart}
+	if _, ok := <-ch; !ok {
+		t.Fatal("event should be forwarded")
+	}
+	mock.eventErrs <- errors.New("daemon restarted")
+	waitFor(t, func() bool {
+		select {
+		case _, ok := <-ch:
+			return !ok
+		default:
+			return false
+		}
+	})
+}
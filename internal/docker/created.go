@@ -0,0 +1,89 @@
+package docker
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// maxCreatedContainers bounds the inspect calls for created containers, in
+// case a script left hundreds behind.
+const maxCreatedContainers = 32
+
+// createdContainers returns the containers that were created but never
+// started, with the host ports their config publishes. A container whose
+// start failed with "port is already allocated" stays in this state, and
+// `docker compose up` creates every container before starting any. The list
+// entry carries no ports until the container runs, so each one is inspected
+// for its port bindings. Containers that cannot be inspected are left out.
+func createdContainers(ctx context.Context, cli dockerAPI) []model.VirtualContainer {
+	list, err := cli.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("status", string(container.StateCreated))),
+	})
+	if err != nil || len(list) == 0 {
+		return nil
+	}
+	if len(list) > maxCreatedContainers {
+		list = list[:maxCreatedContainers]
+	}
+
+	vcs := make([]model.VirtualContainer, len(list))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxStatsConcurrency)
+	for i, c := range list {
+		vcs[i].Info = model.ContainerInfo{Name: cleanContainerName(c.Names), Image: c.Image, ID: shortID(c.ID)}
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if resp, err := cli.ContainerInspect(ctx, id); err == nil && resp.ContainerJSONBase != nil && resp.HostConfig != nil {
+				vcs[i].PortMappings = boundPorts(resp.HostConfig)
+			}
+		}(i, c.ID)
+	}
+	wg.Wait()
+
+	var out []model.VirtualContainer
+	for _, vc := range vcs {
+		if len(vc.PortMappings) > 0 {
+			out = append(out, vc)
+		}
+	}
+	return out
+}
+
+// boundPorts returns the host ports a container config publishes, sorted by
+// host port. Bindings without a fixed host port (Docker picks a free one on
+// start) cannot collide and are skipped.
+func boundPorts(hc *container.HostConfig) []model.PortMapping {
+	var mappings []model.PortMapping
+	for port, bindings := range hc.PortBindings {
+		for _, b := range bindings {
+			hostPort, err := strconv.Atoi(b.HostPort)
+			if err != nil || hostPort == 0 {
+				continue
+			}
+			mappings = append(mappings, model.PortMapping{
+				HostPort:      hostPort,
+				ContainerPort: port.Int(),
+				Protocol:      port.Proto(),
+				HostIP:        b.HostIP,
+			})
+		}
+	}
+	sort.Slice(mappings, func(i, j int) bool {
+		if mappings[i].HostPort != mappings[j].HostPort {
+			return mappings[i].HostPort < mappings[j].HostPort
+		}
+		return mappings[i].Protocol < mappings[j].Protocol
+	})
+	return mappings
+}
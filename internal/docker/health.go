@@ -0,0 +1,58 @@
+package docker
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// healthStatuses maps the suffix of a container's status line
+// ("Up 3 hours (healthy)") to its healthcheck status.
+var healthStatuses = []struct{ suffix, status string }{
+	{"(healthy)", "healthy"},
+	{"(unhealthy)", "unhealthy"},
+	{"(health: starting)", "starting"},
+}
+
+// parseHealth returns the healthcheck status from a container list status
+// line, or "" when the container has no healthcheck.
+func parseHealth(status string) string {
+	for _, h := range healthStatuses {
+		if strings.HasSuffix(status, h.suffix) {
+			return h.status
+		}
+	}
+	return ""
+}
+
+// fillRestartCounts inspects each container concurrently for its restart
+// count, which the container list does not report. Containers that cannot be
+// inspected keep 0.
+func fillRestartCounts(ctx context.Context, cli dockerAPI, infos []model.ContainerInfo, fullIDs []string) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxStatsConcurrency)
+	for i := range infos {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if resp, err := cli.ContainerInspect(ctx, fullIDs[i]); err == nil && resp.ContainerJSONBase != nil {
+				infos[i].RestartCount = resp.RestartCount
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+// containerHealth returns c's info with its health and restart state from
+// the list entry.
+func containerHealth(ci model.ContainerInfo, c container.Summary) model.ContainerInfo {
+	ci.Health = parseHealth(c.Status)
+	ci.Restarting = c.State == container.StateRestarting
+	return ci
+}
@@ -0,0 +1,54 @@
+package docker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+func TestParseHealth(t *testing.T) {
+	tests := map[string]string{
+		"Up 3 hours (healthy)":            "healthy",
+		"Up 2 minutes (unhealthy)":        "unhealthy",
+		"Up 5 seconds (health: starting)": "starting",
+		"Up 3 hours":                      "",
+		"Restarting (1) 4 seconds ago":    "",
+	}
+	for status, want := range tests {
+		if got := parseHealth(status); got != want {
+			t.Errorf("parseHealth(%q) = %q, want %q", status, got, want)
+		}
+	}
+}
+
+func TestResolve_PopulatesHealthAndRestarts(t *testing.T) {
+	mock := &mockDockerAPI{
+		containers: []container.Summary{
+			{ID: "aaaaaaaaaaaaaaaa", Names: []string{"/web"}, Image: "nginx", State: container.StateRunning, Status: "Up 1 hour (healthy)",
+				Ports: []container.Port{{PublicPort: 8080, PrivatePort: 80, Type: "tcp"}}},
+			{ID: "bbbbbbbbbbbbbbbb", Names: []string{"/worker"}, Image: "busybox", State: container.StateRestarting, Status: "Restarting (1) 2 seconds ago"},
+			{ID: "cccccccccccccccc", Names: []string{"/plain"}, Image: "alpine", State: container.StateRunning, Status: "Up 1 hour"},
+		},
+		restarts: map[string]int{"aaaaaaaaaaaaaaaa": 0, "bbbbbbbbbbbbbbbb": 7},
+		// cccc cannot be inspected: restart count stays 0
+	}
+
+	result, err := newTestResolver(mock).Resolve(context.Background())
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	labels := make(map[string]string)
+	for _, vc := range result.Containers {
+		labels[vc.Info.Name] = vc.Info.HealthLabel()
+	}
+	want := map[string]string{"web": "healthy", "worker": "restarting ×7", "plain": ""}
+	for name, label := range want {
+		if labels[name] != label {
+			t.Errorf("%s label = %q, want %q", name, labels[name], label)
+		}
+	}
+	if cp := result.Ports[8080]; cp == nil || cp.Container.Health != "healthy" {
+		t.Errorf("port 8080 container = %+v, want health carried into the port map", cp)
+	}
+}
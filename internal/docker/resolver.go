@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/client"
 	"github.com/kostyay/netmon/internal/model"
 )
@@ -14,6 +17,8 @@ import (
 type ResolveResult struct {
 	Ports      map[int]*ContainerPort
 	Containers []model.VirtualContainer
+	Created    []model.VirtualContainer // created, never started, with the ports their config publishes
+	At         time.Time                // when Docker was queried
 }
 
 // Resolver resolves host ports to Docker container info.
@@ -27,6 +32,7 @@ type ContainerPort struct {
 	HostPort      int
 	ContainerPort int
 	Protocol      string
+	HostIP        string // bind address on the host, e.g. "0.0.0.0" or "127.0.0.1"
 }
 
 // dockerResolver implements Resolver using the Docker Engine API.
@@ -37,6 +43,9 @@ type dockerResolver struct {
 // dockerAPI is the subset of Docker client we need (for testing).
 type dockerAPI interface {
 	ContainerList(ctx context.Context, options container.ListOptions) ([]container.Summary, error)
+	ContainerStatsOneShot(ctx context.Context, containerID string) (container.StatsResponseReader, error)
+	ContainerInspect(ctx context.Context, containerID string) (container.InspectResponse, error)
+	Events(ctx context.Context, options events.ListOptions) (<-chan events.Message, <-chan error)
 	Close() error
 }
 
@@ -49,10 +58,12 @@ func NewResolver() Resolver {
 	}
 }
 
-// Resolve queries Docker for running containers and builds port mappings + virtual container rows.
+// Resolve queries Docker for running containers and builds port mappings + virtual container rows,
+// including health, restart counts and per-container network totals from the stats API, and
+// lists created containers with the ports they will publish.
 // Returns empty result (not error) if Docker is unavailable.
 func (r *dockerResolver) Resolve(ctx context.Context) (*ResolveResult, error) {
-	emptyResult := &ResolveResult{Ports: map[int]*ContainerPort{}}
+	emptyResult := &ResolveResult{Ports: map[int]*ContainerPort{}, At: time.Now()}
 
 	cli, err := r.newClient()
 	if err != nil {
@@ -68,15 +79,23 @@ func (r *dockerResolver) Resolve(ctx context.Context) (*ResolveResult, error) {
 		return emptyResult, nil // Docker unavailable
 	}
 
-	portMap := make(map[int]*ContainerPort)
-	var vcs []model.VirtualContainer
-
-	for _, c := range containers {
-		ci := model.ContainerInfo{
+	infos := make([]model.ContainerInfo, len(containers))
+	fullIDs := make([]string, len(containers))
+	for i, c := range containers {
+		infos[i] = containerHealth(model.ContainerInfo{
 			Name:  cleanContainerName(c.Names),
 			Image: c.Image,
 			ID:    shortID(c.ID),
-		}
+		}, c)
+		fullIDs[i] = c.ID
+	}
+	fillRestartCounts(ctx, cli, infos, fullIDs)
+
+	portMap := make(map[int]*ContainerPort)
+	var vcs []model.VirtualContainer
+
+	for i, c := range containers {
+		ci := infos[i]
 
 		var mappings []model.PortMapping
 		for _, p := range c.Ports {
@@ -88,11 +107,13 @@ func (r *dockerResolver) Resolve(ctx context.Context) (*ResolveResult, error) {
 				HostPort:      int(p.PublicPort),
 				ContainerPort: int(p.PrivatePort),
 				Protocol:      p.Type,
+				HostIP:        p.IP,
 			}
 			mappings = append(mappings, model.PortMapping{
 				HostPort:      int(p.PublicPort),
 				ContainerPort: int(p.PrivatePort),
 				Protocol:      p.Type,
+				HostIP:        p.IP,
 			})
 		}
 
@@ -102,7 +123,49 @@ func (r *dockerResolver) Resolve(ctx context.Context) (*ResolveResult, error) {
 		})
 	}
 
-	return &ResolveResult{Ports: portMap, Containers: vcs}, nil
+	fillNetIO(ctx, cli, vcs, fullIDs)
+
+	return &ResolveResult{Ports: portMap, Containers: vcs, Created: createdContainers(ctx, cli), At: time.Now()}, nil
+}
+
+// containerEvents are the lifecycle events that change port mappings or the
+// container list.
+var containerEvents = []events.Action{events.ActionStart, events.ActionDie, events.ActionDestroy, events.ActionRename}
+
+// watchEvents subscribes to container lifecycle events. The returned channel
+// receives a value per event (coalesced when the reader is behind) and is
+// closed when the subscription ends: ctx done, daemon restart or Docker
+// unavailable.
+func (r *dockerResolver) watchEvents(ctx context.Context) (<-chan struct{}, error) {
+	cli, err := r.newClient()
+	if err != nil {
+		return nil, err
+	}
+	args := filters.NewArgs(filters.Arg("type", string(events.ContainerEventType)))
+	for _, a := range containerEvents {
+		args.Add("event", string(a))
+	}
+	msgs, errs := cli.Events(ctx, events.ListOptions{Filters: args})
+
+	out := make(chan struct{}, 1)
+	go func() {
+		defer close(out)
+		defer func() { _ = cli.Close() }()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-errs:
+				return
+			case <-msgs:
+				select {
+				case out <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+	return out, nil
 }
 
 // cleanContainerName strips the leading "/" from Docker container names.
@@ -2,26 +2,67 @@ package docker
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"io"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
 	"github.com/kostyay/netmon/internal/model"
 )
 
 // mockDockerAPI implements dockerAPI for testing.
 type mockDockerAPI struct {
-	containers []container.Summary
-	err        error
+	containers  []container.Summary
+	created     []container.Summary // returned when listing all containers
+	err         error
+	stats       map[string]string // container ID -> stats JSON body
+	restarts    map[string]int    // container ID -> restart count
+	hostConfigs map[string]string // container ID -> HostConfig JSON
+	events      chan events.Message
+	eventErrs   chan error
 }
 
 func (m *mockDockerAPI) ContainerList(ctx context.Context, options container.ListOptions) ([]container.Summary, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
+	if options.All {
+		return m.created, nil
+	}
 	return m.containers, nil
 }
 
+func (m *mockDockerAPI) ContainerStatsOneShot(ctx context.Context, id string) (container.StatsResponseReader, error) {
+	body, ok := m.stats[id]
+	if !ok {
+		return container.StatsResponseReader{}, errors.New("no such container")
+	}
+	return container.StatsResponseReader{Body: io.NopCloser(strings.NewReader(body))}, nil
+}
+
+func (m *mockDockerAPI) ContainerInspect(ctx context.Context, id string) (container.InspectResponse, error) {
+	n, ok := m.restarts[id]
+	hc, hasConfig := m.hostConfigs[id]
+	if !ok && !hasConfig {
+		return container.InspectResponse{}, errors.New("no such container")
+	}
+	base := &container.ContainerJSONBase{ID: id, RestartCount: n}
+	if hasConfig {
+		if err := json.Unmarshal([]byte(hc), &base.HostConfig); err != nil {
+			return container.InspectResponse{}, err
+		}
+	}
+	return container.InspectResponse{ContainerJSONBase: base}, nil
+}
+
+func (m *mockDockerAPI) Events(ctx context.Context, options events.ListOptions) (<-chan events.Message, <-chan error) {
+	return m.events, m.eventErrs
+}
+
 func (m *mockDockerAPI) Close() error { return nil }
 
 func newTestResolver(mock *mockDockerAPI) *dockerResolver {
@@ -48,7 +89,7 @@ func TestResolve_RunningContainers(t *testing.T) {
 				Names: []string{"/nginx-proxy"},
 				Image: "nginx:latest",
 				Ports: []container.Port{
-					{PublicPort: 8080, PrivatePort: 80, Type: "tcp"},
+					{IP: "0.0.0.0", PublicPort: 8080, PrivatePort: 80, Type: "tcp"},
 					{PublicPort: 8443, PrivatePort: 443, Type: "tcp"},
 				},
 			},
@@ -88,6 +129,9 @@ func TestResolve_RunningContainers(t *testing.T) {
 	if cp.HostPort != 8080 {
 		t.Errorf("HostPort = %d, want 8080", cp.HostPort)
 	}
+	if cp.HostIP != "0.0.0.0" {
+		t.Errorf("HostIP = %q, want '0.0.0.0'", cp.HostIP)
+	}
 
 	cp6379 := result.Ports[6379]
 	if cp6379 == nil {
@@ -106,6 +150,8 @@ func TestResolve_RunningContainers(t *testing.T) {
 	}
 	if len(result.Containers[0].PortMappings) != 2 {
 		t.Errorf("nginx-proxy should have 2 port mappings, got %d", len(result.Containers[0].PortMappings))
+	} else if got := result.Containers[0].PortMappings[0].HostIP; got != "0.0.0.0" {
+		t.Errorf("PortMappings[0].HostIP = %q, want '0.0.0.0'", got)
 	}
 	if result.Containers[1].Info.Name != "redis-cache" {
 		t.Errorf("Container[1].Name = %q, want 'redis-cache'", result.Containers[1].Info.Name)
@@ -185,7 +231,7 @@ func TestResolve_MultiplePortsOneContainer(t *testing.T) {
 				Ports: []container.Port{
 					{PublicPort: 80, PrivatePort: 80, Type: "tcp"},
 					{PublicPort: 443, PrivatePort: 443, Type: "tcp"},
-					{PublicPort: 8080, PrivatePort: 80, Type: "tcp"},
+					{IP: "0.0.0.0", PublicPort: 8080, PrivatePort: 80, Type: "tcp"},
 				},
 			},
 		},
@@ -388,3 +434,38 @@ func TestFormatColumn_Truncation(t *testing.T) {
 		t.Errorf("rune len = %d, want <= 25", len(runes))
 	}
 }
+
+func TestResolve_CreatedContainers(t *testing.T) {
+	mock := &mockDockerAPI{
+		created: []container.Summary{
+			{ID: "aaa111", Names: []string{"/api"}, Image: "api:dev"},
+			{ID: "bbb222", Names: []string{"/worker"}, Image: "worker:dev"},
+		},
+		hostConfigs: map[string]string{
+			"aaa111": `{"PortBindings": {
+				"80/tcp": [{"HostIp": "127.0.0.1", "HostPort": "8080"}],
+				"53/udp": [{"HostIp": "", "HostPort": "5353"}],
+				"9000/tcp": [{"HostIp": "", "HostPort": ""}]
+			}}`,
+			"bbb222": `{"PortBindings": {}}`,
+		},
+	}
+	result, err := newTestResolver(mock).Resolve(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Created) != 1 {
+		t.Fatalf("Created = %+v, want only the container publishing ports", result.Created)
+	}
+	vc := result.Created[0]
+	want := []model.PortMapping{
+		{HostPort: 5353, ContainerPort: 53, Protocol: "udp"},
+		{HostPort: 8080, ContainerPort: 80, Protocol: "tcp", HostIP: "127.0.0.1"},
+	}
+	if vc.Info.Name != "api" || !slices.Equal(vc.PortMappings, want) {
+		t.Errorf("created = %+v, want api with %+v", vc, want)
+	}
+	if len(result.Ports) != 0 {
+		t.Errorf("created containers should not resolve connection ports: %v", result.Ports)
+	}
+}
@@ -0,0 +1,67 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// maxStatsConcurrency bounds parallel stats requests to the Docker daemon.
+const maxStatsConcurrency = 8
+
+// containerNetIO reads one stats sample for a container and sums its network
+// interface counters.
+func containerNetIO(ctx context.Context, cli dockerAPI, id string) (*model.NetIOStats, error) {
+	resp, err := cli.ContainerStatsOneShot(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var stats container.StatsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		return nil, err
+	}
+	return sumNetworks(stats), nil
+}
+
+// sumNetworks totals rx/tx bytes across a container's interfaces.
+// Returns nil for containers without network stats (e.g. --network none).
+func sumNetworks(stats container.StatsResponse) *model.NetIOStats {
+	if len(stats.Networks) == 0 {
+		return nil
+	}
+	total := &model.NetIOStats{UpdatedAt: stats.Read}
+	if total.UpdatedAt.IsZero() {
+		total.UpdatedAt = time.Now()
+	}
+	for _, n := range stats.Networks {
+		total.BytesSent += n.TxBytes
+		total.BytesRecv += n.RxBytes
+	}
+	return total
+}
+
+// fillNetIO populates NetIO for each container concurrently, keyed by full
+// container ID. Containers whose stats cannot be read keep a nil NetIO.
+func fillNetIO(ctx context.Context, cli dockerAPI, vcs []model.VirtualContainer, fullIDs []string) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxStatsConcurrency)
+	for i := range vcs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if netIO, err := containerNetIO(ctx, cli, fullIDs[i]); err == nil {
+				vcs[i].NetIO = netIO
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,53 @@
+package docker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+func TestResolve_PopulatesNetIO(t *testing.T) {
+	mock := &mockDockerAPI{
+		containers: []container.Summary{
+			{ID: "aaaaaaaaaaaaaaaa", Names: []string{"/web"}, Image: "nginx"},
+			{ID: "bbbbbbbbbbbbbbbb", Names: []string{"/batch"}, Image: "busybox"},
+			{ID: "cccccccccccccccc", Names: []string{"/isolated"}, Image: "alpine"},
+		},
+		stats: map[string]string{
+			"aaaaaaaaaaaaaaaa": `{"read":"2026-01-02T03:04:05Z","networks":{"eth0":{"rx_bytes":1000,"tx_bytes":200},"eth1":{"rx_bytes":24,"tx_bytes":56}}}`,
+			"cccccccccccccccc": `{"read":"2026-01-02T03:04:05Z"}`,
+			// bbbb has no stats: request fails
+		},
+	}
+
+	result, err := newTestResolver(mock).Resolve(context.Background())
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	byName := make(map[string]int)
+	for i, vc := range result.Containers {
+		byName[vc.Info.Name] = i
+	}
+
+	web := result.Containers[byName["web"]].NetIO
+	if web == nil || web.BytesRecv != 1024 || web.BytesSent != 256 {
+		t.Errorf("web NetIO = %+v, want rx 1024 tx 256 summed over interfaces", web)
+	}
+	if web != nil && web.UpdatedAt.Year() != 2026 {
+		t.Errorf("UpdatedAt = %v, want sample read time", web.UpdatedAt)
+	}
+	if io := result.Containers[byName["batch"]].NetIO; io != nil {
+		t.Errorf("batch NetIO = %+v, want nil when stats fail", io)
+	}
+	if io := result.Containers[byName["isolated"]].NetIO; io != nil {
+		t.Errorf("isolated NetIO = %+v, want nil without networks", io)
+	}
+}
+
+func TestContainerNetIO_BadJSON(t *testing.T) {
+	mock := &mockDockerAPI{stats: map[string]string{"x": "{not json"}}
+	if _, err := containerNetIO(context.Background(), mock, "x"); err == nil {
+		t.Error("expected decode error")
+	}
+}
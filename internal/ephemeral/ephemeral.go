@@ -0,0 +1,202 @@
+// Package ephemeral catches connections too short-lived for the refresh poll
+// to see: a health check, a DNS query or a curl in a loop can open and close
+// between two snapshots and never show up.
+//
+// On Linux the kernel notifies sock_diag listeners of every TCP and UDP
+// socket it destroys (`ss --events`). The Watcher buffers those closed
+// sockets, and Classify keeps the ones neither snapshot around them listed.
+// Notifications carry no process, only the owning user.
+package ephemeral
+
+import (
+	"errors"
+	"net/netip"
+	"sync"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/kostyay/netmon/internal/model"
+	"github.com/kostyay/netmon/internal/netlink"
+)
+
+// maxPending caps the closed sockets buffered between two drains; more are
+// counted as dropped.
+const maxPending = 10000
+
+// Flow is a connection the kernel reported closed.
+type Flow struct {
+	Protocol model.Protocol
+	Local    netip.AddrPort
+	Remote   netip.AddrPort
+	UID      uint32
+	ClosedAt time.Time
+}
+
+// Batch is what a Watcher collected since the previous Drain.
+type Batch struct {
+	Flows   []Flow
+	Dropped int   // flows beyond maxPending
+	Err     error // last read error, e.g. ENOBUFS when notifications were lost
+}
+
+// listener is the source of destroyed sockets (replaced in tests).
+type listener interface {
+	Read() ([]netlink.Socket, error)
+	Close() error
+}
+
+// Watcher collects closed connections in the background until stopped.
+type Watcher struct {
+	listen func() (listener, error)
+	done   chan struct{}
+
+	mu      sync.Mutex
+	batch   Batch
+	started bool
+	stopped bool
+}
+
+// New returns a Watcher backed by sock_diag destroy notifications (Linux
+// only; Start fails with netlink.ErrUnsupported elsewhere).
+func New() *Watcher {
+	return newWatcher(func() (listener, error) {
+		l, err := netlink.ListenDestroyed()
+		if err != nil {
+			return nil, err
+		}
+		return l, nil
+	})
+}
+
+func newWatcher(listen func() (listener, error)) *Watcher {
+	return &Watcher{listen: listen, done: make(chan struct{})}
+}
+
+// Start subscribes to the notifications and collects them in the background.
+// It returns the subscription error, in which case nothing is started.
+// Starting a stopped or already running watcher is a no-op.
+func (w *Watcher) Start() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.started || w.stopped {
+		return nil
+	}
+	l, err := w.listen()
+	if err != nil {
+		return err
+	}
+	w.started = true
+	go w.run(l)
+	return nil
+}
+
+// Stop ends collection. The subscription closes within one read timeout.
+func (w *Watcher) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stopped {
+		return
+	}
+	w.stopped = true
+	close(w.done)
+}
+
+// Drain returns the flows collected since the previous call and resets the buffer.
+func (w *Watcher) Drain() Batch {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	b := w.batch
+	w.batch = Batch{}
+	return b
+}
+
+func (w *Watcher) run(l listener) {
+	defer func() { _ = l.Close() }()
+	for {
+		select {
+		case <-w.done:
+			return
+		default:
+		}
+		sockets, err := l.Read()
+		now := time.Now()
+
+		w.mu.Lock()
+		if err != nil {
+			w.batch.Err = err
+		}
+		for _, s := range sockets {
+			f, ok := flowOf(s, now)
+			if !ok {
+				continue
+			}
+			if len(w.batch.Flows) >= maxPending {
+				w.batch.Dropped++
+				continue
+			}
+			w.batch.Flows = append(w.batch.Flows, f)
+		}
+		w.mu.Unlock()
+
+		if err != nil && !errors.Is(err, unix.ENOBUFS) {
+			// The subscription is broken; Read would fail again at once
+			return
+		}
+	}
+}
+
+// flowOf converts a destroyed socket to a Flow. Sockets without a peer
+// (listeners, unconnected UDP) are not flows.
+func flowOf(s netlink.Socket, at time.Time) (Flow, bool) {
+	if s.ID.Remote.Port() == 0 || !s.ID.Remote.Addr().IsValid() || s.ID.Remote.Addr().IsUnspecified() {
+		return Flow{}, false
+	}
+	var proto model.Protocol
+	switch s.Protocol {
+	case unix.IPPROTO_TCP:
+		proto = model.ProtocolTCP
+	case unix.IPPROTO_UDP:
+		proto = model.ProtocolUDP
+	default:
+		return Flow{}, false
+	}
+	return Flow{Protocol: proto, Local: s.ID.Local, Remote: s.ID.Remote, UID: s.UID, ClosedAt: at}, true
+}
+
+// flowKey identifies a connection across snapshots and notifications.
+type flowKey struct {
+	protocol model.Protocol
+	id       netlink.SocketID
+}
+
+// Classify returns the flows none of the snapshots list: connections that
+// opened and closed between them. Pass the snapshots before and after the
+// flows were drained, so connections closing right after a snapshot, or
+// opening just before it, are not counted.
+func Classify(flows []Flow, snapshots ...*model.NetworkSnapshot) []Flow {
+	seen := make(map[flowKey]bool)
+	for _, snap := range snapshots {
+		if snap == nil {
+			continue
+		}
+		for _, app := range snap.Applications {
+			for _, conn := range app.Connections {
+				id, err := netlink.ParseSocketID(conn.LocalAddr, conn.RemoteAddr)
+				if err != nil {
+					continue
+				}
+				seen[flowKey{conn.Protocol, id}] = true
+			}
+		}
+	}
+
+	var out []Flow
+	for _, f := range flows {
+		id := netlink.SocketID{Local: netip.AddrPortFrom(f.Local.Addr().Unmap(), f.Local.Port()), Remote: netip.AddrPortFrom(f.Remote.Addr().Unmap(), f.Remote.Port())}
+		if !seen[flowKey{f.Protocol, id}] {
+			out = append(out, f)
+		}
+	}
+	return out
+}
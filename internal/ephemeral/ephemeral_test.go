@@ -0,0 +1,208 @@
+package ephemeral
+
+import (
+	"errors"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/kostyay/netmon/internal/model"
+	"github.com/kostyay/netmon/internal/netlink"
+)
+
+func destroyed(protocol uint8, local, remote string) netlink.Socket {
+	return netlink.Socket{
+		ID: netlink.SocketID{
+			Local:  netip.MustParseAddrPort(local),
+			Remote: netip.MustParseAddrPort(remote),
+		},
+		Protocol: protocol,
+		UID:      1000,
+	}
+}
+
+// fakeListener hands out queued batches of destroyed sockets, one per Read.
+type fakeListener struct {
+	mu      sync.Mutex
+	batches [][]netlink.Socket
+	err     error
+	closed  bool
+}
+
+func (l *fakeListener) Read() ([]netlink.Socket, error) {
+	time.Sleep(time.Millisecond)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err != nil {
+		err := l.err
+		l.err = nil
+		return nil, err
+	}
+	if len(l.batches) == 0 {
+		return nil, nil
+	}
+	b := l.batches[0]
+	l.batches = l.batches[1:]
+	return b, nil
+}
+
+func (l *fakeListener) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.closed = true
+	return nil
+}
+
+func (l *fakeListener) isClosed() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.closed
+}
+
+// drainUntil drains w until it returned n flows or a second passed.
+func drainUntil(t *testing.T, w *Watcher, n int) []Flow {
+	t.Helper()
+	var flows []Flow
+	deadline := time.Now().Add(time.Second)
+	for len(flows) < n && time.Now().Before(deadline) {
+		flows = append(flows, w.Drain().Flows...)
+		time.Sleep(time.Millisecond)
+	}
+	return flows
+}
+
+func TestWatcher_CollectsFlowsWithAPeer(t *testing.T) {
+	l := &fakeListener{batches: [][]netlink.Socket{{
+		destroyed(unix.IPPROTO_TCP, "10.0.0.1:50000", "1.1.1.1:443"),
+		destroyed(unix.IPPROTO_TCP, "0.0.0.0:8080", "0.0.0.0:0"), // listener
+		destroyed(unix.IPPROTO_UDP, "10.0.0.1:40000", "8.8.8.8:53"),
+	}}}
+	w := newWatcher(func() (listener, error) { return l, nil })
+	if err := w.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+
+	flows := drainUntil(t, w, 2)
+	if len(flows) != 2 {
+		t.Fatalf("got %d flows, want the 2 with a peer: %+v", len(flows), flows)
+	}
+	if flows[0].Protocol != model.ProtocolTCP || flows[1].Protocol != model.ProtocolUDP || flows[0].UID != 1000 {
+		t.Errorf("flows = %+v", flows)
+	}
+	if len(w.Drain().Flows) != 0 {
+		t.Error("Drain should reset the buffer")
+	}
+}
+
+func TestWatcher_StartError(t *testing.T) {
+	w := newWatcher(func() (listener, error) { return nil, netlink.ErrUnsupported })
+	if err := w.Start(); !errors.Is(err, netlink.ErrUnsupported) {
+		t.Errorf("Start() = %v, want ErrUnsupported", err)
+	}
+}
+
+func TestWatcher_StopClosesSubscription(t *testing.T) {
+	l := &fakeListener{}
+	w := newWatcher(func() (listener, error) { return l, nil })
+	if err := w.Start(); err != nil {
+		t.Fatal(err)
+	}
+	w.Stop()
+	w.Stop() // idempotent
+
+	deadline := time.Now().Add(time.Second)
+	for !l.isClosed() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if !l.isClosed() {
+		t.Error("subscription should be closed after Stop")
+	}
+}
+
+func TestWatcher_ReportsLostNotifications(t *testing.T) {
+	l := &fakeListener{err: unix.ENOBUFS, batches: [][]netlink.Socket{{
+		destroyed(unix.IPPROTO_TCP, "10.0.0.1:50000", "1.1.1.1:443"),
+	}}}
+	w := newWatcher(func() (listener, error) { return l, nil })
+	if err := w.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+
+	var got Batch
+	deadline := time.Now().Add(time.Second)
+	for len(got.Flows) == 0 && time.Now().Before(deadline) {
+		b := w.Drain()
+		got.Flows = append(got.Flows, b.Flows...)
+		got.Err = errors.Join(got.Err, b.Err)
+		time.Sleep(time.Millisecond)
+	}
+	if !errors.Is(got.Err, unix.ENOBUFS) {
+		t.Errorf("Err = %v, want ENOBUFS", got.Err)
+	}
+	if len(got.Flows) != 1 {
+		t.Errorf("reading should go on after ENOBUFS, got %d flows", len(got.Flows))
+	}
+}
+
+func TestWatcher_CapsPending(t *testing.T) {
+	batch := make([]netlink.Socket, maxPending+5)
+	for i := range batch {
+		batch[i] = destroyed(unix.IPPROTO_TCP, "10.0.0.1:50000", "1.1.1.1:443")
+	}
+	l := &fakeListener{batches: [][]netlink.Socket{batch}}
+	w := newWatcher(func() (listener, error) { return l, nil })
+	if err := w.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		w.mu.Lock()
+		n := len(w.batch.Flows)
+		w.mu.Unlock()
+		if n > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	b := w.Drain()
+	if len(b.Flows) != maxPending || b.Dropped != 5 {
+		t.Errorf("got %d flows, %d dropped; want %d, 5", len(b.Flows), b.Dropped, maxPending)
+	}
+}
+
+func TestClassify(t *testing.T) {
+	prev := &model.NetworkSnapshot{Applications: []model.Application{{
+		Name: "chrome",
+		Connections: []model.Connection{
+			{Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.1:50000", RemoteAddr: "1.1.1.1:443"},
+		},
+	}}}
+	curr := &model.NetworkSnapshot{Applications: []model.Application{{
+		Name: "curl",
+		Connections: []model.Connection{
+			{Protocol: model.ProtocolTCP, LocalAddr: "::ffff:10.0.0.1:50001", RemoteAddr: "::ffff:2.2.2.2:443"},
+			{Protocol: model.ProtocolTCP, LocalAddr: "*:8080", RemoteAddr: "*:*"},
+		},
+	}}}
+	flow := func(proto model.Protocol, local, remote string) Flow {
+		return Flow{Protocol: proto, Local: netip.MustParseAddrPort(local), Remote: netip.MustParseAddrPort(remote)}
+	}
+	flows := []Flow{
+		flow(model.ProtocolTCP, "10.0.0.1:50000", "1.1.1.1:443"), // closed after prev
+		flow(model.ProtocolTCP, "10.0.0.1:50001", "2.2.2.2:443"), // still open in curr (mapped form)
+		flow(model.ProtocolUDP, "10.0.0.1:50000", "1.1.1.1:443"), // same tuple, other protocol
+		flow(model.ProtocolTCP, "10.0.0.1:50002", "3.3.3.3:80"),  // never seen
+	}
+
+	got := Classify(flows, prev, curr, nil)
+	if len(got) != 2 || got[0].Protocol != model.ProtocolUDP || got[1].Remote.Port() != 80 {
+		t.Errorf("Classify() = %+v, want the UDP flow and the one to 3.3.3.3:80", got)
+	}
+}
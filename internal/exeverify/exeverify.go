@@ -0,0 +1,86 @@
+// Package exeverify fingerprints a process's executable for triage: the SHA256
+// of the binary and, on macOS, whether its code signature is intact.
+package exeverify
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"strings"
+)
+
+// Signature is the outcome of a code signature check.
+type Signature int
+
+const (
+	SigUnchecked Signature = iota // no signature check on this platform
+	SigValid                      // signed and the signature verifies
+	SigUnsigned                   // no signature at all
+	SigInvalid                    // signed, but modified or otherwise failing
+)
+
+// String returns the label shown in the detail pane.
+func (s Signature) String() string {
+	switch s {
+	case SigValid:
+		return "valid"
+	case SigUnsigned:
+		return "unsigned"
+	case SigInvalid:
+		return "INVALID"
+	}
+	return "not checked"
+}
+
+// Result is what could be established about an executable. The hash and the
+// signature check fail independently.
+type Result struct {
+	Path      string // executable path as reported for the process
+	SHA256    string // hex digest ("" when HashErr is set)
+	HashErr   error
+	Signature Signature
+	SigDetail string // verifier's reason for SigInvalid
+}
+
+// Verify hashes the executable of pid and checks its signature. Where the
+// platform exposes the running image (/proc/<pid>/exe on Linux) that is what
+// gets hashed, so a binary replaced or deleted since launch is still caught.
+func Verify(pid int32, path string) Result {
+	r := Result{Path: path}
+	r.SHA256, r.HashErr = hashFile(imagePath(pid, path))
+	r.Signature, r.SigDetail = checkSignature(path)
+	return r
+}
+
+// hashFile returns the hex SHA256 of the file at path.
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path) // #nosec G304 - path is a process executable
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// classifyCodesign interprets `codesign --verify` output; failed is whether
+// the command exited non-zero.
+func classifyCodesign(out string, failed bool) (Signature, string) {
+	if !failed {
+		return SigValid, ""
+	}
+	out = strings.TrimSpace(out)
+	if strings.Contains(out, "not signed at all") {
+		return SigUnsigned, ""
+	}
+	// "<path>: a sealed resource is missing or invalid" -> reason only
+	out, _, _ = strings.Cut(out, "\n")
+	if i := strings.LastIndex(out, ": "); i >= 0 {
+		out = out[i+2:]
+	}
+	return SigInvalid, out
+}
@@ -0,0 +1,22 @@
+//go:build linux
+
+package exeverify
+
+import "fmt"
+
+// procRoot is the proc filesystem mount (replaced in tests).
+var procRoot = "/proc"
+
+// imagePath returns /proc/<pid>/exe, which opens the image the process is
+// running even when the path on disk was replaced or deleted.
+func imagePath(pid int32, path string) string {
+	if pid <= 0 {
+		return path
+	}
+	return fmt.Sprintf("%s/%d/exe", procRoot, pid)
+}
+
+// checkSignature is a no-op: ELF binaries carry no signature to verify.
+func checkSignature(string) (Signature, string) {
+	return SigUnchecked, ""
+}
@@ -0,0 +1,28 @@
+//go:build linux
+
+package exeverify
+
+import (
+	"os"
+	"testing"
+)
+
+func TestVerify_HashesRunningImage(t *testing.T) {
+	exe, err := os.Executable()
+	if err != nil {
+		t.Skip(err)
+	}
+	want, err := hashFile(exe)
+	if err != nil {
+		t.Skip(err)
+	}
+
+	// A stale path still hashes the image the process is running
+	r := Verify(int32(os.Getpid()), "/nonexistent/old-binary")
+	if r.HashErr != nil || r.SHA256 != want {
+		t.Errorf("Verify = %q, %v; want %q", r.SHA256, r.HashErr, want)
+	}
+	if r.Signature != SigUnchecked {
+		t.Errorf("signature = %v, want unchecked on Linux", r.Signature)
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !linux && !darwin
+
+package exeverify
+
+// imagePath returns the executable path unchanged.
+func imagePath(_ int32, path string) string {
+	return path
+}
+
+// checkSignature is unsupported on this platform.
+func checkSignature(string) (Signature, string) {
+	return SigUnchecked, ""
+}
@@ -0,0 +1,44 @@
+package exeverify
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHashFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bin")
+	if err := os.WriteFile(path, []byte("abc"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := hashFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"; got != want {
+		t.Errorf("hashFile = %s, want %s", got, want)
+	}
+	if _, err := hashFile(filepath.Join(t.TempDir(), "missing")); !os.IsNotExist(err) {
+		t.Errorf("missing file err = %v, want not-exist", err)
+	}
+}
+
+func TestClassifyCodesign(t *testing.T) {
+	tests := []struct {
+		out        string
+		failed     bool
+		want       Signature
+		wantDetail string
+	}{
+		{"", false, SigValid, ""},
+		{"/usr/local/bin/foo: code object is not signed at all\n", true, SigUnsigned, ""},
+		{"/Applications/X.app/Contents/MacOS/X: a sealed resource is missing or invalid\nfile modified: …\n", true, SigInvalid, "a sealed resource is missing or invalid"},
+		{"/tmp/foo: invalid signature (code or signature have been modified)\n", true, SigInvalid, "invalid signature (code or signature have been modified)"},
+	}
+	for _, tt := range tests {
+		got, detail := classifyCodesign(tt.out, tt.failed)
+		if got != tt.want || detail != tt.wantDetail {
+			t.Errorf("classifyCodesign(%q) = %v, %q; want %v, %q", tt.out, got, detail, tt.want, tt.wantDetail)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+// Package filter matches connections and processes against filter chips, the
+// syntax shared by the TUI's search (/) and `netmon agent`'s filter command:
+// case-insensitive substrings of the process name, PID, addresses, protocol
+// and state, plus the prefixed forms ~fuzzy, tree:PID, geo:CC, asn:N,
+// iface:NAME and domain:NAME.
+package filter
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Filter prefixes. A chip starting with one matches only the field it names.
+const (
+	ASNPrefix    = "asn:"    // asn:15169 or asn:15169,16509, the remote IP's autonomous system
+	DomainPrefix = "domain:" // domain:amazonaws.com, the remote host or a subdomain of it
+	GeoPrefix    = "geo:"    // geo:CN or geo:CN,RU, the remote IP's country
+	IfacePrefix  = "iface:"  // iface:utun3, the connection's interface
+	TreePrefix   = "tree:"   // tree:4242, a process and everything it spawned
+)
+
+// GeoUnknown is the country of remote IPs that are private, loopback or not
+// in the GeoIP table.
+const GeoUnknown = "--"
+
+// Fields holds all filterable fields for a connection or process.
+type Fields struct {
+	ProcessName string
+	PIDs        []int32
+	LocalAddr   string
+	RemoteAddr  string
+	Protocol    string
+	State       string
+	Notes       []string // user notes on the process or remote IP
+	ASN         uint32   // AS number of the remote IP; matched by asn:N only
+	Interface   string   // network interface of the connection; matched by iface:NAME only
+	Via         string   // local proxy label, e.g. "api.github.com:443 via mitmproxy"
+	Lineage     []int32  // PIDs and their ancestors; matched by tree:PID only
+	Country     string   // GeoIP country code of the remote IP; matched by geo:CC only
+	Host        string   // resolved name (or IP) of the remote address; matched by domain:NAME only
+}
+
+// MatchAll reports whether fields match every filter. The filter equal to
+// exactFilter (the CLI argument) uses exact port matching, the rest substring matching.
+func MatchAll(filters []string, fields Fields, exactFilter string) bool {
+	for _, f := range filters {
+		if !Match(f, fields, exactFilter != "" && f == exactFilter) {
+			return false
+		}
+	}
+	return true
+}
+
+// Match checks if any field contains the search string (case-insensitive).
+// When exactPortMatch is true (CLI filters), ONLY matches exact port numbers.
+// When false (interactive search), matches process name, PID, addresses, protocol, state, and ports via substring.
+func Match(filter string, fields Fields, exactPortMatch bool) bool {
+	if filter == "" {
+		return true
+	}
+
+	// asn:N (or asn:N,N) matches the autonomous system of the remote IP
+	if nums, ok := ASNNumbers(filter); ok {
+		return fields.ASN != 0 && slices.Contains(nums, fields.ASN)
+	}
+
+	// domain:NAME matches remote hosts named NAME or under it (unresolved: the IP)
+	if domain, ok := DomainName(filter); ok {
+		return fields.Host != "" && InDomain(fields.Host, domain)
+	}
+
+	// ~pattern matches the process name fuzzily (in order, gaps allowed); rows
+	// without a process name (a process's own connections) match it as a substring
+	if pattern, ok := FuzzyPattern(filter); ok {
+		if fields.ProcessName != "" {
+			_, _, matched := FuzzyMatch(pattern, fields.ProcessName)
+			return matched
+		}
+		filter = pattern
+	}
+
+	// tree:PID matches the process and everything it spawned
+	if root, ok := TreeRoot(filter); ok {
+		return slices.Contains(fields.Lineage, root)
+	}
+
+	// geo:CC (or geo:CC,CC) matches the remote IP's country
+	if countries, ok := GeoCountries(filter); ok {
+		return fields.Country != "" && slices.Contains(countries, fields.Country)
+	}
+
+	// iface:NAME matches the connection's interface exactly
+	if name, ok := strings.CutPrefix(strings.ToLower(filter), IfacePrefix); ok {
+		return fields.Interface != "" && strings.EqualFold(fields.Interface, name)
+	}
+
+	// CLI exact port matching - only match port numbers
+	if exactPortMatch {
+		ports := Ports(fields.LocalAddr, fields.RemoteAddr)
+		for _, port := range ports {
+			if strconv.Itoa(port) == filter {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Interactive search - substring match on all fields
+	filterLower := strings.ToLower(filter)
+
+	// Match process name
+	if fields.ProcessName != "" && strings.Contains(strings.ToLower(fields.ProcessName), filterLower) {
+		return true
+	}
+
+	// Match any PID
+	for _, pid := range fields.PIDs {
+		if strings.Contains(strconv.Itoa(int(pid)), filter) {
+			return true
+		}
+	}
+
+	// Match local address
+	if fields.LocalAddr != "" && strings.Contains(strings.ToLower(fields.LocalAddr), filterLower) {
+		return true
+	}
+
+	// Match remote address
+	if fields.RemoteAddr != "" && strings.Contains(strings.ToLower(fields.RemoteAddr), filterLower) {
+		return true
+	}
+
+	// Match protocol
+	if fields.Protocol != "" && strings.Contains(strings.ToLower(fields.Protocol), filterLower) {
+		return true
+	}
+
+	// Match state
+	if fields.State != "" && strings.Contains(strings.ToLower(fields.State), filterLower) {
+		return true
+	}
+
+	// Match the destination behind a local proxy
+	if fields.Via != "" && strings.Contains(strings.ToLower(fields.Via), filterLower) {
+		return true
+	}
+
+	// Match notes
+	for _, note := range fields.Notes {
+		if strings.Contains(strings.ToLower(note), filterLower) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Ports parses port numbers from address strings like "127.0.0.1:8080".
+func Ports(addrs ...string) []int {
+	var ports []int
+	for _, addr := range addrs {
+		if idx := strings.LastIndex(addr, ":"); idx != -1 {
+			if port, err := strconv.Atoi(addr[idx+1:]); err == nil {
+				ports = append(ports, port)
+			}
+		}
+	}
+	return ports
+}
+
+// ASNNumbers parses an "asn:15169" or "asn:15169,16509" filter; an "AS"
+// prefix on the numbers is accepted.
+func ASNNumbers(filter string) ([]uint32, bool) {
+	s, ok := strings.CutPrefix(strings.ToLower(strings.TrimSpace(filter)), ASNPrefix)
+	if !ok || s == "" {
+		return nil, false
+	}
+	var nums []uint32
+	for _, f := range strings.Split(s, ",") {
+		n, err := strconv.ParseUint(strings.TrimPrefix(strings.TrimSpace(f), "as"), 10, 32)
+		if err != nil {
+			return nil, false
+		}
+		nums = append(nums, uint32(n))
+	}
+	return nums, true
+}
+
+// DomainName parses a "domain:amazonaws.com" filter; a leading "*." is
+// accepted.
+func DomainName(filter string) (string, bool) {
+	s, ok := strings.CutPrefix(strings.ToLower(strings.TrimSpace(filter)), DomainPrefix)
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "*."), ".")
+	return s, ok && s != ""
+}
+
+// InDomain reports whether host is domain or one of its subdomains.
+func InDomain(host, domain string) bool {
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// GeoCountries parses a "geo:CN" or "geo:CN,RU" filter.
+func GeoCountries(filter string) ([]string, bool) {
+	s, ok := strings.CutPrefix(strings.ToUpper(strings.TrimSpace(filter)), strings.ToUpper(GeoPrefix))
+	if !ok || s == "" {
+		return nil, false
+	}
+	return strings.Split(s, ","), true
+}
+
+// TreeRoot parses a "tree:PID" filter.
+func TreeRoot(filter string) (int32, bool) {
+	s, ok := strings.CutPrefix(strings.ToLower(strings.TrimSpace(filter)), TreePrefix)
+	if !ok {
+		return 0, false
+	}
+	pid, err := strconv.ParseInt(s, 10, 32)
+	return int32(pid), err == nil && pid > 0
+}
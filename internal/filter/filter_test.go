@@ -0,0 +1,250 @@
+package filter
+
+import "testing"
+
+// Tests for Match
+
+func TestMatch_EmptyFilter(t *testing.T) {
+	if !Match("", Fields{ProcessName: "Chrome", PIDs: []int32{1234}, LocalAddr: "127.0.0.1:8080"}, false) {
+		t.Error("Empty filter should match everything")
+	}
+}
+
+func TestMatch_ProcessName(t *testing.T) {
+	tests := []struct {
+		filter      string
+		processName string
+		want        bool
+	}{
+		{"chrome", "Chrome", true},
+		{"CHROME", "Chrome", true},
+		{"Chr", "Chrome", true},
+		{"firefox", "Chrome", false},
+		{"ch", "Chrome", true},
+	}
+
+	for _, tt := range tests {
+		got := Match(tt.filter, Fields{ProcessName: tt.processName}, false)
+		if got != tt.want {
+			t.Errorf("Match(%q, processName=%q) = %v, want %v",
+				tt.filter, tt.processName, got, tt.want)
+		}
+	}
+}
+
+func TestMatch_PID(t *testing.T) {
+	tests := []struct {
+		filter string
+		pids   []int32
+		want   bool
+	}{
+		{"1234", []int32{1234}, true},
+		{"123", []int32{1234}, true},      // partial match
+		{"12", []int32{1234, 5678}, true}, // matches first
+		{"56", []int32{1234, 5678}, true}, // matches second
+		{"9999", []int32{1234}, false},
+	}
+
+	for _, tt := range tests {
+		got := Match(tt.filter, Fields{ProcessName: "NoMatch", PIDs: tt.pids}, false)
+		if got != tt.want {
+			t.Errorf("Match(%q, pids=%v) = %v, want %v",
+				tt.filter, tt.pids, got, tt.want)
+		}
+	}
+}
+
+func TestMatch_Port(t *testing.T) {
+	tests := []struct {
+		filter    string
+		localAddr string
+		want      bool
+	}{
+		{"8080", "127.0.0.1:8080", true},
+		{"80", "127.0.0.1:8080", true},    // partial match with exactPortMatch=false
+		{"443", "127.0.0.1:443", true},    // exact
+		{"9999", "127.0.0.1:8080", false}, // no match
+	}
+
+	for _, tt := range tests {
+		got := Match(tt.filter, Fields{ProcessName: "NoMatch", LocalAddr: tt.localAddr}, false)
+		if got != tt.want {
+			t.Errorf("Match(%q, localAddr=%q) = %v, want %v",
+				tt.filter, tt.localAddr, got, tt.want)
+		}
+	}
+}
+
+func TestMatch_Port_ExactMatch(t *testing.T) {
+	tests := []struct {
+		filter    string
+		localAddr string
+		want      bool
+	}{
+		{"8080", "127.0.0.1:8080", true},
+		{"80", "127.0.0.1:8080", false},   // no partial match with exactPortMatch=true
+		{"443", "127.0.0.1:443", true},    // exact match on 443
+		{"9999", "127.0.0.1:8080", false}, // no match
+	}
+
+	for _, tt := range tests {
+		got := Match(tt.filter, Fields{ProcessName: "NoMatch", LocalAddr: tt.localAddr}, true)
+		if got != tt.want {
+			t.Errorf("Match(%q, localAddr=%q, exact=true) = %v, want %v",
+				tt.filter, tt.localAddr, got, tt.want)
+		}
+	}
+}
+
+func TestMatch_Combined(t *testing.T) {
+	// Should match if ANY of the criteria match
+	if !Match("chrome", Fields{ProcessName: "Chrome", PIDs: []int32{1234}, LocalAddr: "127.0.0.1:8080"}, false) {
+		t.Error("Should match on process name")
+	}
+	if !Match("1234", Fields{ProcessName: "Firefox", PIDs: []int32{1234}, LocalAddr: "127.0.0.1:8080"}, false) {
+		t.Error("Should match on PID")
+	}
+	if !Match("8080", Fields{ProcessName: "Firefox", PIDs: []int32{5678}, LocalAddr: "127.0.0.1:8080"}, false) {
+		t.Error("Should match on port")
+	}
+	if Match("nomatch", Fields{ProcessName: "Firefox", PIDs: []int32{5678}, LocalAddr: "127.0.0.1:8080"}, false) {
+		t.Error("Should not match when nothing matches")
+	}
+}
+
+func TestMatch_LocalAddr(t *testing.T) {
+	fields := Fields{
+		LocalAddr:  "127.0.0.1:8080",
+		RemoteAddr: "10.0.0.1:443",
+		Protocol:   "tcp",
+		State:      "ESTABLISHED",
+	}
+	if !Match("127.0.0.1", fields, false) {
+		t.Error("Should match local address")
+	}
+	if !Match("8080", fields, false) {
+		t.Error("Should match local port")
+	}
+}
+
+func TestMatch_RemoteAddr(t *testing.T) {
+	fields := Fields{
+		LocalAddr:  "127.0.0.1:8080",
+		RemoteAddr: "10.0.0.1:443",
+		Protocol:   "tcp",
+		State:      "ESTABLISHED",
+	}
+	if !Match("10.0.0.1", fields, false) {
+		t.Error("Should match remote address")
+	}
+	if !Match("443", fields, false) {
+		t.Error("Should match remote port")
+	}
+}
+
+func TestMatch_Protocol(t *testing.T) {
+	fields := Fields{
+		LocalAddr:  "127.0.0.1:8080",
+		RemoteAddr: "10.0.0.1:443",
+		Protocol:   "tcp",
+		State:      "ESTABLISHED",
+	}
+	if !Match("tcp", fields, false) {
+		t.Error("Should match protocol")
+	}
+	if !Match("TCP", fields, false) {
+		t.Error("Should match protocol case-insensitively")
+	}
+}
+
+func TestMatch_State(t *testing.T) {
+	fields := Fields{
+		LocalAddr:  "127.0.0.1:8080",
+		RemoteAddr: "10.0.0.1:443",
+		Protocol:   "tcp",
+		State:      "ESTABLISHED",
+	}
+	if !Match("established", fields, false) {
+		t.Error("Should match state case-insensitively")
+	}
+	if !Match("ESTAB", fields, false) {
+		t.Error("Should match partial state")
+	}
+}
+
+func TestMatch_NoMatch(t *testing.T) {
+	fields := Fields{
+		LocalAddr:  "127.0.0.1:8080",
+		RemoteAddr: "10.0.0.1:443",
+		Protocol:   "tcp",
+		State:      "ESTABLISHED",
+	}
+	if Match("nomatch", fields, false) {
+		t.Error("Should not match when nothing matches")
+	}
+}
+
+func TestTreeRoot(t *testing.T) {
+	for filter, want := range map[string]int32{"tree:42": 42, " TREE:7 ": 7, "tree:": 0, "tree:-1": 0, "tree:abc": 0, "42": 0} {
+		got, ok := TreeRoot(filter)
+		if got != want && ok || ok != (want > 0) {
+			t.Errorf("TreeRoot(%q) = %d, %v; want %d", filter, got, ok, want)
+		}
+	}
+}
+
+// Tests for Ports
+
+func TestPorts_SingleAddr(t *testing.T) {
+	ports := Ports("127.0.0.1:8080")
+	if len(ports) != 1 {
+		t.Fatalf("expected 1 port, got %d", len(ports))
+	}
+	if ports[0] != 8080 {
+		t.Errorf("expected port 8080, got %d", ports[0])
+	}
+}
+
+func TestPorts_MultipleAddrs(t *testing.T) {
+	ports := Ports("127.0.0.1:8080", "10.0.0.1:443")
+	if len(ports) != 2 {
+		t.Fatalf("expected 2 ports, got %d", len(ports))
+	}
+	if ports[0] != 8080 || ports[1] != 443 {
+		t.Errorf("expected ports [8080, 443], got %v", ports)
+	}
+}
+
+func TestPorts_IPv6(t *testing.T) {
+	ports := Ports("[::1]:9090")
+	if len(ports) != 1 {
+		t.Fatalf("expected 1 port, got %d", len(ports))
+	}
+	if ports[0] != 9090 {
+		t.Errorf("expected port 9090, got %d", ports[0])
+	}
+}
+
+func TestPorts_Wildcard(t *testing.T) {
+	ports := Ports("*:80")
+	if len(ports) != 1 {
+		t.Fatalf("expected 1 port, got %d", len(ports))
+	}
+	if ports[0] != 80 {
+		t.Errorf("expected port 80, got %d", ports[0])
+	}
+}
+
+func TestPorts_NoPort(t *testing.T) {
+	ports := Ports("*")
+	if len(ports) != 0 {
+		t.Errorf("expected 0 ports for '*', got %d", len(ports))
+	}
+}
+
+func TestPorts_Empty(t *testing.T) {
+	ports := Ports()
+	if len(ports) != 0 {
+		t.Errorf("expected 0 ports for empty input, got %d", len(ports))
+	}
+}
@@ -0,0 +1,111 @@
+package filter
+
+import (
+	"strings"
+	"unicode"
+)
+
+// FuzzyPrefix marks a fuzzy filter chip: "~gochr" matches process names
+// containing g, o, c, h, r in order, such as "Google Chrome Helper".
+const FuzzyPrefix = "~"
+
+// FuzzyPattern returns the pattern of a fuzzy chip, or false for a plain one.
+func FuzzyPattern(filter string) (string, bool) {
+	pattern, ok := strings.CutPrefix(filter, FuzzyPrefix)
+	return pattern, ok && pattern != ""
+}
+
+// Scores in the fzf style: every matched rune counts, runes starting a word
+// or following the previous match count more, and gaps cost a little.
+const (
+	fuzzyScoreMatch       = 16
+	fuzzyBonusBoundary    = 8
+	fuzzyBonusConsecutive = 4
+	fuzzyPenaltyGap       = 1
+)
+
+// FuzzyMatch reports whether the runes of pattern appear in text in order,
+// ignoring case. It returns a score (higher is better) and the byte offsets
+// of the matched runes in text. Like fzf's first pass it finds the earliest
+// end of a match, then scans back for the shortest window ending there.
+func FuzzyMatch(pattern, text string) (int, []int, bool) {
+	pat := []rune(strings.ToLower(pattern))
+	if len(pat) == 0 {
+		return 0, nil, true
+	}
+	runes := []rune(text)
+	lower := []rune(strings.ToLower(text))
+	if len(lower) != len(runes) {
+		lower = runes // case mapping changed the length; match case-sensitively
+	}
+
+	// Forward: the earliest index where the whole pattern has matched.
+	end, p := -1, 0
+	for i, r := range lower {
+		if r == pat[p] {
+			p++
+			if p == len(pat) {
+				end = i
+				break
+			}
+		}
+	}
+	if end < 0 {
+		return 0, nil, false
+	}
+
+	// Backward: the latest start that still matches, for the tightest window.
+	start, p := 0, len(pat)-1
+	for i := end; i >= 0; i-- {
+		if lower[i] == pat[p] {
+			p--
+			if p < 0 {
+				start = i
+				break
+			}
+		}
+	}
+
+	// Forward again within the window, recording positions and score.
+	offsets := runeOffsets(text)
+	var positions []int
+	score, p, last := 0, 0, -1
+	for i := start; i <= end && p < len(pat); i++ {
+		if lower[i] != pat[p] {
+			continue
+		}
+		score += fuzzyScoreMatch
+		if i == 0 || isWordBoundary(runes[i-1], runes[i]) {
+			score += fuzzyBonusBoundary
+		}
+		if last >= 0 {
+			if i == last+1 {
+				score += fuzzyBonusConsecutive
+			} else {
+				score -= fuzzyPenaltyGap * (i - last - 1)
+			}
+		}
+		positions = append(positions, offsets[i])
+		last = i
+		p++
+	}
+	return score, positions, true
+}
+
+// isWordBoundary reports whether cur starts a word: after a separator or at
+// a lower-to-upper case change ("GoogleChrome").
+func isWordBoundary(prev, cur rune) bool {
+	if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+		return true
+	}
+	return unicode.IsLower(prev) && unicode.IsUpper(cur)
+}
+
+// runeOffsets returns the byte offset of each rune in s.
+func runeOffsets(s string) []int {
+	offsets := make([]int, 0, len(s))
+	for i := range s {
+		offsets = append(offsets, i)
+	}
+	return offsets
+}
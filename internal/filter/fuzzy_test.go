@@ -0,0 +1,33 @@
+package filter
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	score, pos, ok := FuzzyMatch("gochr", "Google Chrome Helper")
+	if !ok {
+		t.Fatal("gochr should match Google Chrome Helper")
+	}
+	if want := []int{0, 1, 7, 8, 9}; !slices.Equal(pos, want) {
+		t.Errorf("positions = %v, want %v", pos, want)
+	}
+
+	// A tight, word-aligned match beats a scattered one
+	scattered, _, ok := FuzzyMatch("gochr", "gxoxxcxxhxxxr")
+	if !ok || scattered >= score {
+		t.Errorf("scattered score %d should be below %d", scattered, score)
+	}
+
+	for _, text := range []string{"Chrome", "hcorg", ""} {
+		if _, _, ok := FuzzyMatch("gochr", text); ok {
+			t.Errorf("gochr should not match %q", text)
+		}
+	}
+
+	// Byte offsets stay correct after multibyte runes
+	if _, pos, ok := FuzzyMatch("ab", "ä-a-b"); !ok || !slices.Equal(pos, []int{3, 5}) {
+		t.Errorf("positions = %v, want [3 5]", pos)
+	}
+}
@@ -0,0 +1,172 @@
+// Package fixtures loads network snapshots for tests from YAML or JSON files,
+// so test data reads like the tables it ends up in rather than nested struct
+// literals. Shared fixtures live in snapshots/ and are embedded; Load reads
+// any other file.
+//
+//	timestamp: 2026-10-15T09:00:00Z
+//	applications:
+//	  - name: nginx
+//	    exe: /usr/sbin/nginx
+//	    pids: [812, 813]
+//	    connections:
+//	      - {proto: TCP, local: "0.0.0.0:80", remote: "*", state: LISTEN, accept: {queued: 2, backlog: 511}}
+//	      - {pid: 813, proto: TCP, local: "10.0.0.5:80", remote: "10.0.0.9:51234", state: ESTABLISHED, tcp: {rtt: 12ms}}
+//
+// A connection's pid defaults to the application's first PID and its state to
+// "-" (UDP, raw). EstablishedCount and ListenCount are computed as the
+// collector does.
+package fixtures
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+//go:embed snapshots/*.yaml
+var snapshots embed.FS
+
+// file is the on-disk layout of a snapshot fixture.
+type file struct {
+	Timestamp    time.Time     `yaml:"timestamp"`
+	Applications []application `yaml:"applications"`
+	Skipped      int           `yaml:"skipped"`
+	Hidden       int           `yaml:"hidden"`
+}
+
+type application struct {
+	Name        string       `yaml:"name"`
+	Exe         string       `yaml:"exe"`
+	PIDs        []int32      `yaml:"pids"`
+	Connections []connection `yaml:"connections"`
+}
+
+type connection struct {
+	PID    int32  `yaml:"pid"`
+	Proto  string `yaml:"proto"`
+	Local  string `yaml:"local"`
+	Remote string `yaml:"remote"`
+	State  string `yaml:"state"`
+	FD     uint32 `yaml:"fd"`
+	Accept *struct {
+		Queued  uint32 `yaml:"queued"`
+		Backlog uint32 `yaml:"backlog"`
+	} `yaml:"accept"`
+	TCP *struct {
+		RTT     time.Duration `yaml:"rtt"`
+		RTTVar  time.Duration `yaml:"rttvar"`
+		Retrans uint32        `yaml:"retrans"`
+	} `yaml:"tcp"`
+}
+
+// Parse decodes a snapshot fixture. JSON is valid YAML, so both formats
+// are accepted. Unknown fields are an error, to catch typos in test data.
+func Parse(data []byte) (*model.NetworkSnapshot, error) {
+	var f file
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&f); err != nil {
+		return nil, err
+	}
+
+	snap := &model.NetworkSnapshot{
+		Timestamp:    f.Timestamp,
+		SkippedCount: f.Skipped,
+		HiddenCount:  f.Hidden,
+		Applications: make([]model.Application, 0, len(f.Applications)),
+	}
+	for _, a := range f.Applications {
+		if a.Name == "" {
+			return nil, fmt.Errorf("application without a name")
+		}
+		app := model.Application{Name: a.Name, Exe: a.Exe, PIDs: a.PIDs}
+		for i, c := range a.Connections {
+			conn, err := c.toModel(app.PIDs)
+			if err != nil {
+				return nil, fmt.Errorf("%s connection %d: %w", a.Name, i+1, err)
+			}
+			switch conn.State {
+			case model.StateEstablished:
+				app.EstablishedCount++
+			case model.StateListen:
+				app.ListenCount++
+			}
+			app.Connections = append(app.Connections, conn)
+		}
+		snap.Applications = append(snap.Applications, app)
+	}
+	return snap, nil
+}
+
+// toModel converts a fixture connection, defaulting its PID to the
+// application's first.
+func (c connection) toModel(pids []int32) (model.Connection, error) {
+	conn := model.Connection{
+		PID:        c.PID,
+		Protocol:   model.Protocol(c.Proto),
+		LocalAddr:  c.Local,
+		RemoteAddr: c.Remote,
+		State:      model.ConnectionState(c.State),
+		FD:         c.FD,
+	}
+	if conn.PID == 0 && len(pids) > 0 {
+		conn.PID = pids[0]
+	}
+	if conn.State == "" {
+		conn.State = model.StateNone
+	}
+	switch conn.Protocol {
+	case model.ProtocolTCP, model.ProtocolUDP, model.ProtocolICMP, model.ProtocolRaw:
+	default:
+		return conn, fmt.Errorf("unknown proto %q", c.Proto)
+	}
+	if conn.LocalAddr == "" {
+		return conn, fmt.Errorf("missing local address")
+	}
+	if conn.RemoteAddr == "" {
+		conn.RemoteAddr = "*"
+	}
+	if q := c.Accept; q != nil {
+		conn.Accept = &model.AcceptQueue{Queued: q.Queued, Backlog: q.Backlog}
+	}
+	if s := c.TCP; s != nil {
+		conn.TCP = &model.TCPStats{RTT: s.RTT, RTTVar: s.RTTVar, Retrans: s.Retrans}
+	}
+	return conn, nil
+}
+
+// Load reads the snapshot fixture at path.
+func Load(path string) (*model.NetworkSnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	snap, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return snap, nil
+}
+
+// Snapshot returns the embedded fixture snapshots/<name>.yaml, failing the
+// test if it is missing or invalid. Each call returns a fresh copy that the
+// test may modify.
+func Snapshot(tb testing.TB, name string) *model.NetworkSnapshot {
+	tb.Helper()
+	data, err := snapshots.ReadFile("snapshots/" + name + ".yaml")
+	if err != nil {
+		tb.Fatalf("fixture %q: %v", name, err)
+	}
+	snap, err := Parse(data)
+	if err != nil {
+		tb.Fatalf("fixture %q: %v", name, err)
+	}
+	return snap
+}
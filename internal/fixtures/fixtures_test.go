@@ -0,0 +1,75 @@
+package fixtures
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+func TestSnapshot_Workstation(t *testing.T) {
+	snap := Snapshot(t, "workstation")
+	if len(snap.Applications) != 5 || snap.TotalConnections() != 13 {
+		t.Fatalf("got %d apps, %d connections", len(snap.Applications), snap.TotalConnections())
+	}
+
+	chrome := snap.Applications[0]
+	if chrome.EstablishedCount != 2 || chrome.ListenCount != 0 {
+		t.Errorf("chrome counts = %d established, %d listening", chrome.EstablishedCount, chrome.ListenCount)
+	}
+	if c := chrome.Connections[0]; c.PID != 1200 || c.TCP == nil || c.TCP.RTT != 18*time.Millisecond {
+		t.Errorf("first chrome connection = %+v, want PID defaulted to 1200 and an 18ms RTT", c)
+	}
+	if c := chrome.Connections[2]; c.PID != 1201 || c.State != model.StateNone {
+		t.Errorf("UDP connection = %+v, want PID 1201 and no state", c)
+	}
+	if q := snap.Applications[1].Connections[1].Accept; q == nil || !q.Saturated() {
+		t.Errorf("nginx :443 accept queue = %+v, want saturated", q)
+	}
+}
+
+func TestSnapshot_FreshCopy(t *testing.T) {
+	a := Snapshot(t, "workstation")
+	a.Applications[0].Name = "changed"
+	if b := Snapshot(t, "workstation"); b.Applications[0].Name != "chrome" {
+		t.Error("changes to one snapshot should not leak into the next")
+	}
+}
+
+func TestLoad_JSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snap.json")
+	data := `{"applications": [{"name": "sshd", "pids": [22], "connections": [
+		{"proto": "TCP", "local": "0.0.0.0:22", "state": "LISTEN"}]}]}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	snap, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := snap.Applications[0].Connections[0]
+	if c.PID != 22 || c.RemoteAddr != "*" || snap.Applications[0].ListenCount != 1 {
+		t.Errorf("connection = %+v", c)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, tt := range []struct {
+		name, data, want string
+	}{
+		{"unknown field", "applications: [{name: a, cmd: x}]", "field cmd not found"},
+		{"unknown proto", "applications: [{name: a, connections: [{proto: SCTP, local: x}]}]", `a connection 1: unknown proto "SCTP"`},
+		{"missing local", "applications: [{name: a, connections: [{proto: TCP}]}]", "missing local address"},
+		{"no name", "applications: [{pids: [1]}]", "without a name"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+// Package geoip maps remote IPs to the country they are registered in, and
+// with ip2asn-combined to their autonomous system, from a free IP range table:
+// iptoasn.com's ip2country or ip2asn-combined TSV, or DB-IP's "IP to Country
+// Lite" CSV.
+package geoip
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/kostyay/netmon/internal/asn"
+	"github.com/kostyay/netmon/internal/iprange"
+)
+
+// Record is what the table knows about a range: the ISO 3166-1 alpha-2
+// country code it is registered in, and the autonomous system announcing it
+// (ip2asn-combined only).
+type Record struct {
+	Country string
+	AS      asn.Info
+}
+
+// DB is an in-memory IP range table.
+type DB struct {
+	table  *iprange.Table[Record]
+	hasASN bool
+}
+
+// Load parses a range table, one range per line, tab- or comma-separated:
+//
+//	range_start range_end country_code                          (ip2country, DB-IP)
+//	range_start range_end AS_number country_code AS_description (ip2asn-combined)
+//
+// Rows with neither a country ("None", "ZZ") nor an AS (0, unrouted space)
+// are skipped.
+func Load(r io.Reader) (*DB, error) {
+	db := &DB{}
+	var ranges []iprange.Range[Record]
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		sep := "\t"
+		if !strings.Contains(text, sep) {
+			sep = ","
+		}
+		f := strings.Split(text, sep)
+		for i := range f {
+			f[i] = strings.Trim(f[i], `" `)
+		}
+		var rec Record
+		switch len(f) {
+		case 3:
+			rec.Country = f[2]
+		case 5:
+			num, err := strconv.ParseUint(f[2], 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: AS number: %w", line, err)
+			}
+			rec.Country = f[3]
+			if num != 0 {
+				rec.AS = asn.Info{Number: uint32(num), Org: f[4]}
+				db.hasASN = true
+			}
+		default:
+			return nil, fmt.Errorf("line %d: want 3 or 5 fields, got %d", line, len(f))
+		}
+		start, err := netip.ParseAddr(f[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		end, err := netip.ParseAddr(f[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		rec.Country = strings.ToUpper(rec.Country)
+		if len(rec.Country) != 2 || rec.Country == "ZZ" {
+			rec.Country = ""
+		}
+		if rec.Country == "" && rec.AS.Number == 0 {
+			continue
+		}
+		ranges = append(ranges, iprange.Range[Record]{Start: start, End: end, Value: rec})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	db.table = iprange.New(ranges)
+	return db, nil
+}
+
+// LoadFile loads the table at path.
+func LoadFile(path string) (*DB, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	db, err := Load(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return db, nil
+}
+
+// Len returns the number of ranges in the table.
+func (db *DB) Len() int {
+	return db.table.Len()
+}
+
+// HasASN reports whether the table names autonomous systems (ip2asn-combined).
+func (db *DB) HasASN() bool {
+	return db.hasASN
+}
+
+// Country returns the country code ip is registered in, e.g. "DE".
+func (db *DB) Country(ip netip.Addr) (string, bool) {
+	rec, ok := db.table.Lookup(ip)
+	if !ok || rec.Country == "" {
+		return "", false
+	}
+	return rec.Country, true
+}
+
+// ASN returns the autonomous system announcing ip.
+func (db *DB) ASN(ip netip.Addr) (asn.Info, bool) {
+	rec, ok := db.table.Lookup(ip)
+	if !ok || rec.AS.Number == 0 {
+		return asn.Info{}, false
+	}
+	return rec.AS, true
+}
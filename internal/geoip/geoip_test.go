@@ -0,0 +1,92 @@
+package geoip
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/kostyay/netmon/internal/asn"
+)
+
+func TestLoad_Formats(t *testing.T) {
+	for name, table := range map[string]string{
+		"ip2country": "8.8.8.0\t8.8.8.255\tUS\n" +
+			"10.0.0.0\t10.255.255.255\tNone\n" +
+			"5.255.255.0\t5.255.255.255\tnl\n" +
+			"2001:4860::\t2001:4860:ffff:ffff:ffff:ffff:ffff:ffff\tUS\n",
+		"ip2asn-combined": "# iptoasn\n" +
+			"5.255.255.0\t5.255.255.255\t60781\tNL\tLEASEWEB-NL\n" +
+			"8.8.8.0\t8.8.8.255\t15169\tUS\tGOOGLE\n" +
+			"10.0.0.0\t10.255.255.255\t0\tNone\tNot routed\n" +
+			"2001:4860::\t2001:4860:ffff:ffff:ffff:ffff:ffff:ffff\t15169\tUS\tGOOGLE\n",
+		"dbip-csv": "\"8.8.8.0\",\"8.8.8.255\",\"US\"\n" +
+			"5.255.255.0,5.255.255.255,NL\n" +
+			"10.0.0.0,10.255.255.255,ZZ\n" +
+			"2001:4860::,2001:4860:ffff:ffff:ffff:ffff:ffff:ffff,US\n",
+	} {
+		db, err := Load(strings.NewReader(table))
+		if err != nil {
+			t.Fatalf("%s: Load: %v", name, err)
+		}
+		if db.Len() != 3 {
+			t.Errorf("%s: Len = %d, want 3 (unrouted rows skipped)", name, db.Len())
+		}
+		for ip, want := range map[string]string{
+			"8.8.8.8":              "US",
+			"::ffff:5.255.255.7":   "NL",
+			"2001:4860:4860::8888": "US",
+			"10.1.2.3":             "",
+			"1.1.1.1":              "",
+			"::ffff:8.8.4.4":       "",
+		} {
+			got, ok := db.Country(netip.MustParseAddr(ip))
+			if got != want || ok != (want != "") {
+				t.Errorf("%s: Country(%s) = %q, %v; want %q", name, ip, got, ok, want)
+			}
+		}
+	}
+}
+
+func TestLoad_ASN(t *testing.T) {
+	db, err := Load(strings.NewReader("8.8.8.0\t8.8.8.255\t15169\tUS\tGOOGLE\n" +
+		"10.0.0.0\t10.255.255.255\t0\tNone\tNot routed\n" +
+		"192.0.2.0\t192.0.2.255\t64496\tNone\tDOC-AS\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !db.HasASN() || db.Len() != 2 {
+		t.Fatalf("HasASN = %v, Len = %d; want true, 2", db.HasASN(), db.Len())
+	}
+	if info, ok := db.ASN(netip.MustParseAddr("::ffff:8.8.8.8")); !ok || info != (asn.Info{Number: 15169, Org: "GOOGLE"}) {
+		t.Errorf("ASN(8.8.8.8) = %+v, %v", info, ok)
+	}
+	if _, ok := db.Country(netip.MustParseAddr("192.0.2.1")); ok {
+		t.Error("a row without a country should still not have one")
+	}
+	if info, ok := db.ASN(netip.MustParseAddr("192.0.2.1")); !ok || info.Number != 64496 {
+		t.Errorf("ASN(192.0.2.1) = %+v, %v; rows with only an AS are kept", info, ok)
+	}
+	if _, ok := db.ASN(netip.MustParseAddr("10.1.2.3")); ok {
+		t.Error("unrouted rows should be skipped")
+	}
+
+	countries, err := Load(strings.NewReader("8.8.8.0\t8.8.8.255\tUS\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := countries.ASN(netip.MustParseAddr("8.8.8.8")); ok || countries.HasASN() {
+		t.Error("ip2country tables have no AS data")
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	for _, table := range []string{
+		"8.8.8.0\t8.8.8.255\n",
+		"8.8.8.0\tnot-an-ip\tUS\n",
+		"8.8.8.0\t8.8.8.255\tAS15169\tUS\tGOOGLE\n",
+	} {
+		if _, err := Load(strings.NewReader(table)); err == nil || !strings.HasPrefix(err.Error(), "line 1:") {
+			t.Errorf("Load(%q) err = %v, want a line 1 error", table, err)
+		}
+	}
+}
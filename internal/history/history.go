@@ -0,0 +1,161 @@
+// Package history turns snapshots into flow records for long-term storage and
+// builds the queries that answer "which processes talked to 10.1.2.3 yesterday
+// between 14:00 and 15:00". A flow is one process, PID and address pair with
+// the first and last time it was seen, so a store grows as connections come
+// and go rather than with every refresh.
+package history
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// Flow is a connection to a remote peer as seen over time.
+type Flow struct {
+	Process    string    `json:"process"`
+	PID        int32     `json:"pid"`
+	Protocol   string    `json:"protocol"`
+	LocalAddr  string    `json:"localAddr"`
+	RemoteAddr string    `json:"remoteAddr"`
+	RemoteHost string    `json:"remoteHost"` // RemoteAddr without the port, what queries match on
+	FirstSeen  time.Time `json:"firstSeen"`
+	LastSeen   time.Time `json:"lastSeen"`
+}
+
+// Flows returns the snapshot's connections to a remote peer as flows seen at
+// at. Listeners and unconnected sockets have no peer and are left out.
+func Flows(snap *model.NetworkSnapshot, at time.Time) []Flow {
+	if snap == nil {
+		return nil
+	}
+	var out []Flow
+	for _, app := range snap.Applications {
+		for _, conn := range app.Connections {
+			host := remoteHost(conn.RemoteAddr)
+			if conn.State == model.StateListen || host == "" {
+				continue
+			}
+			out = append(out, Flow{
+				Process:    app.Name,
+				PID:        conn.PID,
+				Protocol:   string(conn.Protocol),
+				LocalAddr:  conn.LocalAddr,
+				RemoteAddr: conn.RemoteAddr,
+				RemoteHost: host,
+				FirstSeen:  at,
+				LastSeen:   at,
+			})
+		}
+	}
+	return out
+}
+
+// remoteHost returns the host of a remote address, or "" for no peer
+// ("", "*", "*:*", "0.0.0.0:0").
+func remoteHost(addr string) string {
+	host := addr
+	if i := strings.LastIndex(addr, ":"); i >= 0 {
+		host = addr[:i]
+	}
+	host = strings.Trim(host, "[]")
+	switch host {
+	case "", "*", "0.0.0.0", "::":
+		return ""
+	}
+	return host
+}
+
+// Schema creates the flows table. Times are Unix seconds.
+const Schema = `CREATE TABLE IF NOT EXISTS flows (
+	process     TEXT    NOT NULL,
+	pid         INTEGER NOT NULL,
+	protocol    TEXT    NOT NULL,
+	local_addr  TEXT    NOT NULL,
+	remote_addr TEXT    NOT NULL,
+	remote_host TEXT    NOT NULL,
+	first_seen  INTEGER NOT NULL,
+	last_seen   INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS flows_remote_host ON flows (remote_host, last_seen);
+CREATE INDEX IF NOT EXISTS flows_last_seen ON flows (last_seen);`
+
+// Query selects the flows that were open at some point in [From, To].
+type Query struct {
+	Remote   string // peer IP, matched without the port ("" = any)
+	Process  string // process name ("" = any)
+	From, To time.Time
+}
+
+// SQL returns the statement and arguments selecting the query's flows, in
+// the order they were first seen.
+func (q Query) SQL() (string, []any) {
+	var where []string
+	var args []any
+	if !q.From.IsZero() {
+		where = append(where, "last_seen >= ?")
+		args = append(args, q.From.Unix())
+	}
+	if !q.To.IsZero() {
+		where = append(where, "first_seen <= ?")
+		args = append(args, q.To.Unix())
+	}
+	if q.Remote != "" {
+		where = append(where, "remote_host = ?")
+		args = append(args, strings.Trim(q.Remote, "[]"))
+	}
+	if q.Process != "" {
+		where = append(where, "process = ?")
+		args = append(args, q.Process)
+	}
+	stmt := "SELECT process, pid, protocol, local_addr, remote_addr, remote_host, first_seen, last_seen FROM flows"
+	if len(where) > 0 {
+		stmt += " WHERE " + strings.Join(where, " AND ")
+	}
+	return stmt + " ORDER BY first_seen, process, pid", args
+}
+
+// ParseWindow parses a time window such as "yesterday 14:00-15:00",
+// "14:00-15:30" (today) or "2026-10-14 09:00-17:00", in now's location.
+// An end before the start runs past midnight into the next day.
+func ParseWindow(spec string, now time.Time) (from, to time.Time, err error) {
+	spec = strings.TrimSpace(strings.ReplaceAll(spec, "–", "-"))
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if date, clock, ok := strings.Cut(spec, " "); ok && !strings.Contains(date, ":") {
+		switch date {
+		case "today":
+		case "yesterday":
+			day = day.AddDate(0, 0, -1)
+		default:
+			if day, err = time.ParseInLocation(time.DateOnly, date, now.Location()); err != nil {
+				return from, to, fmt.Errorf("invalid day %q: want today, yesterday or YYYY-MM-DD", date)
+			}
+		}
+		spec = strings.TrimSpace(clock)
+	}
+	start, end, ok := strings.Cut(spec, "-")
+	if !ok {
+		return from, to, fmt.Errorf("invalid window %q: want HH:MM-HH:MM", spec)
+	}
+	if from, err = atClock(day, start); err != nil {
+		return from, to, err
+	}
+	if to, err = atClock(day, end); err != nil {
+		return from, to, err
+	}
+	if !to.After(from) {
+		to = to.AddDate(0, 0, 1)
+	}
+	return from, to, nil
+}
+
+// atClock returns the time of day "HH:MM" on day.
+func atClock(day time.Time, clock string) (time.Time, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(clock))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: want HH:MM", clock)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), 0, 0, day.Location()), nil
+}
@@ -0,0 +1,80 @@
+package history
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+func TestFlows(t *testing.T) {
+	at := time.Date(2026, 10, 14, 14, 30, 0, 0, time.UTC)
+	snap := &model.NetworkSnapshot{Applications: []model.Application{{
+		Name: "curl",
+		Connections: []model.Connection{
+			{PID: 10, Protocol: model.ProtocolTCP, LocalAddr: "192.168.1.5:50000", RemoteAddr: "10.1.2.3:443", State: model.StateEstablished},
+			{PID: 10, Protocol: model.ProtocolTCP, LocalAddr: "[::1]:50001", RemoteAddr: "[2001:db8::1]:443", State: model.StateEstablished},
+			{PID: 10, Protocol: model.ProtocolTCP, LocalAddr: "*:8080", RemoteAddr: "*:*", State: model.StateListen},
+			{PID: 10, Protocol: model.ProtocolUDP, LocalAddr: "0.0.0.0:5353", RemoteAddr: "*"},
+		},
+	}}}
+
+	flows := Flows(snap, at)
+	if len(flows) != 2 {
+		t.Fatalf("got %d flows, want the 2 with a peer: %+v", len(flows), flows)
+	}
+	want := Flow{Process: "curl", PID: 10, Protocol: "TCP", LocalAddr: "192.168.1.5:50000",
+		RemoteAddr: "10.1.2.3:443", RemoteHost: "10.1.2.3", FirstSeen: at, LastSeen: at}
+	if flows[0] != want {
+		t.Errorf("flow = %+v, want %+v", flows[0], want)
+	}
+	if flows[1].RemoteHost != "2001:db8::1" {
+		t.Errorf("IPv6 remote host = %q", flows[1].RemoteHost)
+	}
+}
+
+func TestQuery_SQL(t *testing.T) {
+	from := time.Date(2026, 10, 14, 14, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	stmt, args := Query{Remote: "10.1.2.3", From: from, To: to}.SQL()
+
+	if !strings.HasSuffix(stmt, "FROM flows WHERE last_seen >= ? AND first_seen <= ? AND remote_host = ? ORDER BY first_seen, process, pid") {
+		t.Errorf("stmt = %s", stmt)
+	}
+	if want := []any{from.Unix(), to.Unix(), "10.1.2.3"}; !reflect.DeepEqual(args, want) {
+		t.Errorf("args = %v, want %v", args, want)
+	}
+
+	if stmt, args := (Query{}).SQL(); strings.Contains(stmt, "WHERE") || len(args) != 0 {
+		t.Errorf("empty query: %s %v", stmt, args)
+	}
+}
+
+func TestParseWindow(t *testing.T) {
+	now := time.Date(2026, 10, 15, 9, 12, 0, 0, time.UTC)
+	day := func(d, h, m int) time.Time { return time.Date(2026, 10, d, h, m, 0, 0, time.UTC) }
+	tests := []struct {
+		spec     string
+		from, to time.Time
+	}{
+		{"yesterday 14:00-15:00", day(14, 14, 0), day(14, 15, 0)},
+		{"yesterday 14:00–15:00", day(14, 14, 0), day(14, 15, 0)},
+		{"08:00 - 08:45", day(15, 8, 0), day(15, 8, 45)},
+		{"today 23:30-00:30", day(15, 23, 30), day(16, 0, 30)},
+		{"2026-10-01 09:00-17:00", day(1, 9, 0), day(1, 17, 0)},
+	}
+	for _, tt := range tests {
+		from, to, err := ParseWindow(tt.spec, now)
+		if err != nil || !from.Equal(tt.from) || !to.Equal(tt.to) {
+			t.Errorf("ParseWindow(%q) = %v, %v, %v; want %v, %v", tt.spec, from, to, err, tt.from, tt.to)
+		}
+	}
+
+	for _, spec := range []string{"", "14:00", "monday 14:00-15:00", "14:00-25:00"} {
+		if _, _, err := ParseWindow(spec, now); err == nil {
+			t.Errorf("ParseWindow(%q) should fail", spec)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package history
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	_ "modernc.org/sqlite" // pure Go, keeps the cross-compiles cgo-free
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+const (
+	// DefaultInterval is how often the recorder writes the flows it observed.
+	DefaultInterval = time.Minute
+	// DefaultRetention is how long flows are kept.
+	DefaultRetention = 7 * 24 * time.Hour
+	// pruneInterval is how often flows past retention are deleted.
+	pruneInterval = time.Hour
+)
+
+// Store is a SQLite database of flows.
+type Store struct {
+	db   *sql.DB
+	path string
+}
+
+// Open opens or creates the database at path, creating its directory.
+// The journal is WAL, so `netmon query` can read while a recorder writes.
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(Schema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &Store{db: db, path: path}, nil
+}
+
+// Path returns the database file.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Record writes flows in one transaction. A flow whose row was last seen
+// within gap of its FirstSeen is extended to its LastSeen; otherwise it gets a
+// new row.
+func (s *Store) Record(flows []Flow, gap time.Duration) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	update, err := tx.Prepare(`UPDATE flows SET last_seen = max(last_seen, ?)
+		WHERE remote_host = ? AND last_seen >= ? AND process = ? AND pid = ? AND protocol = ? AND local_addr = ? AND remote_addr = ?`)
+	if err != nil {
+		return err
+	}
+	insert, err := tx.Prepare(`INSERT INTO flows (process, pid, protocol, local_addr, remote_addr, remote_host, first_seen, last_seen)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	for _, f := range flows {
+		first, last := f.FirstSeen.Unix(), f.LastSeen.Unix()
+		res, err := update.Exec(last, f.RemoteHost, f.FirstSeen.Add(-gap).Unix(), f.Process, f.PID, f.Protocol, f.LocalAddr, f.RemoteAddr)
+		if err != nil {
+			return err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			continue
+		}
+		if _, err := insert.Exec(f.Process, f.PID, f.Protocol, f.LocalAddr, f.RemoteAddr, f.RemoteHost, first, last); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Prune deletes the flows last seen before cutoff and returns how many.
+func (s *Store) Prune(cutoff time.Time) (int64, error) {
+	res, err := s.db.Exec("DELETE FROM flows WHERE last_seen < ?", cutoff.Unix())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// Query returns the flows matching q, in the order they were first seen.
+func (s *Store) Query(ctx context.Context, q Query) ([]Flow, error) {
+	stmt, args := q.SQL()
+	rows, err := s.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	var flows []Flow
+	for rows.Next() {
+		var f Flow
+		var first, last int64
+		if err := rows.Scan(&f.Process, &f.PID, &f.Protocol, &f.LocalAddr, &f.RemoteAddr, &f.RemoteHost, &first, &last); err != nil {
+			return nil, err
+		}
+		f.FirstSeen, f.LastSeen = time.Unix(first, 0), time.Unix(last, 0)
+		flows = append(flows, f)
+	}
+	return flows, rows.Err()
+}
+
+// Recorder collects the flows of every observed snapshot and writes them to a
+// Store every interval, so a connection opened and closed between two writes
+// is still recorded. It deletes flows past retention. A nil Recorder records
+// nothing.
+type Recorder struct {
+	store     *Store
+	interval  time.Duration
+	retention time.Duration
+
+	mu      sync.Mutex
+	pending map[flowKey]Flow // flows observed since the last write
+	err     error
+}
+
+// flowKey identifies a flow across snapshots.
+type flowKey struct {
+	process    string
+	pid        int32
+	protocol   string
+	localAddr  string
+	remoteAddr string
+}
+
+// NewRecorder returns a recorder writing to store. Zero durations use
+// DefaultInterval and DefaultRetention.
+func NewRecorder(store *Store, interval, retention time.Duration) *Recorder {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	return &Recorder{store: store, interval: interval, retention: retention}
+}
+
+// Observe adds the flows of a snapshot to the next write, extending those
+// already observed since the last one. Sampled snapshots are skipped: a flow
+// out of the sample for a while would be recorded as two. It returns the error
+// of the last failed write, once.
+func (r *Recorder) Observe(snap *model.NetworkSnapshot) error {
+	if r == nil {
+		return nil
+	}
+	return r.observe(snap, time.Now())
+}
+
+func (r *Recorder) observe(snap *model.NetworkSnapshot, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var flows []Flow
+	if snap == nil || snap.Sample == nil {
+		flows = Flows(snap, now)
+	}
+	for _, f := range flows {
+		if r.pending == nil {
+			r.pending = make(map[flowKey]Flow)
+		}
+		key := flowKey{f.Process, f.PID, f.Protocol, f.LocalAddr, f.RemoteAddr}
+		if seen, ok := r.pending[key]; ok {
+			f.FirstSeen = seen.FirstSeen
+		}
+		r.pending[key] = f
+	}
+	err := r.err
+	r.err = nil
+	return err
+}
+
+// Run writes every interval until ctx is done. A snapshot is recorded once:
+// while no new one is observed (collection paused), flows are not extended.
+func (r *Recorder) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	r.prune(time.Now())
+	lastPrune := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			r.sample()
+			if now.Sub(lastPrune) >= pruneInterval {
+				r.prune(now)
+				lastPrune = now
+			}
+		}
+	}
+}
+
+// sample writes the flows observed since the last sample, if any.
+func (r *Recorder) sample() {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = nil
+	r.mu.Unlock()
+	if len(pending) == 0 {
+		return
+	}
+	flows := make([]Flow, 0, len(pending))
+	for _, f := range pending {
+		flows = append(flows, f)
+	}
+	r.fail(r.store.Record(flows, 2*r.interval))
+}
+
+func (r *Recorder) prune(now time.Time) {
+	_, err := r.store.Prune(now.Add(-r.retention))
+	r.fail(err)
+}
+
+// fail keeps err for the next Observe.
+func (r *Recorder) fail(err error) {
+	if err == nil {
+		return
+	}
+	r.mu.Lock()
+	r.err = fmt.Errorf("history %s: %w", r.store.Path(), err)
+	r.mu.Unlock()
+}
+
+// ParseRetention parses a retention period: a number of days ("7d") or a Go
+// duration ("36h").
+func ParseRetention(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(strings.TrimSpace(s), "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid retention %q: want e.g. 7d or 36h", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid retention %q: want e.g. 7d or 36h", s)
+	}
+	return d, nil
+}
@@ -0,0 +1,171 @@
+package history
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	s, err := Open(filepath.Join(t.TempDir(), "state", "history.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+func curlSnapshot(remote string) *model.NetworkSnapshot {
+	return &model.NetworkSnapshot{Applications: []model.Application{{
+		Name: "curl",
+		Connections: []model.Connection{
+			{PID: 10, Protocol: model.ProtocolTCP, LocalAddr: "192.168.1.5:50000", RemoteAddr: remote, State: model.StateEstablished},
+		},
+	}}}
+}
+
+func TestStore_RecordExtendsOpenFlows(t *testing.T) {
+	s := openTestStore(t)
+	t0 := time.Date(2026, 10, 14, 14, 0, 0, 0, time.UTC)
+	gap := 2 * time.Minute
+	for i, at := range []time.Time{t0, t0.Add(time.Minute), t0.Add(2 * time.Minute), t0.Add(10 * time.Minute)} {
+		if err := s.Record(Flows(curlSnapshot("10.1.2.3:443"), at), gap); err != nil {
+			t.Fatalf("Record #%d: %v", i, err)
+		}
+	}
+
+	flows, err := s.Query(context.Background(), Query{Remote: "10.1.2.3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(flows) != 2 {
+		t.Fatalf("got %d flows, want the first extended over 3 samples and a new one after the gap: %+v", len(flows), flows)
+	}
+	if !flows[0].FirstSeen.Equal(t0) || !flows[0].LastSeen.Equal(t0.Add(2*time.Minute)) {
+		t.Errorf("first flow %v-%v, want 14:00-14:02", flows[0].FirstSeen, flows[0].LastSeen)
+	}
+	if !flows[1].FirstSeen.Equal(t0.Add(10 * time.Minute)) {
+		t.Errorf("second flow first seen %v, want 14:10", flows[1].FirstSeen)
+	}
+}
+
+func TestStore_QueryWindowAndPrune(t *testing.T) {
+	s := openTestStore(t)
+	t0 := time.Date(2026, 10, 14, 14, 30, 0, 0, time.UTC)
+	for _, at := range []time.Time{t0, t0.Add(2 * time.Hour)} {
+		snap := curlSnapshot("10.1.2.3:443")
+		snap.Applications = append(snap.Applications, model.Application{Name: "ssh", Connections: []model.Connection{
+			{PID: 20, Protocol: model.ProtocolTCP, LocalAddr: "192.168.1.5:50001", RemoteAddr: "10.9.9.9:22", State: model.StateEstablished},
+		}})
+		if err := s.Record(Flows(snap, at), time.Minute); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	from := time.Date(2026, 10, 14, 14, 0, 0, 0, time.UTC)
+	flows, err := s.Query(context.Background(), Query{Remote: "10.1.2.3", From: from, To: from.Add(time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(flows) != 1 || flows[0].Process != "curl" || flows[0].PID != 10 {
+		t.Errorf("14:00-15:00 to 10.1.2.3 = %+v, want curl's 14:30 flow only", flows)
+	}
+
+	n, err := s.Prune(t0.Add(time.Hour))
+	if err != nil || n != 2 {
+		t.Errorf("Prune = %d, %v; want the 2 flows of 14:30", n, err)
+	}
+	if flows, _ := s.Query(context.Background(), Query{}); len(flows) != 2 {
+		t.Errorf("%d flows left, want the 2 of 16:30", len(flows))
+	}
+}
+
+func TestRecorder_SamplesEachSnapshotOnce(t *testing.T) {
+	s := openTestStore(t)
+	r := NewRecorder(s, time.Minute, 0)
+	t0 := time.Date(2026, 10, 14, 14, 0, 0, 0, time.UTC)
+
+	if err := r.observe(curlSnapshot("10.1.2.3:443"), t0); err != nil {
+		t.Fatal(err)
+	}
+	r.sample()
+	r.sample() // nothing observed since: paused collection
+	flows, _ := s.Query(context.Background(), Query{})
+	if len(flows) != 1 || !flows[0].LastSeen.Equal(t0) {
+		t.Fatalf("flows = %+v, want one last seen at 14:00", flows)
+	}
+
+	sampled := curlSnapshot("10.7.7.7:443")
+	sampled.Sample = &model.Sample{Total: 60000}
+	_ = r.observe(sampled, t0.Add(2*time.Minute))
+	r.sample()
+	if flows, _ := s.Query(context.Background(), Query{}); len(flows) != 1 {
+		t.Errorf("flows = %+v; a sampled snapshot should not be recorded", flows)
+	}
+
+	var nilRecorder *Recorder
+	if err := nilRecorder.Observe(curlSnapshot("10.1.2.3:443")); err != nil {
+		t.Errorf("nil recorder Observe = %v", err)
+	}
+}
+
+func TestRecorder_RecordsEveryFlowSinceTheLastWrite(t *testing.T) {
+	s := openTestStore(t)
+	r := NewRecorder(s, time.Minute, 0)
+	t0 := time.Date(2026, 10, 14, 14, 0, 0, 0, time.UTC)
+
+	// A short-lived flow to 10.9.9.9 opens and closes between two writes
+	_ = r.observe(curlSnapshot("10.1.2.3:443"), t0)
+	_ = r.observe(curlSnapshot("10.9.9.9:443"), t0.Add(10*time.Second))
+	_ = r.observe(curlSnapshot("10.1.2.3:443"), t0.Add(20*time.Second))
+	r.sample()
+	_ = r.observe(curlSnapshot("10.1.2.3:443"), t0.Add(70*time.Second))
+	r.sample()
+
+	flows, err := s.Query(context.Background(), Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(flows) != 2 {
+		t.Fatalf("flows = %+v, want the long flow and the short one", flows)
+	}
+	long, short := flows[0], flows[1]
+	if long.RemoteHost != "10.1.2.3" || !long.FirstSeen.Equal(t0) || !long.LastSeen.Equal(t0.Add(70*time.Second)) {
+		t.Errorf("long flow = %+v, want 10.1.2.3 seen 14:00:00-14:01:10", long)
+	}
+	if short.RemoteHost != "10.9.9.9" || !short.FirstSeen.Equal(t0.Add(10*time.Second)) || !short.LastSeen.Equal(short.FirstSeen) {
+		t.Errorf("short flow = %+v, want 10.9.9.9 seen at 14:00:10 only", short)
+	}
+}
+
+func TestRecorder_ReportsWriteErrorsOnce(t *testing.T) {
+	s := openTestStore(t)
+	r := NewRecorder(s, time.Minute, 0)
+	_ = s.Close()
+
+	_ = r.Observe(curlSnapshot("10.1.2.3:443"))
+	r.sample()
+	if err := r.Observe(nil); err == nil {
+		t.Error("a failed write should be reported by the next Observe")
+	}
+	if err := r.Observe(nil); err != nil {
+		t.Errorf("the error should be reported once, got %v again", err)
+	}
+}
+
+func TestParseRetention(t *testing.T) {
+	for in, want := range map[string]time.Duration{"7d": 7 * 24 * time.Hour, "36h": 36 * time.Hour, " 1d ": 24 * time.Hour} {
+		if got, err := ParseRetention(in); err != nil || got != want {
+			t.Errorf("ParseRetention(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "0d", "-1h", "week", "d"} {
+		if _, err := ParseRetention(in); err == nil {
+			t.Errorf("ParseRetention(%q) should fail", in)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Package hostgroup groups remote hostnames by registrable domain (eTLD+1),
+// so that e.g. ec2-1-2-3-4.compute-1.amazonaws.com and
+// s3.us-east-1.amazonaws.com aggregate under *.amazonaws.com.
+package hostgroup
+
+import (
+	"net"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// Key returns the aggregation key for a resolved hostname: "*.<eTLD+1>" when
+// the host is a subdomain of a registrable domain, the domain itself when the
+// host is exactly eTLD+1, and the host unchanged for IPs, single-label names
+// and public suffixes.
+func Key(host string) string {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "" || net.ParseIP(host) != nil || !strings.Contains(host, ".") {
+		return host
+	}
+	suffix := icannSuffix(host)
+	if suffix == host {
+		return host
+	}
+	rest := strings.TrimSuffix(host, "."+suffix)
+	domain := rest[strings.LastIndex(rest, ".")+1:] + "." + suffix
+	if domain == host {
+		return domain
+	}
+	return "*." + domain
+}
+
+// icannSuffix returns the public suffix of host, ignoring the list's private
+// section. Private entries such as compute-1.amazonaws.com or cloudfront.net
+// would otherwise make every customer subdomain its own registrable domain,
+// defeating the aggregation.
+func icannSuffix(host string) string {
+	name := host
+	for {
+		suffix, icann := publicsuffix.PublicSuffix(name)
+		if icann || !strings.Contains(suffix, ".") {
+			return suffix
+		}
+		// Private rule: retry with its leftmost label removed.
+		name = suffix[strings.Index(suffix, ".")+1:]
+	}
+}
+
+// Group is an aggregated set of remote hosts sharing a Key.
+type Group struct {
+	Key   string
+	Hosts []string // distinct member hosts, sorted
+	Count int      // total connections across members
+}
+
+// Aggregate folds per-host connection counts into groups keyed by Key,
+// ordered by descending count then key.
+func Aggregate(counts map[string]int) []Group {
+	byKey := make(map[string]*Group)
+	for host, n := range counts {
+		k := Key(host)
+		g, ok := byKey[k]
+		if !ok {
+			g = &Group{Key: k}
+			byKey[k] = g
+		}
+		g.Hosts = append(g.Hosts, host)
+		g.Count += n
+	}
+
+	groups := make([]Group, 0, len(byKey))
+	for _, g := range byKey {
+		sort.Strings(g.Hosts)
+		groups = append(groups, *g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Key < groups[j].Key
+	})
+	return groups
+}
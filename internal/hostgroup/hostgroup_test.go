@@ -0,0 +1,45 @@
+package hostgroup
+
+import "testing"
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"ec2-1-2-3-4.compute-1.amazonaws.com", "*.amazonaws.com"},
+		{"d111111abcdef8.cloudfront.net", "*.cloudfront.net"},
+		{"www.bbc.co.uk", "*.bbc.co.uk"},
+		{"github.com", "github.com"},
+		{"GitHub.com.", "github.com"},
+		{"10.0.0.1", "10.0.0.1"},
+		{"2606:4700::1111", "2606:4700::1111"},
+		{"localhost", "localhost"},
+		{"co.uk", "co.uk"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Key(tt.host); got != tt.want {
+			t.Errorf("Key(%q) = %q, want %q", tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	groups := Aggregate(map[string]int{
+		"a.compute-1.amazonaws.com": 3,
+		"b.compute-1.amazonaws.com": 2,
+		"s3.amazonaws.com":          1,
+		"github.com":                4,
+		"10.0.0.1":                  1,
+	})
+	if len(groups) != 3 {
+		t.Fatalf("len(groups) = %d, want 3: %+v", len(groups), groups)
+	}
+	if groups[0].Key != "*.amazonaws.com" || groups[0].Count != 6 || len(groups[0].Hosts) != 3 {
+		t.Errorf("groups[0] = %+v, want *.amazonaws.com with 6 connections from 3 hosts", groups[0])
+	}
+	if groups[1].Key != "github.com" || groups[2].Key != "10.0.0.1" {
+		t.Errorf("order = %s, %s; want github.com, 10.0.0.1", groups[1].Key, groups[2].Key)
+	}
+}
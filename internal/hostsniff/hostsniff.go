@@ -0,0 +1,232 @@
+// Package hostsniff extracts the hostname a client asked for from the first
+// payload bytes of a connection: the SNI of a TLS ClientHello or the Host
+// header of an HTTP/1.x request. Unlike reverse DNS this names the site
+// actually requested, even behind a CDN or shared IP. It only parses bytes;
+// internal/capture feeds it the first segments of each new flow.
+package hostsniff
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net"
+	"strings"
+)
+
+// Source says where a hostname came from.
+type Source string
+
+const (
+	SourceSNI  Source = "sni"
+	SourceHTTP Source = "http"
+)
+
+var (
+	// ErrTruncated means the payload stops inside a TLS record or HTTP
+	// header block; the hostname may follow in the next segment.
+	ErrTruncated = errors.New("hostsniff: truncated payload")
+	// ErrNotFound means the payload is complete enough but names no host:
+	// not TLS or HTTP, or a ClientHello without SNI.
+	ErrNotFound = errors.New("hostsniff: no hostname")
+)
+
+// Hostname returns the requested hostname in a client's first payload,
+// lowercased and without a port.
+func Hostname(payload []byte) (string, Source, error) {
+	if len(payload) > 0 && payload[0] == recordTypeHandshake {
+		host, err := SNI(payload)
+		return host, SourceSNI, err
+	}
+	host, err := HTTPHost(payload)
+	return host, SourceHTTP, err
+}
+
+const (
+	recordTypeHandshake      = 0x16
+	handshakeTypeClientHello = 0x01
+	extensionServerName      = 0x0000
+	serverNameTypeHostName   = 0x00
+)
+
+// SNI returns the server_name of the TLS ClientHello that payload starts
+// with. A ClientHello spanning several TLS records is not reassembled.
+func SNI(payload []byte) (string, error) {
+	// Record header: type(1) version(2) length(2)
+	if len(payload) < 5 {
+		return "", ErrTruncated
+	}
+	if payload[0] != recordTypeHandshake {
+		return "", ErrNotFound
+	}
+	recLen := int(binary.BigEndian.Uint16(payload[3:5]))
+	if len(payload) < 5+recLen {
+		return "", ErrTruncated
+	}
+	r := reader(payload[5 : 5+recLen])
+
+	// Handshake header: type(1) length(3)
+	typ, ok := r.u8()
+	if !ok || typ != handshakeTypeClientHello {
+		return "", ErrNotFound
+	}
+	helloLen, ok := r.u24()
+	if !ok {
+		return "", ErrNotFound
+	}
+	if helloLen > len(r) {
+		return "", ErrTruncated // continues in the next record
+	}
+	r = r[:helloLen]
+
+	// version(2) random(32) session_id cipher_suites compression_methods
+	if !r.skip(2+32) || !r.skipVec8() || !r.skipVec16() || !r.skipVec8() {
+		return "", ErrNotFound
+	}
+	exts, ok := r.vec16()
+	if !ok {
+		return "", ErrNotFound // SSLv3-style hello without extensions
+	}
+	for len(exts) > 0 {
+		typ, ok1 := exts.u16()
+		data, ok2 := exts.vec16()
+		if !ok1 || !ok2 {
+			return "", ErrNotFound
+		}
+		if typ == extensionServerName {
+			return serverName(data)
+		}
+	}
+	return "", ErrNotFound
+}
+
+// serverName returns the host_name entry of a server_name extension.
+func serverName(data reader) (string, error) {
+	list, ok := data.vec16()
+	if !ok {
+		return "", ErrNotFound
+	}
+	for len(list) > 0 {
+		typ, ok1 := list.u8()
+		name, ok2 := list.vec16()
+		if !ok1 || !ok2 {
+			return "", ErrNotFound
+		}
+		if typ == serverNameTypeHostName {
+			return normalize(string(name))
+		}
+	}
+	return "", ErrNotFound
+}
+
+// httpMethods are the request methods recognized at the start of a payload.
+var httpMethods = []string{"GET ", "POST ", "HEAD ", "PUT ", "DELETE ", "OPTIONS ", "PATCH ", "CONNECT "}
+
+// HTTPHost returns the Host header of the HTTP/1.x request that payload
+// starts with. For CONNECT, the authority in the request line is used.
+func HTTPHost(payload []byte) (string, error) {
+	method := ""
+	for _, m := range httpMethods {
+		if bytes.HasPrefix(payload, []byte(m)) {
+			method = m
+			break
+		}
+	}
+	if method == "" {
+		return "", ErrNotFound
+	}
+	head, _, complete := bytes.Cut(payload, []byte("\r\n\r\n"))
+
+	lines := strings.Split(string(head), "\r\n")
+	if method == "CONNECT " {
+		if fields := strings.Fields(lines[0]); len(fields) >= 2 {
+			return normalize(fields[1])
+		}
+	}
+	for _, line := range lines[1:] {
+		name, value, ok := strings.Cut(line, ":")
+		if ok && strings.EqualFold(strings.TrimSpace(name), "host") {
+			return normalize(strings.TrimSpace(value))
+		}
+	}
+	if !complete {
+		return "", ErrTruncated
+	}
+	return "", ErrNotFound
+}
+
+// normalize lowercases host, strips a port and brackets, and rejects values
+// that cannot be hostnames or IP addresses.
+func normalize(host string) (string, error) {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.ToLower(strings.Trim(host, "[]")), ".")
+	if host == "" || len(host) > 253 {
+		return "", ErrNotFound
+	}
+	for _, c := range host {
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-' || c == '.' || c == '_' || c == ':') {
+			return "", ErrNotFound
+		}
+	}
+	return host, nil
+}
+
+// reader consumes big-endian TLS fields from the front of a byte slice.
+type reader []byte
+
+func (r *reader) u8() (int, bool) {
+	if len(*r) < 1 {
+		return 0, false
+	}
+	v := int((*r)[0])
+	*r = (*r)[1:]
+	return v, true
+}
+
+func (r *reader) u16() (int, bool) {
+	if len(*r) < 2 {
+		return 0, false
+	}
+	v := int(binary.BigEndian.Uint16(*r))
+	*r = (*r)[2:]
+	return v, true
+}
+
+func (r *reader) u24() (int, bool) {
+	if len(*r) < 3 {
+		return 0, false
+	}
+	v := int((*r)[0])<<16 | int((*r)[1])<<8 | int((*r)[2])
+	*r = (*r)[3:]
+	return v, true
+}
+
+func (r *reader) skip(n int) bool {
+	if len(*r) < n {
+		return false
+	}
+	*r = (*r)[n:]
+	return true
+}
+
+// vec16 returns a vector with a 16-bit length prefix.
+func (r *reader) vec16() (reader, bool) {
+	n, ok := r.u16()
+	if !ok || len(*r) < n {
+		return nil, false
+	}
+	v := (*r)[:n]
+	*r = (*r)[n:]
+	return v, true
+}
+
+func (r *reader) skipVec8() bool {
+	n, ok := r.u8()
+	return ok && r.skip(n)
+}
+
+func (r *reader) skipVec16() bool {
+	_, ok := r.vec16()
+	return ok
+}
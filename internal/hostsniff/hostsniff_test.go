@@ -0,0 +1,78 @@
+package hostsniff
+
+import (
+	"crypto/tls"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+// captureConn records what the TLS client writes and fails every read, so
+// the handshake stops after sending the ClientHello.
+type captureConn struct {
+	net.Conn
+	written []byte
+}
+
+func (c *captureConn) Write(b []byte) (int, error) {
+	c.written = append(c.written, b...)
+	return len(b), nil
+}
+func (c *captureConn) Read([]byte) (int, error)         { return 0, errors.New("closed") }
+func (c *captureConn) Close() error                     { return nil }
+func (c *captureConn) SetDeadline(time.Time) error      { return nil }
+func (c *captureConn) SetReadDeadline(time.Time) error  { return nil }
+func (c *captureConn) SetWriteDeadline(time.Time) error { return nil }
+
+// clientHello returns the ClientHello crypto/tls sends for serverName.
+func clientHello(t *testing.T, serverName string) []byte {
+	t.Helper()
+	conn := &captureConn{}
+	_ = tls.Client(conn, &tls.Config{ServerName: serverName, InsecureSkipVerify: true}).Handshake()
+	if len(conn.written) == 0 {
+		t.Fatal("no ClientHello written")
+	}
+	return conn.written
+}
+
+func TestSNI(t *testing.T) {
+	hello := clientHello(t, "Static.Example.COM")
+	host, src, err := Hostname(hello)
+	if err != nil || host != "static.example.com" || src != SourceSNI {
+		t.Fatalf("Hostname() = %q, %q, %v", host, src, err)
+	}
+
+	if _, err := SNI(hello[:len(hello)/2]); !errors.Is(err, ErrTruncated) {
+		t.Errorf("half a ClientHello: err = %v, want ErrTruncated", err)
+	}
+	// crypto/tls omits SNI when the server name is an IP address
+	if _, err := SNI(clientHello(t, "192.0.2.1")); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ClientHello without SNI: err = %v, want ErrNotFound", err)
+	}
+	if _, err := SNI([]byte{0x16, 0x03, 0x01, 0x00, 0x02, 0x02, 0x00}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ServerHello: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestHTTPHost(t *testing.T) {
+	for _, tt := range []struct {
+		payload string
+		want    string
+		err     error
+	}{
+		{"GET / HTTP/1.1\r\nHost: api.example.com:8080\r\nAccept: */*\r\n\r\n", "api.example.com", nil},
+		{"POST /v1 HTTP/1.1\r\nUser-Agent: curl\r\nhost:Example.org\r\n\r\n{}", "example.org", nil},
+		{"CONNECT registry.npmjs.org:443 HTTP/1.1\r\nHost: registry.npmjs.org:443\r\n\r\n", "registry.npmjs.org", nil},
+		{"GET / HTTP/1.1\r\nHost: [2001:db8::1]:80\r\n\r\n", "2001:db8::1", nil},
+		{"GET / HTTP/1.1\r\nAccept: */*\r\n", "", ErrTruncated},
+		{"GET / HTTP/1.0\r\n\r\n", "", ErrNotFound},
+		{"SSH-2.0-OpenSSH_9.6\r\n", "", ErrNotFound},
+		{"GET / HTTP/1.1\r\nHost: <script>\r\n\r\n", "", ErrNotFound},
+	} {
+		host, src, err := Hostname([]byte(tt.payload))
+		if host != tt.want || !errors.Is(err, tt.err) || (err == nil && src != SourceHTTP) {
+			t.Errorf("Hostname(%q) = %q, %q, %v; want %q, %v", tt.payload, host, src, err, tt.want, tt.err)
+		}
+	}
+}
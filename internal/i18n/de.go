@@ -0,0 +1,451 @@
+package i18n
+
+// german is the German catalog.
+var german = map[string]string{
+	// Help sections
+	"Navigation": "Navigation",
+	"Views":      "Ansichten",
+	"Search":     "Suche",
+	"Actions":    "Aktionen",
+	"Other":      "Sonstiges",
+
+	// Help modal command descriptions
+	"Move up":                    "Nach oben",
+	"Move down":                  "Nach unten",
+	"Page up / down":             "Seite hoch / runter",
+	"Half page up / down":        "Halbe Seite hoch / runter",
+	"Select/drill-down":          "Auswählen/öffnen",
+	"Back/cancel":                "Zurück/abbrechen",
+	"Select column (sort mode)":  "Spalte wählen (Sortiermodus)",
+	"Next / previous tab":        "Nächster / vorheriger Tab",
+	"Faster refresh (this view)": "Schneller aktualisieren (diese Ansicht)",
+	"Slower refresh (this view)": "Langsamer aktualisieren (diese Ansicht)",
+	"Quit":                       "Beenden",
+	"Show help":                  "Hilfe anzeigen",
+	"Settings":                   "Einstellungen",
+	"Error diagnostics":          "Fehlerdiagnose",
+	"Search/filter":              "Suchen/filtern",
+	"Filter presets":             "Filtervorlagen",
+	"Load target list (IPs/CIDRs/ports file)":                          "Zielliste laden (Datei mit IPs/CIDRs/Ports)",
+	"Toggle grouped/flat view":                                         "Gruppierte/flache Ansicht umschalten",
+	"Toggle conntrack/NAT view (Linux)":                                "Conntrack/NAT-Ansicht umschalten (Linux)",
+	"Toggle Docker port mappings view":                                 "Docker-Portzuordnungen umschalten",
+	"Toggle bind address view (what is exposed)":                       "Bind-Adressen umschalten (was erreichbar ist)",
+	"Check a port: bind address view, type the port":                   "Port prüfen: Bind-Adressen, Port eingeben",
+	"Connections by country (GeoIP)":                                   "Verbindungen nach Land (GeoIP)",
+	"Compare two processes side by side":                               "Zwei Prozesse nebeneinander vergleichen",
+	"Toggle dashboard header (trend, top talker, newest, errors, DNS)": "Dashboard-Kopfzeile umschalten (Verlauf, Top-Sender, neueste, Fehler, DNS)",
+	"Enter sort mode":                                                  "Sortiermodus starten",
+	"Sort by Nth column (again to reverse)":                            "Nach N-ter Spalte sortieren (erneut: umkehren)",
+	"Auto sort: busiest processes first":                               "Automatisch: aktivste Prozesse zuerst",
+	"Increase refresh rate":                                            "Aktualisierung beschleunigen",
+	"Decrease refresh rate":                                            "Aktualisierung verlangsamen",
+	"Filter to port scan source":                                       "Auf Portscan-Quelle filtern",
+	"Install available update":                                         "Verfügbares Update installieren",
+	"Show process cwd/env (connections view)":                          "Arbeitsverzeichnis/Umgebung des Prozesses zeigen (Verbindungsansicht)",
+	"Select filter chips to remove":                                    "Filter zum Entfernen auswählen",
+	"Annotate process / remote host":                                   "Prozess / entfernten Host kommentieren",
+	"Follow process/container across restarts":                         "Prozess/Container über Neustarts hinweg verfolgen",
+	"Tag process / connection for the incident timeline":               "Prozess / Verbindung für die Vorfall-Zeitleiste markieren",
+	"Process and descendants only (tree:PID)":                          "Nur Prozess und Nachkommen (tree:PID)",
+	"Show remote TLS certificate (connection views)":                   "TLS-Zertifikat der Gegenstelle zeigen (Verbindungsansichten)",
+	"New workspace tab":                                                "Neuer Arbeitsbereich-Tab",
+	"Close workspace tab":                                              "Arbeitsbereich-Tab schließen",
+	"Next tab":                                                         "Nächster Tab",
+	"Previous tab":                                                     "Vorheriger Tab",
+	"Go to tab N":                                                      "Zu Tab N wechseln",
+	"Kill process (SIGTERM)":                                           "Prozess beenden (SIGTERM)",
+	"Force kill (SIGKILL)":                                             "Beenden erzwingen (SIGKILL)",
+	"Close connection (Linux)":                                         "Verbindung schließen (Linux)",
+	"Renice process":                                                   "Prozesspriorität ändern",
+	"Suspend/resume process (SIGSTOP/SIGCONT)":                         "Prozess anhalten/fortsetzen (SIGSTOP/SIGCONT)",
+	"Hash executable, verify signature (connections view)":             "Programmdatei hashen, Signatur prüfen (Verbindungsansicht)",
+	"Go to first row":                                                  "Zur ersten Zeile",
+	"Go to last row":                                                   "Zur letzten Zeile",
+	"Command palette (run any action by name)":                         "Befehlspalette (jede Aktion per Name ausführen)",
+	"Watch port: bell and notification when it starts/stops listening": "Port beobachten: Signalton und Meldung, wenn er zu lauschen beginnt/aufhört",
+	"Interface load (utilization of link speed, top processes)":        "Schnittstellenlast (Auslastung der Verbindungsgeschwindigkeit, Top-Prozesse)",
+	"DNS activity by process (resolvers, query rate)":                  "DNS-Aktivität je Prozess (Resolver, Anfragerate)",
+	"Connections by remote host (grouped by domain)":                   "Verbindungen nach entferntem Host (nach Domain gruppiert)",
+	"Connections by network (ASN)":                                     "Verbindungen nach Netz (ASN)",
+	"Pin/unpin process at the top of the list":                         "Prozess oben in der Liste anheften/lösen",
+
+	// Footer hints
+	"back":       "zurück",
+	"close":      "schließen",
+	"help":       "Hilfe",
+	"kill":       "beenden",
+	"processes":  "Prozesse",
+	"quit":       "beenden",
+	"search":     "suchen",
+	"settings":   "Einstellungen",
+	"sort":       "sortieren",
+	"drill":      "öffnen",
+	"proxy legs": "Proxy-Strecken",
+	"filters":    "Filter",
+	"grouped":    "gruppiert",
+	"flat":       "flach",
+	"env":        "Umgebung",
+	"confirm":    "bestätigen",
+	"signal":     "Signal",
+	"cancel":     "abbrechen",
+	"nice":       "Priorität",
+	"column":     "Spalte",
+	"apply":      "anwenden",
+	"add filter": "Filter hinzufügen",
+	"complete":   "vervollständigen",
+	"select":     "auswählen",
+	"remove":     "entfernen",
+	"clear all":  "alle löschen",
+	"done":       "fertig",
+	"Navigate":   "Navigieren",
+	"Toggle":     "Umschalten",
+	"Close":      "Schließen",
+
+	// Settings
+	"DNS Resolution":                                                "DNS-Auflösung",
+	"Reverse lookup IPs to hostnames":                               "IPs per Reverse-Lookup in Hostnamen auflösen",
+	"Service Names":                                                 "Dienstnamen",
+	"Show http/https instead of 80/443":                             "http/https statt 80/443 anzeigen",
+	"Highlight Changes":                                             "Änderungen hervorheben",
+	"Mark new and changed connections (see Change Style)":           "Neue und geänderte Verbindungen markieren (siehe Änderungsstil)",
+	"Animations":                                                    "Animationen",
+	"Enable UI animations (pulse, spinners)":                        "Animationen aktivieren (Puls, Spinner)",
+	"Docker Containers":                                             "Docker-Container",
+	"Show containers as process rows":                               "Container als Prozesszeilen anzeigen",
+	"Group By Executable":                                           "Nach Programmdatei gruppieren",
+	"Split same-named processes by exe path":                        "Gleichnamige Prozesse nach Programmpfad trennen",
+	"Security Context":                                              "Sicherheitskontext",
+	"Show AppArmor/SELinux/codesign info":                           "AppArmor/SELinux/Codesign-Infos anzeigen",
+	"Churn Columns":                                                 "Fluktuationsspalten",
+	"Show new/closed connections per second":                        "Neue/geschlossene Verbindungen pro Sekunde anzeigen",
+	"Hide Loopback":                                                 "Loopback ausblenden",
+	"Drop 127.0.0.1/::1 connections (count in header)":              "127.0.0.1/::1-Verbindungen verwerfen (Anzahl in der Kopfzeile)",
+	"Instant Refresh":                                               "Sofortige Aktualisierung",
+	"Refresh on socket changes (Linux)":                             "Bei Socket-Änderungen aktualisieren (Linux)",
+	"Ephemeral Flows":                                               "Kurzlebige Verbindungen",
+	"Log connections opened and closed between refreshes (Linux)":   "Zwischen Aktualisierungen geöffnete und geschlossene Verbindungen protokollieren (Linux)",
+	"TCP Stats":                                                     "TCP-Statistik",
+	"RTT and retransmit columns (Linux)":                            "RTT- und Neuübertragungsspalten (Linux)",
+	"Process Env":                                                   "Prozessumgebung",
+	"Allow 'e' to show cwd and whitelisted env vars":                "'e' darf Arbeitsverzeichnis und freigegebene Umgebungsvariablen zeigen",
+	"Protocol Detail":                                               "Protokolldetails",
+	"L7 column: TLS, HTTP/2, SSH, ... from port and process":        "L7-Spalte: TLS, HTTP/2, SSH, ... aus Port und Prozess",
+	"Change Style":                                                  "Änderungsstil",
+	"flash, fade (3s background), gutter (+/-/~) or count (header)": "flash, fade (3s Hintergrund), gutter (+/-/~) oder count (Kopfzeile)",
+	"Process Uptime":                                                "Prozesslaufzeit",
+	"Show how long each process has been running":                   "Anzeigen, wie lange jeder Prozess schon läuft",
+	"Interfaces":                                                    "Schnittstellen",
+	"Iface column: en0, utun3 (VPN), docker0, ... per connection":   "Iface-Spalte: en0, utun3 (VPN), docker0, ... je Verbindung",
+	"Cgroups": "Cgroups",
+	"Unit column: systemd service or container per process (Linux)": "Unit-Spalte: systemd-Dienst oder Container je Prozess (Linux)",
+	"Wrap Navigation": "Umlaufende Navigation",
+	"Up/down wrap around at the first and last row": "Hoch/runter springt an der ersten und letzten Zeile um",
+	"Group Domains": "Domains gruppieren",
+	"Remote hosts panel (H): fold *.amazonaws.com etc. into one row (needs DNS)": "Hosts-Panel (H): *.amazonaws.com usw. in einer Zeile zusammenfassen (braucht DNS)",
+	"Remember Pins": "Angeheftete merken",
+	"Keep pinned processes (*) at the top in the next session too": "Angeheftete Prozesse (*) auch in der nächsten Sitzung oben halten",
+	"Open Files": "Offene Dateien",
+	"FDs column: open files vs the RLIMIT_NOFILE limit (Linux)": "FDs-Spalte: offene Dateien im Verhältnis zum RLIMIT_NOFILE-Limit (Linux)",
+
+	// Modal titles
+	"Keyboard Shortcuts":     "Tastenkürzel",
+	"Diagnostics":            "Diagnose",
+	"Filter Presets":         "Filtervorlagen",
+	"TLS Certificate":        "TLS-Zertifikat",
+	"Note":                   "Notiz",
+	"Target List":            "Zielliste",
+	"Kill Process":           "Prozess beenden",
+	"Stop Container":         "Container stoppen",
+	"Renice Process":         "Prozesspriorität ändern",
+	"Suspend Process":        "Prozess anhalten",
+	"Resume Process":         "Prozess fortsetzen",
+	"Close Connection":       "Verbindung schließen",
+	"Self-Update":            "Selbstaktualisierung",
+	"DNS Activity":           "DNS-Aktivität",
+	"Interface Load":         "Schnittstellenlast",
+	"Connections by Country": "Verbindungen nach Land",
+	"Connections by Host":    "Verbindungen nach Host",
+	"Connections by Network": "Verbindungen nach Netz",
+	"Compare Processes":      "Prozesse vergleichen",
+	"Commands":               "Befehle",
+	"Incident Tag":           "Vorfall-Markierung",
+
+	// Empty states
+	"No matches for '%s'":                           "Keine Treffer für '%s'",
+	"No processes found":                            "Keine Prozesse gefunden",
+	"Process not found":                             "Prozess nicht gefunden",
+	"Waiting for %s to start again…":                "Warte auf Neustart von %s…",
+	"No connections found":                          "Keine Verbindungen gefunden",
+	"No network connections found":                  "Keine Netzwerkverbindungen gefunden",
+	"No tracked flows":                              "Keine verfolgten Verbindungen",
+	"Conntrack unavailable: %v":                     "Conntrack nicht verfügbar: %v",
+	"No running containers (or Docker unavailable)": "Keine laufenden Container (oder Docker nicht verfügbar)",
+	"No published ports":                            "Keine veröffentlichten Ports",
+	"No listening or bound sockets":                 "Keine lauschenden oder gebundenen Sockets",
+	"Port %d is free: nothing is bound to it":       "Port %d ist frei: nichts ist daran gebunden",
+
+	"Loading...":      "Laden...",
+	"Initializing...": "Initialisieren...",
+
+	// Modal content
+	"Confirm":                            "Bestätigen",
+	"Cancel":                             "Abbrechen",
+	"Signal":                             "Signal",
+	"Apply":                              "Anwenden",
+	"Delete":                             "Löschen",
+	"Save":                               "Speichern",
+	"Save current":                       "Aktuellen speichern",
+	"Load":                               "Laden",
+	"Run":                                "Ausführen",
+	"Select":                             "Auswählen",
+	"Scroll":                             "Blättern",
+	"Tag":                                "Markieren",
+	"Update":                             "Aktualisieren",
+	"Re-fetch":                           "Neu abrufen",
+	"Nice":                               "Priorität",
+	"Nice: ":                             "Priorität: ",
+	"Filter to country":                  "Auf Land filtern",
+	"Filter to host":                     "Auf Host filtern",
+	"Filter to network":                  "Auf Netz filtern",
+	"Kill this process?":                 "Diesen Prozess beenden?",
+	"Kill %d processes?":                 "%d Prozesse beenden?",
+	"Stop this container?":               "Diesen Container stoppen?",
+	"Container: %s":                      "Container: %s",
+	"Image:     %s":                      "Image:     %s",
+	"Process: %s":                        "Prozess: %s",
+	"Path:    %s":                        "Pfad:    %s",
+	"PIDs:    %s":                        "PIDs:    %s",
+	"PID:     %d":                        "PID:     %d",
+	"System service: type %q to confirm": "Systemdienst: zum Bestätigen %q eingeben",
+	"%s %s?":                             "%s: %s?",
+	"this process":                       "dieser Prozess",
+	"%d processes":                       "%d Prozesse",
+	"Renice":                             "Priorität ändern",
+	"Suspend":                            "Anhalten",
+	"Resume":                             "Fortsetzen",
+	"%d (highest priority) to %d (lowest); going below the current value needs root.": "%d (höchste Priorität) bis %d (niedrigste); unter den aktuellen Wert braucht root.",
+	"SIGSTOP: sockets stay open but nothing is processed.":                            "SIGSTOP: Sockets bleiben offen, aber nichts wird verarbeitet.",
+	"Press z again to resume.":                                                        "Erneut z drücken zum Fortsetzen.",
+	"The process is stopped. SIGCONT resumes it.":                                     "Der Prozess ist angehalten. SIGCONT setzt ihn fort.",
+	"Close this connection?":                                                          "Diese Verbindung schließen?",
+	"Process: %s (PID %d)":                                                            "Prozess: %s (PID %d)",
+	"Local:   %s":                                                                     "Lokal:   %s",
+	"Remote:  %s":                                                                     "Entfernt: %s",
+	"The process keeps running; the peer gets a RST.":                                 "Der Prozess läuft weiter; die Gegenstelle erhält ein RST.",
+	"Linux only, requires root (CAP_NET_ADMIN).":                                      "Nur Linux, braucht root (CAP_NET_ADMIN).",
+	"Update netmon %s → %s?":                                                          "netmon %s → %s aktualisieren?",
+	"Downloads the release for %s, verifies":                                          "Lädt das Release für %s herunter, prüft",
+	"its sha256 against checksums.txt and replaces":                                   "seine sha256 gegen checksums.txt und ersetzt",
+	"this binary. Restart netmon afterwards.":                                         "dieses Programm. Danach netmon neu starten.",
+	"Handshaking with %s…":                                                            "Handshake mit %s…",
+	"Handshake with %s failed:":                                                       "Handshake mit %s fehlgeschlagen:",
+	"%s · %s · fetched %s":                                                            "%s · %s · abgerufen %s",
+	"Issuer:  ":                                                                       "Aussteller: ",
+	"Names:   ":                                                                       "Namen:   ",
+	"Expires: ":                                                                       "Läuft ab: ",
+	"Errors":                                                                          "Fehler",
+	"Collection":                                                                      "Erfassung",
+	"Events":                                                                          "Ereignisse",
+	"No errors recorded":                                                              "Keine Fehler aufgezeichnet",
+	"No events yet":                                                                   "Noch keine Ereignisse",
+	"No saved presets":                                                                "Keine gespeicherten Vorlagen",
+	"No matching commands":                                                            "Keine passenden Befehle",
+	"Note for":                                                                        "Notiz für",
+	"Shown next to the name and matched by / search.":                                 "Wird neben dem Namen angezeigt und von der /-Suche gefunden.",
+	"Save an empty note to remove it.":                                                "Eine leere Notiz speichern, um sie zu entfernen.",
+	"at %s":                                                                           "um %s",
+	"Comment is optional. Export the timeline from ctrl+p.":                           "Kommentar ist optional. Zeitleiste über ctrl+p exportieren.",
+	"Load target list (one IP, CIDR or port per line)":                                "Zielliste laden (eine IP, ein CIDR oder ein Port pro Zeile)",
+	"Only connections touching a target are shown.":                                   "Nur Verbindungen zu einem Ziel werden angezeigt.",
+	"Load an empty path to clear the list.":                                           "Einen leeren Pfad laden, um die Liste zu leeren.",
+	"Sort by %s":                                                                      "Sortieren nach %s",
+	"(reverse)":                                                                       "(umgekehrt)",
+	"Toggle %s":                                                                       "%s umschalten",
+	"on":                                                                              "an",
+	"off":                                                                             "aus",
+	"Export snapshot to JSON":                                                         "Momentaufnahme als JSON exportieren",
+	"Export incident timeline to Markdown":                                            "Vorfall-Zeitleiste als Markdown exportieren",
+	"Export allowlist violations to JSON":                                             "Allowlist-Verstöße als JSON exportieren",
+
+	// Breakdown panels
+	"Network":       "Netz",
+	"Country":       "Land",
+	"Conns":         "Verb.",
+	"Hosts":         "Hosts",
+	"Top processes": "Top-Prozesse",
+	"Iface":         "Iface",
+	"Speed":         "Tempo",
+	"Util":          "Last",
+	"Query/s":       "Anfr./s",
+	"Total":         "Gesamt",
+	"Resolvers":     "Resolver",
+	"Host":          "Host",
+	"%d hosts":      "%d Hosts",
+	"Unresolved hosts show their IP. Group Domains (S) folds names by domain.": "Nicht aufgelöste Hosts zeigen ihre IP. Domains gruppieren (S) fasst Namen nach Domain zusammen.",
+	"Grouped by registrable domain; unresolved hosts show their IP.":           "Nach registrierbarer Domain gruppiert; nicht aufgelöste Hosts zeigen ihre IP.",
+	"DNS resolution is off: hosts show their IP.":                              "DNS-Auflösung ist aus: Hosts zeigen ihre IP.",
+	"%d networks":                                           "%d Netze",
+	"%d countries":                                          "%d Länder",
+	"… %d more":                                             "… %d weitere",
+	"By endpoint":                                           "Nach Endpunkt",
+	"%s has exited":                                         "%s wurde beendet",
+	"%d–%d of %d endpoints":                                 "%d–%d von %d Endpunkten",
+	"No connections with a remote IP":                       "Keine Verbindungen mit entfernter IP",
+	"No connections with a remote endpoint":                 "Keine Verbindungen mit entferntem Endpunkt",
+	"No DNS traffic seen yet (port 53)":                     "Noch kein DNS-Verkehr gesehen (Port 53)",
+	"Measuring (needs two samples)…":                        "Messe (braucht zwei Messungen)…",
+	"— : private, loopback or not in the GeoIP table.":      "— : privat, Loopback oder nicht in der GeoIP-Tabelle.",
+	"Amber: unexpectedCountries.":                           "Gelb: unexpectedCountries.",
+	"Largest difference first. Amber: only one of the two.": "Größter Unterschied zuerst. Gelb: nur bei einem der beiden.",
+	"Amber: uses a resolver most processes don't. Rates count new port 53 sockets.": "Gelb: nutzt einen Resolver, den die meisten Prozesse nicht nutzen. Raten zählen neue Port-53-Sockets.",
+
+	// Column headers
+	"Process":   "Prozess",
+	"Local":     "Lokal",
+	"Remote":    "Entfernt",
+	"State":     "Status",
+	"Address":   "Adresse",
+	"Container": "Container",
+	"Image":     "Image",
+	"Status":    "Zustand",
+	"Published": "Veröffentl.",
+	"Internal":  "Intern",
+	"Original":  "Original",
+	"Reply":     "Antwort",
+	"Scope":     "Bereich",
+	"Uptime":    "Laufzeit",
+	"Security":  "Sicherheit",
+	"Unit":      "Unit",
+
+	// Header, dashboard and drill-down details
+	"%d connections":                   "%d Verbindungen",
+	"(+%d loopback hidden)":            "(+%d Loopback ausgeblendet)",
+	"+%s −%s conn/s":                   "+%s −%s Verb./s",
+	"large host":                       "großer Host",
+	"installing %s…":                   "installiere %s…",
+	"%s (U to update)":                 "%s (U zum Aktualisieren)",
+	"sampled %d%%":                     "Stichprobe %d%%",
+	"data %s old":                      "Daten %s alt",
+	"FOLLOWING %s":                     "VERFOLGE %s",
+	"(waiting)":                        "(wartet)",
+	"free":                             "frei",
+	"up":                               "aktiv",
+	"listening (%s)":                   "lauscht (%s)",
+	"%s: %d outside":                   "%s: %d außerhalb",
+	"scan? %s → %d ports (%s: filter)": "Scan? %s → %d Ports (%s: filtern)",
+	"%s (%s: interfaces)":              "%s (%s: Schnittstellen)",
+	"partial view: %d sockets hidden (run with sudo)": "Teilansicht: %d Sockets verborgen (mit sudo starten)",
+	"No snapshot yet":                          "Noch keine Momentaufnahme",
+	"Last snapshot took %s, collected %s":      "Letzte Momentaufnahme dauerte %s, erfasst %s",
+	"Top talker":                               "Top-Sender",
+	"Newest":                                   "Neueste",
+	"DNS cache":                                "DNS-Cache",
+	"%s  %d (min %d, max %d)":                  "%s  %d (min. %d, max. %d)",
+	"%s %s → %s (%s ago)":                      "%s %s → %s (vor %s)",
+	"%d (! for details)":                       "%d (! für Details)",
+	"%d%% hit (%d/%d remote IPs)":              "%d%% Treffer (%d/%d entfernte IPs)",
+	"Started: %s (up %s)":                      "Gestartet: %s (läuft seit %s)",
+	"Session total TX %s  RX %s  |  %d opened": "Sitzung gesamt TX %s  RX %s  |  %d geöffnet",
+	"%d PIDs seen":                             "%d PIDs gesehen",
+	"Open files: %d (no limit)":                "Offene Dateien: %d (kein Limit)",
+	"Open files: %d of %d (%.0f%%)":            "Offene Dateien: %d von %d (%.0f%%)",
+	"Ephemeral flows: %d between refreshes":    "Kurzlebige Verbindungen: %d zwischen Aktualisierungen",
+	"(%d not recorded, buffer full)":           "(%d nicht erfasst, Puffer voll)",
+	"Sent":                                     "Gesendet",
+	"Received":                                 "Empfangen",
+	"Processes":                                "Prozesse",
+	"Connections":                              "Verbindungen",
+
+	// Toasts
+	"Killed":                     "Beendet",
+	"Suspended":                  "Angehalten",
+	"Reniced":                    "Priorität geändert",
+	"Resumed":                    "Fortgesetzt",
+	"suspend":                    "anhalten",
+	"renice":                     "Priorität ändern",
+	"resume":                     "fortsetzen",
+	"%s PID %d (%s)":             "%s: PID %d (%s)",
+	"%s %d PIDs (%s)":            "%s: %d PIDs (%s)",
+	"%s %d PIDs, %d failed (%s)": "%s: %d PIDs, %d fehlgeschlagen (%s)",
+	"Failed to %s %s: %v":        "Konnte nicht %s: %s: %v",
+	" to nice %d":                " auf Priorität %d",
+	"Type %q to confirm":         "Zum Bestätigen %q eingeben",
+	"Another system service was just stopped; wait %ds":     "Gerade wurde ein anderer Systemdienst gestoppt; %ds warten",
+	"Not available for containers (use x to stop)":          "Für Container nicht verfügbar (x zum Stoppen)",
+	"Failed to stop container %s: %v":                       "Container %s konnte nicht gestoppt werden: %v",
+	"Stopped container %s":                                  "Container %s gestoppt",
+	"Only connected TCP sockets can be closed":              "Nur verbundene TCP-Sockets können geschlossen werden",
+	"Failed to close %s → %s: %v":                           "Schließen von %s → %s fehlgeschlagen: %v",
+	"Closed %s → %s (%s)":                                   "%s → %s geschlossen (%s)",
+	"Select a process to compare":                           "Einen Prozess zum Vergleichen auswählen",
+	"Compare %s with: select another process, press %s":     "%s vergleichen mit: anderen Prozess auswählen, %s drücken",
+	"Compare cleared":                                       "Vergleich aufgehoben",
+	"Select a process to pin":                               "Einen Prozess zum Anheften auswählen",
+	"Pinned %s":                                             "%s angeheftet",
+	"Unpinned %s":                                           "%s gelöst",
+	"Nothing to annotate":                                   "Nichts zu kommentieren",
+	"Removed note for %s":                                   "Notiz für %s entfernt",
+	"Noted %s: %s":                                          "Notiert %s: %s",
+	"Nothing to tag":                                        "Nichts zu markieren",
+	"Tagged %s (%d in timeline)":                            "%s markiert (%d in der Zeitleiste)",
+	"Nothing tagged yet (m)":                                "Noch nichts markiert (m)",
+	"Export failed: %v":                                     "Export fehlgeschlagen: %v",
+	"Exported %d tags to %s":                                "%d Markierungen nach %s exportiert",
+	"Exported %d violations to %s":                          "%d Verstöße nach %s exportiert",
+	"Exported snapshot to %s":                               "Momentaufnahme nach %s exportiert",
+	"Nothing to export yet":                                 "Noch nichts zu exportieren",
+	"No allowlist loaded (--allow-file)":                    "Keine Allowlist geladen (--allow-file)",
+	"No filters (use / to add one)":                         "Keine Filter (/ fügt einen hinzu)",
+	"No active filter to save (use / first)":                "Kein aktiver Filter zum Speichern (zuerst /)",
+	"Preset name cannot be empty":                           "Vorlagenname darf nicht leer sein",
+	"Saved preset %q":                                       "Vorlage %q gespeichert",
+	"Deleted preset %q":                                     "Vorlage %q gelöscht",
+	"Filter: %s":                                            "Filter: %s",
+	"Filter: connections to %s":                             "Filter: Verbindungen nach %s",
+	"Filter: connections to AS%d %s":                        "Filter: Verbindungen zu AS%d %s",
+	"No GeoIP database: set geoipDatabase in settings.yaml": "Keine GeoIP-Datenbank: geoipDatabase in settings.yaml setzen",
+	"No ASN data: set geoipDatabase to ip2asn-combined.tsv in settings.yaml": "Keine ASN-Daten: geoipDatabase in settings.yaml auf ip2asn-combined.tsv setzen",
+	"Not a TLS connection (remote port 443, 8443, 993, …)":                   "Keine TLS-Verbindung (entfernter Port 443, 8443, 993, …)",
+	"Executable path unknown (run with sudo)":                                "Programmpfad unbekannt (mit sudo starten)",
+	"Hashing %s failed: %s":                                                  "Hashen von %s fehlgeschlagen: %s",
+	"%s: code signature INVALID":                                             "%s: Codesignatur UNGÜLTIG",
+	"%s: executable is unsigned":                                             "%s: Programmdatei ist nicht signiert",
+	"Verified %s: signature valid":                                           "%s geprüft: Signatur gültig",
+	"Hashed %s":                                                              "%s gehasht",
+	"Following %s":                                                           "Verfolge %s",
+	"Stopped following %s":                                                   "%s wird nicht mehr verfolgt",
+	"%s restarted (PID %d → %d)":                                             "%s neu gestartet (PID %d → %d)",
+	"Ephemeral flows unavailable: %v":                                        "Kurzlebige Verbindungen nicht verfügbar: %v",
+	"Instant refresh unavailable: %v":                                        "Sofortige Aktualisierung nicht verfügbar: %v",
+	"%s at %.0f%% of %s":                                                     "%s bei %.0f%% von %s",
+	"Possible socket leak: %s CLOSE_WAIT %d→%d (score %.1f":                  "Mögliches Socket-Leck: %s CLOSE_WAIT %d→%d (Wert %.1f",
+	"Docker port conflict: %s":                                               "Docker-Portkonflikt: %s",
+	"%d Docker port conflicts; see the port mappings view (P)":               "%d Docker-Portkonflikte; siehe Portzuordnungen (P)",
+	"Possible port scan from %s (%d ports) — press %s to filter":             "Möglicher Portscan von %s (%d Ports) — %s zum Filtern",
+	"No port scan detected":                                                  "Kein Portscan erkannt",
+	"No port to watch: select a socket or filter by port (p 3000)":           "Kein Port zum Beobachten: Socket auswählen oder nach Port filtern (p 3000)",
+	"Stopped watching port %d":                                               "Port %d wird nicht mehr beobachtet",
+	"Watching port %d, now %s":                                               "Beobachte Port %d, jetzt %s",
+	"Port %d is now listening (%s)":                                          "Port %d lauscht jetzt (%s)",
+	"Port %d is now free (was %s)":                                           "Port %d ist jetzt frei (war %s)",
+	"Process Env is off (enable it in Settings, S)":                          "Prozessumgebung ist aus (in den Einstellungen aktivieren, S)",
+	"No process to show the tree of":                                         "Kein Prozess, dessen Baum gezeigt werden kann",
+	"Reading processes failed: %v":                                           "Lesen der Prozesse fehlgeschlagen: %v",
+	"Showing %s (PID %d) and its descendants":                                "Zeige %s (PID %d) und seine Nachkommen",
+	"Target list cleared":                                                    "Zielliste geleert",
+	"Target list: %v":                                                        "Zielliste: %v",
+	"Showing connections touching %s":                                        "Zeige Verbindungen zu %s",
+	"stdin is the terminal; use --match-file - when piping":                  "stdin ist das Terminal; beim Pipen --match-file - verwenden",
+	"Script line %d: no column %q in this view":                              "Skriptzeile %d: keine Spalte %q in dieser Ansicht",
+	"Script line %d: export failed: %v":                                      "Skriptzeile %d: Export fehlgeschlagen: %v",
+	"Large host (%d connections): highlights and DNS off, refresh ≥ %s":      "Großer Host (%d Verbindungen): Hervorhebung und DNS aus, Aktualisierung ≥ %s",
+	"Large host mode off":                                                    "Modus für große Hosts aus",
+	"Update already in progress":                                             "Aktualisierung läuft bereits",
+	"Update failed: %v":                                                      "Aktualisierung fehlgeschlagen: %v",
+	"Installed %s — restart netmon to use it":                                "%s installiert — netmon neu starten, um es zu nutzen",
+	"At most %d tabs":                                                        "Höchstens %d Tabs",
+	"Opened tab %d":                                                          "Tab %d geöffnet",
+	"Closed tab %d":                                                          "Tab %d geschlossen",
+	"Only one tab open":                                                      "Nur ein Tab offen",
+}
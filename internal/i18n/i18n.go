@@ -0,0 +1,90 @@
+// Package i18n translates user-facing UI strings.
+//
+// Catalogs are keyed by the English source string, so untranslated strings
+// fall back to English and call sites stay readable:
+//
+//	EmptyStyle().Render(i18n.T("No processes found"))
+//	fmt.Sprintf(i18n.T("No matches for '%s'"), filter)
+//
+// A translation must keep the format verbs of its key, in order.
+package i18n
+
+import (
+	"os"
+	"sort"
+	"strings"
+)
+
+// English is the source locale; it has no catalog.
+const English = "en"
+
+// catalogs maps a locale to its translations, keyed by English string.
+var catalogs = map[string]map[string]string{
+	"de": german,
+}
+
+// current is the active catalog (nil for English). Like the theme it is
+// process-wide, set once at startup.
+var current map[string]string
+
+// T returns the translation of s in the active locale, or s itself.
+func T(s string) string {
+	if t, ok := current[s]; ok {
+		return t
+	}
+	return s
+}
+
+// Has reports whether the catalog for locale translates s.
+func Has(locale, s string) bool {
+	_, ok := catalogs[locale][s]
+	return ok
+}
+
+// SetLocale activates the catalog for locale, falling back to English for
+// unknown locales. Returns the locale in effect.
+func SetLocale(locale string) string {
+	if c, ok := catalogs[locale]; ok {
+		current = c
+		return locale
+	}
+	current = nil
+	return English
+}
+
+// Locales returns the supported locales, English first.
+func Locales() []string {
+	locales := []string{English}
+	for l := range catalogs {
+		locales = append(locales, l)
+	}
+	sort.Strings(locales[1:])
+	return locales
+}
+
+// Detect returns the locale to use: the setting if non-empty, else the first
+// of LC_ALL, LC_MESSAGES and LANG that is set. Values such as "de_DE.UTF-8"
+// are reduced to the language ("de"); "C" and "POSIX" mean English.
+func Detect(setting string) string {
+	if setting != "" {
+		return language(setting)
+	}
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(env); v != "" {
+			return language(v)
+		}
+	}
+	return English
+}
+
+// language reduces a locale name to its lowercase language code.
+func language(locale string) string {
+	if i := strings.IndexAny(locale, "_.@-"); i >= 0 {
+		locale = locale[:i]
+	}
+	locale = strings.ToLower(locale)
+	if locale == "" || locale == "c" || locale == "posix" {
+		return English
+	}
+	return locale
+}
@@ -0,0 +1,72 @@
+package i18n
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+)
+
+func TestT_FallsBackToEnglish(t *testing.T) {
+	t.Cleanup(func() { SetLocale(English) })
+
+	if got := SetLocale("de"); got != "de" {
+		t.Fatalf("SetLocale(de) = %q", got)
+	}
+	if got := T("No processes found"); got != "Keine Prozesse gefunden" {
+		t.Errorf("T = %q, want German", got)
+	}
+	if got := T("not in any catalog"); got != "not in any catalog" {
+		t.Errorf("untranslated T = %q, want the key", got)
+	}
+
+	if got := SetLocale("xx"); got != English {
+		t.Errorf("SetLocale(xx) = %q, want English fallback", got)
+	}
+	if got := T("No processes found"); got != "No processes found" {
+		t.Errorf("T after fallback = %q, want English", got)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	for _, tt := range []struct {
+		setting, lcAll, lang, want string
+	}{
+		{"de", "", "en_US.UTF-8", "de"},
+		{"", "de_DE.UTF-8", "en_US.UTF-8", "de"},
+		{"", "", "de_AT@euro", "de"},
+		{"", "", "C", English},
+		{"", "", "", English},
+		{"DE", "", "", "de"},
+	} {
+		t.Setenv("LC_ALL", tt.lcAll)
+		t.Setenv("LC_MESSAGES", "")
+		t.Setenv("LANG", tt.lang)
+		if got := Detect(tt.setting); got != tt.want {
+			t.Errorf("Detect(%q) with LC_ALL=%q LANG=%q = %q, want %q", tt.setting, tt.lcAll, tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestLocales(t *testing.T) {
+	locales := Locales()
+	if locales[0] != English || !slices.Contains(locales, "de") {
+		t.Errorf("Locales = %v, want en first and de", locales)
+	}
+}
+
+// verbRe matches printf verbs (and %%).
+var verbRe = regexp.MustCompile(`%[-+# 0]*[0-9]*(?:\.[0-9]+)?[a-zA-Z%]`)
+
+func TestCatalogs_KeepFormatVerbs(t *testing.T) {
+	for locale, catalog := range catalogs {
+		for key, translation := range catalog {
+			want, got := verbRe.FindAllString(key, -1), verbRe.FindAllString(translation, -1)
+			if !slices.Equal(want, got) {
+				t.Errorf("%s: %q has verbs %v, want %v", locale, translation, got, want)
+			}
+			if translation == "" {
+				t.Errorf("%s: empty translation for %q", locale, key)
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// Package iprange is an in-memory table of inclusive IP address ranges, as
+// found in free IP-to-country and IP-to-ASN databases, with a binary search
+// lookup.
+package iprange
+
+import (
+	"net/netip"
+	"sort"
+)
+
+// Range is one table row: an inclusive address range and its value.
+type Range[V any] struct {
+	Start, End netip.Addr
+	Value      V
+}
+
+// Table maps IP addresses to the value of the range holding them.
+type Table[V any] struct {
+	ranges []Range[V]
+}
+
+// New returns a table of ranges, which must not overlap. It sorts ranges in
+// place.
+func New[V any](ranges []Range[V]) *Table[V] {
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].Start.Less(ranges[j].Start) })
+	return &Table[V]{ranges: ranges}
+}
+
+// Len returns the number of ranges in the table.
+func (t *Table[V]) Len() int {
+	return len(t.ranges)
+}
+
+// Lookup returns the value of the range holding ip. IPv4-mapped IPv6
+// addresses are looked up as IPv4.
+func (t *Table[V]) Lookup(ip netip.Addr) (V, bool) {
+	ip = ip.Unmap()
+	// Last range starting at or before ip
+	i := sort.Search(len(t.ranges), func(i int) bool { return ip.Less(t.ranges[i].Start) }) - 1
+	if i < 0 {
+		var zero V
+		return zero, false
+	}
+	r := t.ranges[i]
+	if r.Start.BitLen() != ip.BitLen() || r.End.Less(ip) {
+		var zero V
+		return zero, false
+	}
+	return r.Value, true
+}
@@ -0,0 +1,37 @@
+package iprange
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	tbl := New([]Range[string]{
+		{Start: netip.MustParseAddr("8.8.8.0"), End: netip.MustParseAddr("8.8.8.255"), Value: "google"},
+		{Start: netip.MustParseAddr("2001:4860::"), End: netip.MustParseAddr("2001:4860:ffff:ffff:ffff:ffff:ffff:ffff"), Value: "google6"},
+		{Start: netip.MustParseAddr("3.0.0.0"), End: netip.MustParseAddr("3.127.255.255"), Value: "amazon"},
+	})
+	if tbl.Len() != 3 {
+		t.Errorf("Len = %d, want 3", tbl.Len())
+	}
+	tests := []struct {
+		ip   string
+		want string
+	}{
+		{"8.8.8.8", "google"},
+		{"::ffff:8.8.8.8", "google"},
+		{"8.8.4.4", ""},
+		{"3.5.140.2", "amazon"},
+		{"1.1.1.1", ""}, // before the first range
+		{"9.9.9.9", ""}, // after a range, before the next
+		{"::ffff:3.0.0.1", "amazon"},
+		{"2001:4860:4860::8888", "google6"},
+		{"::1", ""}, // IPv6 sorts after IPv4 ranges but must not match them
+	}
+	for _, tt := range tests {
+		got, ok := tbl.Lookup(netip.MustParseAddr(tt.ip))
+		if got != tt.want || ok != (tt.want != "") {
+			t.Errorf("Lookup(%s) = %q, %v; want %q", tt.ip, got, ok, tt.want)
+		}
+	}
+}
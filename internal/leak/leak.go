@@ -0,0 +1,188 @@
+// Package leak spots socket leaks from CLOSE_WAIT counts over time. A socket
+// sits in CLOSE_WAIT after the peer closed until the owning process closes
+// it too, so a process whose CLOSE_WAIT count only ever grows is not closing
+// its sockets: the classic fd leak. TIME_WAIT is not tracked: those sockets
+// belong to the kernel, not a process, and expire on their own.
+package leak
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// Defaults for the sampling window: one sample every 10s over 5 minutes.
+const (
+	DefaultInterval = 10 * time.Second
+	DefaultSamples  = 30
+)
+
+// Thresholds for flagging a process.
+const (
+	MinSamples = 4 // observations before anything is flagged
+	minGrowth  = 3 // CLOSE_WAIT sockets gained over the window
+)
+
+// maxEndpoints caps the endpoints listed per suspect.
+const maxEndpoints = 3
+
+// sample is one observation of a process's CLOSE_WAIT sockets.
+type sample struct {
+	at        time.Time
+	count     int
+	pids      []int32
+	endpoints map[string]int // endpoint -> CLOSE_WAIT sockets on it
+}
+
+// Tracker keeps a sliding window of CLOSE_WAIT samples per process name.
+type Tracker struct {
+	interval time.Duration
+	samples  int
+	last     time.Time
+	series   map[string][]sample
+}
+
+// NewTracker returns a Tracker that keeps one sample per interval, up to
+// samples of them per process.
+func NewTracker(interval time.Duration, samples int) *Tracker {
+	return &Tracker{interval: interval, samples: max(samples, MinSamples), series: make(map[string][]sample)}
+}
+
+// Observe records snap as a sample unless the previous one is less than an
+// interval old. Sampled snapshots (snap.Sample) are skipped: their per-process
+// CLOSE_WAIT counts are partial. It reports whether a sample was taken.
+func (t *Tracker) Observe(snap *model.NetworkSnapshot, at time.Time) bool {
+	if snap == nil || snap.Sample != nil || (!t.last.IsZero() && at.Sub(t.last) < t.interval) {
+		return false
+	}
+	t.last = at
+	seen := make(map[string]bool)
+	for _, app := range snap.Applications {
+		s := sample{at: at, pids: app.PIDs, endpoints: make(map[string]int)}
+		listening := make(map[int]bool)
+		for _, conn := range app.Connections {
+			if conn.State == model.StateListen {
+				listening[model.ExtractPort(conn.LocalAddr)] = true
+			}
+		}
+		for _, conn := range app.Connections {
+			if conn.State != model.StateCloseWait {
+				continue
+			}
+			s.count++
+			s.endpoints[endpoint(conn, listening)]++
+		}
+		if s.count == 0 {
+			continue // none left open: any series starts over
+		}
+		seen[app.Name] = true
+		t.series[app.Name] = append(t.series[app.Name], s)
+		if n := len(t.series[app.Name]); n > t.samples {
+			t.series[app.Name] = t.series[app.Name][n-t.samples:]
+		}
+	}
+	// Processes that went away or closed every CLOSE_WAIT socket are not leaking
+	for name := range t.series {
+		if !seen[name] {
+			delete(t.series, name)
+		}
+	}
+	return true
+}
+
+// endpoint names where a CLOSE_WAIT socket points: the listening port it was
+// accepted on ("listen :8080"), else the remote it dialed ("10.0.0.5:5432").
+func endpoint(conn model.Connection, listening map[int]bool) string {
+	if port := model.ExtractPort(conn.LocalAddr); listening[port] {
+		return "listen :" + strconv.Itoa(port)
+	}
+	return conn.RemoteAddr
+}
+
+// Suspect is a process whose CLOSE_WAIT count grew without ever dropping.
+type Suspect struct {
+	Process   string    `json:"process"`
+	PIDs      []int32   `json:"pids"`
+	First     int       `json:"first"`   // CLOSE_WAIT sockets at the start of the window
+	Current   int       `json:"current"` // CLOSE_WAIT sockets now
+	Score     float64   `json:"score"`
+	Since     time.Time `json:"since"`     // first sample of the window
+	Endpoints []string  `json:"endpoints"` // busiest first, e.g. "listen :8080 (12)"
+}
+
+// Growth is how many CLOSE_WAIT sockets the process gained over the window.
+func (s Suspect) Growth() int {
+	return s.Current - s.First
+}
+
+// Suspects returns the processes whose CLOSE_WAIT count never dropped and grew
+// by at least minGrowth over at least MinSamples samples, highest score first.
+// The score is the growth weighted by how steadily it rose: the share of
+// sample-to-sample steps that went up.
+func (t *Tracker) Suspects() []Suspect {
+	var out []Suspect
+	for name, series := range t.series {
+		if len(series) < MinSamples {
+			continue
+		}
+		first, last := series[0], series[len(series)-1]
+		growth := last.count - first.count
+		if growth < minGrowth {
+			continue
+		}
+		rises, drops := 0, 0
+		for i := 1; i < len(series); i++ {
+			switch d := series[i].count - series[i-1].count; {
+			case d > 0:
+				rises++
+			case d < 0:
+				drops++
+			}
+		}
+		if drops > 0 {
+			continue
+		}
+		score := float64(growth) * float64(rises) / float64(len(series)-1)
+		out = append(out, Suspect{
+			Process:   name,
+			PIDs:      last.pids,
+			First:     first.count,
+			Current:   last.count,
+			Score:     math.Round(score*10) / 10,
+			Since:     first.at,
+			Endpoints: topEndpoints(last.endpoints),
+		})
+	}
+	slices.SortFunc(out, func(a, b Suspect) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Process, b.Process)
+	})
+	return out
+}
+
+// topEndpoints returns the busiest endpoints with their socket counts.
+func topEndpoints(counts map[string]int) []string {
+	keys := slices.Collect(maps.Keys(counts))
+	slices.SortFunc(keys, func(a, b string) int {
+		if c := cmp.Compare(counts[b], counts[a]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+	if len(keys) > maxEndpoints {
+		keys = keys[:maxEndpoints]
+	}
+	out := make([]string, len(keys))
+	for i, k := range keys {
+		out[i] = fmt.Sprintf("%s (%d)", k, counts[k])
+	}
+	return out
+}
@@ -0,0 +1,119 @@
+package leak
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// closeWaitSnap returns a snapshot where each process has the given number of
+// CLOSE_WAIT sockets: "api" on its listening port 8080, "worker" to a remote.
+func closeWaitSnap(counts map[string]int) *model.NetworkSnapshot {
+	snap := &model.NetworkSnapshot{}
+	for name, n := range counts {
+		app := model.Application{Name: name, PIDs: []int32{int32(len(name))}}
+		if name == "api" {
+			app.Connections = append(app.Connections, model.Connection{Protocol: model.ProtocolTCP, LocalAddr: "0.0.0.0:8080", RemoteAddr: "*:*", State: model.StateListen})
+		}
+		for i := range n {
+			conn := model.Connection{Protocol: model.ProtocolTCP, State: model.StateCloseWait,
+				LocalAddr: fmt.Sprintf("10.0.0.2:%d", 40000+i), RemoteAddr: "10.0.0.9:5432"}
+			if name == "api" {
+				conn.LocalAddr, conn.RemoteAddr = "10.0.0.2:8080", fmt.Sprintf("10.0.0.7:%d", 50000+i)
+			}
+			app.Connections = append(app.Connections, conn)
+		}
+		snap.Applications = append(snap.Applications, app)
+	}
+	return snap
+}
+
+func observeSeries(tr *Tracker, series ...map[string]int) {
+	start := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	for i, counts := range series {
+		tr.Observe(closeWaitSnap(counts), start.Add(time.Duration(i)*DefaultInterval))
+	}
+}
+
+func TestSuspects_MonotonicGrowth(t *testing.T) {
+	tr := NewTracker(DefaultInterval, DefaultSamples)
+	observeSeries(tr,
+		map[string]int{"api": 2, "worker": 1, "flappy": 5},
+		map[string]int{"api": 4, "worker": 1, "flappy": 9},
+		map[string]int{"api": 4, "worker": 2, "flappy": 3}, // flappy drops: closes its sockets, just slowly
+		map[string]int{"api": 7, "worker": 3, "flappy": 10},
+		map[string]int{"api": 9, "worker": 3, "flappy": 12},
+	)
+
+	got := tr.Suspects()
+	if len(got) != 1 {
+		t.Fatalf("suspects = %+v, want only api (worker grew by 2, flappy dropped)", got)
+	}
+	s := got[0]
+	if s.Process != "api" || s.First != 2 || s.Current != 9 || s.Growth() != 7 {
+		t.Errorf("suspect = %+v, want api 2 -> 9", s)
+	}
+	if s.Score != 5.3 { // 7 gained, 3 of 4 steps rising
+		t.Errorf("score = %v, want 5.3", s.Score)
+	}
+	if len(s.Endpoints) != 1 || s.Endpoints[0] != "listen :8080 (9)" {
+		t.Errorf("endpoints = %v, want the listening port", s.Endpoints)
+	}
+}
+
+func TestSuspects_RemoteEndpointsAndReset(t *testing.T) {
+	tr := NewTracker(DefaultInterval, DefaultSamples)
+	observeSeries(tr,
+		map[string]int{"worker": 1},
+		map[string]int{"worker": 3},
+		map[string]int{"worker": 5},
+		map[string]int{"worker": 8},
+	)
+	got := tr.Suspects()
+	if len(got) != 1 || got[0].Endpoints[0] != "10.0.0.9:5432 (8)" {
+		t.Fatalf("suspects = %+v, want worker with its remote endpoint", got)
+	}
+
+	// Closing every socket clears the history
+	tr.Observe(closeWaitSnap(map[string]int{"worker": 0}), time.Date(2026, 10, 15, 13, 0, 0, 0, time.UTC))
+	if got := tr.Suspects(); len(got) != 0 {
+		t.Errorf("after closing all sockets suspects = %+v", got)
+	}
+}
+
+func TestObserve_Interval(t *testing.T) {
+	tr := NewTracker(DefaultInterval, DefaultSamples)
+	now := time.Now()
+	snap := closeWaitSnap(map[string]int{"api": 1})
+	if !tr.Observe(snap, now) {
+		t.Error("first observation should be sampled")
+	}
+	if tr.Observe(snap, now.Add(DefaultInterval/2)) {
+		t.Error("observation within the interval should be skipped")
+	}
+	if !tr.Observe(snap, now.Add(DefaultInterval)) {
+		t.Error("observation an interval later should be sampled")
+	}
+	if n := len(tr.series["api"]); n != 2 {
+		t.Errorf("%d samples, want 2", n)
+	}
+
+	sampled := closeWaitSnap(map[string]int{"api": 1})
+	sampled.Sample = &model.Sample{Total: 60000}
+	if tr.Observe(sampled, now.Add(2*DefaultInterval)) || len(tr.series["api"]) != 2 {
+		t.Error("a sampled snapshot's partial counts should be skipped")
+	}
+}
+
+func TestObserve_WindowCapped(t *testing.T) {
+	tr := NewTracker(DefaultInterval, MinSamples)
+	for i := range 10 {
+		tr.Observe(closeWaitSnap(map[string]int{"api": i + 1}), time.Unix(int64(i)*60, 0))
+	}
+	s := tr.Suspects()
+	if len(s) != 1 || s[0].First != 7 || s[0].Current != 10 {
+		t.Errorf("suspects = %+v, want the window to cover the last %d samples (7 -> 10)", s, MinSamples)
+	}
+}
@@ -0,0 +1,153 @@
+// Package matchlist parses target lists of IPs, CIDRs and ports, such as a
+// SIEM export of suspicious hosts, and matches connections against them.
+package matchlist
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// List is a parsed set of targets. A connection matches when either of its
+// endpoints is inside one of the prefixes or uses one of the ports.
+type List struct {
+	prefixes []netip.Prefix
+	ports    map[int]bool
+}
+
+// Parse reads targets separated by newlines, spaces or commas. Each target is
+// an IP ("10.0.3.7", "::1"), a CIDR ("10.0.0.0/8") or a port ("4444").
+// Anything after '#' on a line is a comment.
+func Parse(r io.Reader) (*List, error) {
+	l := &List{ports: make(map[int]bool)}
+	sc := bufio.NewScanner(r)
+	for lineNo := 1; sc.Scan(); lineNo++ {
+		line, _, _ := strings.Cut(sc.Text(), "#")
+		fields := strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || r == ';' || r == ' ' || r == '\t' || r == '\r'
+		})
+		for _, f := range fields {
+			if err := l.add(f); err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if l.Len() == 0 {
+		return nil, errors.New("no targets found")
+	}
+	return l, nil
+}
+
+// Load parses the target file at path; "-" reads standard input.
+func Load(path string) (*List, error) {
+	if path == "-" {
+		return Parse(os.Stdin)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	l, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return l, nil
+}
+
+// add parses one target into l.
+func (l *List) add(target string) error {
+	if port, err := strconv.Atoi(target); err == nil {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("port %d out of range", port)
+		}
+		l.ports[port] = true
+		return nil
+	}
+	if strings.Contains(target, "/") {
+		p, err := netip.ParsePrefix(target)
+		if err != nil {
+			return fmt.Errorf("invalid CIDR %q", target)
+		}
+		l.prefixes = append(l.prefixes, p.Masked())
+		return nil
+	}
+	addr, err := netip.ParseAddr(target)
+	if err != nil {
+		return fmt.Errorf("invalid target %q (want IP, CIDR or port)", target)
+	}
+	addr = addr.Unmap().WithZone("")
+	l.prefixes = append(l.prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	return nil
+}
+
+// Len returns the number of targets.
+func (l *List) Len() int {
+	if l == nil {
+		return 0
+	}
+	return len(l.prefixes) + len(l.ports)
+}
+
+// Summary describes the list, e.g. "48 IPs/CIDRs, 2 ports".
+func (l *List) Summary() string {
+	var parts []string
+	if n := len(l.prefixes); n > 0 {
+		parts = append(parts, plural(n, "IP/CIDR", "IPs/CIDRs"))
+	}
+	if n := len(l.ports); n > 0 {
+		parts = append(parts, plural(n, "port", "ports"))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return "1 " + one
+	}
+	return fmt.Sprintf("%d %s", n, many)
+}
+
+// Match reports whether a connection with the given "ip:port" endpoints
+// touches any target. Wildcard endpoints ("*", "*:*") never match.
+func (l *List) Match(localAddr, remoteAddr string) bool {
+	return l.matchAddr(localAddr) || l.matchAddr(remoteAddr)
+}
+
+// matchAddr reports whether one "ip:port" endpoint touches any target.
+func (l *List) matchAddr(hostPort string) bool {
+	host, port := splitHostPort(hostPort)
+	if port > 0 && l.ports[port] {
+		return true
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap().WithZone("")
+	for _, p := range l.prefixes {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitHostPort splits an endpoint as the collector formats it ("1.2.3.4:443",
+// "::1:443"; IPv6 without brackets) into host and port. port is 0 for "*".
+func splitHostPort(s string) (string, int) {
+	i := strings.LastIndex(s, ":")
+	if i < 0 {
+		return s, 0
+	}
+	port, _ := strconv.Atoi(s[i+1:])
+	return strings.Trim(s[:i], "[]"), port
+}
@@ -0,0 +1,81 @@
+package matchlist
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	l, err := Parse(strings.NewReader(`# SIEM export
+10.0.3.7, 192.168.0.0/16
+2001:db8::/32   4444 # reverse shell
+::ffff:8.8.8.8
+
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Len() != 5 {
+		t.Errorf("Len() = %d, want 5", l.Len())
+	}
+	if got := l.Summary(); got != "4 IPs/CIDRs, 1 port" {
+		t.Errorf("Summary() = %q", got)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"10.0.0.1\nexample.com", "line 2: invalid target"},
+		{"10.0.0.0/33", "invalid CIDR"},
+		{"70000", "out of range"},
+		{"# only comments\n\n", "no targets"},
+	}
+	for _, tt := range tests {
+		_, err := Parse(strings.NewReader(tt.in))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Parse(%q) error = %v, want %q", tt.in, err, tt.want)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	l, err := Parse(strings.NewReader("10.0.3.7 192.168.0.0/16 2001:db8::/32 8.8.8.8 4444"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		local, remote string
+		want          bool
+	}{
+		{"10.0.0.1:52000", "10.0.3.7:443", true},
+		{"192.168.1.5:22", "10.9.9.9:50000", true},
+		{"[::1]:5000", "2001:db8::1:443", true},
+		{"::ffff:10.0.0.1:5000", "::ffff:8.8.8.8:53", true},
+		{"10.0.0.1:4444", "*", true},
+		{"10.0.0.1:52000", "1.1.1.1:443", false},
+		{"*:*", "*", false},
+	}
+	for _, tt := range tests {
+		if got := l.Match(tt.local, tt.remote); got != tt.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tt.local, tt.remote, got, tt.want)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "targets.txt")
+	if err := os.WriteFile(path, []byte("bogus\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil || !strings.HasPrefix(err.Error(), path+": line 1") {
+		t.Errorf("Load error = %v, want it prefixed with the path and line", err)
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("Load of a missing file should fail")
+	}
+}
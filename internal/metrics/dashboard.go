@@ -0,0 +1,117 @@
+package metrics
+
+import "encoding/json"
+
+// Dashboard layout: two time series panels per row.
+const (
+	panelWidth  = 12
+	panelHeight = 8
+)
+
+// dashboard is the subset of Grafana's dashboard model netmon emits.
+type dashboard struct {
+	UID           string     `json:"uid"`
+	Title         string     `json:"title"`
+	Tags          []string   `json:"tags"`
+	SchemaVersion int        `json:"schemaVersion"`
+	Refresh       string     `json:"refresh"`
+	Time          timeRange  `json:"time"`
+	Templating    templating `json:"templating"`
+	Panels        []panel    `json:"panels"`
+}
+
+type timeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type templating struct {
+	List []variable `json:"list"`
+}
+
+type variable struct {
+	Name       string      `json:"name"`
+	Label      string      `json:"label"`
+	Type       string      `json:"type"`
+	Query      string      `json:"query"`
+	Datasource *datasource `json:"datasource,omitempty"`
+	Multi      bool        `json:"multi,omitempty"`
+	IncludeAll bool        `json:"includeAll,omitempty"`
+	AllValue   string      `json:"allValue,omitempty"`
+	Refresh    int         `json:"refresh,omitempty"`
+}
+
+type datasource struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+type panel struct {
+	ID          int         `json:"id"`
+	Type        string      `json:"type"`
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	Datasource  datasource  `json:"datasource"`
+	GridPos     gridPos     `json:"gridPos"`
+	FieldConfig fieldConfig `json:"fieldConfig"`
+	Targets     []target    `json:"targets"`
+}
+
+type gridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type fieldConfig struct {
+	Defaults struct {
+		Unit string `json:"unit"`
+	} `json:"defaults"`
+}
+
+type target struct {
+	RefID        string     `json:"refId"`
+	Expr         string     `json:"expr"`
+	LegendFormat string     `json:"legendFormat"`
+	Datasource   datasource `json:"datasource"`
+}
+
+// promDatasource refers to the datasource picked in the $datasource variable.
+var promDatasource = datasource{Type: "prometheus", UID: "${datasource}"}
+
+// Dashboard returns a Grafana dashboard (JSON model, ready to import) with a
+// time series panel per registered metric, a Prometheus datasource picker and
+// a process filter.
+func Dashboard() ([]byte, error) {
+	d := dashboard{
+		UID:           "netmon",
+		Title:         "netmon",
+		Tags:          []string{"netmon", "network"},
+		SchemaVersion: 39,
+		Refresh:       "30s",
+		Time:          timeRange{From: "now-6h", To: "now"},
+		Templating: templating{List: []variable{
+			{Name: "datasource", Label: "Data source", Type: "datasource", Query: "prometheus"},
+			{Name: "process", Label: "Process", Type: "query", Datasource: &promDatasource,
+				Query: "label_values(netmon_connections, process)", Multi: true, IncludeAll: true, AllValue: ".*", Refresh: 2},
+		}},
+	}
+	for i, m := range Registry {
+		p := panel{
+			ID:          i + 1,
+			Type:        "timeseries",
+			Title:       m.Panel,
+			Description: m.Help,
+			Datasource:  promDatasource,
+			GridPos:     gridPos{H: panelHeight, W: panelWidth, X: (i % 2) * panelWidth, Y: (i / 2) * panelHeight},
+			Targets:     []target{{RefID: "A", Expr: m.Query(), Datasource: promDatasource}},
+		}
+		p.FieldConfig.Defaults.Unit = m.Unit
+		if m.By != "" {
+			p.Targets[0].LegendFormat = "{{" + m.By + "}}"
+		}
+		d.Panels = append(d.Panels, p)
+	}
+	return json.MarshalIndent(d, "", "  ")
+}
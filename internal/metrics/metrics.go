@@ -0,0 +1,98 @@
+// Package metrics is the registry of the Prometheus metrics netmon exposes:
+// names, types, labels and help text, plus generators for a Grafana dashboard
+// and a metric reference built from the same registry, so neither drifts from
+// what is emitted.
+package metrics
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Type is a Prometheus metric type.
+type Type string
+
+// Metric types used by netmon.
+const (
+	Gauge   Type = "gauge"
+	Counter Type = "counter"
+)
+
+// Metric describes one exposed metric and how the dashboard charts it.
+type Metric struct {
+	Name   string
+	Type   Type
+	Help   string
+	Labels []string
+
+	Panel string // dashboard panel title
+	Unit  string // Grafana unit of the charted value, e.g. "short" or "Bps"
+	By    string // label the panel sums by ("" sums everything into one series)
+}
+
+// Registry lists every metric, in dashboard order.
+var Registry = []Metric{
+	{Name: "netmon_connections", Type: Gauge, Labels: []string{"process", "protocol", "state"},
+		Help:  "Open sockets per process, protocol and state.",
+		Panel: "Connections by process", Unit: "short", By: "process"},
+	{Name: "netmon_listen_sockets", Type: Gauge, Labels: []string{"process"},
+		Help:  "Listening TCP sockets per process.",
+		Panel: "Listening sockets by process", Unit: "short", By: "process"},
+	{Name: "netmon_process_sent_bytes_total", Type: Counter, Labels: []string{"process"},
+		Help:  "Bytes sent per process since it started.",
+		Panel: "TX rate by process", Unit: "Bps", By: "process"},
+	{Name: "netmon_process_received_bytes_total", Type: Counter, Labels: []string{"process"},
+		Help:  "Bytes received per process since it started.",
+		Panel: "RX rate by process", Unit: "Bps", By: "process"},
+	{Name: "netmon_processes", Type: Gauge,
+		Help:  "Processes with at least one socket.",
+		Panel: "Processes with sockets", Unit: "short"},
+}
+
+// Lookup returns the registered metric with the given name.
+func Lookup(name string) (Metric, bool) {
+	for _, m := range Registry {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return Metric{}, false
+}
+
+// hasLabel reports whether m carries label.
+func (m Metric) hasLabel(label string) bool {
+	return slices.Contains(m.Labels, label)
+}
+
+// Query returns the PromQL the dashboard charts for m: the value summed by
+// m.By, as a per-second rate for counters, restricted to the dashboard's
+// $process selection when m has a process label.
+func (m Metric) Query() string {
+	sel := m.Name
+	if m.hasLabel("process") {
+		sel += `{process=~"$process"}`
+	}
+	if m.Type == Counter {
+		sel = "rate(" + sel + "[$__rate_interval])"
+	}
+	if m.By == "" {
+		return "sum(" + sel + ")"
+	}
+	return fmt.Sprintf("sum by (%s) (%s)", m.By, sel)
+}
+
+// Reference renders the registry as a Markdown table for documentation.
+func Reference() string {
+	var b strings.Builder
+	b.WriteString("| Metric | Type | Labels | Description |\n")
+	b.WriteString("|--------|------|--------|-------------|\n")
+	for _, m := range Registry {
+		labels := "—"
+		if len(m.Labels) > 0 {
+			labels = "`" + strings.Join(m.Labels, "`, `") + "`"
+		}
+		fmt.Fprintf(&b, "| `%s` | %s | %s | %s |\n", m.Name, m.Type, labels, m.Help)
+	}
+	return b.String()
+}
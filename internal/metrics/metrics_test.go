@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestRegistry_Valid(t *testing.T) {
+	name := regexp.MustCompile(`^netmon_[a-z_]+$`)
+	seen := make(map[string]bool)
+	for _, m := range Registry {
+		if !name.MatchString(m.Name) || seen[m.Name] {
+			t.Errorf("bad or duplicate metric name %q", m.Name)
+		}
+		seen[m.Name] = true
+		if (m.Type == Counter) != strings.HasSuffix(m.Name, "_total") {
+			t.Errorf("%s: counters and only counters end in _total", m.Name)
+		}
+		if m.By != "" && !m.hasLabel(m.By) {
+			t.Errorf("%s: panel sums by %q, not a label", m.Name, m.By)
+		}
+		if m.Help == "" || m.Panel == "" || m.Unit == "" {
+			t.Errorf("%s: missing help, panel title or unit", m.Name)
+		}
+	}
+}
+
+func TestQuery(t *testing.T) {
+	tests := map[string]string{
+		"netmon_connections":              `sum by (process) (netmon_connections{process=~"$process"})`,
+		"netmon_process_sent_bytes_total": `sum by (process) (rate(netmon_process_sent_bytes_total{process=~"$process"}[$__rate_interval]))`,
+		"netmon_processes":                `sum(netmon_processes)`,
+	}
+	for name, want := range tests {
+		m, ok := Lookup(name)
+		if !ok {
+			t.Fatalf("%s not registered", name)
+		}
+		if got := m.Query(); got != want {
+			t.Errorf("%s Query() = %s, want %s", name, got, want)
+		}
+	}
+}
+
+func TestDashboard_MatchesRegistry(t *testing.T) {
+	data, err := Dashboard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var d dashboard
+	if err := json.Unmarshal(data, &d); err != nil {
+		t.Fatalf("dashboard is not valid JSON: %v", err)
+	}
+	if len(d.Panels) != len(Registry) {
+		t.Fatalf("%d panels, want one per metric (%d)", len(d.Panels), len(Registry))
+	}
+	metricRef := regexp.MustCompile(`netmon_[a-z_]+`)
+	for _, p := range d.Panels {
+		for _, tgt := range p.Targets {
+			for _, ref := range metricRef.FindAllString(tgt.Expr, -1) {
+				if _, ok := Lookup(ref); !ok {
+					t.Errorf("panel %q queries unregistered metric %s", p.Title, ref)
+				}
+			}
+		}
+	}
+	for _, v := range d.Templating.List {
+		for _, ref := range metricRef.FindAllString(v.Query, -1) {
+			if _, ok := Lookup(ref); !ok {
+				t.Errorf("variable %q queries unregistered metric %s", v.Name, ref)
+			}
+		}
+	}
+}
+
+func TestReference(t *testing.T) {
+	ref := Reference()
+	for _, m := range Registry {
+		if !strings.Contains(ref, "`"+m.Name+"`") {
+			t.Errorf("reference missing %s", m.Name)
+		}
+	}
+	if !strings.Contains(ref, "`process`, `protocol`, `state`") {
+		t.Errorf("reference missing label list:\n%s", ref)
+	}
+}
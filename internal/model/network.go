@@ -2,6 +2,7 @@ package model
 
 import (
 	"fmt"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
@@ -21,9 +22,27 @@ type Protocol string
 const (
 	ProtocolTCP     Protocol = "TCP"
 	ProtocolUDP     Protocol = "UDP"
+	ProtocolICMP    Protocol = "ICMP" // ping sockets and raw ICMP/ICMPv6 sockets (Linux)
+	ProtocolRaw     Protocol = "RAW"  // raw IP sockets for other protocols (Linux)
 	ProtocolUnknown Protocol = "UNK"
 )
 
+// CastKind classifies a UDP socket that talks to a group of hosts rather than one.
+type CastKind string
+
+const (
+	CastMulticast CastKind = "multicast"
+	CastBroadcast CastKind = "broadcast"
+)
+
+// Well-known UDP ports of multicast and broadcast discovery protocols, used to
+// classify sockets bound to the wildcard address (the group itself is joined
+// per interface and does not show up in the socket's address).
+var (
+	multicastPorts = map[int]bool{5353: true, 1900: true, 5355: true, 3702: true} // mDNS, SSDP, LLMNR, WS-Discovery
+	broadcastPorts = map[int]bool{67: true, 68: true, 137: true, 138: true}       // DHCP, NetBIOS
+)
+
 // ConnectionState represents a TCP connection state.
 type ConnectionState string
 
@@ -40,6 +59,32 @@ type ContainerInfo struct {
 	Name  string // Container name (e.g., "nginx-proxy")
 	Image string // Image tag (e.g., "nginx:latest")
 	ID    string // Short container ID
+
+	Health       string // Healthcheck status: "healthy", "unhealthy" or "starting"; "" without a healthcheck
+	Restarting   bool   // Being restarted by its restart policy
+	RestartCount int    // Restarts by the restart policy since the container was created
+}
+
+// HealthLabel returns the health indicator shown next to a container, e.g.
+// "healthy", "unhealthy ×3" or "restarting ×5" (×N is the restart count), or
+// "" for a running container without a healthcheck that never restarted.
+func (ci ContainerInfo) HealthLabel() string {
+	var parts []string
+	if ci.Restarting {
+		parts = append(parts, "restarting")
+	} else if ci.Health != "" {
+		parts = append(parts, ci.Health)
+	}
+	if ci.RestartCount > 0 {
+		parts = append(parts, fmt.Sprintf("×%d", ci.RestartCount))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Flapping reports whether the container is unhealthy, restarting, or has
+// been restarted, the states worth correlating with network symptoms.
+func (ci ContainerInfo) Flapping() bool {
+	return ci.Health == "unhealthy" || ci.Restarting || ci.RestartCount > 0
 }
 
 // PortMapping represents a Docker container port binding.
@@ -47,12 +92,14 @@ type PortMapping struct {
 	HostPort      int    // Port on the host (e.g., 8080)
 	ContainerPort int    // Port inside the container (e.g., 80)
 	Protocol      string // "tcp" or "udp"
+	HostIP        string // Bind address on the host ("0.0.0.0", "::", "127.0.0.1"); empty if unknown
 }
 
 // VirtualContainer represents a Docker container as a virtual process row.
 type VirtualContainer struct {
 	Info         ContainerInfo
 	PortMappings []PortMapping
+	NetIO        *NetIOStats // Network totals from the Docker stats API (nil if unavailable)
 }
 
 // FormatContainerColumn returns display string for the Container column.
@@ -81,8 +128,129 @@ type Connection struct {
 	LocalAddr   string          // e.g., 127.0.0.1:52341
 	RemoteAddr  string          // e.g., 142.250.80.46:443 or * for listening
 	State       ConnectionState // e.g., ESTABLISHED, LISTEN, - for UDP
+	FD          uint32          // Socket file descriptor in the owning process (0 if unknown, as in gopsutil)
 	Container   *ContainerInfo  // Docker container info (nil for non-Docker)
 	PortMapping *PortMapping    // Docker port mapping (nil if no mapping)
+	TCP         *TCPStats       // Kernel TCP stats (Linux with TCPStats option; nil otherwise)
+	Accept      *AcceptQueue    // Accept queue of a TCP LISTEN socket (Linux; nil otherwise)
+}
+
+// TCPStats holds passive per-connection TCP measurements from the kernel.
+type TCPStats struct {
+	RTT     time.Duration // smoothed round-trip time
+	RTTVar  time.Duration // round-trip time variance
+	Retrans uint32        // total retransmitted segments
+}
+
+// AcceptQueue is the accept queue of a listening TCP socket: connections the
+// kernel has completed but the process has not yet accept()ed.
+type AcceptQueue struct {
+	Queued  uint32 // connections waiting to be accepted
+	Backlog uint32 // queue capacity (listen backlog, capped by somaxconn)
+}
+
+// acceptQueueSaturatedPct is how full an accept queue must be to count as saturated.
+const acceptQueueSaturatedPct = 80
+
+// Saturated reports whether the queue is near capacity, where new connections
+// start being dropped and clients see timeouts. False for a nil queue.
+func (q *AcceptQueue) Saturated() bool {
+	if q == nil || q.Backlog == 0 {
+		return false
+	}
+	return uint64(q.Queued)*100 >= uint64(q.Backlog)*acceptQueueSaturatedPct
+}
+
+// Cast returns whether c is a multicast or broadcast UDP socket, or "" for
+// unicast and other protocols. A multicast or limited broadcast address on
+// either end decides; otherwise an unconnected socket on a discovery port does.
+func (c Connection) Cast() CastKind {
+	if c.Protocol != ProtocolUDP {
+		return ""
+	}
+	for _, addr := range []string{c.LocalAddr, c.RemoteAddr} {
+		ip := net.ParseIP(strings.Trim(AddrHost(addr), "[]"))
+		switch {
+		case ip == nil:
+		case ip.IsMulticast():
+			return CastMulticast
+		case ip.Equal(net.IPv4bcast):
+			return CastBroadcast
+		}
+	}
+	if c.RemoteAddr != "*" {
+		return ""
+	}
+	port := ExtractPort(c.LocalAddr)
+	switch {
+	case multicastPorts[port]:
+		return CastMulticast
+	case broadcastPorts[port]:
+		return CastBroadcast
+	}
+	return ""
+}
+
+// BindScope classifies the local address of a socket by who can reach it.
+type BindScope int
+
+const (
+	BindWildcard  BindScope = iota // 0.0.0.0 or ::, reachable on every interface
+	BindInterface                  // one specific interface address
+	BindLoopback                   // 127.0.0.0/8 or ::1, local only
+)
+
+// String returns "wildcard", "interface" or "loopback".
+func (s BindScope) String() string {
+	switch s {
+	case BindWildcard:
+		return "wildcard"
+	case BindInterface:
+		return "interface"
+	case BindLoopback:
+		return "loopback"
+	default:
+		return fmt.Sprintf("BindScope(%d)", int(s))
+	}
+}
+
+// BindScope classifies the connection's local address. Unparsable addresses
+// count as interface binds.
+func (c Connection) BindScope() BindScope {
+	host := strings.Trim(AddrHost(c.LocalAddr), "[]")
+	if host == "*" || host == "" {
+		return BindWildcard
+	}
+	ip := net.ParseIP(host)
+	switch {
+	case ip == nil:
+		return BindInterface
+	case ip.IsUnspecified():
+		return BindWildcard
+	case ip.IsLoopback():
+		return BindLoopback
+	}
+	return BindInterface
+}
+
+// IsBound reports whether the connection is a server socket waiting for
+// peers: a TCP listener or a UDP socket without a connected remote.
+func (c Connection) IsBound() bool {
+	switch c.Protocol {
+	case ProtocolTCP:
+		return c.State == StateListen
+	case ProtocolUDP:
+		return c.RemoteAddr == "*"
+	}
+	return false
+}
+
+// AddrHost returns the host part of an "ip:port" address, or addr without a port.
+func AddrHost(addr string) string {
+	if idx := strings.LastIndex(addr, ":"); idx >= 0 {
+		return addr[:idx]
+	}
+	return addr
 }
 
 // Application represents a grouped set of connections by app name.
@@ -93,24 +261,40 @@ type Application struct {
 	Connections      []Connection // All connections across all PIDs
 	EstablishedCount int          // Number of ESTABLISHED connections
 	ListenCount      int          // Number of LISTEN connections
+	SampledTotal     int          // Connections before sampling (0 = snapshot not sampled)
 }
 
-// ConnectionCount returns the number of connections for this application.
+// ConnectionCount returns the number of connections for this application,
+// the exact count before sampling when the snapshot is sampled.
 func (a *Application) ConnectionCount() int {
+	if a.SampledTotal > 0 {
+		return a.SampledTotal
+	}
 	return len(a.Connections)
 }
 
 // NetworkSnapshot represents all network data at a point in time.
 type NetworkSnapshot struct {
-	Applications []Application
-	Timestamp    time.Time
-	SkippedCount int // Number of connections skipped due to unknown process
+	Applications  []Application
+	Timestamp     time.Time
+	SkippedCount  int     // Number of connections skipped due to unknown process
+	HiddenCount   int     // Number of connections without an owning PID (excluding TIME_WAIT)
+	LoopbackCount int     // Number of loopback connections dropped by the HideLoopback option
+	Sample        *Sample // Set when Applications hold only a sample of the connections
+}
+
+// Sample describes a snapshot that keeps a sample of a busy host's
+// connections. Application counts (SampledTotal, EstablishedCount,
+// ListenCount) stay exact.
+type Sample struct {
+	Total   int                     // connections before sampling
+	ByState map[ConnectionState]int // exact count per state
 }
 
 // SortByConnectionCount sorts applications by number of connections (descending).
 func (s *NetworkSnapshot) SortByConnectionCount() {
 	sort.Slice(s.Applications, func(i, j int) bool {
-		return len(s.Applications[i].Connections) > len(s.Applications[j].Connections)
+		return s.Applications[i].ConnectionCount() > s.Applications[j].ConnectionCount()
 	})
 }
 
@@ -123,13 +307,35 @@ func (s *NetworkSnapshot) TotalConnections() int {
 	return total
 }
 
-// ConnectionKey uniquely identifies a connection.
+// HostConnections returns the number of connections on the host: the total
+// before sampling for a sampled snapshot, else TotalConnections.
+func (s *NetworkSnapshot) HostConnections() int {
+	if s.Sample != nil {
+		return s.Sample.Total
+	}
+	return s.TotalConnections()
+}
+
+// ConnectionKey uniquely identifies a connection. Protocol and PID keep a TCP
+// and a UDP socket on the same addresses, or two same-named processes on the
+// same addresses, apart; left zero they match any protocol or PID.
 type ConnectionKey struct {
 	ProcessName string
+	Protocol    Protocol
+	PID         int32
 	LocalAddr   string
 	RemoteAddr  string
 }
 
+// Matches reports whether c, owned by processName, is the connection k identifies.
+func (k ConnectionKey) Matches(processName string, c Connection) bool {
+	return processName == k.ProcessName &&
+		c.LocalAddr == k.LocalAddr &&
+		c.RemoteAddr == k.RemoteAddr &&
+		(k.Protocol == "" || c.Protocol == k.Protocol) &&
+		(k.PID == 0 || c.PID == k.PID)
+}
+
 // SelectionID identifies a selected item (process or connection).
 type SelectionID struct {
 	ProcessName   string
@@ -141,14 +347,16 @@ func SelectionIDFromProcess(name string) SelectionID {
 	return SelectionID{ProcessName: name}
 }
 
-// SelectionIDFromConnection creates a SelectionID for a connection.
-func SelectionIDFromConnection(processName, localAddr, remoteAddr string) SelectionID {
+// SelectionIDFromConnection creates a SelectionID for a connection of processName.
+func SelectionIDFromConnection(processName string, c Connection) SelectionID {
 	return SelectionID{
 		ProcessName: processName,
 		ConnectionKey: &ConnectionKey{
 			ProcessName: processName,
-			LocalAddr:   localAddr,
-			RemoteAddr:  remoteAddr,
+			Protocol:    c.Protocol,
+			PID:         c.PID,
+			LocalAddr:   c.LocalAddr,
+			RemoteAddr:  c.RemoteAddr,
 		},
 	}
 }
@@ -216,7 +216,9 @@ func TestSelectionIDFromProcess_EmptyName(t *testing.T) {
 }
 
 func TestSelectionIDFromConnection(t *testing.T) {
-	id := SelectionIDFromConnection("TestApp", "127.0.0.1:80", "10.0.0.1:443")
+	id := SelectionIDFromConnection("TestApp", Connection{
+		Protocol: ProtocolUDP, PID: 42, LocalAddr: "127.0.0.1:80", RemoteAddr: "10.0.0.1:443",
+	})
 
 	if id.ProcessName != "TestApp" {
 		t.Errorf("ProcessName = %q, want 'TestApp'", id.ProcessName)
@@ -233,10 +235,30 @@ func TestSelectionIDFromConnection(t *testing.T) {
 	if id.ConnectionKey.RemoteAddr != "10.0.0.1:443" {
 		t.Errorf("ConnectionKey.RemoteAddr = %q, want '10.0.0.1:443'", id.ConnectionKey.RemoteAddr)
 	}
+	if id.ConnectionKey.Protocol != ProtocolUDP || id.ConnectionKey.PID != 42 {
+		t.Errorf("ConnectionKey protocol/PID = %s/%d, want UDP/42", id.ConnectionKey.Protocol, id.ConnectionKey.PID)
+	}
+}
+
+func TestConnectionKeyMatches_ProtocolAndPID(t *testing.T) {
+	tcp := Connection{Protocol: ProtocolTCP, PID: 1, LocalAddr: "0.0.0.0:53", RemoteAddr: "*:*"}
+	udp := Connection{Protocol: ProtocolUDP, PID: 1, LocalAddr: "0.0.0.0:53", RemoteAddr: "*:*"}
+	other := Connection{Protocol: ProtocolUDP, PID: 2, LocalAddr: "0.0.0.0:53", RemoteAddr: "*:*"}
+
+	key := SelectionIDFromConnection("dnsmasq", udp).ConnectionKey
+	if key.Matches("dnsmasq", tcp) || key.Matches("dnsmasq", other) || !key.Matches("dnsmasq", udp) {
+		t.Error("a UDP key for PID 1 should match only the UDP socket of PID 1")
+	}
+
+	// Keys without protocol or PID match any
+	loose := ConnectionKey{ProcessName: "dnsmasq", LocalAddr: "0.0.0.0:53", RemoteAddr: "*:*"}
+	if !loose.Matches("dnsmasq", tcp) || !loose.Matches("dnsmasq", other) {
+		t.Error("zero protocol and PID should match any")
+	}
 }
 
 func TestSelectionIDFromConnection_EmptyFields(t *testing.T) {
-	id := SelectionIDFromConnection("", "", "")
+	id := SelectionIDFromConnection("", Connection{})
 
 	if id.ProcessName != "" {
 		t.Errorf("ProcessName = %q, want ''", id.ProcessName)
@@ -522,3 +544,111 @@ func TestFormatContainerColumn_NoTruncationNeeded(t *testing.T) {
 		t.Errorf("FormatContainerColumn = %q, want %q", got, want)
 	}
 }
+
+func TestConnectionCast(t *testing.T) {
+	tests := []struct {
+		name string
+		conn Connection
+		want CastKind
+	}{
+		{"mDNS wildcard", Connection{Protocol: ProtocolUDP, LocalAddr: "0.0.0.0:5353", RemoteAddr: "*"}, CastMulticast},
+		{"SSDP wildcard v6", Connection{Protocol: ProtocolUDP, LocalAddr: ":::1900", RemoteAddr: "*"}, CastMulticast},
+		{"bound to group", Connection{Protocol: ProtocolUDP, LocalAddr: "239.255.255.250:9999", RemoteAddr: "*"}, CastMulticast},
+		{"sending to v6 group", Connection{Protocol: ProtocolUDP, LocalAddr: "fe80::1:40000", RemoteAddr: "ff02::fb:5353"}, CastMulticast},
+		{"DHCP client", Connection{Protocol: ProtocolUDP, LocalAddr: "0.0.0.0:68", RemoteAddr: "*"}, CastBroadcast},
+		{"limited broadcast", Connection{Protocol: ProtocolUDP, LocalAddr: "10.0.0.2:40000", RemoteAddr: "255.255.255.255:9"}, CastBroadcast},
+		{"DNS query", Connection{Protocol: ProtocolUDP, LocalAddr: "10.0.0.2:40000", RemoteAddr: "8.8.8.8:53"}, ""},
+		{"connected on discovery port", Connection{Protocol: ProtocolUDP, LocalAddr: "10.0.0.2:5353", RemoteAddr: "10.0.0.9:5353"}, ""},
+		{"TCP", Connection{Protocol: ProtocolTCP, LocalAddr: "0.0.0.0:5353", RemoteAddr: "*"}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.conn.Cast(); got != tt.want {
+			t.Errorf("%s: Cast() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAcceptQueueSaturated(t *testing.T) {
+	tests := []struct {
+		q    *AcceptQueue
+		want bool
+	}{
+		{nil, false},
+		{&AcceptQueue{Queued: 0, Backlog: 0}, false},
+		{&AcceptQueue{Queued: 3, Backlog: 128}, false},
+		{&AcceptQueue{Queued: 102, Backlog: 128}, false},
+		{&AcceptQueue{Queued: 103, Backlog: 128}, true},
+		{&AcceptQueue{Queued: 129, Backlog: 128}, true},
+	}
+	for _, tt := range tests {
+		if got := tt.q.Saturated(); got != tt.want {
+			t.Errorf("%+v.Saturated() = %v, want %v", tt.q, got, tt.want)
+		}
+	}
+}
+
+func TestConnectionBindScope(t *testing.T) {
+	tests := []struct {
+		local string
+		want  BindScope
+	}{
+		{"0.0.0.0:8080", BindWildcard},
+		{":::8080", BindWildcard},
+		{"*:53", BindWildcard},
+		{"127.0.0.1:5432", BindLoopback},
+		{"127.0.0.53:53", BindLoopback},
+		{"::1:6379", BindLoopback},
+		{"192.168.1.5:22", BindInterface},
+		{"fe80::1:22", BindInterface},
+		{"garbage", BindInterface},
+	}
+	for _, tt := range tests {
+		c := Connection{Protocol: ProtocolTCP, LocalAddr: tt.local}
+		if got := c.BindScope(); got != tt.want {
+			t.Errorf("BindScope(%q) = %v, want %v", tt.local, got, tt.want)
+		}
+	}
+}
+
+func TestConnectionIsBound(t *testing.T) {
+	tests := []struct {
+		name string
+		conn Connection
+		want bool
+	}{
+		{"tcp listen", Connection{Protocol: ProtocolTCP, State: StateListen, RemoteAddr: "*"}, true},
+		{"tcp established", Connection{Protocol: ProtocolTCP, State: StateEstablished, RemoteAddr: "1.1.1.1:443"}, false},
+		{"udp unconnected", Connection{Protocol: ProtocolUDP, State: StateNone, RemoteAddr: "*"}, true},
+		{"udp connected", Connection{Protocol: ProtocolUDP, State: StateNone, RemoteAddr: "8.8.8.8:53"}, false},
+		{"raw", Connection{Protocol: ProtocolRaw, RemoteAddr: "*"}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.conn.IsBound(); got != tt.want {
+			t.Errorf("%s: IsBound() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestContainerInfoHealthLabel(t *testing.T) {
+	tests := []struct {
+		info     ContainerInfo
+		want     string
+		flapping bool
+	}{
+		{ContainerInfo{}, "", false},
+		{ContainerInfo{Health: "healthy"}, "healthy", false},
+		{ContainerInfo{Health: "starting"}, "starting", false},
+		{ContainerInfo{Health: "unhealthy"}, "unhealthy", true},
+		{ContainerInfo{Health: "healthy", RestartCount: 2}, "healthy ×2", true},
+		{ContainerInfo{Health: "unhealthy", Restarting: true, RestartCount: 5}, "restarting ×5", true},
+		{ContainerInfo{RestartCount: 1}, "×1", true},
+	}
+	for _, tt := range tests {
+		if got := tt.info.HealthLabel(); got != tt.want {
+			t.Errorf("%+v HealthLabel() = %q, want %q", tt.info, got, tt.want)
+		}
+		if got := tt.info.Flapping(); got != tt.flapping {
+			t.Errorf("%+v Flapping() = %v, want %v", tt.info, got, tt.flapping)
+		}
+	}
+}
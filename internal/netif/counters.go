@@ -0,0 +1,56 @@
+package netif
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+
+	"github.com/shirou/gopsutil/v3/net"
+)
+
+// Counters are an interface's byte totals since boot.
+type Counters struct {
+	BytesSent uint64
+	BytesRecv uint64
+}
+
+// ReadCounters returns the byte counters of every interface, by name.
+func ReadCounters(ctx context.Context) (map[string]Counters, error) {
+	stats, err := net.IOCountersWithContext(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]Counters, len(stats))
+	for _, s := range stats {
+		out[s.Name] = Counters{BytesSent: s.BytesSent, BytesRecv: s.BytesRecv}
+	}
+	return out, nil
+}
+
+// LinkSpeed returns the negotiated speed of an interface in bits per second,
+// or 0 when the OS does not report one (Wi-Fi, virtual and tunnel interfaces
+// usually don't).
+func LinkSpeed(name string) uint64 {
+	return linkSpeed(name)
+}
+
+// mediaSpeed matches the speed in an ifconfig media line, e.g.
+// "media: autoselect (1000baseT <full-duplex>)" or "(10Gbase-SR <...>)".
+var mediaSpeed = regexp.MustCompile(`media:.*\((\d+)(G?)base`)
+
+// parseMediaSpeed returns the link speed in bits per second from ifconfig
+// output, or 0.
+func parseMediaSpeed(ifconfig string) uint64 {
+	m := mediaSpeed.FindStringSubmatch(ifconfig)
+	if m == nil {
+		return 0
+	}
+	n, err := strconv.ParseUint(m[1], 10, 64)
+	if err != nil {
+		return 0
+	}
+	if m[2] == "G" {
+		return n * 1e9
+	}
+	return n * 1e6
+}
@@ -0,0 +1,167 @@
+// Package netif attributes connections to the network interface their
+// traffic goes through (en0, utun3, wg0, docker0), from the interface owning
+// the local address, or the route table when the local address is a wildcard.
+package netif
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// route is one IPv4 route table entry.
+type route struct {
+	prefix netip.Prefix
+	iface  string
+	metric int
+}
+
+// Table maps addresses to interfaces. The zero value attributes nothing.
+type Table struct {
+	byAddr map[netip.Addr]string // interface address → interface name
+	routes []route
+}
+
+// Load reads the interface addresses and, on Linux, the main IPv4 route table.
+// Route table errors are not fatal: attribution by local address still works.
+func Load() (*Table, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	t := &Table{byAddr: make(map[netip.Addr]string)}
+	for _, iface := range ifaces {
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, a := range addrs {
+			if ipnet, ok := a.(*net.IPNet); ok {
+				if ip, ok := netip.AddrFromSlice(ipnet.IP); ok {
+					t.byAddr[ip.Unmap()] = iface.Name
+				}
+			}
+		}
+	}
+	t.routes, _ = loadRoutes()
+	return t, nil
+}
+
+// NewTable builds a table from interface addresses ("10.0.0.5" → "eth0") and
+// routes, for tests and callers with their own data source.
+func NewTable(addrs map[string]string, routes io.Reader) (*Table, error) {
+	t := &Table{byAddr: make(map[netip.Addr]string)}
+	for addr, name := range addrs {
+		ip, err := netip.ParseAddr(addr)
+		if err != nil {
+			return nil, err
+		}
+		t.byAddr[ip.Unmap()] = name
+	}
+	if routes != nil {
+		var err error
+		if t.routes, err = parseRoutes(routes); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// Interface returns the interface a connection between localIP and remoteIP
+// uses, or "" if unknown. The interface owning the local address wins: the
+// kernel picked that source address from the route to the peer. A wildcard
+// local address (unconnected UDP) falls back to the route to the remote IP.
+// Policy routing (ip rule, as used by some VPN clients) is not consulted.
+func (t *Table) Interface(localIP, remoteIP string) string {
+	if t == nil {
+		return ""
+	}
+	local, err := netip.ParseAddr(localIP)
+	if err != nil {
+		return ""
+	}
+	if zone := local.Zone(); zone != "" {
+		return zone // link-local addresses carry their interface
+	}
+	local = local.Unmap()
+	if name, ok := t.byAddr[local]; ok {
+		return name
+	}
+	if !local.IsUnspecified() {
+		return ""
+	}
+	remote, err := netip.ParseAddr(remoteIP)
+	if err != nil {
+		return ""
+	}
+	return t.route(remote.Unmap())
+}
+
+// route returns the interface of the most specific route to ip, preferring
+// the lowest metric among equally specific routes.
+func (t *Table) route(ip netip.Addr) string {
+	best := -1
+	for i, r := range t.routes {
+		if !r.prefix.Contains(ip) {
+			continue
+		}
+		if best < 0 || r.prefix.Bits() > t.routes[best].prefix.Bits() ||
+			(r.prefix.Bits() == t.routes[best].prefix.Bits() && r.metric < t.routes[best].metric) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return ""
+	}
+	return t.routes[best].iface
+}
+
+// rtfUp marks a usable route in /proc/net/route.
+const rtfUp = 0x1
+
+// parseRoutes parses the Linux /proc/net/route format. Addresses and masks are
+// hex in host byte order (little-endian on every platform netmon ships for).
+func parseRoutes(r io.Reader) ([]route, error) {
+	var routes []route
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		fields := strings.Fields(scanner.Text())
+		if n == 1 || len(fields) < 8 {
+			continue // header
+		}
+		dest, err1 := parseHexAddr(fields[1])
+		flags, err2 := strconv.ParseUint(fields[3], 16, 32)
+		metric, err3 := strconv.Atoi(fields[6])
+		mask, err4 := parseHexAddr(fields[7])
+		if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+			return nil, fmt.Errorf("route line %d: malformed %q", n, scanner.Text())
+		}
+		if flags&rtfUp == 0 {
+			continue
+		}
+		bits := 0
+		for _, b := range mask.As4() {
+			for ; b != 0; b <<= 1 {
+				bits++
+			}
+		}
+		routes = append(routes, route{prefix: netip.PrefixFrom(dest, bits).Masked(), iface: fields[0], metric: metric})
+	}
+	return routes, scanner.Err()
+}
+
+// parseHexAddr decodes a little-endian hex IPv4 address ("0101A8C0" → 192.168.1.1).
+func parseHexAddr(s string) (netip.Addr, error) {
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], uint32(v))
+	return netip.AddrFrom4(b), nil
+}
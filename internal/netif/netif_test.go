@@ -0,0 +1,94 @@
+package netif
+
+import (
+	"strings"
+	"testing"
+)
+
+const testRoutes = "Iface\tDestination\tGateway \tFlags\tRefCnt\tUse\tMetric\tMask\t\tMTU\tWindow\tIRTT\n" +
+	"eth0\t00000000\t0101A8C0\t0003\t0\t0\t100\t00000000\t0\t0\t0\n" +
+	"wg0\t0000000A\t00000000\t0001\t0\t0\t0\t000000FF\t0\t0\t0\n" +
+	"eth0\t0001A8C0\t00000000\t0001\t0\t0\t100\t00FFFFFF\t0\t0\t0\n" +
+	"tun9\t0000000A\t00000000\t0001\t0\t0\t50\t0000FFFF\t0\t0\t0\n" +
+	"down0\t0002000A\t00000000\t0000\t0\t0\t0\t00FFFFFF\t0\t0\t0\n"
+
+func TestParseRoutes(t *testing.T) {
+	routes, err := parseRoutes(strings.NewReader(testRoutes))
+	if err != nil {
+		t.Fatalf("parseRoutes: %v", err)
+	}
+	if len(routes) != 4 {
+		t.Fatalf("routes = %d, want 4 (route without RTF_UP skipped)", len(routes))
+	}
+	if got := routes[1].prefix.String(); got != "10.0.0.0/8" {
+		t.Errorf("wg0 prefix = %s, want 10.0.0.0/8", got)
+	}
+	if _, err := parseRoutes(strings.NewReader("hdr\neth0 zz 0 1 0 0 0 0\n")); err == nil {
+		t.Error("malformed line should fail")
+	}
+}
+
+func TestInterface(t *testing.T) {
+	tbl, err := NewTable(map[string]string{
+		"192.168.1.5": "eth0",
+		"10.8.0.2":    "utun3",
+		"127.0.0.1":   "lo",
+	}, strings.NewReader(testRoutes))
+	if err != nil {
+		t.Fatalf("NewTable: %v", err)
+	}
+	for _, tt := range []struct{ local, remote, want string }{
+		{"192.168.1.5", "93.184.216.34", "eth0"},
+		{"::ffff:10.8.0.2", "10.0.0.7", "utun3"}, // IPv4-mapped local address
+		{"127.0.0.1", "127.0.0.1", "lo"},
+		{"fe80::1%en0", "fe80::2", "en0"},  // zone names the interface
+		{"0.0.0.0", "10.0.2.3", "tun9"},    // /16 beats /8
+		{"0.0.0.0", "192.168.1.9", "eth0"}, // connected route
+		{"0.0.0.0", "8.8.8.8", "eth0"},     // default route
+		{"172.16.0.1", "8.8.8.8", ""},      // address on no interface (other namespace)
+		{"0.0.0.0", "*", ""},               // unconnected socket
+	} {
+		if got := tbl.Interface(tt.local, tt.remote); got != tt.want {
+			t.Errorf("Interface(%s, %s) = %q, want %q", tt.local, tt.remote, got, tt.want)
+		}
+	}
+
+	var none *Table
+	if got := none.Interface("192.168.1.5", "8.8.8.8"); got != "" {
+		t.Errorf("nil table = %q, want empty", got)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tbl, err := Load()
+	if err != nil {
+		t.Skipf("no interfaces: %v", err)
+	}
+	if got := tbl.Interface("127.0.0.1", "127.0.0.1"); got == "" {
+		t.Error("loopback address should belong to an interface")
+	}
+}
+
+func TestParseMediaSpeed(t *testing.T) {
+	tests := map[string]uint64{
+		"en0: flags=8863<UP>\n\tmedia: autoselect (1000baseT <full-duplex>)\n\tstatus: active": 1e9,
+		"\tmedia: autoselect (10Gbase-SR <full-duplex>)":                                       10e9,
+		"\tmedia: autoselect (100baseTX <half-duplex>)":                                        100e6,
+		"\tmedia: autoselect\n\tstatus: active":                                                0, // Wi-Fi
+	}
+	for in, want := range tests {
+		if got := parseMediaSpeed(in); got != want {
+			t.Errorf("parseMediaSpeed(%q) = %d, want %d", in, got, want)
+		}
+	}
+}
+
+func TestReadCounters(t *testing.T) {
+	counters, err := ReadCounters(t.Context())
+	if err != nil {
+		t.Skipf("no interface counters: %v", err)
+	}
+	if len(counters) == 0 {
+		t.Error("expected at least one interface")
+	}
+}
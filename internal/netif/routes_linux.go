@@ -0,0 +1,15 @@
+//go:build linux
+
+package netif
+
+import "os"
+
+// loadRoutes reads the main IPv4 route table.
+func loadRoutes() ([]route, error) {
+	f, err := os.Open("/proc/net/route")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return parseRoutes(f)
+}
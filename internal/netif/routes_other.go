@@ -0,0 +1,10 @@
+//go:build !linux
+
+package netif
+
+// loadRoutes is not implemented outside Linux. Attribution uses local
+// addresses only, which covers connected sockets: their source address is
+// the outgoing interface's, utun VPN tunnels included.
+func loadRoutes() ([]route, error) {
+	return nil, nil
+}
@@ -0,0 +1,14 @@
+//go:build darwin
+
+package netif
+
+import "os/exec"
+
+// linkSpeed reads the media line of `ifconfig NAME`.
+func linkSpeed(name string) uint64 {
+	out, err := exec.Command("ifconfig", name).Output()
+	if err != nil {
+		return 0
+	}
+	return parseMediaSpeed(string(out))
+}
@@ -0,0 +1,23 @@
+//go:build linux
+
+package netif
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// linkSpeed reads /sys/class/net/NAME/speed (Mbit/s, -1 when unknown).
+func linkSpeed(name string) uint64 {
+	data, err := os.ReadFile(filepath.Join("/sys/class/net", filepath.Base(name), "speed"))
+	if err != nil {
+		return 0
+	}
+	mbps, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil || mbps <= 0 {
+		return 0
+	}
+	return uint64(mbps) * 1e6
+}
@@ -0,0 +1,8 @@
+//go:build !linux && !darwin
+
+package netif
+
+// linkSpeed is not implemented on this platform.
+func linkSpeed(string) uint64 {
+	return 0
+}
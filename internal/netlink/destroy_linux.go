@@ -0,0 +1,114 @@
+//go:build linux
+
+package netlink
+
+import (
+	"fmt"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// Destroy notification groups from enum sknetlink_groups in linux/sock_diag.h.
+const (
+	sknlgrpInetTCPDestroy  = 1
+	sknlgrpInetUDPDestroy  = 2
+	sknlgrpInet6TCPDestroy = 3
+	sknlgrpInet6UDPDestroy = 4
+)
+
+// destroyReadTimeout bounds a blocking Read, so a reader loop can notice it
+// was asked to stop.
+const destroyReadTimeout = 500 * time.Millisecond
+
+// ListenDestroyed subscribes to the destroy notifications of all TCP and UDP
+// sockets (IPv4 and IPv6). The kernel sends them to unprivileged listeners;
+// each carries the socket's last inet_diag message, without process
+// ownership (the owning file is already closed).
+func ListenDestroyed() (*DestroyListener, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, unix.NETLINK_SOCK_DIAG)
+	if err != nil {
+		return nil, fmt.Errorf("netlink socket: %w", err)
+	}
+	var groups uint32
+	for _, g := range []uint32{sknlgrpInetTCPDestroy, sknlgrpInetUDPDestroy, sknlgrpInet6TCPDestroy, sknlgrpInet6UDPDestroy} {
+		groups |= 1 << (g - 1)
+	}
+	if err := unix.Bind(fd, &unix.SockaddrNetlink{Family: unix.AF_NETLINK, Groups: groups}); err != nil {
+		_ = unix.Close(fd)
+		return nil, fmt.Errorf("netlink subscribe to socket destroy events: %w", err)
+	}
+	tv := unix.NsecToTimeval(destroyReadTimeout.Nanoseconds())
+	if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv); err != nil {
+		_ = unix.Close(fd)
+		return nil, fmt.Errorf("netlink receive timeout: %w", err)
+	}
+	return &DestroyListener{fd: fd, buf: make([]byte, 64*1024)}, nil
+}
+
+// Read blocks for the next batch of destroyed sockets. It returns no sockets
+// and no error when nothing arrived within half a second. An ENOBUFS error
+// means notifications were lost because the reader fell behind; reading can
+// go on.
+func (l *DestroyListener) Read() ([]Socket, error) {
+	buf := l.buf
+	n, from, err := unix.Recvfrom(l.fd, buf, 0)
+	if err == unix.EAGAIN || err == unix.EINTR {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("netlink recv: %w", err)
+	}
+	// The group a notification was sent to tells its protocol, which
+	// inet_diag_msg does not carry.
+	var group uint32
+	if sa, ok := from.(*unix.SockaddrNetlink); ok {
+		group = sa.Groups
+	}
+	msgs, err := syscall.ParseNetlinkMessage(buf[:n])
+	if err != nil {
+		return nil, fmt.Errorf("netlink parse: %w", err)
+	}
+
+	var sockets []Socket
+	for _, m := range msgs {
+		if m.Header.Type != sockDiagByFamily || len(m.Data) == 0 {
+			continue
+		}
+		family, protocol := destroyGroupSocket(group)
+		if f := m.Data[0]; f == unix.AF_INET || f == unix.AF_INET6 {
+			family = f
+		}
+		if protocol == 0 {
+			continue
+		}
+		sock, err := parseInetDiagMsg(m.Data, family, protocol)
+		if err != nil {
+			return nil, err
+		}
+		sockets = append(sockets, sock)
+	}
+	return sockets, nil
+}
+
+// destroyGroupSocket returns the family and protocol of a destroy
+// notification group bitmask, 0, 0 if unknown.
+func destroyGroupSocket(groups uint32) (family, protocol uint8) {
+	switch groups {
+	case 1 << (sknlgrpInetTCPDestroy - 1):
+		return unix.AF_INET, unix.IPPROTO_TCP
+	case 1 << (sknlgrpInetUDPDestroy - 1):
+		return unix.AF_INET, unix.IPPROTO_UDP
+	case 1 << (sknlgrpInet6TCPDestroy - 1):
+		return unix.AF_INET6, unix.IPPROTO_TCP
+	case 1 << (sknlgrpInet6UDPDestroy - 1):
+		return unix.AF_INET6, unix.IPPROTO_UDP
+	}
+	return 0, 0
+}
+
+// Close ends the subscription.
+func (l *DestroyListener) Close() error {
+	return unix.Close(l.fd)
+}
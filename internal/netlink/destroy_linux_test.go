@@ -0,0 +1,69 @@
+//go:build linux
+
+package netlink
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestDestroyGroupSocket(t *testing.T) {
+	tests := []struct {
+		groups           uint32
+		family, protocol uint8
+	}{
+		{1 << 0, unix.AF_INET, unix.IPPROTO_TCP},
+		{1 << 1, unix.AF_INET, unix.IPPROTO_UDP},
+		{1 << 2, unix.AF_INET6, unix.IPPROTO_TCP},
+		{1 << 3, unix.AF_INET6, unix.IPPROTO_UDP},
+		{0, 0, 0},
+	}
+	for _, tt := range tests {
+		family, protocol := destroyGroupSocket(tt.groups)
+		if family != tt.family || protocol != tt.protocol {
+			t.Errorf("destroyGroupSocket(%#x) = %d, %d, want %d, %d", tt.groups, family, protocol, tt.family, tt.protocol)
+		}
+	}
+}
+
+func TestListenDestroyed_SeesClosedConnection(t *testing.T) {
+	l, err := ListenDestroyed()
+	if err != nil {
+		t.Skipf("destroy notifications unavailable: %v", err)
+	}
+	defer l.Close()
+
+	ln, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		if c, err := ln.Accept(); err == nil {
+			c.Close()
+		}
+	}()
+	c, err := net.Dial("tcp4", ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	local := c.LocalAddr().(*net.TCPAddr).AddrPort()
+	c.Close()
+
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		sockets, err := l.Read()
+		if err != nil {
+			t.Fatalf("Read: %v", err)
+		}
+		for _, s := range sockets {
+			if s.Protocol == unix.IPPROTO_TCP && s.ID.Local.Port() == local.Port() {
+				return
+			}
+		}
+	}
+	t.Skip("no destroy notification for the closed connection (kernel without sock_diag destroy events)")
+}
@@ -0,0 +1,278 @@
+//go:build linux
+
+package netlink
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/netip"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// inetDiagNoCookie tells the kernel to match sockets by tuple only.
+const inetDiagNoCookie = ^uint32(0)
+
+// sizeofInetDiagReqV2 is sizeof(struct inet_diag_req_v2).
+const sizeofInetDiagReqV2 = 56
+
+// sizeofInetDiagMsg is sizeof(struct inet_diag_msg).
+const sizeofInetDiagMsg = 72
+
+// sockDiagByFamily is SOCK_DIAG_BY_FAMILY from linux/sock_diag.h.
+const sockDiagByFamily = 20
+
+// inetDiagInfo is the INET_DIAG_INFO attribute type (struct tcp_info for TCP).
+const inetDiagInfo = 2
+
+// tcpListen is TCP_LISTEN from include/net/tcp_states.h.
+const tcpListen = 10
+
+// Offsets into struct tcp_info (linux/tcp.h). Only fields present since 2.6 are read.
+const (
+	tcpInfoRetransmits  = 2   // __u8 tcpi_retransmits
+	tcpInfoRTT          = 68  // __u32 tcpi_rtt (usec)
+	tcpInfoRTTVar       = 72  // __u32 tcpi_rttvar (usec)
+	tcpInfoTotalRetrans = 100 // __u32 tcpi_total_retrans
+	sizeofTCPInfoMin    = 104
+)
+
+// inetDiagReq mirrors struct inet_diag_req_v2 from linux/inet_diag.h.
+type inetDiagReq struct {
+	family   uint8
+	protocol uint8
+	ext      uint8
+	states   uint32
+	id       SocketID
+}
+
+// marshal encodes the request in kernel layout. Ports and addresses are
+// big-endian; everything else uses host byte order.
+func (r inetDiagReq) marshal() []byte {
+	b := make([]byte, sizeofInetDiagReqV2)
+	b[0] = r.family
+	b[1] = r.protocol
+	b[2] = r.ext
+	binary.NativeEndian.PutUint32(b[4:8], r.states)
+	binary.BigEndian.PutUint16(b[8:10], r.id.Local.Port())
+	binary.BigEndian.PutUint16(b[10:12], r.id.Remote.Port())
+	putAddr(b[12:28], r.id.Local.Addr())
+	putAddr(b[28:44], r.id.Remote.Addr())
+	// b[44:48] interface index left as 0 (any)
+	binary.NativeEndian.PutUint32(b[48:52], inetDiagNoCookie)
+	binary.NativeEndian.PutUint32(b[52:56], inetDiagNoCookie)
+	return b
+}
+
+// putAddr writes an address into a 16-byte inet_diag address slot.
+func putAddr(dst []byte, addr netip.Addr) {
+	if addr.Is4() {
+		a := addr.As4()
+		copy(dst, a[:])
+		return
+	}
+	a := addr.As16()
+	copy(dst, a[:])
+}
+
+// familyOf returns the socket family for an address.
+func familyOf(addr netip.Addr) uint8 {
+	if addr.Is4() {
+		return unix.AF_INET
+	}
+	return unix.AF_INET6
+}
+
+// execute sends a single sock_diag request and returns the payloads of all reply messages.
+// An NLMSG_ERROR reply with a non-zero code is returned as an error.
+func execute(msgType, flags uint16, payload []byte) ([][]byte, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, unix.NETLINK_SOCK_DIAG)
+	if err != nil {
+		return nil, fmt.Errorf("netlink socket: %w", err)
+	}
+	defer func() { _ = unix.Close(fd) }()
+
+	msg := make([]byte, unix.SizeofNlMsghdr+len(payload))
+	binary.NativeEndian.PutUint32(msg[0:4], uint32(len(msg)))
+	binary.NativeEndian.PutUint16(msg[4:6], msgType)
+	binary.NativeEndian.PutUint16(msg[6:8], flags)
+	binary.NativeEndian.PutUint32(msg[8:12], 1) // sequence number
+	copy(msg[unix.SizeofNlMsghdr:], payload)
+
+	if err := unix.Sendto(fd, msg, 0, &unix.SockaddrNetlink{Family: unix.AF_NETLINK}); err != nil {
+		return nil, fmt.Errorf("netlink send: %w", err)
+	}
+
+	var replies [][]byte
+	buf := make([]byte, 64*1024)
+	for {
+		n, _, err := unix.Recvfrom(fd, buf, 0)
+		if err != nil {
+			return nil, fmt.Errorf("netlink recv: %w", err)
+		}
+		msgs, err := syscall.ParseNetlinkMessage(buf[:n])
+		if err != nil {
+			return nil, fmt.Errorf("netlink parse: %w", err)
+		}
+		for _, m := range msgs {
+			switch m.Header.Type {
+			case unix.NLMSG_DONE:
+				return replies, nil
+			case unix.NLMSG_ERROR:
+				if len(m.Data) < 4 {
+					return nil, fmt.Errorf("netlink: short error message")
+				}
+				if code := int32(binary.NativeEndian.Uint32(m.Data[0:4])); code != 0 {
+					return nil, unix.Errno(-code)
+				}
+				return replies, nil // ACK
+			default:
+				replies = append(replies, m.Data)
+			}
+		}
+		if flags&unix.NLM_F_DUMP == 0 && len(replies) > 0 {
+			return replies, nil
+		}
+	}
+}
+
+// DestroyTCP forcibly closes a TCP socket via SOCK_DESTROY (like `ss -K`).
+// The owning process sees ECONNABORTED; the peer receives a RST.
+// Requires CAP_NET_ADMIN and a kernel built with CONFIG_INET_DIAG_DESTROY.
+func DestroyTCP(id SocketID) error {
+	req := inetDiagReq{
+		family:   familyOf(id.Local.Addr()),
+		protocol: unix.IPPROTO_TCP,
+		states:   ^uint32(0),
+		id:       id,
+	}
+	_, err := execute(unix.SOCK_DESTROY, unix.NLM_F_REQUEST|unix.NLM_F_ACK, req.marshal())
+	if err != nil {
+		return fmt.Errorf("destroy %s -> %s: %w", id.Local, id.Remote, err)
+	}
+	return nil
+}
+
+// parseInetDiagMsg decodes a struct inet_diag_msg dump reply. The address
+// family is taken from the request rather than idiag_family, which some
+// sock_diag implementations leave zeroed.
+func parseInetDiagMsg(b []byte, family, protocol uint8) (Socket, error) {
+	if len(b) < sizeofInetDiagMsg {
+		return Socket{}, fmt.Errorf("inet_diag_msg: short message (%d bytes)", len(b))
+	}
+	addr := func(slot []byte) netip.Addr {
+		if family == unix.AF_INET {
+			return netip.AddrFrom4([4]byte(slot[:4]))
+		}
+		return netip.AddrFrom16([16]byte(slot[:16])).Unmap()
+	}
+	return Socket{
+		ID: SocketID{
+			Local:  netip.AddrPortFrom(addr(b[8:24]), binary.BigEndian.Uint16(b[4:6])),
+			Remote: netip.AddrPortFrom(addr(b[24:40]), binary.BigEndian.Uint16(b[6:8])),
+		},
+		Protocol: protocol,
+		State:    b[1],
+		RecvQ:    binary.NativeEndian.Uint32(b[56:60]),
+		SendQ:    binary.NativeEndian.Uint32(b[60:64]),
+		UID:      binary.NativeEndian.Uint32(b[64:68]),
+		Inode:    binary.NativeEndian.Uint32(b[68:72]),
+	}, nil
+}
+
+// parseTCPInfo extracts RTT and retransmit counters from the INET_DIAG_INFO
+// attribute following an inet_diag_msg. Returns nil if the attribute is absent
+// (e.g. TIME_WAIT sockets) or truncated.
+func parseTCPInfo(b []byte) *TCPInfo {
+	attrs := b[sizeofInetDiagMsg:]
+	for len(attrs) >= unix.SizeofRtAttr {
+		l := int(binary.NativeEndian.Uint16(attrs[0:2]))
+		typ := binary.NativeEndian.Uint16(attrs[2:4])
+		if l < unix.SizeofRtAttr || l > len(attrs) {
+			return nil
+		}
+		if typ == inetDiagInfo {
+			info := attrs[unix.SizeofRtAttr:l]
+			if len(info) < sizeofTCPInfoMin {
+				return nil
+			}
+			return &TCPInfo{
+				RTT:          time.Duration(binary.NativeEndian.Uint32(info[tcpInfoRTT:])) * time.Microsecond,
+				RTTVar:       time.Duration(binary.NativeEndian.Uint32(info[tcpInfoRTTVar:])) * time.Microsecond,
+				Retransmits:  info[tcpInfoRetransmits],
+				TotalRetrans: binary.NativeEndian.Uint32(info[tcpInfoTotalRetrans:]),
+			}
+		}
+		attrs = attrs[min((l+unix.RTA_ALIGNTO-1)&^(unix.RTA_ALIGNTO-1), len(attrs)):]
+	}
+	return nil
+}
+
+// dump lists the sockets of one family and protocol whose state is in the
+// states bitmask. ext is the idiag_ext bitmask of extra attributes to request.
+func dump(family, protocol, ext uint8, states uint32) ([]Socket, error) {
+	req := inetDiagReq{family: family, protocol: protocol, ext: ext, states: states}
+	replies, err := execute(sockDiagByFamily, unix.NLM_F_REQUEST|unix.NLM_F_DUMP, req.marshal())
+	if err != nil {
+		return nil, fmt.Errorf("dump family %d proto %d: %w", family, protocol, err)
+	}
+	sockets := make([]Socket, 0, len(replies))
+	for _, r := range replies {
+		sock, err := parseInetDiagMsg(r, family, protocol)
+		if err != nil {
+			return nil, err
+		}
+		if ext != 0 {
+			sock.TCP = parseTCPInfo(r)
+		}
+		sockets = append(sockets, sock)
+	}
+	return sockets, nil
+}
+
+// ListSockets dumps all TCP and UDP sockets (IPv4 and IPv6) via inet_diag.
+// This is far cheaper than walking /proc and needs no privileges, but carries
+// no process ownership.
+func ListSockets() ([]Socket, error) {
+	var sockets []Socket
+	for _, family := range []uint8{unix.AF_INET, unix.AF_INET6} {
+		for _, protocol := range []uint8{unix.IPPROTO_TCP, unix.IPPROTO_UDP} {
+			s, err := dump(family, protocol, 0, ^uint32(0))
+			if err != nil {
+				return nil, err
+			}
+			sockets = append(sockets, s...)
+		}
+	}
+	return sockets, nil
+}
+
+// ListTCPInfo dumps all TCP sockets with their kernel tcp_info (Socket.TCP).
+// Unprivileged callers see RTT and retransmits for every user's sockets.
+func ListTCPInfo() ([]Socket, error) {
+	var sockets []Socket
+	for _, family := range []uint8{unix.AF_INET, unix.AF_INET6} {
+		s, err := dump(family, unix.IPPROTO_TCP, 1<<(inetDiagInfo-1), ^uint32(0))
+		if err != nil {
+			return nil, err
+		}
+		sockets = append(sockets, s...)
+	}
+	return sockets, nil
+}
+
+// ListListeners dumps TCP LISTEN sockets (IPv4 and IPv6). RecvQ is the accept
+// queue depth and SendQ the backlog passed to listen(2), capped by somaxconn.
+func ListListeners() ([]Socket, error) {
+	var sockets []Socket
+	for _, family := range []uint8{unix.AF_INET, unix.AF_INET6} {
+		s, err := dump(family, unix.IPPROTO_TCP, 0, 1<<tcpListen)
+		if err != nil {
+			return nil, err
+		}
+		sockets = append(sockets, s...)
+	}
+	return sockets, nil
+}
@@ -0,0 +1,143 @@
+//go:build linux
+
+package netlink
+
+import (
+	"encoding/binary"
+	"net/netip"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestInetDiagReq_Marshal(t *testing.T) {
+	req := inetDiagReq{
+		family:   unix.AF_INET,
+		protocol: unix.IPPROTO_TCP,
+		states:   ^uint32(0),
+		id: SocketID{
+			Local:  netip.MustParseAddrPort("10.0.0.1:8080"),
+			Remote: netip.MustParseAddrPort("10.0.0.2:443"),
+		},
+	}
+	b := req.marshal()
+
+	if len(b) != sizeofInetDiagReqV2 {
+		t.Fatalf("len = %d, want %d", len(b), sizeofInetDiagReqV2)
+	}
+	if b[0] != unix.AF_INET || b[1] != unix.IPPROTO_TCP {
+		t.Errorf("family/protocol = %d/%d", b[0], b[1])
+	}
+	if got := binary.BigEndian.Uint16(b[8:10]); got != 8080 {
+		t.Errorf("sport = %d, want 8080", got)
+	}
+	if got := binary.BigEndian.Uint16(b[10:12]); got != 443 {
+		t.Errorf("dport = %d, want 443", got)
+	}
+	if b[12] != 10 || b[15] != 1 || b[16] != 0 {
+		t.Errorf("src = %v, want 10.0.0.1 padded with zeros", b[12:28])
+	}
+	if b[28] != 10 || b[31] != 2 {
+		t.Errorf("dst = %v, want 10.0.0.2", b[28:44])
+	}
+	if binary.NativeEndian.Uint32(b[48:52]) != inetDiagNoCookie {
+		t.Error("cookie should be INET_DIAG_NOCOOKIE")
+	}
+}
+
+func TestFamilyOf(t *testing.T) {
+	if familyOf(netip.MustParseAddr("1.2.3.4")) != unix.AF_INET {
+		t.Error("IPv4 address should map to AF_INET")
+	}
+	if familyOf(netip.MustParseAddr("::1")) != unix.AF_INET6 {
+		t.Error("IPv6 address should map to AF_INET6")
+	}
+}
+
+func TestParseInetDiagMsg(t *testing.T) {
+	b := make([]byte, sizeofInetDiagMsg)
+	b[0] = unix.AF_INET
+	b[1] = 10 // TCP_LISTEN
+	binary.BigEndian.PutUint16(b[4:6], 8080)
+	binary.BigEndian.PutUint16(b[6:8], 0)
+	copy(b[8:12], []byte{127, 0, 0, 1})
+	binary.NativeEndian.PutUint32(b[56:60], 3)
+	binary.NativeEndian.PutUint32(b[60:64], 128)
+	binary.NativeEndian.PutUint32(b[64:68], 1000)
+	binary.NativeEndian.PutUint32(b[68:72], 12345)
+
+	sock, err := parseInetDiagMsg(b, b[0], unix.IPPROTO_TCP)
+	if err != nil {
+		t.Fatalf("parseInetDiagMsg: %v", err)
+	}
+	if sock.ID.Local != netip.MustParseAddrPort("127.0.0.1:8080") {
+		t.Errorf("local = %v, want 127.0.0.1:8080", sock.ID.Local)
+	}
+	if sock.State != 10 || sock.Inode != 12345 || sock.UID != 1000 || sock.Protocol != unix.IPPROTO_TCP {
+		t.Errorf("sock = %+v", sock)
+	}
+	if sock.RecvQ != 3 || sock.SendQ != 128 {
+		t.Errorf("queues = %d/%d, want 3/128", sock.RecvQ, sock.SendQ)
+	}
+
+	if _, err := parseInetDiagMsg(b[:10], unix.AF_INET, unix.IPPROTO_TCP); err == nil {
+		t.Error("short message should fail")
+	}
+}
+
+func TestParseInetDiagMsg_IPv6(t *testing.T) {
+	b := make([]byte, sizeofInetDiagMsg)
+	b[0] = unix.AF_INET6
+	b[1] = 1 // TCP_ESTABLISHED
+	binary.BigEndian.PutUint16(b[4:6], 50000)
+	binary.BigEndian.PutUint16(b[6:8], 443)
+	b[23] = 1 // ::1
+	v4mapped := netip.MustParseAddr("::ffff:10.0.0.2").As16()
+	copy(b[24:40], v4mapped[:])
+
+	sock, err := parseInetDiagMsg(b, b[0], unix.IPPROTO_TCP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sock.ID.Local.String() != "[::1]:50000" {
+		t.Errorf("local = %v", sock.ID.Local)
+	}
+	if sock.ID.Remote.String() != "10.0.0.2:443" {
+		t.Errorf("remote = %v, want v4-mapped address unmapped", sock.ID.Remote)
+	}
+}
+
+func TestParseTCPInfo(t *testing.T) {
+	info := make([]byte, sizeofTCPInfoMin)
+	info[tcpInfoRetransmits] = 2
+	binary.NativeEndian.PutUint32(info[tcpInfoRTT:], 12500)
+	binary.NativeEndian.PutUint32(info[tcpInfoRTTVar:], 800)
+	binary.NativeEndian.PutUint32(info[tcpInfoTotalRetrans:], 7)
+
+	// An unrelated attribute first (INET_DIAG_MEMINFO, 16 bytes), then INET_DIAG_INFO.
+	msg := make([]byte, sizeofInetDiagMsg)
+	other := make([]byte, unix.SizeofRtAttr+16)
+	binary.NativeEndian.PutUint16(other[0:2], uint16(len(other)))
+	binary.NativeEndian.PutUint16(other[2:4], 1)
+	attr := make([]byte, unix.SizeofRtAttr)
+	binary.NativeEndian.PutUint16(attr[0:2], uint16(unix.SizeofRtAttr+len(info)))
+	binary.NativeEndian.PutUint16(attr[2:4], inetDiagInfo)
+	b := append(append(append(msg, other...), attr...), info...)
+
+	got := parseTCPInfo(b)
+	if got == nil {
+		t.Fatal("parseTCPInfo returned nil")
+	}
+	want := TCPInfo{RTT: 12500 * time.Microsecond, RTTVar: 800 * time.Microsecond, Retransmits: 2, TotalRetrans: 7}
+	if *got != want {
+		t.Errorf("parseTCPInfo = %+v, want %+v", *got, want)
+	}
+
+	if parseTCPInfo(msg) != nil {
+		t.Error("message without attributes should have no tcp_info")
+	}
+	if parseTCPInfo(b[:len(b)-10]) != nil {
+		t.Error("truncated attribute should be rejected")
+	}
+}
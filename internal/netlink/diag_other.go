@@ -0,0 +1,38 @@
+//go:build !linux
+
+package netlink
+
+// DestroyTCP is only supported on Linux.
+func DestroyTCP(id SocketID) error {
+	return ErrUnsupported
+}
+
+// ListSockets is only supported on Linux.
+func ListSockets() ([]Socket, error) {
+	return nil, ErrUnsupported
+}
+
+// ListTCPInfo is only supported on Linux.
+func ListTCPInfo() ([]Socket, error) {
+	return nil, ErrUnsupported
+}
+
+// ListListeners is only supported on Linux.
+func ListListeners() ([]Socket, error) {
+	return nil, ErrUnsupported
+}
+
+// ListenDestroyed is only supported on Linux.
+func ListenDestroyed() (*DestroyListener, error) {
+	return nil, ErrUnsupported
+}
+
+// Read is only supported on Linux.
+func (l *DestroyListener) Read() ([]Socket, error) {
+	return nil, ErrUnsupported
+}
+
+// Close is only supported on Linux.
+func (l *DestroyListener) Close() error {
+	return ErrUnsupported
+}
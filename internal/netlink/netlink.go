@@ -0,0 +1,82 @@
+// Package netlink talks to the Linux sock_diag netlink interface to inspect and
+// manipulate individual sockets. On other platforms operations return ErrUnsupported.
+package netlink
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnsupported is returned when sock_diag is not available on this platform.
+var ErrUnsupported = errors.New("socket diagnostics not supported on this platform")
+
+// SocketID identifies a socket by its local and remote endpoints.
+type SocketID struct {
+	Local  netip.AddrPort
+	Remote netip.AddrPort
+}
+
+// Socket is one entry from a sock_diag dump.
+type Socket struct {
+	ID       SocketID
+	Protocol uint8    // IPPROTO_TCP or IPPROTO_UDP
+	State    uint8    // kernel TCP state (TCP_ESTABLISHED=1 … TCP_LISTEN=10); 7 (CLOSE) for unconnected UDP
+	Inode    uint32   // socket inode, 0 for TIME_WAIT
+	UID      uint32   // owning user
+	RecvQ    uint32   // bytes not yet read; for LISTEN, connections waiting in the accept queue
+	SendQ    uint32   // bytes not yet acknowledged; for LISTEN, the configured backlog
+	TCP      *TCPInfo // kernel TCP stats (ListTCPInfo only; nil when unavailable)
+}
+
+// TCPInfo is the subset of the kernel's struct tcp_info netmon displays.
+type TCPInfo struct {
+	RTT          time.Duration // smoothed round-trip time
+	RTTVar       time.Duration // RTT variance
+	Retransmits  uint8         // retransmits of the currently unacknowledged segment
+	TotalRetrans uint32        // retransmitted segments over the connection's lifetime
+}
+
+// ParseSocketID builds a SocketID from collector address strings like "127.0.0.1:8080" or "::1:443".
+func ParseSocketID(local, remote string) (SocketID, error) {
+	l, err := parseAddrPort(local)
+	if err != nil {
+		return SocketID{}, fmt.Errorf("local address: %w", err)
+	}
+	r, err := parseAddrPort(remote)
+	if err != nil {
+		return SocketID{}, fmt.Errorf("remote address: %w", err)
+	}
+	if l.Addr().Is4() != r.Addr().Is4() {
+		return SocketID{}, fmt.Errorf("address family mismatch: %s / %s", local, remote)
+	}
+	return SocketID{Local: l, Remote: r}, nil
+}
+
+// parseAddrPort parses "ip:port" where ip may be an unbracketed IPv6 address.
+func parseAddrPort(addr string) (netip.AddrPort, error) {
+	idx := strings.LastIndex(addr, ":")
+	if idx < 0 {
+		return netip.AddrPort{}, fmt.Errorf("missing port in %q", addr)
+	}
+	ip, err := netip.ParseAddr(strings.Trim(addr[:idx], "[]"))
+	if err != nil {
+		return netip.AddrPort{}, err
+	}
+	port, err := strconv.ParseUint(addr[idx+1:], 10, 16)
+	if err != nil {
+		return netip.AddrPort{}, fmt.Errorf("invalid port in %q", addr)
+	}
+	return netip.AddrPortFrom(ip.Unmap(), uint16(port)), nil
+}
+
+// DestroyListener receives the kernel's notifications of destroyed TCP and
+// UDP sockets (what `ss --events` shows). Create one with ListenDestroyed.
+// It is read from one goroutine.
+type DestroyListener struct {
+	fd  int
+	buf []byte // receive buffer, reused by every Read
+}
@@ -0,0 +1,56 @@
+package netlink
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestParseSocketID_IPv4(t *testing.T) {
+	id, err := ParseSocketID("192.168.1.10:54321", "142.250.80.46:443")
+	if err != nil {
+		t.Fatalf("ParseSocketID() error = %v", err)
+	}
+	if id.Local != netip.MustParseAddrPort("192.168.1.10:54321") {
+		t.Errorf("Local = %v", id.Local)
+	}
+	if id.Remote != netip.MustParseAddrPort("142.250.80.46:443") {
+		t.Errorf("Remote = %v", id.Remote)
+	}
+}
+
+func TestParseSocketID_IPv6Unbracketed(t *testing.T) {
+	id, err := ParseSocketID("::1:8080", "fe80::1:443")
+	if err != nil {
+		t.Fatalf("ParseSocketID() error = %v", err)
+	}
+	if id.Local.Addr() != netip.MustParseAddr("::1") || id.Local.Port() != 8080 {
+		t.Errorf("Local = %v", id.Local)
+	}
+	if id.Remote.Port() != 443 {
+		t.Errorf("Remote port = %d, want 443", id.Remote.Port())
+	}
+}
+
+func TestParseSocketID_MappedIPv4IsUnmapped(t *testing.T) {
+	id, err := ParseSocketID("::ffff:10.0.0.1:80", "10.0.0.2:5000")
+	if err != nil {
+		t.Fatalf("ParseSocketID() error = %v", err)
+	}
+	if !id.Local.Addr().Is4() {
+		t.Errorf("Local = %v, want IPv4", id.Local)
+	}
+}
+
+func TestParseSocketID_Errors(t *testing.T) {
+	tests := []struct{ local, remote string }{
+		{"*", "1.2.3.4:80"},
+		{"1.2.3.4:80", "*"},
+		{"1.2.3.4:http", "5.6.7.8:80"},
+		{"1.2.3.4:80", "::1:80"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseSocketID(tt.local, tt.remote); err == nil {
+			t.Errorf("ParseSocketID(%q, %q) expected error", tt.local, tt.remote)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// renderJSONL writes one JSON object per flat connection row, for streaming
+// into log pipelines.
+func renderJSONL(w io.Writer, in Input) error {
+	opts := in.pageOf()
+	rows, _ := pageRows(in.Snapshot, opts)
+	fields := selectFields(opts.Fields)
+	enc := json.NewEncoder(w)
+	for _, r := range rows {
+		if err := enc.Encode(r.values(fields)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderCSV writes the flat connection rows as CSV with a header line of
+// field names.
+func renderCSV(w io.Writer, in Input) error {
+	opts := in.pageOf()
+	rows, _ := pageRows(in.Snapshot, opts)
+	fields := selectFields(opts.Fields)
+	cw := csv.NewWriter(w)
+	record := make([]string, len(fields))
+	for i, f := range fields {
+		record[i] = f.name
+	}
+	if err := cw.Write(record); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		for i, f := range fields {
+			record[i] = fmt.Sprint(f.value(r.process, r.conn))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// renderTable writes the flat connection rows as aligned columns.
+func renderTable(w io.Writer, in Input) error {
+	opts := in.pageOf()
+	rows, _ := pageRows(in.Snapshot, opts)
+	fields := selectFields(opts.Fields)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	cells := make([]string, len(fields))
+	for i, f := range fields {
+		cells[i] = strings.ToUpper(f.name)
+	}
+	fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	for _, r := range rows {
+		for i, f := range fields {
+			cells[i] = fmt.Sprint(f.value(r.process, r.conn))
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
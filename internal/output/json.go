@@ -3,18 +3,31 @@ package output
 import (
 	"encoding/json"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/kostyay/netmon/internal/model"
+	"github.com/kostyay/netmon/internal/services"
 )
 
 // JSONConnection represents a connection in JSON output.
 type JSONConnection struct {
-	PID        int32  `json:"pid"`
-	Protocol   string `json:"protocol"`
-	LocalAddr  string `json:"local_addr"`
-	RemoteAddr string `json:"remote_addr"`
-	State      string `json:"state"`
+	PID        int32            `json:"pid"`
+	Protocol   string           `json:"protocol"`
+	LocalAddr  string           `json:"local_addr"`
+	RemoteAddr string           `json:"remote_addr"`
+	State      string           `json:"state"`
+	FD         uint32           `json:"fd,omitempty"`           // socket file descriptor in the owning process
+	Cast       string           `json:"cast,omitempty"`         // "multicast" or "broadcast" for group UDP sockets
+	Service    string           `json:"service,omitempty"`      // service name or user port label of the remote, else local, port
+	Accept     *JSONAcceptQueue `json:"accept_queue,omitempty"` // LISTEN sockets on Linux
+}
+
+// JSONAcceptQueue is the accept queue of a listening TCP socket.
+type JSONAcceptQueue struct {
+	Queued    uint32 `json:"queued"`
+	Backlog   uint32 `json:"backlog"`
+	Saturated bool   `json:"saturated"`
 }
 
 // JSONApplication represents an application in JSON output.
@@ -34,21 +47,56 @@ type JSONOutput struct {
 	Timestamp    time.Time         `json:"timestamp"`
 	Applications []JSONApplication `json:"applications"`
 	SkippedCount int               `json:"skipped_count"`
+	HiddenCount  int               `json:"hidden_count"`
+	Sample       *JSONSample       `json:"sample,omitempty"` // set when connections are a sample
+}
+
+// JSONSample marks a sampled snapshot: connections lists only a sample, while
+// the counts here and in each application are exact.
+type JSONSample struct {
+	Total   int            `json:"total"`
+	ByState map[string]int `json:"by_state"`
+}
+
+// ServiceName returns the service name of conn's remote port, else its local
+// port (listeners, incoming connections): a user port label if configured,
+// else the well-known name, else "".
+func ServiceName(conn model.Connection) string {
+	proto := strings.ToLower(string(conn.Protocol))
+	if name := services.Lookup(model.ExtractPort(conn.RemoteAddr), proto); name != "" {
+		return name
+	}
+	return services.Lookup(model.ExtractPort(conn.LocalAddr), proto)
 }
 
 // RenderJSON writes the network snapshot as JSON to the writer.
 func RenderJSON(w io.Writer, snapshot *model.NetworkSnapshot, ioStats map[int32]*model.NetIOStats) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(BuildJSON(snapshot, ioStats))
+}
+
+// BuildJSON converts the snapshot to its JSON output structure, summing the
+// I/O stats of each application's PIDs.
+func BuildJSON(snapshot *model.NetworkSnapshot, ioStats map[int32]*model.NetIOStats) JSONOutput {
 	output := JSONOutput{
 		Timestamp:    snapshot.Timestamp,
 		Applications: make([]JSONApplication, 0, len(snapshot.Applications)),
 		SkippedCount: snapshot.SkippedCount,
+		HiddenCount:  snapshot.HiddenCount,
+	}
+	if s := snapshot.Sample; s != nil {
+		output.Sample = &JSONSample{Total: s.Total, ByState: make(map[string]int, len(s.ByState))}
+		for state, n := range s.ByState {
+			output.Sample.ByState[string(state)] = n
+		}
 	}
 
 	for _, app := range snapshot.Applications {
 		jApp := JSONApplication{
 			Name:             app.Name,
 			PIDs:             app.PIDs,
-			ConnectionCount:  len(app.Connections),
+			ConnectionCount:  app.ConnectionCount(),
 			EstablishedCount: app.EstablishedCount,
 			ListenCount:      app.ListenCount,
 			Connections:      make([]JSONConnection, 0, len(app.Connections)),
@@ -65,19 +113,23 @@ func RenderJSON(w io.Writer, snapshot *model.NetworkSnapshot, ioStats map[int32]
 		}
 
 		for _, conn := range app.Connections {
-			jApp.Connections = append(jApp.Connections, JSONConnection{
+			jConn := JSONConnection{
 				PID:        conn.PID,
 				Protocol:   string(conn.Protocol),
 				LocalAddr:  conn.LocalAddr,
 				RemoteAddr: conn.RemoteAddr,
 				State:      string(conn.State),
-			})
+				FD:         conn.FD,
+				Cast:       string(conn.Cast()),
+				Service:    ServiceName(conn),
+			}
+			if q := conn.Accept; q != nil {
+				jConn.Accept = &JSONAcceptQueue{Queued: q.Queued, Backlog: q.Backlog, Saturated: q.Saturated()}
+			}
+			jApp.Connections = append(jApp.Connections, jConn)
 		}
 
 		output.Applications = append(output.Applications, jApp)
 	}
-
-	enc := json.NewEncoder(w)
-	enc.SetIndent("", "  ")
-	return enc.Encode(output)
+	return output
 }
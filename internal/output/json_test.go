@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/kostyay/netmon/internal/model"
+	"github.com/kostyay/netmon/internal/services"
 )
 
 func TestRenderJSON(t *testing.T) {
@@ -143,3 +144,143 @@ func TestRenderJSON_NilIOStats(t *testing.T) {
 		t.Errorf("Expected 0 BytesSent, got %d", output.Applications[0].BytesSent)
 	}
 }
+
+func TestRenderJSON_MarksMulticast(t *testing.T) {
+	snapshot := &model.NetworkSnapshot{
+		Timestamp: time.Now(),
+		Applications: []model.Application{{
+			Name: "avahi-daemon",
+			PIDs: []int32{300},
+			Connections: []model.Connection{
+				{PID: 300, Protocol: model.ProtocolUDP, LocalAddr: "0.0.0.0:5353", RemoteAddr: "*", State: model.StateNone},
+				{PID: 300, Protocol: model.ProtocolUDP, LocalAddr: "10.0.0.2:40000", RemoteAddr: "8.8.8.8:53", State: model.StateNone},
+			},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := RenderJSON(&buf, snapshot, nil); err != nil {
+		t.Fatalf("RenderJSON failed: %v", err)
+	}
+	var output JSONOutput
+	if err := json.Unmarshal(buf.Bytes(), &output); err != nil {
+		t.Fatalf("Failed to unmarshal output: %v", err)
+	}
+
+	conns := output.Applications[0].Connections
+	if conns[0].Cast != "multicast" {
+		t.Errorf("mDNS socket cast = %q, want multicast", conns[0].Cast)
+	}
+	if conns[1].Cast != "" || bytes.Contains(buf.Bytes(), []byte(`"cast": ""`)) {
+		t.Errorf("unicast socket should omit cast, got %q", conns[1].Cast)
+	}
+}
+
+func TestRenderJSON_AcceptQueue(t *testing.T) {
+	snapshot := &model.NetworkSnapshot{
+		Timestamp: time.Now(),
+		Applications: []model.Application{{
+			Name: "nginx",
+			PIDs: []int32{400},
+			Connections: []model.Connection{
+				{PID: 400, Protocol: model.ProtocolTCP, LocalAddr: "0.0.0.0:80", RemoteAddr: "*", State: model.StateListen,
+					Accept: &model.AcceptQueue{Queued: 120, Backlog: 128}},
+				{PID: 400, Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.2:80", RemoteAddr: "1.2.3.4:5000", State: model.StateEstablished},
+			},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := RenderJSON(&buf, snapshot, nil); err != nil {
+		t.Fatalf("RenderJSON failed: %v", err)
+	}
+	var output JSONOutput
+	if err := json.Unmarshal(buf.Bytes(), &output); err != nil {
+		t.Fatalf("Failed to unmarshal output: %v", err)
+	}
+
+	conns := output.Applications[0].Connections
+	if q := conns[0].Accept; q == nil || q.Queued != 120 || q.Backlog != 128 || !q.Saturated {
+		t.Errorf("listener accept queue = %+v, want 120/128 saturated", q)
+	}
+	if conns[1].Accept != nil {
+		t.Errorf("established connection should omit accept_queue, got %+v", conns[1].Accept)
+	}
+}
+
+func TestRenderJSON_FD(t *testing.T) {
+	snapshot := &model.NetworkSnapshot{
+		Timestamp: time.Now(),
+		Applications: []model.Application{{
+			Name: "nginx",
+			PIDs: []int32{400},
+			Connections: []model.Connection{
+				{PID: 400, Protocol: model.ProtocolTCP, LocalAddr: "0.0.0.0:80", RemoteAddr: "*", State: model.StateListen, FD: 6},
+				{PID: 400, Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.2:80", RemoteAddr: "1.2.3.4:5000", State: model.StateEstablished},
+			},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := RenderJSON(&buf, snapshot, nil); err != nil {
+		t.Fatalf("RenderJSON failed: %v", err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte(`"fd": 6`)) {
+		t.Errorf("output should include the listener's fd:\n%s", buf.String())
+	}
+	if bytes.Count(buf.Bytes(), []byte(`"fd"`)) != 1 {
+		t.Error("connection with unknown fd should omit it")
+	}
+}
+
+func TestRenderJSON_Sample(t *testing.T) {
+	snapshot := &model.NetworkSnapshot{
+		Applications: []model.Application{{
+			Name:         "haproxy",
+			SampledTotal: 90000,
+			Connections: []model.Connection{
+				{PID: 1, Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.2:443", RemoteAddr: "1.2.3.4:5000", State: model.StateEstablished},
+			},
+		}},
+		Sample: &model.Sample{Total: 90000, ByState: map[model.ConnectionState]int{model.StateEstablished: 90000}},
+	}
+
+	out := BuildJSON(snapshot, nil)
+	if out.Applications[0].ConnectionCount != 90000 || len(out.Applications[0].Connections) != 1 {
+		t.Errorf("application = %+v, want the exact count with the sampled connection", out.Applications[0])
+	}
+	if out.Sample == nil || out.Sample.Total != 90000 || out.Sample.ByState["ESTABLISHED"] != 90000 {
+		t.Errorf("sample = %+v", out.Sample)
+	}
+
+	snapshot.Sample = nil
+	var buf bytes.Buffer
+	if err := RenderJSON(&buf, snapshot, nil); err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(buf.Bytes(), []byte(`"sample"`)) {
+		t.Error("unsampled snapshot should omit sample")
+	}
+}
+
+func TestServiceName_UsesPortLabels(t *testing.T) {
+	if err := services.SetCustom(map[string]string{"15432": "pgbouncer-staging"}); err != nil {
+		t.Fatalf("SetCustom: %v", err)
+	}
+	t.Cleanup(func() { _ = services.SetCustom(nil) })
+
+	tests := []struct {
+		conn model.Connection
+		want string
+	}{
+		{model.Connection{Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.2:50000", RemoteAddr: "10.0.0.9:15432"}, "pgbouncer-staging"},
+		{model.Connection{Protocol: model.ProtocolTCP, LocalAddr: "0.0.0.0:15432", RemoteAddr: "*"}, "pgbouncer-staging"},
+		{model.Connection{Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.2:22", RemoteAddr: "10.0.0.9:50000"}, "ssh"},
+		{model.Connection{Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.2:50000", RemoteAddr: "10.0.0.9:50001"}, ""},
+	}
+	for _, tt := range tests {
+		if got := ServiceName(tt.conn); got != tt.want {
+			t.Errorf("ServiceName(%s → %s) = %q, want %q", tt.conn.LocalAddr, tt.conn.RemoteAddr, got, tt.want)
+		}
+	}
+}
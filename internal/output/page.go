@@ -0,0 +1,174 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// connField is one selectable field of a flat connection row.
+type connField struct {
+	name  string
+	value func(process string, c model.Connection) any
+}
+
+// connFields lists the fields of a flat connection row in output order.
+var connFields = []connField{
+	{"process", func(p string, _ model.Connection) any { return p }},
+	{"pid", func(_ string, c model.Connection) any { return c.PID }},
+	{"protocol", func(_ string, c model.Connection) any { return string(c.Protocol) }},
+	{"laddr", func(_ string, c model.Connection) any { return c.LocalAddr }},
+	{"raddr", func(_ string, c model.Connection) any { return c.RemoteAddr }},
+	{"state", func(_ string, c model.Connection) any { return string(c.State) }},
+	{"fd", func(_ string, c model.Connection) any { return c.FD }},
+	{"cast", func(_ string, c model.Connection) any { return string(c.Cast()) }},
+	{"service", func(_ string, c model.Connection) any { return ServiceName(c) }},
+}
+
+// FieldNames returns the names accepted by ParseFields, in output order.
+func FieldNames() []string {
+	names := make([]string, len(connFields))
+	for i, f := range connFields {
+		names[i] = f.name
+	}
+	return names
+}
+
+// PageOptions selects a page of the flat connection list and its fields.
+type PageOptions struct {
+	Fields []string // field names; nil selects all
+	Limit  int      // max rows; 0 means no limit
+	Offset int      // rows to skip
+}
+
+// ParseFields parses a comma-separated field list such as "process,laddr,state".
+// An empty string selects all fields.
+func ParseFields(s string) ([]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	valid := make(map[string]bool, len(connFields))
+	for _, f := range connFields {
+		valid[f.name] = true
+	}
+	var fields []string
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !valid[name] {
+			return nil, fmt.Errorf("unknown field %q (valid: %s)", name, strings.Join(FieldNames(), ","))
+		}
+		fields = append(fields, name)
+	}
+	return fields, nil
+}
+
+// JSONPage is one page of the flat connection list. Total counts all rows
+// before paging, so a client knows when to stop.
+type JSONPage struct {
+	Timestamp   time.Time        `json:"timestamp"`
+	Total       int              `json:"total"`
+	Offset      int              `json:"offset"`
+	Limit       int              `json:"limit,omitempty"`
+	Connections []map[string]any `json:"connections"`
+}
+
+// pageRow is a connection with its owning application's name.
+type pageRow struct {
+	process string
+	conn    model.Connection
+}
+
+// Page flattens the snapshot into connection rows, orders them by process,
+// PID and addresses so consecutive polls page through a stable order, and
+// returns the requested slice with only the selected fields.
+func Page(snapshot *model.NetworkSnapshot, opts PageOptions) JSONPage {
+	rows, total := pageRows(snapshot, opts)
+	page := JSONPage{
+		Timestamp:   snapshot.Timestamp,
+		Total:       total,
+		Offset:      opts.Offset,
+		Limit:       opts.Limit,
+		Connections: []map[string]any{},
+	}
+	fields := selectFields(opts.Fields)
+	for _, r := range rows {
+		page.Connections = append(page.Connections, r.values(fields))
+	}
+	return page
+}
+
+// pageRows returns the requested slice of the ordered flat connection rows,
+// and how many rows there are before paging.
+func pageRows(snapshot *model.NetworkSnapshot, opts PageOptions) ([]pageRow, int) {
+	var rows []pageRow
+	for _, app := range snapshot.Applications {
+		for _, conn := range app.Connections {
+			rows = append(rows, pageRow{process: app.Name, conn: conn})
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if a.process != b.process {
+			return a.process < b.process
+		}
+		if a.conn.PID != b.conn.PID {
+			return a.conn.PID < b.conn.PID
+		}
+		if a.conn.LocalAddr != b.conn.LocalAddr {
+			return a.conn.LocalAddr < b.conn.LocalAddr
+		}
+		if a.conn.RemoteAddr != b.conn.RemoteAddr {
+			return a.conn.RemoteAddr < b.conn.RemoteAddr
+		}
+		return a.conn.Protocol < b.conn.Protocol
+	})
+
+	start := min(max(opts.Offset, 0), len(rows))
+	end := len(rows)
+	// Compared without adding, as start+Limit overflows for huge limits
+	if opts.Limit > 0 && opts.Limit < len(rows)-start {
+		end = start + opts.Limit
+	}
+	return rows[start:end], len(rows)
+}
+
+// values returns r's selected fields by name.
+func (r pageRow) values(fields []connField) map[string]any {
+	row := make(map[string]any, len(fields))
+	for _, f := range fields {
+		row[f.name] = f.value(r.process, r.conn)
+	}
+	return row
+}
+
+// selectFields returns the fields named in names, or all fields if names is empty.
+// Unknown names are ignored; ParseFields rejects them earlier.
+func selectFields(names []string) []connField {
+	if len(names) == 0 {
+		return connFields
+	}
+	var fields []connField
+	for _, name := range names {
+		for _, f := range connFields {
+			if f.name == name {
+				fields = append(fields, f)
+			}
+		}
+	}
+	return fields
+}
+
+// RenderPage writes one page of the flat connection list as JSON to the writer.
+func RenderPage(w io.Writer, snapshot *model.NetworkSnapshot, opts PageOptions) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(Page(snapshot, opts))
+}
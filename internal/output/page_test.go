@@ -0,0 +1,148 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+func pageSnapshot() *model.NetworkSnapshot {
+	return &model.NetworkSnapshot{
+		Timestamp: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+		Applications: []model.Application{
+			{Name: "zsh", PIDs: []int32{30}, Connections: []model.Connection{
+				{PID: 30, Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.1:50000", RemoteAddr: "1.1.1.1:22", State: model.StateEstablished},
+			}},
+			{Name: "nginx", PIDs: []int32{10}, Connections: []model.Connection{
+				{PID: 10, Protocol: model.ProtocolTCP, LocalAddr: "0.0.0.0:80", RemoteAddr: "*", State: model.StateListen, FD: 6},
+				{PID: 10, Protocol: model.ProtocolTCP, LocalAddr: "0.0.0.0:443", RemoteAddr: "*", State: model.StateListen, FD: 7},
+			}},
+		},
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	fields, err := ParseFields(" process, LADDR ,state,")
+	if err != nil {
+		t.Fatalf("ParseFields: %v", err)
+	}
+	if strings.Join(fields, ",") != "process,laddr,state" {
+		t.Errorf("fields = %v, want [process laddr state]", fields)
+	}
+
+	if fields, err := ParseFields(""); err != nil || fields != nil {
+		t.Errorf("ParseFields(\"\") = %v, %v; want nil (all fields)", fields, err)
+	}
+
+	_, err = ParseFields("process,uid")
+	if err == nil || !strings.Contains(err.Error(), `"uid"`) || !strings.Contains(err.Error(), "laddr") {
+		t.Errorf("ParseFields(uid) err = %v, want unknown field with valid list", err)
+	}
+}
+
+func TestPage_StableOrderAndAllFields(t *testing.T) {
+	page := Page(pageSnapshot(), PageOptions{})
+	if page.Total != 3 || len(page.Connections) != 3 {
+		t.Fatalf("page = total %d, rows %d; want 3/3", page.Total, len(page.Connections))
+	}
+	var got []string
+	for _, row := range page.Connections {
+		got = append(got, row["laddr"].(string))
+	}
+	want := "0.0.0.0:443,0.0.0.0:80,10.0.0.1:50000" // nginx before zsh, then by address
+	if strings.Join(got, ",") != want {
+		t.Errorf("order = %v, want %s", got, want)
+	}
+	if n := len(page.Connections[0]); n != len(FieldNames()) {
+		t.Errorf("fields per row = %d, want all %d", n, len(FieldNames()))
+	}
+	if page.Connections[0]["fd"] != uint32(7) {
+		t.Errorf("fd = %v, want 7", page.Connections[0]["fd"])
+	}
+}
+
+func TestPage_ProtocolBreaksAddressTies(t *testing.T) {
+	// A DNS server listens on the same address over TCP and UDP; snapshot
+	// order varies, the page order must not
+	dns := func(protos ...model.Protocol) *model.NetworkSnapshot {
+		var conns []model.Connection
+		for _, p := range protos {
+			conns = append(conns, model.Connection{PID: 53, Protocol: p, LocalAddr: "0.0.0.0:53", RemoteAddr: "*"})
+		}
+		return &model.NetworkSnapshot{Applications: []model.Application{{Name: "named", PIDs: []int32{53}, Connections: conns}}}
+	}
+	for _, snap := range []*model.NetworkSnapshot{dns(model.ProtocolUDP, model.ProtocolTCP), dns(model.ProtocolTCP, model.ProtocolUDP)} {
+		page := Page(snap, PageOptions{Fields: []string{"protocol"}})
+		var got []string
+		for _, row := range page.Connections {
+			got = append(got, row["protocol"].(string))
+		}
+		if strings.Join(got, ",") != "TCP,UDP" {
+			t.Errorf("order = %v, want TCP then UDP", got)
+		}
+	}
+}
+
+func TestPage_LimitOffsetFields(t *testing.T) {
+	snap := pageSnapshot()
+	tests := []struct {
+		name   string
+		opts   PageOptions
+		wantN  int
+		wantFD bool
+	}{
+		{"first page", PageOptions{Limit: 2}, 2, true},
+		{"last partial page", PageOptions{Limit: 2, Offset: 2}, 1, true},
+		{"offset past end", PageOptions{Limit: 2, Offset: 10}, 0, true},
+		{"huge limit", PageOptions{Limit: math.MaxInt, Offset: 1}, 2, true},
+		{"fields only", PageOptions{Fields: []string{"process", "state"}}, 3, false},
+	}
+	for _, tt := range tests {
+		page := Page(snap, tt.opts)
+		if page.Total != 3 {
+			t.Errorf("%s: Total = %d, want 3 regardless of paging", tt.name, page.Total)
+		}
+		if len(page.Connections) != tt.wantN {
+			t.Errorf("%s: rows = %d, want %d", tt.name, len(page.Connections), tt.wantN)
+		}
+		for _, row := range page.Connections {
+			if _, ok := row["fd"]; ok != tt.wantFD {
+				t.Errorf("%s: row %v has fd = %v, want %v", tt.name, row, ok, tt.wantFD)
+			}
+		}
+	}
+}
+
+func TestRenderPage(t *testing.T) {
+	var buf bytes.Buffer
+	opts := PageOptions{Fields: []string{"process", "laddr", "state"}, Limit: 1, Offset: 1}
+	if err := RenderPage(&buf, pageSnapshot(), opts); err != nil {
+		t.Fatalf("RenderPage: %v", err)
+	}
+	var got struct {
+		Total       int                 `json:"total"`
+		Offset      int                 `json:"offset"`
+		Limit       int                 `json:"limit"`
+		Connections []map[string]string `json:"connections"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if got.Total != 3 || got.Offset != 1 || got.Limit != 1 {
+		t.Errorf("page = %+v, want total 3 offset 1 limit 1", got)
+	}
+	want := map[string]string{"process": "nginx", "laddr": "0.0.0.0:80", "state": "LISTEN"}
+	if len(got.Connections) != 1 || len(got.Connections[0]) != 3 {
+		t.Fatalf("connections = %v, want one row with 3 fields", got.Connections)
+	}
+	for k, v := range want {
+		if got.Connections[0][k] != v {
+			t.Errorf("%s = %q, want %q", k, got.Connections[0][k], v)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kostyay/netmon/internal/metrics"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// promSample is one series of a metric: label values in the metric's label
+// order, and its value.
+type promSample struct {
+	labels []string
+	value  float64
+}
+
+// promSamplers computes the series of each registered metric from a
+// snapshot. Every metric in metrics.Registry needs an entry here.
+var promSamplers = map[string]func(in Input) []promSample{
+	"netmon_connections": func(in Input) []promSample {
+		type key struct{ process, protocol, state string }
+		counts := make(map[key]int)
+		for _, app := range in.Snapshot.Applications {
+			for _, conn := range app.Connections {
+				counts[key{app.Name, string(conn.Protocol), string(conn.State)}]++
+			}
+		}
+		out := make([]promSample, 0, len(counts))
+		for k, n := range counts {
+			out = append(out, promSample{[]string{k.process, k.protocol, k.state}, float64(n)})
+		}
+		return out
+	},
+	"netmon_listen_sockets": perProcess(func(app model.Application, _ Input) (float64, bool) {
+		return float64(app.ListenCount), true
+	}),
+	"netmon_process_sent_bytes_total": perProcess(func(app model.Application, in Input) (float64, bool) {
+		sent, _, ok := appIO(app, in.IOStats)
+		return float64(sent), ok
+	}),
+	"netmon_process_received_bytes_total": perProcess(func(app model.Application, in Input) (float64, bool) {
+		_, recv, ok := appIO(app, in.IOStats)
+		return float64(recv), ok
+	}),
+	"netmon_processes": func(in Input) []promSample {
+		return []promSample{{nil, float64(len(in.Snapshot.Applications))}}
+	},
+}
+
+// perProcess returns a sampler with one series per process name, summing
+// applications that share a name (as with Group By Executable). value reports
+// false when the process has no value, e.g. no I/O stats.
+func perProcess(value func(app model.Application, in Input) (float64, bool)) func(Input) []promSample {
+	return func(in Input) []promSample {
+		sums := make(map[string]float64)
+		for _, app := range in.Snapshot.Applications {
+			if v, ok := value(app, in); ok {
+				sums[app.Name] += v
+			}
+		}
+		out := make([]promSample, 0, len(sums))
+		for name, v := range sums {
+			out = append(out, promSample{[]string{name}, v})
+		}
+		return out
+	}
+}
+
+// appIO sums the I/O stats of app's PIDs; ok is false when none have stats.
+func appIO(app model.Application, ioStats map[int32]*model.NetIOStats) (sent, recv uint64, ok bool) {
+	for _, pid := range app.PIDs {
+		if s, found := ioStats[pid]; found {
+			sent += s.BytesSent
+			recv += s.BytesRecv
+			ok = true
+		}
+	}
+	return sent, recv, ok
+}
+
+// renderPrometheus writes every registered metric in the Prometheus text
+// exposition format, without timestamps, as node_exporter's textfile
+// collector expects. Series are sorted so consecutive files diff cleanly.
+func renderPrometheus(w io.Writer, in Input) error {
+	var b strings.Builder
+	for _, m := range metrics.Registry {
+		sampler, ok := promSamplers[m.Name]
+		if !ok {
+			return fmt.Errorf("no sampler for metric %s", m.Name)
+		}
+		samples := sampler(in)
+		slices.SortFunc(samples, func(a, b promSample) int {
+			return slices.Compare(a.labels, b.labels)
+		})
+		fmt.Fprintf(&b, "# HELP %s %s\n", m.Name, escapeHelp(m.Help))
+		fmt.Fprintf(&b, "# TYPE %s %s\n", m.Name, m.Type)
+		for _, s := range samples {
+			b.WriteString(m.Name)
+			if len(m.Labels) > 0 {
+				pairs := make([]string, len(m.Labels))
+				for i, label := range m.Labels {
+					pairs[i] = label + `="` + escapeLabel(s.labels[i]) + `"`
+				}
+				b.WriteString("{" + strings.Join(pairs, ",") + "}")
+			}
+			b.WriteString(" " + strconv.FormatFloat(s.value, 'g', -1, 64) + "\n")
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// escapeHelp escapes a HELP text: backslashes and line feeds.
+func escapeHelp(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
+
+// escapeLabel escapes a label value: backslashes, double quotes and line feeds.
+func escapeLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
@@ -0,0 +1,100 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// Input is what a Renderer formats: one snapshot, the per-PID I/O stats
+// collected with it (nil when unavailable), and the flat list page options.
+type Input struct {
+	Snapshot *model.NetworkSnapshot
+	IOStats  map[int32]*model.NetIOStats
+	Page     *PageOptions // nil: the format's default (all rows, all fields)
+}
+
+// Renderer writes a snapshot in one output format.
+type Renderer interface {
+	Render(w io.Writer, in Input) error
+}
+
+// RendererFunc adapts a function to a Renderer.
+type RendererFunc func(w io.Writer, in Input) error
+
+// Render calls f(w, in).
+func (f RendererFunc) Render(w io.Writer, in Input) error {
+	return f(w, in)
+}
+
+var (
+	renderersMu sync.RWMutex
+	renderers   = make(map[string]Renderer)
+)
+
+// Register makes a renderer available under name (as in --format NAME). It
+// panics on an empty or duplicate name, like database/sql.Register.
+func Register(name string, r Renderer) {
+	renderersMu.Lock()
+	defer renderersMu.Unlock()
+	if name == "" || r == nil {
+		panic("output: Register with empty name or nil renderer")
+	}
+	if _, dup := renderers[name]; dup {
+		panic("output: Register called twice for format " + name)
+	}
+	renderers[name] = r
+}
+
+// Formats returns the registered format names, sorted.
+func Formats() []string {
+	renderersMu.RLock()
+	defer renderersMu.RUnlock()
+	names := make([]string, 0, len(renderers))
+	for name := range renderers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Lookup returns the renderer registered as name (case-insensitive).
+func Lookup(name string) (Renderer, error) {
+	renderersMu.RLock()
+	r, ok := renderers[strings.ToLower(name)]
+	renderersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown format %q (valid: %s)", name, strings.Join(Formats(), ","))
+	}
+	return r, nil
+}
+
+// The built-in formats.
+func init() {
+	Register("json", RendererFunc(renderJSON))
+	Register("jsonl", RendererFunc(renderJSONL))
+	Register("csv", RendererFunc(renderCSV))
+	Register("table", RendererFunc(renderTable))
+	Register("prometheus", RendererFunc(renderPrometheus))
+}
+
+// renderJSON writes the nested per-application JSON, or one page of the flat
+// connection list when page options are set.
+func renderJSON(w io.Writer, in Input) error {
+	if in.Page != nil {
+		return RenderPage(w, in.Snapshot, *in.Page)
+	}
+	return RenderJSON(w, in.Snapshot, in.IOStats)
+}
+
+// pageOf returns in's page options, or the whole list with all fields.
+func (in Input) pageOf() PageOptions {
+	if in.Page != nil {
+		return *in.Page
+	}
+	return PageOptions{}
+}
@@ -0,0 +1,119 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/kostyay/netmon/internal/metrics"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+func render(t *testing.T, format string, in Input) string {
+	t.Helper()
+	r, err := Lookup(format)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := r.Render(&buf, in); err != nil {
+		t.Fatalf("%s: %v", format, err)
+	}
+	return buf.String()
+}
+
+func TestLookup(t *testing.T) {
+	if got := strings.Join(Formats(), ","); got != "csv,json,jsonl,prometheus,table" {
+		t.Errorf("Formats() = %s", got)
+	}
+	if _, err := Lookup("CSV"); err != nil {
+		t.Errorf("Lookup is case-insensitive: %v", err)
+	}
+	_, err := Lookup("nagios")
+	if err == nil || !strings.Contains(err.Error(), "prometheus") {
+		t.Errorf("Lookup(nagios) err = %v, want unknown format with valid list", err)
+	}
+}
+
+func TestRegister_Duplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("registering json twice should panic")
+		}
+	}()
+	Register("json", RendererFunc(func(io.Writer, Input) error { return nil }))
+}
+
+func TestRender_JSONPage(t *testing.T) {
+	out := render(t, "json", Input{Snapshot: pageSnapshot(), Page: &PageOptions{Fields: []string{"laddr"}, Limit: 1}})
+	var page JSONPage
+	if err := json.Unmarshal([]byte(out), &page); err != nil || page.Total != 3 || len(page.Connections) != 1 {
+		t.Errorf("json page = %s (%v)", out, err)
+	}
+}
+
+func TestRender_Flat(t *testing.T) {
+	in := Input{Snapshot: pageSnapshot(), Page: &PageOptions{Fields: []string{"process", "laddr", "state"}}}
+
+	want := "process,laddr,state\nnginx,0.0.0.0:443,LISTEN\nnginx,0.0.0.0:80,LISTEN\nzsh,10.0.0.1:50000,ESTABLISHED\n"
+	if got := render(t, "csv", in); got != want {
+		t.Errorf("csv =\n%s\nwant\n%s", got, want)
+	}
+
+	lines := strings.Split(strings.TrimSpace(render(t, "jsonl", in)), "\n")
+	if len(lines) != 3 || lines[0] != `{"laddr":"0.0.0.0:443","process":"nginx","state":"LISTEN"}` {
+		t.Errorf("jsonl = %q", lines)
+	}
+
+	table := render(t, "table", in)
+	if !strings.HasPrefix(table, "PROCESS  LADDR           STATE\n") || !strings.Contains(table, "zsh      10.0.0.1:50000  ESTABLISHED") {
+		t.Errorf("table =\n%s", table)
+	}
+
+	// Without page options every row and field is written
+	if got := render(t, "csv", Input{Snapshot: pageSnapshot()}); !strings.HasPrefix(got, strings.Join(FieldNames(), ",")+"\n") || strings.Count(got, "\n") != 4 {
+		t.Errorf("csv (all fields) =\n%s", got)
+	}
+}
+
+func TestRender_Prometheus(t *testing.T) {
+	snap := pageSnapshot()
+	snap.Applications[1].ListenCount = 2
+	snap.Applications[1].Name = `ng"inx`
+	ioStats := map[int32]*model.NetIOStats{10: {BytesSent: 2048, BytesRecv: 1e9}}
+
+	out := render(t, "prometheus", Input{Snapshot: snap, IOStats: ioStats})
+	for _, want := range []string{
+		"# HELP netmon_connections Open sockets per process, protocol and state.\n# TYPE netmon_connections gauge\n",
+		`netmon_connections{process="ng\"inx",protocol="TCP",state="LISTEN"} 2` + "\n",
+		`netmon_connections{process="zsh",protocol="TCP",state="ESTABLISHED"} 1` + "\n",
+		`netmon_listen_sockets{process="zsh"} 0` + "\n",
+		"# TYPE netmon_process_sent_bytes_total counter\n",
+		`netmon_process_sent_bytes_total{process="ng\"inx"} 2048` + "\n",
+		`netmon_process_received_bytes_total{process="ng\"inx"} 1e+09` + "\n",
+		"netmon_processes 2\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("prometheus output missing %q:\n%s", want, out)
+		}
+	}
+	// zsh has no I/O stats: no byte series rather than a fake 0
+	if strings.Contains(out, `sent_bytes_total{process="zsh"}`) {
+		t.Errorf("series for a process without I/O stats:\n%s", out)
+	}
+}
+
+func TestPromSamplers_MatchRegistry(t *testing.T) {
+	for _, m := range metrics.Registry {
+		if _, ok := promSamplers[m.Name]; !ok {
+			t.Errorf("registered metric %s has no sampler", m.Name)
+		}
+	}
+	for name := range promSamplers {
+		if _, ok := metrics.Lookup(name); !ok {
+			t.Errorf("sampler for unregistered metric %s", name)
+		}
+	}
+}
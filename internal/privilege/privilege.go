@@ -0,0 +1,77 @@
+// Package privilege diagnoses which parts of netmon degrade when it runs
+// without root, so the UI and `--check` can say exactly what is missing.
+package privilege
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Limitation is a feature that is unavailable or partial at the current privilege level.
+type Limitation struct {
+	Feature string // what is affected, e.g. "Socket ownership"
+	Impact  string // what the user will observe
+	Needs   string // what would fix it, e.g. "root or CAP_SYS_PTRACE"
+}
+
+// Report is the result of a privilege check.
+type Report struct {
+	Privileged  bool // running as root (all features available)
+	Limitations []Limitation
+}
+
+// Partial reports whether some sockets may be hidden or unattributed.
+func (r Report) Partial() bool {
+	return !r.Privileged && len(r.Limitations) > 0
+}
+
+// Write prints a human-readable diagnosis to w.
+func (r Report) Write(w io.Writer) error {
+	if r.Privileged {
+		_, err := fmt.Fprintln(w, "Running as root: all features available.")
+		return err
+	}
+	if len(r.Limitations) == 0 {
+		_, err := fmt.Fprintln(w, "All features available at the current privilege level.")
+		return err
+	}
+	if _, err := fmt.Fprintln(w, "Running without root. Limitations:"); err != nil {
+		return err
+	}
+	for _, l := range r.Limitations {
+		if _, err := fmt.Fprintf(w, "  - %s: %s (needs %s)\n", l.Feature, l.Impact, l.Needs); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, "Run with sudo for a complete view.")
+	return err
+}
+
+// Linux capability bits (see capabilities(7)).
+const (
+	capKill        = 5
+	capNetAdmin    = 12
+	capSysPtrace   = 19
+	capDacOverride = 1
+)
+
+// parseCapEff returns the effective capability mask from /proc/self/status content.
+func parseCapEff(status string) uint64 {
+	for _, line := range strings.Split(status, "\n") {
+		if v, ok := strings.CutPrefix(line, "CapEff:"); ok {
+			mask, err := strconv.ParseUint(strings.TrimSpace(v), 16, 64)
+			if err != nil {
+				return 0
+			}
+			return mask
+		}
+	}
+	return 0
+}
+
+// hasCap reports whether capability bit c is set in mask.
+func hasCap(mask uint64, c uint) bool {
+	return mask&(1<<c) != 0
+}
@@ -0,0 +1,24 @@
+//go:build darwin
+
+package privilege
+
+import "os"
+
+// Check inspects the effective UID of the current process.
+func Check() Report {
+	if os.Geteuid() == 0 {
+		return Report{Privileged: true}
+	}
+	return Report{Limitations: []Limitation{
+		{
+			Feature: "Socket ownership",
+			Impact:  "other users' and system sockets are hidden",
+			Needs:   "root",
+		},
+		{
+			Feature: "Kill",
+			Impact:  "only your own processes can be signalled",
+			Needs:   "root",
+		},
+	}}
+}
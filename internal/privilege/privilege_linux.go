@@ -0,0 +1,59 @@
+//go:build linux
+
+package privilege
+
+import "os"
+
+// Check inspects the effective UID and capabilities of the current process.
+func Check() Report {
+	if os.Geteuid() == 0 {
+		return Report{Privileged: true}
+	}
+	status, _ := os.ReadFile("/proc/self/status")
+	return linuxReport(parseCapEff(string(status)), conntrackReadable())
+}
+
+// linuxReport builds the report for a non-root process with the given capability mask.
+func linuxReport(caps uint64, conntrackOK bool) Report {
+	var r Report
+	if !hasCap(caps, capSysPtrace) {
+		r.Limitations = append(r.Limitations, Limitation{
+			Feature: "Socket ownership",
+			Impact:  "other users' sockets are hidden (no owning process visible)",
+			Needs:   "root or CAP_SYS_PTRACE",
+		})
+	}
+	if !hasCap(caps, capKill) {
+		r.Limitations = append(r.Limitations, Limitation{
+			Feature: "Kill",
+			Impact:  "only your own processes can be signalled",
+			Needs:   "root or CAP_KILL",
+		})
+	}
+	if !hasCap(caps, capNetAdmin) {
+		r.Limitations = append(r.Limitations, Limitation{
+			Feature: "Close connection",
+			Impact:  "SOCK_DESTROY will fail",
+			Needs:   "root or CAP_NET_ADMIN",
+		})
+	}
+	if !conntrackOK && !hasCap(caps, capDacOverride) {
+		r.Limitations = append(r.Limitations, Limitation{
+			Feature: "Conntrack view",
+			Impact:  "/proc/net/nf_conntrack is not readable",
+			Needs:   "root",
+		})
+	}
+	return r
+}
+
+// conntrackReadable reports whether the conntrack table can be opened.
+// A missing table (module not loaded) is not a privilege problem.
+func conntrackReadable() bool {
+	f, err := os.Open("/proc/net/nf_conntrack")
+	if err != nil {
+		return !os.IsPermission(err)
+	}
+	_ = f.Close()
+	return true
+}
@@ -0,0 +1,21 @@
+//go:build linux
+
+package privilege
+
+import "testing"
+
+func TestLinuxReport(t *testing.T) {
+	none := linuxReport(0, false)
+	if len(none.Limitations) != 4 {
+		t.Errorf("no capabilities: %d limitations, want 4", len(none.Limitations))
+	}
+
+	all := uint64(1<<capKill | 1<<capNetAdmin | 1<<capSysPtrace)
+	if r := linuxReport(all, true); len(r.Limitations) != 0 {
+		t.Errorf("all capabilities: limitations = %+v, want none", r.Limitations)
+	}
+
+	if r := linuxReport(1<<capNetAdmin, true); r.Limitations[0].Feature != "Socket ownership" {
+		t.Errorf("first limitation = %q, want Socket ownership", r.Limitations[0].Feature)
+	}
+}
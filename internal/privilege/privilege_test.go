@@ -0,0 +1,58 @@
+package privilege
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseCapEff(t *testing.T) {
+	status := "Name:\tnetmon\nCapInh:\t0000000000000000\nCapEff:\t0000000000081020\nCapBnd:\t000001ffffffffff\n"
+	mask := parseCapEff(status)
+	for _, c := range []uint{capKill, capNetAdmin, capSysPtrace} {
+		if !hasCap(mask, c) {
+			t.Errorf("capability %d should be set in %x", c, mask)
+		}
+	}
+	if hasCap(mask, capDacOverride) {
+		t.Errorf("CAP_DAC_OVERRIDE should not be set in %x", mask)
+	}
+	if got := parseCapEff("Name:\tx\n"); got != 0 {
+		t.Errorf("parseCapEff without field = %x, want 0", got)
+	}
+}
+
+func TestReportWrite(t *testing.T) {
+	tests := []struct {
+		name   string
+		report Report
+		want   []string
+	}{
+		{"root", Report{Privileged: true}, []string{"all features available"}},
+		{"none missing", Report{}, []string{"All features available"}},
+		{"partial", Report{Limitations: []Limitation{{Feature: "Kill", Impact: "own processes only", Needs: "root"}}},
+			[]string{"Limitations:", "- Kill: own processes only (needs root)", "sudo"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.report.Write(&buf); err != nil {
+				t.Fatal(err)
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(buf.String(), w) {
+					t.Errorf("output missing %q:\n%s", w, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestReportPartial(t *testing.T) {
+	if (Report{Privileged: true}).Partial() {
+		t.Error("root report should not be partial")
+	}
+	if !(Report{Limitations: []Limitation{{}}}).Partial() {
+		t.Error("report with limitations should be partial")
+	}
+}
@@ -0,0 +1,65 @@
+// Package procenv reads a process's working directory and a whitelisted subset
+// of its environment, to show which config a listener was started with.
+// Only explicitly requested keys are returned so secrets never reach the screen.
+package procenv
+
+import (
+	"errors"
+	"os"
+	"strings"
+)
+
+// ErrUnsupported is returned for data the platform cannot provide.
+var ErrUnsupported = errors.New("not supported on this platform")
+
+// DefaultKeys are the environment variables shown when none are configured.
+var DefaultKeys = []string{"PORT", "HOST", "NODE_ENV", "APP_ENV", "RAILS_ENV", "FLASK_ENV", "GO_ENV", "ENVIRONMENT"}
+
+// Var is one environment variable.
+type Var struct {
+	Key   string
+	Value string
+}
+
+// Info is what could be read about a process. Each part fails independently,
+// e.g. another user's cwd may be readable while its environment is not.
+type Info struct {
+	Cwd    string
+	CwdErr error
+	Env    []Var // whitelisted variables that are set, in key order
+	EnvErr error
+}
+
+// filterEnv returns the entries of a NUL-separated environ block whose key is
+// in keys, ordered as keys.
+func filterEnv(environ string, keys []string) []Var {
+	set := make(map[string]string)
+	for _, kv := range strings.Split(environ, "\x00") {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			set[k] = v
+		}
+	}
+	var vars []Var
+	for _, k := range keys {
+		if v, ok := set[k]; ok {
+			vars = append(vars, Var{Key: k, Value: v})
+		}
+	}
+	return vars
+}
+
+// Reason returns a short user-facing explanation for a lookup error.
+func Reason(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, os.ErrPermission):
+		return "permission denied (run with sudo)"
+	case errors.Is(err, os.ErrNotExist):
+		return "process exited"
+	case errors.Is(err, ErrUnsupported):
+		return "unavailable on this OS"
+	default:
+		return err.Error()
+	}
+}
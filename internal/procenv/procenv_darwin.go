@@ -0,0 +1,45 @@
+//go:build darwin
+
+package procenv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Lookup reads the cwd of pid via lsof. macOS does not expose other processes'
+// environments without task_for_pid, so Env is always unavailable.
+func Lookup(pid int32, keys []string) Info {
+	info := Info{EnvErr: ErrUnsupported}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	// #nosec G204 - pid is an integer
+	out, err := exec.CommandContext(ctx, "lsof", "-a", "-p", strconv.Itoa(int(pid)), "-d", "cwd", "-Fn").Output()
+	if errors.Is(err, exec.ErrNotFound) {
+		info.CwdErr = ErrUnsupported
+		return info
+	}
+	if err != nil {
+		// lsof exits non-zero with no output when the process is not ours to inspect
+		info.CwdErr = fmt.Errorf("lsof: %w", os.ErrPermission)
+		return info
+	}
+	info.Cwd, info.CwdErr = parseLsofCwd(string(out))
+	return info
+}
+
+// parseLsofCwd extracts the path from `lsof -Fn` output ("p<pid>\nfcwd\nn<path>").
+func parseLsofCwd(out string) (string, error) {
+	for _, line := range strings.Split(out, "\n") {
+		if path, ok := strings.CutPrefix(line, "n"); ok {
+			return path, nil
+		}
+	}
+	return "", os.ErrPermission
+}
@@ -0,0 +1,25 @@
+//go:build linux
+
+package procenv
+
+import (
+	"fmt"
+	"os"
+)
+
+// procRoot is the proc filesystem mount (replaced in tests).
+var procRoot = "/proc"
+
+// Lookup reads the cwd and the environment variables named in keys for pid.
+// Other users' processes need root (or CAP_SYS_PTRACE).
+func Lookup(pid int32, keys []string) Info {
+	var info Info
+	info.Cwd, info.CwdErr = os.Readlink(fmt.Sprintf("%s/%d/cwd", procRoot, pid))
+	environ, err := os.ReadFile(fmt.Sprintf("%s/%d/environ", procRoot, pid))
+	if err != nil {
+		info.EnvErr = err
+		return info
+	}
+	info.Env = filterEnv(string(environ), keys)
+	return info
+}
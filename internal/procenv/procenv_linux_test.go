@@ -0,0 +1,57 @@
+//go:build linux
+
+package procenv
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLookup_Self(t *testing.T) {
+	t.Setenv("PORT", "4321")
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info := Lookup(int32(os.Getpid()), []string{"PORT"})
+
+	if info.CwdErr != nil || info.Cwd != wd {
+		t.Errorf("Cwd = %q, %v; want %q", info.Cwd, info.CwdErr, wd)
+	}
+	// t.Setenv changes the Go environment, not the environ the process started with.
+	if info.EnvErr != nil {
+		t.Errorf("EnvErr = %v, want readable own environment", info.EnvErr)
+	}
+}
+
+func TestLookup_FakeProc(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "42")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("/srv/app", filepath.Join(dir, "cwd")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "environ"), []byte("PORT=3000\x00TOKEN=x\x00"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	old := procRoot
+	procRoot = root
+	t.Cleanup(func() { procRoot = old })
+
+	info := Lookup(42, []string{"PORT", "TOKEN_NOT_LISTED"})
+	if info.Cwd != "/srv/app" {
+		t.Errorf("Cwd = %q, want /srv/app", info.Cwd)
+	}
+	if len(info.Env) != 1 || info.Env[0] != (Var{"PORT", "3000"}) {
+		t.Errorf("Env = %v, want only PORT", info.Env)
+	}
+
+	missing := Lookup(43, nil)
+	if missing.CwdErr == nil || missing.EnvErr == nil {
+		t.Error("missing process should report errors for both parts")
+	}
+}
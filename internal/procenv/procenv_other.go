@@ -0,0 +1,8 @@
+//go:build !linux && !darwin
+
+package procenv
+
+// Lookup is unsupported on this platform.
+func Lookup(pid int32, keys []string) Info {
+	return Info{CwdErr: ErrUnsupported, EnvErr: ErrUnsupported}
+}
@@ -0,0 +1,42 @@
+package procenv
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestFilterEnv(t *testing.T) {
+	environ := "PATH=/usr/bin\x00PORT=8080\x00SECRET_TOKEN=hunter2\x00NODE_ENV=production\x00EMPTY=\x00"
+	got := filterEnv(environ, []string{"NODE_ENV", "PORT", "EMPTY", "MISSING"})
+	want := []Var{{"NODE_ENV", "production"}, {"PORT", "8080"}, {"EMPTY", ""}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("filterEnv() = %v, want %v", got, want)
+	}
+}
+
+func TestFilterEnv_OnlyWhitelisted(t *testing.T) {
+	for _, v := range filterEnv("SECRET_TOKEN=hunter2\x00AWS_SECRET_ACCESS_KEY=x\x00", DefaultKeys) {
+		t.Errorf("non-whitelisted variable leaked: %s", v.Key)
+	}
+}
+
+func TestReason(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, ""},
+		{fmt.Errorf("open: %w", os.ErrPermission), "permission denied (run with sudo)"},
+		{fmt.Errorf("open: %w", os.ErrNotExist), "process exited"},
+		{ErrUnsupported, "unavailable on this OS"},
+		{errors.New("boom"), "boom"},
+	}
+	for _, tt := range tests {
+		if got := Reason(tt.err); got != tt.want {
+			t.Errorf("Reason(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package process
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// DefaultCritical are the process name patterns that need a typed
+// confirmation before they are signalled when no patterns are configured:
+// stopping one of them can lock you out of a remote host or take down every
+// container on it.
+var DefaultCritical = []string{
+	"sshd", "systemd", "systemd-*", "init", "launchd", "docker*", "containerd*",
+}
+
+// Target is a process to signal.
+type Target struct {
+	PID  int32
+	Name string // display name, e.g. "sshd (sbin)" when grouped by executable
+	Exe  string // full executable path, if known
+}
+
+// CriticalName returns the name critical patterns are matched against and that
+// confirms signalling the process: the executable's base name, or the display
+// name when the path is unknown.
+func (t Target) CriticalName() string {
+	if t.Exe != "" {
+		return filepath.Base(t.Exe)
+	}
+	return t.Name
+}
+
+// IsCritical reports whether the target matches one of patterns (path.Match
+// globs, case-insensitive). Nil patterns use DefaultCritical.
+func IsCritical(patterns []string, t Target) bool {
+	if patterns == nil {
+		patterns = DefaultCritical
+	}
+	name := strings.ToLower(t.CriticalName())
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(strings.ToLower(pattern), name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// UnconfirmedError is returned when a critical process is signalled without
+// its name as confirmation.
+type UnconfirmedError struct {
+	Target Target
+}
+
+func (e *UnconfirmedError) Error() string {
+	return fmt.Sprintf("PID %d (%s) is a system service: confirm with its name %q", e.Target.PID, e.Target.Name, e.Target.CriticalName())
+}
+
+// Signaller sends signals, refusing critical processes unless confirmed. Every
+// path that stops or kills a process goes through it.
+type Signaller struct {
+	Critical []string                                // patterns; nil uses DefaultCritical
+	Kill     func(pid int, sig syscall.Signal) error // e.g. syscall.Kill
+}
+
+// Signal sends sig to t. A critical target is only signalled when confirm is
+// its CriticalName; SIGCONT, which only resumes a process, always goes through.
+func (s Signaller) Signal(t Target, sig syscall.Signal, confirm string) error {
+	if sig != syscall.SIGCONT && IsCritical(s.Critical, t) && confirm != t.CriticalName() {
+		return &UnconfirmedError{Target: t}
+	}
+	return s.Kill(int(t.PID), sig)
+}
@@ -0,0 +1,60 @@
+package process
+
+import (
+	"errors"
+	"syscall"
+	"testing"
+)
+
+func TestIsCritical(t *testing.T) {
+	for _, tt := range []struct {
+		target Target
+		want   bool
+	}{
+		{Target{Name: "sshd"}, true},
+		{Target{Name: "systemd-resolved"}, true},
+		{Target{Name: "Docker Desktop"}, true},
+		{Target{Name: "sshd-session2"}, false},
+		{Target{Name: "nginx"}, false},
+		// grouped by executable: the display name carries the directory
+		{Target{Name: "sshd (sbin)", Exe: "/usr/sbin/sshd"}, true},
+		{Target{Name: "systemd (lib)", Exe: "/usr/lib/systemd/systemd"}, true},
+		// a renamed process is judged by what it runs
+		{Target{Name: "sshd", Exe: "/opt/app/bin/server"}, false},
+	} {
+		if got := IsCritical(nil, tt.target); got != tt.want {
+			t.Errorf("IsCritical(%+v) = %v, want %v", tt.target, got, tt.want)
+		}
+	}
+	if !IsCritical([]string{"NGINX*"}, Target{Exe: "/usr/sbin/nginx"}) {
+		t.Error("configured patterns should match case-insensitively")
+	}
+}
+
+func TestSignaller_CriticalNeedsConfirmation(t *testing.T) {
+	var sent []syscall.Signal
+	s := Signaller{Kill: func(_ int, sig syscall.Signal) error { sent = append(sent, sig); return nil }}
+	sshd := Target{PID: 1, Name: "sshd (sbin)", Exe: "/usr/sbin/sshd"}
+
+	var unconfirmed *UnconfirmedError
+	for _, confirm := range []string{"", "sshd (sbin)"} {
+		if err := s.Signal(sshd, syscall.SIGSTOP, confirm); !errors.As(err, &unconfirmed) {
+			t.Errorf("confirm %q: err = %v, want UnconfirmedError", confirm, err)
+		}
+	}
+	if len(sent) != 0 {
+		t.Fatalf("sent %v to an unconfirmed critical process", sent)
+	}
+	if err := s.Signal(sshd, syscall.SIGCONT, ""); err != nil {
+		t.Errorf("SIGCONT should not need a confirmation: %v", err)
+	}
+	if err := s.Signal(sshd, syscall.SIGTERM, "sshd"); err != nil {
+		t.Errorf("confirmed kill: %v", err)
+	}
+	if err := s.Signal(Target{PID: 2, Name: "nginx"}, syscall.SIGTERM, ""); err != nil {
+		t.Errorf("ordinary process: %v", err)
+	}
+	if len(sent) != 3 {
+		t.Errorf("sent %v, want SIGCONT, SIGTERM, SIGTERM", sent)
+	}
+}
@@ -0,0 +1,46 @@
+package process
+
+import (
+	"context"
+	"slices"
+
+	gprocess "github.com/shirou/gopsutil/v3/process"
+)
+
+// maxTreeDepth bounds ancestor walks, in case the parent table has a cycle
+// (PIDs reused between reads).
+const maxTreeDepth = 64
+
+// Parents returns the parent PID of every process on the host. Processes
+// that exit while being read are skipped.
+func Parents() (map[int32]int32, error) {
+	ctx := context.Background()
+	procs, err := gprocess.ProcessesWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	parents := make(map[int32]int32, len(procs))
+	for _, p := range procs {
+		if ppid, err := p.PpidWithContext(ctx); err == nil {
+			parents[p.Pid] = ppid
+		}
+	}
+	return parents, nil
+}
+
+// Lineage returns pids and all their ancestors in parents, for matching
+// tree:PID filters.
+func Lineage(parents map[int32]int32, pids []int32) []int32 {
+	out := slices.Clone(pids)
+	for _, pid := range pids {
+		for range maxTreeDepth {
+			ppid, ok := parents[pid]
+			if !ok || ppid <= 0 || ppid == pid {
+				break
+			}
+			out = append(out, ppid)
+			pid = ppid
+		}
+	}
+	return out
+}
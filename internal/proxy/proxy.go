@@ -0,0 +1,127 @@
+// Package proxy recognizes local forward proxies (mitmproxy, Charles, Squid,
+// SOCKS daemons, …) by process name and, for mitmproxy, reads the original
+// destination of each client connection from mitmweb's web API. Connections
+// through a local proxy otherwise all point at the same 127.0.0.1 port.
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// known maps proxy executables (lowercase, without .exe) to display names.
+var known = map[string]string{
+	"mitmproxy":  "mitmproxy",
+	"mitmdump":   "mitmproxy",
+	"mitmweb":    "mitmproxy",
+	"charles":    "Charles",
+	"proxyman":   "Proxyman",
+	"fiddler":    "Fiddler",
+	"squid":      "Squid",
+	"privoxy":    "Privoxy",
+	"tinyproxy":  "tinyproxy",
+	"polipo":     "Polipo",
+	"3proxy":     "3proxy",
+	"cntlm":      "Cntlm",
+	"sockd":      "Dante",
+	"danted":     "Dante",
+	"microsocks": "microsocks",
+	"gost":       "gost",
+	"sslocal":    "Shadowsocks",
+	"ss-local":   "Shadowsocks",
+	"v2ray":      "V2Ray",
+	"xray":       "Xray",
+	"clash":      "Clash",
+	"mihomo":     "Clash",
+	"sing-box":   "sing-box",
+	"tor":        "Tor",
+}
+
+// Name returns the display name of a known proxy given a process name or
+// executable path, or "" when process is not a known proxy.
+func Name(process string) string {
+	base := strings.ToLower(filepath.Base(process))
+	return known[strings.TrimSuffix(base, ".exe")]
+}
+
+// flow is the part of a mitmweb /flows entry needed to map a client
+// connection to its destination.
+type flow struct {
+	ClientConn struct {
+		Peername []json.RawMessage `json:"peername"` // [host, port]
+	} `json:"client_conn"`
+	ServerConn struct {
+		Address []json.RawMessage `json:"address"` // [host, port]
+	} `json:"server_conn"`
+	Request *struct {
+		PrettyHost string `json:"pretty_host"`
+		Host       string `json:"host"`
+		Port       int    `json:"port"`
+	} `json:"request"`
+}
+
+// Destinations fetches the flows mitmweb holds from its web interface at
+// baseURL (as printed by mitmweb, including any ?token=) and returns the
+// destination of each client connection, "host:port" keyed by the client's
+// source port. Later flows win when a port was reused.
+func Destinations(ctx context.Context, baseURL string) (map[int]string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("proxy API URL: %w", err)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/flows"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("mitmweb %s: %s", u.Redacted(), resp.Status)
+	}
+	var flows []flow
+	if err := json.NewDecoder(resp.Body).Decode(&flows); err != nil {
+		return nil, fmt.Errorf("mitmweb flows: %w", err)
+	}
+
+	dests := make(map[int]string, len(flows))
+	for _, f := range flows {
+		_, port, ok := hostPort(f.ClientConn.Peername)
+		if !ok {
+			continue
+		}
+		if r := f.Request; r != nil && r.Port > 0 && (r.PrettyHost != "" || r.Host != "") {
+			host := r.PrettyHost
+			if host == "" {
+				host = r.Host
+			}
+			dests[port] = net.JoinHostPort(host, strconv.Itoa(r.Port))
+		} else if host, p, ok := hostPort(f.ServerConn.Address); ok {
+			dests[port] = net.JoinHostPort(host, strconv.Itoa(p))
+		}
+	}
+	return dests, nil
+}
+
+// hostPort decodes mitmproxy's [host, port] address pair.
+func hostPort(addr []json.RawMessage) (string, int, bool) {
+	if len(addr) != 2 {
+		return "", 0, false
+	}
+	var host string
+	var port int
+	if json.Unmarshal(addr[0], &host) != nil || json.Unmarshal(addr[1], &port) != nil || host == "" || port <= 0 {
+		return "", 0, false
+	}
+	return host, port, true
+}
@@ -0,0 +1,61 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestName(t *testing.T) {
+	tests := map[string]string{
+		"mitmdump":                  "mitmproxy",
+		"/usr/local/bin/mitmweb":    "mitmproxy",
+		"Charles":                   "Charles",
+		"fiddler.exe":               "Fiddler",
+		"squid":                     "Squid",
+		"chrome":                    "",
+		"/opt/homebrew/bin/privoxy": "Privoxy",
+	}
+	for in, want := range tests {
+		if got := Name(in); got != want {
+			t.Errorf("Name(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestDestinations(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/flows" || r.URL.Query().Get("token") != "abc" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`[
+			{"client_conn":{"peername":["127.0.0.1",51000]},"server_conn":{"address":["140.82.112.3",443]},
+			 "request":{"pretty_host":"api.github.com","host":"140.82.112.3","port":443}},
+			{"client_conn":{"peername":["127.0.0.1",51001]},"server_conn":{"address":["db.internal",5432]}},
+			{"client_conn":{"peername":["127.0.0.1",51000]},"server_conn":{"address":["1.1.1.1",443]},
+			 "request":{"host":"one.one.one.one","port":443}},
+			{"client_conn":{"peername":null},"server_conn":{"address":["ignored",80]}}
+		]`))
+	}))
+	defer srv.Close()
+
+	dests, err := Destinations(context.Background(), srv.URL+"/?token=abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[int]string{51000: "one.one.one.one:443", 51001: "db.internal:5432"}
+	if len(dests) != len(want) {
+		t.Fatalf("dests = %v, want %v", dests, want)
+	}
+	for port, dest := range want {
+		if dests[port] != dest {
+			t.Errorf("dests[%d] = %q, want %q", port, dests[port], dest)
+		}
+	}
+
+	if _, err := Destinations(context.Background(), srv.URL); err == nil {
+		t.Error("want an error without the token")
+	}
+}
@@ -16,7 +16,7 @@ type githubRelease struct {
 // CheckLatest fetches the latest release from GitHub API.
 // Returns the latest tag if newer than currentVersion, empty string if current.
 func CheckLatest(owner, repo, currentVersion string) (string, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/latest", owner, repo)
+	url := fmt.Sprintf("%s/repos/%s/%s/releases/latest", apiBaseURL, owner, repo)
 
 	client := &http.Client{Timeout: 5 * time.Second}
 	resp, err := client.Get(url)
@@ -0,0 +1,206 @@
+package release
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// apiBaseURL is the GitHub API root (replaced in tests).
+var apiBaseURL = "https://api.github.com"
+
+// checksumsAsset is the goreleaser checksum file published with every release.
+const checksumsAsset = "checksums.txt"
+
+// maxArchiveSize bounds release downloads; the archive holds a single static binary.
+const maxArchiveSize = 100 << 20
+
+// Asset is a downloadable file attached to a release.
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Update describes the release archive matching this platform.
+type Update struct {
+	Version   string // release tag, e.g. "v1.3.0"
+	Archive   Asset  // netmon_<version>_<os>_<arch>.tar.gz
+	Checksums Asset  // checksums.txt (sha256)
+}
+
+// ArchiveName returns the goreleaser archive name for a release tag and platform.
+func ArchiveName(tag, goos, goarch string) string {
+	return fmt.Sprintf("netmon_%s_%s_%s.tar.gz", strings.TrimPrefix(tag, "v"), goos, goarch)
+}
+
+// FindUpdate looks up the latest release and returns its archive for goos/goarch.
+// Returns nil if the latest release is not newer than currentVersion.
+func FindUpdate(ctx context.Context, owner, repo, currentVersion, goos, goarch string) (*Update, error) {
+	var rel struct {
+		TagName string  `json:"tag_name"`
+		Assets  []Asset `json:"assets"`
+	}
+	url := fmt.Sprintf("%s/repos/%s/%s/releases/latest", apiBaseURL, owner, repo)
+	body, err := get(ctx, url, 1<<20)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, &rel); err != nil {
+		return nil, err
+	}
+	if rel.TagName == "" || !isNewer(strings.TrimPrefix(rel.TagName, "v"), strings.TrimPrefix(currentVersion, "v")) {
+		return nil, nil
+	}
+
+	u := &Update{Version: rel.TagName}
+	want := ArchiveName(rel.TagName, goos, goarch)
+	for _, a := range rel.Assets {
+		switch a.Name {
+		case want:
+			u.Archive = a
+		case checksumsAsset:
+			u.Checksums = a
+		}
+	}
+	if u.Archive.URL == "" {
+		return nil, fmt.Errorf("release %s has no %s build (%s)", rel.TagName, goos+"/"+goarch, want)
+	}
+	if u.Checksums.URL == "" {
+		return nil, fmt.Errorf("release %s has no %s; refusing unverified update", rel.TagName, checksumsAsset)
+	}
+	return u, nil
+}
+
+// Apply downloads the update, verifies its sha256 against the release checksums
+// and atomically replaces the binary at exePath. The old binary keeps running
+// until the process exits.
+func Apply(ctx context.Context, u *Update, exePath string) error {
+	sums, err := get(ctx, u.Checksums.URL, 1<<20)
+	if err != nil {
+		return fmt.Errorf("download checksums: %w", err)
+	}
+	want, err := checksumFor(sums, u.Archive.Name)
+	if err != nil {
+		return err
+	}
+	archive, err := get(ctx, u.Archive.URL, maxArchiveSize)
+	if err != nil {
+		return fmt.Errorf("download %s: %w", u.Archive.Name, err)
+	}
+	sum := sha256.Sum256(archive)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return fmt.Errorf("checksum mismatch for %s: got %s, want %s", u.Archive.Name, got, want)
+	}
+	bin, err := extractBinary(archive, "netmon")
+	if err != nil {
+		return err
+	}
+	return replaceFile(exePath, bin)
+}
+
+// checksumFor finds name in a sha256sum-style listing ("<hex>  <file>" per line).
+func checksumFor(sums []byte, name string) (string, error) {
+	sc := bufio.NewScanner(bytes.NewReader(sums))
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("%s not listed in %s", name, checksumsAsset)
+}
+
+// extractBinary returns the contents of the regular file named name in a .tar.gz.
+func extractBinary(archive []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%s not found in archive", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag == tar.TypeReg && filepath.Base(hdr.Name) == name {
+			return io.ReadAll(io.LimitReader(tr, maxArchiveSize))
+		}
+	}
+}
+
+// replaceFile writes data next to path and renames it into place, keeping the
+// original file mode, so a failed update never leaves a truncated binary.
+func replaceFile(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".netmon-update-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// get fetches url and returns at most limit bytes of the body.
+func get(ctx context.Context, url string, limit int64) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s returned %d", url, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, fmt.Errorf("GET %s: response larger than %d bytes", url, limit)
+	}
+	return body, nil
+}
+
+// Executable returns the resolved path of the running binary, following symlinks
+// (e.g. a Homebrew or ~/bin link) so the real file is replaced.
+func Executable() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(exe)
+}
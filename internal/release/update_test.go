@@ -0,0 +1,174 @@
+package release
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// makeArchive returns a .tar.gz holding files (name → contents).
+func makeArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, body := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o755, Size: int64(len(body)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// fakeReleaseServer serves a latest release with the given tag, archive and
+// checksums, and points apiBaseURL at it for the duration of the test.
+func fakeReleaseServer(t *testing.T, tag string, archive []byte, checksums string) {
+	t.Helper()
+	name := ArchiveName(tag, "linux", "amd64")
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	mux.HandleFunc("/repos/kostyay/netmon/releases/latest", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"tag_name": tag,
+			"assets": []Asset{
+				{Name: name, URL: srv.URL + "/dl/" + name},
+				{Name: checksumsAsset, URL: srv.URL + "/dl/" + checksumsAsset},
+			},
+		})
+	})
+	mux.HandleFunc("/dl/"+name, func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write(archive) })
+	mux.HandleFunc("/dl/"+checksumsAsset, func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte(checksums)) })
+
+	old := apiBaseURL
+	apiBaseURL = srv.URL
+	t.Cleanup(func() { apiBaseURL = old })
+}
+
+func sha(b []byte) string {
+	s := sha256.Sum256(b)
+	return hex.EncodeToString(s[:])
+}
+
+func TestArchiveName(t *testing.T) {
+	if got := ArchiveName("v1.2.3", "darwin", "arm64"); got != "netmon_1.2.3_darwin_arm64.tar.gz" {
+		t.Errorf("ArchiveName() = %q", got)
+	}
+}
+
+func TestFindUpdate(t *testing.T) {
+	archive := makeArchive(t, map[string]string{"netmon": "new"})
+	fakeReleaseServer(t, "v1.3.0", archive, "")
+	ctx := context.Background()
+
+	u, err := FindUpdate(ctx, "kostyay", "netmon", "v1.2.0", "linux", "amd64")
+	if err != nil {
+		t.Fatalf("FindUpdate: %v", err)
+	}
+	if u == nil || u.Version != "v1.3.0" || u.Archive.Name != "netmon_1.3.0_linux_amd64.tar.gz" {
+		t.Errorf("FindUpdate() = %+v, want v1.3.0 linux/amd64 archive", u)
+	}
+
+	if u, err := FindUpdate(ctx, "kostyay", "netmon", "v1.3.0", "linux", "amd64"); err != nil || u != nil {
+		t.Errorf("FindUpdate() on latest = %+v, %v; want nil, nil", u, err)
+	}
+
+	if _, err := FindUpdate(ctx, "kostyay", "netmon", "v1.2.0", "windows", "amd64"); err == nil {
+		t.Error("FindUpdate() for an unpublished platform should fail")
+	}
+}
+
+func TestApply_ReplacesBinary(t *testing.T) {
+	archive := makeArchive(t, map[string]string{"README.md": "docs", "netmon": "new binary"})
+	name := ArchiveName("v1.3.0", "linux", "amd64")
+	fakeReleaseServer(t, "v1.3.0", archive, fmt.Sprintf("%s  other.tar.gz\n%s  %s\n", strings.Repeat("0", 64), sha(archive), name))
+
+	exe := filepath.Join(t.TempDir(), "netmon")
+	if err := os.WriteFile(exe, []byte("old binary"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	u, err := FindUpdate(context.Background(), "kostyay", "netmon", "v1.0.0", "linux", "amd64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Apply(context.Background(), u, exe); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+
+	got, err := os.ReadFile(exe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "new binary" {
+		t.Errorf("binary = %q, want replaced", got)
+	}
+	info, _ := os.Stat(exe)
+	if info.Mode().Perm() != 0o750 {
+		t.Errorf("mode = %o, want original 750 kept", info.Mode().Perm())
+	}
+	if leftovers, _ := filepath.Glob(filepath.Join(filepath.Dir(exe), ".netmon-update-*")); len(leftovers) > 0 {
+		t.Errorf("temp files left behind: %v", leftovers)
+	}
+}
+
+func TestApply_ChecksumMismatch(t *testing.T) {
+	archive := makeArchive(t, map[string]string{"netmon": "tampered"})
+	name := ArchiveName("v1.3.0", "linux", "amd64")
+	fakeReleaseServer(t, "v1.3.0", archive, fmt.Sprintf("%s  %s\n", strings.Repeat("ab", 32), name))
+
+	exe := filepath.Join(t.TempDir(), "netmon")
+	if err := os.WriteFile(exe, []byte("old binary"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	u, err := FindUpdate(context.Background(), "kostyay", "netmon", "v1.0.0", "linux", "amd64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Apply(context.Background(), u, exe)
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("Apply() err = %v, want checksum mismatch", err)
+	}
+	if got, _ := os.ReadFile(exe); string(got) != "old binary" {
+		t.Errorf("binary = %q, want untouched after failed verification", got)
+	}
+}
+
+func TestChecksumFor(t *testing.T) {
+	sums := []byte("AABB  netmon_1.0.0_linux_amd64.tar.gz\nccdd *netmon_1.0.0_darwin_arm64.tar.gz\n")
+	if got, err := checksumFor(sums, "netmon_1.0.0_linux_amd64.tar.gz"); err != nil || got != "aabb" {
+		t.Errorf("checksumFor(linux) = %q, %v", got, err)
+	}
+	if got, err := checksumFor(sums, "netmon_1.0.0_darwin_arm64.tar.gz"); err != nil || got != "ccdd" {
+		t.Errorf("checksumFor(binary-mode entry) = %q, %v", got, err)
+	}
+	if _, err := checksumFor(sums, "missing.tar.gz"); err == nil {
+		t.Error("checksumFor(missing) should fail")
+	}
+}
+
+func TestExtractBinary_Missing(t *testing.T) {
+	archive := makeArchive(t, map[string]string{"LICENSE": "MIT"})
+	if _, err := extractBinary(archive, "netmon"); err == nil {
+		t.Error("extractBinary() without the binary should fail")
+	}
+}
@@ -0,0 +1,87 @@
+// Package security looks up the security context of a process: its MAC label
+// (AppArmor/SELinux on Linux), sandboxing, and code signing team on macOS.
+// It helps judge how much to trust an unfamiliar process holding connections.
+package security
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Context describes the confinement of a single process.
+type Context struct {
+	Kind      string // "apparmor", "selinux", "codesign", or "" if unknown
+	Label     string // profile/type, e.g. "firefox (enforce)", "httpd_t", "unsigned"
+	TeamID    string // macOS code signing team identifier
+	Sandboxed bool   // seccomp filter (Linux) or App Sandbox entitlement (macOS)
+}
+
+// String returns a compact summary like "apparmor:firefox (enforce) sandboxed".
+// Returns "-" when nothing is known.
+func (c Context) String() string {
+	var parts []string
+	if c.Label != "" {
+		if c.Kind != "" && c.Kind != "codesign" {
+			parts = append(parts, c.Kind+":"+c.Label)
+		} else {
+			parts = append(parts, c.Label)
+		}
+	}
+	if c.TeamID != "" {
+		parts = append(parts, "team:"+c.TeamID)
+	}
+	if c.Sandboxed {
+		parts = append(parts, "sandboxed")
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, " ")
+}
+
+// parseLSMLabel classifies the contents of /proc/<pid>/attr/current.
+// AppArmor labels look like "unconfined" or "/usr/bin/foo (enforce)";
+// SELinux labels look like "user:role:type:level", of which the type is kept.
+func parseLSMLabel(raw string) (kind, label string) {
+	label = strings.TrimSpace(strings.TrimRight(raw, "\x00\n"))
+	if label == "" {
+		return "", ""
+	}
+	if parts := strings.Split(label, ":"); len(parts) >= 3 && !strings.Contains(label, " (") {
+		return "selinux", parts[2]
+	}
+	return "apparmor", label
+}
+
+// parseSeccompMode returns the Seccomp mode from /proc/<pid>/status (0 = disabled).
+func parseSeccompMode(status string) int {
+	for _, line := range strings.Split(status, "\n") {
+		if v, ok := strings.CutPrefix(line, "Seccomp:"); ok {
+			mode, _ := strconv.Atoi(strings.TrimSpace(v))
+			return mode
+		}
+	}
+	return 0
+}
+
+// parseCodesign extracts signing info from `codesign -dv` output (written to stderr).
+func parseCodesign(out string) (teamID, label string) {
+	if strings.Contains(out, "not signed at all") {
+		return "", "unsigned"
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if v, ok := strings.CutPrefix(line, "TeamIdentifier="); ok && v != "not set" {
+			teamID = strings.TrimSpace(v)
+		}
+		if strings.TrimSpace(line) == "Signature=adhoc" {
+			label = "adhoc"
+		}
+	}
+	return teamID, label
+}
+
+// hasSandboxEntitlement reports whether an entitlements plist enables the App Sandbox.
+func hasSandboxEntitlement(plist string) bool {
+	_, after, ok := strings.Cut(plist, "<key>com.apple.security.app-sandbox</key>")
+	return ok && strings.HasPrefix(strings.TrimSpace(after), "<true/>")
+}
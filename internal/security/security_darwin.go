@@ -0,0 +1,55 @@
+//go:build darwin
+
+package security
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// codesignCache caches results per executable path (signatures don't change while running).
+var (
+	codesignCache   = make(map[string]Context)
+	codesignCacheMu sync.Mutex
+)
+
+// Lookup returns the code signing context of exe. pid is unused on macOS.
+func Lookup(pid int32, exe string) (Context, error) {
+	if exe == "" {
+		return Context{}, nil
+	}
+
+	codesignCacheMu.Lock()
+	cached, ok := codesignCache[exe]
+	codesignCacheMu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	// codesign writes signing details to stderr
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "codesign", "-dv", "--verbose=2", exe)
+	cmd.Stderr = &stderr
+	_ = cmd.Run() // exits non-zero for unsigned binaries; output is still useful
+
+	result := Context{Kind: "codesign"}
+	result.TeamID, result.Label = parseCodesign(stderr.String())
+
+	if result.Label != "unsigned" {
+		ents, err := exec.CommandContext(ctx, "codesign", "-d", "--entitlements", "-", "--xml", exe).Output()
+		if err == nil {
+			result.Sandboxed = hasSandboxEntitlement(string(ents))
+		}
+	}
+
+	codesignCacheMu.Lock()
+	codesignCache[exe] = result
+	codesignCacheMu.Unlock()
+	return result, nil
+}
@@ -0,0 +1,25 @@
+//go:build linux
+
+package security
+
+import (
+	"fmt"
+	"os"
+)
+
+// Lookup returns the security context of pid. exe is unused on Linux.
+func Lookup(pid int32, exe string) (Context, error) {
+	var ctx Context
+
+	// attr/current may be missing or unreadable when no LSM is active
+	if raw, err := os.ReadFile(fmt.Sprintf("/proc/%d/attr/current", pid)); err == nil {
+		ctx.Kind, ctx.Label = parseLSMLabel(string(raw))
+	}
+
+	status, err := os.ReadFile(fmt.Sprintf("/proc/%d/status", pid))
+	if err != nil {
+		return ctx, err
+	}
+	ctx.Sandboxed = parseSeccompMode(string(status)) > 0
+	return ctx, nil
+}
@@ -0,0 +1,20 @@
+//go:build linux
+
+package security
+
+import (
+	"os"
+	"testing"
+)
+
+func TestLookup_Self(t *testing.T) {
+	if _, err := Lookup(int32(os.Getpid()), ""); err != nil {
+		t.Fatalf("Lookup(self) error: %v", err)
+	}
+}
+
+func TestLookup_MissingPID(t *testing.T) {
+	if _, err := Lookup(-1, ""); err == nil {
+		t.Error("expected error for nonexistent PID")
+	}
+}
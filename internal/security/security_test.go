@@ -0,0 +1,85 @@
+package security
+
+import "testing"
+
+func TestParseLSMLabel(t *testing.T) {
+	tests := []struct {
+		raw       string
+		wantKind  string
+		wantLabel string
+	}{
+		{"", "", ""},
+		{"unconfined\n", "apparmor", "unconfined"},
+		{"/usr/sbin/cupsd (enforce)\n", "apparmor", "/usr/sbin/cupsd (enforce)"},
+		{"firefox (complain)", "apparmor", "firefox (complain)"},
+		{"system_u:system_r:httpd_t:s0\x00", "selinux", "httpd_t"},
+		{"unconfined_u:unconfined_r:unconfined_t:s0-s0:c0.c1023", "selinux", "unconfined_t"},
+	}
+	for _, tt := range tests {
+		kind, label := parseLSMLabel(tt.raw)
+		if kind != tt.wantKind || label != tt.wantLabel {
+			t.Errorf("parseLSMLabel(%q) = (%q, %q), want (%q, %q)", tt.raw, kind, label, tt.wantKind, tt.wantLabel)
+		}
+	}
+}
+
+func TestParseSeccompMode(t *testing.T) {
+	status := "Name:\tchrome\nNoNewPrivs:\t1\nSeccomp:\t2\nSeccomp_filters:\t3\n"
+	if got := parseSeccompMode(status); got != 2 {
+		t.Errorf("parseSeccompMode = %d, want 2", got)
+	}
+	if got := parseSeccompMode("Name:\tbash\n"); got != 0 {
+		t.Errorf("parseSeccompMode without field = %d, want 0", got)
+	}
+}
+
+func TestParseCodesign(t *testing.T) {
+	tests := []struct {
+		name      string
+		out       string
+		wantTeam  string
+		wantLabel string
+	}{
+		{"unsigned", "/tmp/a.out: code object is not signed at all\n", "", "unsigned"},
+		{"team", "Executable=/Applications/Slack.app\nAuthority=Developer ID Application: Slack\nTeamIdentifier=BQR82RBBHL\n", "BQR82RBBHL", ""},
+		{"adhoc", "Signature=adhoc\nTeamIdentifier=not set\n", "", "adhoc"},
+		{"platform", "Authority=Software Signing\nTeamIdentifier=not set\n", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			team, label := parseCodesign(tt.out)
+			if team != tt.wantTeam || label != tt.wantLabel {
+				t.Errorf("parseCodesign = (%q, %q), want (%q, %q)", team, label, tt.wantTeam, tt.wantLabel)
+			}
+		})
+	}
+}
+
+func TestHasSandboxEntitlement(t *testing.T) {
+	on := "<dict><key>com.apple.security.app-sandbox</key>\n\t<true/></dict>"
+	off := "<dict><key>com.apple.security.app-sandbox</key><false/></dict>"
+	if !hasSandboxEntitlement(on) {
+		t.Error("expected sandbox entitlement to be detected")
+	}
+	if hasSandboxEntitlement(off) || hasSandboxEntitlement("<dict></dict>") {
+		t.Error("expected no sandbox entitlement")
+	}
+}
+
+func TestContextString(t *testing.T) {
+	tests := []struct {
+		ctx  Context
+		want string
+	}{
+		{Context{}, "-"},
+		{Context{Kind: "apparmor", Label: "unconfined"}, "apparmor:unconfined"},
+		{Context{Kind: "selinux", Label: "httpd_t", Sandboxed: true}, "selinux:httpd_t sandboxed"},
+		{Context{Kind: "codesign", TeamID: "BQR82RBBHL", Sandboxed: true}, "team:BQR82RBBHL sandboxed"},
+		{Context{Kind: "codesign", Label: "unsigned"}, "unsigned"},
+	}
+	for _, tt := range tests {
+		if got := tt.ctx.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.ctx, got, tt.want)
+		}
+	}
+}
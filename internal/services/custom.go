@@ -0,0 +1,46 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// custom holds the user's port labels. Like the theme it is process-wide,
+// set once at startup; it takes precedence over commonServices.
+var custom map[serviceKey]string
+
+// SetCustom installs user port labels keyed by "PORT" (TCP and UDP) or
+// "PORT/tcp", "PORT/udp", e.g. {"9200": "elasticsearch", "15432/tcp":
+// "pgbouncer-staging"}. A nil map removes them. On error nothing changes.
+func SetCustom(labels map[string]string) error {
+	parsed := make(map[serviceKey]string, len(labels))
+	for key, label := range labels {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			return fmt.Errorf("port label %q: empty label", key)
+		}
+		portStr, proto, hasProto := strings.Cut(key, "/")
+		port, err := strconv.Atoi(portStr)
+		if err != nil || port < 1 || port > 65535 {
+			return fmt.Errorf("port label %q: invalid port", key)
+		}
+		protos := []string{"tcp", "udp"}
+		if hasProto {
+			proto = strings.ToLower(proto)
+			if proto != "tcp" && proto != "udp" {
+				return fmt.Errorf("port label %q: protocol must be tcp or udp", key)
+			}
+			protos = []string{proto}
+		}
+		for _, p := range protos {
+			k := serviceKey{port, p}
+			// A protocol-specific entry wins over a "PORT" entry for both.
+			if _, exists := parsed[k]; !exists || hasProto {
+				parsed[k] = label
+			}
+		}
+	}
+	custom = parsed
+	return nil
+}
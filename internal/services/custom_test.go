@@ -0,0 +1,69 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetCustom(t *testing.T) {
+	t.Cleanup(func() { _ = SetCustom(nil) })
+
+	err := SetCustom(map[string]string{
+		"9200":      "es-prod",
+		"15432":     "pgbouncer-staging",
+		"15432/udp": "pgb-metrics",
+		"5353/UDP":  "mdns-relay",
+	})
+	if err != nil {
+		t.Fatalf("SetCustom: %v", err)
+	}
+	tests := []struct {
+		port  int
+		proto string
+		want  string
+	}{
+		{9200, "tcp", "es-prod"}, // overrides the built-in name
+		{9200, "udp", "es-prod"},
+		{15432, "tcp", "pgbouncer-staging"},
+		{15432, "udp", "pgb-metrics"}, // protocol-specific entry wins
+		{5353, "udp", "mdns-relay"},
+		{5353, "tcp", ""},
+		{22, "tcp", "ssh"}, // built-ins still apply
+	}
+	for _, tt := range tests {
+		if got := Lookup(tt.port, tt.proto); got != tt.want {
+			t.Errorf("Lookup(%d, %s) = %q, want %q", tt.port, tt.proto, got, tt.want)
+		}
+	}
+	if got := LookupTCP(15432); got != "pgbouncer-staging" {
+		t.Errorf("LookupTCP = %q", got)
+	}
+
+	if err := SetCustom(nil); err != nil || Lookup(9200, "tcp") != "elasticsearch" {
+		t.Errorf("SetCustom(nil) should restore built-ins, got %q, %v", Lookup(9200, "tcp"), err)
+	}
+}
+
+func TestSetCustom_Errors(t *testing.T) {
+	t.Cleanup(func() { _ = SetCustom(nil) })
+	_ = SetCustom(map[string]string{"8081": "kept"})
+
+	for key, want := range map[string]string{
+		"http":     "invalid port",
+		"70000":    "invalid port",
+		"9200/sct": "tcp or udp",
+		"9201":     "empty label",
+	} {
+		label := "x"
+		if key == "9201" {
+			label = " "
+		}
+		err := SetCustom(map[string]string{key: label})
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("SetCustom(%q) = %v, want error containing %q", key, err, want)
+		}
+	}
+	if got := LookupTCP(8081); got != "kept" {
+		t.Errorf("failed SetCustom should keep the previous labels, got %q", got)
+	}
+}
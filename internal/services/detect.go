@@ -0,0 +1,103 @@
+package services
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// protocolPorts maps well-known server ports to the application protocol
+// spoken on them. Unlike commonServices these are wire protocols, so 443
+// is "TLS" rather than "https".
+var protocolPorts = map[serviceKey]string{
+	{21, "tcp"}:    "FTP",
+	{22, "tcp"}:    "SSH",
+	{25, "tcp"}:    "SMTP",
+	{53, "tcp"}:    "DNS",
+	{53, "udp"}:    "DNS",
+	{80, "tcp"}:    "HTTP",
+	{123, "udp"}:   "NTP",
+	{143, "tcp"}:   "IMAP",
+	{443, "tcp"}:   "TLS",
+	{443, "udp"}:   "QUIC",
+	{465, "tcp"}:   "TLS",
+	{587, "tcp"}:   "SMTP",
+	{636, "tcp"}:   "TLS",
+	{853, "tcp"}:   "TLS",
+	{993, "tcp"}:   "TLS",
+	{995, "tcp"}:   "TLS",
+	{1433, "tcp"}:  "TDS",
+	{3306, "tcp"}:  "MySQL",
+	{3389, "tcp"}:  "RDP",
+	{4222, "tcp"}:  "NATS",
+	{5222, "tcp"}:  "XMPP",
+	{5353, "udp"}:  "mDNS",
+	{5432, "tcp"}:  "PostgreSQL",
+	{5672, "tcp"}:  "AMQP",
+	{6379, "tcp"}:  "Redis",
+	{6443, "tcp"}:  "TLS",
+	{8080, "tcp"}:  "HTTP",
+	{8443, "tcp"}:  "TLS",
+	{9092, "tcp"}:  "Kafka",
+	{9200, "tcp"}:  "HTTP",
+	{11211, "tcp"}: "Memcached",
+	{27017, "tcp"}: "MongoDB",
+}
+
+// serverProcesses maps server executables to the protocol they speak on any
+// port, for servers moved off their default port.
+var serverProcesses = map[string]string{
+	"sshd":            "SSH",
+	"ssh":             "SSH",
+	"postgres":        "PostgreSQL",
+	"redis-server":    "Redis",
+	"valkey-server":   "Redis",
+	"mysqld":          "MySQL",
+	"mariadbd":        "MySQL",
+	"mongod":          "MongoDB",
+	"memcached":       "Memcached",
+	"nats-server":     "NATS",
+	"rabbitmq-server": "AMQP",
+}
+
+// browsers negotiate HTTP/2 over TLS and HTTP/3 over QUIC.
+var browsers = []string{"chrome", "chromium", "firefox", "safari", "msedge", "brave", "vivaldi"}
+
+// Detect guesses the application protocol of a connection, e.g. "TLS",
+// "HTTP/2", "SSH" or "PostgreSQL". It uses the remote port (outgoing
+// connections), then the local port (listeners and incoming connections),
+// then the owning process for servers on non-default ports. Browsers upgrade
+// TLS to "HTTP/2" and QUIC to "HTTP/3". process may be a name or an
+// executable path. Returns "" when nothing matches; payload is never read.
+func Detect(proto string, localPort, remotePort int, process string) string {
+	proto = strings.ToLower(proto)
+	name := strings.ToLower(filepath.Base(process))
+
+	label := protocolPorts[serviceKey{remotePort, proto}]
+	if label == "" {
+		label = protocolPorts[serviceKey{localPort, proto}]
+	}
+	if label == "" && proto == "tcp" {
+		label = serverProcesses[name]
+	}
+
+	if isBrowser(name) {
+		switch label {
+		case "TLS":
+			return "HTTP/2"
+		case "QUIC":
+			return "HTTP/3"
+		}
+	}
+	return label
+}
+
+// isBrowser reports whether name looks like a web browser process
+// ("Google Chrome Helper", "firefox-bin").
+func isBrowser(name string) bool {
+	for _, b := range browsers {
+		if strings.Contains(name, b) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package services
+
+import "testing"
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name    string
+		proto   string
+		local   int
+		remote  int
+		process string
+		want    string
+	}{
+		{"outgoing TLS", "TCP", 52341, 443, "curl", "TLS"},
+		{"browser TLS is HTTP/2", "TCP", 52341, 443, "Google Chrome Helper", "HTTP/2"},
+		{"browser QUIC is HTTP/3", "UDP", 52341, 443, "firefox", "HTTP/3"},
+		{"non-browser QUIC", "UDP", 52341, 443, "curl", "QUIC"},
+		{"listener by local port", "TCP", 5432, 0, "postgres", "PostgreSQL"},
+		{"incoming ssh", "TCP", 22, 60000, "sshd", "SSH"},
+		{"server on custom port", "TCP", 6380, 0, "redis-server", "Redis"},
+		{"exe path", "TCP", 2222, 0, "/usr/sbin/sshd", "SSH"},
+		{"remote port wins", "TCP", 8080, 6379, "app", "Redis"},
+		{"unknown", "TCP", 52341, 12345, "app", ""},
+		{"process hint is TCP only", "UDP", 5000, 5001, "sshd", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Detect(tt.proto, tt.local, tt.remote, tt.process); got != tt.want {
+				t.Errorf("Detect(%q, %d, %d, %q) = %q, want %q", tt.proto, tt.local, tt.remote, tt.process, got, tt.want)
+			}
+		})
+	}
+}
@@ -61,18 +61,22 @@ var commonServices = map[serviceKey]string{
 	{27017, "tcp"}: "mongodb",
 }
 
-// Lookup returns the service name for a port/protocol combination.
+// Lookup returns the service name for a port/protocol combination: the
+// user's label (SetCustom) if any, else the well-known name.
 // Returns empty string if not found.
 func Lookup(port int, proto string) string {
+	if name, ok := custom[serviceKey{port, proto}]; ok {
+		return name
+	}
 	return commonServices[serviceKey{port, proto}]
 }
 
 // LookupTCP returns the service name for a TCP port.
 func LookupTCP(port int) string {
-	return commonServices[serviceKey{port, "tcp"}]
+	return Lookup(port, "tcp")
 }
 
 // LookupUDP returns the service name for a UDP port.
 func LookupUDP(port int) string {
-	return commonServices[serviceKey{port, "udp"}]
+	return Lookup(port, "udp")
 }
@@ -0,0 +1,200 @@
+// Package sockwatch detects changes to the kernel socket table quickly and
+// cheaply, so the UI can refresh as soon as connections open or close instead
+// of waiting for the next poll.
+//
+// The kernel multicasts sock_diag events only for destroyed TCP/UDP sockets,
+// not for new ones, so the watcher takes short inet_diag dumps (a few hundred
+// microseconds even with thousands of sockets) and compares an
+// order-independent fingerprint. A destroy notification triggers a dump right
+// away, so closed connections are noticed without waiting for the next tick.
+// Only a change triggers the expensive /proc walk in the collector.
+package sockwatch
+
+import (
+	"errors"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/kostyay/netmon/internal/netlink"
+)
+
+// DefaultInterval is how often the socket table is fingerprinted.
+const DefaultInterval = 200 * time.Millisecond
+
+// Event reports a socket table change, or a failed dump when Err is set.
+type Event struct {
+	Err error
+}
+
+// Watcher polls the socket table and emits an Event when it changes.
+// Events are coalesced: a slow consumer sees at most one pending event.
+type Watcher struct {
+	interval time.Duration
+	list     func() ([]netlink.Socket, error)
+	listen   func() (listener, error)
+	events   chan Event
+	done     chan struct{}
+
+	mu      sync.Mutex
+	started bool
+	stopped bool
+}
+
+// listener is the source of destroy notifications (replaced in tests).
+type listener interface {
+	Read() ([]netlink.Socket, error)
+	Close() error
+}
+
+// New returns a Watcher backed by netlink inet_diag dumps and sock_diag
+// destroy notifications (Linux only; Start fails with netlink.ErrUnsupported
+// elsewhere).
+func New(interval time.Duration) *Watcher {
+	w := newWatcher(interval, netlink.ListSockets)
+	w.listen = func() (listener, error) {
+		l, err := netlink.ListenDestroyed()
+		if err != nil {
+			return nil, err
+		}
+		return l, nil
+	}
+	return w
+}
+
+func newWatcher(interval time.Duration, list func() ([]netlink.Socket, error)) *Watcher {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Watcher{
+		interval: interval,
+		list:     list,
+		events:   make(chan Event, 1),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start takes the initial fingerprint and begins watching in the background.
+// It returns the error of the initial dump, in which case nothing is started.
+// Starting a stopped or already running watcher is a no-op.
+func (w *Watcher) Start() error {
+	sockets, err := w.list()
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stopped || w.started {
+		return nil
+	}
+	w.started = true
+	closed := make(chan struct{}, 1)
+	if w.listen != nil {
+		// Without the subscription, closes are still seen on the next tick
+		if l, err := w.listen(); err == nil {
+			go w.watchClosed(l, closed)
+		}
+	}
+	go w.run(fingerprintOf(sockets), closed)
+	return nil
+}
+
+// Events returns the channel of change notifications. It is closed by Stop.
+func (w *Watcher) Events() <-chan Event {
+	return w.events
+}
+
+// Stop ends watching and closes the Events channel. Safe to call more than once.
+func (w *Watcher) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stopped {
+		return
+	}
+	w.stopped = true
+	close(w.done)
+	if !w.started {
+		close(w.events) // no run loop to close it
+	}
+}
+
+func (w *Watcher) run(last fingerprint, closed <-chan struct{}) {
+	defer close(w.events)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+		case <-closed:
+		}
+		sockets, err := w.list()
+		if err != nil {
+			w.emit(Event{Err: err})
+			continue
+		}
+		fp := fingerprintOf(sockets)
+		if fp != last {
+			last = fp
+			w.emit(Event{})
+		}
+	}
+}
+
+// watchClosed signals closed, coalesced, whenever the kernel reports
+// destroyed sockets, until the watcher stops or the subscription fails.
+func (w *Watcher) watchClosed(l listener, closed chan<- struct{}) {
+	defer func() { _ = l.Close() }()
+	for {
+		select {
+		case <-w.done:
+			return
+		default:
+		}
+		sockets, err := l.Read()
+		if err != nil && !errors.Is(err, unix.ENOBUFS) {
+			return
+		}
+		// Lost notifications (ENOBUFS) mean something closed as well
+		if len(sockets) > 0 || err != nil {
+			select {
+			case closed <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+// emit queues ev unless an event is already pending.
+func (w *Watcher) emit(ev Event) {
+	select {
+	case w.events <- ev:
+	default:
+	}
+}
+
+// fingerprint summarizes a socket table independently of dump order.
+type fingerprint struct {
+	count int
+	sum   uint64
+}
+
+// fingerprintOf hashes each socket's protocol, endpoints and state. Summing
+// the hashes makes the result independent of the order the kernel returns them.
+func fingerprintOf(sockets []netlink.Socket) fingerprint {
+	fp := fingerprint{count: len(sockets)}
+	h := fnv.New64a()
+	for _, s := range sockets {
+		h.Reset()
+		local, _ := s.ID.Local.MarshalBinary()
+		remote, _ := s.ID.Remote.MarshalBinary()
+		h.Write([]byte{s.Protocol, s.State})
+		h.Write(local)
+		h.Write(remote)
+		fp.sum += h.Sum64()
+	}
+	return fp
+}
@@ -0,0 +1,204 @@
+package sockwatch
+
+import (
+	"errors"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kostyay/netmon/internal/netlink"
+)
+
+func sock(local, remote string, state uint8) netlink.Socket {
+	return netlink.Socket{
+		ID: netlink.SocketID{
+			Local:  netip.MustParseAddrPort(local),
+			Remote: netip.MustParseAddrPort(remote),
+		},
+		Protocol: 6,
+		State:    state,
+	}
+}
+
+func TestFingerprintOf_OrderIndependent(t *testing.T) {
+	a := sock("10.0.0.1:5000", "1.1.1.1:443", 1)
+	b := sock("10.0.0.1:5001", "8.8.8.8:53", 1)
+	if fingerprintOf([]netlink.Socket{a, b}) != fingerprintOf([]netlink.Socket{b, a}) {
+		t.Error("fingerprint should not depend on dump order")
+	}
+}
+
+func TestFingerprintOf_DetectsChanges(t *testing.T) {
+	base := []netlink.Socket{sock("10.0.0.1:5000", "1.1.1.1:443", 1)}
+	fp := fingerprintOf(base)
+
+	tests := []struct {
+		name    string
+		sockets []netlink.Socket
+	}{
+		{"new connection", append(base[:1:1], sock("10.0.0.1:5001", "1.1.1.1:443", 1))},
+		{"closed connection", nil},
+		{"state change", []netlink.Socket{sock("10.0.0.1:5000", "1.1.1.1:443", 8)}},
+		{"different port", []netlink.Socket{sock("10.0.0.1:5002", "1.1.1.1:443", 1)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if fingerprintOf(tt.sockets) == fp {
+				t.Error("fingerprint should change")
+			}
+		})
+	}
+}
+
+// fakeTable is a socket table that tests can mutate between dumps.
+type fakeTable struct {
+	mu      sync.Mutex
+	sockets []netlink.Socket
+	err     error
+}
+
+func (f *fakeTable) list() ([]netlink.Socket, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.sockets, f.err
+}
+
+func (f *fakeTable) set(sockets []netlink.Socket, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sockets, f.err = sockets, err
+}
+
+func waitEvent(t *testing.T, w *Watcher) Event {
+	t.Helper()
+	select {
+	case ev, ok := <-w.Events():
+		if !ok {
+			t.Fatal("events channel closed")
+		}
+		return ev
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+	return Event{}
+}
+
+func TestWatcher_EmitsOnChange(t *testing.T) {
+	table := &fakeTable{sockets: []netlink.Socket{sock("10.0.0.1:5000", "1.1.1.1:443", 1)}}
+	w := newWatcher(5*time.Millisecond, table.list)
+	if err := w.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer w.Stop()
+
+	select {
+	case ev := <-w.Events():
+		t.Fatalf("unexpected event before any change: %+v", ev)
+	case <-time.After(30 * time.Millisecond):
+	}
+
+	table.set(nil, nil)
+	if ev := waitEvent(t, w); ev.Err != nil {
+		t.Errorf("Err = %v, want nil", ev.Err)
+	}
+
+	dumpErr := errors.New("netlink: permission denied")
+	table.set(nil, dumpErr)
+	if ev := waitEvent(t, w); !errors.Is(ev.Err, dumpErr) {
+		t.Errorf("Err = %v, want %v", ev.Err, dumpErr)
+	}
+}
+
+func TestWatcher_StartFailsOnInitialDump(t *testing.T) {
+	table := &fakeTable{err: netlink.ErrUnsupported}
+	w := newWatcher(time.Millisecond, table.list)
+	if err := w.Start(); !errors.Is(err, netlink.ErrUnsupported) {
+		t.Errorf("Start() = %v, want ErrUnsupported", err)
+	}
+}
+
+func TestWatcher_StopClosesEvents(t *testing.T) {
+	table := &fakeTable{}
+	w := newWatcher(time.Millisecond, table.list)
+	if err := w.Start(); err != nil {
+		t.Fatal(err)
+	}
+	w.Stop()
+	w.Stop() // idempotent
+
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case _, ok := <-w.Events():
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatal("events channel not closed after Stop")
+		}
+	}
+}
+
+func TestWatcher_StopBeforeStart(t *testing.T) {
+	w := newWatcher(time.Millisecond, (&fakeTable{}).list)
+	w.Stop()
+	if _, ok := <-w.Events(); ok {
+		t.Error("events should be closed when stopped before Start")
+	}
+	if err := w.Start(); err != nil {
+		t.Errorf("Start after Stop = %v, want nil no-op", err)
+	}
+}
+
+// fakeListener reports one batch of destroyed sockets once fire is closed.
+type fakeListener struct {
+	fire chan struct{}
+	sent bool
+}
+
+func (l *fakeListener) Read() ([]netlink.Socket, error) {
+	if l.sent {
+		time.Sleep(time.Millisecond)
+		return nil, nil
+	}
+	select {
+	case <-l.fire:
+		l.sent = true
+		return []netlink.Socket{sock("10.0.0.1:5000", "1.1.1.1:443", 7)}, nil
+	case <-time.After(time.Millisecond):
+		return nil, nil
+	}
+}
+
+func (l *fakeListener) Close() error { return nil }
+
+func TestWatcher_DumpsOnDestroyNotification(t *testing.T) {
+	table := &fakeTable{sockets: []netlink.Socket{sock("10.0.0.1:5000", "1.1.1.1:443", 1)}}
+	l := &fakeListener{fire: make(chan struct{})}
+	w := newWatcher(time.Hour, table.list) // no tick during the test
+	w.listen = func() (listener, error) { return l, nil }
+	if err := w.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+
+	table.set(nil, nil)
+	close(l.fire)
+	if ev := waitEvent(t, w); ev.Err != nil {
+		t.Errorf("Err = %v, want nil", ev.Err)
+	}
+}
+
+func TestWatcher_PollsWithoutDestroySubscription(t *testing.T) {
+	table := &fakeTable{sockets: []netlink.Socket{sock("10.0.0.1:5000", "1.1.1.1:443", 1)}}
+	w := newWatcher(5*time.Millisecond, table.list)
+	w.listen = func() (listener, error) { return nil, netlink.ErrUnsupported }
+	if err := w.Start(); err != nil {
+		t.Fatalf("Start = %v, a failed subscription should fall back to polling", err)
+	}
+	defer w.Stop()
+
+	table.set(nil, nil)
+	waitEvent(t, w)
+}
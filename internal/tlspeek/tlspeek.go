@@ -0,0 +1,73 @@
+// Package tlspeek fetches the certificate chain a TLS endpoint presents, to
+// identify the service behind an opaque IP. It only performs the handshake and
+// never sends application data.
+package tlspeek
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"time"
+)
+
+// Cert describes one certificate of the presented chain.
+type Cert struct {
+	Subject  string
+	Issuer   string
+	DNSNames []string
+	NotAfter time.Time
+}
+
+// Result is what an endpoint presented. The chain is read without verification
+// (peeking at an IP has no name to verify against); VerifyErr reports whether
+// it chains to a system root.
+type Result struct {
+	Addr      string
+	Version   string // negotiated TLS version, e.g. "TLS 1.3"
+	Chain     []Cert // leaf first
+	VerifyErr error
+	At        time.Time
+}
+
+// Peek performs a TLS handshake with addr ("host:port") without SNI and returns
+// the presented chain.
+func Peek(ctx context.Context, addr string) (Result, error) {
+	// #nosec G402 - the chain is only displayed, no data is sent
+	dialer := &tls.Dialer{Config: &tls.Config{InsecureSkipVerify: true}}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return Result{}, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	state := conn.(*tls.Conn).ConnectionState()
+	if len(state.PeerCertificates) == 0 {
+		return Result{}, errors.New("no certificate presented")
+	}
+	res := Result{
+		Addr:      addr,
+		Version:   tls.VersionName(state.Version),
+		VerifyErr: verify(state.PeerCertificates),
+		At:        time.Now(),
+	}
+	for _, c := range state.PeerCertificates {
+		res.Chain = append(res.Chain, Cert{
+			Subject:  c.Subject.String(),
+			Issuer:   c.Issuer.String(),
+			DNSNames: c.DNSNames,
+			NotAfter: c.NotAfter,
+		})
+	}
+	return res, nil
+}
+
+// verify checks the chain against the system roots, ignoring the host name.
+func verify(certs []*x509.Certificate) error {
+	intermediates := x509.NewCertPool()
+	for _, c := range certs[1:] {
+		intermediates.AddCert(c)
+	}
+	_, err := certs[0].Verify(x509.VerifyOptions{Intermediates: intermediates})
+	return err
+}
@@ -0,0 +1,47 @@
+package tlspeek
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPeek(t *testing.T) {
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer srv.Close()
+	addr := strings.TrimPrefix(srv.URL, "https://")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	res, err := Peek(ctx, addr)
+	if err != nil {
+		t.Fatalf("Peek: %v", err)
+	}
+	if res.Addr != addr || len(res.Chain) == 0 {
+		t.Fatalf("result = %+v", res)
+	}
+	leaf := res.Chain[0]
+	if !strings.Contains(leaf.Subject, "Acme Co") || leaf.NotAfter.IsZero() {
+		t.Errorf("leaf = %+v, want the httptest certificate", leaf)
+	}
+	if !strings.HasPrefix(res.Version, "TLS 1.") {
+		t.Errorf("version = %q", res.Version)
+	}
+	if res.VerifyErr == nil {
+		t.Error("self-signed test certificate should not verify against system roots")
+	}
+}
+
+func TestPeek_NotTLS(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := Peek(ctx, strings.TrimPrefix(srv.URL, "http://")); err == nil {
+		t.Error("plain HTTP endpoint should fail the handshake")
+	}
+}
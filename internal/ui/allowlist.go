@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kostyay/netmon/internal/allowlist"
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// WithAllowList returns a copy of the model that highlights and counts the
+// outbound connections outside allowed, loaded from path (shown in the header).
+func (m Model) WithAllowList(allowed *allowlist.List, path string) Model {
+	m.allowList = allowed
+	m.allowFile = path
+	m.refreshAllowlist()
+	return m
+}
+
+// refreshAllowlist recomputes the violations for the current snapshot, using
+// the reverse DNS names resolved so far for wildcard domains.
+func (m *Model) refreshAllowlist() {
+	if m.allowList == nil {
+		return
+	}
+	m.violations = allowlist.Violations(m.snapshot, m.allowList, m.dnsCache)
+	m.allowOutside = make(map[ConnectionKey]bool, len(m.violations))
+	for _, v := range m.violations {
+		m.allowOutside[ConnectionKey{
+			PID:        v.PID,
+			Protocol:   model.Protocol(v.Protocol),
+			LocalAddr:  v.LocalAddr,
+			RemoteAddr: v.RemoteAddr,
+		}] = true
+	}
+}
+
+// outsideAllowlist reports whether conn is a violation of the loaded
+// allowlist; always false without one.
+func (m Model) outsideAllowlist(conn model.Connection) bool {
+	return m.allowOutside[KeyFromConnection(conn)]
+}
+
+// allowListLabel returns the header label for the allowlist, e.g.
+// "allow.txt: 3 outside", or "" without one.
+func (m Model) allowListLabel() string {
+	if m.allowList == nil {
+		return ""
+	}
+	name := filepath.Base(m.allowFile)
+	if m.allowFile == "-" {
+		name = "stdin"
+	}
+	return fmt.Sprintf(i18n.T("%s: %d outside"), name, len(m.violations))
+}
+
+// exportViolations writes the current violations to a timestamped JSON file
+// in the working directory.
+func (m *Model) exportViolations() tea.Cmd {
+	if m.allowList == nil {
+		return m.notify(toastWarn, i18n.T("No allowlist loaded (--allow-file)"))
+	}
+	path := "netmon-violations-" + time.Now().Format("20060102-150405") + ".json"
+	if err := writeViolations(path, m.violations); err != nil {
+		return m.notify(toastError, fmt.Sprintf(i18n.T("Export failed: %v"), err))
+	}
+	return m.notify(toastSuccess, fmt.Sprintf(i18n.T("Exported %d violations to %s"), len(m.violations), path))
+}
+
+// writeViolations writes violations to path as JSON.
+func writeViolations(path string, violations []allowlist.Violation) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := allowlist.WriteJSON(f, violations); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kostyay/netmon/internal/allowlist"
+)
+
+func TestAllowList_HighlightsAndCounts(t *testing.T) {
+	allowed, err := allowlist.Parse(strings.NewReader("1.1.1.0/24\n*.example.net\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := chipsTestModel().WithAllowList(allowed, "/etc/netmon/allow.txt")
+
+	chrome := m.snapshot.Applications[0].Connections
+	if m.outsideAllowlist(chrome[0]) || !m.outsideAllowlist(chrome[1]) {
+		t.Errorf("1.1.1.1 should be allowed and 2.2.2.2 outside")
+	}
+	if !m.rowMark(chrome[1]).outside {
+		t.Error("rowMark should carry the violation")
+	}
+	if got, want := renderConnRow("row", chrome[1], false, m.rowMark(chrome[1])), WarnStyle().Render("  row")+"\n"; got != want {
+		t.Errorf("violation row = %q, want the warning style %q", got, want)
+	}
+
+	m.width = 160
+	if header := stripAnsi(m.renderHeader()); !strings.Contains(header, "allow.txt: 2 outside") {
+		t.Errorf("header should count violations, got %q", header)
+	}
+
+	// A reverse DNS name matching a wildcard domain clears the violation.
+	m.dnsCache = map[string]string{"3.3.3.3": "cdn.example.net"}
+	m.refreshAllowlist()
+	if len(m.violations) != 1 || m.violations[0].RemoteAddr != "2.2.2.2:80" {
+		t.Errorf("violations = %+v, want only 2.2.2.2", m.violations)
+	}
+}
+
+func TestAllowList_ExportViolations(t *testing.T) {
+	t.Chdir(t.TempDir())
+	m := chipsTestModel()
+	if cmd := m.exportViolations(); cmd == nil || m.allowList != nil {
+		t.Fatal("export without an allowlist should only warn")
+	}
+
+	allowed, err := allowlist.Parse(strings.NewReader("1.1.1.1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m = m.WithAllowList(allowed, "allow.txt")
+	m.exportViolations()
+
+	files, _ := filepath.Glob("netmon-violations-*.json")
+	if len(files) != 1 {
+		t.Fatalf("exported files = %v, want one", files)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []allowlist.Violation
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Process != "chrome" || got[1].RemoteAddr != "3.3.3.3:443" {
+		t.Errorf("exported %+v, want the chrome and curl violations", got)
+	}
+}
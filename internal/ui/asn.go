@@ -0,0 +1,198 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/asn"
+	"github.com/kostyay/netmon/internal/filter"
+	"github.com/kostyay/netmon/internal/i18n"
+)
+
+// Network breakdown: when the GeoIP table (geoipDatabase) is iptoasn.com's
+// ip2asn-combined, it also names the autonomous system announcing each remote
+// IP. The panel ('N') counts connections per network and asn:N chips filter
+// by it.
+const (
+	asnModalWidth   = 72
+	maxASNRows      = 14
+	maxASNProcesses = 3
+)
+
+// hasASN reports whether the loaded GeoIP table names autonomous systems.
+func (m Model) hasASN() bool {
+	return m.geo != nil && m.geo.HasASN()
+}
+
+// remoteIP parses the IP of a remote address.
+func remoteIP(remoteAddr string) (netip.Addr, bool) {
+	ip, err := netip.ParseAddr(strings.Trim(extractIP(remoteAddr), "[]"))
+	return ip, err == nil
+}
+
+// remoteASN returns the number of the autonomous system announcing a remote
+// address, 0 when unknown or without AS data.
+func (m Model) remoteASN(remoteAddr string) uint32 {
+	if !m.hasASN() {
+		return 0
+	}
+	ip, ok := remoteIP(remoteAddr)
+	if !ok {
+		return 0
+	}
+	info, _ := m.geo.ASN(ip)
+	return info.Number
+}
+
+// toggleASN opens or closes the network breakdown panel.
+func (m Model) toggleASN() (tea.Model, tea.Cmd) {
+	if !m.hasASN() {
+		return m, m.notify(toastWarn, i18n.T("No ASN data: set geoipDatabase to ip2asn-combined.tsv in settings.yaml"))
+	}
+	m.asnMode = !m.asnMode
+	m.asnCursor = 0
+	return m, nil
+}
+
+// asnRow is one autonomous system in the breakdown panel.
+type asnRow struct {
+	asn.Summary
+	processes []string // by connection count, most first
+}
+
+// asnRows counts the connections with a remote IP per autonomous system, most
+// connections first; the asn.Unknown bucket sorts last.
+func (m Model) asnRows() []asnRow {
+	if m.snapshot == nil || !m.hasASN() {
+		return nil
+	}
+	counts := make(map[netip.Addr]int)
+	procs := make(map[asn.Info]map[string]int)
+	for _, app := range m.snapshot.Applications {
+		for _, conn := range app.Connections {
+			ip, ok := remoteIP(conn.RemoteAddr)
+			if !ok {
+				continue
+			}
+			counts[ip]++
+			info, ok := m.geo.ASN(ip)
+			if !ok {
+				info = asn.Unknown
+			}
+			if procs[info] == nil {
+				procs[info] = make(map[string]int)
+			}
+			procs[info][app.Name]++
+		}
+	}
+
+	var rows, unknown []asnRow
+	for _, s := range asn.Summarize(m.geo.ASN, counts) {
+		row := asnRow{Summary: s, processes: topProcesses(procs[s.Info])}
+		if s.Info == asn.Unknown {
+			unknown = append(unknown, row)
+			continue
+		}
+		rows = append(rows, row)
+	}
+	return append(rows, unknown...)
+}
+
+// topProcesses returns the process names by connection count, most first.
+func topProcesses(counts map[string]int) []string {
+	procs := make([]string, 0, len(counts))
+	for name := range counts {
+		procs = append(procs, name)
+	}
+	slices.SortFunc(procs, func(x, y string) int {
+		if c := cmp.Compare(counts[y], counts[x]); c != 0 {
+			return c
+		}
+		return strings.Compare(x, y)
+	})
+	return procs
+}
+
+// handleASNKey handles a key press while the network panel is open: Enter
+// filters to the selected network.
+func (m *Model) handleASNKey(key string) tea.Cmd {
+	rows := m.asnRows()
+	switch {
+	case matchKey(key, KeyEsc, KeyQuit, KeyASN):
+		m.asnMode = false
+	case matchKey(key, KeyUp, KeyUpAlt):
+		if m.asnCursor > 0 {
+			m.asnCursor--
+		}
+	case matchKey(key, KeyDown, KeyDownAlt):
+		if m.asnCursor < len(rows)-1 {
+			m.asnCursor++
+		}
+	case matchKey(key, KeyEnter):
+		if m.asnCursor >= len(rows) || rows[m.asnCursor].Info == asn.Unknown {
+			return nil
+		}
+		row := rows[m.asnCursor]
+		for i := len(m.filterChips) - 1; i >= 0; i-- {
+			if _, ok := filter.ASNNumbers(m.filterChips[i]); ok {
+				m.removeFilterChip(i)
+			}
+		}
+		m.addFilterChip(filter.ASNPrefix + strconv.FormatUint(uint64(row.Number), 10))
+		m.asnMode = false
+		m.clampCursor()
+		return m.notify(toastInfo, fmt.Sprintf(i18n.T("Filter: connections to AS%d %s"), row.Number, row.Org))
+	}
+	return nil
+}
+
+// renderASNModalContent renders the network breakdown: per autonomous system,
+// the connection and host counts and the processes holding the connections.
+func (m Model) renderASNModalContent() string {
+	keyStyle := FooterKeyStyle()
+	descStyle := FooterDescStyle()
+
+	// cursor(2) + network(28) + conns(6) + hosts(6) + separators; processes get the rest
+	const netWidth = 28
+	procWidth := asnModalWidth - 2 - netWidth - 6 - 6 - 6 - 6
+	lines := []string{keyStyle.Render(fmt.Sprintf("  %-*s  %6s  %6s  %s", netWidth, i18n.T("Network"), i18n.T("Conns"), i18n.T("Hosts"), i18n.T("Top processes")))}
+
+	rows := m.asnRows()
+	if len(rows) == 0 {
+		lines = append(lines, descStyle.Render("  "+i18n.T("No connections with a remote IP")))
+	}
+	start := max(0, min(m.asnCursor-maxASNRows+1, len(rows)-maxASNRows))
+	for i := start; i < len(rows) && i < start+maxASNRows; i++ {
+		r := rows[i]
+		procs := r.processes[:min(len(r.processes), maxASNProcesses)]
+		label := fmt.Sprintf("AS%d %s", r.Number, r.Org)
+		if r.Info == asn.Unknown {
+			label = "—"
+		}
+		cursor := "  "
+		if i == m.asnCursor {
+			cursor = "▸ "
+		}
+		line := cursor + fmt.Sprintf("%-*s  %6d  %6d  %s", netWidth, truncateString(label, netWidth), r.Count, r.Hosts,
+			truncateString(strings.Join(procs, ", "), procWidth))
+		if i == m.asnCursor {
+			lines = append(lines, SelectedConnStyle().Render(line))
+		} else {
+			lines = append(lines, descStyle.Render(line))
+		}
+	}
+	if len(rows) > maxASNRows {
+		lines = append(lines, descStyle.Render("  "+fmt.Sprintf(i18n.T("%d networks"), len(rows))))
+	}
+
+	lines = append(lines, "", descStyle.Render(i18n.T("— : private, loopback or not in the GeoIP table.")),
+		"", keyStyle.Render("Enter")+descStyle.Render(" "+i18n.T("Filter to network")+"  ")+
+			keyStyle.Render(KeyASN.Key)+descStyle.Render("/")+keyStyle.Render("Esc")+descStyle.Render(" "+i18n.T("Close")))
+	return strings.Join(lines, "\n")
+}
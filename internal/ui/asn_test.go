@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/asn"
+	"github.com/kostyay/netmon/internal/geoip"
+)
+
+// asnTestModel is chipsTestModel with an ip2asn-combined GeoIP table placing
+// 1.1.1.1 and 2.2.2.2 in AS13335 and 3.3.3.3 in AS16509.
+func asnTestModel(t *testing.T) Model {
+	t.Helper()
+	db, err := geoip.Load(strings.NewReader("1.1.1.0\t1.1.1.255\t13335\tUS\tCLOUDFLARENET\n" +
+		"2.2.2.0\t2.2.2.255\t13335\tUS\tCLOUDFLARENET\n" +
+		"3.3.3.0\t3.3.3.255\t16509\tUS\tAMAZON-02\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := chipsTestModel()
+	m.geo = db
+	return m
+}
+
+func TestASNFilter(t *testing.T) {
+	m := asnTestModel(t)
+
+	m.filterChips = []string{"asn:16509"}
+	if got := appNames(m.filteredApps()); !slices.Equal(got, []string{"curl"}) {
+		t.Errorf("asn:16509 -> %v, want curl", got)
+	}
+	m.filterChips = []string{"ASN:AS13335,16509"}
+	if got := appNames(m.filteredApps()); !slices.Equal(got, []string{"chrome", "curl"}) {
+		t.Errorf("ASN:AS13335,16509 -> %v, want chrome and curl", got)
+	}
+	m.filterChips = []string{"asn:13335"}
+	m.PushView(m.newViewState(LevelConnections, "curl"))
+	if conns := m.filteredConnections(m.snapshot.Applications[1].Connections); len(conns) != 0 {
+		t.Errorf("asn:13335 in curl's connections = %v, want none", conns)
+	}
+}
+
+func TestASNRows(t *testing.T) {
+	m := asnTestModel(t)
+	m.snapshot.Applications[1].Connections = append(m.snapshot.Applications[1].Connections,
+		m.snapshot.Applications[1].Connections[0], m.snapshot.Applications[1].Connections[0])
+	m.snapshot.Applications[1].Connections[2].RemoteAddr = "10.0.0.9:80"
+
+	rows := m.asnRows()
+	var got []string
+	for _, r := range rows {
+		got = append(got, r.Org)
+	}
+	// AMAZON-02 and CLOUDFLARENET both hold 2 connections; ties sort by name, unknown last
+	if !slices.Equal(got, []string{"AMAZON-02", "CLOUDFLARENET", asn.Unknown.Org}) {
+		t.Fatalf("networks = %v, want AMAZON-02, CLOUDFLARENET, then unknown", got)
+	}
+	if rows[1].Count != 2 || rows[1].Hosts != 2 || !slices.Equal(rows[1].processes, []string{"chrome"}) {
+		t.Errorf("CLOUDFLARENET row = %+v, want 2 conns to 2 hosts by chrome", rows[1])
+	}
+}
+
+func TestASNPanel_EnterAddsChip(t *testing.T) {
+	m := asnTestModel(t)
+	m = pressKey(m, "N")
+	if !m.asnMode {
+		t.Fatal("N should open the network panel")
+	}
+	if content := stripAnsi(m.renderASNModalContent()); !strings.Contains(content, "AS13335 CLOUDFLARENET") {
+		t.Errorf("panel missing AS13335:\n%s", content)
+	}
+
+	m = pressKey(m, "j")
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.asnMode || !slices.Equal(m.filterChips, []string{"asn:16509"}) {
+		t.Errorf("after Enter: asnMode = %v, chips = %v; want closed with asn:16509", m.asnMode, m.filterChips)
+	}
+}
+
+func TestASNPanel_NeedsASNData(t *testing.T) {
+	m := chipsTestModel()
+	if m = pressKey(m, "N"); m.asnMode {
+		t.Error("panel should stay closed without a GeoIP table")
+	}
+	db, err := geoip.Load(strings.NewReader("1.1.1.0\t1.1.1.255\tAU\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.geo = db
+	if m = pressKey(m, "N"); m.asnMode {
+		t.Error("panel should stay closed with a country-only table")
+	}
+}
@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"github.com/kostyay/netmon/internal/audit"
+	"github.com/kostyay/netmon/internal/config"
+)
+
+// appendAudit writes an audit log entry (replaced in tests).
+var appendAudit = audit.Append
+
+// auditAction records a process-affecting action in the audit log. Write
+// failures show up in diagnostics; the action itself is not affected.
+func (m *Model) auditAction(e audit.Entry, done, failed int, lastErr error) {
+	if m.auditPath == "" {
+		return
+	}
+	e.Source = "tui"
+	e.Result, e.Error = audit.Outcome(done, failed, lastErr)
+	if err := appendAudit(m.auditPath, e); err != nil {
+		m.recordError(sourceAudit, err)
+	}
+}
+
+// countOutcome converts a single error into done/failed counts for auditAction.
+func countOutcome(err error) (done, failed int) {
+	if err != nil {
+		return 0, 1
+	}
+	return 1, 0
+}
+
+// auditLogPath returns the configured audit log location, or "" if it cannot
+// be resolved.
+func auditLogPath() string {
+	path, err := config.AuditLogPath(config.CurrentSettings)
+	if err != nil {
+		return ""
+	}
+	return path
+}
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/audit"
+)
+
+func TestAudit_KillRecorded(t *testing.T) {
+	origSignal := sendSignal
+	sendSignal = func(int, syscall.Signal) error { return nil }
+	t.Cleanup(func() { sendSignal = origSignal })
+
+	m := chipsTestModel()
+	m.auditPath = filepath.Join(t.TempDir(), "audit.log")
+
+	m = pressKey(m, "X")
+	m = pressSpecial(m, tea.KeyEnter)
+
+	entries, err := audit.Read(m.auditPath, 0)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("entries = %+v, %v; want one", entries, err)
+	}
+	e := entries[0]
+	if e.Action != "kill" || e.Target != "chrome" || e.Signal != "SIGKILL" || e.Result != audit.ResultOK || e.Source != "tui" || e.User == "" {
+		t.Errorf("entry = %+v", e)
+	}
+	if len(e.PIDs) != 1 || e.PIDs[0] != 10 {
+		t.Errorf("PIDs = %v, want [10]", e.PIDs)
+	}
+}
+
+func TestAudit_FailedSuspendRecorded(t *testing.T) {
+	stubProcActions(t, 0, false, errors.New("operation not permitted"))
+	m := chipsTestModel()
+	m.auditPath = filepath.Join(t.TempDir(), "audit.log")
+
+	m = pressKey(m, "z")
+	m = pressSpecial(m, tea.KeyEnter)
+
+	entries, _ := audit.Read(m.auditPath, 0)
+	if len(entries) != 1 || entries[0].Action != "suspend" || entries[0].Result != audit.ResultFailed || entries[0].Error != "operation not permitted" {
+		t.Errorf("entries = %+v", entries)
+	}
+}
+
+func TestAudit_WriteFailureInDiagnostics(t *testing.T) {
+	origAppend, origSignal := appendAudit, sendSignal
+	appendAudit = func(string, audit.Entry) error { return errors.New("read-only file system") }
+	sendSignal = func(int, syscall.Signal) error { return nil }
+	t.Cleanup(func() { appendAudit, sendSignal = origAppend, origSignal })
+
+	m := chipsTestModel()
+	m.auditPath = "/audit.log"
+	m = pressKey(m, "x")
+	m = pressSpecial(m, tea.KeyEnter)
+
+	if !strings.HasPrefix(toastText(m), "Killed") {
+		t.Errorf("toast = %q, the kill itself should still be reported", toastText(m))
+	}
+	diag := m.recentDiagnostics()
+	if len(diag) == 0 || diag[0].Source != sourceAudit {
+		t.Errorf("diagnostics = %+v, want an audit error", diag)
+	}
+}
+
+func TestAudit_DisabledWithoutPath(t *testing.T) {
+	calls := 0
+	origAppend := appendAudit
+	appendAudit = func(string, audit.Entry) error { calls++; return nil }
+	t.Cleanup(func() { appendAudit = origAppend })
+
+	m := chipsTestModel()
+	m.auditAction(audit.Entry{Action: "kill"}, 1, 0, nil)
+	if calls != 0 {
+		t.Error("no audit path should mean no writes")
+	}
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// maxSuggestions caps the alternatives listed after the inline completion.
+const maxSuggestions = 3
+
+// searchSuggestions returns completions for the search query, drawn from the
+// snapshot: process names, remote hosts (resolved names and IPs), states and
+// ports. Matches are case-insensitive prefixes, shortest first; the query
+// itself is never suggested.
+func (m Model) searchSuggestions(query string) []string {
+	if query == "" || m.snapshot == nil {
+		return nil
+	}
+	seen := map[string]bool{}
+	var out []string
+	add := func(candidate string) {
+		if candidate == "" || seen[candidate] || len(candidate) <= len(query) ||
+			!strings.EqualFold(candidate[:len(query)], query) {
+			return
+		}
+		seen[candidate] = true
+		out = append(out, candidate)
+	}
+	addPort := func(addr string) {
+		if port := model.ExtractPort(addr); port > 0 {
+			add(strconv.Itoa(port))
+		}
+	}
+	for _, app := range m.snapshot.Applications {
+		add(app.Name)
+		for _, conn := range app.Connections {
+			ip := extractIP(conn.RemoteAddr)
+			if ip != "*" && ip != "" {
+				add(ip)
+				add(m.dnsCache[ip])
+			}
+			add(m.stateLabel(conn))
+			addPort(conn.LocalAddr)
+			addPort(conn.RemoteAddr)
+		}
+	}
+	slices.SortFunc(out, func(a, b string) int {
+		if len(a) != len(b) {
+			return len(a) - len(b)
+		}
+		return strings.Compare(a, b)
+	})
+	return out
+}
+
+// acceptSuggestion replaces the search query with its top suggestion.
+func (m *Model) acceptSuggestion() {
+	if s := m.searchSuggestions(m.searchQuery); len(s) > 0 {
+		m.searchQuery = s[0]
+	}
+}
+
+// renderSearchLine returns the search status line: the query, the cursor, the
+// rest of the top suggestion dimmed, and a few alternatives.
+func (m Model) renderSearchLine() string {
+	line := "/" + m.searchQuery + "█"
+	suggestions := m.searchSuggestions(m.searchQuery)
+	if len(suggestions) == 0 {
+		return line
+	}
+	dimStyle := DimmedStyle()
+	line += dimStyle.Render(suggestions[0][len(m.searchQuery):])
+	if rest := suggestions[1:]; len(rest) > 0 {
+		more := ""
+		if len(rest) > maxSuggestions {
+			more = ", …"
+			rest = rest[:maxSuggestions]
+		}
+		line += dimStyle.Render("   " + strings.Join(rest, ", ") + more)
+	}
+	return line
+}
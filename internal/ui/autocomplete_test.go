@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSearchSuggestions_Sources(t *testing.T) {
+	m := chipsTestModel()
+	m.dnsCache = map[string]string{"1.1.1.1": "one.one.one.one"}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"ch", []string{"chrome"}},
+		{"CU", []string{"curl"}},
+		{"44", []string{"443"}},
+		{"500", []string{"50000", "50001", "50002"}},
+		{"est", []string{"ESTABLISHED"}},
+		{"one", []string{"one.one.one.one"}},
+		{"1.", []string{"1.1.1.1"}},
+		{"chrome", nil}, // the query itself is not a suggestion
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := m.searchSuggestions(tt.query); !slices.Equal(got, tt.want) {
+			t.Errorf("searchSuggestions(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestSearch_TabAcceptsSuggestion(t *testing.T) {
+	m := chipsTestModel()
+
+	m = pressKey(m, "/")
+	m = typeKeys(m, "chr")
+	m = pressSpecial(m, tea.KeyTab)
+	if m.searchQuery != "chrome" {
+		t.Fatalf("query = %q, want chrome", m.searchQuery)
+	}
+	m = pressSpecial(m, tea.KeyEnter)
+	if !slices.Equal(m.filterChips, []string{"chrome"}) {
+		t.Errorf("chips = %v, want [chrome]", m.filterChips)
+	}
+
+	// Without a suggestion Tab leaves the query alone
+	m = pressKey(m, "/")
+	m = typeKeys(m, "zzz")
+	m = pressSpecial(m, tea.KeyTab)
+	if m.searchQuery != "zzz" {
+		t.Errorf("query = %q, want unchanged", m.searchQuery)
+	}
+}
+
+func TestRenderSearchLine_InlineCompletion(t *testing.T) {
+	m := chipsTestModel()
+	m.searchMode = true
+	m.searchQuery = "500"
+
+	line := stripAnsi(m.renderSearchLine())
+	if !strings.HasPrefix(line, "/500█00") {
+		t.Errorf("line = %q, want the top suggestion completed inline", line)
+	}
+	if !strings.Contains(line, "50001, 50002") {
+		t.Errorf("line = %q, want alternatives listed", line)
+	}
+	if !strings.Contains(stripAnsi(m.renderKeybindingsText()), "tab complete") {
+		t.Error("footer should offer tab to complete")
+	}
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"math"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// The auto sort (the process list default) ranks processes by how worth a look
+// they are: connection churn, bandwidth and listening sockets, each weighted so
+// that typical values land in the same range.
+const (
+	autoChurnWeight     = 1.0 // per connection opened or closed per second
+	autoBandwidthWeight = 1.0 // per decade of KB/s (1 KB/s ≈ 0.3, 1 MB/s ≈ 3)
+	autoListenWeight    = 0.5 // per listening socket
+)
+
+// netIORate returns the throughput between two samples of the same counters.
+// Returns false without a usable interval or when the counters went backwards
+// (PID reuse, container restart).
+func netIORate(prev, curr *model.NetIOStats) (ioRate, bool) {
+	if prev == nil || curr == nil {
+		return ioRate{}, false
+	}
+	elapsed := curr.UpdatedAt.Sub(prev.UpdatedAt).Seconds()
+	if elapsed <= 0 || curr.BytesSent < prev.BytesSent || curr.BytesRecv < prev.BytesRecv {
+		return ioRate{}, false
+	}
+	return ioRate{
+		TX: float64(curr.BytesSent-prev.BytesSent) / elapsed,
+		RX: float64(curr.BytesRecv-prev.BytesRecv) / elapsed,
+	}, true
+}
+
+// recordNetIORates updates the per-PID throughput from a new netIO sample.
+// Must run before the sample replaces netIOCache. PIDs without a previous
+// sample keep no rate.
+func (m *Model) recordNetIORates(stats map[int32]*model.NetIOStats) {
+	if m.netIORates == nil {
+		m.netIORates = make(map[int32]ioRate)
+	}
+	for pid, curr := range stats {
+		if r, ok := netIORate(m.netIOCache[pid], curr); ok {
+			m.netIORates[pid] = r
+		} else {
+			delete(m.netIORates, pid)
+		}
+	}
+}
+
+// interestScore returns the auto sort score of app; higher is more interesting.
+func (m Model) interestScore(app model.Application) float64 {
+	churn := m.churnRate(app.Name)
+	var bytesPerSec float64
+	for _, pid := range app.PIDs {
+		r := m.netIORates[pid]
+		bytesPerSec += r.TX + r.RX
+	}
+	return autoChurnWeight*(churn.Opened+churn.Closed) +
+		autoBandwidthWeight*math.Log10(1+bytesPerSec/1024) +
+		autoListenWeight*float64(app.ListenCount)
+}
+
+// interestScores returns interestScore for each app, keyed by name, so sorting
+// computes each score once.
+func (m Model) interestScores(apps []model.Application) map[string]float64 {
+	scores := make(map[string]float64, len(apps))
+	for _, app := range apps {
+		scores[app.Name] = m.interestScore(app)
+	}
+	return scores
+}
+
+// autoSort switches the process list back to the auto sort.
+func (m *Model) autoSort() {
+	view := m.CurrentView()
+	if view == nil || view.Level != LevelProcessList {
+		return
+	}
+	view.SortColumn = SortAuto
+	view.SortAscending = true
+	view.SelectedColumn = SortAuto
+	view.SortMode = false
+	m.rememberSort(view)
+}
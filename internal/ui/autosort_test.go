@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kostyay/netmon/internal/config"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+func TestNetIORate(t *testing.T) {
+	t0 := time.Now()
+	prev := &model.NetIOStats{BytesSent: 1000, BytesRecv: 500, UpdatedAt: t0}
+
+	r, ok := netIORate(prev, &model.NetIOStats{BytesSent: 3000, BytesRecv: 1500, UpdatedAt: t0.Add(2 * time.Second)})
+	if !ok || r.TX != 1000 || r.RX != 500 {
+		t.Errorf("rate = %+v, %v; want 1000/500 B/s", r, ok)
+	}
+	if _, ok := netIORate(prev, &model.NetIOStats{BytesSent: 10, UpdatedAt: t0.Add(time.Second)}); ok {
+		t.Error("counters going backwards should give no rate")
+	}
+	if _, ok := netIORate(prev, prev); ok {
+		t.Error("zero interval should give no rate")
+	}
+	if _, ok := netIORate(nil, prev); ok {
+		t.Error("missing previous sample should give no rate")
+	}
+}
+
+func TestRecordNetIORates(t *testing.T) {
+	t0 := time.Now()
+	m := createTestModel()
+	m.netIOCache[100] = &model.NetIOStats{BytesSent: 0, UpdatedAt: t0}
+
+	m.recordNetIORates(map[int32]*model.NetIOStats{
+		100: {BytesSent: 2048, UpdatedAt: t0.Add(time.Second)},
+		200: {BytesSent: 4096, UpdatedAt: t0.Add(time.Second)},
+	})
+
+	if m.netIORates[100].TX != 2048 {
+		t.Errorf("pid 100 rate = %+v, want 2048 B/s", m.netIORates[100])
+	}
+	if _, ok := m.netIORates[200]; ok {
+		t.Error("first sample of a PID should not produce a rate")
+	}
+}
+
+// autoSortModel returns a model where "busy" has bandwidth, "churny" churns
+// connections, "server" listens, and "idle" does nothing.
+func autoSortModel() Model {
+	m := createTestModel()
+	m.snapshot = &model.NetworkSnapshot{Applications: []model.Application{
+		{Name: "alpha-idle", PIDs: []int32{1}},
+		{Name: "busy", PIDs: []int32{2}},
+		{Name: "churny", PIDs: []int32{3}},
+		{Name: "server", PIDs: []int32{4}, ListenCount: 2},
+		{Name: "zeta-idle", PIDs: []int32{5}},
+	}}
+	m.netIORates = map[int32]ioRate{2: {TX: 512 * 1024, RX: 512 * 1024}} // 1 MB/s
+	m.churnSamples = []churnSample{{
+		elapsed: time.Second,
+		opened:  map[string]int{"churny": 5},
+		closed:  map[string]int{"churny": 5},
+	}}
+	m.CurrentView().SortColumn = SortAuto
+	m.CurrentView().SortAscending = true
+	return m
+}
+
+func TestSortProcessList_Auto(t *testing.T) {
+	m := autoSortModel()
+
+	got := appNames(m.sortProcessList(m.snapshot.Applications))
+	want := []string{"churny", "busy", "server", "alpha-idle", "zeta-idle"}
+	if !slices.Equal(got, want) {
+		t.Errorf("auto order = %v, want %v", got, want)
+	}
+
+	m.CurrentView().SortAscending = false
+	got = appNames(m.sortProcessList(m.snapshot.Applications))
+	if got[0] != "zeta-idle" || got[len(got)-1] != "churny" {
+		t.Errorf("reversed auto order = %v, want least interesting first", got)
+	}
+}
+
+func TestInterestScore_Components(t *testing.T) {
+	m := autoSortModel()
+	scores := m.interestScores(m.snapshot.Applications)
+
+	if scores["alpha-idle"] != 0 {
+		t.Errorf("idle score = %v, want 0", scores["alpha-idle"])
+	}
+	if scores["server"] != 2*autoListenWeight {
+		t.Errorf("server score = %v, want %v", scores["server"], 2*autoListenWeight)
+	}
+	if s := scores["busy"]; s < 3 || s > 3.1 {
+		t.Errorf("1 MB/s score = %v, want about 3", s)
+	}
+	if scores["churny"] != 10*autoChurnWeight {
+		t.Errorf("churn score = %v, want %v", scores["churny"], 10*autoChurnWeight)
+	}
+}
+
+func TestAutoSortKey_RestoresAuto(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	m := autoSortModel()
+	m.quickSort(1)
+	if m.CurrentView().SortColumn == SortAuto {
+		t.Fatal("quick sort should leave auto sort")
+	}
+
+	m = pressKey(m, "a")
+	if v := m.CurrentView(); v.SortColumn != SortAuto || !v.SortAscending {
+		t.Errorf("sort = %v/%v, want Auto ascending", v.SortColumn, v.SortAscending)
+	}
+	if !strings.Contains(m.renderBreadcrumbsText(), "auto sort") {
+		t.Errorf("breadcrumbs = %q, want auto sort shown", m.renderBreadcrumbsText())
+	}
+}
+
+func TestAutoSortKey_IgnoredOutsideProcessList(t *testing.T) {
+	m := autoSortModel()
+	m.PushView(ViewState{Level: LevelConnections, ProcessName: "busy", SortColumn: SortLocal})
+	m = pressKey(m, "a")
+	if m.CurrentView().SortColumn != SortLocal {
+		t.Errorf("sort = %v, want connections view unchanged", m.CurrentView().SortColumn)
+	}
+}
+
+func TestNewViewState_SavedAutoPreference(t *testing.T) {
+	m := createTestModel()
+	m.sortPrefs = map[string]config.SortPref{
+		"processList":    {Column: "Auto", Ascending: false},
+		"allConnections": {Column: "Auto"}, // process list only
+	}
+	if v := m.newViewState(LevelProcessList, ""); v.SortColumn != SortAuto || v.SortAscending {
+		t.Errorf("process list sort = %v/%v, want saved Auto desc", v.SortColumn, v.SortAscending)
+	}
+	if v := m.newViewState(LevelAllConnections, ""); v.SortColumn != SortProcess {
+		t.Errorf("flat view sort = %v, want default Process", v.SortColumn)
+	}
+}
@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// benchSizes are the connection counts the hot paths are measured at: a
+// laptop, a busy server, and a proxy or load balancer.
+var benchSizes = []int{1000, 10000, 50000}
+
+// syntheticSnapshot returns n connections spread over n/50 processes with a
+// realistic mix of states, remote hosts and ports. seed shifts which
+// connections exist, so two seeds make a churned pair for diffing.
+func syntheticSnapshot(n, seed int) *model.NetworkSnapshot {
+	states := []model.ConnectionState{model.StateEstablished, model.StateEstablished, model.StateTimeWait, model.StateListen, model.StateCloseWait}
+	apps := make([]model.Application, max(n/50, 1))
+	for i := range apps {
+		pid := int32(1000 + i)
+		apps[i] = model.Application{Name: fmt.Sprintf("proc-%03d", i%300), PIDs: []int32{pid}}
+	}
+	for i := range n {
+		app := &apps[i%len(apps)]
+		k := i + seed
+		state := states[k%len(states)]
+		remote := fmt.Sprintf("%d.%d.%d.%d:%d", 10+k%200, k/200%250, k/50000%250, k%250, []int{443, 80, 5432, 6379, 22}[k%5])
+		if state == model.StateListen {
+			remote = "*"
+		}
+		app.Connections = append(app.Connections, model.Connection{
+			PID:        app.PIDs[0],
+			Protocol:   model.ProtocolTCP,
+			LocalAddr:  fmt.Sprintf("10.0.0.1:%d", 1024+k%60000),
+			RemoteAddr: remote,
+			State:      state,
+		})
+		switch state {
+		case model.StateEstablished:
+			app.EstablishedCount++
+		case model.StateListen:
+			app.ListenCount++
+		}
+	}
+	return &model.NetworkSnapshot{Applications: apps, Timestamp: time.Now()}
+}
+
+// benchModel returns a sized model on the given level over a synthetic snapshot.
+func benchModel(n int, level ViewLevel) Model {
+	m := createTestModel()
+	m.snapshot = syntheticSnapshot(n, 0)
+	m.rowCache = newRowCache()
+	m.pipeline = newPipelineCache()
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 50})
+	m = updated.(Model)
+	m.stack = []ViewState{m.newViewState(level, "")}
+	m.updateViewportContent()
+	return m
+}
+
+// benchEachSize runs fn as one sub-benchmark per size in benchSizes.
+func benchEachSize(b *testing.B, fn func(b *testing.B, n int)) {
+	for _, n := range benchSizes {
+		b.Run(fmt.Sprintf("conns=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			fn(b, n)
+		})
+	}
+}
+
+func BenchmarkRenderAllConnectionsData_Sizes(b *testing.B) {
+	benchEachSize(b, func(b *testing.B, n int) {
+		m := benchModel(n, LevelAllConnections)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			m.CurrentView().Cursor = i % 40
+			_ = m.renderAllConnectionsData()
+		}
+	})
+}
+
+func BenchmarkFilteredAllConnections(b *testing.B) {
+	benchEachSize(b, func(b *testing.B, n int) {
+		m := benchModel(n, LevelAllConnections)
+		m.filterChips = []string{"proc-01", "443"}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = m.filteredAllConnections()
+		}
+	})
+}
+
+func BenchmarkSortAllConnections(b *testing.B) {
+	benchEachSize(b, func(b *testing.B, n int) {
+		m := benchModel(n, LevelAllConnections)
+		m.CurrentView().SortColumn = SortRemote
+		conns := m.filteredAllConnections()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = m.sortAllConnections(conns)
+		}
+	})
+}
+
+func BenchmarkSortConnectionsForView(b *testing.B) {
+	benchEachSize(b, func(b *testing.B, n int) {
+		m := benchModel(n, LevelConnections)
+		m.CurrentView().SortColumn = SortState
+		var conns []model.Connection // one process holding every connection
+		for _, app := range m.snapshot.Applications {
+			conns = append(conns, app.Connections...)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = m.sortConnectionsForView(conns)
+		}
+	})
+}
+
+func BenchmarkSortProcessList(b *testing.B) {
+	benchEachSize(b, func(b *testing.B, n int) {
+		m := benchModel(n, LevelProcessList)
+		m.CurrentView().SortColumn = SortConns
+		apps := m.filteredApps()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = m.sortProcessList(apps)
+		}
+	})
+}
+
+func BenchmarkDiffConnections(b *testing.B) {
+	benchEachSize(b, func(b *testing.B, n int) {
+		prev, curr := syntheticSnapshot(n, 0), syntheticSnapshot(n, n/20) // 5% churn
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = diffConnections(prev, curr)
+		}
+	})
+}
+
+// frameBudget is the most one frame of steady-state work may take: a cursor
+// move and the redraw of the visible rows, with the sorted rows cached.
+// Performance rewrites must keep TestFrameBudget passing.
+const frameBudget = time.Millisecond
+
+// frameBudgetConns is the connection count the budget applies to.
+const frameBudgetConns = 10000
+
+func TestFrameBudget(t *testing.T) {
+	if testing.Short() || raceEnabled {
+		t.Skip("timing test: skipped with -short and under the race detector")
+	}
+	m := benchModel(frameBudgetConns, LevelAllConnections)
+	keys := []tea.KeyMsg{{Type: tea.KeyDown}, {Type: tea.KeyUp}}
+
+	// Average 200 frames; take the best of three runs so a busy machine does
+	// not fail the build.
+	const frames = 200
+	best := time.Duration(1<<63 - 1)
+	for range 3 {
+		start := time.Now()
+		for i := range frames {
+			updated, _ := m.Update(keys[i%2])
+			m = updated.(Model)
+			_ = m.renderAllConnectionsData()
+		}
+		if d := time.Since(start) / frames; d < best {
+			best = d
+		}
+	}
+	if best > frameBudget {
+		t.Errorf("frame over %d connections took %v, budget %v", frameBudgetConns, best, frameBudget)
+	}
+}
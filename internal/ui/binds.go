@@ -0,0 +1,343 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// bindRow is one bound socket in the bind address view. A socket several
+// processes listen on (SO_REUSEPORT, or a listener inherited across fork)
+// is one row listing every owner.
+type bindRow struct {
+	conn    model.Connection
+	process string
+	exe     string
+	scope   model.BindScope
+	owners  []bindOwner // every process holding the socket, first is conn's
+}
+
+// bindOwner is a process holding a bound socket.
+type bindOwner struct {
+	pid     int32
+	process string
+	exe     string
+}
+
+// exposed reports whether the row deserves a warning: a wildcard bind by a
+// process that is not a system service. Unknown executables are not flagged,
+// since without privileges they are usually other users' daemons.
+func (r bindRow) exposed() bool {
+	if r.scope != model.BindWildcard {
+		return false
+	}
+	for _, o := range r.owners {
+		if o.exe != "" && !isSystemExe(o.exe) {
+			return true
+		}
+	}
+	return false
+}
+
+// shared reports whether several processes hold the socket.
+func (r bindRow) shared() bool {
+	return len(r.owners) > 1
+}
+
+// mixedSharing reports whether the socket is shared by different programs:
+// worker processes of one server are expected, two executables on one port are
+// not. Executables are compared when known, else process names.
+func (r bindRow) mixedSharing() bool {
+	if !r.shared() {
+		return false
+	}
+	first := r.owners[0]
+	for _, o := range r.owners[1:] {
+		if o.exe != "" && first.exe != "" {
+			if o.exe != first.exe {
+				return true
+			}
+		} else if o.process != first.process {
+			return true
+		}
+	}
+	return false
+}
+
+// pidLabel returns the PID cell: the PID, or the owner count when shared.
+func (r bindRow) pidLabel() string {
+	if r.shared() {
+		return fmt.Sprintf("%d PIDs", len(r.owners))
+	}
+	return strconv.Itoa(int(r.conn.PID))
+}
+
+// processLabel returns the Process cell: the process name, "nginx ×4" for
+// workers of one program, or every distinct name for mixed sharing.
+func (r bindRow) processLabel() string {
+	if !r.shared() {
+		return r.process
+	}
+	if !r.mixedSharing() {
+		return fmt.Sprintf("%s ×%d", r.process, len(r.owners))
+	}
+	var names []string
+	for _, o := range r.owners {
+		if !slices.Contains(names, o.process) {
+			names = append(names, o.process)
+		}
+	}
+	return "⚠ " + strings.Join(names, ", ")
+}
+
+// systemExeDirs are install locations of OS services and distro daemons.
+var systemExeDirs = []string{"/sbin/", "/usr/sbin/", "/lib/", "/usr/lib/", "/usr/libexec/", "/System/"}
+
+// isSystemExe reports whether exe is installed in a system directory.
+func isSystemExe(exe string) bool {
+	for _, dir := range systemExeDirs {
+		if strings.HasPrefix(exe, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// bindsColumns returns the column definitions for the bind address view.
+func bindsColumns() []columnDef {
+	return []columnDef{
+		{label: "Scope", id: SortBindScope, minWidth: 10, flex: 0},
+		{label: "Address", id: SortLocal, minWidth: 22, flex: 2},
+		{label: "Proto", id: SortProtocol, minWidth: 6, flex: 0},
+		{label: "PID", id: SortPID, minWidth: 7, flex: 0, rightAlign: true},
+		{label: "Process", id: SortProcess, minWidth: 16, flex: 3},
+	}
+}
+
+// toggleBindsView switches between the bind address view and the process list.
+func (m Model) toggleBindsView() (tea.Model, tea.Cmd) {
+	view := m.CurrentView()
+	if view != nil && view.Level == LevelBinds {
+		m.stack = []ViewState{m.newViewState(LevelProcessList, "")}
+		return m, nil
+	}
+	m.dockerView = false
+	m.stack = []ViewState{m.newViewState(LevelBinds, "")}
+	return m, nil
+}
+
+// checkPort opens the bind address view with the search prompt started at ":",
+// so typing a port narrows the list to the sockets holding it.
+func (m Model) checkPort() (tea.Model, tea.Cmd) {
+	m.dockerView = false
+	m.stack = []ViewState{m.newViewState(LevelBinds, "")}
+	m.searchMode = true
+	m.searchQuery = ":"
+	return m, nil
+}
+
+// portQuery returns the port of a filter such as ":3000" or "3000".
+func portQuery(filter string) (int, bool) {
+	port, err := strconv.Atoi(strings.TrimPrefix(filter, ":"))
+	if err != nil || port < 1 || port > 65535 {
+		return 0, false
+	}
+	return port, true
+}
+
+// bindRows returns every bound socket (TCP listeners and unconnected UDP
+// sockets) in the snapshot. Established connections are left out: their local
+// address is whatever interface the peer reached, not what the server exposes.
+// Sockets on the same protocol and address held by several PIDs are merged.
+func (m Model) bindRows() []bindRow {
+	if m.snapshot == nil {
+		return nil
+	}
+	type bindKey struct {
+		proto model.Protocol
+		addr  string
+	}
+	var rows []bindRow
+	index := make(map[bindKey]int)
+	for _, app := range m.snapshot.Applications {
+		for _, conn := range app.Connections {
+			if !conn.IsBound() {
+				continue
+			}
+			owner := bindOwner{pid: conn.PID, process: app.Name, exe: app.Exe}
+			key := bindKey{conn.Protocol, conn.LocalAddr}
+			if i, ok := index[key]; ok {
+				if !slices.ContainsFunc(rows[i].owners, func(o bindOwner) bool { return o.pid == conn.PID }) {
+					rows[i].owners = append(rows[i].owners, owner)
+				}
+				continue
+			}
+			index[key] = len(rows)
+			rows = append(rows, bindRow{conn: conn, process: app.Name, exe: app.Exe, scope: conn.BindScope(), owners: []bindOwner{owner}})
+		}
+	}
+	return rows
+}
+
+// filteredBinds returns bound sockets matching every current filter.
+func (m Model) filteredBinds() []bindRow {
+	rows := m.bindRows()
+	filters := m.currentFilters()
+	if len(filters) == 0 {
+		return rows
+	}
+	var result []bindRow
+	for _, r := range rows {
+		fields := []string{r.scope.String(), r.conn.LocalAddr, string(r.conn.Protocol)}
+		for _, o := range r.owners {
+			fields = append(fields, strconv.Itoa(int(o.pid)), o.process)
+		}
+		if conntrackMatches(fields, filters) {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// sortBinds sorts bound sockets grouped by scope: wildcard binds first, then
+// interface, then loopback. The sort column orders rows within each group;
+// sorting by Scope itself reverses the group order when descending.
+func (m Model) sortBinds(rows []bindRow) []bindRow {
+	view := m.CurrentView()
+	if view == nil {
+		return rows
+	}
+
+	sorted := make([]bindRow, len(rows))
+	copy(sorted, rows)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.scope != b.scope {
+			if view.SortColumn == SortBindScope && !view.SortAscending {
+				return a.scope > b.scope
+			}
+			return a.scope < b.scope
+		}
+
+		var cmp int
+		switch view.SortColumn {
+		case SortProtocol:
+			cmp = compareString(string(a.conn.Protocol), string(b.conn.Protocol))
+		case SortPID:
+			cmp = compareInt32(a.conn.PID, b.conn.PID)
+		case SortProcess:
+			cmp = compareString(a.process, b.process)
+		default:
+			cmp = compareInt(model.ExtractPort(a.conn.LocalAddr), model.ExtractPort(b.conn.LocalAddr))
+		}
+
+		// Secondary sort for stable ordering when primary keys are equal
+		if cmp == 0 {
+			cmp = compareString(a.conn.LocalAddr, b.conn.LocalAddr)
+		}
+		if cmp == 0 {
+			cmp = compareString(a.process, b.process)
+		}
+
+		if view.SortAscending || view.SortColumn == SortBindScope {
+			return cmp < 0
+		}
+		return cmp > 0
+	})
+
+	return sorted
+}
+
+// bindSummary counts bound sockets per scope, e.g. "3 wildcard (1 exposed), 2 interface, 4 loopback",
+// followed by ", 2 shared (1 mixed)" when processes share a socket.
+func bindSummary(rows []bindRow) string {
+	counts := make(map[model.BindScope]int)
+	exposed, shared, mixed := 0, 0, 0
+	for _, r := range rows {
+		counts[r.scope]++
+		if r.exposed() {
+			exposed++
+		}
+		if r.shared() {
+			shared++
+		}
+		if r.mixedSharing() {
+			mixed++
+		}
+	}
+	wildcard := fmt.Sprintf("%d wildcard", counts[model.BindWildcard])
+	if exposed > 0 {
+		wildcard += fmt.Sprintf(" (%d exposed)", exposed)
+	}
+	summary := fmt.Sprintf("%s, %d interface, %d loopback", wildcard, counts[model.BindInterface], counts[model.BindLoopback])
+	if shared > 0 {
+		summary += fmt.Sprintf(", %d shared", shared)
+		if mixed > 0 {
+			summary += fmt.Sprintf(" (%d mixed)", mixed)
+		}
+	}
+	return summary
+}
+
+// renderBindsHeader renders the header for the bind address table.
+func (m Model) renderBindsHeader(widths []int) string {
+	view := m.CurrentView()
+	if view == nil {
+		return ""
+	}
+	return renderTableHeader(bindsColumns(), widths, view.SelectedColumn, view.SortColumn, view.SortAscending, true)
+}
+
+// renderBindsData renders only the data rows for the bind address view (no header).
+// Wildcard binds from non-system processes and sockets shared by different
+// programs are rendered in the warning style.
+func (m Model) renderBindsData() string {
+	view := m.CurrentView()
+	if view == nil {
+		return ""
+	}
+
+	rows := m.filteredBinds()
+	if len(rows) == 0 {
+		filters := m.currentFilters()
+		if len(filters) == 1 {
+			if port, ok := portQuery(filters[0]); ok {
+				return EmptyStyle().Render(fmt.Sprintf(i18n.T("Port %d is free: nothing is bound to it"), port))
+			}
+		}
+		if filter := m.currentFilter(); filter != "" {
+			return EmptyStyle().Render(fmt.Sprintf(i18n.T("No matches for '%s'"), filter))
+		}
+		return EmptyStyle().Render(i18n.T("No listening or bound sockets"))
+	}
+
+	var b strings.Builder
+	widths := calculateColumnWidths(bindsColumns(), m.contentWidth())
+	rows = m.sortBinds(rows)
+
+	var w rowWriter
+	for i, r := range rows {
+		w.reset()
+		w.left(r.scope.String(), widths[0])
+		w.left(truncateString(r.conn.LocalAddr, widths[1]), widths[1])
+		w.left(string(r.conn.Protocol), widths[2])
+		w.right(r.pidLabel(), widths[3])
+		w.left(truncateString(r.processLabel(), widths[4]), widths[4])
+		row := w.String()
+		if (r.exposed() || r.mixedSharing()) && i != view.Cursor {
+			b.WriteString(rowStyles().warn.render("  "+row) + "\n")
+			continue
+		}
+		b.WriteString(renderRow(row, i == view.Cursor))
+	}
+
+	return b.String()
+}
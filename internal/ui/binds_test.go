@@ -0,0 +1,203 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// bindsModel returns a model with a user dev server on the wildcard address,
+// sshd on the wildcard address, a database on loopback and a DNS stub on an
+// interface address, plus one established connection.
+func bindsModel() Model {
+	m := createTestModel()
+	m.snapshot = &model.NetworkSnapshot{Applications: []model.Application{
+		{Name: "python3", Exe: "/home/dev/.venv/bin/python3", PIDs: []int32{100}, Connections: []model.Connection{
+			{PID: 100, Protocol: model.ProtocolTCP, LocalAddr: "0.0.0.0:8000", RemoteAddr: "*", State: model.StateListen},
+			{PID: 100, Protocol: model.ProtocolTCP, LocalAddr: "192.168.1.5:8000", RemoteAddr: "192.168.1.9:50000", State: model.StateEstablished},
+		}},
+		{Name: "sshd", Exe: "/usr/sbin/sshd", PIDs: []int32{200}, Connections: []model.Connection{
+			{PID: 200, Protocol: model.ProtocolTCP, LocalAddr: ":::22", RemoteAddr: "*", State: model.StateListen},
+		}},
+		{Name: "postgres", Exe: "/opt/pg/bin/postgres", PIDs: []int32{300}, Connections: []model.Connection{
+			{PID: 300, Protocol: model.ProtocolTCP, LocalAddr: "127.0.0.1:5432", RemoteAddr: "*", State: model.StateListen},
+		}},
+		{Name: "dnsmasq", Exe: "/usr/sbin/dnsmasq", PIDs: []int32{400}, Connections: []model.Connection{
+			{PID: 400, Protocol: model.ProtocolUDP, LocalAddr: "192.168.1.5:53", RemoteAddr: "*", State: model.StateNone},
+		}},
+	}}
+	return m
+}
+
+func TestBindsView_Toggle(t *testing.T) {
+	m := bindsModel()
+	m = pressKey(m, "B")
+	if m.CurrentView().Level != LevelBinds {
+		t.Fatalf("Level = %v, want Binds", m.CurrentView().Level)
+	}
+	m = pressKey(m, "B")
+	if m.CurrentView().Level != LevelProcessList {
+		t.Errorf("Level = %v, want ProcessList after second toggle", m.CurrentView().Level)
+	}
+}
+
+func TestBindRows_OnlyBoundSockets(t *testing.T) {
+	m := bindsModel()
+	rows := m.bindRows()
+	if len(rows) != 4 {
+		t.Fatalf("bindRows = %d, want 4 (established connection excluded)", len(rows))
+	}
+}
+
+func TestBindsView_GroupedByScope(t *testing.T) {
+	m := pressKey(bindsModel(), "B")
+
+	var got []string
+	for _, r := range m.sortBinds(m.bindRows()) {
+		got = append(got, r.process)
+	}
+	want := []string{"sshd", "python3", "dnsmasq", "postgres"} // wildcard by port, interface, loopback
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+
+	// Sorting by another column keeps the groups and orders within them
+	m.CurrentView().SortColumn = SortProcess
+	m.CurrentView().SortAscending = false
+	got = got[:0]
+	for _, r := range m.sortBinds(m.bindRows()) {
+		got = append(got, r.process)
+	}
+	want = []string{"sshd", "python3", "dnsmasq", "postgres"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("order by process desc = %v, want %v", got, want)
+	}
+
+	// Descending scope puts loopback first
+	m.CurrentView().SortColumn = SortBindScope
+	rows := m.sortBinds(m.bindRows())
+	if rows[0].scope != model.BindLoopback {
+		t.Errorf("first scope = %v, want loopback when descending", rows[0].scope)
+	}
+}
+
+func TestBindRow_Exposed(t *testing.T) {
+	exposed := map[string]bool{}
+	for _, r := range bindsModel().bindRows() {
+		exposed[r.process] = r.exposed()
+	}
+	want := map[string]bool{"python3": true, "sshd": false, "postgres": false, "dnsmasq": false}
+	for name, w := range want {
+		if exposed[name] != w {
+			t.Errorf("exposed[%s] = %v, want %v", name, exposed[name], w)
+		}
+	}
+
+	unknown := bindRow{scope: model.BindWildcard}
+	if unknown.exposed() {
+		t.Error("wildcard bind with unknown executable should not be flagged")
+	}
+}
+
+func TestBindSummary(t *testing.T) {
+	got := bindSummary(bindsModel().bindRows())
+	want := "2 wildcard (1 exposed), 1 interface, 1 loopback"
+	if got != want {
+		t.Errorf("bindSummary = %q, want %q", got, want)
+	}
+}
+
+func TestBindsView_FilterAndRender(t *testing.T) {
+	m := bindsModel()
+	m.width, m.height = 120, 40
+	m = pressKey(m, "B")
+
+	out := stripAnsi(m.renderBindsData())
+	for _, want := range []string{"wildcard", "0.0.0.0:8000", "loopback", "127.0.0.1:5432", "interface"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("render missing %q:\n%s", want, out)
+		}
+	}
+
+	m.filterChips = []string{"loopback"}
+	rows := m.filteredBinds()
+	if len(rows) != 1 || rows[0].process != "postgres" {
+		t.Errorf("filtered = %+v, want only postgres", rows)
+	}
+}
+
+func TestCheckPort_OpensBindsWithPortPrompt(t *testing.T) {
+	m := bindsModel()
+	m.width, m.height = 120, 40
+	m = pressKey(m, "p")
+	if m.CurrentView().Level != LevelBinds || !m.searchMode || m.searchQuery != ":" {
+		t.Fatalf("level=%v searchMode=%v query=%q, want binds view with ':' prompt", m.CurrentView().Level, m.searchMode, m.searchQuery)
+	}
+
+	m.searchQuery = ":5432"
+	if rows := m.filteredBinds(); len(rows) != 1 || rows[0].process != "postgres" {
+		t.Errorf("filtered = %+v, want only postgres", rows)
+	}
+
+	m.searchQuery = ":3000"
+	if out := stripAnsi(m.renderBindsData()); !strings.Contains(out, "Port 3000 is free") {
+		t.Errorf("render = %q, want free port message", out)
+	}
+}
+
+func TestBindRows_MergesSharedListeners(t *testing.T) {
+	m := bindsModel()
+	workers := func(pid int32) model.Application {
+		return model.Application{Name: "nginx", Exe: "/usr/sbin/nginx", PIDs: []int32{pid}, Connections: []model.Connection{
+			{PID: pid, Protocol: model.ProtocolTCP, LocalAddr: "0.0.0.0:80", RemoteAddr: "*", State: model.StateListen},
+		}}
+	}
+	m.snapshot.Applications = append(m.snapshot.Applications, workers(500), workers(501), workers(502))
+
+	rows := m.bindRows()
+	if len(rows) != 5 {
+		t.Fatalf("bindRows = %d, want 5 (nginx workers merged)", len(rows))
+	}
+	nginx := rows[4]
+	if !nginx.shared() || nginx.mixedSharing() {
+		t.Errorf("nginx shared=%v mixed=%v, want shared by one program", nginx.shared(), nginx.mixedSharing())
+	}
+	if nginx.pidLabel() != "3 PIDs" || nginx.processLabel() != "nginx ×3" {
+		t.Errorf("labels = %q / %q, want 3 PIDs / nginx ×3", nginx.pidLabel(), nginx.processLabel())
+	}
+
+	m.filterChips = []string{"501"}
+	if got := m.filteredBinds(); len(got) != 1 || got[0].process != "nginx" {
+		t.Errorf("filter by second owner PID = %+v, want the nginx row", got)
+	}
+}
+
+func TestBindRow_MixedSharing(t *testing.T) {
+	m := bindsModel()
+	m.snapshot.Applications = append(m.snapshot.Applications, model.Application{
+		Name: "node", Exe: "/usr/bin/node", PIDs: []int32{600}, Connections: []model.Connection{
+			{PID: 600, Protocol: model.ProtocolTCP, LocalAddr: "0.0.0.0:8000", RemoteAddr: "*", State: model.StateListen},
+		}})
+	m.width, m.height = 120, 40
+	m = pressKey(m, "B")
+
+	rows := m.bindRows()
+	if len(rows) != 4 || !rows[0].mixedSharing() {
+		t.Fatalf("rows = %d, mixed = %v, want python3 and node merged and flagged", len(rows), rows[0].mixedSharing())
+	}
+	if got := rows[0].processLabel(); got != "⚠ python3, node" {
+		t.Errorf("processLabel = %q", got)
+	}
+	if got := bindSummary(rows); !strings.HasSuffix(got, ", 1 shared (1 mixed)") {
+		t.Errorf("bindSummary = %q, want shared count", got)
+	}
+	if out := stripAnsi(m.renderBindsData()); !strings.Contains(out, "2 PIDs") {
+		t.Errorf("render missing owner count:\n%s", out)
+	}
+
+	same := bindRow{owners: []bindOwner{{pid: 1, process: "app"}, {pid: 2, process: "app"}}}
+	if same.mixedSharing() {
+		t.Error("same process name with unknown executables should not be mixed")
+	}
+}
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"net/netip"
+	"time"
+
+	"github.com/kostyay/netmon/internal/capture"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// Capture mode (--capture): the hostname a connection's client asked for,
+// read from its TLS ClientHello (SNI) or HTTP Host header, labels the remote
+// address in preference to reverse DNS, which names the CDN or hosting
+// provider rather than the site.
+
+// WithCapture returns a Model labelling connections with the hostnames t
+// learns from captured packets.
+func (m Model) WithCapture(t *capture.Table) Model {
+	m.capture = t
+	return m
+}
+
+// connFlow returns the capture flow of an outbound TCP connection.
+func connFlow(conn model.Connection) (capture.Flow, bool) {
+	if conn.Protocol != model.ProtocolTCP || conn.IsBound() {
+		return capture.Flow{}, false
+	}
+	local, err1 := netip.ParseAddrPort(conn.LocalAddr)
+	remote, err2 := netip.ParseAddrPort(conn.RemoteAddr)
+	if err1 != nil || err2 != nil {
+		return capture.Flow{}, false
+	}
+	return capture.Flow{Src: local, Dst: remote}, true
+}
+
+// refreshSniffed drops the names of connections gone from snap and copies
+// the capture's names when they changed, dropping cached rows.
+func (m *Model) refreshSniffed(snap *model.NetworkSnapshot, now time.Time) {
+	if m.capture == nil {
+		return
+	}
+	if err := m.capture.Err(); err != nil {
+		m.recordError(sourceCapture, err)
+	}
+	open := make(map[capture.Flow]bool)
+	for _, app := range snap.Applications {
+		for _, conn := range app.Connections {
+			if f, ok := connFlow(conn); ok {
+				open[f] = true
+			}
+		}
+	}
+	m.capture.Retain(func(f capture.Flow) bool { return open[f] }, now)
+	if m.capture.Gen() == m.sniffedGen {
+		return
+	}
+	m.sniffed, m.sniffedGen = m.capture.Names()
+	m.rowCache.invalidate()
+}
+
+// sniffedHost returns the hostname conn's client asked for, or "".
+func (m Model) sniffedHost(conn model.Connection) string {
+	if len(m.sniffed) == 0 {
+		return ""
+	}
+	f, ok := connFlow(conn)
+	if !ok {
+		return ""
+	}
+	return m.sniffed[f].Host
+}
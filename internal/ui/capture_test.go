@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"encoding/binary"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kostyay/netmon/internal/capture"
+)
+
+// ipv4TCP builds an IPv4 packet with one TCP segment (flags: 0x02 SYN, 0x10 ACK).
+func ipv4TCP(src, dst string, seq uint32, flags byte, payload string) []byte {
+	s, d := netip.MustParseAddrPort(src), netip.MustParseAddrPort(dst)
+	pkt := make([]byte, 40+len(payload))
+	pkt[0] = 0x45
+	binary.BigEndian.PutUint16(pkt[2:4], uint16(len(pkt)))
+	pkt[9] = 6
+	sa, da := s.Addr().As4(), d.Addr().As4()
+	copy(pkt[12:16], sa[:])
+	copy(pkt[16:20], da[:])
+	binary.BigEndian.PutUint16(pkt[20:22], s.Port())
+	binary.BigEndian.PutUint16(pkt[22:24], d.Port())
+	binary.BigEndian.PutUint32(pkt[24:28], seq)
+	pkt[32] = 5 << 4
+	pkt[33] = flags
+	copy(pkt[40:], payload)
+	return pkt
+}
+
+func TestCapture_LabelsRemoteWithSniffedHost(t *testing.T) {
+	table := capture.NewTable()
+	now := time.Now()
+	table.Packet(ipv4TCP("10.0.0.2:50001", "2.2.2.2:80", 1, 0x02, ""), now)
+	table.Packet(ipv4TCP("10.0.0.2:50001", "2.2.2.2:80", 2, 0x10, "GET / HTTP/1.1\r\nHost: shop.example\r\n\r\n"), now)
+
+	m := chipsTestModel().WithCapture(table)
+	m.width = 160
+	m.dnsCache = map[string]string{"2.2.2.2": "cdn-2-2-2-2.provider.net"}
+	m = sendData(m, m.snapshot)
+
+	conns := m.snapshot.Applications[0].Connections
+	if got := m.remoteCell(conns[1]); got != "shop.example:80" {
+		t.Errorf("sniffed connection remote = %q, want the SNI/Host name over reverse DNS", got)
+	}
+	if got := m.remoteCell(conns[0]); got != "1.1.1.1:443" {
+		t.Errorf("other connection remote = %q, want its address", got)
+	}
+	if header := stripAnsi(m.renderHeader()); !strings.Contains(header, "pcap") {
+		t.Errorf("header missing the pcap badge:\n%s", header)
+	}
+}
@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/kostyay/netmon/internal/config"
+)
+
+// Table rows are the hot path of every frame: a connection view renders a few
+// hundred of them per refresh. Cells are laid out by display width, since a
+// CJK character or an emoji takes two terminal columns and an escape code
+// none (fmt's %-*s pads by rune count, which pushed the columns after a wide
+// container name out of line), into a buffer reused across rows, and colored
+// with precomputed escape codes instead of a lipgloss Render per row.
+
+// textWidth returns how many terminal columns s takes, ignoring ANSI escape codes.
+func textWidth(s string) int {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c >= 0x80 || c == '\x1b' {
+			return ansi.StringWidth(s)
+		}
+	}
+	return len(s)
+}
+
+// rowWriter builds a table row cell by cell, cells separated by a space.
+// Its buffer is reused from row to row.
+type rowWriter struct {
+	buf   []byte
+	cells int
+}
+
+// reset starts a new row.
+func (w *rowWriter) reset() {
+	w.buf = w.buf[:0]
+	w.cells = 0
+}
+
+// left writes s left-aligned in a cell width columns wide. Like %-*s it does
+// not truncate: callers pass cells already cut to width.
+func (w *rowWriter) left(s string, width int) {
+	w.sep()
+	w.buf = append(w.buf, s...)
+	w.pad(width - textWidth(s))
+}
+
+// right writes s right-aligned in a cell width columns wide.
+func (w *rowWriter) right(s string, width int) {
+	w.sep()
+	w.pad(width - textWidth(s))
+	w.buf = append(w.buf, s...)
+}
+
+// int writes n right-aligned in a cell width columns wide.
+func (w *rowWriter) int(n int64, width int) {
+	w.sep()
+	w.pad(width - len(strconv.FormatInt(n, 10)))
+	w.buf = strconv.AppendInt(w.buf, n, 10)
+}
+
+// offset returns the byte offset in the row at which the next cell starts.
+func (w *rowWriter) offset() int {
+	if w.cells > 0 {
+		return len(w.buf) + 1
+	}
+	return 0
+}
+
+func (w *rowWriter) sep() {
+	if w.cells > 0 {
+		w.buf = append(w.buf, ' ')
+	}
+	w.cells++
+}
+
+func (w *rowWriter) pad(n int) {
+	for ; n > 0; n-- {
+		w.buf = append(w.buf, ' ')
+	}
+}
+
+// String returns the row written since the last reset.
+func (w *rowWriter) String() string {
+	return string(w.buf)
+}
+
+// styleCodes is a style reduced to the escape codes around its text, for
+// styles that only set colors and attributes (no padding, width or borders).
+type styleCodes struct {
+	prefix, suffix string
+}
+
+// codesMarker stands in for the text when rendering a style to find its codes.
+const codesMarker = "\uE000"
+
+// codesOf returns the escape codes style wraps single-line text in.
+func codesOf(style lipgloss.Style) styleCodes {
+	out := style.Render(codesMarker)
+	before, after, ok := strings.Cut(out, codesMarker)
+	if !ok {
+		return styleCodes{}
+	}
+	return styleCodes{before, after}
+}
+
+// render wraps s, which must be a single line, in the codes.
+func (c styleCodes) render(s string) string {
+	return c.prefix + s + c.suffix
+}
+
+// tableStyles are the row styles of the table renderers as escape codes.
+type tableStyles struct {
+	theme *config.Theme
+	plain bool
+
+	conn, selected, added, removed, warn styleCodes
+	connMatch, selectedMatch             styleCodes // fuzzy-matched runes
+}
+
+var cachedTableStyles *tableStyles
+
+// rowStyles returns the table row styles, computed again only when the theme
+// or plain mode changed (both are normally set once, before the model).
+func rowStyles() *tableStyles {
+	if s := cachedTableStyles; s != nil && s.theme == config.CurrentTheme && s.plain == plainMode {
+		return s
+	}
+	conn, selected := ConnStyle(), SelectedConnStyle()
+	cachedTableStyles = &tableStyles{
+		theme:         config.CurrentTheme,
+		plain:         plainMode,
+		conn:          codesOf(conn),
+		selected:      codesOf(selected),
+		added:         codesOf(AddedConnStyle()),
+		removed:       codesOf(RemovedConnStyle()),
+		warn:          codesOf(WarnStyle()),
+		connMatch:     codesOf(conn.Bold(true).Underline(true)),
+		selectedMatch: codesOf(selected.Bold(true).Underline(true)),
+	}
+	return cachedTableStyles
+}
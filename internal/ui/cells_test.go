@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+func TestTextWidth(t *testing.T) {
+	tests := map[string]int{
+		"":                      0,
+		"nginx":                 5,
+		"日本語":                   6,
+		"🐳 web":                 6,
+		"\x1b[1mbold\x1b[0m":    4,
+		"\x1b[31m日本\x1b[0m abc": 8,
+	}
+	for s, want := range tests {
+		if got := textWidth(s); got != want {
+			t.Errorf("textWidth(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func TestTruncateString_WideRunes(t *testing.T) {
+	tests := []struct {
+		s      string
+		maxLen int
+		want   string
+	}{
+		{"日本語コンテナ", 14, "日本語コンテナ"},
+		{"日本語コンテナ", 9, "日本語..."},
+		{"日本語コンテナ", 8, "日本..."}, // a wide rune is never split
+		{"日本語", 3, "日"},
+		{"🐳whale", 5, "🐳..."},
+		{"café-latte", 6, "caf..."},
+	}
+	for _, tt := range tests {
+		got := truncateString(tt.s, tt.maxLen)
+		if got != tt.want {
+			t.Errorf("truncateString(%q, %d) = %q, want %q", tt.s, tt.maxLen, got, tt.want)
+		}
+		if w := textWidth(got); w > tt.maxLen {
+			t.Errorf("truncateString(%q, %d) is %d columns wide", tt.s, tt.maxLen, w)
+		}
+	}
+}
+
+func TestPad_WideRunes(t *testing.T) {
+	if got := padRight("日本", 6); got != "日本  " {
+		t.Errorf("padRight = %q", got)
+	}
+	if got := padLeft("日本", 6); got != "  日本" {
+		t.Errorf("padLeft = %q", got)
+	}
+	if got := padLeft("toolong", 3); got != "toolong" {
+		t.Errorf("padLeft should not truncate, got %q", got)
+	}
+}
+
+func TestRowWriter(t *testing.T) {
+	var w rowWriter
+	w.int(42, 5)
+	start := w.offset()
+	w.left("日本", 6)
+	w.right("1.2 KB", 8)
+	if got, want := w.String(), "   42 日本     1.2 KB"; got != want {
+		t.Errorf("row = %q, want %q", got, want)
+	}
+	if !strings.HasPrefix(w.String()[start:], "日本") {
+		t.Errorf("offset %d does not point at the second cell", start)
+	}
+
+	// The buffer is reused for the next row
+	w.reset()
+	w.left("tcp", 4)
+	if got := w.String(); got != "tcp " {
+		t.Errorf("row after reset = %q", got)
+	}
+}
+
+func TestCodesOf_MatchesRender(t *testing.T) {
+	for _, style := range []lipgloss.Style{ConnStyle(), SelectedConnStyle(), WarnStyle().Bold(true)} {
+		row := "  1234 nginx  12"
+		if got, want := codesOf(style).render(row), style.Render(row); got != want {
+			t.Errorf("codes render %q, Render %q", got, want)
+		}
+	}
+}
+
+func TestProcessList_WideNamesStayAligned(t *testing.T) {
+	m := manyAppsModel(2)
+	m.snapshot.Applications[0].Name = "日本語コンテナ"
+	m.snapshot.Applications[1].Name = "🐳-proxy"
+	m.snapshot.Applications = append(m.snapshot.Applications, model.Application{
+		Name: "plain", PIDs: []int32{1}, Connections: []model.Connection{{PID: 1, Protocol: model.ProtocolTCP}},
+	})
+	m.pipeline.invalidate()
+
+	var widths []int
+	for _, line := range strings.Split(strings.TrimRight(stripAnsi(m.renderProcessListData()), "\n"), "\n") {
+		widths = append(widths, textWidth(line))
+	}
+	if len(widths) != 3 {
+		t.Fatalf("got %d rows, want 3", len(widths))
+	}
+	for _, w := range widths[1:] {
+		if w != widths[0] {
+			t.Fatalf("row widths differ: %v", widths)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/model"
+	"github.com/kostyay/netmon/internal/tlspeek"
+)
+
+// peekTLS fetches the certificate chain of an endpoint (replaced in tests).
+var peekTLS = tlspeek.Peek
+
+// certModalWidth is the width of the certificate modal.
+const certModalWidth = 72
+
+// certPeekTimeout bounds the handshake.
+const certPeekTimeout = 5 * time.Second
+
+// tlsPorts are remote ports that speak TLS from the first byte.
+var tlsPorts = map[int]bool{
+	443: true, 465: true, 636: true, 853: true, 993: true, 995: true,
+	5986: true, 6443: true, 8443: true, 9443: true,
+}
+
+// CertPeekMsg carries the result of a TLS handshake with Addr.
+type CertPeekMsg struct {
+	Addr   string
+	Result tlspeek.Result
+	Err    error
+}
+
+// startCertPeek opens the certificate modal for the selected connection's
+// remote endpoint, handshaking unless the chain is cached.
+func (m Model) startCertPeek() (tea.Model, tea.Cmd) {
+	conn := m.selectedConnection()
+	if conn == nil {
+		return m, nil
+	}
+	port := model.ExtractPort(conn.RemoteAddr)
+	if conn.Protocol != model.ProtocolTCP || !tlsPorts[port] {
+		return m, m.notify(toastWarn, i18n.T("Not a TLS connection (remote port 443, 8443, 993, …)"))
+	}
+	addr := net.JoinHostPort(extractIP(conn.RemoteAddr), strconv.Itoa(port))
+	m.certMode = true
+	m.certAddr = addr
+	m.certErr = nil
+	if _, ok := m.certCache[addr]; ok {
+		return m, nil
+	}
+	return m, m.fetchCert(addr)
+}
+
+// fetchCert returns a command that handshakes with addr.
+func (m *Model) fetchCert(addr string) tea.Cmd {
+	m.certLoading = true
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), certPeekTimeout)
+		defer cancel()
+		res, err := peekTLS(ctx, addr)
+		return CertPeekMsg{Addr: addr, Result: res, Err: err}
+	}
+}
+
+// handleCertPeek caches a successful handshake and shows the result if the
+// modal is still open on that endpoint.
+func (m Model) handleCertPeek(msg CertPeekMsg) (tea.Model, tea.Cmd) {
+	if msg.Err == nil {
+		if m.certCache == nil {
+			m.certCache = make(map[string]tlspeek.Result)
+		}
+		m.certCache[msg.Addr] = msg.Result
+	}
+	if m.certMode && m.certAddr == msg.Addr {
+		m.certLoading = false
+		m.certErr = msg.Err
+	}
+	return m, nil
+}
+
+// handleCertKey handles a key press while the certificate modal is open.
+func (m Model) handleCertKey(key string) (tea.Model, tea.Cmd) {
+	switch {
+	case matchKey(key, KeyEsc, KeyEnter, KeyQuit, KeyCertPeek):
+		m.certMode = false
+		m.certLoading = false
+		m.certErr = nil
+	case matchKey(key, KeyCertRefetch):
+		if m.certLoading {
+			return m, nil
+		}
+		delete(m.certCache, m.certAddr)
+		m.certErr = nil
+		return m, m.fetchCert(m.certAddr)
+	}
+	return m, nil
+}
+
+// renderCertModalContent returns the certificate modal content.
+func (m Model) renderCertModalContent() string {
+	keyStyle := FooterKeyStyle()
+	descStyle := FooterDescStyle()
+	dimStyle := DimmedStyle()
+	warnStyle := WarnStyle()
+	now := time.Now()
+	width := certModalWidth - 6
+
+	footer := keyStyle.Render(KeyCertRefetch.Key) + descStyle.Render(" "+i18n.T("Re-fetch")+"  ") + keyStyle.Render("Esc") + descStyle.Render(" "+i18n.T("Close"))
+	res, cached := m.certCache[m.certAddr]
+	switch {
+	case m.certLoading && !cached:
+		return strings.Join([]string{descStyle.Render(fmt.Sprintf(i18n.T("Handshaking with %s…"), m.certAddr)), "", footer}, "\n")
+	case m.certErr != nil:
+		return strings.Join([]string{
+			warnStyle.Render(fmt.Sprintf(i18n.T("Handshake with %s failed:"), m.certAddr)),
+			descStyle.Render(truncateString(m.certErr.Error(), width)),
+			"", footer,
+		}, "\n")
+	case !cached:
+		return footer
+	}
+
+	lines := []string{descStyle.Render(fmt.Sprintf(i18n.T("%s · %s · fetched %s"), res.Addr, res.Version, formatAgo(now.Sub(res.At))))}
+	if res.VerifyErr != nil {
+		lines = append(lines, warnStyle.Render(truncateString("⚠ Untrusted: "+res.VerifyErr.Error(), width)))
+	} else {
+		lines = append(lines, descStyle.Render("✓ Chains to a system root"))
+	}
+	for i, c := range res.Chain {
+		lines = append(lines, "",
+			keyStyle.Render(fmt.Sprintf("[%d] ", i))+descStyle.Render(truncateString(c.Subject, width-4)),
+			dimStyle.Render("    "+i18n.T("Issuer:  "))+descStyle.Render(truncateString(c.Issuer, width-13)),
+		)
+		if len(c.DNSNames) > 0 {
+			lines = append(lines, dimStyle.Render("    "+i18n.T("Names:   "))+descStyle.Render(truncateString(strings.Join(c.DNSNames, ", "), width-13)))
+		}
+		expiry := formatCertExpiry(c.NotAfter, now)
+		if c.NotAfter.Before(now.Add(14 * 24 * time.Hour)) {
+			expiry = warnStyle.Render(expiry)
+		} else {
+			expiry = descStyle.Render(expiry)
+		}
+		lines = append(lines, dimStyle.Render("    "+i18n.T("Expires: "))+expiry)
+	}
+	lines = append(lines, "", footer)
+	return strings.Join(lines, "\n")
+}
+
+// formatCertExpiry formats an expiry date with the days remaining, e.g.
+// "2026-01-21 (98 days)" or "2025-10-01 (expired)".
+func formatCertExpiry(notAfter, now time.Time) string {
+	date := notAfter.Format("2006-01-02")
+	if notAfter.Before(now) {
+		return date + " (expired)"
+	}
+	return fmt.Sprintf("%s (%d days)", date, int(notAfter.Sub(now).Hours()/24))
+}
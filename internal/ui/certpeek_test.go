@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/tlspeek"
+)
+
+// stubPeekTLS replaces the handshake and counts calls.
+func stubPeekTLS(t *testing.T, res tlspeek.Result, err error) *int {
+	t.Helper()
+	calls := 0
+	orig := peekTLS
+	peekTLS = func(_ context.Context, addr string) (tlspeek.Result, error) {
+		calls++
+		res.Addr = addr
+		return res, err
+	}
+	t.Cleanup(func() { peekTLS = orig })
+	return &calls
+}
+
+func certTestModel() Model {
+	m := chipsTestModel()
+	m.PushView(ViewState{Level: LevelConnections, ProcessName: "chrome", SortColumn: SortRemote, SortAscending: true})
+	return m
+}
+
+// runCmd executes cmd and feeds its message back into the model.
+func runCmd(m Model, cmd tea.Cmd) Model {
+	if cmd == nil {
+		return m
+	}
+	updated, _ := m.Update(cmd())
+	return updated.(Model)
+}
+
+func TestCertPeek_FetchesAndCaches(t *testing.T) {
+	calls := stubPeekTLS(t, tlspeek.Result{
+		Version: "TLS 1.3",
+		Chain: []tlspeek.Cert{
+			{Subject: "CN=one.one.one.one", Issuer: "CN=DigiCert", DNSNames: []string{"one.one.one.one"}, NotAfter: time.Now().Add(90 * 24 * time.Hour)},
+		},
+		At: time.Now(),
+	}, nil)
+	m := certTestModel()
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	m = updated.(Model)
+	if !m.certMode || m.certAddr != "1.1.1.1:443" || !m.certLoading {
+		t.Fatalf("certMode = %v, addr = %q, loading = %v", m.certMode, m.certAddr, m.certLoading)
+	}
+	if !strings.Contains(m.renderCertModalContent(), "Handshaking") {
+		t.Error("modal should show progress while handshaking")
+	}
+
+	m = runCmd(m, cmd)
+	content := m.renderCertModalContent()
+	for _, want := range []string{"TLS 1.3", "CN=one.one.one.one", "CN=DigiCert", "days)"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("modal missing %q:\n%s", want, content)
+		}
+	}
+
+	m = pressSpecial(m, tea.KeyEsc)
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	m = updated.(Model)
+	if cmd != nil || !m.certMode || *calls != 1 {
+		t.Errorf("second peek should use the cache (calls = %d)", *calls)
+	}
+}
+
+func TestCertPeek_Refetch(t *testing.T) {
+	calls := stubPeekTLS(t, tlspeek.Result{Chain: []tlspeek.Cert{{Subject: "CN=x"}}}, nil)
+	m := certTestModel()
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	m = runCmd(updated.(Model), cmd)
+
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m = runCmd(updated.(Model), cmd)
+	if *calls != 2 {
+		t.Errorf("calls = %d, want re-fetch", *calls)
+	}
+	if m.actionMode {
+		t.Error("r in the certificate modal should not open renice")
+	}
+}
+
+func TestCertPeek_HandshakeError(t *testing.T) {
+	stubPeekTLS(t, tlspeek.Result{}, errors.New("connection refused"))
+	m := certTestModel()
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	m = runCmd(updated.(Model), cmd)
+
+	if !strings.Contains(m.renderCertModalContent(), "connection refused") {
+		t.Errorf("modal should show the error:\n%s", m.renderCertModalContent())
+	}
+	if _, ok := m.certCache["1.1.1.1:443"]; ok {
+		t.Error("failed handshakes should not be cached")
+	}
+}
+
+func TestCertPeek_NonTLSPort(t *testing.T) {
+	calls := stubPeekTLS(t, tlspeek.Result{}, nil)
+	m := certTestModel()
+	m.CurrentView().Cursor = 1 // 2.2.2.2:80
+
+	m = pressKey(m, "t")
+	if m.certMode || *calls != 0 {
+		t.Error("port 80 should not be peeked")
+	}
+	if !strings.Contains(toastText(m), "Not a TLS connection") {
+		t.Errorf("toast = %q", toastText(m))
+	}
+}
+
+func TestCertPeek_StaleResultIgnored(t *testing.T) {
+	m := certTestModel()
+	m.certMode, m.certAddr, m.certLoading = true, "3.3.3.3:443", true
+
+	updated, _ := m.Update(CertPeekMsg{Addr: "1.1.1.1:443", Err: errors.New("timeout")})
+	m = updated.(Model)
+	if !m.certLoading || m.certErr != nil {
+		t.Error("a result for another endpoint should not change the open modal")
+	}
+}
+
+func TestFormatCertExpiry(t *testing.T) {
+	now := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	if got := formatCertExpiry(now.Add(98*24*time.Hour), now); got != "2026-01-07 (98 days)" {
+		t.Errorf("got %q", got)
+	}
+	if got := formatCertExpiry(now.Add(-time.Hour), now); got != "2025-09-30 (expired)" {
+		t.Errorf("got %q", got)
+	}
+}
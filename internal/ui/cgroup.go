@@ -0,0 +1,75 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/cgroup"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// lookupCgroup reads a process's cgroup (replaced in tests).
+var lookupCgroup = cgroup.Lookup
+
+// cgroupColumn is the optional process list column shown when Cgroups is enabled.
+var cgroupColumn = columnDef{label: "Unit", id: SortCgroup, minWidth: 14, flex: 2}
+
+// cgroupLabel returns the Unit cell for an app's primary PID: the systemd
+// unit or container, "…" while the lookup is pending, "—" if unknown.
+func (m Model) cgroupLabel(pids []int32) string {
+	if len(pids) == 0 {
+		return "—"
+	}
+	info, ok := m.cgroups[pids[0]]
+	if !ok {
+		return "…"
+	}
+	if info.Unit == "" {
+		return "—"
+	}
+	return info.Unit
+}
+
+// cgroupLine returns the "Cgroup:" line for the connections view header, or
+// "" when disabled or unknown.
+func (m Model) cgroupLine(pids []int32) string {
+	if !m.showCgroups || len(pids) == 0 {
+		return ""
+	}
+	info, ok := m.cgroups[pids[0]]
+	if !ok || info.Path == "" {
+		return ""
+	}
+	return "Cgroup: " + info.Path
+}
+
+// queueCgroupLookups returns a command reading the cgroups of primary PIDs
+// not yet cached. A process only changes cgroup when moved by systemd or a
+// container runtime, so each PID is looked up once.
+func (m Model) queueCgroupLookups(snapshot *model.NetworkSnapshot) tea.Cmd {
+	if !m.showCgroups || snapshot == nil {
+		return nil
+	}
+
+	var pids []int32
+	for _, app := range snapshot.Applications {
+		if len(app.PIDs) == 0 {
+			continue
+		}
+		if _, ok := m.cgroups[app.PIDs[0]]; !ok {
+			pids = append(pids, app.PIDs[0])
+		}
+	}
+	if len(pids) == 0 {
+		return nil
+	}
+
+	return func() tea.Msg {
+		infos := make(map[int32]cgroup.Info, len(pids))
+		for _, pid := range pids {
+			// Failures are cached as an empty Info and shown as "—"
+			info, _ := lookupCgroup(pid)
+			infos[pid] = info
+		}
+		return CgroupsResolvedMsg{Cgroups: infos}
+	}
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/kostyay/netmon/internal/cgroup"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+func TestQueueCgroupLookups(t *testing.T) {
+	m := chipsTestModel()
+	if m.queueCgroupLookups(m.snapshot) != nil {
+		t.Error("no lookups expected when disabled")
+	}
+
+	m.showCgroups = true
+	m.cgroups = map[int32]cgroup.Info{10: {Path: "/system.slice/chrome.service", Unit: "chrome.service"}}
+	var looked []int32
+	orig := lookupCgroup
+	t.Cleanup(func() { lookupCgroup = orig })
+	lookupCgroup = func(pid int32) (cgroup.Info, error) {
+		looked = append(looked, pid)
+		return cgroup.Info{}, errors.New("no such process")
+	}
+
+	m = runCmd(m, m.queueCgroupLookups(m.snapshot))
+	if !slices.Equal(looked, []int32{20}) {
+		t.Errorf("looked up %v, want only the uncached PID 20", looked)
+	}
+	if got := m.cgroupLabel([]int32{10}); got != "chrome.service" {
+		t.Errorf("cgroupLabel(10) = %q, want chrome.service", got)
+	}
+	if got := m.cgroupLabel([]int32{20}); got != "—" {
+		t.Errorf("cgroupLabel(20) = %q, want — after a failed lookup", got)
+	}
+	if got := m.cgroupLabel([]int32{30}); got != "…" {
+		t.Errorf("cgroupLabel(30) = %q, want … while pending", got)
+	}
+}
+
+func TestSortProcessList_CgroupGroupsByUnit(t *testing.T) {
+	m := createTestModel()
+	m.showCgroups = true
+	m.snapshot = &model.NetworkSnapshot{Applications: []model.Application{
+		{Name: "celery", PIDs: []int32{1}}, {Name: "gunicorn", PIDs: []int32{2}}, {Name: "python3", PIDs: []int32{3}},
+	}}
+	m.cgroups = map[int32]cgroup.Info{
+		1: {Unit: "worker.service"}, 2: {Unit: "api.service"}, 3: {Unit: "worker.service"},
+	}
+	if cols := m.activeProcessListColumns(); cols[len(cols)-1].id != SortCgroup {
+		t.Fatal("Cgroups should add the Unit column")
+	}
+	view := m.CurrentView()
+	view.SortColumn, view.SortAscending = SortCgroup, true
+	if got := strings.Join(appNames(m.sortProcessList(m.snapshot.Applications)), ","); got != "gunicorn,celery,python3" {
+		t.Errorf("sorted by Unit = %v, want gunicorn,celery,python3", got)
+	}
+}
@@ -0,0 +1,180 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/kostyay/netmon/internal/config"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// changeHighlightDuration is how long a connection change stays marked.
+const changeHighlightDuration = 3 * time.Second
+
+// changeStyle is how connection changes are shown (Change Style setting).
+type changeStyle string
+
+const (
+	changeFlash  changeStyle = "flash"  // color the row's text while the change is fresh
+	changeFade   changeStyle = "fade"   // background that fades out over changeHighlightDuration
+	changeGutter changeStyle = "gutter" // +/-/~ marker left of the row, text unchanged
+	changeCount  changeStyle = "count"  // no row styling; counts in the header
+)
+
+// changeStyles lists the styles in the order the setting cycles through them.
+var changeStyles = []changeStyle{changeFlash, changeFade, changeGutter, changeCount}
+
+// parseChangeStyle returns the style named s, or flash for unknown or empty names.
+func parseChangeStyle(s string) changeStyle {
+	for _, cs := range changeStyles {
+		if string(cs) == s {
+			return cs
+		}
+	}
+	return changeFlash
+}
+
+// next returns the style after s, wrapping around.
+func (s changeStyle) next() changeStyle {
+	for i, cs := range changeStyles {
+		if cs == s {
+			return changeStyles[(i+1)%len(changeStyles)]
+		}
+	}
+	return changeFlash
+}
+
+// fadeSteps is how many background shades a fading row goes through. Steps
+// keep the row cache effective: a row re-renders only when its shade changes.
+const fadeSteps = 3
+
+// rowMark is how a row's change is drawn. It is comparable so the row cache
+// can tell when a cached row's highlight went stale.
+type rowMark struct {
+	changed  bool
+	kind     ChangeType
+	style    changeStyle
+	step     uint8 // fade shade, 0 = freshest
+	outside  bool  // violates the --allow-file allowlist
+	conflict bool  // host socket on a port Docker publishes
+	region   bool  // remote IP in one of the unexpectedCountries
+}
+
+// rowMark returns how conn's change, if any, is drawn in the current style.
+// The count style marks no rows.
+func (m Model) rowMark(conn model.Connection) rowMark {
+	mark := rowMark{outside: m.outsideAllowlist(conn), conflict: m.conflictingSocket(conn),
+		region: m.unexpectedRegion(conn.RemoteAddr)}
+	change := m.GetChange(conn)
+	if change == nil || m.changeStyle == changeCount {
+		return mark
+	}
+	mark.changed, mark.kind, mark.style = true, change.Type, m.changeStyle
+	if m.changeStyle == changeFade {
+		mark.step = fadeStep(time.Since(change.Timestamp))
+	}
+	return mark
+}
+
+// fadeStep maps a change's age to its fade shade, 0 to fadeSteps-1.
+func fadeStep(age time.Duration) uint8 {
+	step := int(age * fadeSteps / changeHighlightDuration)
+	return uint8(max(0, min(step, fadeSteps-1)))
+}
+
+// changeColor returns the theme color of a change kind.
+func changeColor(kind ChangeType) config.Color {
+	switch kind {
+	case ChangeRemoved:
+		return config.CurrentTheme.Styles.Table.RemovedFgColor
+	case ChangeModified:
+		return config.CurrentTheme.Styles.Header.WarnFg
+	default:
+		return config.CurrentTheme.Styles.Table.AddedFgColor
+	}
+}
+
+// gutterMarker returns the gutter symbol of a change kind.
+func gutterMarker(kind ChangeType) string {
+	switch kind {
+	case ChangeRemoved:
+		return "-"
+	case ChangeModified:
+		return "~"
+	default:
+		return "+"
+	}
+}
+
+// renderGutterMarker renders the gutter symbol of a change kind in its color.
+func renderGutterMarker(kind ChangeType) string {
+	return lipgloss.NewStyle().
+		Foreground(themeColor(changeColor(kind))).
+		Bold(true).
+		Render(gutterMarker(kind))
+}
+
+// fadeStyle returns the row style of a fading change: the change color blended
+// toward the table background, one shade per step.
+func fadeStyle(kind ChangeType, step uint8) lipgloss.Style {
+	bg := config.CurrentTheme.Styles.Table.BgColor
+	weight := 1 - float64(step+1)/float64(fadeSteps+1)
+	return ConnStyle().Background(themeColor(blendColor(changeColor(kind), bg, weight)))
+}
+
+// blendColor mixes two "#rrggbb" colors, weight 1 giving a and 0 giving b.
+// Returns a unchanged if either color is not in hex form.
+func blendColor(a, b config.Color, weight float64) config.Color {
+	ar, ag, ab, okA := parseHexColor(a)
+	br, bg, bb, okB := parseHexColor(b)
+	if !okA || !okB {
+		return a
+	}
+	mix := func(x, y uint8) uint8 {
+		return uint8(float64(x)*weight + float64(y)*(1-weight) + 0.5)
+	}
+	return config.Color(fmt.Sprintf("#%02x%02x%02x", mix(ar, br), mix(ag, bg), mix(ab, bb)))
+}
+
+// parseHexColor parses "#rrggbb".
+func parseHexColor(c config.Color) (r, g, b uint8, ok bool) {
+	s := string(c)
+	if len(s) != 7 || s[0] != '#' {
+		return 0, 0, 0, false
+	}
+	v, err := strconv.ParseUint(s[1:], 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return uint8(v >> 16), uint8(v >> 8), uint8(v), true
+}
+
+// changeCounts counts the marked changes by kind.
+func (m Model) changeCounts() (added, modified, removed int) {
+	for _, c := range m.changes {
+		switch c.Type {
+		case ChangeAdded:
+			added++
+		case ChangeModified:
+			modified++
+		case ChangeRemoved:
+			removed++
+		}
+	}
+	return added, modified, removed
+}
+
+// changeCountText returns the header counts for the count style, e.g.
+// "Δ +3 ~1 −2", or "" in other styles or when nothing changed recently.
+func (m Model) changeCountText() string {
+	if m.changeStyle != changeCount || !m.highlightChanges || m.largeHost {
+		return ""
+	}
+	added, modified, removed := m.changeCounts()
+	if added+modified+removed == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Δ +%d ~%d −%d", added, modified, removed)
+}
@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kostyay/netmon/internal/config"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+func TestParseChangeStyle(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want changeStyle
+	}{{"", changeFlash}, {"fade", changeFade}, {"gutter", changeGutter}, {"count", changeCount}, {"bogus", changeFlash}} {
+		if got := parseChangeStyle(tt.in); got != tt.want {
+			t.Errorf("parseChangeStyle(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestChangeStyle_NextCycles(t *testing.T) {
+	s := changeFlash
+	var got []string
+	for range changeStyles {
+		s = s.next()
+		got = append(got, string(s))
+	}
+	if want := "fade,gutter,count,flash"; strings.Join(got, ",") != want {
+		t.Errorf("cycle = %v, want %s", got, want)
+	}
+}
+
+func TestFadeStep(t *testing.T) {
+	for _, tt := range []struct {
+		age  time.Duration
+		want uint8
+	}{{0, 0}, {999 * time.Millisecond, 0}, {time.Second, 1}, {2500 * time.Millisecond, 2}, {10 * time.Second, 2}, {-time.Second, 0}} {
+		if got := fadeStep(tt.age); got != tt.want {
+			t.Errorf("fadeStep(%v) = %d, want %d", tt.age, got, tt.want)
+		}
+	}
+}
+
+func TestBlendColor(t *testing.T) {
+	a, b := config.Color("#ff0000"), config.Color("#000000")
+	if got := blendColor(a, b, 1); got != a {
+		t.Errorf("weight 1 = %s, want %s", got, a)
+	}
+	if got := blendColor(a, b, 0); got != b {
+		t.Errorf("weight 0 = %s, want %s", got, b)
+	}
+	if got := blendColor(a, b, 0.5); got != "#800000" {
+		t.Errorf("weight 0.5 = %s, want #800000", got)
+	}
+	if got := blendColor("red", b, 0.5); got != "red" {
+		t.Errorf("non-hex color = %s, want it unchanged", got)
+	}
+}
+
+func TestRowMark_PerStyle(t *testing.T) {
+	m := createTestModel()
+	m.highlightChanges = true
+	conn := model.Connection{PID: 1, LocalAddr: "10.0.0.1:1"}
+	m.changes[KeyFromConnection(conn)] = Change{Type: ChangeAdded, Timestamp: time.Now().Add(-2500 * time.Millisecond)}
+
+	m.changeStyle = changeFade
+	if mark := m.rowMark(conn); !mark.changed || mark.step != fadeSteps-1 {
+		t.Errorf("fade mark = %+v, want last fade step", mark)
+	}
+	m.changeStyle = changeGutter
+	if mark := m.rowMark(conn); !mark.changed || mark.step != 0 {
+		t.Errorf("gutter mark = %+v, want changed without fade step", mark)
+	}
+	m.changeStyle = changeCount
+	if mark := m.rowMark(conn); mark.changed {
+		t.Errorf("count mark = %+v, want no row mark", mark)
+	}
+	if mark := m.rowMark(model.Connection{PID: 2}); mark.changed {
+		t.Error("unchanged connection should not be marked")
+	}
+}
+
+func TestRenderRowWithHighlight_Gutter(t *testing.T) {
+	for kind, marker := range map[ChangeType]string{ChangeAdded: "+", ChangeModified: "~", ChangeRemoved: "-"} {
+		out := stripAnsi(renderRowWithHighlight("row", false, rowMark{changed: true, kind: kind, style: changeGutter}))
+		if out != marker+" row\n" {
+			t.Errorf("gutter row for %v = %q, want marker %q", kind, out, marker)
+		}
+	}
+	if out := stripAnsi(renderRowWithHighlight("row", false, rowMark{})); out != "  row\n" {
+		t.Errorf("plain row = %q, want two-space indent", out)
+	}
+}
+
+func TestChangeCountText(t *testing.T) {
+	m := createTestModel()
+	m.highlightChanges = true
+	m.changes[ConnectionKey{PID: 1}] = Change{Type: ChangeAdded}
+	m.changes[ConnectionKey{PID: 2}] = Change{Type: ChangeAdded}
+	m.changes[ConnectionKey{PID: 3}] = Change{Type: ChangeModified}
+	m.changes[ConnectionKey{PID: 4}] = Change{Type: ChangeRemoved}
+
+	if got := m.changeCountText(); got != "" {
+		t.Errorf("flash style count text = %q, want empty", got)
+	}
+	m.changeStyle = changeCount
+	if got, want := m.changeCountText(), "Δ +2 ~1 −1"; got != want {
+		t.Errorf("count text = %q, want %q", got, want)
+	}
+	m.largeHost = true
+	if got := m.changeCountText(); got != "" {
+		t.Errorf("large host count text = %q, want empty", got)
+	}
+}
+
+func TestSettingsToggle_ChangeStyle(t *testing.T) {
+	m := createTestModel()
+	m.changeStyle = changeFlash
+	var item settingItem
+	for _, it := range settingItems() {
+		if it.name == "Change Style" {
+			item = it
+		}
+	}
+	if item.value == nil {
+		t.Fatal("Change Style should show its value")
+	}
+	item.toggle(&m)
+	if m.changeStyle != changeFade || item.value(&m) != "fade" {
+		t.Errorf("changeStyle = %q, want fade after toggle", m.changeStyle)
+	}
+	if config.CurrentSettings.ChangeStyle != "fade" {
+		t.Errorf("persisted ChangeStyle = %q, want fade", config.CurrentSettings.ChangeStyle)
+	}
+}
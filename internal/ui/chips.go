@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kostyay/netmon/internal/i18n"
+)
+
+// Filters are a list of chips combined with AND: each '/' search adds one, so a
+// search can be narrowed step by step ("chrome", then "443", then "ESTAB").
+// Chip mode ('f') selects a chip in the footer to remove it on its own.
+
+// currentFilters returns the filter chips in effect, including the search being
+// typed so results narrow live.
+func (m Model) currentFilters() []string {
+	if m.searchMode && m.searchQuery != "" {
+		return append(slices.Clip(m.filterChips), m.searchQuery)
+	}
+	return m.filterChips
+}
+
+// currentFilter returns the filters as one display string, e.g. "chrome + 443", or "".
+func (m Model) currentFilter() string {
+	return strings.Join(m.currentFilters(), " + ")
+}
+
+// setFilter replaces all chips with filter (none if empty).
+func (m *Model) setFilter(filter string) {
+	m.filterChips = nil
+	m.addFilterChip(filter)
+}
+
+// clearFilters removes every chip.
+func (m *Model) clearFilters() {
+	m.filterChips = nil
+	m.chipMode = false
+	m.chipCursor = 0
+}
+
+// addFilterChip appends filter as a chip unless it is empty or already present.
+func (m *Model) addFilterChip(filter string) {
+	filter = strings.TrimSpace(filter)
+	if filter == "" || slices.Contains(m.filterChips, filter) {
+		return
+	}
+	m.filterChips = append(slices.Clip(m.filterChips), filter)
+}
+
+// removeFilterChip deletes the chip at idx. Removing the CLI filter's chip also
+// drops its exact port matching, so retyping it searches by substring.
+func (m *Model) removeFilterChip(idx int) {
+	if idx < 0 || idx >= len(m.filterChips) {
+		return
+	}
+	if m.filterChips[idx] == m.cliFilter {
+		m.cliFilter = ""
+	}
+	m.filterChips = slices.Delete(slices.Clone(m.filterChips), idx, idx+1)
+	if m.chipCursor >= len(m.filterChips) {
+		m.chipCursor = max(len(m.filterChips)-1, 0)
+	}
+	if len(m.filterChips) == 0 {
+		m.chipMode = false
+	}
+}
+
+// enterChipMode starts chip selection on the most recently added chip.
+func (m *Model) enterChipMode() tea.Cmd {
+	if len(m.filterChips) == 0 {
+		return m.notify(toastInfo, i18n.T("No filters (use / to add one)"))
+	}
+	m.chipMode = true
+	m.chipCursor = len(m.filterChips) - 1
+	return nil
+}
+
+// handleChipKey handles a key press while selecting filter chips.
+func (m *Model) handleChipKey(key string) {
+	switch {
+	case matchKey(key, KeyLeft, KeyLeftAlt):
+		if m.chipCursor > 0 {
+			m.chipCursor--
+		}
+	case matchKey(key, KeyRight, KeyRightAlt):
+		if m.chipCursor < len(m.filterChips)-1 {
+			m.chipCursor++
+		}
+	case matchKey(key, KeyChipRemove, KeyChipRemoveAlt, KeyBack):
+		m.removeFilterChip(m.chipCursor)
+		m.clampCursor()
+	case matchKey(key, KeyChipClear):
+		m.clearFilters()
+		m.clampCursor()
+	case matchKey(key, KeyEsc, KeyEnter, KeyFilterChips):
+		m.chipMode = false
+	}
+}
+
+// renderFilterChips returns the chips as "[chrome] [443]", highlighting the
+// selected chip in chip mode, or "" without filters.
+func (m Model) renderFilterChips() string {
+	selected := lipgloss.NewStyle().Reverse(true)
+	chips := make([]string, len(m.filterChips))
+	for i, c := range m.filterChips {
+		chip := "[" + truncateString(c, 24) + "]"
+		if m.chipMode && i == m.chipCursor {
+			chip = selected.Render(chip)
+		}
+		chips[i] = chip
+	}
+	return strings.Join(chips, " ")
+}
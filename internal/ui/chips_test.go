@@ -0,0 +1,228 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/config"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// chipsTestModel has chrome on 443 and 80, and curl on 443.
+func chipsTestModel() Model {
+	m := createTestModel()
+	m.snapshot = &model.NetworkSnapshot{Applications: []model.Application{
+		{Name: "chrome", PIDs: []int32{10}, Connections: []model.Connection{
+			{Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.2:50000", RemoteAddr: "1.1.1.1:443", State: model.StateEstablished, PID: 10},
+			{Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.2:50001", RemoteAddr: "2.2.2.2:80", State: model.StateEstablished, PID: 10},
+		}},
+		{Name: "curl", PIDs: []int32{20}, Connections: []model.Connection{
+			{Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.2:50002", RemoteAddr: "3.3.3.3:443", State: model.StateEstablished, PID: 20},
+		}},
+	}}
+	return m
+}
+
+func appNames(apps []model.Application) []string {
+	var names []string
+	for _, a := range apps {
+		names = append(names, a.Name)
+	}
+	return names
+}
+
+func TestFilterChips_CombineWithAND(t *testing.T) {
+	m := chipsTestModel()
+
+	m.filterChips = []string{"443"}
+	if got := appNames(m.filteredApps()); !slices.Equal(got, []string{"chrome", "curl"}) {
+		t.Errorf("443 -> %v, want chrome and curl", got)
+	}
+	m.filterChips = []string{"443", "chrome"}
+	if got := appNames(m.filteredApps()); !slices.Equal(got, []string{"chrome"}) {
+		t.Errorf("443 + chrome -> %v, want chrome", got)
+	}
+	m.filterChips = []string{"443", "2.2.2.2"}
+	if got := appNames(m.filteredApps()); len(got) != 0 {
+		t.Errorf("443 + 2.2.2.2 -> %v, want none: no single connection matches both", got)
+	}
+
+	m.filterChips = []string{"chrome", "1.1.1"}
+	if got := m.filteredAllConnections(); len(got) != 1 || got[0].RemoteAddr != "1.1.1.1:443" {
+		t.Errorf("flat view chrome + 1.1.1 = %+v, want the 443 connection only", got)
+	}
+}
+
+func TestFilterChips_SearchAddsChip(t *testing.T) {
+	m := chipsTestModel()
+
+	m = typeKeys(m, "/443")
+	if got := appNames(m.filteredApps()); len(got) != 2 {
+		t.Errorf("live search should apply while typing, got %v", got)
+	}
+	m = pressSpecial(m, tea.KeyEnter)
+	m = typeKeys(m, "/curl")
+	m = pressSpecial(m, tea.KeyEnter)
+
+	if !slices.Equal(m.filterChips, []string{"443", "curl"}) {
+		t.Fatalf("filterChips = %q, want [443 curl]", m.filterChips)
+	}
+	if m.searchQuery != "" {
+		t.Errorf("searchQuery = %q, want cleared after adding chip", m.searchQuery)
+	}
+	if got := appNames(m.filteredApps()); !slices.Equal(got, []string{"curl"}) {
+		t.Errorf("filtered = %v, want curl", got)
+	}
+}
+
+func TestFilterChips_SearchEscKeepsChips(t *testing.T) {
+	m := chipsTestModel()
+	m.filterChips = []string{"443"}
+
+	m = typeKeys(m, "/zzz")
+	m = pressSpecial(m, tea.KeyEsc)
+
+	if m.searchMode || m.searchQuery != "" {
+		t.Error("Esc should leave search and discard the query")
+	}
+	if !slices.Equal(m.filterChips, []string{"443"}) {
+		t.Errorf("filterChips = %q, want confirmed chips kept", m.filterChips)
+	}
+}
+
+func TestFilterChips_BackspaceEditsLastChip(t *testing.T) {
+	m := chipsTestModel()
+	m.filterChips = []string{"443", "curl"}
+
+	m = typeKeys(m, "/")
+	m = pressSpecial(m, tea.KeyBackspace)
+
+	if !slices.Equal(m.filterChips, []string{"443"}) || m.searchQuery != "curl" {
+		t.Errorf("chips = %q, query = %q; want last chip moved back into the query", m.filterChips, m.searchQuery)
+	}
+}
+
+func TestFilterChips_DuplicateAndBlankIgnored(t *testing.T) {
+	m := chipsTestModel()
+	m.addFilterChip("443")
+	m.addFilterChip("443")
+	m.addFilterChip("  ")
+	if !slices.Equal(m.filterChips, []string{"443"}) {
+		t.Errorf("filterChips = %q, want [443]", m.filterChips)
+	}
+}
+
+func TestChipMode_RemoveSelected(t *testing.T) {
+	m := chipsTestModel()
+	m.filterChips = []string{"443", "chrome", "ESTAB"}
+
+	m = typeKeys(m, "f")
+	if !m.chipMode || m.chipCursor != 2 {
+		t.Fatalf("chipMode = %v, cursor = %d; want last chip selected", m.chipMode, m.chipCursor)
+	}
+	m = pressSpecial(m, tea.KeyLeft)
+	m = typeKeys(m, "d")
+
+	if !slices.Equal(m.filterChips, []string{"443", "ESTAB"}) {
+		t.Errorf("filterChips = %q, want chrome removed", m.filterChips)
+	}
+	if !m.chipMode {
+		t.Error("chip mode should stay open while chips remain")
+	}
+
+	m = typeKeys(m, "dd")
+	if len(m.filterChips) != 0 || m.chipMode {
+		t.Errorf("chips = %q, chipMode = %v; want empty and mode closed", m.filterChips, m.chipMode)
+	}
+}
+
+func TestChipMode_KeysDoNotLeak(t *testing.T) {
+	m := chipsTestModel()
+	m.filterChips = []string{"443"}
+
+	m = typeKeys(m, "fq")
+	if m.quitting {
+		t.Error("q in chip mode should not quit")
+	}
+	m = pressSpecial(m, tea.KeyEsc)
+	if m.chipMode {
+		t.Error("Esc should leave chip mode")
+	}
+	if !slices.Equal(m.filterChips, []string{"443"}) {
+		t.Errorf("filterChips = %q, want unchanged", m.filterChips)
+	}
+}
+
+func TestChipMode_ClearAll(t *testing.T) {
+	m := chipsTestModel()
+	m.filterChips = []string{"443", "chrome"}
+	m = typeKeys(m, "fc")
+	if len(m.filterChips) != 0 || m.chipMode {
+		t.Errorf("chips = %q, chipMode = %v; want all cleared", m.filterChips, m.chipMode)
+	}
+}
+
+func TestChipMode_NoChips(t *testing.T) {
+	m := chipsTestModel()
+	m = typeKeys(m, "f")
+	if m.chipMode {
+		t.Error("chip mode should not open without filters")
+	}
+	if !strings.Contains(toastText(m), "No filters") {
+		t.Errorf("toast = %q", toastText(m))
+	}
+}
+
+func TestFilterChips_CLIChipExactUntilRemoved(t *testing.T) {
+	m := chipsTestModel().WithFilter("44")
+	if got := m.filteredApps(); len(got) != 0 {
+		t.Errorf("CLI filter 44 should match ports exactly, got %v", appNames(got))
+	}
+
+	m.addFilterChip("chrome")
+	m.removeFilterChip(0)
+	m.addFilterChip("44")
+	if got := appNames(m.filteredApps()); !slices.Equal(got, []string{"chrome"}) {
+		t.Errorf("retyped 44 + chrome = %v, want substring match on chrome", got)
+	}
+}
+
+func TestRenderFooter_ShowsChips(t *testing.T) {
+	m := chipsTestModel()
+	m.width = 160
+	m.filterChips = []string{"443", "chrome"}
+
+	footer := m.renderFooter()
+	if !strings.Contains(footer, "[443] [chrome]") {
+		t.Errorf("footer should list chips, got:\n%s", footer)
+	}
+	if !strings.Contains(footer, "filters") {
+		t.Error("footer should offer chip selection when chips exist")
+	}
+
+	m.chipMode = true
+	if !strings.Contains(m.renderFooter(), "remove") {
+		t.Error("chip mode footer should show chip keys")
+	}
+}
+
+func TestPresets_MultipleChips(t *testing.T) {
+	isolatePresets(t)
+	m := chipsTestModel()
+	m.filterChips = []string{"443", "chrome"}
+
+	m.savePreset("chrome-tls", m.filterChips)
+	m.clearFilters()
+	m.openPresets()
+	m.applyPreset(0)
+
+	if !slices.Equal(m.filterChips, []string{"443", "chrome"}) {
+		t.Errorf("filterChips = %q, want preset chips restored", m.filterChips)
+	}
+	if got := config.CurrentSettings.FilterPresets; len(got) != 1 || !slices.Equal(got[0].Filters, []string{"443", "chrome"}) {
+		t.Errorf("persisted presets = %+v", got)
+	}
+}
@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// churnWindow is the sliding window over which connection churn rates are averaged.
+const churnWindow = 10 * time.Second
+
+// churnSample holds opened/closed connection counts from one snapshot diff.
+type churnSample struct {
+	at      time.Time
+	elapsed time.Duration  // time since the previous snapshot
+	opened  map[string]int // process name -> connections opened
+	closed  map[string]int // process name -> connections closed
+	total   struct{ opened, closed int }
+}
+
+// churnRate is a connection open/close rate in connections per second.
+type churnRate struct {
+	Opened float64
+	Closed float64
+}
+
+// Optional process list columns for churn.
+var churnColumns = []columnDef{
+	{label: "New/s", id: SortNewRate, minWidth: 6, flex: 0, rightAlign: true},
+	{label: "Closed/s", id: SortClosedRate, minWidth: 8, flex: 0, rightAlign: true},
+}
+
+// countChurn counts connections opened and closed between two snapshots, per process name.
+// Uses the same connection identity as the diff layer (ConnectionKey).
+func countChurn(prev, curr *model.NetworkSnapshot) churnSample {
+	s := churnSample{opened: map[string]int{}, closed: map[string]int{}}
+	if prev == nil || curr == nil {
+		return s
+	}
+
+	prevSet := make(map[ConnectionKey]string)
+	currSet := make(map[ConnectionKey]string)
+	for _, app := range prev.Applications {
+		for _, conn := range app.Connections {
+			prevSet[KeyFromConnection(conn)] = app.Name
+		}
+	}
+	for _, app := range curr.Applications {
+		for _, conn := range app.Connections {
+			currSet[KeyFromConnection(conn)] = app.Name
+		}
+	}
+
+	for key, name := range currSet {
+		if _, found := prevSet[key]; !found {
+			s.opened[name]++
+			s.total.opened++
+		}
+	}
+	for key, name := range prevSet {
+		if _, found := currSet[key]; !found {
+			s.closed[name]++
+			s.total.closed++
+		}
+	}
+	return s
+}
+
+// recordChurn adds a churn sample for the transition prev -> curr and drops samples
+// older than churnWindow. Transitions from or to a sampled snapshot are not
+// counted, as connections leaving the sample would look closed.
+func (m *Model) recordChurn(prev, curr *model.NetworkSnapshot) {
+	if prev == nil || curr == nil || prev.Sample != nil || curr.Sample != nil {
+		return
+	}
+	elapsed := curr.Timestamp.Sub(prev.Timestamp)
+	if elapsed <= 0 {
+		return
+	}
+	s := countChurn(prev, curr)
+	s.at = curr.Timestamp
+	s.elapsed = elapsed
+	m.recordSessionOpened(s)
+
+	cutoff := curr.Timestamp.Add(-churnWindow)
+	kept := m.churnSamples[:0]
+	for _, old := range m.churnSamples {
+		if old.at.After(cutoff) {
+			kept = append(kept, old)
+		}
+	}
+	m.churnSamples = append(kept, s)
+}
+
+// churnRate returns the averaged churn rate for a process, or globally when name is "".
+func (m Model) churnRate(name string) churnRate {
+	var span time.Duration
+	var opened, closed int
+	for _, s := range m.churnSamples {
+		span += s.elapsed
+		if name == "" {
+			opened += s.total.opened
+			closed += s.total.closed
+		} else {
+			opened += s.opened[name]
+			closed += s.closed[name]
+		}
+	}
+	if span <= 0 {
+		return churnRate{}
+	}
+	secs := span.Seconds()
+	return churnRate{Opened: float64(opened) / secs, Closed: float64(closed) / secs}
+}
+
+// formatRate formats a per-second rate compactly ("0", "0.3", "12").
+func formatRate(r float64) string {
+	switch {
+	case r == 0:
+		return "0"
+	case r < 10:
+		return fmt.Sprintf("%.1f", r)
+	default:
+		return fmt.Sprintf("%.0f", r)
+	}
+}
@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+func churnSnapshot(at time.Time, apps ...model.Application) *model.NetworkSnapshot {
+	return &model.NetworkSnapshot{Timestamp: at, Applications: apps}
+}
+
+func churnApp(name string, pid int32, remotes ...string) model.Application {
+	app := model.Application{Name: name, PIDs: []int32{pid}}
+	for _, r := range remotes {
+		app.Connections = append(app.Connections, model.Connection{
+			PID: pid, Protocol: model.ProtocolTCP, LocalAddr: "127.0.0.1:5000", RemoteAddr: r, State: model.StateEstablished,
+		})
+	}
+	return app
+}
+
+func TestCountChurn(t *testing.T) {
+	t0 := time.Unix(1000, 0)
+	prev := churnSnapshot(t0, churnApp("curl", 1, "1.1.1.1:443", "2.2.2.2:443"), churnApp("ssh", 2, "3.3.3.3:22"))
+	curr := churnSnapshot(t0.Add(time.Second), churnApp("curl", 1, "2.2.2.2:443", "4.4.4.4:443", "5.5.5.5:443"))
+
+	s := countChurn(prev, curr)
+	if s.total.opened != 2 || s.total.closed != 2 {
+		t.Errorf("totals = +%d -%d, want +2 -2", s.total.opened, s.total.closed)
+	}
+	if s.opened["curl"] != 2 || s.closed["curl"] != 1 || s.closed["ssh"] != 1 {
+		t.Errorf("per-process = opened %v closed %v", s.opened, s.closed)
+	}
+}
+
+func TestRecordChurn_RatesAndWindow(t *testing.T) {
+	m := createTestModel()
+	t0 := time.Unix(1000, 0)
+	s0 := churnSnapshot(t0, churnApp("curl", 1))
+	s1 := churnSnapshot(t0.Add(2*time.Second), churnApp("curl", 1, "1.1.1.1:443", "2.2.2.2:443"))
+	s2 := churnSnapshot(t0.Add(4*time.Second), churnApp("curl", 1, "2.2.2.2:443"))
+
+	m.recordChurn(s0, s1)
+	m.recordChurn(s1, s2)
+
+	// 2 opened and 1 closed over 4 seconds
+	if r := m.churnRate(""); r.Opened != 0.5 || r.Closed != 0.25 {
+		t.Errorf("global rate = %+v, want {0.5 0.25}", r)
+	}
+	if r := m.churnRate("curl"); r.Opened != 0.5 {
+		t.Errorf("curl opened rate = %v, want 0.5", r.Opened)
+	}
+	if r := m.churnRate("ssh"); r.Opened != 0 || r.Closed != 0 {
+		t.Errorf("ssh rate = %+v, want zero", r)
+	}
+
+	// A quiet snapshot well past the window evicts older samples.
+	s3 := churnSnapshot(t0.Add(4*time.Second+churnWindow), churnApp("curl", 1, "2.2.2.2:443"))
+	m.recordChurn(s2, s3)
+	if len(m.churnSamples) != 1 {
+		t.Errorf("samples = %d, want 1 after window expiry", len(m.churnSamples))
+	}
+	if r := m.churnRate(""); r.Opened != 0 || r.Closed != 0 {
+		t.Errorf("rate after expiry = %+v, want zero", r)
+	}
+}
+
+func TestRecordChurn_IgnoresFirstSnapshot(t *testing.T) {
+	m := createTestModel()
+	m.recordChurn(nil, churnSnapshot(time.Now(), churnApp("curl", 1, "1.1.1.1:443")))
+	if len(m.churnSamples) != 0 {
+		t.Error("no churn should be recorded without a previous snapshot")
+	}
+}
+
+func TestFormatRate(t *testing.T) {
+	tests := map[float64]string{0: "0", 0.25: "0.2", 3: "3.0", 12.4: "12"}
+	for in, want := range tests {
+		if got := formatRate(in); got != want {
+			t.Errorf("formatRate(%v) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestActiveProcessListColumns_Churn(t *testing.T) {
+	m := createTestModel()
+	m.churnColumns = true
+	m.securityContext = true
+	cols := m.activeProcessListColumns()
+	base := len(processListColumns())
+	if len(cols) != base+3 {
+		t.Fatalf("columns = %d, want %d", len(cols), base+3)
+	}
+	if cols[base].id != SortNewRate || cols[base+1].id != SortClosedRate || cols[base+2].id != SortSecurity {
+		t.Errorf("optional columns = %v %v %v, want New/s Closed/s Security", cols[base].id, cols[base+1].id, cols[base+2].id)
+	}
+}
+
+func TestChurn_RenderedInHeaderAndColumns(t *testing.T) {
+	m := createTestModel()
+	m.churnColumns = true
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	m = updated.(Model)
+
+	view := m.View()
+	if !strings.Contains(view, "conn/s") {
+		t.Error("header should show connection churn rate")
+	}
+	if !strings.Contains(view, "New/s") || !strings.Contains(view, "Closed/s") {
+		t.Error("process list should show churn columns when enabled")
+	}
+}
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/audit"
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/model"
+	"github.com/kostyay/netmon/internal/netlink"
+)
+
+// destroyTCP closes a single TCP socket (replaced in tests).
+var destroyTCP = netlink.DestroyTCP
+
+// closeTargetInfo holds info about the connection to be closed.
+type closeTargetInfo struct {
+	PID         int32
+	ProcessName string
+	LocalAddr   string
+	RemoteAddr  string
+}
+
+// enterCloseMode opens the close-connection confirmation for the selected connection.
+// Only TCP connections with a concrete remote endpoint can be closed.
+func (m Model) enterCloseMode() (tea.Model, tea.Cmd) {
+	conn := m.selectedConnection()
+	if conn == nil {
+		return m, nil
+	}
+	if conn.Protocol != model.ProtocolTCP || conn.RemoteAddr == "*" || conn.State == model.StateListen {
+		return m, m.notify(toastWarn, i18n.T("Only connected TCP sockets can be closed"))
+	}
+	m.closeMode = true
+	m.closeTarget = &closeTargetInfo{
+		PID:         conn.PID,
+		ProcessName: conn.ProcessName,
+		LocalAddr:   conn.LocalAddr,
+		RemoteAddr:  conn.RemoteAddr,
+	}
+	return m, nil
+}
+
+// executeClose destroys the target socket without signalling the owning process.
+func (m Model) executeClose() (tea.Model, tea.Cmd) {
+	target := m.closeTarget
+	m.closeMode = false
+	m.closeTarget = nil
+	if target == nil {
+		return m, nil
+	}
+
+	id, err := netlink.ParseSocketID(target.LocalAddr, target.RemoteAddr)
+	if err == nil {
+		err = destroyTCP(id)
+	}
+	done, failed := countOutcome(err)
+	m.auditAction(audit.Entry{
+		Action: "close",
+		Target: fmt.Sprintf("%s %s → %s", target.ProcessName, target.LocalAddr, target.RemoteAddr),
+		PIDs:   []int32{target.PID},
+	}, done, failed, err)
+
+	var toastCmd tea.Cmd
+	if err != nil {
+		toastCmd = m.notify(toastError, fmt.Sprintf(i18n.T("Failed to close %s → %s: %v"), target.LocalAddr, target.RemoteAddr, err))
+	} else {
+		toastCmd = m.notify(toastSuccess, fmt.Sprintf(i18n.T("Closed %s → %s (%s)"), target.LocalAddr, target.RemoteAddr, target.ProcessName))
+	}
+	return m, tea.Batch(toastCmd, m.fetchData())
+}
+
+// renderCloseModalContent returns the close-connection confirmation modal content.
+func (m Model) renderCloseModalContent() string {
+	if m.closeTarget == nil {
+		return ""
+	}
+
+	dangerStyle := ErrorStyle()
+	descStyle := FooterDescStyle()
+	dimStyle := DimmedStyle()
+
+	lines := []string{
+		"",
+		dangerStyle.Render("  " + i18n.T("Close this connection?")),
+		"",
+		descStyle.Render("  " + fmt.Sprintf(i18n.T("Process: %s (PID %d)"), m.closeTarget.ProcessName, m.closeTarget.PID)),
+		descStyle.Render("  " + fmt.Sprintf(i18n.T("Local:   %s"), m.closeTarget.LocalAddr)),
+		descStyle.Render("  " + fmt.Sprintf(i18n.T("Remote:  %s"), m.closeTarget.RemoteAddr)),
+		"",
+		dimStyle.Render("  " + i18n.T("The process keeps running; the peer gets a RST.")),
+		dimStyle.Render("  " + i18n.T("Linux only, requires root (CAP_NET_ADMIN).")),
+		"",
+		"  " + dangerStyle.Render("↵") + descStyle.Render(" "+i18n.T("Confirm")+"  ") +
+			dangerStyle.Render("Esc") + descStyle.Render(" "+i18n.T("Cancel")),
+	}
+	return strings.Join(lines, "\n")
+}
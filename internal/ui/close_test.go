@@ -0,0 +1,156 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/model"
+	"github.com/kostyay/netmon/internal/netlink"
+)
+
+// closeTestModel returns a model in the connections view of App1 with one
+// established TCP connection and one listening socket.
+func closeTestModel() Model {
+	m := createTestModel()
+	m.snapshot.Applications[0].Connections = []model.Connection{
+		{PID: 100, LocalAddr: "10.0.0.1:5000", RemoteAddr: "1.2.3.4:443", Protocol: model.ProtocolTCP, State: model.StateEstablished},
+		{PID: 100, LocalAddr: "*:8080", RemoteAddr: "*", Protocol: model.ProtocolTCP, State: model.StateListen},
+	}
+	m.stack = []ViewState{{
+		Level:          LevelConnections,
+		ProcessName:    "App1",
+		Cursor:         1, // "*:8080" sorts first, established conn is second
+		SortColumn:     SortLocal,
+		SortAscending:  true,
+		SelectedColumn: SortLocal,
+	}}
+	return m
+}
+
+func pressD(m Model) Model {
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	return updated.(Model)
+}
+
+func stubDestroyTCP(t *testing.T, err error) *[]netlink.SocketID {
+	t.Helper()
+	var calls []netlink.SocketID
+	orig := destroyTCP
+	destroyTCP = func(id netlink.SocketID) error {
+		calls = append(calls, id)
+		return err
+	}
+	t.Cleanup(func() { destroyTCP = orig })
+	return &calls
+}
+
+func TestCloseMode_DEntersCloseMode(t *testing.T) {
+	m := pressD(closeTestModel())
+
+	if !m.closeMode {
+		t.Fatal("closeMode should be true after pressing 'd'")
+	}
+	if m.closeTarget.RemoteAddr != "1.2.3.4:443" {
+		t.Errorf("RemoteAddr = %q, want 1.2.3.4:443", m.closeTarget.RemoteAddr)
+	}
+	if m.closeTarget.ProcessName != "App1" {
+		t.Errorf("ProcessName = %q, want App1", m.closeTarget.ProcessName)
+	}
+}
+
+func TestCloseMode_ListenSocketRejected(t *testing.T) {
+	m := closeTestModel()
+	m.stack[0].Cursor = 0
+	m = pressD(m)
+
+	if m.closeMode {
+		t.Error("closeMode should be false for a listening socket")
+	}
+	if toastText(m) == "" {
+		t.Error("expected status message explaining why close is unavailable")
+	}
+}
+
+func TestCloseMode_ProcessListIgnored(t *testing.T) {
+	m := pressD(createTestModel())
+	if m.closeMode {
+		t.Error("closeMode should be false in process list")
+	}
+}
+
+func TestCloseMode_EscCancels(t *testing.T) {
+	calls := stubDestroyTCP(t, nil)
+	m := pressD(closeTestModel())
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+
+	if m.closeMode || m.closeTarget != nil {
+		t.Error("close mode should be cleared after Esc")
+	}
+	if len(*calls) != 0 {
+		t.Errorf("destroyTCP called %d times, want 0", len(*calls))
+	}
+}
+
+func TestCloseMode_EnterDestroysSocket(t *testing.T) {
+	calls := stubDestroyTCP(t, nil)
+	m := pressD(closeTestModel())
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+
+	if m.closeMode {
+		t.Error("closeMode should be false after confirm")
+	}
+	if len(*calls) != 1 {
+		t.Fatalf("destroyTCP called %d times, want 1", len(*calls))
+	}
+	got := (*calls)[0]
+	if got.Local.String() != "10.0.0.1:5000" || got.Remote.String() != "1.2.3.4:443" {
+		t.Errorf("destroyTCP(%v → %v), want 10.0.0.1:5000 → 1.2.3.4:443", got.Local, got.Remote)
+	}
+	if !strings.HasPrefix(toastText(m), "Closed") {
+		t.Errorf("toast = %q, want prefix Closed", toastText(m))
+	}
+}
+
+func TestCloseMode_EnterReportsError(t *testing.T) {
+	stubDestroyTCP(t, errors.New("operation not permitted"))
+	m := pressD(closeTestModel())
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+
+	if !strings.Contains(toastText(m), "operation not permitted") {
+		t.Errorf("toast = %q, want error text", toastText(m))
+	}
+}
+
+func TestCloseMode_AllConnectionsView(t *testing.T) {
+	m := closeTestModel()
+	m.snapshot.Applications = m.snapshot.Applications[:1]
+	m.stack[0].Level = LevelAllConnections
+	m.stack[0].SortColumn = SortLocal
+	m = pressD(m)
+
+	if !m.closeMode {
+		t.Fatal("closeMode should be true in all-connections view")
+	}
+	if m.closeTarget.LocalAddr != "10.0.0.1:5000" {
+		t.Errorf("LocalAddr = %q, want 10.0.0.1:5000", m.closeTarget.LocalAddr)
+	}
+}
+
+func TestCloseMode_ModalRendered(t *testing.T) {
+	updated, _ := closeTestModel().Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m := pressD(updated.(Model))
+
+	out := m.View()
+	if !strings.Contains(out, "Close Connection") || !strings.Contains(out, "1.2.3.4:443") {
+		t.Error("View should render close-connection modal with remote address")
+	}
+}
@@ -0,0 +1,506 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/docker"
+)
+
+// Help modal sections, in display order.
+const (
+	sectionNavigation = "Navigation"
+	sectionViews      = "Views"
+	sectionSearch     = "Search"
+	sectionActions    = "Actions"
+	sectionOther      = "Other"
+)
+
+var helpSections = []string{sectionNavigation, sectionViews, sectionSearch, sectionActions, sectionOther}
+
+// command is a user action available in normal mode (no modal or input mode
+// open). The registry drives key dispatch, the help modal and the footer hints,
+// and its IDs are what custom keybindings will refer to.
+type command struct {
+	id      string
+	keys    []Keybinding // bound keys; shown in help unless label is set
+	label   string       // help key label overriding keys (e.g. "←→")
+	desc    string
+	section string
+	levels  []ViewLevel           // views where the command applies (nil = all)
+	enabled func(m Model) bool    // extra availability condition (nil = always)
+	match   func(key string) bool // matches keys not listed in keys (quick sort digits)
+	hint    func(m Model) string  // footer label in the current state ("" = none)
+	run     func(m Model, msg tea.KeyMsg) (tea.Model, tea.Cmd)
+}
+
+// hintAlways returns a hint func showing label whenever the command is available.
+func hintAlways(label string) func(Model) string {
+	return func(Model) string { return label }
+}
+
+// hintAt returns a hint func showing label only at the given levels.
+func hintAt(label string, levels ...ViewLevel) func(Model) string {
+	return func(m Model) string {
+		if view := m.CurrentView(); view != nil && containsLevel(levels, view.Level) {
+			return label
+		}
+		return ""
+	}
+}
+
+func containsLevel(levels []ViewLevel, level ViewLevel) bool {
+	for _, l := range levels {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
+
+// connectionLevels are the views listing individual connections.
+var connectionLevels = []ViewLevel{LevelConnections, LevelAllConnections}
+
+// commandRegistry lists the normal-mode commands in footer order.
+var commandRegistry = []command{
+	// Navigation
+	{id: "up", keys: []Keybinding{KeyUp, KeyUpAlt}, desc: "Move up", section: sectionNavigation,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { m.moveUp(); return m, nil }},
+	{id: "down", keys: []Keybinding{KeyDown, KeyDownAlt}, desc: "Move down", section: sectionNavigation,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { m.moveDown(); return m, nil }},
+	{id: "page", keys: []Keybinding{KeyPageUp, KeyPageDown}, desc: "Page up / down", section: sectionNavigation,
+		run: func(m Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+			if matchKey(msg.String(), KeyPageUp) {
+				m.moveCursor(-m.pageSize())
+			} else {
+				m.moveCursor(m.pageSize())
+			}
+			return m, nil
+		}},
+	{id: "half-page", keys: []Keybinding{KeyHalfUp, KeyHalfDown}, desc: "Half page up / down", section: sectionNavigation,
+		run: func(m Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+			step := max(m.pageSize()/2, 1)
+			if matchKey(msg.String(), KeyHalfUp) {
+				step = -step
+			}
+			m.moveCursor(step)
+			return m, nil
+		}},
+	{id: "top", keys: []Keybinding{KeyTop, KeyTopAlt}, label: "home, gg", desc: "Go to first row", section: sectionNavigation,
+		run: func(m Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+			if matchKey(msg.String(), KeyTopAlt) {
+				m.pendingG = true // the second g jumps (see Update)
+				return m, nil
+			}
+			m.moveToTop()
+			return m, nil
+		}},
+	{id: "bottom", keys: []Keybinding{KeyBottom, KeyBottomAlt}, desc: "Go to last row", section: sectionNavigation,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { m.moveToBottom(); return m, nil }},
+	{id: "select", keys: []Keybinding{KeyEnter, KeySpace}, label: "enter", desc: "Select/drill-down", section: sectionNavigation,
+		hint: func(m Model) string {
+			switch m.CurrentView().Level {
+			case LevelProcessList:
+				return "drill"
+			case LevelAllConnections:
+				if c := m.selectedConnection(); c != nil && c.isProxied() {
+					return "proxy legs"
+				}
+			}
+			return ""
+		},
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m.activateSelection() }},
+	{id: "back", keys: []Keybinding{KeyEsc, KeyBack}, desc: "Back/cancel", section: sectionNavigation,
+		hint: hintAt("back", LevelConnections),
+		run:  func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { m.goBack(); return m, nil }},
+	{id: "sort-column", keys: []Keybinding{KeyLeft, KeyLeftAlt, KeyRight, KeyRightAlt}, label: "←→", desc: "Select column (sort mode)", section: sectionViews,
+		run: func(m Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+			step := 1
+			if matchKey(msg.String(), KeyLeft, KeyLeftAlt) {
+				step = -1
+			}
+			m.moveSortColumn(step)
+			return m, nil
+		}},
+
+	// Workspace tabs
+	{id: "new-tab", keys: []Keybinding{KeyNewTab}, desc: KeyNewTab.Desc, section: sectionViews,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m, m.newTab() }},
+	{id: "close-tab", keys: []Keybinding{KeyCloseTab}, desc: KeyCloseTab.Desc, section: sectionViews,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m, m.closeTab() }},
+	{id: "cycle-tab", keys: []Keybinding{KeyNextTab, KeyPrevTab}, desc: "Next / previous tab", section: sectionViews,
+		run: func(m Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+			if matchKey(msg.String(), KeyPrevTab) {
+				return m, m.cycleTab(-1)
+			}
+			return m, m.cycleTab(1)
+		}},
+	{id: "jump-tab", keys: []Keybinding{KeyJumpTab}, desc: KeyJumpTab.Desc, section: sectionViews,
+		match: func(key string) bool { _, ok := tabIndex(key); return ok },
+		run: func(m Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+			i, _ := tabIndex(msg.String())
+			return m, m.switchTab(i)
+		}},
+
+	// Search
+	{id: "search", keys: []Keybinding{KeySearch}, desc: KeySearch.Desc, section: sectionSearch, hint: hintAlways("search"),
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) {
+			// The query becomes a new chip
+			m.searchMode = true
+			m.searchQuery = ""
+			return m, nil
+		}},
+	{id: "filter-chips", keys: []Keybinding{KeyFilterChips}, desc: KeyFilterChips.Desc, section: sectionSearch,
+		hint: func(m Model) string {
+			if len(m.filterChips) > 0 {
+				return "filters"
+			}
+			return ""
+		},
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m, m.enterChipMode() }},
+	{id: "tree-filter", keys: []Keybinding{KeyTree}, desc: KeyTree.Desc, section: sectionSearch,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m.filterToTree() }},
+	{id: "presets", keys: []Keybinding{KeyPresets}, desc: KeyPresets.Desc, section: sectionSearch,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { m.openPresets(); return m, nil }},
+	{id: "match-list", keys: []Keybinding{KeyMatchList}, desc: KeyMatchList.Desc, section: sectionSearch,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { m.startMatchPrompt(); return m, nil }},
+
+	// Views
+	{id: "sort-mode", keys: []Keybinding{KeySortMode}, desc: KeySortMode.Desc, section: sectionViews, hint: hintAlways("sort"),
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { m.enterSortMode(); return m, nil }},
+	{id: "quick-sort", keys: []Keybinding{KeyQuickSort}, desc: KeyQuickSort.Desc, section: sectionViews,
+		match: func(key string) bool { _, ok := quickSortIndex(key); return ok },
+		run: func(m Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+			idx, _ := quickSortIndex(msg.String())
+			m.quickSort(idx)
+			return m, nil
+		}},
+	{id: "auto-sort", keys: []Keybinding{KeyAutoSort}, desc: KeyAutoSort.Desc, section: sectionViews, levels: []ViewLevel{LevelProcessList},
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { m.autoSort(); return m, nil }},
+	{id: "toggle-view", keys: []Keybinding{KeyToggleView}, desc: KeyToggleView.Desc, section: sectionViews,
+		hint: func(m Model) string {
+			switch m.CurrentView().Level {
+			case LevelConntrack, LevelDockerPorts, LevelBinds:
+				return ""
+			case LevelAllConnections:
+				return "grouped"
+			}
+			return "flat"
+		},
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { m.toggleGroupedView(); return m, nil }},
+	{id: "conntrack", keys: []Keybinding{KeyConntrack}, desc: KeyConntrack.Desc, section: sectionViews,
+		hint: hintAt("processes", LevelConntrack),
+		run:  func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m.toggleConntrackView() }},
+	{id: "docker-ports", keys: []Keybinding{KeyDockerPorts}, desc: KeyDockerPorts.Desc, section: sectionViews,
+		hint: hintAt("processes", LevelDockerPorts),
+		run:  func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m.toggleDockerPortsView() }},
+	{id: "binds", keys: []Keybinding{KeyBinds}, desc: KeyBinds.Desc, section: sectionViews,
+		hint: hintAt("processes", LevelBinds),
+		run:  func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m.toggleBindsView() }},
+	{id: "check-port", keys: []Keybinding{KeyCheckPort}, desc: KeyCheckPort.Desc, section: sectionViews,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m.checkPort() }},
+	{id: "dns-activity", keys: []Keybinding{KeyDNSActivity}, desc: KeyDNSActivity.Desc, section: sectionViews,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m.toggleDNSActivity(), nil }},
+	{id: "interfaces", keys: []Keybinding{KeyInterfaces}, desc: KeyInterfaces.Desc, section: sectionViews,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m.toggleInterfaces(), nil }},
+	{id: "countries", keys: []Keybinding{KeyGeo}, desc: KeyGeo.Desc, section: sectionViews,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m.toggleGeo() }},
+	{id: "compare", keys: []Keybinding{KeyCompare}, desc: KeyCompare.Desc, section: sectionViews, levels: []ViewLevel{LevelProcessList},
+		hint: func(m Model) string {
+			if m.compareWith != "" {
+				return "compare with " + m.compareWith
+			}
+			return ""
+		},
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m, m.toggleCompare() }},
+	{id: "dashboard", keys: []Keybinding{KeyDashboard}, desc: KeyDashboard.Desc, section: sectionViews,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m.toggleDashboard() }},
+	{id: "scan-filter", keys: []Keybinding{KeyScanFilter}, desc: KeyScanFilter.Desc, section: sectionViews,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m, m.filterScanSource() }},
+
+	// Actions
+	{id: "kill", keys: []Keybinding{KeyKillTerm}, desc: KeyKillTerm.Desc, section: sectionActions,
+		hint: hintAt("kill", LevelProcessList, LevelConnections, LevelAllConnections),
+		run:  func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m.enterKillMode("SIGTERM") }},
+	{id: "kill-force", keys: []Keybinding{KeyKillForce}, desc: KeyKillForce.Desc, section: sectionActions,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m.enterKillMode("SIGKILL") }},
+	{id: "renice", keys: []Keybinding{KeyRenice}, desc: KeyRenice.Desc, section: sectionActions,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m.enterReniceMode() }},
+	{id: "suspend", keys: []Keybinding{KeySuspend}, desc: KeySuspend.Desc, section: sectionActions,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m.enterSuspendMode() }},
+	{id: "verify-exe", keys: []Keybinding{KeyVerifyExe}, desc: KeyVerifyExe.Desc, section: sectionActions, levels: []ViewLevel{LevelConnections},
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m.startExeVerify() }},
+	{id: "close-connection", keys: []Keybinding{KeyCloseConn}, desc: KeyCloseConn.Desc, section: sectionActions,
+		hint: hintAt("close", connectionLevels...),
+		run:  func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m.enterCloseMode() }},
+	{id: "process-env", keys: []Keybinding{KeyProcessEnv}, desc: KeyProcessEnv.Desc, section: sectionViews, levels: []ViewLevel{LevelConnections},
+		hint: func(m Model) string {
+			if m.processEnv {
+				return "env"
+			}
+			return ""
+		},
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m.toggleProcEnv() }},
+	{id: "cert-peek", keys: []Keybinding{KeyCertPeek}, desc: KeyCertPeek.Desc, section: sectionViews,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m.startCertPeek() }},
+	{id: "asn", keys: []Keybinding{KeyASN}, desc: KeyASN.Desc, section: sectionViews,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m.toggleASN() }},
+	{id: "hosts", keys: []Keybinding{KeyHosts}, desc: KeyHosts.Desc, section: sectionViews,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m.toggleHosts() }},
+	{id: "watch-port", keys: []Keybinding{KeyWatchPort}, desc: KeyWatchPort.Desc, section: sectionActions,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m.togglePortWatch() }},
+	{id: "note", keys: []Keybinding{KeyNote}, desc: KeyNote.Desc, section: sectionActions,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m, m.startNote() }},
+	{id: "tag", keys: []Keybinding{KeyTag}, desc: KeyTag.Desc, section: sectionActions,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m, m.startTag() }},
+	{id: "pin", keys: []Keybinding{KeyPin}, desc: KeyPin.Desc, section: sectionActions,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m, m.togglePin() }},
+	{id: "follow", keys: []Keybinding{KeyFollow}, desc: KeyFollow.Desc, section: sectionActions, levels: []ViewLevel{LevelProcessList, LevelConnections},
+		hint: func(m Model) string {
+			if m.follow != nil {
+				return "unfollow"
+			}
+			return ""
+		},
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m.toggleFollow() }},
+	{id: "self-update", keys: []Keybinding{KeySelfUpdate}, desc: KeySelfUpdate.Desc, section: sectionActions,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { return m.enterUpdateMode() }},
+	{id: "refresh-faster", keys: []Keybinding{KeyRefreshUp, {Key: "="}}, desc: "Faster refresh (this view)", section: sectionActions,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { m.adjustRefresh(-RefreshStep); return m, nil }},
+	{id: "refresh-slower", keys: []Keybinding{KeyRefreshDown, {Key: "_"}}, desc: "Slower refresh (this view)", section: sectionActions,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { m.adjustRefresh(RefreshStep); return m, nil }},
+
+	// Other
+	{id: "palette", keys: []Keybinding{KeyPalette}, desc: KeyPalette.Desc, section: sectionOther,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { m.openPalette(); return m, nil }},
+	{id: "settings", keys: []Keybinding{KeySettings}, desc: KeySettings.Desc, section: sectionOther, hint: hintAlways("settings"),
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) {
+			m.settingsMode = true
+			m.settingsCursor = 0
+			return m, nil
+		}},
+	{id: "diagnostics", keys: []Keybinding{KeyDiagnostics}, desc: KeyDiagnostics.Desc, section: sectionOther,
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { m.diagMode = true; return m, nil }},
+	{id: "help", keys: []Keybinding{KeyHelp}, desc: KeyHelp.Desc, section: sectionOther, hint: hintAlways("help"),
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { m.helpMode = true; return m, nil }},
+	{id: "quit", keys: []Keybinding{KeyQuit, KeyQuitAlt}, desc: "Quit", section: sectionOther, hint: hintAlways("quit"),
+		run: func(m Model, _ tea.KeyMsg) (tea.Model, tea.Cmd) { m.quitting = true; return m, tea.Quit }},
+}
+
+// matches reports whether key triggers the command.
+func (c command) matches(key string) bool {
+	if matchKey(key, c.keys...) {
+		return true
+	}
+	return c.match != nil && c.match(key)
+}
+
+// availableIn reports whether the command applies to the model's current state.
+func (c command) availableIn(m Model) bool {
+	if c.levels != nil {
+		view := m.CurrentView()
+		if view == nil || !containsLevel(c.levels, view.Level) {
+			return false
+		}
+	}
+	return c.enabled == nil || c.enabled(m)
+}
+
+// keyLabel returns how the command's keys are shown in help, e.g. "up, k".
+func (c command) keyLabel() string {
+	if c.label != "" {
+		return c.label
+	}
+	keys := make([]string, len(c.keys))
+	for i, k := range c.keys {
+		keys[i] = k.Key
+	}
+	return strings.Join(keys, ", ")
+}
+
+// commandForKey returns the command bound to key, if any.
+func commandForKey(key string) (command, bool) {
+	for _, c := range commandRegistry {
+		if c.matches(key) {
+			return c, true
+		}
+	}
+	return command{}, false
+}
+
+// runCommand dispatches a normal-mode key. Keys bound to a command that does
+// not apply here are swallowed; handled is false for unbound keys.
+func (m Model) runCommand(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	c, ok := commandForKey(msg.String())
+	if !ok {
+		return m, nil, false
+	}
+	if !c.availableIn(m) {
+		return m, nil, true
+	}
+	model, cmd := c.run(m, msg)
+	return model, cmd, true
+}
+
+// footerHints returns the footer key/label pairs for normal mode, in registry order.
+func (m Model) footerHints() [][2]string {
+	var hints [][2]string
+	for _, c := range commandRegistry {
+		if c.hint == nil || !c.availableIn(m) {
+			continue
+		}
+		if label := c.hint(m); label != "" {
+			hints = append(hints, [2]string{footerKey(c), label})
+		}
+	}
+	return hints
+}
+
+// footerKey returns the compact key shown in the footer.
+func footerKey(c command) string {
+	switch c.keys[0] {
+	case KeyEnter:
+		return "↵"
+	}
+	return c.keys[0].Key
+}
+
+// moveUp moves the cursor up one row, to the last row from the first when
+// Wrap Navigation is on.
+func (m *Model) moveUp() {
+	view := m.CurrentView()
+	if view == nil {
+		return
+	}
+	// Use cursor directly (not resolveSelectionIndex) to handle duplicate items
+	if view.Cursor > 0 {
+		view.Cursor--
+		m.updateSelectedIDFromCursor()
+	} else if m.wrapNav {
+		m.moveToBottom()
+	}
+}
+
+// moveDown moves the cursor down one row, to the first row from the last when
+// Wrap Navigation is on.
+func (m *Model) moveDown() {
+	view := m.CurrentView()
+	if view == nil || m.snapshot == nil {
+		return
+	}
+	maxCursor := m.filteredCount()
+	// Use cursor directly (not resolveSelectionIndex) to handle duplicate items
+	if maxCursor > 0 && view.Cursor < maxCursor-1 {
+		view.Cursor++
+		m.updateSelectedIDFromCursor()
+	} else if m.wrapNav {
+		m.moveToTop()
+	}
+}
+
+// moveToTop moves the cursor to the first row.
+func (m *Model) moveToTop() {
+	if view := m.CurrentView(); view != nil {
+		m.moveCursor(-view.Cursor)
+	}
+}
+
+// moveToBottom moves the cursor to the last row.
+func (m *Model) moveToBottom() {
+	if view := m.CurrentView(); view != nil {
+		m.moveCursor(m.filteredCount() - 1 - view.Cursor)
+	}
+}
+
+// moveSortColumn moves the sort mode column selection by step.
+func (m *Model) moveSortColumn(step int) {
+	view := m.CurrentView()
+	if view == nil || !view.SortMode {
+		return
+	}
+	columns := m.columnsForLevel(view.Level)
+	idx := m.findColumnIndex(columns, view.SelectedColumn) + step
+	if idx >= 0 && idx < len(columns) {
+		view.SelectedColumn = columns[idx]
+	}
+}
+
+// enterSortMode starts column selection at the current sort column.
+func (m *Model) enterSortMode() {
+	view := m.CurrentView()
+	if view == nil || view.SortMode {
+		return
+	}
+	view.SortMode = true
+	view.SelectedColumn = view.SortColumn
+}
+
+// activateSelection applies the selected sort column in sort mode, otherwise
+// drills into the selected process or container, or expands a docker-proxy row.
+func (m Model) activateSelection() (tea.Model, tea.Cmd) {
+	view := m.CurrentView()
+	if view == nil || m.snapshot == nil {
+		return m, nil
+	}
+	if view.SortMode {
+		if view.SortColumn == view.SelectedColumn {
+			view.SortAscending = !view.SortAscending
+		} else {
+			view.SortColumn = view.SelectedColumn
+			view.SortAscending = true
+		}
+		view.SortMode = false
+		m.rememberSort(view)
+		return m, nil
+	}
+	if view.Level == LevelAllConnections {
+		m.toggleProxyDetail()
+		return m, nil
+	}
+	if view.Level != LevelProcessList {
+		return m, nil
+	}
+	apps := m.visibleApps()
+	vcs := m.filteredVirtualContainers()
+	if view.Cursor >= 0 && view.Cursor < len(apps) {
+		app := apps[view.Cursor]
+		m.clearFilters()
+		m.dockerView = docker.IsDockerProcess(app.Name)
+		m.PushView(m.newViewState(LevelConnections, app.Name))
+		if m.dockerView {
+			return m, m.fetchDockerContainers()
+		}
+	} else if vcIdx := view.Cursor - len(apps); vcIdx >= 0 && vcIdx < len(vcs) {
+		// Drill into virtual container row
+		vc := vcs[vcIdx]
+		m.clearFilters()
+		m.dockerView = true
+		m.PushView(m.newViewState(LevelConnections, containerDisplayName(vc)))
+		return m, m.fetchDockerContainers()
+	}
+	return m, nil
+}
+
+// goBack leaves sort mode without changing the sort, otherwise pops the view.
+func (m *Model) goBack() {
+	if view := m.CurrentView(); view != nil && view.SortMode {
+		view.SortMode = false
+		return
+	}
+	m.PopView()
+	m.dockerView = false
+}
+
+// toggleGroupedView switches between the process list and the flat connections view.
+func (m *Model) toggleGroupedView() {
+	view := m.CurrentView()
+	if view == nil {
+		return
+	}
+	if view.Level == LevelAllConnections {
+		m.stack = []ViewState{m.newViewState(LevelProcessList, "")}
+	} else {
+		m.stack = []ViewState{m.newViewState(LevelAllConnections, "")}
+	}
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCommandRegistry_UniqueIDsAndKeys(t *testing.T) {
+	ids := map[string]bool{}
+	keys := map[string]string{}
+	for _, c := range commandRegistry {
+		if ids[c.id] {
+			t.Errorf("duplicate command id %q", c.id)
+		}
+		ids[c.id] = true
+		if c.run == nil || c.section == "" || c.desc == "" {
+			t.Errorf("command %q needs run, section and desc", c.id)
+		}
+		for _, k := range c.keys {
+			if c.match != nil {
+				continue // display-only key like "1-9"
+			}
+			if other, ok := keys[k.Key]; ok {
+				t.Errorf("key %q bound to both %q and %q", k.Key, other, c.id)
+			}
+			keys[k.Key] = c.id
+		}
+	}
+	for key, id := range keys {
+		if c, _ := commandForKey(key); c.id != id {
+			t.Errorf("key %q dispatches to %q, want %q", key, c.id, id)
+		}
+	}
+}
+
+func TestCommandForKey_QuickSortDigits(t *testing.T) {
+	for _, key := range []string{"1", "9"} {
+		if c, ok := commandForKey(key); !ok || c.id != "quick-sort" {
+			t.Errorf("commandForKey(%q) = %q, %v; want quick-sort", key, c.id, ok)
+		}
+	}
+	if _, ok := commandForKey("0"); ok {
+		t.Error(`"0" should not be bound`)
+	}
+}
+
+func TestFooterHints_PerLevel(t *testing.T) {
+	labels := func(m Model) []string {
+		var out []string
+		for _, h := range m.footerHints() {
+			out = append(out, h[0]+" "+h[1])
+		}
+		return out
+	}
+	tests := []struct {
+		level ViewLevel
+		want  []string
+	}{
+		{LevelProcessList, []string{"↵ drill", "/ search", "s sort", "v flat", "x kill", "S settings", "? help", "q quit"}},
+		{LevelConnections, []string{"esc back", "/ search", "s sort", "v flat", "x kill", "d close", "S settings", "? help", "q quit"}},
+		{LevelAllConnections, []string{"/ search", "s sort", "v grouped", "x kill", "d close", "S settings", "? help", "q quit"}},
+		{LevelConntrack, []string{"/ search", "s sort", "c processes", "S settings", "? help", "q quit"}},
+	}
+	for _, tt := range tests {
+		m := createTestModel()
+		m.stack = []ViewState{{Level: tt.level}}
+		if got := labels(m); !slices.Equal(got, tt.want) {
+			t.Errorf("level %v hints = %v, want %v", tt.level, got, tt.want)
+		}
+	}
+
+	m := createTestModel()
+	m.filterChips = []string{"chrome"}
+	if got := labels(m); !slices.Contains(got, "f filters") {
+		t.Errorf("hints with chips = %v, want f filters", got)
+	}
+}
+
+func TestHelpModal_ListsEveryCommand(t *testing.T) {
+	m := createTestModel()
+	content := stripAnsi(m.renderHelpModalContent())
+	for _, section := range helpSections {
+		if !strings.Contains(content, section) {
+			t.Errorf("help missing section %q", section)
+		}
+	}
+	for _, c := range commandRegistry {
+		if !strings.Contains(content, c.keyLabel()+" "+c.desc) {
+			t.Errorf("help missing command %q (%s %s)", c.id, c.keyLabel(), c.desc)
+		}
+	}
+}
+
+func TestRunCommand_UnavailableKeySwallowed(t *testing.T) {
+	m := createTestModel()
+	// Process env only applies in the connections view
+	m = pressKey(m, KeyProcessEnv.Key)
+	if m.envExpanded || toastText(m) != "" {
+		t.Error("e should do nothing outside the connections view")
+	}
+}
@@ -0,0 +1,263 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// Process comparison: 'b' marks the selected process, 'b' on a second one
+// opens a panel with both side by side, their stats and their connections
+// grouped by endpoint in aligned rows, largest difference first.
+const (
+	compareModalWidth = 96
+	compareValueWidth = 14
+	maxCompareRows    = 12
+)
+
+// toggleCompare marks the selected process for comparison, or opens the
+// comparison with the marked one. 'b' on the marked process clears the mark.
+func (m *Model) toggleCompare() tea.Cmd {
+	view := m.CurrentView()
+	if view == nil || view.Level != LevelProcessList {
+		return nil
+	}
+	apps := m.visibleApps()
+	idx := m.resolveSelectionIndex()
+	if idx < 0 || idx >= len(apps) {
+		return m.notify(toastWarn, i18n.T("Select a process to compare"))
+	}
+	name := apps[idx].Name
+
+	switch m.compareWith {
+	case "":
+		m.compareWith = name
+		return m.notify(toastInfo, fmt.Sprintf(i18n.T("Compare %s with: select another process, press %s"), name, KeyCompare.Key))
+	case name:
+		m.compareWith = ""
+		return m.notify(toastInfo, i18n.T("Compare cleared"))
+	}
+	m.compareA, m.compareB = m.compareWith, name
+	m.compareWith = ""
+	m.compareMode = true
+	m.compareCursor = 0
+	return nil
+}
+
+// handleCompareKey handles a key press while the comparison panel is open;
+// up/down scroll the endpoint rows.
+func (m *Model) handleCompareKey(key string) {
+	switch {
+	case matchKey(key, KeyEsc, KeyQuit, KeyCompare):
+		m.compareMode = false
+	case matchKey(key, KeyUp, KeyUpAlt):
+		if m.compareCursor > 0 {
+			m.compareCursor--
+		}
+	case matchKey(key, KeyDown, KeyDownAlt):
+		if m.compareCursor < len(m.compareRows())-maxCompareRows {
+			m.compareCursor++
+		}
+	}
+}
+
+// compareApp returns the named process from the current snapshot, nil once
+// it has exited.
+func (m Model) compareApp(name string) *model.Application {
+	if m.snapshot == nil {
+		return nil
+	}
+	for i := range m.snapshot.Applications {
+		if m.snapshot.Applications[i].Name == name {
+			return &m.snapshot.Applications[i]
+		}
+	}
+	return nil
+}
+
+// compareRow is one aligned line of the comparison: a label and the value
+// for each process.
+type compareRow struct {
+	label string
+	a, b  int
+}
+
+// endpointCounts counts app's connections per endpoint: "in :PORT" for
+// clients of a port it listens on, else the remote host:port. Bound sockets
+// are left to the state counts.
+func (m Model) endpointCounts(app *model.Application) map[string]int {
+	counts := make(map[string]int)
+	if app == nil {
+		return counts
+	}
+	listening := make(map[int]bool)
+	for _, conn := range app.Connections {
+		if conn.IsBound() {
+			listening[model.ExtractPort(conn.LocalAddr)] = true
+		}
+	}
+	for _, conn := range app.Connections {
+		if conn.IsBound() || conn.RemoteAddr == "" {
+			continue
+		}
+		if port := model.ExtractPort(conn.LocalAddr); listening[port] {
+			counts["in :"+strconv.Itoa(port)]++
+			continue
+		}
+		counts[formatRemoteAddr(conn.RemoteAddr, string(conn.Protocol), m.dnsCache, m.serviceNames)]++
+	}
+	return counts
+}
+
+// compareRows aligns the endpoints of the two compared processes, largest
+// difference first.
+func (m Model) compareRows() []compareRow {
+	a := m.endpointCounts(m.compareApp(m.compareA))
+	b := m.endpointCounts(m.compareApp(m.compareB))
+	rows := make([]compareRow, 0, len(a)+len(b))
+	for label, n := range a {
+		rows = append(rows, compareRow{label: label, a: n, b: b[label]})
+	}
+	for label, n := range b {
+		if _, ok := a[label]; !ok {
+			rows = append(rows, compareRow{label: label, b: n})
+		}
+	}
+	slices.SortFunc(rows, func(x, y compareRow) int {
+		return cmp.Or(cmp.Compare(absInt(y.a-y.b), absInt(x.a-x.b)),
+			cmp.Compare(y.a+y.b, x.a+x.b), strings.Compare(x.label, y.label))
+	})
+	return rows
+}
+
+// compareStateRows counts connections per state for both processes, most
+// common first.
+func (m Model) compareStateRows(a, b *model.Application) []compareRow {
+	counts := make(map[string]*compareRow)
+	add := func(app *model.Application, side func(*compareRow)) {
+		if app == nil {
+			return
+		}
+		for _, conn := range app.Connections {
+			label := m.stateLabel(conn)
+			if label == "" {
+				label = "—"
+			}
+			r := counts[label]
+			if r == nil {
+				r = &compareRow{label: label}
+				counts[label] = r
+			}
+			side(r)
+		}
+	}
+	add(a, func(r *compareRow) { r.a++ })
+	add(b, func(r *compareRow) { r.b++ })
+	rows := make([]compareRow, 0, len(counts))
+	for _, r := range counts {
+		rows = append(rows, *r)
+	}
+	slices.SortFunc(rows, func(x, y compareRow) int {
+		return cmp.Or(cmp.Compare(y.a+y.b, x.a+x.b), strings.Compare(x.label, y.label))
+	})
+	return rows
+}
+
+// renderCompareModalContent renders the two processes side by side: stats,
+// connections per state, then connections per endpoint.
+func (m Model) renderCompareModalContent() string {
+	keyStyle := FooterKeyStyle()
+	descStyle := FooterDescStyle()
+	warnStyle := WarnStyle()
+
+	labelWidth := compareModalWidth - 2 - 2*(compareValueWidth+2)
+	line := func(label, a, b string) string {
+		return "  " + padRight(truncateString(label, labelWidth), labelWidth) +
+			"  " + padLeft(truncateString(a, compareValueWidth), compareValueWidth) +
+			"  " + padLeft(truncateString(b, compareValueWidth), compareValueWidth)
+	}
+	count := func(app *model.Application, n int) string {
+		if app == nil {
+			return "-"
+		}
+		return strconv.Itoa(n)
+	}
+
+	appA, appB := m.compareApp(m.compareA), m.compareApp(m.compareB)
+	lines := []string{keyStyle.Render(line("", m.compareA, m.compareB))}
+	for _, name := range []string{m.compareA, m.compareB} {
+		if m.compareApp(name) == nil {
+			lines = append(lines, warnStyle.Render("  "+fmt.Sprintf(i18n.T("%s has exited"), name)))
+		}
+	}
+
+	stat := func(label string, a, b string) {
+		lines = append(lines, descStyle.Render(line(i18n.T(label), a, b)))
+	}
+	pids := func(app *model.Application) string {
+		if app == nil {
+			return "-"
+		}
+		return strconv.Itoa(len(app.PIDs))
+	}
+	conns := func(app *model.Application) string {
+		if app == nil {
+			return "-"
+		}
+		return strconv.Itoa(app.ConnectionCount())
+	}
+	io := func(app *model.Application) (string, string) {
+		if app == nil {
+			return "-", "-"
+		}
+		return m.getAggregatedNetIO(app.PIDs)
+	}
+	stat("Processes", pids(appA), pids(appB))
+	stat("Connections", conns(appA), conns(appB))
+	sentA, recvA := io(appA)
+	sentB, recvB := io(appB)
+	stat("Sent", sentA, sentB)
+	stat("Received", recvA, recvB)
+	for _, r := range m.compareStateRows(appA, appB) {
+		stat("  "+r.label, count(appA, r.a), count(appB, r.b))
+	}
+
+	lines = append(lines, "", keyStyle.Render(line(i18n.T("By endpoint"), "", "")))
+	rows := m.compareRows()
+	if len(rows) == 0 {
+		lines = append(lines, descStyle.Render("  "+i18n.T("No connections with a remote endpoint")))
+	}
+	start := min(m.compareCursor, max(0, len(rows)-maxCompareRows))
+	for _, r := range rows[start:min(len(rows), start+maxCompareRows)] {
+		text := line(r.label, count(appA, r.a), count(appB, r.b))
+		if (r.a == 0) != (r.b == 0) {
+			// Only one of the two talks to this endpoint
+			lines = append(lines, warnStyle.Render(text))
+		} else {
+			lines = append(lines, descStyle.Render(text))
+		}
+	}
+	if len(rows) > maxCompareRows {
+		lines = append(lines, descStyle.Render("  "+fmt.Sprintf(i18n.T("%d–%d of %d endpoints"), start+1, min(len(rows), start+maxCompareRows), len(rows))))
+	}
+
+	lines = append(lines, "", descStyle.Render(i18n.T("Largest difference first. Amber: only one of the two.")),
+		"", keyStyle.Render("j/k")+descStyle.Render(" "+i18n.T("Scroll")+"  ")+
+			keyStyle.Render(KeyCompare.Key)+descStyle.Render("/")+keyStyle.Render("Esc")+descStyle.Render(" "+i18n.T("Close")))
+	return strings.Join(lines, "\n")
+}
+
+// absInt returns the absolute value of n.
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+func TestCompare_MarkThenOpen(t *testing.T) {
+	m := chipsTestModel()
+	m = pressKey(m, "b")
+	if m.compareWith != "chrome" || m.compareMode {
+		t.Fatalf("first b should mark chrome, got %q (mode %v)", m.compareWith, m.compareMode)
+	}
+	m = pressKey(m, "j")
+	m = pressKey(m, "b")
+	if !m.compareMode || m.compareA != "chrome" || m.compareB != "curl" || m.compareWith != "" {
+		t.Fatalf("second b should compare chrome with curl: %q vs %q (mode %v)", m.compareA, m.compareB, m.compareMode)
+	}
+	content := stripAnsi(m.renderCompareModalContent())
+	for _, want := range []string{"chrome", "curl", "Connections", "ESTABLISHED", "By endpoint"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("panel missing %q:\n%s", want, content)
+		}
+	}
+	m = pressKey(m, "b")
+	if m.compareMode {
+		t.Error("b should close the panel")
+	}
+}
+
+func TestCompare_UnmarkSameProcess(t *testing.T) {
+	m := chipsTestModel()
+	m = pressKey(m, "b")
+	m = pressKey(m, "b")
+	if m.compareWith != "" || m.compareMode {
+		t.Errorf("b twice on the same process should clear the mark, got %q (mode %v)", m.compareWith, m.compareMode)
+	}
+}
+
+func TestCompareRows_LargestDifferenceFirst(t *testing.T) {
+	m := chipsTestModel()
+	m.serviceNames = false
+	chrome := &m.snapshot.Applications[0]
+	chrome.Connections = append(chrome.Connections,
+		model.Connection{Protocol: model.ProtocolTCP, LocalAddr: "*:8080", State: model.StateListen, PID: 10},
+		model.Connection{Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.2:8080", RemoteAddr: "4.4.4.4:61000", State: model.StateEstablished, PID: 10},
+		model.Connection{Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.2:8080", RemoteAddr: "5.5.5.5:62000", State: model.StateEstablished, PID: 10},
+	)
+	curl := &m.snapshot.Applications[1]
+	curl.Connections = append(curl.Connections,
+		model.Connection{Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.2:50003", RemoteAddr: "1.1.1.1:443", State: model.StateEstablished, PID: 20})
+	m.compareA, m.compareB = "chrome", "curl"
+
+	rows := m.compareRows()
+	if len(rows) != 4 {
+		t.Fatalf("rows = %+v, want 4 endpoints", rows)
+	}
+	if rows[0] != (compareRow{label: "in :8080", a: 2}) {
+		t.Errorf("first row = %+v, want chrome's 2 inbound clients of :8080", rows[0])
+	}
+	if last := rows[len(rows)-1]; last != (compareRow{label: "1.1.1.1:443", a: 1, b: 1}) {
+		t.Errorf("last row = %+v, want the endpoint both use", last)
+	}
+}
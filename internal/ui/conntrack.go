@@ -0,0 +1,176 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/conntrack"
+	"github.com/kostyay/netmon/internal/i18n"
+)
+
+// conntrackColumns returns the column definitions for the conntrack (NAT) view.
+func conntrackColumns() []columnDef {
+	return []columnDef{
+		{label: "Proto", id: SortProtocol, minWidth: 6, flex: 0},
+		{label: "Original", id: SortOriginal, minWidth: 24, flex: 3},
+		{label: "Reply", id: SortReply, minWidth: 24, flex: 3},
+		{label: "State", id: SortState, minWidth: 11, flex: 0},
+		{label: "NAT", id: SortNAT, minWidth: 9, flex: 0},
+		{label: "TTL", id: SortTTL, minWidth: 6, flex: 0, rightAlign: true},
+	}
+}
+
+// toggleConntrackView switches between the conntrack view and the process list.
+func (m Model) toggleConntrackView() (tea.Model, tea.Cmd) {
+	view := m.CurrentView()
+	if view != nil && view.Level == LevelConntrack {
+		m.stack = []ViewState{m.newViewState(LevelProcessList, "")}
+		return m, nil
+	}
+	m.dockerView = false
+	m.stack = []ViewState{m.newViewState(LevelConntrack, "")}
+	return m, m.fetchConntrack()
+}
+
+// fetchConntrack returns a command that reads the conntrack table.
+func (m Model) fetchConntrack() tea.Cmd {
+	if m.conntrackReader == nil {
+		return nil
+	}
+	reader := m.conntrackReader
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+		entries, err := reader.Read(ctx)
+		return ConntrackMsg{Entries: entries, Err: err}
+	}
+}
+
+// filteredConntrack returns conntrack entries matching every current filter.
+func (m Model) filteredConntrack() []conntrack.Entry {
+	filters := m.currentFilters()
+	if len(filters) == 0 {
+		return m.conntrackEntries
+	}
+	var result []conntrack.Entry
+	for _, e := range m.conntrackEntries {
+		fields := []string{e.Protocol, e.State, e.NAT(), e.Original.String(), e.Reply.String()}
+		if conntrackMatches(fields, filters) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// conntrackMatches reports whether every filter is a substring of some field.
+func conntrackMatches(fields, filters []string) bool {
+	for _, filter := range filters {
+		filter = strings.ToLower(filter)
+		if !slices.ContainsFunc(fields, func(f string) bool {
+			return strings.Contains(strings.ToLower(f), filter)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// sortConntrack sorts conntrack entries based on current view state.
+func (m Model) sortConntrack(entries []conntrack.Entry) []conntrack.Entry {
+	view := m.CurrentView()
+	if view == nil {
+		return entries
+	}
+
+	sorted := make([]conntrack.Entry, len(entries))
+	copy(sorted, entries)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		var cmp int
+		switch view.SortColumn {
+		case SortProtocol:
+			cmp = compareString(sorted[i].Protocol, sorted[j].Protocol)
+		case SortReply:
+			cmp = compareString(sorted[i].Reply.String(), sorted[j].Reply.String())
+		case SortState:
+			cmp = compareString(sorted[i].State, sorted[j].State)
+		case SortNAT:
+			cmp = compareString(sorted[i].NAT(), sorted[j].NAT())
+		case SortTTL:
+			cmp = compareInt(sorted[i].Timeout, sorted[j].Timeout)
+		default:
+			cmp = compareString(sorted[i].Original.String(), sorted[j].Original.String())
+		}
+
+		// Secondary sort for stable ordering when primary keys are equal
+		if cmp == 0 {
+			cmp = compareString(sorted[i].Original.String(), sorted[j].Original.String())
+		}
+
+		if view.SortAscending {
+			return cmp < 0
+		}
+		return cmp > 0
+	})
+
+	return sorted
+}
+
+// renderConntrackHeader renders the header for the conntrack table.
+func (m Model) renderConntrackHeader(widths []int) string {
+	view := m.CurrentView()
+	if view == nil {
+		return ""
+	}
+	return renderTableHeader(conntrackColumns(), widths, view.SelectedColumn, view.SortColumn, view.SortAscending, true)
+}
+
+// renderConntrackData renders only the data rows for the conntrack view (no header).
+func (m Model) renderConntrackData() string {
+	view := m.CurrentView()
+	if view == nil {
+		return ""
+	}
+
+	if m.conntrackErr != nil {
+		return EmptyStyle().Render(fmt.Sprintf(i18n.T("Conntrack unavailable: %v"), m.conntrackErr))
+	}
+
+	entries := m.filteredConntrack()
+	if len(entries) == 0 {
+		filter := m.currentFilter()
+		if filter != "" {
+			return EmptyStyle().Render(fmt.Sprintf(i18n.T("No matches for '%s'"), filter))
+		}
+		return EmptyStyle().Render(i18n.T("No tracked flows"))
+	}
+
+	var b strings.Builder
+	widths := calculateColumnWidths(conntrackColumns(), m.contentWidth())
+	entries = m.sortConntrack(entries)
+
+	var w rowWriter
+	for i, e := range entries {
+		nat := e.NAT()
+		if nat == "" {
+			nat = "-"
+		}
+		w.reset()
+		w.left(e.Protocol, widths[0])
+		w.left(truncateString(e.Original.String(), widths[1]), widths[1])
+		w.left(truncateString(e.Reply.String(), widths[2]), widths[2])
+		w.left(e.State, widths[3])
+		w.left(nat, widths[4])
+		w.right(strconv.Itoa(e.Timeout)+"s", widths[5])
+		b.WriteString(renderRow(w.String(), i == view.Cursor))
+	}
+
+	return b.String()
+}
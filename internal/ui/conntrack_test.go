@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/conntrack"
+)
+
+func testConntrackEntries() []conntrack.Entry {
+	return []conntrack.Entry{
+		{
+			Protocol: "tcp", State: "ESTABLISHED", Timeout: 300,
+			Original: conntrack.Tuple{Src: "10.0.0.5", Dst: "10.0.0.1", SrcPort: 1000, DstPort: 22},
+			Reply:    conntrack.Tuple{Src: "10.0.0.1", Dst: "10.0.0.5", SrcPort: 22, DstPort: 1000},
+		},
+		{
+			Protocol: "tcp", State: "ESTABLISHED", Timeout: 100,
+			Original: conntrack.Tuple{Src: "172.17.0.2", Dst: "1.2.3.4", SrcPort: 40000, DstPort: 443},
+			Reply:    conntrack.Tuple{Src: "1.2.3.4", Dst: "192.168.1.5", SrcPort: 443, DstPort: 40000},
+		},
+		{
+			Protocol: "udp", Timeout: 20,
+			Original: conntrack.Tuple{Src: "192.168.1.9", Dst: "192.168.1.5", SrcPort: 5000, DstPort: 8053},
+			Reply:    conntrack.Tuple{Src: "172.17.0.3", Dst: "192.168.1.9", SrcPort: 53, DstPort: 5000},
+		},
+	}
+}
+
+func pressC(m Model) (Model, tea.Cmd) {
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	return updated.(Model), cmd
+}
+
+func TestConntrackView_ToggleAndFetch(t *testing.T) {
+	m := createTestModel()
+	m.conntrackReader = &mockConntrackReader{entries: testConntrackEntries()}
+
+	m, cmd := pressC(m)
+	if m.CurrentView().Level != LevelConntrack {
+		t.Fatalf("Level = %v, want Conntrack", m.CurrentView().Level)
+	}
+	if cmd == nil {
+		t.Fatal("entering conntrack view should fetch entries")
+	}
+	msg, ok := cmd().(ConntrackMsg)
+	if !ok {
+		t.Fatalf("cmd returned %T, want ConntrackMsg", cmd())
+	}
+	updated, _ := m.Update(msg)
+	m = updated.(Model)
+	if len(m.conntrackEntries) != 3 {
+		t.Errorf("conntrackEntries = %d, want 3", len(m.conntrackEntries))
+	}
+
+	m, _ = pressC(m)
+	if m.CurrentView().Level != LevelProcessList {
+		t.Errorf("Level = %v, want ProcessList after second toggle", m.CurrentView().Level)
+	}
+}
+
+func TestConntrackView_DefaultSortShowsNATFirst(t *testing.T) {
+	m := createTestModel()
+	m, _ = pressC(m)
+	m.conntrackEntries = testConntrackEntries()
+
+	sorted := m.sortConntrack(m.conntrackEntries)
+	if sorted[0].NAT() == "" || sorted[len(sorted)-1].NAT() != "" {
+		t.Errorf("NAT order = %q, %q, %q; want NATed first", sorted[0].NAT(), sorted[1].NAT(), sorted[2].NAT())
+	}
+}
+
+func TestConntrackView_SortByTTL(t *testing.T) {
+	m := createTestModel()
+	m, _ = pressC(m)
+	m.CurrentView().SortColumn = SortTTL
+	m.CurrentView().SortAscending = true
+
+	sorted := m.sortConntrack(testConntrackEntries())
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i-1].Timeout > sorted[i].Timeout {
+			t.Fatalf("not sorted by TTL: %d before %d", sorted[i-1].Timeout, sorted[i].Timeout)
+		}
+	}
+}
+
+func TestConntrackView_Filter(t *testing.T) {
+	m := createTestModel()
+	m, _ = pressC(m)
+	m.conntrackEntries = testConntrackEntries()
+
+	m.filterChips = []string{"dnat"}
+	if got := len(m.filteredConntrack()); got != 1 {
+		t.Errorf("filter 'dnat' matched %d entries, want 1", got)
+	}
+	m.filterChips = []string{"172.17"}
+	if got := len(m.filteredConntrack()); got != 2 {
+		t.Errorf("filter '172.17' matched %d entries, want 2", got)
+	}
+}
+
+func TestConntrackView_CursorSurvivesDataRefresh(t *testing.T) {
+	m := createTestModel()
+	m, _ = pressC(m)
+	m.conntrackEntries = testConntrackEntries()
+	m.CurrentView().Cursor = 2
+
+	updated, _ := m.Update(DataMsg{Snapshot: createTestSnapshot()})
+	m = updated.(Model)
+	if m.CurrentView().Cursor != 2 {
+		t.Errorf("Cursor = %d, want 2 after snapshot refresh", m.CurrentView().Cursor)
+	}
+}
+
+func TestConntrackView_ColumnsForLevel(t *testing.T) {
+	m := createTestModel()
+	cols := m.columnsForLevel(LevelConntrack)
+	want := []SortColumn{SortProtocol, SortOriginal, SortReply, SortState, SortNAT, SortTTL}
+	if len(cols) != len(want) {
+		t.Fatalf("columns = %v, want %v", cols, want)
+	}
+	for i := range want {
+		if cols[i] != want[i] {
+			t.Errorf("cols[%d] = %v, want %v", i, cols[i], want[i])
+		}
+	}
+}
+
+func TestConntrackView_Render(t *testing.T) {
+	updated, _ := createTestModel().Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	m, _ := pressC(updated.(Model))
+	updated, _ = m.Update(ConntrackMsg{Entries: testConntrackEntries()})
+	m = updated.(Model)
+
+	out := m.View()
+	for _, want := range []string{"tracked flows: 3", "Original", "Reply", "SNAT", "DNAT", "CONNTRACK"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("View missing %q", want)
+		}
+	}
+}
+
+func TestConntrackView_RenderError(t *testing.T) {
+	updated, _ := createTestModel().Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	m, _ := pressC(updated.(Model))
+	updated, _ = m.Update(ConntrackMsg{Err: errors.New("permission denied")})
+	m = updated.(Model)
+
+	if out := m.View(); !strings.Contains(out, "permission denied") {
+		t.Error("View should show conntrack read error")
+	}
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// ioRate is a network throughput in bytes per second.
+type ioRate struct {
+	TX float64
+	RX float64
+}
+
+// containerIORates derives per-container throughput from two consecutive
+// Docker stats samples, keyed by container ID. Containers without a previous
+// sample, or whose counters went backwards (restart), are omitted.
+func containerIORates(prev, curr []model.VirtualContainer) map[string]ioRate {
+	before := make(map[string]*model.NetIOStats, len(prev))
+	for _, vc := range prev {
+		if vc.NetIO != nil {
+			before[vc.Info.ID] = vc.NetIO
+		}
+	}
+
+	rates := make(map[string]ioRate)
+	for _, vc := range curr {
+		if r, ok := netIORate(before[vc.Info.ID], vc.NetIO); ok {
+			rates[vc.Info.ID] = r
+		}
+	}
+	return rates
+}
+
+// containerIOCells returns the TX and RX cells for a virtual container row:
+// cumulative totals like process rows, or "—" when stats are unavailable.
+func containerIOCells(vc model.VirtualContainer) (tx, rx string) {
+	if vc.NetIO == nil {
+		return "—", "—"
+	}
+	return formatBytes(vc.NetIO.BytesSent), formatBytes(vc.NetIO.BytesRecv)
+}
+
+// containerIOSummary returns "TX: total (rate/s)  RX: total (rate/s)" for the
+// container drill-down header.
+func (m Model) containerIOSummary(vc model.VirtualContainer) string {
+	if vc.NetIO == nil {
+		return "TX: —  RX: —"
+	}
+	tx, rx := containerIOCells(vc)
+	if r, ok := m.containerRates[vc.Info.ID]; ok {
+		return fmt.Sprintf("TX: %s (%s/s)  RX: %s (%s/s)", tx, formatBytes(uint64(r.TX)), rx, formatBytes(uint64(r.RX)))
+	}
+	return fmt.Sprintf("TX: %s  RX: %s", tx, rx)
+}
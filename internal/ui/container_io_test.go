@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+func vcWithIO(id string, tx, rx uint64, at time.Time) model.VirtualContainer {
+	return model.VirtualContainer{
+		Info:  model.ContainerInfo{Name: "c-" + id, Image: "img", ID: id},
+		NetIO: &model.NetIOStats{BytesSent: tx, BytesRecv: rx, UpdatedAt: at},
+	}
+}
+
+func TestContainerIORates(t *testing.T) {
+	t0 := time.Unix(1000, 0)
+	prev := []model.VirtualContainer{
+		vcWithIO("a", 1000, 5000, t0),
+		vcWithIO("restarted", 9000, 9000, t0),
+	}
+	curr := []model.VirtualContainer{
+		vcWithIO("a", 3000, 9000, t0.Add(2*time.Second)),
+		vcWithIO("restarted", 10, 10, t0.Add(2*time.Second)),
+		vcWithIO("new", 100, 100, t0.Add(2*time.Second)),
+	}
+
+	rates := containerIORates(prev, curr)
+	if r := rates["a"]; r.TX != 1000 || r.RX != 2000 {
+		t.Errorf("rate a = %+v, want TX 1000 RX 2000 B/s", r)
+	}
+	if _, ok := rates["restarted"]; ok {
+		t.Error("counter reset should not produce a rate")
+	}
+	if _, ok := rates["new"]; ok {
+		t.Error("container without a previous sample should not have a rate")
+	}
+}
+
+func TestContainerIOCells(t *testing.T) {
+	tx, rx := containerIOCells(model.VirtualContainer{})
+	if tx != "—" || rx != "—" {
+		t.Errorf("cells without stats = %q %q, want dashes", tx, rx)
+	}
+	tx, rx = containerIOCells(vcWithIO("a", 2048, 1024*1024, time.Now()))
+	if tx != "2.0 KB" || rx != "1.0 MB" {
+		t.Errorf("cells = %q %q, want 2.0 KB / 1.0 MB", tx, rx)
+	}
+}
+
+func TestDockerResolvedMsg_ComputesRates(t *testing.T) {
+	m := testModelWithDockerContainers()
+	t0 := time.Now()
+	m.virtualContainers = []model.VirtualContainer{vcWithIO("a", 0, 0, t0)}
+
+	updated, _ := m.Update(DockerResolvedMsg{VirtualContainers: []model.VirtualContainer{vcWithIO("a", 4096, 0, t0.Add(time.Second))}})
+	m = updated.(Model)
+
+	if r := m.containerRates["a"]; r.TX != 4096 {
+		t.Errorf("TX rate = %v, want 4096", r.TX)
+	}
+	if got := m.containerIOSummary(m.virtualContainers[0]); !strings.Contains(got, "4.0 KB (4.0 KB/s)") {
+		t.Errorf("summary = %q, want total and rate", got)
+	}
+}
+
+func TestVirtualContainerRow_ShowsNetIO(t *testing.T) {
+	m := testModelWithDockerContainers()
+	m.virtualContainers[0].NetIO = &model.NetIOStats{BytesSent: 3 * 1024 * 1024, BytesRecv: 512}
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = updated.(Model)
+
+	out := m.renderProcessListData()
+	if !strings.Contains(out, "3.0 MB") || !strings.Contains(out, "512 B") {
+		t.Error("virtual container row should show TX/RX totals from Docker stats")
+	}
+}
+
+func TestDockerResolvedMsg_SkipsUnchangedCachedResult(t *testing.T) {
+	m := testModelWithDockerContainers()
+	t0 := time.Now()
+	m.virtualContainers = []model.VirtualContainer{vcWithIO("a", 0, 0, t0)}
+	msg := DockerResolvedMsg{VirtualContainers: []model.VirtualContainer{vcWithIO("a", 4096, 0, t0.Add(time.Second))}, At: t0.Add(time.Second)}
+
+	updated, _ := m.Update(msg)
+	m = updated.(Model)
+	// The cache hands out the same resolution until its next refresh
+	updated, _ = m.Update(msg)
+	m = updated.(Model)
+
+	if r := m.containerRates["a"]; r.TX != 4096 {
+		t.Errorf("TX rate = %v, want 4096 kept across a repeated cached result", r.TX)
+	}
+}
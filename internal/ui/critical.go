@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"fmt"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/process"
+)
+
+// criticalKillCooldown is how long after stopping one critical process the
+// next one is refused, so a slip in a fast kill loop cannot take down several
+// system services in a row.
+const criticalKillCooldown = 10 * time.Second
+
+// criticalPatternsFromSettings returns the configured patterns, or nil for
+// process.DefaultCritical.
+func criticalPatternsFromSettings(patterns []string) []string {
+	if len(patterns) == 0 {
+		return nil
+	}
+	return patterns
+}
+
+// processTarget returns pid of the target as a process.Target.
+func (t *killTargetInfo) processTarget(pid int32) process.Target {
+	return process.Target{PID: pid, Name: t.ProcessName, Exe: t.Exe}
+}
+
+// confirmName returns the name typed to confirm signalling a critical target:
+// its executable's base name, so "sshd (sbin)" is confirmed with "sshd".
+func (t *killTargetInfo) confirmName() string {
+	return t.processTarget(t.PID).CriticalName()
+}
+
+// isCriticalProcess reports whether the target matches a critical pattern.
+// Containers are stopped through Docker and never are.
+func (m Model) isCriticalProcess(t *killTargetInfo) bool {
+	return t.ContainerID == "" && process.IsCritical(m.criticalPatterns, t.processTarget(t.PID))
+}
+
+// signalPID sends sig to one PID of target. Every signal the TUI sends goes
+// through here, so a critical process is refused unless confirm is its name
+// whichever modal (or script) got this far.
+func (m Model) signalPID(target *killTargetInfo, pid int32, sig syscall.Signal, confirm string) error {
+	s := process.Signaller{Critical: m.criticalPatterns, Kill: sendSignal}
+	return s.Signal(target.processTarget(pid), sig, confirm)
+}
+
+// criticalKillWait returns how long until another critical process may be
+// stopped, or 0.
+func (m Model) criticalKillWait(now time.Time) time.Duration {
+	if m.lastCriticalKill.IsZero() {
+		return 0
+	}
+	return max(0, criticalKillCooldown-now.Sub(m.lastCriticalKill))
+}
+
+// confirmCritical checks the typed confirmation and the cooldown before a
+// critical target is signalled. It returns a warning toast when the signal
+// must not be sent; otherwise it starts the cooldown.
+func (m *Model) confirmCritical(target *killTargetInfo) (tea.Cmd, bool) {
+	if m.confirmTyped != target.confirmName() {
+		return m.notify(toastWarn, fmt.Sprintf(i18n.T("Type %q to confirm"), target.confirmName())), false
+	}
+	now := time.Now()
+	if wait := m.criticalKillWait(now); wait > 0 {
+		return m.notify(toastWarn, fmt.Sprintf(i18n.T("Another system service was just stopped; wait %ds"), int(wait.Seconds()+0.5))), false
+	}
+	m.lastCriticalKill = now
+	return nil, true
+}
+
+// typeConfirmation edits the typed confirmation: printable keys type and
+// backspace deletes.
+func (m *Model) typeConfirmation(msg tea.KeyMsg) {
+	switch {
+	case matchKey(msg.String(), KeyBack):
+		if r := []rune(m.confirmTyped); len(r) > 0 {
+			m.confirmTyped = string(r[:len(r)-1])
+		}
+	case msg.Type == tea.KeyRunes:
+		m.confirmTyped += string(msg.Runes)
+	}
+}
+
+// handleCriticalKillKey handles keys in the kill modal of a critical process:
+// printable keys type the confirmation, so only the arrow keys and tab
+// switch the signal.
+func (m Model) handleCriticalKillKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	switch {
+	case matchKey(key, KeyEnter):
+		if cmd, ok := m.confirmCritical(m.killTarget); !ok {
+			return m, cmd
+		}
+		return m.executeKill()
+	case matchKey(key, KeyEsc):
+		m.killMode = false
+		m.killTarget = nil
+		m.confirmTyped = ""
+	case matchKey(key, KeyUp, KeyDown) || key == "tab":
+		if m.killTarget.Signal == "SIGTERM" {
+			m.killTarget.Signal = "SIGKILL"
+		} else {
+			m.killTarget.Signal = "SIGTERM"
+		}
+	default:
+		m.typeConfirmation(msg)
+	}
+	return m, nil
+}
+
+// handleCriticalActionKey handles keys in the suspend modal of a critical
+// process, which is confirmed the same way as a kill.
+func (m Model) handleCriticalActionKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	switch {
+	case matchKey(key, KeyEnter):
+		if cmd, ok := m.confirmCritical(m.actionTarget); !ok {
+			return m, cmd
+		}
+		return m.executeAction()
+	case matchKey(key, KeyEsc):
+		m.closeAction()
+	default:
+		m.typeConfirmation(msg)
+	}
+	return m, nil
+}
+
+// renderCriticalConfirm returns the modal lines asking for the target's name,
+// with the typed text so far.
+func (m Model) renderCriticalConfirm(target *killTargetInfo) []string {
+	dangerStyle := ErrorStyle()
+	descStyle := FooterDescStyle()
+	prompt := descStyle.Render("  > "+m.confirmTyped) + dangerStyle.Render("█")
+	if m.confirmTyped == target.confirmName() {
+		prompt += descStyle.Render("  ✓")
+	}
+	return []string{
+		"",
+		dangerStyle.Render("  " + fmt.Sprintf(i18n.T("System service: type %q to confirm"), target.confirmName())),
+		prompt,
+	}
+}
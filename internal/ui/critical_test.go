@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestIsCriticalProcess_ExeBaseName(t *testing.T) {
+	m := createTestModel()
+	m.criticalPatterns = criticalPatternsFromSettings(nil)
+	target := &killTargetInfo{PID: 1, ProcessName: "sshd (sbin)", Exe: "/usr/sbin/sshd"}
+	if !m.isCriticalProcess(target) || target.confirmName() != "sshd" {
+		t.Errorf("critical = %v, confirm with %q; want sshd matched by its executable", m.isCriticalProcess(target), target.confirmName())
+	}
+	target.ContainerID = "abc"
+	if m.isCriticalProcess(target) {
+		t.Error("containers are stopped through Docker, not confirmed by name")
+	}
+}
+
+func TestKillMode_CriticalRequiresTypedName(t *testing.T) {
+	var killed []int
+	origSignal := sendSignal
+	sendSignal = func(pid int, _ syscall.Signal) error { killed = append(killed, pid); return nil }
+	t.Cleanup(func() { sendSignal = origSignal })
+
+	m := chipsTestModel()
+	m.auditPath = filepath.Join(t.TempDir(), "audit.log")
+	m.criticalPatterns = []string{"chrome"}
+
+	m = pressKey(m, "x")
+	if !m.killTarget.Critical {
+		t.Fatal("chrome should need a typed confirmation")
+	}
+	if !strings.Contains(stripAnsi(m.renderKillModalContent()), `type "chrome" to confirm`) {
+		t.Error("modal should ask for the process name")
+	}
+
+	m = pressSpecial(m, tea.KeyEnter)
+	if !m.killMode || len(killed) != 0 {
+		t.Fatal("Enter alone must not kill a critical process")
+	}
+
+	// j and k type instead of switching the signal
+	m = typeKeys(m, "chromk")
+	m = pressSpecial(m, tea.KeyBackspace)
+	m = typeKeys(m, "e")
+	if m.confirmTyped != "chrome" || m.killTarget.Signal != "SIGTERM" {
+		t.Fatalf("typed %q, signal %s", m.confirmTyped, m.killTarget.Signal)
+	}
+	m = pressSpecial(m, tea.KeyEnter)
+	if m.killMode || len(killed) != 1 || killed[0] != 10 {
+		t.Errorf("killMode = %v, killed = %v; want PID 10 killed", m.killMode, killed)
+	}
+	if m.confirmTyped != "" {
+		t.Error("typed name should be cleared after the kill")
+	}
+}
+
+func TestKillMode_CriticalRateLimited(t *testing.T) {
+	var killed int
+	origSignal := sendSignal
+	sendSignal = func(int, syscall.Signal) error { killed++; return nil }
+	t.Cleanup(func() { sendSignal = origSignal })
+
+	m := chipsTestModel()
+	m.auditPath = filepath.Join(t.TempDir(), "audit.log")
+	m.criticalPatterns = []string{"chrome"}
+	m.lastCriticalKill = time.Now().Add(-2 * time.Second)
+
+	m = typeKeys(pressKey(m, "x"), "chrome")
+	m = pressSpecial(m, tea.KeyEnter)
+	if killed != 0 || !m.killMode {
+		t.Fatal("a second critical kill within the cooldown should be refused")
+	}
+	if toast := m.currentToast(time.Now()); toast == nil || !strings.Contains(toast.Message, "wait 8s") {
+		t.Errorf("toast = %+v, want a wait hint", toast)
+	}
+
+	m.lastCriticalKill = time.Now().Add(-criticalKillCooldown)
+	m = pressSpecial(m, tea.KeyEnter)
+	if killed != 1 {
+		t.Error("kill should go through once the cooldown has passed")
+	}
+}
+
+func TestKillMode_NonCriticalUnchanged(t *testing.T) {
+	m := chipsTestModel()
+	m.criticalPatterns = []string{"sshd"}
+	m = pressKey(m, "x")
+	if m.killTarget.Critical {
+		t.Fatal("chrome is not critical")
+	}
+	m = pressKey(m, "j")
+	if m.killTarget.Signal != "SIGKILL" {
+		t.Error("j should still switch the signal for ordinary processes")
+	}
+}
+
+func TestSuspend_CriticalRequiresTypedName(t *testing.T) {
+	_, sigs := stubProcActions(t, 0, false, nil)
+	m := chipsTestModel()
+	m.auditPath = filepath.Join(t.TempDir(), "audit.log")
+	m.criticalPatterns = []string{"chrome"}
+
+	m = pressKey(m, "z")
+	if !m.needsConfirm() || !strings.Contains(stripAnsi(m.renderActionModalContent()), `type "chrome" to confirm`) {
+		t.Fatal("suspending a critical process should ask for its name")
+	}
+	m = pressSpecial(m, tea.KeyEnter)
+	if !m.actionMode || len(*sigs) != 0 {
+		t.Fatal("Enter alone must not stop a critical process")
+	}
+	m = pressSpecial(typeKeys(m, "chrome"), tea.KeyEnter)
+	if m.actionMode || len(*sigs) != 1 || (*sigs)[0] != syscall.SIGSTOP {
+		t.Errorf("actionMode = %v, signals = %v; want SIGSTOP sent", m.actionMode, *sigs)
+	}
+
+	// Resuming does not stop anything and needs no confirmation.
+	processStopped = func(int32) bool { return true }
+	m = pressSpecial(pressKey(m, "z"), tea.KeyEnter)
+	if len(*sigs) != 2 || (*sigs)[1] != syscall.SIGCONT {
+		t.Errorf("signals = %v, want SIGCONT sent without typing", *sigs)
+	}
+}
+
+func TestScript_CannotKillCriticalUnconfirmed(t *testing.T) {
+	var killed int
+	origSignal := sendSignal
+	sendSignal = func(int, syscall.Signal) error { killed++; return nil }
+	t.Cleanup(func() { sendSignal = origSignal })
+
+	s, err := ParseScript(strings.NewReader("kill\nkey enter\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := chipsTestModel().WithScript(s)
+	m.criticalPatterns = []string{"chrome"}
+	m, _ = m.runScript()
+	if killed != 0 || !m.killMode {
+		t.Errorf("killed %d, killMode = %v; a script must not kill a critical process without typing its name", killed, m.killMode)
+	}
+}
+
+func TestSignalPID_RefusesUnconfirmedCritical(t *testing.T) {
+	var killed int
+	origSignal := sendSignal
+	sendSignal = func(int, syscall.Signal) error { killed++; return nil }
+	t.Cleanup(func() { sendSignal = origSignal })
+
+	m := createTestModel()
+	target := &killTargetInfo{PID: 1, ProcessName: "systemd (lib)", Exe: "/usr/lib/systemd/systemd"}
+	if err := m.signalPID(target, 1, syscall.SIGKILL, ""); err == nil || killed != 0 {
+		t.Errorf("err = %v, killed = %d; want an unconfirmed critical process refused", err, killed)
+	}
+	if err := m.signalPID(target, 1, syscall.SIGKILL, "systemd"); err != nil || killed != 1 {
+		t.Errorf("err = %v, killed = %d; want the confirmed kill sent", err, killed)
+	}
+}
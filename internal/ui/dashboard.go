@@ -0,0 +1,188 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// dashboardLines is how many lines the dashboard adds to the header.
+const dashboardLines = 3
+
+// connHistoryLen is how many connection count samples the sparkline shows.
+const connHistoryLen = 40
+
+// sparkBlocks are the sparkline levels, lowest first.
+var (
+	sparkBlocks      = []rune("▁▂▃▄▅▆▇█")
+	plainSparkBlocks = []rune("_.-=#")
+)
+
+// newestConn is the most recently opened connection, for the dashboard.
+type newestConn struct {
+	process string
+	conn    model.Connection
+	at      time.Time
+}
+
+// toggleDashboard shows or hides the dashboard lines in the header.
+func (m Model) toggleDashboard() (tea.Model, tea.Cmd) {
+	m.dashboard = !m.dashboard
+	return m, nil
+}
+
+// headerLines returns the height of the header, including the dashboard when shown.
+func (m Model) headerLines() int {
+	if m.dashboard {
+		return headerHeight + dashboardLines
+	}
+	return headerHeight
+}
+
+// recordDashboard samples the connection count and remembers the newest of
+// the connections added in curr. Runs on every snapshot so the sparkline has
+// history when the dashboard is opened.
+func (m *Model) recordDashboard(curr *model.NetworkSnapshot, changes map[ConnectionKey]Change, at time.Time) {
+	m.connHistory = append(m.connHistory, curr.HostConnections())
+	if len(m.connHistory) > connHistoryLen {
+		m.connHistory = m.connHistory[len(m.connHistory)-connHistoryLen:]
+	}
+
+	for _, app := range curr.Applications {
+		for _, conn := range app.Connections {
+			if c, ok := changes[KeyFromConnection(conn)]; ok && c.Type == ChangeAdded {
+				m.newest = &newestConn{process: app.Name, conn: conn, at: at}
+				return
+			}
+		}
+	}
+}
+
+// sparkline renders values as a row of block characters scaled between their
+// min and max. A flat series renders at the lowest level.
+func sparkline(values []int) string {
+	blocks := sparkBlocks
+	if plainMode {
+		blocks = plainSparkBlocks
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		level := 0
+		if hi > lo {
+			level = (v - lo) * (len(blocks) - 1) / (hi - lo)
+		}
+		b.WriteRune(blocks[level])
+	}
+	return b.String()
+}
+
+// topTalker returns the application with the highest current throughput
+// (TX+RX over its PIDs), or ok=false when nothing is transferring.
+func (m Model) topTalker() (name string, rate ioRate, ok bool) {
+	if m.snapshot == nil {
+		return "", ioRate{}, false
+	}
+	for _, app := range m.snapshot.Applications {
+		var total ioRate
+		for _, pid := range app.PIDs {
+			if r, has := m.netIORates[pid]; has {
+				total.TX += r.TX
+				total.RX += r.RX
+			}
+		}
+		if total.TX+total.RX > rate.TX+rate.RX {
+			name, rate, ok = app.Name, total, true
+		}
+	}
+	return name, rate, ok
+}
+
+// errorCount returns how many errors the diagnostics log has recorded, repeats included.
+func (m Model) errorCount() int {
+	n := 0
+	for _, e := range m.diagLog {
+		n += e.Count
+	}
+	return n
+}
+
+// dnsHitRate returns how many distinct remote IPs in the snapshot are
+// answered from the DNS cache (resolved or known to have no name), out of all.
+func (m Model) dnsHitRate() (hits, total int) {
+	if m.snapshot == nil {
+		return 0, 0
+	}
+	seen := make(map[string]bool)
+	for _, app := range m.snapshot.Applications {
+		for _, conn := range app.Connections {
+			ip := extractIP(conn.RemoteAddr)
+			if ip == "" || ip == "*" || seen[ip] {
+				continue
+			}
+			seen[ip] = true
+			total++
+			if _, ok := m.dnsCache[ip]; ok {
+				hits++
+			}
+		}
+	}
+	return hits, total
+}
+
+// dashboardContent returns the plain-text dashboard lines: the connection
+// count sparkline, then two columns of stats.
+func (m Model) dashboardContent(now time.Time) []string {
+	field := func(label, value string) string { return fmt.Sprintf("%-10s %s", i18n.T(label), value) }
+
+	conns := "—"
+	if n := len(m.connHistory); n > 0 {
+		lo, hi := m.connHistory[0], m.connHistory[0]
+		for _, v := range m.connHistory {
+			lo, hi = min(lo, v), max(hi, v)
+		}
+		conns = fmt.Sprintf(i18n.T("%s  %d (min %d, max %d)"), sparkline(m.connHistory), m.connHistory[n-1], lo, hi)
+	}
+
+	talker := "—"
+	if name, r, ok := m.topTalker(); ok {
+		talker = fmt.Sprintf("%s ▲ %s ▼ %s", name, formatBytesRate(r.TX), formatBytesRate(r.RX))
+	}
+	newest := "—"
+	if m.newest != nil {
+		newest = fmt.Sprintf(i18n.T("%s %s → %s (%s ago)"), m.newest.process, m.newest.conn.Protocol,
+			m.newest.conn.RemoteAddr, formatUptime(now.Sub(m.newest.at)))
+	}
+
+	errs := "0"
+	if n := m.errorCount(); n > 0 {
+		errs = fmt.Sprintf(i18n.T("%d (! for details)"), n)
+	}
+	dnsText := i18n.T("off")
+	if m.dnsEnabled {
+		dnsText = "—"
+		if hits, total := m.dnsHitRate(); total > 0 {
+			dnsText = fmt.Sprintf(i18n.T("%d%% hit (%d/%d remote IPs)"), hits*100/total, hits, total)
+		}
+	}
+
+	left := []string{field("Top talker", talker), field("Errors", errs)}
+	width := max(len([]rune(left[0])), len([]rune(left[1])))
+	pad := func(s string) string { return s + strings.Repeat(" ", width-len([]rune(s))) }
+	return []string{
+		field("Conns", conns),
+		pad(left[0]) + "   " + field("Newest", newest),
+		pad(left[1]) + "   " + field("DNS cache", dnsText),
+	}
+}
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+func TestDashboard_ToggleResizesViewport(t *testing.T) {
+	m := createTestModel()
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = updated.(Model)
+	before := m.viewport.Height
+
+	m = pressKey(m, "D")
+	if !m.dashboard {
+		t.Fatal("D should show the dashboard")
+	}
+	if m.viewport.Height != before-dashboardLines {
+		t.Errorf("viewport height = %d, want %d", m.viewport.Height, before-dashboardLines)
+	}
+	if got := strings.Count(m.renderHeader(), "\n") + 1; got != headerHeight+dashboardLines {
+		t.Errorf("header lines = %d, want %d", got, headerHeight+dashboardLines)
+	}
+
+	m = pressKey(m, "D")
+	if m.dashboard || m.viewport.Height != before {
+		t.Errorf("second D should hide the dashboard and restore height %d, got %d", before, m.viewport.Height)
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	if got := sparkline([]int{0, 7, 14}); got != "▁▄█" {
+		t.Errorf("sparkline = %q, want ▁▄█", got)
+	}
+	if got := sparkline([]int{5, 5}); got != "▁▁" {
+		t.Errorf("flat sparkline = %q, want ▁▁", got)
+	}
+	if got := sparkline(nil); got != "" {
+		t.Errorf("empty sparkline = %q", got)
+	}
+}
+
+func TestRecordDashboard(t *testing.T) {
+	m := createTestModel()
+	for range connHistoryLen + 5 {
+		m.recordDashboard(m.snapshot, nil, time.Now())
+	}
+	if len(m.connHistory) != connHistoryLen {
+		t.Errorf("history = %d samples, want %d", len(m.connHistory), connHistoryLen)
+	}
+
+	conn := model.Connection{PID: 200, Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.1:5000", RemoteAddr: "1.1.1.1:443"}
+	snap := &model.NetworkSnapshot{Applications: []model.Application{{Name: "curl", Connections: []model.Connection{conn}}}}
+	m.recordDashboard(snap, map[ConnectionKey]Change{KeyFromConnection(conn): {Type: ChangeAdded}}, time.Now())
+	if m.newest == nil || m.newest.process != "curl" {
+		t.Fatalf("newest = %+v, want curl", m.newest)
+	}
+	m.recordDashboard(snap, nil, time.Now())
+	if m.newest == nil {
+		t.Error("newest connection should be kept until another opens")
+	}
+}
+
+func TestDashboardContent(t *testing.T) {
+	m := createTestModel()
+	m.dnsEnabled = true
+	m.snapshot = &model.NetworkSnapshot{Applications: []model.Application{
+		{Name: "chrome", PIDs: []int32{1}, Connections: []model.Connection{{RemoteAddr: "1.1.1.1:443"}, {RemoteAddr: "8.8.8.8:443"}}},
+		{Name: "sshd", PIDs: []int32{2}, Connections: []model.Connection{{RemoteAddr: "*"}}},
+	}}
+	m.netIORates = map[int32]ioRate{1: {TX: 2048, RX: 1024}, 2: {TX: 10}}
+	m.dnsCache = map[string]string{"1.1.1.1": "one.one.one.one"}
+	m.connHistory = []int{10, 20}
+	m.recordError(sourceDNS, errors.New("timeout"))
+
+	if name, _, ok := m.topTalker(); !ok || name != "chrome" {
+		t.Errorf("topTalker = %q, want chrome", name)
+	}
+	if hits, total := m.dnsHitRate(); hits != 1 || total != 2 {
+		t.Errorf("dnsHitRate = %d/%d, want 1/2", hits, total)
+	}
+
+	out := strings.Join(m.dashboardContent(time.Now()), "\n")
+	for _, want := range []string{"20 (min 10, max 20)", "Top talker chrome", "Errors     1", "50% hit (1/2 remote IPs)", "Newest     —"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("dashboard missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/kostyay/netmon/internal/debugserver"
+	"github.com/kostyay/netmon/internal/history"
+	"github.com/kostyay/netmon/internal/web"
+)
+
+// WithDebugStatus publishes collect/render timings and cache sizes to s for the
+// --debug-listen status page.
+func (m Model) WithDebugStatus(s *debugserver.Status) Model {
+	m.debugStatus = s
+	return m
+}
+
+// WithWebHub publishes each snapshot to h for the --web live view.
+func (m Model) WithWebHub(h *web.Hub) Model {
+	m.webHub = h
+	return m
+}
+
+// publishWeb sends the current snapshot to the web view. No-op without a hub.
+func (m *Model) publishWeb() {
+	if err := m.webHub.Publish(m.snapshot, m.netIOCache); err != nil {
+		m.recordError(sourceWeb, err)
+	}
+}
+
+// WithHistory hands each snapshot to r for the --store flow history.
+func (m Model) WithHistory(r *history.Recorder) Model {
+	m.history = r
+	return m
+}
+
+// publishHistory hands the current snapshot to the history recorder. No-op
+// without a recorder.
+func (m *Model) publishHistory() {
+	if err := m.history.Observe(m.snapshot); err != nil {
+		m.recordError(sourceHistory, err)
+	}
+}
+
+// observe records how long an operation took since start. No-op without a debug status.
+func (m Model) observe(name string, start time.Time) {
+	m.debugStatus.Observe(name, time.Since(start))
+}
+
+// publishDebugGauges reports current data and cache sizes.
+func (m Model) publishDebugGauges() {
+	s := m.debugStatus
+	if s == nil {
+		return
+	}
+	apps, conns := 0, 0
+	if m.snapshot != nil {
+		apps = len(m.snapshot.Applications)
+		conns = m.snapshot.HostConnections()
+	}
+	s.SetGauge("applications", apps)
+	s.SetGauge("connections", conns)
+	s.SetGauge("conntrack_entries", len(m.conntrackEntries))
+	s.SetGauge("dns_cache", len(m.dnsCache))
+	s.SetGauge("netio_cache", len(m.netIOCache))
+	s.SetGauge("docker_cache", len(m.dockerCache))
+	s.SetGauge("security_cache", len(m.securityCache))
+	s.SetGauge("changes", len(m.changes))
+	s.SetGauge("diag_entries", len(m.diagLog))
+}
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/debugserver"
+	"github.com/kostyay/netmon/internal/web"
+)
+
+func TestDebugStatus_PublishesTimingsAndGauges(t *testing.T) {
+	status := debugserver.NewStatus()
+	m := createTestModel().WithDebugStatus(status)
+	m.dnsCache = map[string]string{"1.1.1.1": "one.one.one.one"}
+
+	updated, _ := m.Update(DataMsg{Snapshot: createTestSnapshot()})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = updated.(Model)
+	m.View()
+	if msg := m.fetchData()(); msg == nil {
+		t.Fatal("fetchData returned nil")
+	}
+
+	var b strings.Builder
+	if err := status.Write(&b); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	for _, want := range []string{"collect", "render", "applications", "dns_cache"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("status page missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestDebugStatus_DisabledByDefault(t *testing.T) {
+	m := createTestModel()
+	// Must not panic without a status sink.
+	m.observe("render", time.Now())
+	m.publishDebugGauges()
+}
+
+func TestWebHub_PublishesSnapshots(t *testing.T) {
+	hub := web.NewHub()
+	m := createTestModel().WithWebHub(hub)
+
+	updated, _ := m.Update(DataMsg{Snapshot: createTestSnapshot()})
+	m = updated.(Model)
+
+	latest := string(hub.Latest())
+	if !strings.Contains(latest, `"applications"`) || !strings.Contains(latest, m.snapshot.Applications[0].Name) {
+		t.Errorf("hub should hold the snapshot as JSON, got %q", latest)
+	}
+}
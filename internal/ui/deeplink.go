@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ViewSpec is a starting view set from the command line, so shell aliases can
+// open exactly the view they need:
+//
+//	netmon --view connections --process chrome --filter 443 --sort remote:desc
+type ViewSpec struct {
+	View    string   // one of viewNames; "" is the process list, or connections with Process
+	Process string   // process to drill into (its name as shown in the process list)
+	Filters []string // filter chips, as typed after /
+	Sort    string   // COLUMN or COLUMN:asc|desc, by column header
+}
+
+// viewNames maps --view names to view levels.
+var viewNames = map[string]ViewLevel{
+	"processes":    LevelProcessList,
+	"connections":  LevelConnections,
+	"all":          LevelAllConnections,
+	"binds":        LevelBinds,
+	"conntrack":    LevelConntrack,
+	"docker-ports": LevelDockerPorts,
+}
+
+// ViewNames returns the names accepted by --view, sorted.
+func ViewNames() []string {
+	return slices.Sorted(maps.Keys(viewNames))
+}
+
+// WithView returns a copy of the model opened on spec's view: the view stack
+// is built up front (process list, then the process's connections), filter
+// chips are added and the top view is sorted. Errors name the offending flag.
+func (m Model) WithView(spec ViewSpec) (Model, error) {
+	name := spec.View
+	if name == "" {
+		name = "processes"
+		if spec.Process != "" {
+			name = "connections"
+		}
+	}
+	level, ok := viewNames[name]
+	if !ok {
+		return m, fmt.Errorf("--view: unknown view %q (want %s)", spec.View, strings.Join(ViewNames(), ", "))
+	}
+	switch {
+	case level == LevelConnections && spec.Process == "":
+		return m, fmt.Errorf("--view connections needs --process")
+	case level != LevelConnections && spec.Process != "":
+		return m, fmt.Errorf("--process only applies to --view connections")
+	}
+
+	m.stack = []ViewState{m.newViewState(LevelProcessList, "")}
+	if level == LevelConnections {
+		m.stack = append(m.stack, m.newViewState(LevelConnections, spec.Process))
+	} else if level != LevelProcessList {
+		m.stack = []ViewState{m.newViewState(level, "")}
+	}
+
+	for _, f := range spec.Filters {
+		m.addFilterChip(f)
+	}
+
+	if spec.Sort != "" {
+		col, dir, _ := strings.Cut(spec.Sort, ":")
+		if !slices.Contains([]string{"", "asc", "desc"}, dir) {
+			return m, fmt.Errorf("--sort: direction must be asc or desc, got %q", dir)
+		}
+		if !m.scriptSort(col, dir == "desc") {
+			return m, fmt.Errorf("--sort: no column %q in the %s view", col, name)
+		}
+	}
+	return m, nil
+}
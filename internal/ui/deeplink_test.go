@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestWithView_ConnectionsFilteredSorted(t *testing.T) {
+	m, err := NewModel().WithView(ViewSpec{Process: "App2", Filters: []string{"443", "tcp"}, Sort: "remote:desc"})
+	if err != nil {
+		t.Fatalf("WithView: %v", err)
+	}
+	if len(m.stack) != 2 || m.stack[0].Level != LevelProcessList {
+		t.Fatalf("stack = %+v, want process list then connections", m.stack)
+	}
+	view := m.CurrentView()
+	if view.Level != LevelConnections || view.ProcessName != "App2" {
+		t.Errorf("view = %v %q, want App2 connections", view.Level, view.ProcessName)
+	}
+	if view.SortColumn != SortRemote || view.SortAscending {
+		t.Errorf("sort = %v asc=%v, want Remote descending", view.SortColumn, view.SortAscending)
+	}
+	if strings.Join(m.filterChips, ",") != "443,tcp" {
+		t.Errorf("chips = %v, want [443 tcp]", m.filterChips)
+	}
+
+	// Esc goes back to the process list like a manual drill-down
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if got := m.CurrentView().Level; got != LevelProcessList {
+		t.Errorf("after esc level = %v, want process list", got)
+	}
+}
+
+func TestWithView_TopLevelViews(t *testing.T) {
+	for name, want := range map[string]ViewLevel{
+		"processes": LevelProcessList,
+		"all":       LevelAllConnections,
+		"binds":     LevelBinds,
+		"conntrack": LevelConntrack,
+	} {
+		m, err := NewModel().WithView(ViewSpec{View: name})
+		if err != nil {
+			t.Errorf("WithView(%q): %v", name, err)
+			continue
+		}
+		if len(m.stack) != 1 || m.CurrentView().Level != want {
+			t.Errorf("WithView(%q) stack = %+v, want only %v", name, m.stack, want)
+		}
+	}
+}
+
+func TestWithView_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		spec ViewSpec
+		want string
+	}{
+		{ViewSpec{View: "nope"}, `unknown view "nope"`},
+		{ViewSpec{View: "connections"}, "needs --process"},
+		{ViewSpec{View: "binds", Process: "x"}, "only applies to --view connections"},
+		{ViewSpec{Sort: "remote"}, `no column "remote" in the processes view`},
+		{ViewSpec{View: "all", Sort: "remote:sideways"}, "asc or desc"},
+	} {
+		_, err := NewModel().WithView(tc.spec)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("WithView(%+v) = %v, want error containing %q", tc.spec, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kostyay/netmon/internal/i18n"
+)
+
+// Error sources shown in the diagnostics panel.
+const (
+	sourceCollector = "collector"
+	sourceNetIO     = "netio"
+	sourceDNS       = "dns"
+	sourceDocker    = "docker"
+	sourceConntrack = "conntrack"
+	sourceSecurity  = "security"
+	sourcePrivilege = "privilege"
+	sourceSockWatch = "sockwatch"
+	sourceUpdate    = "update"
+	sourceAudit     = "audit"
+	sourceProxy     = "proxy"
+	sourceCapture   = "capture"
+	sourceGeoIP     = "geoip"
+	sourceWeb       = "web"
+	sourceEphemeral = "ephemeral"
+	sourceHistory   = "history"
+)
+
+// maxDiagEntries caps the diagnostics log; the least recently seen entry is evicted.
+const maxDiagEntries = 50
+
+// diagEntry is a distinct error message from one source, with repeat count.
+type diagEntry struct {
+	Source  string
+	Message string
+	Count   int
+	First   time.Time
+	Last    time.Time
+}
+
+// recordError adds err to the diagnostics log, merging repeats of the same message.
+func (m *Model) recordError(source string, err error) {
+	if err == nil {
+		return
+	}
+	now := time.Now()
+	msg := err.Error()
+	for i := range m.diagLog {
+		e := &m.diagLog[i]
+		if e.Source == source && e.Message == msg {
+			e.Count++
+			e.Last = now
+			return
+		}
+	}
+	if len(m.diagLog) >= maxDiagEntries {
+		oldest := 0
+		for i, e := range m.diagLog {
+			if e.Last.Before(m.diagLog[oldest].Last) {
+				oldest = i
+			}
+		}
+		m.diagLog = append(m.diagLog[:oldest], m.diagLog[oldest+1:]...)
+	}
+	m.diagLog = append(m.diagLog, diagEntry{Source: source, Message: msg, Count: 1, First: now, Last: now})
+}
+
+// isExpectedDNSError reports whether a reverse lookup failed only because no PTR record exists.
+func isExpectedDNSError(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+// recentDiagnostics returns diagnostics entries, most recently seen first.
+func (m Model) recentDiagnostics() []diagEntry {
+	entries := make([]diagEntry, len(m.diagLog))
+	copy(entries, m.diagLog)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Last.After(entries[j].Last)
+	})
+	return entries
+}
+
+// diagModalWidth is the width of the diagnostics modal.
+const diagModalWidth = 76
+
+// maxDiagEvents is how many recent notifications the diagnostics panel lists.
+const maxDiagEvents = 10
+
+// renderDiagnosticsModalContent returns the diagnostics panel content:
+// recorded errors followed by the most recent footer notifications.
+func (m Model) renderDiagnosticsModalContent() string {
+	keyStyle := FooterKeyStyle()
+	descStyle := FooterDescStyle()
+	dimStyle := DimmedStyle()
+	warnStyle := WarnStyle()
+	now := time.Now()
+
+	// time(8) + source(9) + count(5) + separators; message gets the rest
+	msgWidth := diagModalWidth - 8 - 2 - 9 - 2 - 5 - 2 - 6
+	row := func(at time.Time, label, count, msg string) string {
+		return fmt.Sprintf("%s  %s  %s  %s",
+			dimStyle.Render(fmt.Sprintf("%-8s", formatAgo(now.Sub(at)))),
+			warnStyle.Render(fmt.Sprintf("%-9s", label)),
+			descStyle.Render(fmt.Sprintf("%5s", count)),
+			descStyle.Render(truncateString(msg, msgWidth)),
+		)
+	}
+
+	entries := m.recentDiagnostics()
+	lines := []string{keyStyle.Render(i18n.T("Errors"))}
+	if len(entries) == 0 {
+		lines = append(lines, descStyle.Render("  "+i18n.T("No errors recorded")))
+	}
+	for _, e := range entries {
+		count := ""
+		if e.Count > 1 {
+			count = fmt.Sprintf("×%d", e.Count)
+		}
+		lines = append(lines, row(e.Last, e.Source, count, e.Message))
+	}
+
+	lines = append(lines, "", keyStyle.Render(i18n.T("Collection")), descStyle.Render("  "+m.collectionSummary(now)))
+	if s := m.ephemeralSummary(); s != "" {
+		lines = append(lines, descStyle.Render("  "+s))
+	}
+
+	lines = append(lines, "", keyStyle.Render(i18n.T("Events")))
+	if len(m.toastLog) == 0 {
+		lines = append(lines, descStyle.Render("  "+i18n.T("No events yet")))
+	}
+	for i, shown := len(m.toastLog)-1, 0; i >= 0 && shown < maxDiagEvents; i, shown = i-1, shown+1 {
+		t := m.toastLog[i]
+		label := t.Label
+		if label == "" {
+			label = t.Severity.String()
+		}
+		lines = append(lines, row(t.At, label, "", t.Message))
+	}
+
+	lines = append(lines, "", keyStyle.Render("!")+descStyle.Render("/")+keyStyle.Render("Esc")+descStyle.Render(" "+i18n.T("Close")))
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRecordError_MergesRepeats(t *testing.T) {
+	m := createTestModel()
+	m.recordError(sourceCollector, errors.New("permission denied"))
+	m.recordError(sourceCollector, errors.New("permission denied"))
+	m.recordError(sourceNetIO, errors.New("permission denied"))
+	m.recordError(sourceDocker, nil)
+
+	if len(m.diagLog) != 2 {
+		t.Fatalf("diagLog has %d entries, want 2", len(m.diagLog))
+	}
+	if m.diagLog[0].Count != 2 {
+		t.Errorf("collector count = %d, want 2", m.diagLog[0].Count)
+	}
+}
+
+func TestRecordError_EvictsOldest(t *testing.T) {
+	m := createTestModel()
+	for i := 0; i < maxDiagEntries+5; i++ {
+		m.recordError(sourceDNS, fmt.Errorf("error %d", i))
+	}
+	if len(m.diagLog) != maxDiagEntries {
+		t.Fatalf("diagLog has %d entries, want %d", len(m.diagLog), maxDiagEntries)
+	}
+	if m.diagLog[0].Message != "error 5" {
+		t.Errorf("oldest remaining = %q, want error 5", m.diagLog[0].Message)
+	}
+}
+
+func TestUpdate_RecordsErrorsFromAllSources(t *testing.T) {
+	m := createTestModel()
+	m.dnsCache = make(map[string]string)
+	msgs := []tea.Msg{
+		DataMsg{Err: errors.New("collect failed")},
+		NetIOMsg{Err: errors.New("nettop failed")},
+		DNSResolvedMsg{IP: "1.2.3.4", Err: errors.New("i/o timeout")},
+		DockerResolvedMsg{Err: errors.New("api error")},
+		ConntrackMsg{Err: errors.New("permission denied")},
+		SecurityResolvedMsg{PID: 1, Err: errors.New("no such process")},
+	}
+	for _, msg := range msgs {
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+
+	sources := map[string]bool{}
+	for _, e := range m.diagLog {
+		sources[e.Source] = true
+	}
+	for _, s := range []string{sourceCollector, sourceNetIO, sourceDNS, sourceDocker, sourceConntrack, sourceSecurity} {
+		if !sources[s] {
+			t.Errorf("missing diagnostics entry for %s", s)
+		}
+	}
+}
+
+func TestUpdate_IgnoresMissingPTRRecords(t *testing.T) {
+	m := createTestModel()
+	m.dnsCache = make(map[string]string)
+	err := &net.DNSError{Err: "no such host", Name: "4.3.2.1.in-addr.arpa", IsNotFound: true}
+	updated, _ := m.Update(DNSResolvedMsg{IP: "1.2.3.4", Err: err})
+	m = updated.(Model)
+	if len(m.diagLog) != 0 {
+		t.Errorf("missing PTR record should not be logged, got %v", m.diagLog)
+	}
+}
+
+func TestDiagnosticsMode_ToggleAndRender(t *testing.T) {
+	updated, _ := createTestModel().Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m := updated.(Model)
+	m.recordError(sourceDocker, errors.New("daemon is unhealthy"))
+	m.recordError(sourceDocker, errors.New("daemon is unhealthy"))
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'!'}})
+	m = updated.(Model)
+	if !m.diagMode {
+		t.Fatal("diagMode should be true after '!'")
+	}
+	out := m.View()
+	for _, want := range []string{"Diagnostics", "docker", "×2", "daemon is unhealthy"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("diagnostics panel missing %q", want)
+		}
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.diagMode {
+		t.Error("diagMode should be false after Esc")
+	}
+}
+
+func TestDiagnosticsModal_Empty(t *testing.T) {
+	m := createTestModel()
+	if out := m.renderDiagnosticsModalContent(); !strings.Contains(out, "No errors recorded") {
+		t.Errorf("empty panel should say so, got:\n%s", out)
+	}
+}
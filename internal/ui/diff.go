@@ -6,12 +6,13 @@ import (
 	"github.com/kostyay/netmon/internal/model"
 )
 
-// ChangeType indicates whether a connection was added or removed.
+// ChangeType indicates whether a connection was added, removed or changed state.
 type ChangeType int
 
 const (
 	ChangeAdded ChangeType = iota
 	ChangeRemoved
+	ChangeModified // same connection, different state (e.g. ESTABLISHED -> CLOSE_WAIT)
 )
 
 // ConnectionKey uniquely identifies a connection for diffing.
@@ -39,9 +40,9 @@ func KeyFromConnection(c model.Connection) ConnectionKey {
 }
 
 // GetChange returns a pointer to the Change for a connection, or nil if no change.
-// Returns nil if highlight changes is disabled.
+// Returns nil if highlight changes is disabled or in large host mode.
 func (m Model) GetChange(c model.Connection) *Change {
-	if !m.highlightChanges {
+	if !m.highlightChanges || m.largeHost {
 		return nil
 	}
 	key := KeyFromConnection(c)
@@ -66,34 +67,40 @@ func (m *Model) pruneExpiredChanges(maxAge time.Duration) {
 
 // diffConnections compares previous and current snapshots, returning changes map.
 // The returned map contains new changes; caller should merge with existing changes.
+// A sampled snapshot lists only part of the connections, and connections move
+// in and out of the sample as its rate changes, so there is no diff with one.
 func diffConnections(prev, curr *model.NetworkSnapshot) map[ConnectionKey]Change {
-	if prev == nil || curr == nil {
+	if prev == nil || curr == nil || prev.Sample != nil || curr.Sample != nil {
 		return nil
 	}
 
 	now := time.Now()
 	changes := make(map[ConnectionKey]Change)
 
-	// Build sets of connections
-	prevSet := make(map[ConnectionKey]struct{})
-	currSet := make(map[ConnectionKey]struct{})
+	// Build sets of connections with their states
+	prevSet := make(map[ConnectionKey]model.ConnectionState)
+	currSet := make(map[ConnectionKey]model.ConnectionState)
 
 	for _, app := range prev.Applications {
 		for _, conn := range app.Connections {
-			prevSet[KeyFromConnection(conn)] = struct{}{}
+			prevSet[KeyFromConnection(conn)] = conn.State
 		}
 	}
 
 	for _, app := range curr.Applications {
 		for _, conn := range app.Connections {
-			currSet[KeyFromConnection(conn)] = struct{}{}
+			currSet[KeyFromConnection(conn)] = conn.State
 		}
 	}
 
-	// Find added connections (in curr but not in prev)
-	for key := range currSet {
-		if _, found := prevSet[key]; !found {
+	// Find added connections (in curr but not in prev) and state transitions
+	for key, state := range currSet {
+		prevState, found := prevSet[key]
+		switch {
+		case !found:
 			changes[key] = Change{Type: ChangeAdded, Timestamp: now}
+		case prevState != state:
+			changes[key] = Change{Type: ChangeModified, Timestamp: now}
 		}
 	}
 
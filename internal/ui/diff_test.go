@@ -228,3 +228,19 @@ func TestPruneExpiredChanges_NilMap(t *testing.T) {
 	// Should not panic
 	m.pruneExpiredChanges(3 * time.Second)
 }
+
+func TestDiffConnections_StateChangeIsModified(t *testing.T) {
+	conn := model.Connection{PID: 100, Protocol: "TCP", LocalAddr: "127.0.0.1:8080", RemoteAddr: "10.0.0.1:443", State: model.StateEstablished}
+	closing := conn
+	closing.State = model.StateCloseWait
+	prev := &model.NetworkSnapshot{Applications: []model.Application{{Name: "App1", Connections: []model.Connection{conn}}}}
+	curr := &model.NetworkSnapshot{Applications: []model.Application{{Name: "App1", Connections: []model.Connection{closing}}}}
+
+	changes := diffConnections(prev, curr)
+	if len(changes) != 1 {
+		t.Fatalf("expected 1 change, got %d", len(changes))
+	}
+	if c := changes[KeyFromConnection(conn)]; c.Type != ChangeModified {
+		t.Errorf("change type = %v, want ChangeModified", c.Type)
+	}
+}
@@ -0,0 +1,174 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// dnsPort is the port outbound DNS queries go to.
+const dnsPort = 53
+
+// dnsModalWidth is the width of the DNS activity panel.
+const dnsModalWidth = 76
+
+// maxDNSRows caps the processes listed in the DNS activity panel.
+const maxDNSRows = 15
+
+// dnsActivity is one process's outbound DNS since launch. Queries are counted
+// from new sockets to port 53 (most resolvers use a fresh UDP socket per
+// query), so the rate is a lower bound for clients that reuse a socket.
+type dnsActivity struct {
+	resolvers map[string]time.Time // resolver IP -> last seen
+	queries   []time.Time          // new port 53 sockets within churnWindow
+	total     int                  // new port 53 sockets since launch
+	forwarder bool                 // serves DNS itself (binds port 53), e.g. systemd-resolved
+}
+
+// isDNSQuery reports whether conn talks to a resolver on port 53.
+func isDNSQuery(conn model.Connection) bool {
+	return model.ExtractPort(conn.RemoteAddr) == dnsPort
+}
+
+// recordDNSActivity notes the resolvers contacted in snap and counts the port
+// 53 sockets the diff layer saw appear as queries.
+func (m *Model) recordDNSActivity(snap *model.NetworkSnapshot, changes map[ConnectionKey]Change, now time.Time) {
+	if snap == nil {
+		return
+	}
+	if m.dnsActivity == nil {
+		m.dnsActivity = make(map[string]*dnsActivity)
+	}
+	cutoff := now.Add(-churnWindow)
+	for _, app := range snap.Applications {
+		serves := slices.ContainsFunc(app.Connections, func(c model.Connection) bool {
+			return c.IsBound() && model.ExtractPort(c.LocalAddr) == dnsPort
+		})
+		for _, conn := range app.Connections {
+			if !isDNSQuery(conn) {
+				continue
+			}
+			a := m.dnsActivity[app.Name]
+			if a == nil {
+				a = &dnsActivity{resolvers: make(map[string]time.Time)}
+				m.dnsActivity[app.Name] = a
+			}
+			a.forwarder = a.forwarder || serves
+			a.resolvers[extractIP(conn.RemoteAddr)] = now
+			if c, ok := changes[KeyFromConnection(conn)]; ok && c.Type == ChangeAdded {
+				a.queries = append(a.queries, now)
+				a.total++
+			}
+		}
+	}
+	for _, a := range m.dnsActivity {
+		i := 0
+		for i < len(a.queries) && a.queries[i].Before(cutoff) {
+			i++
+		}
+		a.queries = a.queries[i:]
+	}
+}
+
+// dnsRow is one process in the DNS activity panel.
+type dnsRow struct {
+	process   string
+	rate      float64 // queries per second over churnWindow
+	total     int
+	resolvers []string // sorted, most recently seen first
+	unusual   bool     // uses a resolver other than the most common one
+}
+
+// dnsRows returns the processes with DNS activity, busiest first, flagging
+// those that use a resolver other than the one most processes use, the
+// usual sign of a hardcoded or misconfigured resolver. Local forwarders are
+// not flagged: talking to upstream resolvers is their job.
+func (m Model) dnsRows() []dnsRow {
+	usage := make(map[string]int) // resolver -> processes using it
+	for _, a := range m.dnsActivity {
+		for ip := range a.resolvers {
+			usage[ip]++
+		}
+	}
+	common := ""
+	for ip, n := range usage {
+		if n > usage[common] || (n == usage[common] && ip < common) {
+			common = ip
+		}
+	}
+
+	rows := make([]dnsRow, 0, len(m.dnsActivity))
+	for name, a := range m.dnsActivity {
+		r := dnsRow{process: name, rate: float64(len(a.queries)) / churnWindow.Seconds(), total: a.total}
+		for ip := range a.resolvers {
+			r.resolvers = append(r.resolvers, ip)
+			if ip != common && !a.forwarder {
+				r.unusual = true
+			}
+		}
+		slices.SortFunc(r.resolvers, func(x, y string) int {
+			if c := a.resolvers[y].Compare(a.resolvers[x]); c != 0 {
+				return c
+			}
+			return strings.Compare(x, y)
+		})
+		rows = append(rows, r)
+	}
+	slices.SortFunc(rows, func(x, y dnsRow) int {
+		switch {
+		case x.rate != y.rate:
+			return cmp.Compare(y.rate, x.rate)
+		case x.total != y.total:
+			return y.total - x.total
+		}
+		return strings.Compare(x.process, y.process)
+	})
+	return rows
+}
+
+// toggleDNSActivity opens or closes the DNS activity panel.
+func (m Model) toggleDNSActivity() Model {
+	m.dnsMode = !m.dnsMode
+	return m
+}
+
+// renderDNSModalContent renders the DNS activity panel: per process, the
+// query rate, queries since launch, and the resolvers contacted.
+func (m Model) renderDNSModalContent() string {
+	keyStyle := FooterKeyStyle()
+	descStyle := FooterDescStyle()
+	warnStyle := WarnStyle()
+
+	// process(18) + rate(7) + total(6) + count(3) + separators; resolver list gets the rest
+	resolverWidth := dnsModalWidth - 18 - 7 - 6 - 3 - 8 - 6
+	lines := []string{keyStyle.Render(fmt.Sprintf("%-18s  %7s  %6s  %s", i18n.T("Process"), i18n.T("Query/s"), i18n.T("Total"), i18n.T("Resolvers")))}
+
+	rows := m.dnsRows()
+	if len(rows) == 0 {
+		lines = append(lines, descStyle.Render("  "+i18n.T("No DNS traffic seen yet (port 53)")))
+	}
+	for i, r := range rows {
+		if i == maxDNSRows {
+			lines = append(lines, descStyle.Render("  "+fmt.Sprintf(i18n.T("… %d more"), len(rows)-maxDNSRows)))
+			break
+		}
+		line := fmt.Sprintf("%-18s  %7s  %6d  %3d %s",
+			truncateString(r.process, 18), formatRate(r.rate), r.total, len(r.resolvers),
+			truncateString(strings.Join(r.resolvers, ", "), resolverWidth))
+		if r.unusual {
+			lines = append(lines, warnStyle.Render(line))
+		} else {
+			lines = append(lines, descStyle.Render(line))
+		}
+	}
+
+	lines = append(lines, "",
+		descStyle.Render(i18n.T("Amber: uses a resolver most processes don't. Rates count new port 53 sockets.")),
+		"", keyStyle.Render(KeyDNSActivity.Key)+descStyle.Render("/")+keyStyle.Render("Esc")+descStyle.Render(" "+i18n.T("Close")))
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+func dnsConn(pid int32, local, resolver string) model.Connection {
+	return model.Connection{PID: pid, Protocol: model.ProtocolUDP, LocalAddr: local, RemoteAddr: resolver + ":53", State: model.StateNone}
+}
+
+func TestRecordDNSActivity_RatesAndResolvers(t *testing.T) {
+	m := createTestModel()
+	q1 := dnsConn(10, "10.0.0.2:40000", "127.0.0.53")
+	q2 := dnsConn(10, "10.0.0.2:40001", "127.0.0.53")
+	rogue := dnsConn(20, "10.0.0.2:40002", "8.8.8.8")
+	snap := &model.NetworkSnapshot{Applications: []model.Application{
+		{Name: "curl", PIDs: []int32{10}, Connections: []model.Connection{q1, q2}},
+		{Name: "miner", PIDs: []int32{20}, Connections: []model.Connection{rogue}},
+		{Name: "wget", PIDs: []int32{30}, Connections: []model.Connection{dnsConn(30, "10.0.0.2:40003", "127.0.0.53")}},
+	}}
+	now := time.Now()
+	added := Change{Type: ChangeAdded, Timestamp: now}
+	m.recordDNSActivity(snap, map[ConnectionKey]Change{
+		KeyFromConnection(q1): added, KeyFromConnection(q2): added, KeyFromConnection(rogue): added,
+	}, now)
+
+	rows := m.dnsRows()
+	if len(rows) != 3 || rows[0].process != "curl" || rows[0].total != 2 {
+		t.Fatalf("rows = %+v, want curl first with 2 queries", rows)
+	}
+	if got := rows[0].rate; got != 2/churnWindow.Seconds() {
+		t.Errorf("curl rate = %v, want %v", got, 2/churnWindow.Seconds())
+	}
+	for _, r := range rows {
+		if want := r.process == "miner"; r.unusual != want {
+			t.Errorf("%s unusual = %v, want %v", r.process, r.unusual, want)
+		}
+	}
+
+	// Queries age out of the rate but not the total
+	m.recordDNSActivity(&model.NetworkSnapshot{}, nil, now.Add(churnWindow+time.Second))
+	if a := m.dnsActivity["curl"]; len(a.queries) != 0 || a.total != 2 {
+		t.Errorf("after window queries = %d total = %d, want 0 and 2", len(a.queries), a.total)
+	}
+}
+
+func TestDNSRows_ForwarderNotFlagged(t *testing.T) {
+	m := createTestModel()
+	snap := &model.NetworkSnapshot{Applications: []model.Application{
+		{Name: "systemd-resolved", PIDs: []int32{1}, Connections: []model.Connection{
+			{PID: 1, Protocol: model.ProtocolUDP, LocalAddr: "127.0.0.53:53", RemoteAddr: "*", State: model.StateNone},
+			dnsConn(1, "10.0.0.2:40000", "1.1.1.1"),
+		}},
+		{Name: "curl", PIDs: []int32{10}, Connections: []model.Connection{dnsConn(10, "10.0.0.2:40001", "127.0.0.53")}},
+		{Name: "wget", PIDs: []int32{30}, Connections: []model.Connection{dnsConn(30, "10.0.0.2:40002", "127.0.0.53")}},
+	}}
+	m.recordDNSActivity(snap, nil, time.Now())
+	for _, r := range m.dnsRows() {
+		if r.unusual {
+			t.Errorf("%s flagged; forwarders and stub users are normal", r.process)
+		}
+	}
+}
+
+func TestDNSActivity_Modal(t *testing.T) {
+	m := createTestModel()
+	m = pressKey(m, KeyDNSActivity.Key)
+	if !m.dnsMode {
+		t.Fatal("R should open the DNS activity panel")
+	}
+	if got := m.renderDNSModalContent(); !strings.Contains(got, "No DNS traffic seen yet") {
+		t.Errorf("empty panel = %q", got)
+	}
+	m = pressKey(m, KeyDNSActivity.Key)
+	if m.dnsMode {
+		t.Error("R again should close the panel")
+	}
+}
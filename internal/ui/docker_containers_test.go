@@ -142,7 +142,7 @@ func TestFilteredVirtualContainers_WhenDisabled(t *testing.T) {
 
 func TestFilteredVirtualContainers_WithFilter(t *testing.T) {
 	m := testModelWithDockerContainers()
-	m.activeFilter = "nginx"
+	m.filterChips = []string{"nginx"}
 
 	vcs := m.filteredVirtualContainers()
 	if len(vcs) != 1 {
@@ -155,7 +155,7 @@ func TestFilteredVirtualContainers_WithFilter(t *testing.T) {
 
 func TestFilteredVirtualContainers_FilterByImage(t *testing.T) {
 	m := testModelWithDockerContainers()
-	m.activeFilter = "redis:7"
+	m.filterChips = []string{"redis:7"}
 
 	vcs := m.filteredVirtualContainers()
 	if len(vcs) != 1 {
@@ -168,7 +168,7 @@ func TestFilteredVirtualContainers_FilterByImage(t *testing.T) {
 
 func TestFilteredVirtualContainers_FilterByID(t *testing.T) {
 	m := testModelWithDockerContainers()
-	m.activeFilter = "abc123"
+	m.filterChips = []string{"abc123"}
 
 	vcs := m.filteredVirtualContainers()
 	if len(vcs) != 1 {
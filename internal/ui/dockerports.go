@@ -0,0 +1,263 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/docker"
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// portMappingRow is one published port of a container in the port mappings view.
+type portMappingRow struct {
+	container model.ContainerInfo
+	mapping   model.PortMapping
+	conns     int    // established connections on the published port
+	listening bool   // a Docker process listens on the published port
+	created   bool   // the container is created but not started
+	conflict  string // host processes bound to the published port
+}
+
+// published returns the host side of the mapping, e.g. "0.0.0.0:8080".
+func (r portMappingRow) published() string {
+	ip := r.mapping.HostIP
+	if ip == "" {
+		ip = "*"
+	}
+	return ip + ":" + strconv.Itoa(r.mapping.HostPort)
+}
+
+// status describes whether anything uses the mapping: "3 conns", "listening",
+// "idle" or "not started", or the host processes holding the port ("⚠ nginx").
+func (r portMappingRow) status() string {
+	switch {
+	case r.conflict != "":
+		return "⚠ " + r.conflict
+	case r.created:
+		return "not started"
+	case r.conns == 1:
+		return "1 conn"
+	case r.conns > 1:
+		return fmt.Sprintf("%d conns", r.conns)
+	case r.listening:
+		return "listening"
+	default:
+		return "idle"
+	}
+}
+
+// activity ranks the mapping for sorting: idle, then listening, then by connection count.
+func (r portMappingRow) activity() int {
+	if r.conns > 0 {
+		return r.conns + 1
+	}
+	if r.listening {
+		return 1
+	}
+	return 0
+}
+
+// dockerPortsColumns returns the column definitions for the port mappings view.
+func dockerPortsColumns() []columnDef {
+	return []columnDef{
+		{label: "Container", id: SortContainer, minWidth: 16, flex: 3},
+		{label: "Image", id: SortProcess, minWidth: 12, flex: 2},
+		{label: "Published", id: SortLocal, minWidth: 16, flex: 2},
+		{label: "Internal", id: SortRemote, minWidth: 9, flex: 0},
+		{label: "Proto", id: SortProtocol, minWidth: 6, flex: 0},
+		{label: "Status", id: SortConns, minWidth: 10, flex: 0},
+	}
+}
+
+// toggleDockerPortsView switches between the port mappings view and the process list.
+func (m Model) toggleDockerPortsView() (tea.Model, tea.Cmd) {
+	view := m.CurrentView()
+	if view != nil && view.Level == LevelDockerPorts {
+		m.stack = []ViewState{m.newViewState(LevelProcessList, "")}
+		return m, nil
+	}
+	m.dockerView = false
+	m.stack = []ViewState{m.newViewState(LevelDockerPorts, "")}
+	return m, m.fetchDockerContainers()
+}
+
+// portMappingRows lists every published port of the running containers, and
+// the ports created containers will publish, and correlates them with live
+// connections. Connections are attributed the same way as virtual container
+// rows: a Docker process (docker-proxy, com.docker.backend) owning a socket on
+// the published host port. A mapping bound to a specific address only counts
+// sockets on that address.
+func (m Model) portMappingRows() []portMappingRow {
+	var rows []portMappingRow
+	for _, vc := range m.virtualContainers {
+		for _, pm := range vc.PortMappings {
+			rows = append(rows, portMappingRow{container: vc.Info, mapping: pm, conflict: m.mappingConflict(vc.Info, pm)})
+		}
+	}
+	for _, vc := range m.createdContainers {
+		for _, pm := range vc.PortMappings {
+			rows = append(rows, portMappingRow{container: vc.Info, mapping: pm, created: true, conflict: m.mappingConflict(vc.Info, pm)})
+		}
+	}
+	if m.snapshot == nil || len(rows) == 0 {
+		return rows
+	}
+	for _, app := range m.snapshot.Applications {
+		if !docker.IsDockerProcess(app.Name) {
+			continue
+		}
+		for _, conn := range app.Connections {
+			for i := range rows {
+				if rows[i].created || !mappingOwnsConn(rows[i].mapping, conn) {
+					continue
+				}
+				switch conn.State {
+				case model.StateEstablished:
+					rows[i].conns++
+				case model.StateListen:
+					rows[i].listening = true
+				}
+			}
+		}
+	}
+	return rows
+}
+
+// mappingOwnsConn reports whether conn is a socket on the mapping's published port.
+func mappingOwnsConn(pm model.PortMapping, conn model.Connection) bool {
+	if model.ExtractPort(conn.LocalAddr) != pm.HostPort {
+		return false
+	}
+	if pm.Protocol != "" && !strings.EqualFold(pm.Protocol, string(conn.Protocol)) {
+		return false
+	}
+	switch pm.HostIP {
+	case "", "0.0.0.0", "::":
+		return true
+	}
+	host := conn.LocalAddr[:strings.LastIndex(conn.LocalAddr, ":")]
+	return host == pm.HostIP || host == "*"
+}
+
+// filteredPortMappings returns port mappings matching every current filter.
+func (m Model) filteredPortMappings() []portMappingRow {
+	rows := m.portMappingRows()
+	filters := m.currentFilters()
+	if len(filters) == 0 {
+		return rows
+	}
+	var result []portMappingRow
+	for _, r := range rows {
+		fields := []string{r.container.Name, r.container.Image, r.published(),
+			strconv.Itoa(r.mapping.ContainerPort), r.mapping.Protocol, r.status()}
+		if conntrackMatches(fields, filters) {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// sortPortMappings sorts port mappings based on current view state.
+func (m Model) sortPortMappings(rows []portMappingRow) []portMappingRow {
+	view := m.CurrentView()
+	if view == nil {
+		return rows
+	}
+
+	sorted := make([]portMappingRow, len(rows))
+	copy(sorted, rows)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		var cmp int
+		switch view.SortColumn {
+		case SortProcess:
+			cmp = compareString(a.container.Image, b.container.Image)
+		case SortLocal:
+			cmp = compareInt(a.mapping.HostPort, b.mapping.HostPort)
+		case SortRemote:
+			cmp = compareInt(a.mapping.ContainerPort, b.mapping.ContainerPort)
+		case SortProtocol:
+			cmp = compareString(a.mapping.Protocol, b.mapping.Protocol)
+		case SortConns:
+			cmp = compareInt(a.activity(), b.activity())
+		default:
+			cmp = compareString(a.container.Name, b.container.Name)
+		}
+
+		// Secondary sort for stable ordering when primary keys are equal
+		if cmp == 0 {
+			cmp = compareString(a.container.Name, b.container.Name)
+		}
+		if cmp == 0 {
+			cmp = compareInt(a.mapping.HostPort, b.mapping.HostPort)
+		}
+		if cmp == 0 {
+			cmp = compareString(a.mapping.HostIP, b.mapping.HostIP)
+		}
+
+		if view.SortAscending {
+			return cmp < 0
+		}
+		return cmp > 0
+	})
+
+	return sorted
+}
+
+// renderDockerPortsHeader renders the header for the port mappings table.
+func (m Model) renderDockerPortsHeader(widths []int) string {
+	view := m.CurrentView()
+	if view == nil {
+		return ""
+	}
+	return renderTableHeader(dockerPortsColumns(), widths, view.SelectedColumn, view.SortColumn, view.SortAscending, true)
+}
+
+// renderDockerPortsData renders only the data rows for the port mappings view (no header).
+func (m Model) renderDockerPortsData() string {
+	view := m.CurrentView()
+	if view == nil {
+		return ""
+	}
+
+	rows := m.filteredPortMappings()
+	if len(rows) == 0 {
+		filter := m.currentFilter()
+		if filter != "" {
+			return EmptyStyle().Render(fmt.Sprintf(i18n.T("No matches for '%s'"), filter))
+		}
+		if len(m.virtualContainers)+len(m.createdContainers) == 0 {
+			return EmptyStyle().Render(i18n.T("No running containers (or Docker unavailable)"))
+		}
+		return EmptyStyle().Render(i18n.T("No published ports"))
+	}
+
+	var b strings.Builder
+	widths := calculateColumnWidths(dockerPortsColumns(), m.contentWidth())
+	rows = m.sortPortMappings(rows)
+
+	var w rowWriter
+	for i, r := range rows {
+		w.reset()
+		w.left(truncateString(withHealth(r.container.Name, r.container), widths[0]), widths[0])
+		w.left(truncateString(r.container.Image, widths[1]), widths[1])
+		w.left(truncateString(r.published(), widths[2]), widths[2])
+		w.left(strconv.Itoa(r.mapping.ContainerPort), widths[3])
+		w.left(r.mapping.Protocol, widths[4])
+		w.left(r.status(), widths[5])
+		row := w.String()
+		if (r.container.Flapping() || r.conflict != "") && i != view.Cursor {
+			b.WriteString(rowStyles().warn.render("  "+row) + "\n")
+			continue
+		}
+		b.WriteString(renderRow(row, i == view.Cursor))
+	}
+
+	return b.String()
+}
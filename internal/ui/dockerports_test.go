@@ -0,0 +1,189 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/docker"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+func testPortContainers() []model.VirtualContainer {
+	return []model.VirtualContainer{
+		{
+			Info: model.ContainerInfo{Name: "web", Image: "nginx:latest", ID: "abc"},
+			PortMappings: []model.PortMapping{
+				{HostPort: 8080, ContainerPort: 80, Protocol: "tcp", HostIP: "0.0.0.0"},
+				{HostPort: 8443, ContainerPort: 443, Protocol: "tcp", HostIP: "127.0.0.1"},
+			},
+		},
+		{
+			Info: model.ContainerInfo{Name: "cache", Image: "redis:7", ID: "def"},
+			PortMappings: []model.PortMapping{
+				{HostPort: 6379, ContainerPort: 6379, Protocol: "tcp", HostIP: "0.0.0.0"},
+			},
+		},
+	}
+}
+
+// dockerPortsModel returns a model whose docker-proxy has two clients on 8080,
+// a listener on 6379 and a socket on 8443 bound to another address.
+func dockerPortsModel() Model {
+	m := createTestModel()
+	m.virtualContainers = testPortContainers()
+	m.snapshot = &model.NetworkSnapshot{Applications: []model.Application{{
+		Name: "docker-proxy",
+		PIDs: []int32{500},
+		Connections: []model.Connection{
+			{Protocol: model.ProtocolTCP, LocalAddr: "0.0.0.0:8080", RemoteAddr: "*:*", State: model.StateListen},
+			{Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.5:8080", RemoteAddr: "10.0.0.9:51000", State: model.StateEstablished},
+			{Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.5:8080", RemoteAddr: "10.0.0.9:51001", State: model.StateEstablished},
+			{Protocol: model.ProtocolTCP, LocalAddr: "0.0.0.0:6379", RemoteAddr: "*:*", State: model.StateListen},
+			{Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.5:8443", RemoteAddr: "10.0.0.9:51002", State: model.StateEstablished},
+		},
+	}}}
+	return m
+}
+
+func pressP(m Model) (Model, tea.Cmd) {
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}})
+	return updated.(Model), cmd
+}
+
+func TestDockerPortsView_ToggleAndFetch(t *testing.T) {
+	m := createTestModel()
+	m.dockerResolver = &mockDockerResolver{result: &docker.ResolveResult{
+		Ports:      map[int]*docker.ContainerPort{},
+		Containers: testPortContainers(),
+	}}
+
+	m, cmd := pressP(m)
+	if m.CurrentView().Level != LevelDockerPorts {
+		t.Fatalf("Level = %v, want DockerPorts", m.CurrentView().Level)
+	}
+	if cmd == nil {
+		t.Fatal("entering port mappings view should query Docker")
+	}
+	updated, _ := m.Update(cmd())
+	m = updated.(Model)
+	if got := len(m.portMappingRows()); got != 3 {
+		t.Errorf("portMappingRows = %d, want 3", got)
+	}
+
+	m, _ = pressP(m)
+	if m.CurrentView().Level != LevelProcessList {
+		t.Errorf("Level = %v, want ProcessList after second toggle", m.CurrentView().Level)
+	}
+}
+
+func TestPortMappingRows_CorrelatesConnections(t *testing.T) {
+	m := dockerPortsModel()
+
+	status := map[int]string{}
+	for _, r := range m.portMappingRows() {
+		status[r.mapping.HostPort] = r.status()
+	}
+	want := map[int]string{
+		8080: "2 conns",
+		6379: "listening",
+		8443: "idle", // bound to 127.0.0.1; the 10.0.0.5 socket is not this mapping
+	}
+	for port, w := range want {
+		if status[port] != w {
+			t.Errorf("status[%d] = %q, want %q", port, status[port], w)
+		}
+	}
+}
+
+func TestPortMappingRows_IgnoresNonDockerProcesses(t *testing.T) {
+	m := dockerPortsModel()
+	m.snapshot.Applications[0].Name = "nginx"
+
+	for _, r := range m.portMappingRows() {
+		if r.conns != 0 || r.listening {
+			t.Errorf("%s: status = %q, want idle for non-Docker owner", r.published(), r.status())
+		}
+	}
+}
+
+func TestDockerPortsView_SortByStatus(t *testing.T) {
+	m := dockerPortsModel()
+	m, _ = pressP(m)
+	m.CurrentView().SortColumn = SortConns
+	m.CurrentView().SortAscending = false
+
+	rows := m.sortPortMappings(m.portMappingRows())
+	var got []int
+	for _, r := range rows {
+		got = append(got, r.mapping.HostPort)
+	}
+	want := []int{8080, 6379, 8443}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("order = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestDockerPortsView_Filter(t *testing.T) {
+	m := dockerPortsModel()
+	m, _ = pressP(m)
+	m.filterChips = []string{"redis"}
+
+	rows := m.filteredPortMappings()
+	if len(rows) != 1 || rows[0].container.Name != "cache" {
+		t.Errorf("filtered = %+v, want only cache", rows)
+	}
+}
+
+func TestDockerPortsView_Render(t *testing.T) {
+	m := dockerPortsModel()
+	m.width, m.height = 140, 40
+	m, _ = pressP(m)
+
+	out := stripAnsi(m.renderDockerPortsData())
+	for _, want := range []string{"web", "nginx:latest", "0.0.0.0:8080", "127.0.0.1:8443", "2 conns", "listening", "idle"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("render missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestDockerPortsView_EmptyState(t *testing.T) {
+	m := createTestModel()
+	m, _ = pressP(m)
+
+	out := stripAnsi(m.renderDockerPortsData())
+	if !strings.Contains(out, "No running containers") {
+		t.Errorf("empty render = %q, want no containers message", out)
+	}
+}
+
+func TestDockerViews_ContainerHealth(t *testing.T) {
+	m := dockerPortsModel()
+	m.width, m.height = 140, 40
+	m.dockerContainers = true
+	m.virtualContainers[1].Info.Health = "unhealthy"
+	m.virtualContainers[1].Info.RestartCount = 3
+
+	out := stripAnsi(m.renderProcessListData())
+	if !strings.Contains(out, "🐳 cache (redis:7) · unhealthy ×3") {
+		t.Errorf("process list missing health indicator:\n%s", out)
+	}
+	if strings.Contains(out, "(nginx:latest) ·") {
+		t.Errorf("container without healthcheck or restarts got an indicator:\n%s", out)
+	}
+
+	m.filterChips = []string{"unhealthy"}
+	if vcs := m.filteredVirtualContainers(); len(vcs) != 1 || vcs[0].Info.Name != "cache" {
+		t.Errorf("filter unhealthy = %+v, want only cache", vcs)
+	}
+	m.filterChips = nil
+
+	m, _ = pressP(m)
+	if out := stripAnsi(m.renderDockerPortsData()); !strings.Contains(out, "cache · unhealthy ×3") {
+		t.Errorf("port mappings missing health indicator:\n%s", out)
+	}
+}
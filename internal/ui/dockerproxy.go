@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// Docker's userland proxy (one docker-proxy process per published port)
+// accepts the client on the host port and dials the container, so every
+// proxied request shows up twice in the flat view. The client leg is kept and
+// labelled with the container; the backend legs are hidden until Enter expands
+// that proxy.
+
+// dockerProxyName is the process name of Docker's userland proxy.
+const dockerProxyName = "docker-proxy"
+
+// dockerProxy is one docker-proxy process with both legs present.
+type dockerProxy struct {
+	backend string                  // container label, e.g. "web:80" or "172.17.0.2:80"
+	legs    []connectionWithProcess // backend legs (proxy -> container)
+}
+
+// dockerProxies classifies each docker-proxy PID's sockets from the whole
+// snapshot (so filters do not change the roles): sockets on a port the PID
+// listens on are client legs, other non-LISTEN sockets are backend legs.
+// Only PIDs with legs of both kinds are returned.
+func (m Model) dockerProxies() map[int32]*dockerProxy {
+	if m.snapshot == nil {
+		return nil
+	}
+	var proxies map[int32]*dockerProxy
+	for _, app := range m.snapshot.Applications {
+		if app.Name != dockerProxyName {
+			continue
+		}
+		listening := map[int32]map[int]bool{}
+		for _, conn := range app.Connections {
+			if conn.State == model.StateListen {
+				if listening[conn.PID] == nil {
+					listening[conn.PID] = map[int]bool{}
+				}
+				listening[conn.PID][model.ExtractPort(conn.LocalAddr)] = true
+			}
+		}
+		clients := map[int32]int{} // PID -> a host port with a client leg
+		legs := map[int32][]connectionWithProcess{}
+		for _, conn := range app.Connections {
+			ports := listening[conn.PID]
+			if conn.State == model.StateListen || ports == nil {
+				continue
+			}
+			if port := model.ExtractPort(conn.LocalAddr); ports[port] {
+				clients[conn.PID] = port
+			} else {
+				legs[conn.PID] = append(legs[conn.PID], connectionWithProcess{Connection: conn, ProcessName: app.Name, ProxyLeg: true})
+			}
+		}
+		for pid, hostPort := range clients {
+			if len(legs[pid]) == 0 {
+				continue
+			}
+			if proxies == nil {
+				proxies = make(map[int32]*dockerProxy)
+			}
+			proxies[pid] = &dockerProxy{backend: m.proxyBackend(hostPort, legs[pid][0]), legs: legs[pid]}
+		}
+	}
+	return proxies
+}
+
+// proxyBackend labels the container behind hostPort by name when Docker
+// container info is available, otherwise by the backend leg's remote address.
+func (m Model) proxyBackend(hostPort int, leg connectionWithProcess) string {
+	if cp := m.dockerCache[hostPort]; cp != nil {
+		return fmt.Sprintf("%s:%d", cp.Container.Name, cp.ContainerPort)
+	}
+	return leg.RemoteAddr
+}
+
+// collapseDockerProxy drops docker-proxy backend legs and labels the client
+// legs with their backend.
+func (m Model) collapseDockerProxy(conns []connectionWithProcess, proxies map[int32]*dockerProxy) []connectionWithProcess {
+	if len(proxies) == 0 {
+		return conns
+	}
+	result := make([]connectionWithProcess, 0, len(conns))
+	for _, c := range conns {
+		p := proxies[c.PID]
+		if p == nil || c.ProcessName != dockerProxyName || c.State == model.StateListen {
+			result = append(result, c)
+			continue
+		}
+		if isProxyLeg(p, c.Connection) {
+			continue
+		}
+		c.Backend = p.backend
+		result = append(result, c)
+	}
+	return result
+}
+
+// isProxyLeg reports whether conn is one of p's backend legs.
+func isProxyLeg(p *dockerProxy, conn model.Connection) bool {
+	key := KeyFromConnection(conn)
+	for _, leg := range p.legs {
+		if KeyFromConnection(leg.Connection) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// expandProxyLegs inserts the backend legs of expanded proxies after the
+// proxy's first client leg.
+func (m Model) expandProxyLegs(conns []connectionWithProcess, proxies map[int32]*dockerProxy) []connectionWithProcess {
+	if len(m.proxyExpanded) == 0 {
+		return conns
+	}
+	result := make([]connectionWithProcess, 0, len(conns))
+	done := map[int32]bool{}
+	for _, c := range conns {
+		result = append(result, c)
+		if c.Backend == "" || done[c.PID] || !m.proxyExpanded[c.PID] {
+			continue
+		}
+		done[c.PID] = true
+		if p := proxies[c.PID]; p != nil {
+			result = append(result, m.sortAllConnections(p.legs)...)
+		}
+	}
+	return result
+}
+
+// isProxied reports whether c is a collapsed or expanded docker-proxy row.
+func (c connectionWithProcess) isProxied() bool {
+	return c.Backend != "" || c.ProxyLeg
+}
+
+// toggleProxyDetail shows or hides the backend legs of the selected row's
+// docker-proxy.
+func (m *Model) toggleProxyDetail() {
+	sel := m.selectedConnection()
+	if sel == nil || !sel.isProxied() {
+		return
+	}
+	if m.proxyExpanded == nil {
+		m.proxyExpanded = make(map[int32]bool)
+	}
+	if m.proxyExpanded[sel.PID] {
+		delete(m.proxyExpanded, sel.PID)
+	} else {
+		m.proxyExpanded[sel.PID] = true
+	}
+	m.pipeline.invalidate()
+}
+
+// processCell returns the Process cell of a flat view row: the name with its
+// note, the container a docker-proxy client leg forwards to, or an indented
+// marker for an expanded backend leg.
+func (m Model) processCell(c connectionWithProcess) string {
+	switch {
+	case c.ProxyLeg:
+		return "  ↳ " + c.ProcessName
+	case c.Backend != "":
+		return m.withNote(c.ProcessName, c.ProcessName) + " ⇢ " + c.Backend
+	}
+	return m.withNote(c.ProcessName, c.ProcessName)
+}
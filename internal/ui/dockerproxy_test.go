@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/docker"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// proxyTestModel adds a docker-proxy publishing 8080 with one proxied client
+// to chipsTestModel, in the flat view.
+func proxyTestModel() Model {
+	m := chipsTestModel()
+	m.snapshot.Applications = append(m.snapshot.Applications, model.Application{
+		Name: dockerProxyName, PIDs: []int32{50}, Connections: []model.Connection{
+			{Protocol: model.ProtocolTCP, LocalAddr: "0.0.0.0:8080", RemoteAddr: "*", State: model.StateListen, PID: 50},
+			{Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.2:8080", RemoteAddr: "5.5.5.5:40000", State: model.StateEstablished, PID: 50},
+			{Protocol: model.ProtocolTCP, LocalAddr: "172.17.0.1:45000", RemoteAddr: "172.17.0.2:80", State: model.StateEstablished, PID: 50},
+		},
+	})
+	m.stack = []ViewState{{Level: LevelAllConnections, SortColumn: SortProcess, SortAscending: true}}
+	return m
+}
+
+func remoteAddrs(conns []connectionWithProcess) []string {
+	var out []string
+	for _, c := range conns {
+		out = append(out, c.RemoteAddr)
+	}
+	return out
+}
+
+func TestDockerProxy_BackendLegCollapsed(t *testing.T) {
+	m := proxyTestModel()
+
+	conns := m.visibleAllConnections()
+	if slices.Contains(remoteAddrs(conns), "172.17.0.2:80") {
+		t.Errorf("backend leg should be hidden, got %v", remoteAddrs(conns))
+	}
+	idx := slices.Index(remoteAddrs(conns), "5.5.5.5:40000")
+	if idx < 0 || conns[idx].Backend != "172.17.0.2:80" {
+		t.Fatalf("client leg = %+v, want labelled with the backend address", conns)
+	}
+	if got := m.processCell(conns[idx]); got != "docker-proxy ⇢ 172.17.0.2:80" {
+		t.Errorf("process cell = %q", got)
+	}
+}
+
+func TestDockerProxy_ContainerNameFromDocker(t *testing.T) {
+	m := proxyTestModel()
+	m.dockerCache = map[int]*docker.ContainerPort{8080: {Container: model.ContainerInfo{Name: "web"}, HostPort: 8080, ContainerPort: 80}}
+
+	for _, c := range m.visibleAllConnections() {
+		if c.RemoteAddr == "5.5.5.5:40000" && c.Backend != "web:80" {
+			t.Errorf("backend = %q, want web:80", c.Backend)
+		}
+	}
+}
+
+func TestDockerProxy_EnterExpandsLegs(t *testing.T) {
+	m := proxyTestModel()
+	conns := m.visibleAllConnections()
+	m.CurrentView().Cursor = slices.Index(remoteAddrs(conns), "5.5.5.5:40000")
+
+	if !strings.Contains(stripAnsi(m.renderKeybindingsText()), "↵ proxy legs") {
+		t.Error("footer should offer expanding the proxy row")
+	}
+	m = pressSpecial(m, tea.KeyEnter)
+	conns = m.visibleAllConnections()
+	idx := slices.Index(remoteAddrs(conns), "172.17.0.2:80")
+	if idx < 0 || !conns[idx].ProxyLeg || conns[idx-1].RemoteAddr != "5.5.5.5:40000" {
+		t.Fatalf("rows = %v, want the backend leg right after its client leg", remoteAddrs(conns))
+	}
+	if got := m.processCell(conns[idx]); got != "  ↳ docker-proxy" {
+		t.Errorf("leg cell = %q", got)
+	}
+
+	m = pressSpecial(m, tea.KeyEnter)
+	if slices.Contains(remoteAddrs(m.visibleAllConnections()), "172.17.0.2:80") {
+		t.Error("second Enter should collapse the legs again")
+	}
+}
+
+func TestDockerProxy_UnpairedLeftAlone(t *testing.T) {
+	m := proxyTestModel()
+	// No client leg: nothing to collapse into
+	conns := m.snapshot.Applications[2].Connections
+	m.snapshot.Applications[2].Connections = slices.Delete(slices.Clone(conns), 1, 2)
+
+	if !slices.Contains(remoteAddrs(m.visibleAllConnections()), "172.17.0.2:80") {
+		t.Error("a backend leg without client legs should stay visible")
+	}
+}
+
+func TestDockerProxy_OtherProcessesUntouched(t *testing.T) {
+	m := proxyTestModel()
+	for _, c := range m.visibleAllConnections() {
+		if c.ProcessName != dockerProxyName && c.isProxied() {
+			t.Errorf("%s row marked as proxied", c.ProcessName)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package ui
+
+import (
+	"bytes"
+	"syscall"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/exp/teatest"
+
+	"github.com/kostyay/netmon/internal/config"
+	"github.com/kostyay/netmon/internal/docker"
+	"github.com/kostyay/netmon/internal/model"
+	"github.com/kostyay/netmon/internal/security"
+)
+
+// e2eTimeout bounds every wait so a broken flow fails instead of hanging.
+const e2eTimeout = 3 * time.Second
+
+// sentSignal records a signal delivered through sendSignal.
+type sentSignal struct {
+	pid int
+	sig syscall.Signal
+}
+
+// e2eHarness drives the full Bubble Tea program (Init, tick loop, renderer)
+// against mock data sources and asserts on the rendered terminal output.
+type e2eHarness struct {
+	t       *testing.T
+	tm      *teatest.TestModel
+	signals []sentSignal
+}
+
+// e2eSnapshot returns a small, deterministic snapshot for end-to-end flows.
+func e2eSnapshot() *model.NetworkSnapshot {
+	return &model.NetworkSnapshot{
+		Timestamp: time.Now(),
+		Applications: []model.Application{
+			{
+				Name: "nginx", PIDs: []int32{100}, ListenCount: 1,
+				Connections: []model.Connection{
+					{PID: 100, Protocol: model.ProtocolTCP, LocalAddr: "0.0.0.0:8080", RemoteAddr: "*", State: model.StateListen},
+				},
+			},
+			{
+				Name: "curl", PIDs: []int32{200}, EstablishedCount: 1,
+				Connections: []model.Connection{
+					{PID: 200, Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.2:51000", RemoteAddr: "93.184.216.34:443", State: model.StateEstablished},
+				},
+			},
+			{
+				Name: "dnsmasq", PIDs: []int32{300},
+				Connections: []model.Connection{
+					{PID: 300, Protocol: model.ProtocolUDP, LocalAddr: "127.0.0.1:53", RemoteAddr: "*"},
+				},
+			},
+		},
+	}
+}
+
+// newE2EHarness starts the program with the given snapshot served by a mock
+// collector. Network access (version check) and real signals are stubbed out.
+func newE2EHarness(t *testing.T, snapshot *model.NetworkSnapshot) *e2eHarness {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	h := &e2eHarness{t: t}
+
+	origLatest, origSignal := checkLatest, sendSignal
+	checkLatest = func(owner, repo, current string) (string, error) { return "", nil }
+	sendSignal = func(pid int, sig syscall.Signal) error {
+		h.signals = append(h.signals, sentSignal{pid: pid, sig: sig})
+		return nil
+	}
+	t.Cleanup(func() { checkLatest, sendSignal = origLatest, origSignal })
+
+	m := Model{
+		collector:       newMockCollector(snapshot),
+		netIOCollector:  newMockNetIOCollector(nil),
+		dockerResolver:  newMockDockerResolver(nil),
+		conntrackReader: &mockConntrackReader{},
+		refreshInterval: DefaultRefreshInterval,
+		netIOCache:      make(map[int32]*model.NetIOStats),
+		changes:         make(map[ConnectionKey]Change),
+		dnsCache:        make(map[string]string),
+		dockerCache:     make(map[int]*docker.ContainerPort),
+		securityCache:   make(map[int32]security.Context),
+		sortPrefs:       make(map[string]config.SortPref),
+		version:         "dev",
+	}
+	m.stack = []ViewState{m.newViewState(LevelProcessList, "")}
+
+	h.tm = teatest.NewTestModel(t, m, teatest.WithInitialTermSize(120, 30))
+	return h
+}
+
+// keys types each character of s as a separate key press.
+func (h *e2eHarness) keys(s string) {
+	for _, r := range s {
+		h.tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+// press sends a special key such as tea.KeyEnter or tea.KeyEsc.
+func (h *e2eHarness) press(k tea.KeyType) {
+	h.tm.Send(tea.KeyMsg{Type: k})
+}
+
+// waitFor blocks until text appears in newly rendered output (ANSI stripped).
+// Output is consumed as it is read, so each call only sees frames rendered
+// since the previous wait.
+func (h *e2eHarness) waitFor(text string) {
+	h.t.Helper()
+	teatest.WaitFor(h.t, h.tm.Output(), func(out []byte) bool {
+		return bytes.Contains([]byte(ansi.Strip(string(out))), []byte(text))
+	}, teatest.WithDuration(e2eTimeout), teatest.WithCheckInterval(10*time.Millisecond))
+}
+
+// quit exits the program and returns the final model.
+func (h *e2eHarness) quit() Model {
+	h.t.Helper()
+	h.tm.Send(tea.KeyMsg{Type: tea.KeyCtrlC})
+	return h.tm.FinalModel(h.t, teatest.WithFinalTimeout(e2eTimeout)).(Model)
+}
+
+func TestE2E_InitialRender(t *testing.T) {
+	h := newE2EHarness(t, e2eSnapshot())
+	h.waitFor("dnsmasq")
+
+	final := h.quit()
+	if final.snapshot == nil || len(final.snapshot.Applications) != 3 {
+		t.Error("final model should hold the collected snapshot")
+	}
+}
+
+func TestE2E_DrillDownFilterKill(t *testing.T) {
+	h := newE2EHarness(t, e2eSnapshot())
+	h.waitFor("nginx")
+
+	// Filter the process list down to curl and drill in.
+	h.keys("/curl")
+	h.press(tea.KeyEnter)
+	h.press(tea.KeyEnter)
+	h.waitFor("93.184.216.34:443")
+
+	// Kill the owning process from the connections view.
+	h.keys("x")
+	h.waitFor("SIGTERM")
+	h.press(tea.KeyEnter)
+	h.waitFor("Killed PID 200 (curl)")
+
+	final := h.quit()
+	if len(h.signals) != 1 || h.signals[0] != (sentSignal{pid: 200, sig: syscall.SIGTERM}) {
+		t.Errorf("signals = %+v, want SIGTERM to PID 200", h.signals)
+	}
+	if view := final.CurrentView(); view.Level != LevelConnections || view.ProcessName != "curl" {
+		t.Errorf("final view = %v %q, want connections for curl", view.Level, view.ProcessName)
+	}
+	if final.killMode {
+		t.Error("kill modal should be closed after confirming")
+	}
+}
+
+func TestE2E_EscCancelsKillAndPopsView(t *testing.T) {
+	h := newE2EHarness(t, e2eSnapshot())
+	h.waitFor("nginx")
+
+	h.press(tea.KeyEnter) // drill into first process (auto sort: nginx, the only listener)
+	h.waitFor("0.0.0.0:8080")
+	h.keys("X")
+	h.waitFor("SIGKILL")
+	h.press(tea.KeyEsc) // cancel kill
+	h.press(tea.KeyEsc) // back to process list
+	h.waitFor("dnsmasq")
+
+	final := h.quit()
+	if len(h.signals) != 0 {
+		t.Errorf("signals = %+v, want none after cancel", h.signals)
+	}
+	if view := final.CurrentView(); view.Level != LevelProcessList {
+		t.Errorf("final level = %v, want process list", view.Level)
+	}
+}
@@ -0,0 +1,192 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/ephemeral"
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// Ephemeral flows: connections that opened and closed between two refreshes
+// are logged to the diagnostics event history ('!') as "ephemeral", grouped
+// by process and peer, up to maxEphemeralEvents entries per refresh.
+const (
+	ephemeralLabel     = "ephemeral"
+	maxEphemeralEvents = 3
+)
+
+// effectiveUID returns netmon's effective user ID (replaced in tests).
+var effectiveUID = os.Geteuid
+
+// startEphemeralWatch starts a closed connection watcher in the background.
+func startEphemeralWatch() tea.Msg {
+	w := ephemeral.New()
+	if err := w.Start(); err != nil {
+		return EphemeralWatchStartedMsg{Err: err}
+	}
+	return EphemeralWatchStartedMsg{Watcher: w}
+}
+
+// handleEphemeralWatchStarted adopts a newly started watcher, or reports why
+// ephemeral flows are unavailable.
+func (m *Model) handleEphemeralWatchStarted(msg EphemeralWatchStartedMsg) tea.Cmd {
+	if msg.Err != nil {
+		m.recordError(sourceEphemeral, msg.Err)
+		return m.notify(toastWarn, fmt.Sprintf(i18n.T("Ephemeral flows unavailable: %v"), msg.Err))
+	}
+	if !m.ephemeralFlows {
+		// Setting was turned off while the watcher was starting.
+		msg.Watcher.Stop()
+		return nil
+	}
+	m.stopEphemeralWatch()
+	m.ephemeralWatcher = msg.Watcher
+	return nil
+}
+
+// stopEphemeralWatch stops the running watcher, if any.
+func (m *Model) stopEphemeralWatch() {
+	if m.ephemeralWatcher != nil {
+		m.ephemeralWatcher.Stop()
+		m.ephemeralWatcher = nil
+	}
+}
+
+// recordEphemeral logs the connections closed since the last snapshot that
+// neither prev nor curr lists.
+func (m *Model) recordEphemeral(prev, curr *model.NetworkSnapshot) {
+	if m.ephemeralWatcher == nil {
+		return
+	}
+	m.addEphemeral(m.ephemeralWatcher.Drain(), prev, curr)
+}
+
+// addEphemeral counts and logs the flows of batch missing from prev and curr.
+// Sampled snapshots do not list every connection, so their batch is dropped
+// unclassified, as is the first one, which holds connections opened before
+// netmon started.
+func (m *Model) addEphemeral(batch ephemeral.Batch, prev, curr *model.NetworkSnapshot) {
+	if batch.Err != nil {
+		m.recordError(sourceEphemeral, batch.Err)
+	}
+	m.ephemeralDropped += batch.Dropped
+	if prev == nil || curr == nil || prev.Sample != nil || curr.Sample != nil {
+		return
+	}
+
+	uid := effectiveUID()
+	var flows []ephemeral.Flow
+	for _, f := range ephemeral.Classify(batch.Flows, prev, curr) {
+		// Without root, other users' processes are missing from snapshots:
+		// their closed connections would all look ephemeral
+		if uid != 0 && int(f.UID) != uid {
+			continue
+		}
+		if m.hideLoopback && f.Remote.Addr().Unmap().IsLoopback() {
+			continue
+		}
+		flows = append(flows, f)
+	}
+	if len(flows) == 0 {
+		return
+	}
+	m.ephemeralTotal += len(flows)
+
+	type group struct {
+		desc  string
+		count int
+	}
+	listeners := boundApps(prev, curr)
+	byDesc := make(map[string]*group)
+	for _, f := range flows {
+		desc := m.ephemeralDesc(f, listeners)
+		if g := byDesc[desc]; g != nil {
+			g.count++
+		} else {
+			byDesc[desc] = &group{desc: desc, count: 1}
+		}
+	}
+	groups := make([]*group, 0, len(byDesc))
+	for _, g := range byDesc {
+		groups = append(groups, g)
+	}
+	slices.SortFunc(groups, func(a, b *group) int {
+		return cmp.Or(cmp.Compare(b.count, a.count), strings.Compare(a.desc, b.desc))
+	})
+
+	for _, g := range groups[:min(len(groups), maxEphemeralEvents)] {
+		msg := g.desc
+		if g.count > 1 {
+			msg += fmt.Sprintf(" ×%d", g.count)
+		}
+		m.logEvent(ephemeralLabel, msg)
+	}
+	if rest := len(groups) - maxEphemeralEvents; rest > 0 {
+		more := 0
+		for _, g := range groups[maxEphemeralEvents:] {
+			more += g.count
+		}
+		m.logEvent(ephemeralLabel, fmt.Sprintf("%d more flows to %d peers", more, rest))
+	}
+}
+
+// boundPort is a local port a process listens on (TCP) or is bound to (UDP).
+type boundPort struct {
+	protocol model.Protocol
+	port     int
+}
+
+// boundApps maps the bound ports of snapshots to the process holding them;
+// the first snapshot listing a port wins.
+func boundApps(snapshots ...*model.NetworkSnapshot) map[boundPort]string {
+	apps := make(map[boundPort]string)
+	for _, snap := range snapshots {
+		for _, app := range snap.Applications {
+			for _, conn := range app.Connections {
+				if !conn.IsBound() {
+					continue
+				}
+				key := boundPort{conn.Protocol, model.ExtractPort(conn.LocalAddr)}
+				if _, ok := apps[key]; !ok {
+					apps[key] = app.Name
+				}
+			}
+		}
+	}
+	return apps
+}
+
+// ephemeralDesc describes a closed flow for the event history. Clients of a
+// port a process listens on (listeners, from boundApps) are attributed to it,
+// e.g. "TCP nginx :80 ← 10.0.0.7"; outbound flows carry only the owning user,
+// e.g. "TCP uid 1000 → one.one.one.one:https".
+func (m Model) ephemeralDesc(f ephemeral.Flow, listeners map[boundPort]string) string {
+	port := int(f.Local.Port())
+	if name, ok := listeners[boundPort{f.Protocol, port}]; ok {
+		return fmt.Sprintf("%s %s :%d ← %s", f.Protocol, name, port, f.Remote.Addr().Unmap())
+	}
+	// Unbracketed, as the collector formats addresses and the DNS cache keys them
+	addr := fmt.Sprintf("%s:%d", f.Remote.Addr().Unmap(), f.Remote.Port())
+	remote := formatRemoteAddr(addr, string(f.Protocol), m.dnsCache, m.serviceNames)
+	return fmt.Sprintf("%s uid %d → %s", f.Protocol, f.UID, remote)
+}
+
+// ephemeralSummary returns the diagnostics line counting ephemeral flows, ""
+// when the setting is off.
+func (m Model) ephemeralSummary() string {
+	if !m.ephemeralFlows {
+		return ""
+	}
+	s := fmt.Sprintf(i18n.T("Ephemeral flows: %d between refreshes"), m.ephemeralTotal)
+	if m.ephemeralDropped > 0 {
+		s += " " + fmt.Sprintf(i18n.T("(%d not recorded, buffer full)"), m.ephemeralDropped)
+	}
+	return s
+}
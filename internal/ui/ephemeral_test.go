@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"errors"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/kostyay/netmon/internal/ephemeral"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+func closedFlow(proto model.Protocol, local, remote string, uid uint32) ephemeral.Flow {
+	return ephemeral.Flow{Protocol: proto, Local: netip.MustParseAddrPort(local), Remote: netip.MustParseAddrPort(remote), UID: uid}
+}
+
+func ephemeralEvents(m Model) []string {
+	var msgs []string
+	for _, t := range m.toastLog {
+		if t.Label == ephemeralLabel {
+			msgs = append(msgs, t.Message)
+		}
+	}
+	return msgs
+}
+
+func stubEffectiveUID(t *testing.T, uid int) {
+	t.Helper()
+	orig := effectiveUID
+	effectiveUID = func() int { return uid }
+	t.Cleanup(func() { effectiveUID = orig })
+}
+
+func TestAddEphemeral_LogsFlowsMissingFromSnapshots(t *testing.T) {
+	stubEffectiveUID(t, 0)
+	m := chipsTestModel()
+	m.ephemeralFlows = true
+	m.snapshot.Applications = append(m.snapshot.Applications, model.Application{
+		Name: "nginx", PIDs: []int32{30}, Connections: []model.Connection{
+			{Protocol: model.ProtocolTCP, LocalAddr: "*:80", RemoteAddr: "*:*", State: model.StateListen, PID: 30},
+		},
+	})
+
+	batch := ephemeral.Batch{Flows: []ephemeral.Flow{
+		closedFlow(model.ProtocolTCP, "10.0.0.2:50000", "1.1.1.1:443", 1000), // listed by chrome
+		closedFlow(model.ProtocolTCP, "10.0.0.2:80", "10.0.0.7:41000", 33),   // health check to nginx
+		closedFlow(model.ProtocolTCP, "10.0.0.2:80", "10.0.0.7:41001", 33),
+		closedFlow(model.ProtocolUDP, "10.0.0.2:40000", "8.8.8.8:53", 1000),
+	}}
+	m.addEphemeral(batch, m.snapshot, m.snapshot)
+
+	got := ephemeralEvents(m)
+	want := []string{"TCP nginx :80 ← 10.0.0.7 ×2", "UDP uid 1000 → 8.8.8.8:53"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("events = %q, want %q", got, want)
+	}
+	if m.ephemeralTotal != 3 {
+		t.Errorf("ephemeralTotal = %d, want 3", m.ephemeralTotal)
+	}
+	if s := m.ephemeralSummary(); !strings.Contains(s, "3 between refreshes") {
+		t.Errorf("summary = %q", s)
+	}
+}
+
+func TestAddEphemeral_SkipsUnclassifiableBatches(t *testing.T) {
+	stubEffectiveUID(t, 0)
+	flows := ephemeral.Batch{Flows: []ephemeral.Flow{closedFlow(model.ProtocolTCP, "10.0.0.2:50009", "9.9.9.9:443", 1000)}}
+
+	m := chipsTestModel()
+	m.addEphemeral(flows, nil, m.snapshot)
+	if len(ephemeralEvents(m)) != 0 {
+		t.Error("first batch predates the first snapshot and should be dropped")
+	}
+
+	sampled := *m.snapshot
+	sampled.Sample = &model.Sample{Total: 100000}
+	m.addEphemeral(flows, m.snapshot, &sampled)
+	if len(ephemeralEvents(m)) != 0 {
+		t.Error("sampled snapshots do not list every connection; nothing should be logged")
+	}
+}
+
+func TestAddEphemeral_UnprivilegedOnlyOwnUser(t *testing.T) {
+	stubEffectiveUID(t, 1000)
+	m := chipsTestModel()
+	m.hideLoopback = true
+	batch := ephemeral.Batch{Flows: []ephemeral.Flow{
+		closedFlow(model.ProtocolTCP, "10.0.0.2:50010", "9.9.9.9:443", 0),        // root's process, invisible to us
+		closedFlow(model.ProtocolTCP, "127.0.0.1:50011", "127.0.0.1:8080", 1000), // loopback, hidden
+		closedFlow(model.ProtocolTCP, "10.0.0.2:50012", "9.9.9.9:443", 1000),
+	}}
+	m.addEphemeral(batch, m.snapshot, m.snapshot)
+
+	if got := ephemeralEvents(m); len(got) != 1 || !strings.Contains(got[0], "uid 1000 → 9.9.9.9") {
+		t.Errorf("events = %q, want only the own user's non-loopback flow", got)
+	}
+}
+
+func TestAddEphemeral_CapsEventsPerRefresh(t *testing.T) {
+	stubEffectiveUID(t, 0)
+	m := chipsTestModel()
+	var batch ephemeral.Batch
+	for i, remote := range []string{"9.9.9.1:443", "9.9.9.2:443", "9.9.9.3:443", "9.9.9.4:443", "9.9.9.5:443"} {
+		batch.Flows = append(batch.Flows, closedFlow(model.ProtocolTCP, netip.AddrPortFrom(netip.MustParseAddr("10.0.0.2"), uint16(51000+i)).String(), remote, 1000))
+	}
+	m.addEphemeral(batch, m.snapshot, m.snapshot)
+
+	got := ephemeralEvents(m)
+	if len(got) != maxEphemeralEvents+1 || got[maxEphemeralEvents] != "2 more flows to 2 peers" {
+		t.Errorf("events = %q", got)
+	}
+}
+
+func TestAddEphemeral_RecordsReadErrors(t *testing.T) {
+	m := chipsTestModel()
+	m.addEphemeral(ephemeral.Batch{Err: errors.New("netlink recv: no buffer space available")}, m.snapshot, m.snapshot)
+	if len(m.diagLog) != 1 || m.diagLog[0].Source != sourceEphemeral {
+		t.Errorf("diagLog = %+v", m.diagLog)
+	}
+}
+
+func TestHandleEphemeralWatchStarted_Error(t *testing.T) {
+	m := createTestModel()
+	m.ephemeralFlows = true
+	if cmd := m.handleEphemeralWatchStarted(EphemeralWatchStartedMsg{Err: errors.New("unsupported")}); cmd == nil {
+		t.Error("expected a warning toast")
+	}
+	if m.ephemeralWatcher != nil || len(m.diagLog) == 0 {
+		t.Error("failed start should be recorded and leave no watcher")
+	}
+}
+
+func TestLogEvent_ShowsLabelInDiagnostics(t *testing.T) {
+	m := createTestModel()
+	m.logEvent(ephemeralLabel, "TCP uid 1000 → 9.9.9.9:443")
+	if len(m.toasts) != 0 {
+		t.Error("logEvent should not show a toast")
+	}
+	if out := stripAnsi(m.renderDiagnosticsModalContent()); !strings.Contains(out, "ephemeral") || !strings.Contains(out, "9.9.9.9") {
+		t.Errorf("diagnostics should list the event under its label:\n%s", out)
+	}
+}
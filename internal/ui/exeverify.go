@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/exeverify"
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/procenv"
+)
+
+// verifyExe hashes an executable and checks its signature (replaced in tests).
+var verifyExe = exeverify.Verify
+
+// ExeVerifiedMsg carries the verification result of a process's executable.
+type ExeVerifiedMsg struct {
+	Name   string // process name, for the result toast
+	Result exeverify.Result
+}
+
+// newVerifySpinner returns the spinner shown while an executable is hashed.
+func newVerifySpinner() spinner.Model {
+	if plainMode {
+		return spinner.New(spinner.WithSpinner(spinner.Line))
+	}
+	return spinner.New(spinner.WithSpinner(spinner.MiniDot), spinner.WithStyle(WarnStyle()))
+}
+
+// startExeVerify hashes the drill-down process's executable and checks its
+// code signature in the background. Pressing it again re-verifies.
+func (m Model) startExeVerify() (tea.Model, tea.Cmd) {
+	app := m.findSelectedApp(m.CurrentView().ProcessName)
+	if app == nil || len(app.PIDs) == 0 {
+		return m, nil
+	}
+	if app.Exe == "" {
+		return m, m.notify(toastWarn, i18n.T("Executable path unknown (run with sudo)"))
+	}
+	if m.verifyPending[app.Exe] {
+		return m, nil
+	}
+	if m.verifyPending == nil {
+		m.verifyPending = make(map[string]bool)
+	}
+	cmds := []tea.Cmd{}
+	if len(m.verifyPending) == 0 {
+		cmds = append(cmds, m.verifySpinner.Tick)
+	}
+	m.verifyPending[app.Exe] = true
+	delete(m.verifyCache, app.Exe)
+
+	name, pid, path := app.Name, app.PIDs[0], app.Exe
+	cmds = append(cmds, func() tea.Msg {
+		return ExeVerifiedMsg{Name: name, Result: verifyExe(pid, path)}
+	})
+	return m, tea.Batch(cmds...)
+}
+
+// handleExeVerified caches a verification result and reports it as a toast,
+// which matters when the user has navigated away from the detail pane.
+func (m Model) handleExeVerified(msg ExeVerifiedMsg) (tea.Model, tea.Cmd) {
+	r := msg.Result
+	delete(m.verifyPending, r.Path)
+	if m.verifyCache == nil {
+		m.verifyCache = make(map[string]exeverify.Result)
+	}
+	m.verifyCache[r.Path] = r
+
+	switch {
+	case r.HashErr != nil:
+		return m, m.notify(toastError, fmt.Sprintf(i18n.T("Hashing %s failed: %s"), msg.Name, procenv.Reason(r.HashErr)))
+	case r.Signature == exeverify.SigInvalid:
+		return m, m.notify(toastError, fmt.Sprintf(i18n.T("%s: code signature INVALID"), msg.Name))
+	case r.Signature == exeverify.SigUnsigned:
+		return m, m.notify(toastWarn, fmt.Sprintf(i18n.T("%s: executable is unsigned"), msg.Name))
+	case r.Signature == exeverify.SigValid:
+		return m, m.notify(toastSuccess, fmt.Sprintf(i18n.T("Verified %s: signature valid"), msg.Name))
+	}
+	return m, m.notify(toastSuccess, fmt.Sprintf(i18n.T("Hashed %s"), msg.Name))
+}
+
+// handleVerifySpinnerTick advances the spinner while a verification runs.
+func (m Model) handleVerifySpinnerTick(msg spinner.TickMsg) (tea.Model, tea.Cmd) {
+	if len(m.verifyPending) == 0 {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.verifySpinner, cmd = m.verifySpinner.Update(msg)
+	return m, cmd
+}
+
+// exeVerifyLine returns the detail pane line for exe: a spinner while it is
+// hashed, then its digest and signature status. Returns "" when exe has not
+// been verified. warn is set for unsigned or invalid signatures.
+func (m Model) exeVerifyLine(exe string) (line string, warn bool) {
+	if exe == "" {
+		return "", false
+	}
+	if m.verifyPending[exe] {
+		return m.verifySpinner.View() + " Hashing executable…", false
+	}
+	r, ok := m.verifyCache[exe]
+	if !ok {
+		return "", false
+	}
+
+	line = "SHA256: " + r.SHA256
+	if r.HashErr != nil {
+		line = "SHA256: " + procenv.Reason(r.HashErr)
+	}
+	switch r.Signature {
+	case exeverify.SigUnchecked:
+		return line, false
+	case exeverify.SigInvalid:
+		line += "  |  Signature: " + r.Signature.String()
+		if r.SigDetail != "" {
+			line += " (" + r.SigDetail + ")"
+		}
+		return line, true
+	}
+	return line + "  |  Signature: " + r.Signature.String(), r.Signature == exeverify.SigUnsigned
+}
@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/exeverify"
+)
+
+// stubVerifyExe replaces the hash/signature check with a fixed result.
+func stubVerifyExe(t *testing.T, r exeverify.Result) *[]int32 {
+	t.Helper()
+	var pids []int32
+	orig := verifyExe
+	verifyExe = func(pid int32, path string) exeverify.Result {
+		pids = append(pids, pid)
+		r.Path = path
+		return r
+	}
+	t.Cleanup(func() { verifyExe = orig })
+	return &pids
+}
+
+// verifyTestModel drills into chrome with a known executable path.
+func verifyTestModel() Model {
+	m := chipsTestModel()
+	m.width = 160
+	m.verifySpinner = newVerifySpinner()
+	m.snapshot.Applications[0].Exe = "/opt/chrome/chrome"
+	m.PushView(ViewState{Level: LevelConnections, ProcessName: "chrome", SortColumn: SortRemote, SortAscending: true})
+	return m
+}
+
+func TestExeVerify_SpinnerThenResult(t *testing.T) {
+	pids := stubVerifyExe(t, exeverify.Result{SHA256: "ab12cd", Signature: exeverify.SigValid})
+	m := verifyTestModel()
+	baseHeight := m.frozenHeaderHeight()
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("V")})
+	m = updated.(Model)
+	if !m.verifyPending["/opt/chrome/chrome"] {
+		t.Fatal("V should start verifying the drill-down executable")
+	}
+	if line, _ := m.exeVerifyLine("/opt/chrome/chrome"); !strings.Contains(line, "Hashing executable") {
+		t.Errorf("pending line = %q, want a spinner", line)
+	}
+	if m.frozenHeaderHeight() != baseHeight+1 {
+		t.Errorf("header height = %d, want %d", m.frozenHeaderHeight(), baseHeight+1)
+	}
+
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		t.Fatal("V should start the spinner and the verification together")
+	}
+	for _, c := range batch {
+		if v, ok := c().(ExeVerifiedMsg); ok {
+			updated, _ = m.Update(v)
+			m = updated.(Model)
+		}
+	}
+	if len(*pids) != 1 || (*pids)[0] != 10 {
+		t.Errorf("verified PIDs = %v, want [10]", *pids)
+	}
+	if m.verifyPending["/opt/chrome/chrome"] {
+		t.Error("result should clear the pending state")
+	}
+	if !strings.Contains(stripAnsi(m.renderFrozenHeader()), "SHA256: ab12cd  |  Signature: valid") {
+		t.Errorf("detail pane should show the result, got:\n%s", stripAnsi(m.renderFrozenHeader()))
+	}
+	if last := m.toasts[len(m.toasts)-1].Message; last != "Verified chrome: signature valid" {
+		t.Errorf("toast = %q", last)
+	}
+}
+
+func TestExeVerify_ResultLines(t *testing.T) {
+	m := verifyTestModel()
+	tests := []struct {
+		r        exeverify.Result
+		want     string
+		wantWarn bool
+	}{
+		{exeverify.Result{SHA256: "ab12"}, "SHA256: ab12", false},
+		{exeverify.Result{SHA256: "ab12", Signature: exeverify.SigUnsigned}, "SHA256: ab12  |  Signature: unsigned", true},
+		{exeverify.Result{SHA256: "ab12", Signature: exeverify.SigInvalid, SigDetail: "a sealed resource is missing or invalid"},
+			"SHA256: ab12  |  Signature: INVALID (a sealed resource is missing or invalid)", true},
+		{exeverify.Result{HashErr: os.ErrPermission}, "SHA256: permission denied (run with sudo)", false},
+	}
+	for _, tt := range tests {
+		m.verifyCache = map[string]exeverify.Result{"/opt/chrome/chrome": tt.r}
+		if line, warn := m.exeVerifyLine("/opt/chrome/chrome"); line != tt.want || warn != tt.wantWarn {
+			t.Errorf("line = %q (warn %v), want %q (warn %v)", line, warn, tt.want, tt.wantWarn)
+		}
+	}
+	if line, _ := m.exeVerifyLine("/usr/bin/other"); line != "" {
+		t.Errorf("unverified exe line = %q, want empty", line)
+	}
+}
+
+func TestExeVerify_UnknownExe(t *testing.T) {
+	pids := stubVerifyExe(t, exeverify.Result{})
+	m := verifyTestModel()
+	m.snapshot.Applications[0].Exe = ""
+
+	m = pressKey(m, "V")
+	if len(*pids) != 0 || len(m.verifyPending) != 0 {
+		t.Error("nothing should be verified without an executable path")
+	}
+	if last := m.toasts[len(m.toasts)-1].Message; !strings.Contains(last, "Executable path unknown") {
+		t.Errorf("toast = %q", last)
+	}
+}
+
+func TestExeVerify_OnlyInConnectionsView(t *testing.T) {
+	stubVerifyExe(t, exeverify.Result{})
+	m := chipsTestModel()
+	m.snapshot.Applications[0].Exe = "/opt/chrome/chrome"
+
+	m = pressKey(m, "V")
+	if len(m.verifyPending) != 0 {
+		t.Error("V should do nothing on the process list")
+	}
+}
+
+func TestExeVerify_SpinnerStopsWhenIdle(t *testing.T) {
+	m := verifyTestModel()
+	if _, cmd := m.Update(spinner.TickMsg{ID: m.verifySpinner.ID()}); cmd != nil {
+		t.Error("spinner should not keep ticking with nothing pending")
+	}
+	m.verifyPending = map[string]bool{"/opt/chrome/chrome": true}
+	if _, cmd := m.Update(spinner.TickMsg{ID: m.verifySpinner.ID()}); cmd == nil {
+		t.Error("spinner should tick while a verification runs")
+	}
+}
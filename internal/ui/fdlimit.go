@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	gprocess "github.com/shirou/gopsutil/v3/process"
+
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// fdWarnPct is how much of its open file limit a process must use to be
+// highlighted: past the limit, accept() and connect() fail with EMFILE and
+// clients see connection refused under load.
+const fdWarnPct = 80
+
+// fdUsage is a process's open file descriptors against its RLIMIT_NOFILE soft
+// limit. A zero limit means the lookup failed.
+type fdUsage struct {
+	open  uint64
+	limit uint64 // math.MaxUint64 when unlimited
+}
+
+// pct returns how much of the limit is in use, or -1 when unknown.
+func (u fdUsage) pct() float64 {
+	if u.limit == 0 {
+		return -1
+	}
+	return float64(u.open) * 100 / float64(u.limit)
+}
+
+// lookupFDUsage returns a process's open file count and limit (replaced in
+// tests). Both come from /proc on Linux; other platforms report an error.
+var lookupFDUsage = func(pid int32) (fdUsage, error) {
+	ctx := context.Background()
+	p, err := gprocess.NewProcessWithContext(ctx, pid)
+	if err != nil {
+		return fdUsage{}, err
+	}
+	open, err := p.NumFDsWithContext(ctx)
+	if err != nil {
+		return fdUsage{}, err
+	}
+	limits, err := p.RlimitWithContext(ctx)
+	if err != nil {
+		return fdUsage{}, err
+	}
+	for _, l := range limits {
+		if l.Resource == gprocess.RLIMIT_NOFILE {
+			return fdUsage{open: uint64(open), limit: l.Soft}, nil
+		}
+	}
+	return fdUsage{}, fmt.Errorf("pid %d: no open file limit", pid)
+}
+
+// fdColumn is the optional process list column shown when Open Files is enabled.
+var fdColumn = columnDef{label: "FDs", id: SortFDs, minWidth: 11, flex: 0, rightAlign: true}
+
+// appFDUsage returns the usage of the app's PID closest to its limit, since
+// each PID has its own limit, and false while no PID has been looked up.
+func (m Model) appFDUsage(pids []int32) (fdUsage, bool) {
+	var worst fdUsage
+	found := false
+	for _, pid := range pids {
+		u, ok := m.fdUsage[pid]
+		if !ok {
+			continue
+		}
+		if !found || u.pct() > worst.pct() {
+			worst = u
+		}
+		found = true
+	}
+	return worst, found
+}
+
+// fdLabel returns the FDs cell for an app: "812/1024", "…" while the lookup
+// is pending, "—" if it failed.
+func (m Model) fdLabel(pids []int32) string {
+	u, ok := m.appFDUsage(pids)
+	switch {
+	case !ok:
+		return "…"
+	case u.limit == 0:
+		return "—"
+	case u.limit == math.MaxUint64:
+		return strconv.FormatUint(u.open, 10) + "/∞"
+	default:
+		return strconv.FormatUint(u.open, 10) + "/" + strconv.FormatUint(u.limit, 10)
+	}
+}
+
+// fdNearLimit reports whether any PID of the app uses fdWarnPct or more of
+// its open file limit. Always false when Open Files is off.
+func (m Model) fdNearLimit(pids []int32) bool {
+	if !m.showFDs {
+		return false
+	}
+	u, ok := m.appFDUsage(pids)
+	return ok && u.pct() >= fdWarnPct
+}
+
+// fdSortKey orders apps by the share of their limit in use; unknown sorts low.
+func (m Model) fdSortKey(pids []int32) float64 {
+	u, ok := m.appFDUsage(pids)
+	if !ok {
+		return -1
+	}
+	return u.pct()
+}
+
+// fdDetailLine returns the connections view line for the selected app, e.g.
+// "Open files: 812 of 1024 (79%)", or "" when unknown.
+func (m Model) fdDetailLine(pids []int32) string {
+	u, ok := m.appFDUsage(pids)
+	if !ok || u.limit == 0 {
+		return ""
+	}
+	if u.limit == math.MaxUint64 {
+		return fmt.Sprintf(i18n.T("Open files: %d (no limit)"), u.open)
+	}
+	return fmt.Sprintf(i18n.T("Open files: %d of %d (%.0f%%)"), u.open, u.limit, u.pct())
+}
+
+// queueFDLookups returns a command reading the open file usage of every PID
+// in snapshot. File counts change constantly, so they are read on every refresh.
+func (m Model) queueFDLookups(snapshot *model.NetworkSnapshot) tea.Cmd {
+	if !m.showFDs || snapshot == nil {
+		return nil
+	}
+	var pids []int32
+	for _, app := range snapshot.Applications {
+		pids = append(pids, app.PIDs...)
+	}
+	if len(pids) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		usage := make(map[int32]fdUsage, len(pids))
+		for _, pid := range pids {
+			// Failures are kept as a zero limit and shown as "—"
+			u, _ := lookupFDUsage(pid)
+			usage[pid] = u
+		}
+		return FDUsageResolvedMsg{Usage: usage}
+	}
+}
@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+func TestFDLabel(t *testing.T) {
+	m := createTestModel()
+	m.fdUsage = map[int32]fdUsage{
+		1: {open: 12, limit: 1024},
+		2: {open: 900, limit: 1024},
+		3: {open: 40, limit: math.MaxUint64},
+		4: {}, // lookup failed
+	}
+	for _, tt := range []struct {
+		pids []int32
+		want string
+	}{
+		{[]int32{1}, "12/1024"},
+		{[]int32{1, 2}, "900/1024"}, // the PID closest to its limit
+		{[]int32{3}, "40/∞"},
+		{[]int32{4}, "—"},
+		{[]int32{5}, "…"},
+	} {
+		if got := m.fdLabel(tt.pids); got != tt.want {
+			t.Errorf("fdLabel(%v) = %q, want %q", tt.pids, got, tt.want)
+		}
+	}
+
+	if m.fdNearLimit([]int32{2}) {
+		t.Error("nothing is highlighted while Open Files is off")
+	}
+	m.showFDs = true
+	if !m.fdNearLimit([]int32{1, 2}) || m.fdNearLimit([]int32{1}) || m.fdNearLimit([]int32{3}) {
+		t.Error("only the app with a PID at 88% of its limit should be near it")
+	}
+	if got := m.fdDetailLine([]int32{2}); got != "Open files: 900 of 1024 (88%)" {
+		t.Errorf("fdDetailLine = %q", got)
+	}
+}
+
+func TestQueueFDLookups(t *testing.T) {
+	m := createTestModel()
+	if cmd := m.queueFDLookups(m.snapshot); cmd != nil {
+		t.Error("no lookups expected when disabled")
+	}
+
+	m.showFDs = true
+	orig := lookupFDUsage
+	lookupFDUsage = func(pid int32) (fdUsage, error) {
+		if pid == 300 {
+			return fdUsage{}, errors.New("permission denied")
+		}
+		return fdUsage{open: uint64(pid), limit: 1024}, nil
+	}
+	t.Cleanup(func() { lookupFDUsage = orig })
+
+	m.fdUsage = map[int32]fdUsage{999: {open: 1, limit: 2}}
+	cmd := m.queueFDLookups(m.snapshot)
+	if cmd == nil {
+		t.Fatal("expected lookups for the snapshot's PIDs")
+	}
+	updated, _ := m.Update(cmd())
+	m = updated.(Model)
+	if got := m.fdUsage[200]; got.open != 200 || got.limit != 1024 {
+		t.Errorf("usage[200] = %+v", got)
+	}
+	if got, ok := m.fdUsage[300]; !ok || got.limit != 0 {
+		t.Errorf("failed lookup should be kept as unknown, got %+v, %v", got, ok)
+	}
+	if _, ok := m.fdUsage[999]; ok {
+		t.Error("readings should be replaced, dropping PIDs that are gone")
+	}
+}
+
+func TestProcessList_HighlightsNearFDLimit(t *testing.T) {
+	m := manyAppsModel(3)
+	m.showFDs = true
+	m.fdUsage = map[int32]fdUsage{}
+	for _, app := range m.snapshot.Applications {
+		m.fdUsage[app.PIDs[0]] = fdUsage{open: 10, limit: 1024}
+	}
+	near := m.snapshot.Applications[1]
+	m.fdUsage[near.PIDs[0]] = fdUsage{open: 1000, limit: 1024}
+
+	if cols := m.activeProcessListColumns(); cols[len(cols)-1].id != SortFDs {
+		t.Fatal("Open Files should add the FDs column")
+	}
+	out := m.renderProcessListData()
+	warn := rowStyles().warn
+	var found bool
+	for _, line := range strings.Split(out, "\n") {
+		if strings.Contains(stripAnsi(line), near.Name) {
+			found = strings.HasPrefix(line, warn.prefix) && strings.Contains(line, "1000/1024")
+		}
+	}
+	if !found {
+		t.Errorf("row of %s should be in the warning style with 1000/1024:\n%s", near.Name, out)
+	}
+}
+
+func TestSortProcessList_FDs(t *testing.T) {
+	m := createTestModel()
+	m.showFDs = true
+	m.snapshot = &model.NetworkSnapshot{Applications: []model.Application{
+		{Name: "a", PIDs: []int32{1}}, {Name: "b", PIDs: []int32{2}}, {Name: "c", PIDs: []int32{3}},
+	}}
+	m.fdUsage = map[int32]fdUsage{1: {open: 10, limit: 100}, 2: {open: 90, limit: 100}}
+	view := m.CurrentView()
+	view.SortColumn, view.SortAscending = SortFDs, false
+	got := appNames(m.sortProcessList(m.snapshot.Applications))
+	if strings.Join(got, ",") != "b,a,c" {
+		t.Errorf("sorted by FDs = %v, want b,a,c (unknown last)", got)
+	}
+}
@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// followTarget is a process or container kept in view across restarts
+// (ctrl+f), e.g. while restarting a service over and over during debugging.
+type followTarget struct {
+	name string  // application or virtual container name
+	exe  string  // executable path, to find the process again under a new name
+	pids []int32 // PIDs when last seen, to notice restarts
+	gone bool    // missing from the latest snapshot (restarting)
+}
+
+// toggleFollow starts following the selected process or container, drilling
+// into it from the process list, or stops following.
+func (m Model) toggleFollow() (tea.Model, tea.Cmd) {
+	if m.follow != nil {
+		name := m.follow.name
+		m.follow = nil
+		return m, m.notify(toastInfo, fmt.Sprintf(i18n.T("Stopped following %s"), name))
+	}
+	view := m.CurrentView()
+	if view == nil || m.snapshot == nil {
+		return m, nil
+	}
+
+	switch view.Level {
+	case LevelProcessList:
+		target := m.selectedProcessTarget()
+		if target == nil {
+			return m, nil
+		}
+		f := &followTarget{name: target.ProcessName, pids: target.PIDs}
+		if target.ContainerID == "" {
+			f.exe = target.Exe
+		}
+		m.follow = f
+		updated, cmd := m.activateSelection()
+		m = updated.(Model)
+		return m, tea.Batch(cmd, m.notify(toastInfo, fmt.Sprintf(i18n.T("Following %s"), f.name)))
+	case LevelConnections:
+		app := m.findSelectedApp(view.ProcessName)
+		if app == nil {
+			return m, nil
+		}
+		f := &followTarget{name: view.ProcessName, pids: app.PIDs}
+		if !isVirtualContainerName(view.ProcessName) {
+			f.exe = app.Exe
+		}
+		m.follow = f
+		return m, m.notify(toastInfo, fmt.Sprintf(i18n.T("Following %s"), f.name))
+	}
+	return m, nil
+}
+
+// refreshFollow finds the followed process in a new snapshot. A process that
+// came back under another name (the only one running its executable) is
+// renamed in the view stack; one that came back after being gone is
+// reselected in the process list; a change of every PID is reported as a
+// restart.
+func (m *Model) refreshFollow() tea.Cmd {
+	f := m.follow
+	if f == nil || m.snapshot == nil {
+		return nil
+	}
+	app := m.findSelectedApp(f.name)
+	if app == nil && f.exe != "" {
+		app = m.appByExe(f.exe)
+		if app != nil {
+			m.renameFollowed(app.Name)
+		}
+	}
+	if app == nil {
+		f.gone = true
+		return nil
+	}
+
+	var cmd tea.Cmd
+	if len(f.pids) > 0 && len(app.PIDs) > 0 && !slices.ContainsFunc(app.PIDs, func(pid int32) bool { return slices.Contains(f.pids, pid) }) {
+		cmd = m.notify(toastInfo, fmt.Sprintf(i18n.T("%s restarted (PID %d → %d)"), f.name, f.pids[0], app.PIDs[0]))
+	}
+	if f.gone {
+		m.selectFollowed()
+	}
+	f.gone = false
+	f.pids = app.PIDs
+	return cmd
+}
+
+// appByExe returns the only application running exe, or nil when none or
+// several do (interpreters such as python run many unrelated programs).
+func (m Model) appByExe(exe string) *model.Application {
+	var found *model.Application
+	for i := range m.snapshot.Applications {
+		if m.snapshot.Applications[i].Exe != exe {
+			continue
+		}
+		if found != nil {
+			return nil
+		}
+		found = &m.snapshot.Applications[i]
+	}
+	return found
+}
+
+// renameFollowed points the followed process and every view of it at name.
+func (m *Model) renameFollowed(name string) {
+	old := m.follow.name
+	for i := range m.stack {
+		if m.stack[i].ProcessName == old {
+			m.stack[i].ProcessName = name
+		}
+	}
+	m.follow.name = name
+	m.selectFollowed()
+}
+
+// selectFollowed moves the process list selection to the followed process,
+// so going back lands on it; validateSelection moves the cursor.
+func (m *Model) selectFollowed() {
+	for i := range m.stack {
+		if m.stack[i].Level == LevelProcessList {
+			m.stack[i].SelectedID = model.SelectionIDFromProcess(m.follow.name)
+		}
+	}
+}
+
+// followLabel returns the header badge, e.g. "FOLLOWING nginx", or "".
+func (m Model) followLabel() string {
+	if m.follow == nil {
+		return ""
+	}
+	label := fmt.Sprintf(i18n.T("FOLLOWING %s"), truncateString(m.follow.name, 24))
+	if m.follow.gone {
+		label += " " + i18n.T("(waiting)")
+	}
+	return label
+}
+
+// missingProcessMessage is shown in the connections view of a process that is
+// not in the snapshot: a wait while it is followed, else "Process not found".
+func (m Model) missingProcessMessage(processName string) string {
+	if m.follow != nil && m.follow.gone && m.follow.name == processName {
+		return EmptyStyle().Render(fmt.Sprintf(i18n.T("Waiting for %s to start again…"), processName))
+	}
+	return EmptyStyle().Render(i18n.T("Process not found"))
+}
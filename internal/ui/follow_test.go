@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/fixtures"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// followSnapshot returns the workstation fixture with chrome renamed to name
+// and restarted as pid (left out when name is empty), after an extra app per
+// name in extra.
+func followSnapshot(t *testing.T, name string, pid int32, extra ...string) *model.NetworkSnapshot {
+	t.Helper()
+	snap := fixtures.Snapshot(t, "workstation")
+	chrome := &snap.Applications[0]
+	if name == "" {
+		snap.Applications = snap.Applications[1:]
+	} else {
+		chrome.Name, chrome.PIDs = name, []int32{pid}
+		for i := range chrome.Connections {
+			chrome.Connections[i].PID = pid
+		}
+	}
+	for _, e := range extra {
+		snap.Applications = append([]model.Application{{Name: e, PIDs: []int32{99}, Connections: []model.Connection{
+			{Protocol: model.ProtocolTCP, LocalAddr: "192.168.1.20:40000", RemoteAddr: "9.9.9.9:443", State: model.StateEstablished, PID: 99},
+		}}}, snap.Applications...)
+	}
+	return snap
+}
+
+// followModel shows the workstation fixture following chrome (PID 1200).
+func followModel(t *testing.T) Model {
+	t.Helper()
+	m := pressSpecial(goldenModel(t, "workstation", 120, 30), tea.KeyCtrlF)
+	if m.follow == nil || m.CurrentView().Level != LevelConnections || m.CurrentView().ProcessName != "chrome" {
+		t.Fatalf("ctrl+f should follow and drill into chrome, view = %+v", m.CurrentView())
+	}
+	return m
+}
+
+func TestFollow_SurvivesRestart(t *testing.T) {
+	m := followModel(t)
+
+	m = sendData(m, followSnapshot(t, "", 0))
+	if view := stripAnsi(m.View()); !strings.Contains(view, "Waiting for chrome to start again") || !strings.Contains(view, "FOLLOWING chrome (waiting)") {
+		t.Errorf("view while chrome is gone:\n%s", view)
+	}
+
+	m = sendData(m, followSnapshot(t, "chrome", 11))
+	if m.follow.gone || m.CurrentView().ProcessName != "chrome" {
+		t.Fatalf("follow = %+v, view = %+v", m.follow, m.CurrentView())
+	}
+	if last := m.toasts[len(m.toasts)-1]; last.Message != "chrome restarted (PID 1200 → 11)" {
+		t.Errorf("last toast = %q, want the restart", last.Message)
+	}
+	if !strings.Contains(stripAnsi(m.View()), "FOLLOWING chrome") {
+		t.Error("header should show the FOLLOWING badge")
+	}
+
+	m = pressSpecial(m, tea.KeyCtrlF)
+	if m.follow != nil || m.followLabel() != "" {
+		t.Error("ctrl+f again should stop following")
+	}
+}
+
+func TestFollow_ReselectsInProcessList(t *testing.T) {
+	m := followModel(t)
+	m.goBack()
+
+	// While chrome is gone the selection falls on curl
+	m = sendData(m, followSnapshot(t, "", 0))
+	// It comes back with a new process sorting before it
+	m = sendData(m, followSnapshot(t, "chrome", 11, "alpha"))
+	apps := m.visibleApps()
+	if got := apps[m.CurrentView().Cursor].Name; got != "chrome" {
+		t.Errorf("cursor on %s, want chrome reselected", got)
+	}
+}
+
+func TestFollow_RenamedProcess(t *testing.T) {
+	m := followModel(t)
+	m = sendData(m, followSnapshot(t, "chrome-beta", 12))
+	if m.CurrentView().ProcessName != "chrome-beta" || m.follow.name != "chrome-beta" {
+		t.Errorf("view = %q, following %q; want chrome-beta (same executable)", m.CurrentView().ProcessName, m.follow.name)
+	}
+
+	// Not when several processes run the executable
+	snap := followSnapshot(t, "chrome-2", 13)
+	snap.Applications = append(snap.Applications, model.Application{Name: "chrome-3", Exe: snap.Applications[0].Exe, PIDs: []int32{14}})
+	m = sendData(m, snap)
+	if !m.follow.gone || m.CurrentView().ProcessName != "chrome-beta" {
+		t.Errorf("ambiguous executable should wait, got view %q", m.CurrentView().ProcessName)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/charmbracelet/x/ansi"
+
 	"github.com/kostyay/netmon/internal/model"
 	"github.com/kostyay/netmon/internal/services"
 )
@@ -27,15 +29,16 @@ func formatPIDList(pids []int32) string {
 	return fmt.Sprintf("%d, %d +%d more", pids[0], pids[1], len(pids)-2)
 }
 
-// truncateString truncates a string to maxLen with ellipsis if needed.
+// truncateString truncates a string to maxLen terminal columns, with an
+// ellipsis if needed. Wide runes count as two columns and are never split.
 func truncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
+	if len(s) <= maxLen || textWidth(s) <= maxLen {
 		return s
 	}
 	if maxLen < 4 {
-		return s[:maxLen]
+		return ansi.Truncate(s, max(maxLen, 0), "")
 	}
-	return s[:maxLen-3] + "..."
+	return ansi.Truncate(s, maxLen, "...")
 }
 
 // truncateAddr is an alias for truncateString (kept for readability at call sites).
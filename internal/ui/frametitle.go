@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// frameTitle returns the title on the content frame: what the current view
+// lists, as "shown/total" when a filter, target list or collapse hides rows,
+// e.g. "chrome: 37/212 connections (filtered)". Unfiltered, the process list
+// keeps the host-wide count (with the sampling breakdown on large hosts).
+func (m Model) frameTitle() string {
+	view := m.CurrentView()
+	if m.snapshot == nil || view == nil {
+		return "connections: 0"
+	}
+	status := m.frameStatus()
+
+	switch view.Level {
+	case LevelConntrack:
+		return fmt.Sprintf("tracked flows: %s%s", shownOf(len(m.filteredConntrack()), len(m.conntrackEntries)), m.filterStatus())
+	case LevelDockerPorts:
+		return fmt.Sprintf("port mappings: %s%s", shownOf(len(m.filteredPortMappings()), len(m.portMappingRows())), m.filterStatus())
+	case LevelBinds:
+		if m.filtering() {
+			return fmt.Sprintf("binds: %s%s", shownOf(len(m.filteredBinds()), len(m.bindRows())), m.filterStatus())
+		}
+		return "binds: " + bindSummary(m.bindRows())
+	case LevelConnections:
+		app := m.findSelectedApp(view.ProcessName)
+		if app == nil {
+			return view.ProcessName + ": 0 connections"
+		}
+		return fmt.Sprintf("%s: %s connections%s", view.ProcessName, shownOf(len(m.visibleConnections(app)), len(app.Connections)), status)
+	case LevelAllConnections:
+		return fmt.Sprintf("connections: %s%s", shownOf(len(m.visibleAllConnections()), m.snapshot.TotalConnections()), status)
+	}
+
+	if !m.filtering() {
+		if m.snapshot.Sample != nil {
+			return sampledFrameTitle(m.snapshot)
+		}
+		return fmt.Sprintf("connections: %d", m.snapshot.TotalConnections())
+	}
+	// Connections held by the listed processes
+	apps := m.visibleApps()
+	conns := 0
+	for _, app := range apps {
+		conns += app.ConnectionCount()
+	}
+	return fmt.Sprintf("processes: %s · connections: %s%s",
+		shownOf(len(apps), len(m.snapshot.Applications)), shownOf(conns, m.snapshot.TotalConnections()), status)
+}
+
+// filtering reports whether filter chips, a search being typed or a target
+// list narrow the lists.
+func (m Model) filtering() bool {
+	return len(m.currentFilters()) > 0 || m.matchList != nil
+}
+
+// filterStatus returns " (filtered)" while filtering, else "".
+func (m Model) filterStatus() string {
+	if m.filtering() {
+		return " (filtered)"
+	}
+	return ""
+}
+
+// frameStatus is filterStatus plus the sampling state of large hosts, e.g.
+// " (filtered, sampled of 48210)".
+func (m Model) frameStatus() string {
+	var flags []string
+	if m.filtering() {
+		flags = append(flags, "filtered")
+	}
+	if m.snapshot != nil && m.snapshot.Sample != nil {
+		flags = append(flags, fmt.Sprintf("sampled of %d", m.snapshot.Sample.Total))
+	}
+	if len(flags) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(flags, ", ") + ")"
+}
+
+// shownOf formats a count of listed rows: "37/212" when rows are hidden, else "212".
+func shownOf(shown, total int) string {
+	if shown == total {
+		return strconv.Itoa(total)
+	}
+	return fmt.Sprintf("%d/%d", shown, total)
+}
@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+func TestFrameTitle(t *testing.T) {
+	m := chipsTestModel()
+	if got, want := m.frameTitle(), "connections: 3"; got != want {
+		t.Errorf("unfiltered = %q, want %q", got, want)
+	}
+
+	m.filterChips = []string{"curl"}
+	if got, want := m.frameTitle(), "processes: 1/2 · connections: 1/3 (filtered)"; got != want {
+		t.Errorf("process list = %q, want %q", got, want)
+	}
+
+	m.filterChips = []string{"443"}
+
+	m.PushView(m.newViewState(LevelConnections, "chrome"))
+	if got, want := m.frameTitle(), "chrome: 1/2 connections (filtered)"; got != want {
+		t.Errorf("connections view = %q, want %q", got, want)
+	}
+
+	m.snapshot.Sample = &model.Sample{Total: 5000}
+	m.PopView()
+	m.PushView(m.newViewState(LevelAllConnections, ""))
+	if got, want := m.frameTitle(), "connections: 2/3 (filtered, sampled of 5000)"; got != want {
+		t.Errorf("flat view = %q, want %q", got, want)
+	}
+}
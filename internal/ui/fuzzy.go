@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/kostyay/netmon/internal/filter"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// fuzzyHighlights returns the byte offsets in name matched by the fuzzy chips
+// in effect, sorted, for highlighting the rendered name.
+func (m Model) fuzzyHighlights(name string) []int {
+	var positions []int
+	for _, f := range m.currentFilters() {
+		pattern, ok := filter.FuzzyPattern(f)
+		if !ok {
+			continue
+		}
+		if _, pos, ok := filter.FuzzyMatch(pattern, name); ok {
+			positions = append(positions, pos...)
+		}
+	}
+	slices.Sort(positions)
+	return slices.Compact(positions)
+}
+
+// nameCellMatches returns the fuzzy highlights of a process name shifted to
+// its cell at nameStart in the row, dropping those cut off by truncation to width.
+func (m Model) nameCellMatches(name string, nameStart, width int) []int {
+	visible := len(name)
+	if visible > width {
+		visible = width
+		if width >= 4 {
+			visible -= len("...") // truncateString's ellipsis
+		}
+	}
+	var matches []int
+	for _, i := range m.fuzzyHighlights(name) {
+		if i < visible {
+			matches = append(matches, nameStart+i)
+		}
+	}
+	return matches
+}
+
+// renderRowWithMatches renders a row like renderRow, with the runes at the
+// given byte offsets of content underlined in bold. Offsets past the end
+// (a truncated cell) are ignored.
+func renderRowWithMatches(content string, matches []int, isSelected bool) string {
+	if len(matches) == 0 {
+		return renderRow(content, isSelected)
+	}
+	styles := rowStyles()
+	base, match := styles.conn, styles.connMatch
+	if isSelected {
+		base, match = styles.selected, styles.selectedMatch
+	}
+
+	var b strings.Builder
+	b.WriteString(base.render("  "))
+	prev := 0
+	for _, i := range matches {
+		if i < prev || i >= len(content) {
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(content[i:])
+		if i > prev {
+			b.WriteString(base.render(content[prev:i]))
+		}
+		b.WriteString(match.render(content[i : i+size]))
+		prev = i + size
+	}
+	if prev < len(content) {
+		b.WriteString(base.render(content[prev:]))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// fuzzyScore sums the scores of the fuzzy chips in effect against name.
+// The bool is false when no fuzzy chip is in effect.
+func (m Model) fuzzyScore(name string) (int, bool) {
+	total, active := 0, false
+	for _, f := range m.currentFilters() {
+		if pattern, ok := filter.FuzzyPattern(f); ok {
+			active = true
+			score, _, _ := filter.FuzzyMatch(pattern, name)
+			total += score
+		}
+	}
+	return total, active
+}
+
+// rankFuzzy orders apps best fuzzy match first, as fzf does, keeping the
+// sort order among equal scores. Without fuzzy chips apps are unchanged.
+func (m Model) rankFuzzy(apps []model.Application) []model.Application {
+	if _, ok := m.fuzzyScore(""); !ok || len(apps) < 2 {
+		return apps
+	}
+	scores := make(map[string]int, len(apps))
+	for _, app := range apps {
+		scores[app.Name], _ = m.fuzzyScore(app.Name)
+	}
+	slices.SortStableFunc(apps, func(a, b model.Application) int {
+		return scores[b.Name] - scores[a.Name]
+	})
+	return apps
+}
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+func TestFuzzyChip_FiltersAndRanks(t *testing.T) {
+	m := createTestModel()
+	m.snapshot = &model.NetworkSnapshot{Applications: []model.Application{
+		{Name: "go-chr-runner", PIDs: []int32{1}},
+		{Name: "Google Chrome Helper", PIDs: []int32{2}},
+		{Name: "firefox", PIDs: []int32{3}},
+	}}
+
+	m.filterChips = []string{"gochr"}
+	if apps := m.filteredApps(); len(apps) != 0 {
+		t.Errorf("plain chip should stay a substring match, got %d apps", len(apps))
+	}
+
+	m.filterChips = []string{"~gochr"}
+	apps := m.visibleApps()
+	if len(apps) != 2 || apps[0].Name != "go-chr-runner" {
+		t.Fatalf("apps = %v, want both Chrome-like names, tightest match first", appNames(apps))
+	}
+}
+
+func TestFuzzyChip_HighlightsName(t *testing.T) {
+	m := createTestModel()
+	m.width = 120
+	m.snapshot = &model.NetworkSnapshot{Applications: []model.Application{
+		{Name: "Google Chrome Helper", PIDs: []int32{2}},
+	}}
+	m.filterChips = []string{"~gochr"}
+
+	out := m.renderProcessListData()
+	if !strings.Contains(stripAnsi(out), "Google Chrome Helper") {
+		t.Fatalf("render = %q, want the full name", stripAnsi(out))
+	}
+	if matches := m.nameCellMatches("Google Chrome Helper", 7, 30); !slices.Equal(matches, []int{7, 8, 14, 15, 16}) {
+		t.Errorf("cell matches = %v", matches)
+	}
+	if matches := m.nameCellMatches("Google Chrome Helper", 0, 10); !slices.Equal(matches, []int{0, 1}) {
+		t.Errorf("truncated cell matches = %v, want only the visible runes", matches)
+	}
+}
+
+func TestFuzzyChip_ConnectionsFallBackToSubstring(t *testing.T) {
+	conns := []model.Connection{{LocalAddr: "10.0.0.1:443", RemoteAddr: "1.1.1.1:50000"}}
+	m := createTestModel()
+	m.filterChips = []string{"~443"}
+	if got := m.filteredConnections(conns); len(got) != 1 {
+		t.Errorf("~443 in a process's connections = %d rows, want a substring match", len(got))
+	}
+}
@@ -0,0 +1,257 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/config"
+	"github.com/kostyay/netmon/internal/filter"
+	"github.com/kostyay/netmon/internal/geoip"
+	"github.com/kostyay/netmon/internal/i18n"
+)
+
+// Country breakdown: with a GeoIP table configured (geoipDatabase), remote
+// IPs are mapped to the country they are registered in. The panel ('C')
+// counts connections per country, geo:CC chips filter by it, and
+// connections to the unexpectedCountries are drawn in amber.
+const (
+	geoModalWidth   = 64
+	maxGeoRows      = 14
+	maxGeoProcesses = 3
+)
+
+// loadGeoIP reads the GeoIP table (replaced in tests).
+var loadGeoIP = geoip.LoadFile
+
+// GeoIPLoadedMsg carries the GeoIP table read at startup.
+type GeoIPLoadedMsg struct {
+	DB  *geoip.DB
+	Err error
+}
+
+// unexpectedCountriesFromSettings returns the unexpectedCountries setting as
+// a set of upper-case country codes.
+func unexpectedCountriesFromSettings(s *config.Settings) map[string]bool {
+	set := make(map[string]bool, len(s.UnexpectedCountries))
+	for _, c := range s.UnexpectedCountries {
+		if c = strings.ToUpper(strings.TrimSpace(c)); c != "" {
+			set[c] = true
+		}
+	}
+	return set
+}
+
+// loadGeoIPCmd reads the configured GeoIP table in the background; nil
+// without one. The table is static for the session.
+func (m Model) loadGeoIPCmd() tea.Cmd {
+	path := m.geoPath
+	if path == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		db, err := loadGeoIP(config.ExpandHome(path))
+		return GeoIPLoadedMsg{DB: db, Err: err}
+	}
+}
+
+// handleGeoIPLoaded installs the GeoIP table; a load failure is only logged
+// to diagnostics, the feature stays off.
+func (m *Model) handleGeoIPLoaded(msg GeoIPLoadedMsg) {
+	if msg.Err != nil {
+		m.recordError(sourceGeoIP, msg.Err)
+		return
+	}
+	m.geo = msg.DB
+	m.pipeline.invalidate()
+}
+
+// remoteCountry returns the country code of a remote address, filter.GeoUnknown
+// when it is not in the table, or "" without a GeoIP table or remote IP.
+func (m Model) remoteCountry(remoteAddr string) string {
+	if m.geo == nil {
+		return ""
+	}
+	ip, err := netip.ParseAddr(strings.Trim(extractIP(remoteAddr), "[]"))
+	if err != nil {
+		return ""
+	}
+	if cc, ok := m.geo.Country(ip); ok {
+		return cc
+	}
+	return filter.GeoUnknown
+}
+
+// unexpectedRegion reports whether the connection goes to one of the
+// unexpectedCountries.
+func (m Model) unexpectedRegion(remoteAddr string) bool {
+	if len(m.geoUnexpected) == 0 {
+		return false
+	}
+	return m.geoUnexpected[m.remoteCountry(remoteAddr)]
+}
+
+// toggleGeo opens or closes the country breakdown panel.
+func (m Model) toggleGeo() (tea.Model, tea.Cmd) {
+	if m.geo == nil {
+		return m, m.notify(toastWarn, i18n.T("No GeoIP database: set geoipDatabase in settings.yaml"))
+	}
+	m.geoMode = !m.geoMode
+	m.geoCursor = 0
+	return m, nil
+}
+
+// geoRow is one country in the breakdown panel.
+type geoRow struct {
+	country   string
+	conns     int
+	hosts     int
+	processes []string // by connection count, most first
+}
+
+// geoRows counts the connections with a remote IP per country, most
+// connections first; the filter.GeoUnknown bucket sorts last.
+func (m Model) geoRows() []geoRow {
+	if m.snapshot == nil || m.geo == nil {
+		return nil
+	}
+	type acc struct {
+		conns int
+		hosts map[string]bool
+		procs map[string]int
+	}
+	byCountry := make(map[string]*acc)
+	for _, app := range m.snapshot.Applications {
+		for _, conn := range app.Connections {
+			cc := m.remoteCountry(conn.RemoteAddr)
+			if cc == "" {
+				continue
+			}
+			a := byCountry[cc]
+			if a == nil {
+				a = &acc{hosts: make(map[string]bool), procs: make(map[string]int)}
+				byCountry[cc] = a
+			}
+			a.conns++
+			a.hosts[extractIP(conn.RemoteAddr)] = true
+			a.procs[app.Name]++
+		}
+	}
+
+	rows := make([]geoRow, 0, len(byCountry))
+	for cc, a := range byCountry {
+		procs := make([]string, 0, len(a.procs))
+		for name := range a.procs {
+			procs = append(procs, name)
+		}
+		slices.SortFunc(procs, func(x, y string) int {
+			if c := cmp.Compare(a.procs[y], a.procs[x]); c != 0 {
+				return c
+			}
+			return strings.Compare(x, y)
+		})
+		rows = append(rows, geoRow{country: cc, conns: a.conns, hosts: len(a.hosts), processes: procs})
+	}
+	slices.SortFunc(rows, func(a, b geoRow) int {
+		if (a.country == filter.GeoUnknown) != (b.country == filter.GeoUnknown) {
+			if a.country == filter.GeoUnknown {
+				return 1
+			}
+			return -1
+		}
+		if c := cmp.Compare(b.conns, a.conns); c != 0 {
+			return c
+		}
+		return strings.Compare(a.country, b.country)
+	})
+	return rows
+}
+
+// handleGeoKey handles a key press while the country panel is open: Enter
+// filters to the selected country.
+func (m *Model) handleGeoKey(key string) tea.Cmd {
+	rows := m.geoRows()
+	switch {
+	case matchKey(key, KeyEsc, KeyQuit, KeyGeo):
+		m.geoMode = false
+	case matchKey(key, KeyUp, KeyUpAlt):
+		if m.geoCursor > 0 {
+			m.geoCursor--
+		}
+	case matchKey(key, KeyDown, KeyDownAlt):
+		if m.geoCursor < len(rows)-1 {
+			m.geoCursor++
+		}
+	case matchKey(key, KeyEnter):
+		if m.geoCursor >= len(rows) || rows[m.geoCursor].country == filter.GeoUnknown {
+			return nil
+		}
+		cc := rows[m.geoCursor].country
+		for i := len(m.filterChips) - 1; i >= 0; i-- {
+			if _, ok := filter.GeoCountries(m.filterChips[i]); ok {
+				m.removeFilterChip(i)
+			}
+		}
+		m.addFilterChip(filter.GeoPrefix + cc)
+		m.geoMode = false
+		m.clampCursor()
+		return m.notify(toastInfo, fmt.Sprintf(i18n.T("Filter: connections to %s"), cc))
+	}
+	return nil
+}
+
+// renderGeoModalContent renders the country breakdown: per country, the
+// connection and host counts and the processes holding the connections.
+func (m Model) renderGeoModalContent() string {
+	keyStyle := FooterKeyStyle()
+	descStyle := FooterDescStyle()
+	warnStyle := WarnStyle()
+
+	// cursor(2) + country(7) + conns(6) + hosts(6) + separators; processes get the rest
+	procWidth := geoModalWidth - 2 - 7 - 6 - 6 - 6 - 6
+	lines := []string{keyStyle.Render(fmt.Sprintf("  %-7s  %6s  %6s  %s", i18n.T("Country"), i18n.T("Conns"), i18n.T("Hosts"), i18n.T("Top processes")))}
+
+	rows := m.geoRows()
+	if len(rows) == 0 {
+		lines = append(lines, descStyle.Render("  "+i18n.T("No connections with a remote IP")))
+	}
+	start := max(0, min(m.geoCursor-maxGeoRows+1, len(rows)-maxGeoRows))
+	for i := start; i < len(rows) && i < start+maxGeoRows; i++ {
+		r := rows[i]
+		procs := r.processes[:min(len(r.processes), maxGeoProcesses)]
+		label := r.country
+		if r.country == filter.GeoUnknown {
+			label = "—"
+		}
+		cursor := "  "
+		if i == m.geoCursor {
+			cursor = "▸ "
+		}
+		line := cursor + fmt.Sprintf("%-7s  %6d  %6d  %s", label, r.conns, r.hosts,
+			truncateString(strings.Join(procs, ", "), procWidth))
+		switch {
+		case i == m.geoCursor:
+			lines = append(lines, SelectedConnStyle().Render(line))
+		case m.geoUnexpected[r.country]:
+			lines = append(lines, warnStyle.Render(line))
+		default:
+			lines = append(lines, descStyle.Render(line))
+		}
+	}
+	if len(rows) > maxGeoRows {
+		lines = append(lines, descStyle.Render("  "+fmt.Sprintf(i18n.T("%d countries"), len(rows))))
+	}
+
+	hint := i18n.T("— : private, loopback or not in the GeoIP table.")
+	if len(m.geoUnexpected) > 0 {
+		hint = i18n.T("Amber: unexpectedCountries.") + " " + hint
+	}
+	lines = append(lines, "", descStyle.Render(hint),
+		"", keyStyle.Render("Enter")+descStyle.Render(" "+i18n.T("Filter to country")+"  ")+
+			keyStyle.Render(KeyGeo.Key)+descStyle.Render("/")+keyStyle.Render("Esc")+descStyle.Render(" "+i18n.T("Close")))
+	return strings.Join(lines, "\n")
+}
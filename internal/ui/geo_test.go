@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/filter"
+	"github.com/kostyay/netmon/internal/geoip"
+)
+
+// geoTestModel is chipsTestModel with a GeoIP table placing 1.1.1.1 in AU,
+// 2.2.2.2 in FR and 3.3.3.3 in CN.
+func geoTestModel(t *testing.T) Model {
+	t.Helper()
+	db, err := geoip.Load(strings.NewReader("1.1.1.0\t1.1.1.255\tAU\n2.2.2.0\t2.2.2.255\tFR\n3.3.3.0\t3.3.3.255\tCN\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := chipsTestModel()
+	m.geo = db
+	m.geoUnexpected = map[string]bool{"CN": true}
+	return m
+}
+
+func TestGeoFilter(t *testing.T) {
+	m := geoTestModel(t)
+
+	m.filterChips = []string{"geo:cn"}
+	if got := appNames(m.filteredApps()); !slices.Equal(got, []string{"curl"}) {
+		t.Errorf("geo:cn -> %v, want curl", got)
+	}
+	m.filterChips = []string{"geo:FR,CN"}
+	if got := appNames(m.filteredApps()); !slices.Equal(got, []string{"chrome", "curl"}) {
+		t.Errorf("geo:FR,CN -> %v, want chrome and curl", got)
+	}
+	m.filterChips = []string{"geo:AU"}
+	m.PushView(m.newViewState(LevelConnections, "chrome"))
+	if conns := m.filteredConnections(m.snapshot.Applications[0].Connections); len(conns) != 1 || conns[0].RemoteAddr != "1.1.1.1:443" {
+		t.Errorf("geo:AU in chrome's connections = %v", conns)
+	}
+}
+
+func TestGeoRows_AndUnexpectedHighlight(t *testing.T) {
+	m := geoTestModel(t)
+	m.snapshot.Applications[0].Connections[1].RemoteAddr = "10.0.0.9:80"
+
+	var got []string
+	for _, r := range m.geoRows() {
+		got = append(got, r.country)
+	}
+	if !slices.Equal(got, []string{"AU", "CN", filter.GeoUnknown}) {
+		t.Errorf("countries = %v, want AU, CN, then unknown", got)
+	}
+
+	curl := m.snapshot.Applications[1].Connections[0]
+	chrome := m.snapshot.Applications[0].Connections[0]
+	if !m.rowMark(curl).region || m.rowMark(chrome).region {
+		t.Error("only the connection to CN should be highlighted")
+	}
+}
+
+func TestGeoPanel_EnterFilters(t *testing.T) {
+	m := geoTestModel(t)
+	m.filterChips = []string{"geo:AU"}
+	m = pressKey(m, "C")
+	if !m.geoMode {
+		t.Fatal("C should open the country panel")
+	}
+	if content := stripAnsi(m.renderGeoModalContent()); !strings.Contains(content, "curl") {
+		t.Errorf("panel should list the processes per country:\n%s", content)
+	}
+	m = pressKey(m, "j") // AU, CN, FR: one connection each, by code
+	m = pressSpecial(m, tea.KeyEnter)
+
+	if m.geoMode || !slices.Equal(m.filterChips, []string{"geo:CN"}) {
+		t.Errorf("geoMode = %v, chips = %v; want the panel closed and [geo:CN]", m.geoMode, m.filterChips)
+	}
+}
+
+func TestGeoPanel_WithoutDatabase(t *testing.T) {
+	m := chipsTestModel()
+	m = pressKey(m, "C")
+	if m.geoMode || len(m.toasts) == 0 || !strings.Contains(m.toasts[0].Message, "geoipDatabase") {
+		t.Error("without a GeoIP table, C should explain how to configure one")
+	}
+}
+
+func TestLoadGeoIP(t *testing.T) {
+	orig := loadGeoIP
+	t.Cleanup(func() { loadGeoIP = orig })
+	loadGeoIP = func(string) (*geoip.DB, error) { return nil, errors.New("no such file") }
+
+	m := chipsTestModel()
+	if m.loadGeoIPCmd() != nil {
+		t.Error("no load without geoipDatabase")
+	}
+	m.geoPath = "/nonexistent.tsv"
+	m = runCmd(m, m.loadGeoIPCmd())
+	if m.geo != nil || len(m.diagLog) == 0 || m.diagLog[0].Source != sourceGeoIP {
+		t.Errorf("load failure should go to diagnostics: %+v", m.diagLog)
+	}
+}
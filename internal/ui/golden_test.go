@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/exp/golden"
+
+	"github.com/kostyay/netmon/internal/fixtures"
+)
+
+// Golden view tests render whole screens from a fixture snapshot and compare
+// them, without colors, against testdata/<test>/<size>.golden. After an
+// intended layout change, review the diff and regenerate with:
+//
+//	go test ./internal/ui -run Golden -update
+
+// goldenSizes are the terminal sizes every golden view is rendered at: the
+// classic 80x24, a common split pane and a wide window.
+var goldenSizes = []struct{ width, height int }{
+	{80, 24},
+	{120, 30},
+	{160, 40},
+}
+
+// goldenModel returns a model showing the named fixture at the given size.
+func goldenModel(t *testing.T, fixture string, width, height int) Model {
+	t.Helper()
+	snap := fixtures.Snapshot(t, fixture)
+	m := createTestModel()
+	m.collector = newMockCollector(snap)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: width, Height: height})
+	return sendData(updated.(Model), snap)
+}
+
+// testGoldenViews renders the model that setup returns at each golden size.
+func testGoldenViews(t *testing.T, setup func(Model) Model) {
+	for _, size := range goldenSizes {
+		t.Run(fmt.Sprintf("%dx%d", size.width, size.height), func(t *testing.T) {
+			m := setup(goldenModel(t, "workstation", size.width, size.height))
+			golden.RequireEqual(t, []byte(stripAnsi(m.View())))
+		})
+	}
+}
+
+func TestGolden_ProcessList(t *testing.T) {
+	testGoldenViews(t, func(m Model) Model { return m })
+}
+
+func TestGolden_ProcessListSortedByConns(t *testing.T) {
+	testGoldenViews(t, func(m Model) Model { return pressKey(m, "3") })
+}
+
+func TestGolden_Connections(t *testing.T) {
+	// nginx: a saturated accept queue and a CLOSE_WAIT connection
+	testGoldenViews(t, func(m Model) Model {
+		return pressSpecial(pressKey(pressKey(m, "j"), "j"), tea.KeyEnter)
+	})
+}
+
+func TestGolden_FlatView(t *testing.T) {
+	testGoldenViews(t, func(m Model) Model { return pressKey(m, "v") })
+}
+
+func TestGolden_HelpModal(t *testing.T) {
+	testGoldenViews(t, func(m Model) Model { return pressKey(m, "?") })
+}
+
+func TestGolden_SettingsModal(t *testing.T) {
+	testGoldenViews(t, func(m Model) Model { return pressKey(m, "S") })
+}
+
+func TestGolden_KillModal(t *testing.T) {
+	testGoldenViews(t, func(m Model) Model { return pressKey(m, "x") })
+}
@@ -0,0 +1,186 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/filter"
+	"github.com/kostyay/netmon/internal/hostgroup"
+	"github.com/kostyay/netmon/internal/i18n"
+)
+
+// Remote hosts panel ('H'): connections per remote host, by resolved name
+// when DNS resolution has one. With Group Domains on, hosts sharing a
+// registrable domain fold into one *.<domain> row, so hundreds of
+// ec2-*.compute-1.amazonaws.com addresses show as *.amazonaws.com. Enter adds
+// a domain:NAME chip.
+const (
+	hostsModalWidth  = 72
+	maxHostRows      = 14
+	maxHostProcesses = 3
+)
+
+// remoteHost returns the resolved name of a remote address, or its IP while
+// unresolved; "" for addresses without a remote IP (listeners).
+func (m Model) remoteHost(remoteAddr string) string {
+	ip, ok := remoteIP(remoteAddr)
+	if !ok || ip.IsUnspecified() {
+		return ""
+	}
+	if host := m.dnsCache[extractIP(remoteAddr)]; host != "" {
+		return strings.TrimSuffix(strings.ToLower(host), ".")
+	}
+	return ip.String()
+}
+
+// groupingDomains reports whether the panel folds hosts by domain: names only
+// group once resolved.
+func (m Model) groupingDomains() bool {
+	return m.groupDomains && m.dnsEnabled
+}
+
+// toggleHosts opens or closes the remote hosts panel.
+func (m Model) toggleHosts() (tea.Model, tea.Cmd) {
+	m.hostsMode = !m.hostsMode
+	m.hostsCursor = 0
+	return m, nil
+}
+
+// hostRow is one remote host, or one registrable domain when grouping, in the
+// panel.
+type hostRow struct {
+	hostgroup.Group
+	processes []string // by connection count, most first
+}
+
+// hostRows counts the connections per remote host (or per hostgroup.Key when
+// grouping), most connections first.
+func (m Model) hostRows() []hostRow {
+	if m.snapshot == nil {
+		return nil
+	}
+	key := func(host string) string { return host }
+	if m.groupingDomains() {
+		key = hostgroup.Key
+	}
+	counts := make(map[string]int)
+	procs := make(map[string]map[string]int)
+	for _, app := range m.snapshot.Applications {
+		for _, conn := range app.Connections {
+			host := m.remoteHost(conn.RemoteAddr)
+			if host == "" {
+				continue
+			}
+			counts[host]++
+			k := key(host)
+			if procs[k] == nil {
+				procs[k] = make(map[string]int)
+			}
+			procs[k][app.Name]++
+		}
+	}
+
+	var groups []hostgroup.Group
+	if m.groupingDomains() {
+		groups = hostgroup.Aggregate(counts)
+	} else {
+		for host, n := range counts {
+			groups = append(groups, hostgroup.Group{Key: host, Hosts: []string{host}, Count: n})
+		}
+		slices.SortFunc(groups, func(x, y hostgroup.Group) int {
+			if c := cmp.Compare(y.Count, x.Count); c != 0 {
+				return c
+			}
+			return strings.Compare(x.Key, y.Key)
+		})
+	}
+	rows := make([]hostRow, 0, len(groups))
+	for _, g := range groups {
+		rows = append(rows, hostRow{Group: g, processes: topProcesses(procs[g.Key])})
+	}
+	return rows
+}
+
+// handleHostsKey handles a key press while the remote hosts panel is open:
+// Enter filters to the selected host or domain.
+func (m *Model) handleHostsKey(key string) tea.Cmd {
+	rows := m.hostRows()
+	switch {
+	case matchKey(key, KeyEsc, KeyQuit, KeyHosts):
+		m.hostsMode = false
+	case matchKey(key, KeyUp, KeyUpAlt):
+		if m.hostsCursor > 0 {
+			m.hostsCursor--
+		}
+	case matchKey(key, KeyDown, KeyDownAlt):
+		if m.hostsCursor < len(rows)-1 {
+			m.hostsCursor++
+		}
+	case matchKey(key, KeyEnter):
+		if m.hostsCursor >= len(rows) {
+			return nil
+		}
+		domain := strings.TrimPrefix(rows[m.hostsCursor].Key, "*.")
+		for i := len(m.filterChips) - 1; i >= 0; i-- {
+			if _, ok := filter.DomainName(m.filterChips[i]); ok {
+				m.removeFilterChip(i)
+			}
+		}
+		m.addFilterChip(filter.DomainPrefix + domain)
+		m.hostsMode = false
+		m.clampCursor()
+		return m.notify(toastInfo, fmt.Sprintf(i18n.T("Filter: connections to %s"), rows[m.hostsCursor].Key))
+	}
+	return nil
+}
+
+// renderHostsModalContent renders the remote hosts: per host or domain, the
+// connection and host counts and the processes holding the connections.
+func (m Model) renderHostsModalContent() string {
+	keyStyle := FooterKeyStyle()
+	descStyle := FooterDescStyle()
+
+	// cursor(2) + host(34) + conns(6) + hosts(6) + separators; processes get the rest
+	const hostWidth = 34
+	procWidth := hostsModalWidth - 2 - hostWidth - 6 - 6 - 6 - 6
+	lines := []string{keyStyle.Render(fmt.Sprintf("  %-*s  %6s  %6s  %s", hostWidth, i18n.T("Host"), i18n.T("Conns"), i18n.T("Hosts"), i18n.T("Top processes")))}
+
+	rows := m.hostRows()
+	if len(rows) == 0 {
+		lines = append(lines, descStyle.Render("  "+i18n.T("No connections with a remote IP")))
+	}
+	start := max(0, min(m.hostsCursor-maxHostRows+1, len(rows)-maxHostRows))
+	for i := start; i < len(rows) && i < start+maxHostRows; i++ {
+		r := rows[i]
+		procs := r.processes[:min(len(r.processes), maxHostProcesses)]
+		cursor := "  "
+		if i == m.hostsCursor {
+			cursor = "▸ "
+		}
+		line := cursor + fmt.Sprintf("%-*s  %6d  %6d  %s", hostWidth, truncateString(r.Key, hostWidth), r.Count, len(r.Hosts),
+			truncateString(strings.Join(procs, ", "), procWidth))
+		if i == m.hostsCursor {
+			lines = append(lines, SelectedConnStyle().Render(line))
+		} else {
+			lines = append(lines, descStyle.Render(line))
+		}
+	}
+	if len(rows) > maxHostRows {
+		lines = append(lines, descStyle.Render("  "+fmt.Sprintf(i18n.T("%d hosts"), len(rows))))
+	}
+
+	note := i18n.T("Unresolved hosts show their IP. Group Domains (S) folds names by domain.")
+	if m.groupingDomains() {
+		note = i18n.T("Grouped by registrable domain; unresolved hosts show their IP.")
+	} else if !m.dnsEnabled {
+		note = i18n.T("DNS resolution is off: hosts show their IP.")
+	}
+	lines = append(lines, "", descStyle.Render(note),
+		"", keyStyle.Render("Enter")+descStyle.Render(" "+i18n.T("Filter to host")+"  ")+
+			keyStyle.Render(KeyHosts.Key)+descStyle.Render("/")+keyStyle.Render("Esc")+descStyle.Render(" "+i18n.T("Close")))
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// hostsTestModel is chipsTestModel with DNS on, 1.1.1.1 and 2.2.2.2 resolved
+// under amazonaws.com and 3.3.3.3 unresolved.
+func hostsTestModel() Model {
+	m := chipsTestModel()
+	m.dnsEnabled = true
+	m.dnsCache = map[string]string{
+		"1.1.1.1": "ec2-1-1-1-1.compute-1.amazonaws.com.",
+		"2.2.2.2": "s3.amazonaws.com",
+	}
+	return m
+}
+
+func TestDomainFilter(t *testing.T) {
+	m := hostsTestModel()
+
+	m.filterChips = []string{"domain:amazonaws.com"}
+	if got := appNames(m.filteredApps()); !slices.Equal(got, []string{"chrome"}) {
+		t.Errorf("domain:amazonaws.com -> %v, want chrome", got)
+	}
+	m.filterChips = []string{"DOMAIN:*.s3.amazonaws.com"}
+	m.PushView(m.newViewState(LevelConnections, "chrome"))
+	if conns := m.filteredConnections(m.snapshot.Applications[0].Connections); len(conns) != 1 || conns[0].RemoteAddr != "2.2.2.2:80" {
+		t.Errorf("domain:*.s3.amazonaws.com in chrome's connections = %v, want 2.2.2.2:80", conns)
+	}
+	m.filterChips = []string{"domain:3.3.3.3"}
+	if got := appNames(m.filteredApps()); !slices.Equal(got, []string{"curl"}) {
+		t.Errorf("domain:3.3.3.3 -> %v, want curl (unresolved hosts match their IP)", got)
+	}
+	m.filterChips = []string{"domain:mazonaws.com"}
+	if got := appNames(m.filteredApps()); len(got) != 0 {
+		t.Errorf("domain:mazonaws.com -> %v, want none (labels match whole)", got)
+	}
+}
+
+func TestHostRows_GroupDomains(t *testing.T) {
+	m := hostsTestModel()
+
+	var got []string
+	for _, r := range m.hostRows() {
+		got = append(got, r.Key)
+	}
+	if want := []string{"3.3.3.3", "ec2-1-1-1-1.compute-1.amazonaws.com", "s3.amazonaws.com"}; !slices.Equal(got, want) {
+		t.Errorf("hosts = %v, want %v", got, want)
+	}
+
+	m.groupDomains = true
+	rows := m.hostRows()
+	if len(rows) != 2 || rows[0].Key != "*.amazonaws.com" || rows[0].Count != 2 || len(rows[0].Hosts) != 2 {
+		t.Fatalf("grouped rows = %+v, want *.amazonaws.com with 2 connections from 2 hosts first", rows)
+	}
+	if !slices.Equal(rows[0].processes, []string{"chrome"}) {
+		t.Errorf("*.amazonaws.com processes = %v, want chrome", rows[0].processes)
+	}
+
+	m.dnsEnabled = false
+	if rows := m.hostRows(); len(rows) != 3 {
+		t.Errorf("with DNS off, rows = %+v, want one per host", rows)
+	}
+}
+
+func TestHostsPanel_EnterAddsChip(t *testing.T) {
+	m := hostsTestModel()
+	m.groupDomains = true
+	m = pressKey(m, "H")
+	if !m.hostsMode {
+		t.Fatal("H should open the remote hosts panel")
+	}
+	if content := stripAnsi(m.renderHostsModalContent()); !strings.Contains(content, "*.amazonaws.com") {
+		t.Errorf("panel missing *.amazonaws.com:\n%s", content)
+	}
+
+	m.filterChips = []string{"domain:example.com"}
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.hostsMode || !slices.Equal(m.filterChips, []string{"domain:amazonaws.com"}) {
+		t.Errorf("after Enter: hostsMode = %v, chips = %v; want closed with domain:amazonaws.com", m.hostsMode, m.filterChips)
+	}
+}
+
+func TestDNSResolved_RefiltersDomainChips(t *testing.T) {
+	m := hostsTestModel()
+	m.pipeline = newPipelineCache()
+	m.filterChips = []string{"domain:example.net"}
+	if got := appNames(m.visibleApps()); len(got) != 0 {
+		t.Fatalf("before resolving: %v, want none", got)
+	}
+	updated, _ := m.Update(DNSResolvedMsg{IP: "3.3.3.3", Hostname: "www.example.net"})
+	m = updated.(Model)
+	if got := appNames(m.visibleApps()); !slices.Equal(got, []string{"curl"}) {
+		t.Errorf("after resolving 3.3.3.3: %v, want curl", got)
+	}
+}
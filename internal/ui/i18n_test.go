@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/kostyay/netmon/internal/i18n"
+)
+
+func TestRender_Translated(t *testing.T) {
+	i18n.SetLocale("de")
+	t.Cleanup(func() { i18n.SetLocale(i18n.English) })
+
+	m := createTestModel()
+	m.width, m.height = 120, 40
+
+	help := stripAnsi(m.renderHelpModalContent())
+	for _, want := range []string{"Ansichten", "Hilfe anzeigen"} {
+		if !strings.Contains(help, want) {
+			t.Errorf("help modal missing %q", want)
+		}
+	}
+	if settings := stripAnsi(m.renderSettingsModalContent()); !strings.Contains(settings, "DNS-Auflösung") {
+		t.Error("settings modal should be translated")
+	}
+	if footer := stripAnsi(m.renderKeybindingsText()); !strings.Contains(footer, "suchen") {
+		t.Errorf("footer = %q, want translated hints", footer)
+	}
+
+	m.filterChips = []string{"nomatch"}
+	if out := stripAnsi(m.renderProcessListData()); !strings.Contains(out, "Keine Treffer für 'nomatch'") {
+		t.Errorf("empty state = %q, want German", out)
+	}
+}
+
+// TestCatalogs_CoverUI fails on any help, settings or footer string, or any
+// i18n.T literal in this package, that a catalog does not translate, so new
+// commands, settings and messages cannot ship in English only.
+func TestCatalogs_CoverUI(t *testing.T) {
+	keys := translatedLiterals(t) // key -> where it is shown
+	for _, section := range helpSections {
+		keys[section] = "help section"
+	}
+	for _, c := range commandRegistry {
+		keys[c.desc] = "command " + c.id
+	}
+	for _, s := range settingItems() {
+		keys[s.name] = "setting"
+		keys[s.desc] = "setting " + s.name
+	}
+	m := chipsTestModel()
+	for level := LevelProcessList; level <= LevelBinds; level++ {
+		m.stack = []ViewState{{Level: level}}
+		for _, c := range commandRegistry {
+			if c.hint != nil {
+				if label := c.hint(m); label != "" {
+					keys[label] = "footer hint of " + c.id
+				}
+			}
+		}
+	}
+
+	for _, locale := range i18n.Locales()[1:] {
+		for key, where := range keys {
+			if !i18n.Has(locale, key) {
+				t.Errorf("%s catalog is missing %q (%s)", locale, key, where)
+			}
+		}
+	}
+}
+
+// translatedLiterals returns the string literals passed to i18n.T in the
+// package sources, with their positions.
+func translatedLiterals(t *testing.T) map[string]string {
+	t.Helper()
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := map[string]string{}
+	fset := token.NewFileSet()
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) != 1 {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != "T" {
+				return true
+			}
+			if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != "i18n" {
+				return true
+			}
+			if lit, ok := call.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				if s, err := strconv.Unquote(lit.Value); err == nil {
+					keys[s] = fset.Position(lit.Pos()).String()
+				}
+			}
+			return true
+		})
+	}
+	return keys
+}
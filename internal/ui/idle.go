@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"hash/fnv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Idle mode saves CPU (and battery) while nobody is looking: after idleAfter
+// without input or a connection opening, closing or changing state, refresh
+// slows to idleMinRefresh, the LIVE pulse holds still and refresh ticks no
+// longer re-render the table. Any key, mouse event, resize or connection
+// change wakes it.
+const (
+	idleAfter             = time.Minute
+	idleMinRefresh        = 5 * time.Second
+	idleAnimationInterval = 2 * time.Second
+)
+
+// trackIdle wakes the UI on user input and puts it to sleep on a refresh
+// tick once nothing happened for idleAfter.
+func (m *Model) trackIdle(msg tea.Msg) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg, tea.MouseMsg, tea.WindowSizeMsg:
+		m.markActive(time.Now())
+	case TickMsg:
+		if !m.lastActivity.IsZero() && msg.Time.Sub(m.lastActivity) >= idleAfter {
+			m.idle = true
+		}
+	}
+}
+
+// markActive records activity at now and leaves idle mode.
+func (m *Model) markActive(now time.Time) {
+	m.lastActivity = now
+	m.idle = false
+}
+
+// animationInterval returns the pulse rate: slower while idle.
+func (m Model) animationInterval() time.Duration {
+	if m.idle {
+		return idleAnimationInterval
+	}
+	return animationInterval
+}
+
+// skipsRender reports whether msg can leave the table as it is: while idle,
+// ticks change nothing on screen (highlights have long expired).
+func (m Model) skipsRender(msg tea.Msg) bool {
+	if !m.idle {
+		return false
+	}
+	switch msg.(type) {
+	case TickMsg, AnimationTickMsg:
+		return true
+	}
+	return false
+}
+
+// contentHash fingerprints rendered viewport content, so an identical frame
+// is not handed to the viewport again.
+func contentHash(content string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(content))
+	return h.Sum64()
+}
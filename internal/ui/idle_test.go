@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+func TestIdle_SlowsRefreshUntilInput(t *testing.T) {
+	m := chipsTestModel()
+	m.refreshInterval = time.Second
+	now := time.Now()
+	m.lastActivity = now.Add(-idleAfter / 2)
+
+	updated, _ := m.Update(TickMsg{Time: now, Gen: m.tickGen})
+	m = updated.(Model)
+	if m.idle {
+		t.Fatal("idle before idleAfter")
+	}
+
+	m.lastActivity = now.Add(-idleAfter)
+	gen := m.tickGen
+	updated, _ = m.Update(TickMsg{Time: now, Gen: m.tickGen})
+	m = updated.(Model)
+	if !m.idle || m.effectiveRefreshInterval() != idleMinRefresh || m.tickGen == gen {
+		t.Fatalf("idle = %v, interval = %v, tickGen bumped = %v; want idle at %v, rescheduled",
+			m.idle, m.effectiveRefreshInterval(), m.tickGen != gen, idleMinRefresh)
+	}
+	if m.animationInterval() != idleAnimationInterval || !m.skipsRender(AnimationTickMsg{}) {
+		t.Error("idle should slow the pulse and skip re-rendering on ticks")
+	}
+
+	m = pressKey(m, "j")
+	if m.idle || m.effectiveRefreshInterval() != time.Second || m.skipsRender(AnimationTickMsg{}) {
+		t.Errorf("a key should wake the UI: idle = %v, interval = %v", m.idle, m.effectiveRefreshInterval())
+	}
+}
+
+func TestIdle_ConnectionChangeWakes(t *testing.T) {
+	m := chipsTestModel()
+	m.idle = true
+
+	same := *m.snapshot
+	m = sendData(m, &same)
+	if !m.idle {
+		t.Fatal("an unchanged snapshot should not wake the UI")
+	}
+
+	changed := &model.NetworkSnapshot{Applications: append([]model.Application{{
+		Name: "ssh", PIDs: []int32{30}, Connections: []model.Connection{
+			{Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.2:50010", RemoteAddr: "4.4.4.4:22", State: model.StateEstablished, PID: 30},
+		},
+	}}, same.Applications...)}
+	m = sendData(m, changed)
+	if m.idle {
+		t.Error("a new connection should wake the UI")
+	}
+}
+
+func TestIdle_HoldsPulse(t *testing.T) {
+	m := chipsTestModel()
+	m.animations = true
+	m.idle = true
+	for range 3 {
+		updated, _ := m.Update(AnimationTickMsg(time.Now()))
+		m = updated.(Model)
+		if m.animationFrame != 0 {
+			t.Fatal("the LIVE pulse should hold still while idle")
+		}
+	}
+	if m.skipsRender(tea.KeyMsg{}) {
+		t.Error("input is never skipped")
+	}
+}
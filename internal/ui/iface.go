@@ -0,0 +1,36 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/kostyay/netmon/internal/model"
+	"github.com/kostyay/netmon/internal/netif"
+)
+
+// Optional connection column for the network interface a connection uses.
+var ifaceColumn = columnDef{label: "Iface", id: SortInterface, minWidth: 8, flex: 0}
+
+// ifaceReload is how often interface addresses and routes are re-read, so a
+// VPN coming up is picked up without a restart.
+const ifaceReload = 10 * time.Second
+
+// loadInterfaces reads the interface table (replaced in tests).
+var loadInterfaces = netif.Load
+
+// refreshInterfaces reloads the interface table when it is older than
+// ifaceReload. Failures keep the previous table.
+func (m *Model) refreshInterfaces(now time.Time) {
+	if m.ifaces != nil && now.Sub(m.ifacesAt) < ifaceReload {
+		return
+	}
+	m.ifacesAt = now
+	if t, err := loadInterfaces(); err == nil {
+		m.ifaces = t
+		m.rowCache.invalidate()
+	}
+}
+
+// connInterface returns the interface conn's traffic goes through, or "".
+func (m Model) connInterface(conn model.Connection) string {
+	return m.ifaces.Interface(extractIP(conn.LocalAddr), extractIP(conn.RemoteAddr))
+}
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kostyay/netmon/internal/model"
+	"github.com/kostyay/netmon/internal/netif"
+)
+
+// ifaceModel returns a model whose interface table puts 192.168.1.5 on en0
+// and 10.8.0.2 on the utun3 VPN tunnel, with one curl connection over each.
+func ifaceModel(t *testing.T) Model {
+	t.Helper()
+	tbl, err := netif.NewTable(map[string]string{"192.168.1.5": "en0", "10.8.0.2": "utun3"}, nil)
+	if err != nil {
+		t.Fatalf("NewTable: %v", err)
+	}
+	m := createTestModel()
+	m.width = 140
+	m.ifaces, m.ifacesAt = tbl, time.Now()
+	m.snapshot = &model.NetworkSnapshot{Applications: []model.Application{{
+		Name: "curl",
+		PIDs: []int32{9},
+		Connections: []model.Connection{
+			{PID: 9, Protocol: model.ProtocolTCP, LocalAddr: "192.168.1.5:50000", RemoteAddr: "93.184.216.34:443", State: model.StateEstablished},
+			{PID: 9, Protocol: model.ProtocolTCP, LocalAddr: "10.8.0.2:50001", RemoteAddr: "10.0.0.7:5432", State: model.StateEstablished},
+		},
+	}}}
+	return m
+}
+
+func TestIfaceColumn(t *testing.T) {
+	m := ifaceModel(t)
+	view := m.newViewState(LevelConnections, "curl")
+	view.SortColumn, view.SortAscending = SortInterface, false
+	m.stack = append(m.stack, view)
+
+	if cols := m.columnsForLevel(LevelConnections); cols[len(cols)-1] == SortInterface {
+		t.Fatal("Iface column should be hidden when the setting is off")
+	}
+	m.showIfaces = true
+	m.protoDetail = true
+	cols := m.columnsForLevel(LevelConnections)
+	if cols[len(cols)-2] != SortInterface {
+		t.Fatalf("columns = %v, want Iface before L7", cols)
+	}
+
+	lines := strings.Split(strings.TrimSpace(m.renderConnectionsListData()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], "utun3") || !strings.Contains(lines[1], "en0") {
+		t.Errorf("rows = %q, want utun3 then en0 (descending Iface sort)", lines)
+	}
+}
+
+func TestIfaceFilter(t *testing.T) {
+	m := ifaceModel(t)
+	m.filterChips = []string{"iface:UTUN3"}
+	if apps := m.filteredApps(); len(apps) != 1 {
+		t.Fatalf("filtered apps = %d, want curl", len(apps))
+	}
+	conns := m.filteredConnections(m.snapshot.Applications[0].Connections)
+	if len(conns) != 1 || conns[0].RemoteAddr != "10.0.0.7:5432" {
+		t.Errorf("filtered = %+v, want only the VPN connection", conns)
+	}
+
+	m.filterChips = []string{"iface:utun"}
+	if apps := m.filteredApps(); len(apps) != 0 {
+		t.Error("iface: should match the interface name exactly")
+	}
+}
+
+func TestRefreshInterfaces_Throttled(t *testing.T) {
+	loads := 0
+	orig := loadInterfaces
+	loadInterfaces = func() (*netif.Table, error) {
+		loads++
+		return &netif.Table{}, nil
+	}
+	t.Cleanup(func() { loadInterfaces = orig })
+
+	m := createTestModel()
+	now := time.Now()
+	m.refreshInterfaces(now)
+	m.refreshInterfaces(now.Add(time.Second))
+	m.refreshInterfaces(now.Add(ifaceReload))
+	if loads != 2 {
+		t.Errorf("loads = %d, want 2 (reload only after %v)", loads, ifaceReload)
+	}
+}
@@ -0,0 +1,326 @@
+package ui
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/config"
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/netif"
+)
+
+// Interface load: per-interface throughput from the OS byte counters, as a
+// share of the link speed (or a configured budget). Crossing the saturation
+// threshold warns once, naming the processes moving the most bytes through
+// the interface; the warning re-arms below 80% of the threshold.
+const (
+	defaultSaturationThreshold = 80 // percent of link speed
+	maxIfaceContributors       = 3
+	maxIfaceRows               = 12
+	ifaceModalWidth            = 72
+)
+
+// Interface counters and link speeds (replaced in tests).
+var (
+	readIfaceCounters = netif.ReadCounters
+	readLinkSpeed     = netif.LinkSpeed
+)
+
+// IfaceCountersMsg carries a sample of the interface byte counters, and the
+// link speeds in bits per second when they were re-read.
+type IfaceCountersMsg struct {
+	Counters map[string]netif.Counters
+	Speeds   map[string]uint64
+	At       time.Time
+	Err      error
+}
+
+// ifaceLoad is an interface's throughput between the last two samples.
+type ifaceLoad struct {
+	rx, tx float64 // bytes per second
+	speed  uint64  // bits per second, 0 when unknown
+}
+
+// utilization returns the busier direction's share of the link speed in
+// percent (links are full duplex), or -1 when the speed is unknown.
+func (l ifaceLoad) utilization() float64 {
+	if l.speed == 0 {
+		return -1
+	}
+	return max(l.rx, l.tx) * 8 / float64(l.speed) * 100
+}
+
+// saturationThresholdFromSettings returns the utilization percent that warns:
+// the settings value, the default when unset, 0 (never) when negative.
+func saturationThresholdFromSettings(s *config.Settings) int {
+	switch {
+	case s.SaturationThreshold < 0:
+		return 0
+	case s.SaturationThreshold == 0:
+		return defaultSaturationThreshold
+	default:
+		return s.SaturationThreshold
+	}
+}
+
+// linkSpeedsFromSettings converts the linkSpeeds setting (Mbit/s) to bits per second.
+func linkSpeedsFromSettings(s *config.Settings) map[string]uint64 {
+	speeds := make(map[string]uint64, len(s.LinkSpeeds))
+	for name, mbps := range s.LinkSpeeds {
+		if mbps > 0 {
+			speeds[name] = uint64(mbps) * 1e6
+		}
+	}
+	return speeds
+}
+
+// fetchIfaceCounters samples the interface counters, re-reading link speeds
+// every ifaceReload.
+func (m Model) fetchIfaceCounters() tea.Cmd {
+	withSpeeds := time.Since(m.ifaceSpeedsAt) >= ifaceReload
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		counters, err := readIfaceCounters(ctx)
+		msg := IfaceCountersMsg{Counters: counters, At: time.Now(), Err: err}
+		if err == nil && withSpeeds {
+			msg.Speeds = make(map[string]uint64, len(counters))
+			for name := range counters {
+				msg.Speeds[name] = readLinkSpeed(name)
+			}
+		}
+		return msg
+	}
+}
+
+// handleIfaceCounters turns a counter sample into per-interface rates and
+// checks them against the saturation threshold.
+func (m *Model) handleIfaceCounters(msg IfaceCountersMsg) tea.Cmd {
+	if msg.Err != nil {
+		m.recordError(sourceNetIO, msg.Err)
+		return nil
+	}
+	if msg.Speeds != nil {
+		m.ifaceSpeeds, m.ifaceSpeedsAt = msg.Speeds, msg.At
+	}
+	loads := make(map[string]ifaceLoad, len(msg.Counters))
+	if secs := msg.At.Sub(m.ifaceCountersAt).Seconds(); m.ifaceCounters != nil && secs > 0 {
+		for name, c := range msg.Counters {
+			p, ok := m.ifaceCounters[name]
+			if !ok || c.BytesSent < p.BytesSent || c.BytesRecv < p.BytesRecv {
+				continue // new interface or counter reset
+			}
+			loads[name] = ifaceLoad{
+				rx:    float64(c.BytesRecv-p.BytesRecv) / secs,
+				tx:    float64(c.BytesSent-p.BytesSent) / secs,
+				speed: m.ifaceSpeed(name),
+			}
+		}
+	}
+	m.ifaceCounters, m.ifaceCountersAt, m.ifaceLoads = msg.Counters, msg.At, loads
+	return m.checkSaturation()
+}
+
+// ifaceSpeed returns the link speed of an interface in bits per second: the
+// linkSpeeds setting, else what the OS reports.
+func (m Model) ifaceSpeed(name string) uint64 {
+	if s, ok := m.linkSpeeds[name]; ok {
+		return s
+	}
+	return m.ifaceSpeeds[name]
+}
+
+// checkSaturation warns about interfaces that crossed the threshold.
+func (m *Model) checkSaturation() tea.Cmd {
+	if m.saturationAt <= 0 {
+		return nil
+	}
+	if m.ifaceSaturated == nil {
+		m.ifaceSaturated = make(map[string]bool)
+	}
+	var cmds []tea.Cmd
+	for _, name := range slices.Sorted(maps.Keys(m.ifaceLoads)) {
+		util := m.ifaceLoads[name].utilization()
+		switch {
+		case util >= float64(m.saturationAt) && !m.ifaceSaturated[name]:
+			m.ifaceSaturated[name] = true
+			cmds = append(cmds, m.notify(toastWarn, m.saturationMessage(name)))
+		case m.ifaceSaturated[name] && util < float64(m.saturationAt)*0.8:
+			delete(m.ifaceSaturated, name)
+		}
+	}
+	for name := range m.ifaceSaturated {
+		if _, ok := m.ifaceLoads[name]; !ok {
+			delete(m.ifaceSaturated, name)
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// saturationMessage describes a saturated interface and its top processes,
+// e.g. "en0 at 93% of 1 Gbit/s: rsync 110.0 MB/s, chrome 2.1 MB/s".
+func (m Model) saturationMessage(name string) string {
+	load := m.ifaceLoads[name]
+	msg := fmt.Sprintf(i18n.T("%s at %.0f%% of %s"), name, load.utilization(), formatLinkSpeed(load.speed))
+	if top := m.ifaceContributors(name); len(top) > 0 {
+		msg += ": " + strings.Join(top, ", ")
+	}
+	return msg
+}
+
+// ifaceContributors returns the processes with the highest throughput that
+// have connections through the interface, as "name rate". A process with
+// connections on several interfaces counts its whole throughput on each.
+func (m Model) ifaceContributors(name string) []string {
+	if m.snapshot == nil {
+		return nil
+	}
+	type contributor struct {
+		process string
+		rate    float64
+	}
+	var top []contributor
+	for _, app := range m.snapshot.Applications {
+		through := false
+		for _, conn := range app.Connections {
+			if m.connInterface(conn) == name {
+				through = true
+				break
+			}
+		}
+		if !through {
+			continue
+		}
+		var rate float64
+		for _, pid := range app.PIDs {
+			r := m.netIORates[pid]
+			rate += r.TX + r.RX
+		}
+		if rate > 0 {
+			top = append(top, contributor{app.Name, rate})
+		}
+	}
+	slices.SortFunc(top, func(a, b contributor) int {
+		if c := cmp.Compare(b.rate, a.rate); c != 0 {
+			return c
+		}
+		return strings.Compare(a.process, b.process)
+	})
+	out := make([]string, 0, maxIfaceContributors)
+	for i, c := range top {
+		if i == maxIfaceContributors {
+			break
+		}
+		out = append(out, c.process+" "+formatBytesRate(c.rate))
+	}
+	return out
+}
+
+// formatLinkSpeed formats bits per second as "100 Mbit/s" or "2.5 Gbit/s".
+func formatLinkSpeed(bps uint64) string {
+	if bps == 0 {
+		return "—"
+	}
+	if bps >= 1e9 {
+		return strconv.FormatFloat(float64(bps)/1e9, 'f', -1, 64) + " Gbit/s"
+	}
+	return strconv.FormatFloat(float64(bps)/1e6, 'f', -1, 64) + " Mbit/s"
+}
+
+// saturationBadge returns the header badge for saturated interfaces, e.g.
+// "en0 93% (I: interfaces)", or "".
+func (m Model) saturationBadge() string {
+	if len(m.ifaceSaturated) == 0 {
+		return ""
+	}
+	var parts []string
+	for _, name := range slices.Sorted(maps.Keys(m.ifaceSaturated)) {
+		parts = append(parts, fmt.Sprintf("%s %.0f%%", name, m.ifaceLoads[name].utilization()))
+	}
+	return fmt.Sprintf(i18n.T("%s (%s: interfaces)"), strings.Join(parts, " "), KeyInterfaces.Key)
+}
+
+// toggleInterfaces opens or closes the interface load panel.
+func (m Model) toggleInterfaces() Model {
+	m.ifaceMode = !m.ifaceMode
+	return m
+}
+
+// ifaceRow is one interface in the interface load panel.
+type ifaceRow struct {
+	name string
+	load ifaceLoad
+}
+
+// ifaceRows returns the interfaces that have moved bytes, most utilized
+// first, then busiest.
+func (m Model) ifaceRows() []ifaceRow {
+	rows := make([]ifaceRow, 0, len(m.ifaceLoads))
+	for name, load := range m.ifaceLoads {
+		if c := m.ifaceCounters[name]; c.BytesSent+c.BytesRecv == 0 {
+			continue
+		}
+		rows = append(rows, ifaceRow{name, load})
+	}
+	slices.SortFunc(rows, func(a, b ifaceRow) int {
+		if c := cmp.Compare(b.load.utilization(), a.load.utilization()); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.load.rx+b.load.tx, a.load.rx+a.load.tx); c != 0 {
+			return c
+		}
+		return strings.Compare(a.name, b.name)
+	})
+	return rows
+}
+
+// renderIfaceModalContent renders the interface load panel: per interface,
+// link speed, receive and send rates, utilization and top processes.
+func (m Model) renderIfaceModalContent() string {
+	keyStyle := FooterKeyStyle()
+	descStyle := FooterDescStyle()
+	warnStyle := WarnStyle()
+
+	// iface(10) + speed(11) + rx(11) + tx(11) + util(5) + separators; processes get the rest
+	topWidth := ifaceModalWidth - 10 - 11 - 11 - 11 - 5 - 10 - 6
+	lines := []string{keyStyle.Render(fmt.Sprintf("%-10s  %11s  %11s  %11s  %5s  %s", i18n.T("Iface"), i18n.T("Speed"), "RX/s", "TX/s", i18n.T("Util"), i18n.T("Top processes")))}
+
+	rows := m.ifaceRows()
+	if len(rows) == 0 {
+		lines = append(lines, descStyle.Render("  "+i18n.T("Measuring (needs two samples)…")))
+	}
+	for i, r := range rows {
+		if i == maxIfaceRows {
+			lines = append(lines, descStyle.Render("  "+fmt.Sprintf(i18n.T("… %d more"), len(rows)-maxIfaceRows)))
+			break
+		}
+		util := "—"
+		if u := r.load.utilization(); u >= 0 {
+			util = fmt.Sprintf("%.0f%%", u)
+		}
+		line := fmt.Sprintf("%-10s  %11s  %11s  %11s  %5s  %s",
+			truncateString(r.name, 10), formatLinkSpeed(r.load.speed), formatBytesRate(r.load.rx), formatBytesRate(r.load.tx), util,
+			truncateString(strings.Join(m.ifaceContributors(r.name), ", "), topWidth))
+		if m.ifaceSaturated[r.name] {
+			lines = append(lines, warnStyle.Render(line))
+		} else {
+			lines = append(lines, descStyle.Render(line))
+		}
+	}
+
+	hint := "Amber: above the saturation threshold. Speed — : unknown, set linkSpeeds."
+	if m.saturationAt > 0 {
+		hint = fmt.Sprintf("Amber: above %d%% of link speed. Speed — : unknown, set linkSpeeds.", m.saturationAt)
+	}
+	lines = append(lines, "", descStyle.Render(hint),
+		"", keyStyle.Render(KeyInterfaces.Key)+descStyle.Render("/")+keyStyle.Render("Esc")+descStyle.Render(" "+i18n.T("Close")))
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kostyay/netmon/internal/model"
+	"github.com/kostyay/netmon/internal/netif"
+)
+
+// ifaceLoadModel has rsync and curl talking through eth0 and chrome through
+// wlan0, with rsync moving the most bytes.
+func ifaceLoadModel(t *testing.T) Model {
+	t.Helper()
+	m := createTestModel()
+	tbl, err := netif.NewTable(map[string]string{"10.0.0.5": "eth0", "192.168.1.9": "wlan0"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.ifaces = tbl
+	m.saturationAt = 80
+	m.snapshot = &model.NetworkSnapshot{Applications: []model.Application{
+		{Name: "rsync", PIDs: []int32{1}, Connections: []model.Connection{{Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.5:40000", RemoteAddr: "10.0.0.7:873"}}},
+		{Name: "curl", PIDs: []int32{2}, Connections: []model.Connection{{Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.5:40001", RemoteAddr: "1.1.1.1:443"}}},
+		{Name: "chrome", PIDs: []int32{3}, Connections: []model.Connection{{Protocol: model.ProtocolTCP, LocalAddr: "192.168.1.9:50000", RemoteAddr: "8.8.8.8:443"}}},
+	}}
+	m.netIORates = map[int32]ioRate{1: {TX: 100e6}, 2: {RX: 1e6}, 3: {RX: 50e6}}
+	return m
+}
+
+// sampleIfaces feeds a counter sample one second after the last (two on the
+// first call): eth0 sends sent bytes, wlan0 receives 50 MB.
+func sampleIfaces(m *Model, sent uint64, speeds map[string]uint64) {
+	if m.ifaceCounters == nil {
+		m.handleIfaceCounters(IfaceCountersMsg{
+			Counters: map[string]netif.Counters{"eth0": {BytesSent: 1000, BytesRecv: 1000}, "wlan0": {BytesRecv: 1}},
+			Speeds:   speeds, At: time.Now(),
+		})
+	}
+	eth, wlan := m.ifaceCounters["eth0"], m.ifaceCounters["wlan0"]
+	eth.BytesSent += sent
+	wlan.BytesRecv += 50e6
+	m.handleIfaceCounters(IfaceCountersMsg{
+		Counters: map[string]netif.Counters{"eth0": eth, "wlan0": wlan},
+		At:       m.ifaceCountersAt.Add(time.Second),
+	})
+}
+
+func TestIfaceLoad_SaturationWarning(t *testing.T) {
+	m := ifaceLoadModel(t)
+	sampleIfaces(&m, 115e6, map[string]uint64{"eth0": 1e9}) // 920 Mbit/s of 1 Gbit/s
+
+	if u := m.ifaceLoads["eth0"].utilization(); u < 91 || u > 93 {
+		t.Errorf("eth0 utilization = %.1f, want 92", u)
+	}
+	if u := m.ifaceLoads["wlan0"].utilization(); u != -1 {
+		t.Errorf("wlan0 utilization = %.1f, want unknown (no link speed)", u)
+	}
+	if len(m.toasts) != 1 {
+		t.Fatalf("toasts = %+v, want one warning for eth0", m.toasts)
+	}
+	msg := m.toasts[0].Message
+	if !strings.HasPrefix(msg, "eth0 at 92% of 1 Gbit/s: rsync 95.4 MB/s, curl") || strings.Contains(msg, "chrome") {
+		t.Errorf("toast = %q, want eth0 with its processes, busiest first", msg)
+	}
+	if badge := m.saturationBadge(); badge != "eth0 92% (I: interfaces)" {
+		t.Errorf("badge = %q", badge)
+	}
+
+	// Still saturated: no repeat. Well below the threshold: re-armed.
+	sampleIfaces(&m, 110e6, nil)
+	if len(m.toasts) != 1 {
+		t.Errorf("repeated warning while still saturated: %+v", m.toasts)
+	}
+	sampleIfaces(&m, 10e6, nil)
+	if len(m.ifaceSaturated) != 0 || m.saturationBadge() != "" {
+		t.Errorf("saturated = %v after traffic dropped", m.ifaceSaturated)
+	}
+}
+
+func TestIfaceLoad_LinkSpeedSetting(t *testing.T) {
+	m := ifaceLoadModel(t)
+	m.linkSpeeds = map[string]uint64{"wlan0": 400e6} // 50 MB/s = 400 Mbit/s: a full budget
+	sampleIfaces(&m, 0, map[string]uint64{"eth0": 1e9})
+
+	if len(m.toasts) != 1 || !strings.HasPrefix(m.toasts[0].Message, "wlan0 at 100% of 400 Mbit/s: chrome") {
+		t.Errorf("toasts = %+v, want wlan0 over its configured speed", m.toasts)
+	}
+}
+
+func TestIfaceLoad_Disabled(t *testing.T) {
+	m := ifaceLoadModel(t)
+	m.saturationAt = 0
+	sampleIfaces(&m, 125e6, map[string]uint64{"eth0": 1e9})
+	if len(m.toasts) != 0 {
+		t.Errorf("toasts = %+v, want none with the threshold off", m.toasts)
+	}
+}
+
+func TestIfaceLoad_Panel(t *testing.T) {
+	m := ifaceLoadModel(t)
+	sampleIfaces(&m, 115e6, map[string]uint64{"eth0": 1e9})
+
+	m = pressKey(m, "I")
+	if !m.ifaceMode {
+		t.Fatal("I should open the interface panel")
+	}
+	out := stripAnsi(m.renderIfaceModalContent())
+	eth, wlan := strings.Index(out, "eth0"), strings.Index(out, "wlan0")
+	if eth < 0 || wlan < eth {
+		t.Errorf("panel should list eth0 (utilized) before wlan0 (unknown speed):\n%s", out)
+	}
+	if !strings.Contains(out, "1 Gbit/s") || !strings.Contains(out, "92%") {
+		t.Errorf("panel missing speed or utilization:\n%s", out)
+	}
+
+	m = pressKey(m, "I")
+	if m.ifaceMode {
+		t.Error("I again should close the panel")
+	}
+}
+
+func TestFormatLinkSpeed(t *testing.T) {
+	for bps, want := range map[uint64]string{0: "—", 100e6: "100 Mbit/s", 2.5e9: "2.5 Gbit/s", 10e9: "10 Gbit/s"} {
+		if got := formatLinkSpeed(bps); got != want {
+			t.Errorf("formatLinkSpeed(%d) = %q, want %q", bps, got, want)
+		}
+	}
+}
@@ -12,35 +12,101 @@ var (
 	KeyQuitAlt     = Keybinding{Key: "ctrl+c", Desc: "Quit"}
 	KeyHelp        = Keybinding{Key: "?", Desc: "Show help"}
 	KeySettings    = Keybinding{Key: "S", Desc: "Settings"}
+	KeyDiagnostics = Keybinding{Key: "!", Desc: "Error diagnostics"}
+	KeyPalette     = Keybinding{Key: "ctrl+p", Desc: "Command palette (run any action by name)"}
 	KeySearch      = Keybinding{Key: "/", Desc: "Search/filter"}
+	KeyPresets     = Keybinding{Key: "F", Desc: "Filter presets"}
+	KeyMatchList   = Keybinding{Key: "M", Desc: "Load target list (IPs/CIDRs/ports file)"}
 	KeyToggleView  = Keybinding{Key: "v", Desc: "Toggle grouped/flat view"}
+	KeyConntrack   = Keybinding{Key: "c", Desc: "Toggle conntrack/NAT view (Linux)"}
+	KeyDockerPorts = Keybinding{Key: "P", Desc: "Toggle Docker port mappings view"}
+	KeyBinds       = Keybinding{Key: "B", Desc: "Toggle bind address view (what is exposed)"}
+	KeyCheckPort   = Keybinding{Key: "p", Desc: "Check a port: bind address view, type the port"}
+	KeyWatchPort   = Keybinding{Key: "w", Desc: "Watch port: bell and notification when it starts/stops listening"}
+	KeyDNSActivity = Keybinding{Key: "R", Desc: "DNS activity by process (resolvers, query rate)"}
+	KeyInterfaces  = Keybinding{Key: "I", Desc: "Interface load (utilization of link speed, top processes)"}
+	KeyGeo         = Keybinding{Key: "C", Desc: "Connections by country (GeoIP)"}
+	KeyDashboard   = Keybinding{Key: "D", Desc: "Toggle dashboard header (trend, top talker, newest, errors, DNS)"}
 	KeySortMode    = Keybinding{Key: "s", Desc: "Enter sort mode"}
+	KeyQuickSort   = Keybinding{Key: "1-9", Desc: "Sort by Nth column (again to reverse)"}
+	KeyAutoSort    = Keybinding{Key: "a", Desc: "Auto sort: busiest processes first"}
 	KeyRefreshUp   = Keybinding{Key: "+", Desc: "Increase refresh rate"}
 	KeyRefreshDown = Keybinding{Key: "-", Desc: "Decrease refresh rate"}
+	KeyScanFilter  = Keybinding{Key: "A", Desc: "Filter to port scan source"}
+	KeySelfUpdate  = Keybinding{Key: "U", Desc: "Install available update"}
+	KeyProcessEnv  = Keybinding{Key: "e", Desc: "Show process cwd/env (connections view)"}
+	KeyFilterChips = Keybinding{Key: "f", Desc: "Select filter chips to remove"}
+	KeyNote        = Keybinding{Key: "n", Desc: "Annotate process / remote host"}
+	KeyPin         = Keybinding{Key: "*", Desc: "Pin/unpin process at the top of the list"}
+	KeyFollow      = Keybinding{Key: "ctrl+f", Desc: "Follow process/container across restarts"}
+	KeyTag         = Keybinding{Key: "m", Desc: "Tag process / connection for the incident timeline"}
+	KeyTree        = Keybinding{Key: "o", Desc: "Process and descendants only (tree:PID)"}
+	KeyCompare     = Keybinding{Key: "b", Desc: "Compare two processes side by side"}
+	KeyCertPeek    = Keybinding{Key: "t", Desc: "Show remote TLS certificate (connection views)"}
+	KeyASN         = Keybinding{Key: "N", Desc: "Connections by network (ASN)"}
+	KeyHosts       = Keybinding{Key: "H", Desc: "Connections by remote host (grouped by domain)"}
+	KeyNewTab      = Keybinding{Key: "T", Desc: "New workspace tab"}
+	KeyCloseTab    = Keybinding{Key: "W", Desc: "Close workspace tab"}
+	KeyNextTab     = Keybinding{Key: "tab", Desc: "Next tab"}
+	KeyPrevTab     = Keybinding{Key: "shift+tab", Desc: "Previous tab"}
+	KeyJumpTab     = Keybinding{Key: "alt+1-9", Desc: "Go to tab N"}
 )
 
 // Navigation keybindings
 var (
-	KeyUp       = Keybinding{Key: "up", Desc: "Move up"}
-	KeyUpAlt    = Keybinding{Key: "k", Desc: "Move up"}
-	KeyDown     = Keybinding{Key: "down", Desc: "Move down"}
-	KeyDownAlt  = Keybinding{Key: "j", Desc: "Move down"}
-	KeyPageUp   = Keybinding{Key: "pgup", Desc: "Page up"}
-	KeyPageDown = Keybinding{Key: "pgdown", Desc: "Page down"}
-	KeyLeft     = Keybinding{Key: "left", Desc: "Move left (sort mode)"}
-	KeyLeftAlt  = Keybinding{Key: "h", Desc: "Move left (sort mode)"}
-	KeyRight    = Keybinding{Key: "right", Desc: "Move right (sort mode)"}
-	KeyRightAlt = Keybinding{Key: "l", Desc: "Move right (sort mode)"}
-	KeyEnter    = Keybinding{Key: "enter", Desc: "Select/drill-down"}
-	KeySpace    = Keybinding{Key: " ", Desc: "Select/drill-down"}
-	KeyEsc      = Keybinding{Key: "esc", Desc: "Back/cancel"}
-	KeyBack     = Keybinding{Key: "backspace", Desc: "Back/cancel"}
+	KeyUp        = Keybinding{Key: "up", Desc: "Move up"}
+	KeyUpAlt     = Keybinding{Key: "k", Desc: "Move up"}
+	KeyDown      = Keybinding{Key: "down", Desc: "Move down"}
+	KeyDownAlt   = Keybinding{Key: "j", Desc: "Move down"}
+	KeyPageUp    = Keybinding{Key: "pgup", Desc: "Page up"}
+	KeyPageDown  = Keybinding{Key: "pgdown", Desc: "Page down"}
+	KeyHalfUp    = Keybinding{Key: "ctrl+u", Desc: "Half page up"}
+	KeyHalfDown  = Keybinding{Key: "ctrl+d", Desc: "Half page down"}
+	KeyTop       = Keybinding{Key: "home", Desc: "Go to first row"}
+	KeyTopAlt    = Keybinding{Key: "g", Desc: "Go to first row (gg)"}
+	KeyBottom    = Keybinding{Key: "end", Desc: "Go to last row"}
+	KeyBottomAlt = Keybinding{Key: "G", Desc: "Go to last row"}
+	KeyLeft      = Keybinding{Key: "left", Desc: "Move left (sort mode)"}
+	KeyLeftAlt   = Keybinding{Key: "h", Desc: "Move left (sort mode)"}
+	KeyRight     = Keybinding{Key: "right", Desc: "Move right (sort mode)"}
+	KeyRightAlt  = Keybinding{Key: "l", Desc: "Move right (sort mode)"}
+	KeyEnter     = Keybinding{Key: "enter", Desc: "Select/drill-down"}
+	KeySpace     = Keybinding{Key: " ", Desc: "Select/drill-down"}
+	KeyEsc       = Keybinding{Key: "esc", Desc: "Back/cancel"}
+	KeyBack      = Keybinding{Key: "backspace", Desc: "Back/cancel"}
 )
 
 // Kill keybindings
 var (
 	KeyKillTerm  = Keybinding{Key: "x", Desc: "Kill process (SIGTERM)"}
 	KeyKillForce = Keybinding{Key: "X", Desc: "Force kill (SIGKILL)"}
+	KeyCloseConn = Keybinding{Key: "d", Desc: "Close connection (Linux)"}
+	KeyRenice    = Keybinding{Key: "r", Desc: "Renice process"}
+	KeySuspend   = Keybinding{Key: "z", Desc: "Suspend/resume process (SIGSTOP/SIGCONT)"}
+	KeyVerifyExe = Keybinding{Key: "V", Desc: "Hash executable, verify signature (connections view)"}
+)
+
+// Search mode keybindings
+var (
+	KeyComplete = Keybinding{Key: "tab", Desc: "Accept suggestion"}
+)
+
+// Filter preset picker keybindings
+var (
+	KeyPresetSave   = Keybinding{Key: "n", Desc: "Save current filter"}
+	KeyPresetDelete = Keybinding{Key: "d", Desc: "Delete preset"}
+)
+
+// Filter chip mode keybindings
+var (
+	KeyChipRemove    = Keybinding{Key: "d", Desc: "Remove selected filter"}
+	KeyChipRemoveAlt = Keybinding{Key: "delete", Desc: "Remove selected filter"}
+	KeyChipClear     = Keybinding{Key: "c", Desc: "Clear all filters"}
+)
+
+// TLS certificate modal keybindings
+var (
+	KeyCertRefetch = Keybinding{Key: "r", Desc: "Re-fetch certificate"}
 )
 
 // Confirm/cancel keybindings
@@ -8,19 +8,38 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/kostyay/netmon/internal/audit"
 	"github.com/kostyay/netmon/internal/docker"
+	"github.com/kostyay/netmon/internal/i18n"
 	"github.com/kostyay/netmon/internal/model"
 	"github.com/kostyay/netmon/internal/process"
 )
 
+// sendSignal delivers a signal to a process. Overridden in tests.
+var sendSignal = syscall.Kill
+
 // enterKillMode sets up kill mode with the currently selected target.
 func (m Model) enterKillMode(signal string) (tea.Model, tea.Cmd) {
-	if m.snapshot == nil {
+	target := m.selectedProcessTarget()
+	if target == nil {
 		return m, nil
 	}
+	target.Signal = signal
+	m.killMode = true
+	m.killTarget = target
+	m.confirmTyped = ""
+	return m, nil
+}
+
+// selectedProcessTarget returns the process (all PIDs in the process list, the
+// connection's PID elsewhere) or container under the cursor, or nil.
+func (m Model) selectedProcessTarget() *killTargetInfo {
+	if m.snapshot == nil {
+		return nil
+	}
 	view := m.CurrentView()
 	if view == nil {
-		return m, nil
+		return nil
 	}
 
 	var target *killTargetInfo
@@ -28,31 +47,29 @@ func (m Model) enterKillMode(signal string) (tea.Model, tea.Cmd) {
 
 	switch view.Level {
 	case LevelProcessList:
-		apps := m.sortProcessList(m.filteredApps())
+		apps := m.visibleApps()
 		if idx < len(apps) {
 			app := apps[idx]
 			if len(app.PIDs) == 0 {
-				return m, nil
+				return nil
 			}
 			target = &killTargetInfo{
 				PID:         app.PIDs[0],
 				PIDs:        app.PIDs,
 				ProcessName: app.Name,
 				Exe:         app.Exe,
-				Signal:      signal,
 			}
 		} else {
 			// Virtual container row
 			vcs := m.filteredVirtualContainers()
 			vcIdx := idx - len(apps)
 			if vcIdx < 0 || vcIdx >= len(vcs) {
-				return m, nil
+				return nil
 			}
 			vc := vcs[vcIdx]
 			target = &killTargetInfo{
 				ProcessName: containerDisplayName(vc),
 				Exe:         vc.Info.Image,
-				Signal:      signal,
 				ContainerID: vc.Info.ID,
 			}
 		}
@@ -60,11 +77,11 @@ func (m Model) enterKillMode(signal string) (tea.Model, tea.Cmd) {
 	case LevelConnections:
 		selectedApp := m.findSelectedApp(view.ProcessName)
 		if selectedApp == nil {
-			return m, nil
+			return nil
 		}
-		conns := m.sortConnectionsForView(m.filteredConnections(selectedApp.Connections))
+		conns := m.visibleConnections(selectedApp)
 		if idx >= len(conns) {
-			return m, nil
+			return nil
 		}
 		conn := conns[idx]
 		target = &killTargetInfo{
@@ -72,7 +89,6 @@ func (m Model) enterKillMode(signal string) (tea.Model, tea.Cmd) {
 			ProcessName: selectedApp.Name,
 			Exe:         selectedApp.Exe,
 			Port:        model.ExtractPort(conn.LocalAddr),
-			Signal:      signal,
 		}
 		// If viewing a virtual container, set ContainerID for docker stop
 		if vc := m.findVirtualContainer(view.ProcessName); vc != nil {
@@ -80,9 +96,9 @@ func (m Model) enterKillMode(signal string) (tea.Model, tea.Cmd) {
 		}
 
 	case LevelAllConnections:
-		conns := m.sortAllConnections(m.filteredAllConnections())
+		conns := m.visibleAllConnections()
 		if idx >= len(conns) {
-			return m, nil
+			return nil
 		}
 		conn := conns[idx]
 		var exe string
@@ -97,24 +113,21 @@ func (m Model) enterKillMode(signal string) (tea.Model, tea.Cmd) {
 			ProcessName: conn.ProcessName,
 			Exe:         exe,
 			Port:        model.ExtractPort(conn.LocalAddr),
-			Signal:      signal,
 		}
 	}
 
-	if target == nil {
-		return m, nil
+	if target != nil {
+		target.Critical = m.isCriticalProcess(target)
 	}
-
-	m.killMode = true
-	m.killTarget = target
-	return m, nil
+	return target
 }
 
-// finishKill sets common fields after kill execution.
-func (m *Model) finishKill() {
+// finishKill closes the kill modal and reports the result as a toast.
+func (m *Model) finishKill(sev toastSeverity, result string) tea.Cmd {
 	m.killMode = false
-	m.killResultAt = time.Now()
 	m.killTarget = nil
+	m.confirmTyped = ""
+	return m.notify(sev, result)
 }
 
 // executeKill sends the signal to the target process(es) or stops a Docker container.
@@ -130,18 +143,24 @@ func (m Model) executeKill() (tea.Model, tea.Cmd) {
 		defer cancel()
 
 		var err error
+		action := "stop"
 		if m.killTarget.Signal == "SIGKILL" {
+			action = "kill"
 			err = docker.KillContainer(ctx, m.killTarget.ContainerID)
 		} else {
 			err = docker.StopContainer(ctx, m.killTarget.ContainerID, 10)
 		}
+		done, failed := countOutcome(err)
+		m.auditAction(audit.Entry{
+			Action:      action,
+			Target:      m.killTarget.ProcessName,
+			ContainerID: m.killTarget.ContainerID,
+			Signal:      m.killTarget.Signal,
+		}, done, failed, err)
 		if err != nil {
-			m.killResult = fmt.Sprintf("Failed to stop container %s: %v", m.killTarget.ContainerID, err)
-		} else {
-			m.killResult = fmt.Sprintf("Stopped container %s", m.killTarget.ContainerID)
+			return m, m.finishKill(toastError, fmt.Sprintf(i18n.T("Failed to stop container %s: %v"), m.killTarget.ContainerID, err))
 		}
-		m.finishKill()
-		return m, nil
+		return m, m.finishKill(toastSuccess, fmt.Sprintf(i18n.T("Stopped container %s"), m.killTarget.ContainerID))
 	}
 
 	// Process kill via syscall
@@ -158,7 +177,7 @@ func (m Model) executeKill() (tea.Model, tea.Cmd) {
 	var killed, failed int
 	var lastErr error
 	for _, pid := range pidsToKill {
-		if err := syscall.Kill(int(pid), sig); err != nil {
+		if err := m.signalPID(m.killTarget, pid, sig, m.confirmTyped); err != nil {
 			failed++
 			lastErr = err
 		} else {
@@ -166,18 +185,11 @@ func (m Model) executeKill() (tea.Model, tea.Cmd) {
 		}
 	}
 
-	if failed == 0 {
-		if len(pidsToKill) == 1 {
-			m.killResult = fmt.Sprintf("Killed PID %d (%s)", pidsToKill[0], m.killTarget.ProcessName)
-		} else {
-			m.killResult = fmt.Sprintf("Killed %d PIDs (%s)", killed, m.killTarget.ProcessName)
-		}
-	} else if killed == 0 {
-		m.killResult = fmt.Sprintf("Failed to kill %s: %v", m.killTarget.ProcessName, lastErr)
-	} else {
-		m.killResult = fmt.Sprintf("Killed %d PIDs, %d failed (%s)", killed, failed, m.killTarget.ProcessName)
-	}
-
-	m.finishKill()
-	return m, nil
+	m.auditAction(audit.Entry{
+		Action: "kill",
+		Target: m.killTarget.ProcessName,
+		PIDs:   pidsToKill,
+		Signal: m.killTarget.Signal,
+	}, killed, failed, lastErr)
+	return m, m.finishKill(pidResult("Killed", "kill", m.killTarget.ProcessName, pidsToKill, killed, failed, lastErr))
 }
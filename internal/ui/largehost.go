@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kostyay/netmon/internal/config"
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// Large host mode keeps the UI responsive on busy servers: above a connection
+// threshold, per-row change highlights, new DNS lookups and executable
+// grouping are switched off and refresh slows to largeHostMinRefresh.
+// Far above it, the collector keeps only a sample of defaultSampleCap
+// connections, bounding memory and render time on hosts with 100k+ sockets.
+const (
+	defaultLargeHostThreshold = 10000
+	largeHostMinRefresh       = 5 * time.Second
+	defaultSampleCap          = 50000
+)
+
+// largeHostThresholdFromSettings returns the connection count that turns
+// large host mode on: the settings value, the default when unset, 0 (never)
+// when negative.
+func largeHostThresholdFromSettings(s *config.Settings) int {
+	switch {
+	case s.LargeHostThreshold < 0:
+		return 0
+	case s.LargeHostThreshold == 0:
+		return defaultLargeHostThreshold
+	default:
+		return s.LargeHostThreshold
+	}
+}
+
+// updateLargeHost enters large host mode at largeHostAt connections and
+// leaves it below 80% of that, so a host hovering at the threshold does not flap.
+func (m *Model) updateLargeHost(conns int) tea.Cmd {
+	if m.largeHostAt <= 0 {
+		return nil
+	}
+	switch {
+	case !m.largeHost && conns >= m.largeHostAt:
+		m.largeHost = true
+		clear(m.changes)
+		m.rowCache.invalidate()
+		m.applyCollectorOptions()
+		return m.notify(toastWarn, fmt.Sprintf(i18n.T("Large host (%d connections): highlights and DNS off, refresh ≥ %s"), conns, largeHostMinRefresh))
+	case m.largeHost && conns < m.largeHostAt*8/10:
+		m.largeHost = false
+		m.applyCollectorOptions()
+		return m.notify(toastInfo, i18n.T("Large host mode off"))
+	}
+	return nil
+}
+
+// sampleCapFromSettings returns the collector's sample cap: the settings
+// value, the default when unset, 0 (never sample) when negative.
+func sampleCapFromSettings(s *config.Settings) int {
+	switch {
+	case s.SampleCap < 0:
+		return 0
+	case s.SampleCap == 0:
+		return defaultSampleCap
+	default:
+		return s.SampleCap
+	}
+}
+
+// sampleBadge returns the header badge for a sampled snapshot, e.g.
+// "sampled 4%", or "" when every connection is shown.
+func (m Model) sampleBadge() string {
+	if m.snapshot == nil || m.snapshot.Sample == nil || m.snapshot.Sample.Total == 0 {
+		return ""
+	}
+	pct := m.snapshot.TotalConnections() * 100 / m.snapshot.Sample.Total
+	return fmt.Sprintf(i18n.T("sampled %d%%"), max(1, pct))
+}
+
+// sampledFrameTitle returns the connection frame title for a sampled
+// snapshot: rows shown, the exact total and the largest exact state counts,
+// e.g. "connections: 5012 sampled of 120000 · ESTABLISHED 91000 · TIME_WAIT 28000".
+func sampledFrameTitle(snap *model.NetworkSnapshot) string {
+	type stateCount struct {
+		state model.ConnectionState
+		n     int
+	}
+	var counts []stateCount
+	for state, n := range snap.Sample.ByState {
+		if state != model.StateNone {
+			counts = append(counts, stateCount{state, n})
+		}
+	}
+	slices.SortFunc(counts, func(a, b stateCount) int {
+		return cmp.Or(cmp.Compare(b.n, a.n), cmp.Compare(a.state, b.state))
+	})
+	parts := []string{fmt.Sprintf("connections: %d sampled of %d", snap.TotalConnections(), snap.Sample.Total)}
+	for _, c := range counts[:min(3, len(counts))] {
+		parts = append(parts, fmt.Sprintf("%s %d", c.state, c.n))
+	}
+	return strings.Join(parts, " · ")
+}
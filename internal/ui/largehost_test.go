@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kostyay/netmon/internal/config"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// snapshotWithConns returns one app with n established connections.
+func snapshotWithConns(n int) *model.NetworkSnapshot {
+	app := model.Application{Name: "nginx", PIDs: []int32{1}}
+	for i := range n {
+		app.Connections = append(app.Connections, model.Connection{
+			PID: 1, Protocol: model.ProtocolTCP, State: model.StateEstablished,
+			LocalAddr: "10.0.0.1:443", RemoteAddr: fmt.Sprintf("10.1.0.1:%d", 40000+i),
+		})
+	}
+	return &model.NetworkSnapshot{Applications: []model.Application{app}}
+}
+
+func TestLargeHost_EntersAndLeaves(t *testing.T) {
+	m := createTestModel()
+	m.largeHostAt = 10
+	m.groupByExe = true
+	m.dnsEnabled = true
+	mc := m.collector.(*mockCollector)
+
+	m = sendData(m, snapshotWithConns(2))
+	m = sendData(m, snapshotWithConns(10))
+	if !m.largeHost {
+		t.Fatal("10 connections should enter large host mode")
+	}
+	if last := m.toasts[len(m.toasts)-1].Message; !strings.Contains(last, "Large host (10 connections)") {
+		t.Errorf("toast = %q", last)
+	}
+	if len(m.changes) != 0 {
+		t.Errorf("changes = %d, want none kept in large host mode", len(m.changes))
+	}
+	if mc.opts.GroupByExe {
+		t.Error("large host mode should group by process name")
+	}
+	if m.queueDNSLookups(m.snapshot) != nil {
+		t.Error("large host mode should not queue DNS lookups")
+	}
+	if got := m.effectiveRefreshInterval(); got != largeHostMinRefresh {
+		t.Errorf("refresh = %v, want %v", got, largeHostMinRefresh)
+	}
+	m.width = 160
+	if !strings.Contains(stripAnsi(m.renderHeader()), "(large host)") {
+		t.Error("header should show large host mode")
+	}
+
+	// Hysteresis: 9 is below the threshold but above 80% of it.
+	m = sendData(m, snapshotWithConns(9))
+	if !m.largeHost {
+		t.Error("9 connections should stay in large host mode")
+	}
+	m = sendData(m, snapshotWithConns(7))
+	if m.largeHost || !mc.opts.GroupByExe {
+		t.Error("7 connections should leave large host mode and restore exe grouping")
+	}
+	if m.effectiveRefreshInterval() != m.refreshInterval {
+		t.Error("refresh should return to the configured interval")
+	}
+}
+
+func TestLargeHost_NoHighlights(t *testing.T) {
+	m := createTestModel()
+	m.highlightChanges = true
+	conn := model.Connection{PID: 1, LocalAddr: "10.0.0.1:1"}
+	m.changes[KeyFromConnection(conn)] = Change{Type: ChangeAdded, Timestamp: time.Now()}
+	if m.GetChange(conn) == nil {
+		t.Fatal("change should be highlighted normally")
+	}
+	m.largeHost = true
+	if m.GetChange(conn) != nil {
+		t.Error("large host mode should not highlight rows")
+	}
+}
+
+func TestLargeHostThresholdFromSettings(t *testing.T) {
+	for _, tt := range []struct{ set, want int }{{0, defaultLargeHostThreshold}, {-1, 0}, {500, 500}} {
+		if got := largeHostThresholdFromSettings(&config.Settings{LargeHostThreshold: tt.set}); got != tt.want {
+			t.Errorf("threshold(%d) = %d, want %d", tt.set, got, tt.want)
+		}
+	}
+}
+
+func TestSampleCapFromSettings(t *testing.T) {
+	for _, tt := range []struct{ set, want int }{{0, defaultSampleCap}, {-1, 0}, {20000, 20000}} {
+		if got := sampleCapFromSettings(&config.Settings{SampleCap: tt.set}); got != tt.want {
+			t.Errorf("sample cap(%d) = %d, want %d", tt.set, got, tt.want)
+		}
+	}
+}
+
+func TestSampledSnapshot_ShowsExactCounts(t *testing.T) {
+	m := createTestModel()
+	m.largeHostAt = 1000
+	m.sampleCap = 20
+	m.applyCollectorOptions()
+	if mc := m.collector.(*mockCollector); mc.opts.SampleCap != 20 {
+		t.Errorf("collector sample cap = %d, want 20", mc.opts.SampleCap)
+	}
+
+	// 20 sampled rows standing for 2000 connections on the host.
+	snap := snapshotWithConns(20)
+	snap.Applications[0].SampledTotal = 2000
+	snap.Applications[0].EstablishedCount = 1500
+	snap.Sample = &model.Sample{Total: 2000, ByState: map[model.ConnectionState]int{
+		model.StateEstablished: 1500, model.StateTimeWait: 499, model.StateListen: 1,
+	}}
+	m = sendData(m, snap)
+	if !m.largeHost {
+		t.Error("large host mode should follow the exact total, not the sample")
+	}
+
+	m.width = 160
+	header := stripAnsi(m.renderHeader())
+	if !strings.Contains(header, "2000 connections") || !strings.Contains(header, "(sampled 1%)") {
+		t.Errorf("header should show the exact total and the sample badge, got %q", header)
+	}
+	if got, want := sampledFrameTitle(m.snapshot), "connections: 20 sampled of 2000 · ESTABLISHED 1500 · TIME_WAIT 499 · LISTEN 1"; got != want {
+		t.Errorf("frame title = %q, want %q", got, want)
+	}
+	if got := m.snapshot.Applications[0].ConnectionCount(); got != 2000 {
+		t.Errorf("process count = %d, want the exact 2000", got)
+	}
+}
+
+func TestSampledSnapshot_NotDiffed(t *testing.T) {
+	m := createTestModel()
+	t0 := time.Now()
+	snapAt := func(n int, sampled bool, at time.Time) *model.NetworkSnapshot {
+		snap := snapshotWithConns(n)
+		snap.Timestamp = at
+		if sampled {
+			snap.Sample = &model.Sample{Total: 5000, ByState: map[model.ConnectionState]int{model.StateEstablished: 5000}}
+		}
+		return snap
+	}
+
+	m = sendData(m, snapAt(10, false, t0))
+	m.changes, m.churnSamples = map[ConnectionKey]Change{}, nil // the fixture snapshot went away
+	// Rows missing from a sample did not close, and rows back in the next
+	// complete snapshot did not open
+	m = sendData(m, snapAt(3, true, t0.Add(time.Second)))
+	m = sendData(m, snapAt(10, false, t0.Add(2*time.Second)))
+	if len(m.changes) != 0 || len(m.churnSamples) != 0 {
+		t.Errorf("changes = %d, churn samples = %d; want none across a sampled snapshot", len(m.changes), len(m.churnSamples))
+	}
+
+	m = sendData(m, snapAt(12, false, t0.Add(3*time.Second)))
+	if len(m.changes) != 2 || len(m.churnSamples) != 1 {
+		t.Errorf("changes = %d, churn samples = %d; want the 2 new connections between complete snapshots", len(m.changes), len(m.churnSamples))
+	}
+}
+
+func sendData(m Model, snapshot *model.NetworkSnapshot) Model {
+	updated, _ := m.Update(DataMsg{Snapshot: snapshot})
+	return updated.(Model)
+}
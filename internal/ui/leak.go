@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/leak"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// checkLeaks samples snap's CLOSE_WAIT counts and warns once per process when
+// it starts looking like a socket leak. A process that stops being a suspect
+// can warn again later.
+func (m *Model) checkLeaks(snap *model.NetworkSnapshot, now time.Time) tea.Cmd {
+	if m.leaks == nil {
+		m.leaks = leak.NewTracker(leak.DefaultInterval, leak.DefaultSamples)
+		m.leakAlerted = make(map[string]bool)
+	}
+	if !m.leaks.Observe(snap, now) {
+		return nil
+	}
+	suspects := m.leaks.Suspects()
+	current := make(map[string]bool, len(suspects))
+	var cmds []tea.Cmd
+	for _, s := range suspects {
+		current[s.Process] = true
+		if m.leakAlerted[s.Process] {
+			continue
+		}
+		cmds = append(cmds, m.notify(toastWarn, leakMessage(s)))
+	}
+	m.leakAlerted = current
+	return tea.Batch(cmds...)
+}
+
+// leakMessage describes a suspect, e.g.
+// "Possible socket leak: api CLOSE_WAIT 3→15 (score 12.0, listen :8080)".
+func leakMessage(s leak.Suspect) string {
+	msg := fmt.Sprintf(i18n.T("Possible socket leak: %s CLOSE_WAIT %d→%d (score %.1f"), s.Process, s.First, s.Current, s.Score)
+	if len(s.Endpoints) > 0 {
+		msg += ", " + s.Endpoints[0]
+	}
+	return msg + ")"
+}
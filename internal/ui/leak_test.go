@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kostyay/netmon/internal/leak"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// closeWaitSnapshot returns a snapshot where "api" holds n CLOSE_WAIT sockets
+// accepted on its listening port 8080.
+func closeWaitSnapshot(n int) *model.NetworkSnapshot {
+	app := model.Application{Name: "api", PIDs: []int32{42}, Connections: []model.Connection{
+		{Protocol: model.ProtocolTCP, LocalAddr: "0.0.0.0:8080", RemoteAddr: "*:*", State: model.StateListen},
+	}}
+	for range n {
+		app.Connections = append(app.Connections, model.Connection{Protocol: model.ProtocolTCP,
+			LocalAddr: "10.0.0.2:8080", RemoteAddr: "10.0.0.7:50000", State: model.StateCloseWait})
+	}
+	return &model.NetworkSnapshot{Applications: []model.Application{app}}
+}
+
+func TestCheckLeaks_WarnsOncePerSuspect(t *testing.T) {
+	m := createTestModel()
+	start := time.Now()
+	for i, n := range []int{1, 3, 5, 8, 9, 12} {
+		m.checkLeaks(closeWaitSnapshot(n), start.Add(time.Duration(i)*leak.DefaultInterval))
+	}
+	if len(m.toasts) != 1 || m.toasts[0].Severity != toastWarn {
+		t.Fatalf("toasts = %+v, want one warning", m.toasts)
+	}
+	msg := m.toasts[0].Message
+	if !strings.Contains(msg, "api CLOSE_WAIT 1→8") || !strings.Contains(msg, "listen :8080") {
+		t.Errorf("toast = %q", msg)
+	}
+
+	// Sockets closed: no longer a suspect, so a new leak warns again
+	m.checkLeaks(closeWaitSnapshot(0), start.Add(10*leak.DefaultInterval))
+	if len(m.leakAlerted) != 0 {
+		t.Errorf("alerted = %v after the sockets closed", m.leakAlerted)
+	}
+}
+
+func TestCheckLeaks_SteadyCountIsQuiet(t *testing.T) {
+	m := createTestModel()
+	start := time.Now()
+	for i := range 8 {
+		m.checkLeaks(closeWaitSnapshot(5), start.Add(time.Duration(i)*leak.DefaultInterval))
+	}
+	if len(m.toasts) != 0 {
+		t.Errorf("toasts = %+v, want none for a steady CLOSE_WAIT count", m.toasts)
+	}
+}
@@ -0,0 +1,165 @@
+package ui
+
+import (
+	"context"
+	"maps"
+	"net"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/model"
+	"github.com/kostyay/netmon/internal/proxy"
+)
+
+// Connections through a local forward proxy (mitmproxy, Charles, Squid, a
+// SOCKS daemon, …) all point at the same loopback port, hiding where they
+// really go. Such connections get a "via NAME" label after the remote
+// address; with --proxy-api set to a mitmweb URL, the label is the original
+// destination mitmproxy is forwarding the connection to.
+
+// fetchProxyDestinations reads client destinations from the proxy API
+// (replaced in tests).
+var fetchProxyDestinations = proxy.Destinations
+
+// proxyAPITimeout bounds one request to the proxy API.
+const proxyAPITimeout = 3 * time.Second
+
+// ProxyFlowsMsg carries the destinations read from the proxy API, keyed by
+// the client's source port.
+type ProxyFlowsMsg struct {
+	Dests map[int]string
+	Err   error
+}
+
+// WithProxyAPI returns a Model that reads original destinations from the
+// mitmweb instance at url (--proxy-api).
+func (m Model) WithProxyAPI(url string) Model {
+	m.proxyAPI = url
+	return m
+}
+
+// localProxyPorts returns the TCP ports known proxy processes listen on in
+// snap, mapped to the proxy's display name.
+func localProxyPorts(snap *model.NetworkSnapshot) map[int]string {
+	if snap == nil {
+		return nil
+	}
+	var ports map[int]string
+	for _, app := range snap.Applications {
+		name := proxy.Name(app.Name)
+		if name == "" && app.Exe != "" {
+			name = proxy.Name(app.Exe)
+		}
+		if name == "" {
+			continue
+		}
+		for _, conn := range app.Connections {
+			if conn.Protocol != model.ProtocolTCP || conn.State != model.StateListen {
+				continue
+			}
+			if ports == nil {
+				ports = make(map[int]string)
+			}
+			ports[model.ExtractPort(conn.LocalAddr)] = name
+		}
+	}
+	return ports
+}
+
+// refreshLocalProxies records the proxy ports in snap, dropping cached rows
+// when they change, and returns a command reading the proxy API when it is
+// set and some connection goes through a proxy.
+func (m *Model) refreshLocalProxies(snap *model.NetworkSnapshot) tea.Cmd {
+	ports := localProxyPorts(snap)
+	if !maps.Equal(ports, m.proxyPorts) {
+		m.proxyPorts = ports
+		m.rowCache.invalidate()
+		m.pipeline.invalidate() // filters match proxy labels
+	}
+	if m.proxyAPI == "" || m.proxyFetching || !m.hasProxiedConnections(snap) {
+		return nil
+	}
+	m.proxyFetching = true
+	url := m.proxyAPI
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), proxyAPITimeout)
+		defer cancel()
+		dests, err := fetchProxyDestinations(ctx, url)
+		return ProxyFlowsMsg{Dests: dests, Err: err}
+	}
+}
+
+// hasProxiedConnections reports whether any connection in snap goes through
+// a local proxy.
+func (m Model) hasProxiedConnections(snap *model.NetworkSnapshot) bool {
+	if len(m.proxyPorts) == 0 || snap == nil {
+		return false
+	}
+	for _, app := range snap.Applications {
+		for _, conn := range app.Connections {
+			if m.proxyName(conn) != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// handleProxyFlows stores the destinations read from the proxy API. On error
+// the previous ones are kept and the error goes to diagnostics.
+func (m Model) handleProxyFlows(msg ProxyFlowsMsg) (tea.Model, tea.Cmd) {
+	m.proxyFetching = false
+	if msg.Err != nil {
+		m.recordError(sourceProxy, msg.Err)
+		return m, nil
+	}
+	if !maps.Equal(msg.Dests, m.proxyDests) {
+		m.proxyDests = msg.Dests
+		m.rowCache.invalidate()
+		m.pipeline.invalidate()
+	}
+	return m, nil
+}
+
+// proxyName returns the name of the local proxy conn connects to, or "".
+func (m Model) proxyName(conn model.Connection) string {
+	if len(m.proxyPorts) == 0 || conn.Protocol != model.ProtocolTCP || conn.IsBound() {
+		return ""
+	}
+	ip := net.ParseIP(extractIP(conn.RemoteAddr))
+	if ip == nil || !ip.IsLoopback() {
+		return ""
+	}
+	return m.proxyPorts[model.ExtractPort(conn.RemoteAddr)]
+}
+
+// proxyVia returns the label for a connection through a local proxy: its
+// original destination when the proxy API knows it, e.g.
+// "api.github.com:443 via mitmproxy", else "via mitmproxy". Returns "" for
+// other connections.
+func (m Model) proxyVia(conn model.Connection) string {
+	name := m.proxyName(conn)
+	if name == "" {
+		return ""
+	}
+	if dest := m.proxyDests[model.ExtractPort(conn.LocalAddr)]; dest != "" {
+		return dest + " via " + name
+	}
+	return "via " + name
+}
+
+// remoteCell returns the Remote Address cell: the formatted address (named by
+// capture, else reverse DNS) with its note, followed by the proxy label for
+// connections through a local proxy.
+func (m Model) remoteCell(conn model.Connection) string {
+	names := m.dnsCache
+	if host := m.sniffedHost(conn); host != "" {
+		names = map[string]string{extractIP(conn.RemoteAddr): host}
+	}
+	cell := m.withNote(formatRemoteAddr(conn.RemoteAddr, string(conn.Protocol), names, m.serviceNames), extractIP(conn.RemoteAddr))
+	if via := m.proxyVia(conn); via != "" {
+		cell += " ⇢ " + via
+	}
+	return cell
+}
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// proxySnapshot has mitmdump listening on 8080, curl connected through it,
+// mitmdump's accepted side of that connection, and a direct connection.
+func proxySnapshot() *model.NetworkSnapshot {
+	return &model.NetworkSnapshot{Applications: []model.Application{
+		{Name: "mitmdump", PIDs: []int32{10}, Connections: []model.Connection{
+			{PID: 10, Protocol: model.ProtocolTCP, LocalAddr: "127.0.0.1:8080", RemoteAddr: "*:*", State: model.StateListen},
+			{PID: 10, Protocol: model.ProtocolTCP, LocalAddr: "127.0.0.1:8080", RemoteAddr: "127.0.0.1:51000", State: model.StateEstablished},
+		}},
+		{Name: "curl", PIDs: []int32{20}, Connections: []model.Connection{
+			{PID: 20, Protocol: model.ProtocolTCP, LocalAddr: "127.0.0.1:51000", RemoteAddr: "127.0.0.1:8080", State: model.StateEstablished},
+			{PID: 20, Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.2:51001", RemoteAddr: "10.0.0.9:8080", State: model.StateEstablished},
+		}},
+	}}
+}
+
+func TestProxyVia_Detection(t *testing.T) {
+	m := createTestModel()
+	snap := proxySnapshot()
+	m.snapshot = snap
+	if cmd := m.refreshLocalProxies(snap); cmd != nil {
+		t.Error("no proxy API set, want no fetch")
+	}
+
+	client := snap.Applications[1].Connections[0]
+	if got := m.remoteCell(client); !strings.HasSuffix(got, "⇢ via mitmproxy") {
+		t.Errorf("client remote cell = %q, want a via mitmproxy label", got)
+	}
+	for _, conn := range []model.Connection{
+		snap.Applications[0].Connections[0], // the listener
+		snap.Applications[0].Connections[1], // the proxy's accepted side
+		snap.Applications[1].Connections[1], // same port on another host
+	} {
+		if via := m.proxyVia(conn); via != "" {
+			t.Errorf("proxyVia(%s -> %s) = %q, want none", conn.LocalAddr, conn.RemoteAddr, via)
+		}
+	}
+}
+
+func TestProxyVia_DestinationsFromAPI(t *testing.T) {
+	orig := fetchProxyDestinations
+	defer func() { fetchProxyDestinations = orig }()
+	var gotURL string
+	fetchProxyDestinations = func(_ context.Context, url string) (map[int]string, error) {
+		gotURL = url
+		return map[int]string{51000: "api.github.com:443"}, nil
+	}
+
+	m := createTestModel().WithProxyAPI("http://127.0.0.1:8081")
+	snap := proxySnapshot()
+	m.snapshot = snap
+	cmd := m.refreshLocalProxies(snap)
+	if cmd == nil {
+		t.Fatal("want a proxy API fetch")
+	}
+	if again := m.refreshLocalProxies(snap); again != nil {
+		t.Error("fetch already in flight, want no second one")
+	}
+	updated, _ := m.handleProxyFlows(cmd().(ProxyFlowsMsg))
+	m = updated.(Model)
+	if gotURL != "http://127.0.0.1:8081" {
+		t.Errorf("fetched %q", gotURL)
+	}
+
+	client := snap.Applications[1].Connections[0]
+	if got, want := m.proxyVia(client), "api.github.com:443 via mitmproxy"; got != want {
+		t.Errorf("proxyVia = %q, want %q", got, want)
+	}
+
+	m.stack = []ViewState{{Level: LevelAllConnections}}
+	m.addFilterChip("github")
+	conns := m.filteredAllConnections()
+	if len(conns) != 1 || conns[0].ProcessName != "curl" {
+		t.Errorf("filter github = %+v, want curl's proxied connection", conns)
+	}
+}
+
+func TestHandleProxyFlows_ErrorKeepsDestinations(t *testing.T) {
+	m := createTestModel()
+	m.proxyDests = map[int]string{51000: "example.com:443"}
+	m.proxyFetching = true
+	updated, _ := m.handleProxyFlows(ProxyFlowsMsg{Err: errors.New("connection refused")})
+	m = updated.(Model)
+	if m.proxyFetching || m.proxyDests[51000] != "example.com:443" {
+		t.Errorf("fetching = %v dests = %v", m.proxyFetching, m.proxyDests)
+	}
+	if len(m.diagLog) != 1 || m.diagLog[0].Source != sourceProxy {
+		t.Errorf("diagLog = %+v, want one proxy error", m.diagLog)
+	}
+}
@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kostyay/netmon/internal/config"
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/matchlist"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+const matchModalWidth = 60 // width of the target list prompt
+
+// WithMatchList returns a copy of the model showing only connections that
+// touch targets, loaded from path (shown in the header).
+func (m Model) WithMatchList(targets *matchlist.List, path string) Model {
+	m.matchList = targets
+	m.matchFile = path
+	return m
+}
+
+// matchesTargets reports whether conn touches the loaded target list; always
+// true when no list is loaded.
+func (m Model) matchesTargets(conn model.Connection) bool {
+	return m.matchList == nil || m.matchList.Match(conn.LocalAddr, conn.RemoteAddr)
+}
+
+// appMatchesTargets reports whether any connection of app touches the target list.
+func (m Model) appMatchesTargets(app *model.Application) bool {
+	if m.matchList == nil {
+		return true
+	}
+	if app == nil {
+		return false
+	}
+	for _, conn := range app.Connections {
+		if m.matchesTargets(conn) {
+			return true
+		}
+	}
+	return false
+}
+
+// startMatchPrompt opens the target list prompt, prefilled with the current file.
+func (m *Model) startMatchPrompt() {
+	m.matchMode = true
+	m.matchPath = m.matchFile
+	if m.matchPath == "-" {
+		m.matchPath = ""
+	}
+}
+
+// handleMatchKey handles a key press while typing a target list path.
+func (m *Model) handleMatchKey(msg tea.KeyMsg) tea.Cmd {
+	key := msg.String()
+	switch {
+	case matchKey(key, KeyEnter):
+		path := strings.TrimSpace(m.matchPath)
+		m.closeMatchPrompt()
+		return m.loadMatchList(path)
+	case matchKey(key, KeyEsc):
+		m.closeMatchPrompt()
+	case matchKey(key, KeyBack):
+		if r := []rune(m.matchPath); len(r) > 0 {
+			m.matchPath = string(r[:len(r)-1])
+		}
+	default:
+		if r := msg.Runes; len(r) > 0 && r[0] >= 32 {
+			m.matchPath += string(r)
+		}
+	}
+	return nil
+}
+
+// closeMatchPrompt leaves the prompt without loading anything.
+func (m *Model) closeMatchPrompt() {
+	m.matchMode = false
+	m.matchPath = ""
+}
+
+// loadMatchList replaces the target list with the file at path; an empty
+// path clears it. A file that fails to parse keeps the current list.
+func (m *Model) loadMatchList(path string) tea.Cmd {
+	if path == "" {
+		if m.matchList == nil {
+			return nil
+		}
+		m.matchList, m.matchFile = nil, ""
+		m.pipeline.invalidate()
+		m.clampCursor()
+		return m.notify(toastInfo, i18n.T("Target list cleared"))
+	}
+	if path == "-" {
+		return m.notify(toastWarn, i18n.T("stdin is the terminal; use --match-file - when piping"))
+	}
+	targets, err := matchlist.Load(config.ExpandHome(path))
+	if err != nil {
+		m.recordError("match-file", err)
+		return m.notify(toastError, fmt.Sprintf(i18n.T("Target list: %v"), err))
+	}
+	m.matchList, m.matchFile = targets, path
+	m.pipeline.invalidate()
+	m.clampCursor()
+	return m.notify(toastSuccess, fmt.Sprintf(i18n.T("Showing connections touching %s"), targets.Summary()))
+}
+
+// matchListLabel returns the header label for the loaded target list, e.g.
+// "targets.txt: 48 IPs/CIDRs", or "" without one.
+func (m Model) matchListLabel() string {
+	if m.matchList == nil {
+		return ""
+	}
+	name := filepath.Base(m.matchFile)
+	if m.matchFile == "-" {
+		name = "stdin"
+	}
+	return name + ": " + m.matchList.Summary()
+}
+
+// renderMatchModalContent returns the target list prompt content.
+func (m Model) renderMatchModalContent() string {
+	keyStyle := FooterKeyStyle()
+	descStyle := FooterDescStyle()
+	lines := []string{
+		i18n.T("Load target list (one IP, CIDR or port per line)"),
+		"",
+		"> " + m.matchPath + "█",
+		"",
+		DimmedStyle().Render(i18n.T("Only connections touching a target are shown.")),
+		DimmedStyle().Render(i18n.T("Load an empty path to clear the list.")),
+		"",
+		keyStyle.Render("Enter") + descStyle.Render(" "+i18n.T("Load")+"  ") + keyStyle.Render("Esc") + descStyle.Render(" "+i18n.T("Cancel")),
+	}
+	return strings.Join(lines, "\n")
+}
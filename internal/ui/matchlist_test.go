@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kostyay/netmon/internal/matchlist"
+)
+
+func TestMatchList_FiltersAllViews(t *testing.T) {
+	targets, err := matchlist.Parse(strings.NewReader("2.2.2.0/24\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := chipsTestModel().WithMatchList(targets, "/tmp/siem.txt")
+
+	if got := appNames(m.visibleApps()); !slices.Equal(got, []string{"chrome"}) {
+		t.Errorf("process list = %v, want only chrome", got)
+	}
+	if conns := m.visibleConnections(&m.snapshot.Applications[0]); len(conns) != 1 || conns[0].RemoteAddr != "2.2.2.2:80" {
+		t.Errorf("chrome connections = %v, want only 2.2.2.2:80", conns)
+	}
+	if all := m.visibleAllConnections(); len(all) != 1 {
+		t.Errorf("all connections = %d rows, want 1", len(all))
+	}
+
+	// Chips still narrow further.
+	m.filterChips = []string{"443"}
+	if got := m.filteredApps(); len(got) != 0 {
+		t.Errorf("target list + 443 = %v, want nothing", appNames(got))
+	}
+
+	m.width = 160
+	if header := stripAnsi(m.renderHeader()); !strings.Contains(header, "siem.txt: 1 IP/CIDR") {
+		t.Errorf("header should show the target list, got %q", header)
+	}
+}
+
+func TestMatchList_LoadFromPrompt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "targets.txt")
+	if err := os.WriteFile(path, []byte("3.3.3.3\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	m := chipsTestModel()
+	m = pressKey(m, "M")
+	if !m.matchMode {
+		t.Fatal("M should open the target list prompt")
+	}
+	for _, r := range path {
+		m = pressKey(m, string(r))
+	}
+	m = pressSpecial(m, tea.KeyEnter)
+
+	if m.matchMode || m.matchList == nil {
+		t.Fatal("enter should load the list and close the prompt")
+	}
+	if got := appNames(m.visibleApps()); !slices.Equal(got, []string{"curl"}) {
+		t.Errorf("process list = %v, want only curl", got)
+	}
+
+	// Reopen and clear the prefilled path: loading nothing drops the list.
+	m = pressKey(m, "M")
+	for range []rune(path) {
+		m = pressSpecial(m, tea.KeyBackspace)
+	}
+	m = pressSpecial(m, tea.KeyEnter)
+	if m.matchList != nil || len(m.visibleApps()) != 2 {
+		t.Error("an empty path should clear the target list")
+	}
+}
+
+func TestMatchList_LoadErrorKeepsList(t *testing.T) {
+	m := chipsTestModel()
+	targets, _ := matchlist.Parse(strings.NewReader("443"))
+	m = m.WithMatchList(targets, "ports.txt")
+
+	m.loadMatchList(filepath.Join(t.TempDir(), "missing.txt"))
+	if m.matchList != targets {
+		t.Error("a failed load should keep the current list")
+	}
+	if last := m.toasts[len(m.toasts)-1].Message; !strings.Contains(last, "Target list:") {
+		t.Errorf("toast = %q", last)
+	}
+}
@@ -3,17 +3,27 @@ package ui
 import (
 	"time"
 
+	"github.com/kostyay/netmon/internal/cgroup"
+	"github.com/kostyay/netmon/internal/conntrack"
 	"github.com/kostyay/netmon/internal/docker"
+	"github.com/kostyay/netmon/internal/ephemeral"
 	"github.com/kostyay/netmon/internal/model"
+	"github.com/kostyay/netmon/internal/security"
+	"github.com/kostyay/netmon/internal/sockwatch"
 )
 
 // TickMsg is sent on each refresh interval.
-type TickMsg time.Time
+type TickMsg struct {
+	Time time.Time
+	Gen  int // tick generation; ticks from before the last interval change are dropped
+}
 
 // DataMsg contains updated network data.
 type DataMsg struct {
-	Snapshot *model.NetworkSnapshot
-	Err      error
+	Snapshot    *model.NetworkSnapshot
+	Err         error
+	CollectedAt time.Time     // when collection started
+	Duration    time.Duration // how long collection took
 }
 
 // NetIOMsg contains network I/O statistics from background collection.
@@ -29,6 +39,31 @@ type DNSResolvedMsg struct {
 	Err      error
 }
 
+// SecurityResolvedMsg contains a process security context lookup result.
+type SecurityResolvedMsg struct {
+	PID     int32
+	Context security.Context
+	Err     error
+}
+
+// StartTimesResolvedMsg contains process start time lookups, PID -> start
+// (zero when the lookup failed).
+type StartTimesResolvedMsg struct {
+	Times map[int32]time.Time
+}
+
+// FDUsageResolvedMsg contains the open file usage of a snapshot's PIDs,
+// replacing the previous readings (a zero limit when the lookup failed).
+type FDUsageResolvedMsg struct {
+	Usage map[int32]fdUsage
+}
+
+// CgroupsResolvedMsg contains process cgroup lookups, PID -> cgroup (empty
+// when the lookup failed).
+type CgroupsResolvedMsg struct {
+	Cgroups map[int32]cgroup.Info
+}
+
 // VersionCheckMsg contains result of GitHub release check.
 type VersionCheckMsg struct {
 	LatestVersion string // empty if up-to-date
@@ -39,8 +74,36 @@ type VersionCheckMsg struct {
 type DockerResolvedMsg struct {
 	Containers        map[int]*docker.ContainerPort // host port → container info
 	VirtualContainers []model.VirtualContainer      // containers as virtual process rows
+	CreatedContainers []model.VirtualContainer      // created, not started, with the ports they will publish
+	At                time.Time                     // when Docker was queried (zero = unknown)
 	Err               error
 }
 
+// ConntrackMsg contains conntrack table entries.
+type ConntrackMsg struct {
+	Entries []conntrack.Entry
+	Err     error
+}
+
+// SockWatchStartedMsg reports the result of starting the socket table watcher.
+type SockWatchStartedMsg struct {
+	Watcher *sockwatch.Watcher // nil when Err is set
+	Err     error
+}
+
+// SocketChangeMsg is sent when the socket table changed (or a watcher dump failed).
+type SocketChangeMsg struct {
+	Watcher *sockwatch.Watcher // watcher that produced the event; stale events are dropped
+	Err     error
+	Closed  bool // watcher was stopped
+}
+
+// EphemeralWatchStartedMsg reports the result of starting the closed
+// connection watcher.
+type EphemeralWatchStartedMsg struct {
+	Watcher *ephemeral.Watcher // nil when Err is set
+	Err     error
+}
+
 // AnimationTickMsg is sent for UI animation updates (e.g., live indicator pulse).
 type AnimationTickMsg time.Time
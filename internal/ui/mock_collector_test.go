@@ -3,6 +3,8 @@ package ui
 import (
 	"context"
 
+	"github.com/kostyay/netmon/internal/collector"
+	"github.com/kostyay/netmon/internal/conntrack"
 	"github.com/kostyay/netmon/internal/docker"
 	"github.com/kostyay/netmon/internal/model"
 )
@@ -11,12 +13,17 @@ import (
 type mockCollector struct {
 	snapshot *model.NetworkSnapshot
 	err      error
+	opts     collector.Options // last options received via SetOptions
 }
 
 func (m *mockCollector) Collect(ctx context.Context) (*model.NetworkSnapshot, error) {
 	return m.snapshot, m.err
 }
 
+func (m *mockCollector) SetOptions(opts collector.Options) {
+	m.opts = opts
+}
+
 // newMockCollector creates a mockCollector with the given snapshot.
 func newMockCollector(snapshot *model.NetworkSnapshot) *mockCollector {
 	return &mockCollector{snapshot: snapshot}
@@ -54,3 +61,13 @@ func (m *mockDockerResolver) Resolve(ctx context.Context) (*docker.ResolveResult
 func newMockDockerResolver(containers map[int]*docker.ContainerPort) *mockDockerResolver {
 	return &mockDockerResolver{result: &docker.ResolveResult{Ports: containers}}
 }
+
+// mockConntrackReader is a test double for conntrack.Reader.
+type mockConntrackReader struct {
+	entries []conntrack.Entry
+	err     error
+}
+
+func (m *mockConntrackReader) Read(ctx context.Context) ([]conntrack.Entry, error) {
+	return m.entries, m.err
+}
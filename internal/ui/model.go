@@ -2,16 +2,34 @@ package ui
 
 import (
 	"fmt"
-	"strconv"
-	"strings"
+	"maps"
 	"time"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kostyay/netmon/internal/allowlist"
+	"github.com/kostyay/netmon/internal/capture"
+	"github.com/kostyay/netmon/internal/cgroup"
 	"github.com/kostyay/netmon/internal/collector"
 	"github.com/kostyay/netmon/internal/config"
+	"github.com/kostyay/netmon/internal/conntrack"
+	"github.com/kostyay/netmon/internal/debugserver"
 	"github.com/kostyay/netmon/internal/docker"
+	"github.com/kostyay/netmon/internal/ephemeral"
+	"github.com/kostyay/netmon/internal/exeverify"
+	"github.com/kostyay/netmon/internal/geoip"
+	"github.com/kostyay/netmon/internal/history"
+	"github.com/kostyay/netmon/internal/leak"
+	"github.com/kostyay/netmon/internal/matchlist"
 	"github.com/kostyay/netmon/internal/model"
+	"github.com/kostyay/netmon/internal/netif"
+	"github.com/kostyay/netmon/internal/privilege"
+	"github.com/kostyay/netmon/internal/procenv"
+	"github.com/kostyay/netmon/internal/security"
+	"github.com/kostyay/netmon/internal/sockwatch"
+	"github.com/kostyay/netmon/internal/tlspeek"
+	"github.com/kostyay/netmon/internal/web"
 )
 
 // Refresh interval bounds.
@@ -29,6 +47,9 @@ const (
 	LevelProcessList    ViewLevel = iota // Level 0: list of processes
 	LevelConnections                     // Level 1: connections for a specific process
 	LevelAllConnections                  // Level 2: flat view of all connections
+	LevelConntrack                       // Kernel conntrack table (Linux NAT flows)
+	LevelDockerPorts                     // Docker container port mappings
+	LevelBinds                           // Bound sockets grouped by local bind address
 )
 
 // String returns a human-readable name for the ViewLevel.
@@ -40,6 +61,12 @@ func (v ViewLevel) String() string {
 		return "Connections"
 	case LevelAllConnections:
 		return "All Connections"
+	case LevelConntrack:
+		return "Conntrack"
+	case LevelDockerPorts:
+		return "Docker Ports"
+	case LevelBinds:
+		return "Binds"
 	default:
 		return fmt.Sprintf("ViewLevel(%d)", v)
 	}
@@ -63,6 +90,32 @@ const (
 	SortRX
 	// Docker-specific columns
 	SortContainer
+	// Conntrack-specific columns
+	SortOriginal
+	SortReply
+	SortNAT
+	SortTTL
+	// Optional process list columns
+	SortSecurity
+	SortNewRate
+	SortClosedRate
+	// Optional connection columns (Linux TCP stats)
+	SortRTT
+	SortRetrans
+	// Optional connection column (detected application protocol)
+	SortProtoDetail
+	// Bind address view column
+	SortBindScope
+	// Optional process list column (process uptime)
+	SortUptime
+	// Optional connection column (network interface)
+	SortInterface
+	// Optional process list column (open files against their limit)
+	SortFDs
+	// Optional process list column (cgroup: systemd unit or container)
+	SortCgroup
+	// Process list composite ranking (no column; see interestScore)
+	SortAuto
 )
 
 // String returns a human-readable name for the SortColumn.
@@ -92,6 +145,38 @@ func (s SortColumn) String() string {
 		return "RX"
 	case SortContainer:
 		return "Container"
+	case SortOriginal:
+		return "Original"
+	case SortReply:
+		return "Reply"
+	case SortNAT:
+		return "NAT"
+	case SortTTL:
+		return "TTL"
+	case SortSecurity:
+		return "Security"
+	case SortNewRate:
+		return "New/s"
+	case SortClosedRate:
+		return "Closed/s"
+	case SortRTT:
+		return "RTT"
+	case SortRetrans:
+		return "Retrans"
+	case SortProtoDetail:
+		return "L7"
+	case SortBindScope:
+		return "Scope"
+	case SortUptime:
+		return "Uptime"
+	case SortInterface:
+		return "Iface"
+	case SortFDs:
+		return "FDs"
+	case SortCgroup:
+		return "Unit"
+	case SortAuto:
+		return "Auto"
 	default:
 		return fmt.Sprintf("SortColumn(%d)", s)
 	}
@@ -117,23 +202,52 @@ type Model struct {
 	collector      collector.Collector
 	netIOCollector collector.NetIOCollector
 	netIOCache     map[int32]*model.NetIOStats // Network I/O stats keyed by PID
+	netIORates     map[int32]ioRate            // throughput between the last two netIO samples, keyed by PID
+	rateStats      map[string]*rateStats       // session min/avg/peak throughput, keyed by process name
+	sessionTotals  map[string]*sessionTotals   // bytes and connections since launch, keyed by process name
+	sessionStart   time.Time                   // first netIO sample (the session totals baseline)
 
 	// Change highlighting
 	changes          map[ConnectionKey]Change // Recently changed connections
 	highlightChanges bool                     // whether to show change highlights
+	changeStyle      changeStyle              // how changes are shown (flash, fade, gutter, count)
+
+	// Connection churn (derived from snapshot diffs)
+	churnSamples []churnSample // samples within churnWindow
+	churnColumns bool          // show New/s and Closed/s process list columns
 
 	// Navigation stack (replaces viewMode, expandedApps, cursor, tableCursor)
 	stack []ViewState
 
+	// Workspace tabs (workspace.go): the active tab's state is in stack and the
+	// filter fields; tabs holds all tabs and is empty while there is only one
+	tabs      []workspace
+	activeTab int
+
 	// UI State
 	quitting bool
 
 	// Error tracking
 	lastError     error
 	lastErrorTime time.Time
+	diagLog       []diagEntry // recent errors from all sources (diagnostics panel)
+	diagMode      bool        // true when diagnostics panel is visible
+
+	// DNS activity panel (R): outbound port 53 traffic per process name
+	dnsActivity map[string]*dnsActivity
+	dnsMode     bool
+
+	// Snapshot timing, for the staleness badge
+	snapshotAt      time.Time     // when the displayed snapshot was collected
+	collectDuration time.Duration // how long that collection took
+
+	// Privilege level (set via WithPrivilege)
+	privilege privilege.Report
 
 	// Configuration
-	refreshInterval time.Duration
+	refreshInterval time.Duration            // process list interval; default for levels without an override
+	refreshPrefs    map[string]time.Duration // per-level overrides keyed by viewPrefKey (nil disables overrides and persistence)
+	tickGen         int                      // current tick generation (bumped when the effective interval changes)
 
 	// Dimensions
 	width  int
@@ -141,20 +255,41 @@ type Model struct {
 
 	// Viewport for scrollable content
 	viewport        viewport.Model
-	viewportContent string // Pre-rendered content for viewport (set in Update, used in View)
-	ready           bool   // true after viewport initialized on first WindowSizeMsg
+	viewportContent string         // Pre-rendered content for viewport (set in Update, used in View)
+	ready           bool           // true after viewport initialized on first WindowSizeMsg
+	rowCache        *rowCache      // styled connection rows reused across renders (nil disables)
+	pipeline        *pipelineCache // memoized filter+sort results (nil disables)
 
 	// Search/filter state
-	searchMode   bool   // true when search input is active
-	searchQuery  string // current search text (live updates while typing)
-	activeFilter string // confirmed filter (applied after Enter)
-	cliFilter    string // CLI-provided filter (uses exact port matching)
+	searchMode  bool     // true when search input is active
+	searchQuery string   // search text being typed (becomes a chip on Enter)
+	filterChips []string // confirmed filters, combined with AND
+	cliFilter   string   // CLI-provided filter (its chip uses exact port matching)
+	chipMode    bool     // true while selecting a filter chip to remove
+	chipCursor  int      // selected chip in chip mode
 
 	// Kill mode state
 	killMode     bool            // true when kill confirmation dialog is active
 	killTarget   *killTargetInfo // target process/connection to kill
-	killResult   string          // result message from kill operation
-	killResultAt time.Time       // when killResult was set (for auto-dismiss)
+	confirmTyped string          // name typed to confirm signalling a critical process
+	toasts       []toast         // queued footer notifications (head is showing)
+	toastLog     []toast         // notification history for the diagnostics panel
+
+	follow  *followTarget   // process kept in view across restarts (ctrl+f), nil when off
+	parents map[int32]int32 // PID -> parent PID, read only while a tree:PID filter is in effect
+
+	criticalPatterns []string  // process name patterns that need a typed confirmation (nil: the defaults)
+	lastCriticalKill time.Time // when a critical process was last stopped (rate limit)
+
+	// Renice / suspend / resume modal
+	actionMode   bool            // true when the modal is open
+	action       procAction      // action to confirm
+	actionTarget *killTargetInfo // target process
+	actionNice   int             // nice value to apply (renice)
+
+	// Close-connection mode state
+	closeMode   bool             // true when close-connection confirmation is active
+	closeTarget *closeTargetInfo // connection to close
 
 	// DNS resolution
 	dnsCache   map[string]string // IP -> hostname cache
@@ -163,32 +298,236 @@ type Model struct {
 	// Service names
 	serviceNames bool // show service names instead of port numbers
 
+	// Collection options (pushed to the collector via applyCollectorOptions)
+	groupByExe   bool // group processes by executable path instead of name
+	hideLoopback bool // drop loopback connections; count shown in header
+	tcpStats     bool // attach RTT/retransmits to TCP connections (Linux); adds connection columns
+	protoDetail  bool // show the detected application protocol (TLS, SSH, ...) connection column
+	showIfaces   bool // show the network interface (en0, utun3, ...) connection column
+	largeHostAt  int  // connection count that enables large host mode (0 = never)
+	sampleCap    int  // connections kept per snapshot before sampling (0 = never sample)
+	largeHost    bool // large host mode: no highlights, DNS or exe grouping; slower refresh
+
+	// Navigation
+	wrapNav  bool // up on the first row goes to the last, down on the last to the first
+	pendingG bool // g pressed; a second g jumps to the first row
+
+	// Interface attribution (column and iface: filters), reloaded every ifaceReload
+	ifaces   *netif.Table
+	ifacesAt time.Time
+
+	// Interface load (ifaceload.go)
+	ifaceCounters   map[string]netif.Counters // last counter sample
+	ifaceCountersAt time.Time
+	ifaceLoads      map[string]ifaceLoad // throughput between the last two samples
+	ifaceSpeeds     map[string]uint64    // link speeds reported by the OS (bits/s)
+	ifaceSpeedsAt   time.Time
+	linkSpeeds      map[string]uint64 // linkSpeeds setting (bits/s), wins over ifaceSpeeds
+	saturationAt    int               // utilization percent that warns (0 = never)
+	ifaceSaturated  map[string]bool   // interfaces above the threshold, already warned about
+	ifaceMode       bool              // interface load panel visible
+
+	// Country breakdown (geo.go)
+	geo           *geoip.DB       // nil until loaded, or without geoipDatabase
+	geoPath       string          // geoipDatabase setting
+	geoUnexpected map[string]bool // unexpectedCountries setting, upper-case codes
+	geoMode       bool            // country panel visible
+	geoCursor     int
+
+	// Process comparison (compare.go)
+	compareWith   string // process marked with 'b', compared with the next one
+	compareA      string // processes in the comparison panel
+	compareB      string
+	compareMode   bool // comparison panel visible
+	compareCursor int  // first endpoint row shown
+
+	// Command palette (palette.go)
+	paletteMode   bool
+	paletteQuery  string
+	paletteCursor int // index into the matches for paletteQuery
+
+	// Instant refresh (Linux): refetch as soon as the socket table changes
+	instantRefresh bool               // setting; the tick poll keeps running as fallback
+	sockWatcher    *sockwatch.Watcher // running watcher (nil when off or unsupported)
+
+	// Ephemeral flows (Linux): connections that opened and closed between refreshes
+	ephemeralFlows   bool               // setting
+	ephemeralWatcher *ephemeral.Watcher // running watcher (nil when off or unsupported)
+	ephemeralTotal   int                // flows logged this session
+	ephemeralDropped int                // flows lost to a full buffer
+
+	// Port scan detection (fed by the diff layer)
+	scanPorts map[string]map[int]time.Time // remote IP -> local port -> last new connection
+	scanAlert *scanAlert                   // flagged remote IP (nil when none)
+
+	// UDP pseudo-states (udpstate.go)
+	udpLastActive map[ConnectionKey]time.Time // last inferred activity per UDP socket
+	udpActive     map[ConnectionKey]bool      // UDP sockets active within udpActiveWindow
+
+	// Port watches (w, --watch-port): alert when a port starts or stops listening
+	portWatches []portWatch // sorted by port
+
+	// Security context (AppArmor/SELinux/codesign)
+	securityContext bool                       // show Security column and detail line
+	securityCache   map[int32]security.Context // PID -> resolved context
+
+	// Dashboard header ('D'): history is recorded even while hidden
+	dashboard   bool        // header shows the dashboard lines
+	connHistory []int       // total connection count per snapshot, oldest first
+	newest      *newestConn // most recently opened connection
+
+	// Process uptime (optional process list column)
+	showUptime bool                // show Uptime column and Started detail line
+	startTimes map[int32]time.Time // PID -> start time (zero when unknown)
+
+	// Open files against RLIMIT_NOFILE (optional process list column)
+	showFDs bool              // show FDs column and Open files detail line
+	fdUsage map[int32]fdUsage // PID -> latest reading
+
+	// Cgroup: systemd unit or container (optional process list column, Linux)
+	showCgroups bool                  // show Unit column and Cgroup detail line
+	cgroups     map[int32]cgroup.Info // PID -> cgroup (empty when unknown)
+
+	// Process cwd/environment in the connections detail pane (privacy-gated)
+	processEnv  bool                   // setting: allow the 'e' expansion
+	envKeys     []string               // whitelisted environment variables
+	envExpanded bool                   // detail pane shows Cwd/Env lines
+	envCache    map[int32]procenv.Info // PID -> lookup result
+
+	// Executable hash/signature verification, shown in the connections detail pane
+	verifyCache   map[string]exeverify.Result // exe path -> last result
+	verifyPending map[string]bool             // exe paths being verified
+	verifySpinner spinner.Model               // animates while any verification runs
+
+	// TLS certificate modal
+	certMode    bool                      // true when the modal is visible
+	certAddr    string                    // endpoint shown ("ip:port")
+	certLoading bool                      // handshake in flight
+	certErr     error                     // last handshake error for certAddr
+	certCache   map[string]tlspeek.Result // host:port -> presented chain
+
+	// Network breakdown (asn.go)
+	asnMode   bool // network panel visible
+	asnCursor int
+
+	// Remote hosts panel (hosts.go)
+	hostsMode    bool // remote hosts panel visible
+	hostsCursor  int
+	groupDomains bool // fold hosts into *.<registrable domain> rows while DNS is on
+
+	// Local forward proxies (localproxy.go)
+	proxyPorts    map[int]string // TCP port -> proxy name, from the last snapshot
+	proxyAPI      string         // mitmweb URL for original destinations ("" disables)
+	proxyDests    map[int]string // client source port -> original destination
+	proxyFetching bool           // proxy API request in flight
+
+	// Hostnames from captured ClientHello SNI / HTTP Host (--capture, capture.go)
+	capture    *capture.Table                // nil without --capture
+	sniffed    map[capture.Flow]capture.Name // copy of the capture's names
+	sniffedGen uint64                        // capture generation sniffed was copied at
+
+	// CLOSE_WAIT leak detection (leak.go)
+	leaks       *leak.Tracker   // created on the first snapshot
+	leakAlerted map[string]bool // suspects already warned about
+
+	// Audit log of kill/stop/suspend/renice/close actions ("" disables)
+	auditPath string
+
+	// Sort preferences per view level (nil disables persistence)
+	sortPrefs map[string]config.SortPref
+
 	// Settings modal
 	settingsMode   bool // true when settings modal is visible
 	settingsCursor int  // which setting is selected (0-based)
 
+	// Filter presets picker
+	presets       []config.FilterPreset // saved filters (persisted in settings)
+	presetsMode   bool                  // true when the presets picker is visible
+	presetsCursor int                   // selected preset
+	presetNaming  bool                  // true while typing a name for the current filter
+	presetName    string                // name being typed
+
+	// Notes on process names and remote IPs (persisted in settings)
+	notes      map[string]string // process name or IP -> note
+	noteMode   bool              // true while editing a note
+	noteTarget string            // process name or IP being annotated
+	noteText   string            // note being typed
+
+	// Incident timeline tags ('m', timeline.go), kept for the session
+	tags       []incidentTag // tagged observations, in tagging order
+	tagMode    bool          // true while typing a tag comment
+	pendingTag incidentTag   // tag being commented, captured when opened
+	tagText    string        // comment being typed
+
+	// Pinned processes, kept at the top of the process list (pins.go)
+	pins         map[string]bool // process name -> pinned
+	rememberPins bool            // save pins in settings
+
+	// Target list (--match-file or 'M'): only connections touching it are shown
+	matchList *matchlist.List // nil = no list loaded
+	matchFile string          // path the list was loaded from
+	matchMode bool            // true while typing a path to load
+	matchPath string          // path being typed
+
+	// Egress allowlist (--allow-file): connections outside it are highlighted
+	allowList    *allowlist.List
+	allowFile    string                 // path the list was loaded from
+	allowOutside map[ConnectionKey]bool // current snapshot's violations
+	violations   []allowlist.Violation  // same, in snapshot order for export
+
 	// Help modal
 	helpMode bool // true when help modal is visible
 
 	// PID targeting (from --pid flag)
 	targetPID int32 // PID to drill into on first snapshot (0 = disabled)
 
+	// Startup actions (from --script flag)
+	script *Script // actions to run on first snapshot (nil = none or already run)
+
 	// Version string (set via WithVersion)
 	version string
 
+	// Self-diagnostics status page (set via WithDebugStatus; nil when disabled)
+	debugStatus *debugserver.Status
+
+	// Live web view (set via WithWebHub; nil when --web is off)
+	webHub *web.Hub
+
+	// Flow history recorder (set via WithHistory; nil when --store is off)
+	history *history.Recorder
+
 	// Update available (set via VersionCheckMsg)
 	updateAvailable string // e.g., "v1.2.0" (empty if up-to-date)
+	updateMode      bool   // true when the self-update confirmation is open
+	updating        bool   // true while a self-update download is running
 
 	// Animation state
 	animations     bool // whether animations are enabled
 	animationFrame int  // current animation frame (for pulsing indicators)
 
+	// Idle mode (idle.go)
+	idle         bool      // no input or connection changes for idleAfter
+	lastActivity time.Time // last input or connection change
+	viewportHash uint64    // contentHash of the viewport content last set
+
 	// Docker container resolution
 	dockerResolver    docker.Resolver               // resolves host ports to containers
 	dockerCache       map[int]*docker.ContainerPort // host port → container info
+	dockerResolvedAt  time.Time                     // resolution time of dockerCache
 	dockerView        bool                          // true when viewing Docker process connections
 	dockerContainers  bool                          // show virtual container rows in process list
 	virtualContainers []model.VirtualContainer      // cached virtual container rows
+	createdContainers []model.VirtualContainer      // created but not started (port conflict checks)
+	containerRates    map[string]ioRate             // container ID -> network throughput from stats deltas
+	proxyExpanded     map[int32]bool                // docker-proxy PIDs whose backend legs are shown
+
+	portConflicts     []portConflict  // host sockets on ports Docker publishes (portconflict.go)
+	portConflictsSeen map[string]bool // conflict keys of the last refresh, toasted once
+
+	// Conntrack view (Linux)
+	conntrackReader  conntrack.Reader  // reads the kernel conntrack table
+	conntrackEntries []conntrack.Entry // last read conntrack entries
+	conntrackErr     error             // last read error (shown in place of rows)
 }
 
 // killTargetInfo holds info about the process to be killed.
@@ -200,39 +539,83 @@ type killTargetInfo struct {
 	Port        int    // optional, 0 if killing by PID only
 	Signal      string // signal to send (default SIGTERM)
 	ContainerID string // Docker container ID (non-empty → use docker stop/kill)
+	Critical    bool   // matches a critical pattern: confirmed by typing confirmName()
 }
 
 // NewModel creates a new Model with default settings.
 func NewModel() Model {
-	return Model{
+	m := Model{
 		collector:        collector.New(),
 		netIOCollector:   collector.NewNetIOCollector(),
 		refreshInterval:  DefaultRefreshInterval,
 		netIOCache:       make(map[int32]*model.NetIOStats),
 		changes:          make(map[ConnectionKey]Change),
+		rowCache:         newRowCache(),
+		pipeline:         newPipelineCache(),
 		highlightChanges: config.CurrentSettings.HighlightChanges,
+		changeStyle:      parseChangeStyle(config.CurrentSettings.ChangeStyle),
 		dnsCache:         make(map[string]string),
 		dnsEnabled:       config.CurrentSettings.DNSEnabled,
 		serviceNames:     config.CurrentSettings.ServiceNames,
-		animations:       config.CurrentSettings.Animations,
-		dockerResolver:   docker.NewResolver(),
+		animations:       config.CurrentSettings.Animations && !plainMode,
+		lastActivity:     time.Now(),
+		dockerResolver:   docker.NewCachedResolver(docker.NewResolver(), docker.DefaultCacheInterval),
 		dockerCache:      make(map[int]*docker.ContainerPort),
 		dockerContainers: config.CurrentSettings.DockerContainers,
-		stack: []ViewState{{
-			Level:          LevelProcessList,
-			ProcessName:    "",
-			Cursor:         0,
-			SortColumn:     SortProcess,
-			SortAscending:  true,
-			SelectedColumn: SortProcess,
-		}},
+		conntrackReader:  conntrack.NewReader(),
+		groupByExe:       config.CurrentSettings.GroupByExe,
+		securityContext:  config.CurrentSettings.SecurityContext,
+		churnColumns:     config.CurrentSettings.ChurnColumns,
+		hideLoopback:     config.CurrentSettings.HideLoopback,
+		instantRefresh:   config.CurrentSettings.InstantRefresh,
+		ephemeralFlows:   config.CurrentSettings.EphemeralFlows,
+		tcpStats:         config.CurrentSettings.TCPStats,
+		protoDetail:      config.CurrentSettings.ProtocolDetail,
+		showIfaces:       config.CurrentSettings.Interfaces,
+		wrapNav:          config.CurrentSettings.WrapNavigation,
+		largeHostAt:      largeHostThresholdFromSettings(config.CurrentSettings),
+		sampleCap:        sampleCapFromSettings(config.CurrentSettings),
+		saturationAt:     saturationThresholdFromSettings(config.CurrentSettings),
+		linkSpeeds:       linkSpeedsFromSettings(config.CurrentSettings),
+		securityCache:    make(map[int32]security.Context),
+		showUptime:       config.CurrentSettings.ProcessUptime,
+		showFDs:          config.CurrentSettings.OpenFiles,
+		showCgroups:      config.CurrentSettings.Cgroups,
+		cgroups:          make(map[int32]cgroup.Info),
+		startTimes:       make(map[int32]time.Time),
+		processEnv:       config.CurrentSettings.ProcessEnv,
+		envKeys:          envKeysFromSettings(config.CurrentSettings.EnvKeys),
+		criticalPatterns: criticalPatternsFromSettings(config.CurrentSettings.CriticalProcesses),
+		geoPath:          config.CurrentSettings.GeoIPDatabase,
+		geoUnexpected:    unexpectedCountriesFromSettings(config.CurrentSettings),
+		sortPrefs:        make(map[string]config.SortPref),
+		refreshPrefs:     make(map[string]time.Duration),
+		presets:          append([]config.FilterPreset(nil), config.CurrentSettings.FilterPresets...),
+		notes:            maps.Clone(config.CurrentSettings.Notes),
+		pins:             pinsFromSettings(config.CurrentSettings),
+		rememberPins:     config.CurrentSettings.RememberPins,
+		groupDomains:     config.CurrentSettings.GroupDomains,
+		auditPath:        auditLogPath(),
+		verifySpinner:    newVerifySpinner(),
 	}
+	for k, v := range config.CurrentSettings.Sort {
+		m.sortPrefs[k] = v
+	}
+	for k, v := range config.CurrentSettings.Refresh {
+		m.refreshPrefs[k] = clampRefresh(v)
+	}
+	if d, ok := m.refreshPrefs[viewPrefKey(LevelProcessList)]; ok {
+		m.refreshInterval = d
+	}
+	m.stack = []ViewState{m.newViewState(LevelProcessList, "")}
+	m.applyCollectorOptions()
+	return m
 }
 
 // WithFilter returns a copy of the model with an initial filter applied.
 // CLI filters use exact port matching (port 80 only matches port 80, not 8080).
 func (m Model) WithFilter(filter string) Model {
-	m.activeFilter = filter
+	m.setFilter(filter)
 	m.cliFilter = filter // CLI filters use exact port matching
 	return m
 }
@@ -249,12 +632,13 @@ func (m Model) WithVersion(v string) Model {
 	return m
 }
 
-// useExactPortMatch returns true if the current filter should use exact port matching.
-// This is true when the filter was set via CLI argument, not via interactive search.
-func (m Model) useExactPortMatch() bool {
-	// If there's a CLI filter and it matches the active filter, use exact matching
-	// If user has cleared/changed the filter interactively, fall back to substring
-	return m.cliFilter != "" && m.cliFilter == m.activeFilter
+// WithCollectors returns a copy of the model that reads from the given collectors
+// instead of the local ones, e.g. a daemon client when attached to `netmon daemon`.
+func (m Model) WithCollectors(c collector.Collector, netIO collector.NetIOCollector) Model {
+	m.collector = c
+	m.netIOCollector = netIO
+	m.applyCollectorOptions()
+	return m
 }
 
 // CurrentView returns the current view state (top of stack).
@@ -286,70 +670,3 @@ func (m *Model) AtRootLevel() bool {
 }
 
 var _ tea.Model = Model{}
-
-// filterFields holds all filterable fields for a connection or process.
-type filterFields struct {
-	ProcessName string
-	PIDs        []int32
-	LocalAddr   string
-	RemoteAddr  string
-	Protocol    string
-	State       string
-}
-
-// matchesFilter checks if any field contains the search string (case-insensitive).
-// When exactPortMatch is true (CLI filters), ONLY matches exact port numbers.
-// When false (interactive search), matches process name, PID, addresses, protocol, state, and ports via substring.
-func matchesFilter(filter string, fields filterFields, exactPortMatch bool) bool {
-	if filter == "" {
-		return true
-	}
-
-	// CLI exact port matching - only match port numbers
-	if exactPortMatch {
-		ports := extractPortsFromAddrs(fields.LocalAddr, fields.RemoteAddr)
-		for _, port := range ports {
-			if strconv.Itoa(port) == filter {
-				return true
-			}
-		}
-		return false
-	}
-
-	// Interactive search - substring match on all fields
-	filterLower := strings.ToLower(filter)
-
-	// Match process name
-	if fields.ProcessName != "" && strings.Contains(strings.ToLower(fields.ProcessName), filterLower) {
-		return true
-	}
-
-	// Match any PID
-	for _, pid := range fields.PIDs {
-		if strings.Contains(strconv.Itoa(int(pid)), filter) {
-			return true
-		}
-	}
-
-	// Match local address
-	if fields.LocalAddr != "" && strings.Contains(strings.ToLower(fields.LocalAddr), filterLower) {
-		return true
-	}
-
-	// Match remote address
-	if fields.RemoteAddr != "" && strings.Contains(strings.ToLower(fields.RemoteAddr), filterLower) {
-		return true
-	}
-
-	// Match protocol
-	if fields.Protocol != "" && strings.Contains(strings.ToLower(fields.Protocol), filterLower) {
-		return true
-	}
-
-	// Match state
-	if fields.State != "" && strings.Contains(strings.ToLower(fields.State), filterLower) {
-		return true
-	}
-
-	return false
-}
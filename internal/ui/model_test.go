@@ -148,10 +148,10 @@ func TestModel_WithMockCollector(t *testing.T) {
 
 func TestTickMsg(t *testing.T) {
 	now := time.Now()
-	msg := TickMsg(now)
+	msg := TickMsg{Time: now}
 
 	// Verify it's the same time
-	if time.Time(msg) != now {
+	if msg.Time != now {
 		t.Error("TickMsg should preserve time value")
 	}
 }
@@ -361,8 +361,8 @@ func TestNavigationStack_ColumnSelection(t *testing.T) {
 	m := NewModel()
 
 	// Initial column selection
-	if m.CurrentView().SelectedColumn != SortProcess {
-		t.Errorf("initial column = %v, want SortProcess", m.CurrentView().SelectedColumn)
+	if m.CurrentView().SelectedColumn != SortAuto {
+		t.Errorf("initial column = %v, want SortAuto", m.CurrentView().SelectedColumn)
 	}
 
 	// Move column selection
@@ -441,195 +441,13 @@ func TestViewState_Defaults(t *testing.T) {
 	if view.Cursor != 0 {
 		t.Errorf("default cursor = %d, want 0", view.Cursor)
 	}
-	if view.SortColumn != SortProcess {
-		t.Errorf("default sortColumn = %v, want SortProcess", view.SortColumn)
+	if view.SortColumn != SortAuto {
+		t.Errorf("default sortColumn = %v, want SortAuto", view.SortColumn)
 	}
 	if view.SortAscending != true {
 		t.Error("default sortAscending should be true")
 	}
-	if view.SelectedColumn != SortProcess {
-		t.Errorf("default selectedColumn = %v, want SortProcess", view.SelectedColumn)
-	}
-}
-
-// Tests for matchesFilter function
-
-func TestMatchesFilter_EmptyFilter(t *testing.T) {
-	if !matchesFilter("", filterFields{ProcessName: "Chrome", PIDs: []int32{1234}, LocalAddr: "127.0.0.1:8080"}, false) {
-		t.Error("Empty filter should match everything")
-	}
-}
-
-func TestMatchesFilter_ProcessName(t *testing.T) {
-	tests := []struct {
-		filter      string
-		processName string
-		want        bool
-	}{
-		{"chrome", "Chrome", true},
-		{"CHROME", "Chrome", true},
-		{"Chr", "Chrome", true},
-		{"firefox", "Chrome", false},
-		{"ch", "Chrome", true},
-	}
-
-	for _, tt := range tests {
-		got := matchesFilter(tt.filter, filterFields{ProcessName: tt.processName}, false)
-		if got != tt.want {
-			t.Errorf("matchesFilter(%q, processName=%q) = %v, want %v",
-				tt.filter, tt.processName, got, tt.want)
-		}
-	}
-}
-
-func TestMatchesFilter_PID(t *testing.T) {
-	tests := []struct {
-		filter string
-		pids   []int32
-		want   bool
-	}{
-		{"1234", []int32{1234}, true},
-		{"123", []int32{1234}, true},      // partial match
-		{"12", []int32{1234, 5678}, true}, // matches first
-		{"56", []int32{1234, 5678}, true}, // matches second
-		{"9999", []int32{1234}, false},
-	}
-
-	for _, tt := range tests {
-		got := matchesFilter(tt.filter, filterFields{ProcessName: "NoMatch", PIDs: tt.pids}, false)
-		if got != tt.want {
-			t.Errorf("matchesFilter(%q, pids=%v) = %v, want %v",
-				tt.filter, tt.pids, got, tt.want)
-		}
-	}
-}
-
-func TestMatchesFilter_Port(t *testing.T) {
-	tests := []struct {
-		filter    string
-		localAddr string
-		want      bool
-	}{
-		{"8080", "127.0.0.1:8080", true},
-		{"80", "127.0.0.1:8080", true},    // partial match with exactPortMatch=false
-		{"443", "127.0.0.1:443", true},    // exact
-		{"9999", "127.0.0.1:8080", false}, // no match
-	}
-
-	for _, tt := range tests {
-		got := matchesFilter(tt.filter, filterFields{ProcessName: "NoMatch", LocalAddr: tt.localAddr}, false)
-		if got != tt.want {
-			t.Errorf("matchesFilter(%q, localAddr=%q) = %v, want %v",
-				tt.filter, tt.localAddr, got, tt.want)
-		}
-	}
-}
-
-func TestMatchesFilter_Port_ExactMatch(t *testing.T) {
-	tests := []struct {
-		filter    string
-		localAddr string
-		want      bool
-	}{
-		{"8080", "127.0.0.1:8080", true},
-		{"80", "127.0.0.1:8080", false},   // no partial match with exactPortMatch=true
-		{"443", "127.0.0.1:443", true},    // exact match on 443
-		{"9999", "127.0.0.1:8080", false}, // no match
-	}
-
-	for _, tt := range tests {
-		got := matchesFilter(tt.filter, filterFields{ProcessName: "NoMatch", LocalAddr: tt.localAddr}, true)
-		if got != tt.want {
-			t.Errorf("matchesFilter(%q, localAddr=%q, exact=true) = %v, want %v",
-				tt.filter, tt.localAddr, got, tt.want)
-		}
-	}
-}
-
-func TestMatchesFilter_Combined(t *testing.T) {
-	// Should match if ANY of the criteria match
-	if !matchesFilter("chrome", filterFields{ProcessName: "Chrome", PIDs: []int32{1234}, LocalAddr: "127.0.0.1:8080"}, false) {
-		t.Error("Should match on process name")
-	}
-	if !matchesFilter("1234", filterFields{ProcessName: "Firefox", PIDs: []int32{1234}, LocalAddr: "127.0.0.1:8080"}, false) {
-		t.Error("Should match on PID")
-	}
-	if !matchesFilter("8080", filterFields{ProcessName: "Firefox", PIDs: []int32{5678}, LocalAddr: "127.0.0.1:8080"}, false) {
-		t.Error("Should match on port")
-	}
-	if matchesFilter("nomatch", filterFields{ProcessName: "Firefox", PIDs: []int32{5678}, LocalAddr: "127.0.0.1:8080"}, false) {
-		t.Error("Should not match when nothing matches")
-	}
-}
-
-func TestMatchesFilter_LocalAddr(t *testing.T) {
-	fields := filterFields{
-		LocalAddr:  "127.0.0.1:8080",
-		RemoteAddr: "10.0.0.1:443",
-		Protocol:   "tcp",
-		State:      "ESTABLISHED",
-	}
-	if !matchesFilter("127.0.0.1", fields, false) {
-		t.Error("Should match local address")
-	}
-	if !matchesFilter("8080", fields, false) {
-		t.Error("Should match local port")
-	}
-}
-
-func TestMatchesFilter_RemoteAddr(t *testing.T) {
-	fields := filterFields{
-		LocalAddr:  "127.0.0.1:8080",
-		RemoteAddr: "10.0.0.1:443",
-		Protocol:   "tcp",
-		State:      "ESTABLISHED",
-	}
-	if !matchesFilter("10.0.0.1", fields, false) {
-		t.Error("Should match remote address")
-	}
-	if !matchesFilter("443", fields, false) {
-		t.Error("Should match remote port")
-	}
-}
-
-func TestMatchesFilter_Protocol(t *testing.T) {
-	fields := filterFields{
-		LocalAddr:  "127.0.0.1:8080",
-		RemoteAddr: "10.0.0.1:443",
-		Protocol:   "tcp",
-		State:      "ESTABLISHED",
-	}
-	if !matchesFilter("tcp", fields, false) {
-		t.Error("Should match protocol")
-	}
-	if !matchesFilter("TCP", fields, false) {
-		t.Error("Should match protocol case-insensitively")
-	}
-}
-
-func TestMatchesFilter_State(t *testing.T) {
-	fields := filterFields{
-		LocalAddr:  "127.0.0.1:8080",
-		RemoteAddr: "10.0.0.1:443",
-		Protocol:   "tcp",
-		State:      "ESTABLISHED",
-	}
-	if !matchesFilter("established", fields, false) {
-		t.Error("Should match state case-insensitively")
-	}
-	if !matchesFilter("ESTAB", fields, false) {
-		t.Error("Should match partial state")
-	}
-}
-
-func TestMatchesFilter_NoMatch(t *testing.T) {
-	fields := filterFields{
-		LocalAddr:  "127.0.0.1:8080",
-		RemoteAddr: "10.0.0.1:443",
-		Protocol:   "tcp",
-		State:      "ESTABLISHED",
-	}
-	if matchesFilter("nomatch", fields, false) {
-		t.Error("Should not match when nothing matches")
+	if view.SelectedColumn != SortAuto {
+		t.Errorf("default selectedColumn = %v, want SortAuto", view.SelectedColumn)
 	}
 }
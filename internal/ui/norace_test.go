@@ -0,0 +1,6 @@
+//go:build !race
+
+package ui
+
+// raceEnabled reports whether tests run under the race detector.
+const raceEnabled = false
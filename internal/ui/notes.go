@@ -0,0 +1,154 @@
+package ui
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kostyay/netmon/internal/config"
+	"github.com/kostyay/netmon/internal/i18n"
+)
+
+const (
+	noteSeparator  = " · " // between a process name or address and its note
+	noteModalWidth = 56    // width of the note editor
+)
+
+// noteFor returns the note for a process name or IP, or "".
+func (m Model) noteFor(key string) string {
+	if key == "" {
+		return ""
+	}
+	return m.notes[key]
+}
+
+// withNote appends the note for key to text.
+func (m Model) withNote(text, key string) string {
+	if note := m.noteFor(key); note != "" {
+		return text + noteSeparator + note
+	}
+	return text
+}
+
+// notesFor returns the notes on the given process names and IPs, for filter matching.
+func (m Model) notesFor(keys ...string) []string {
+	if len(m.notes) == 0 {
+		return nil
+	}
+	var notes []string
+	for _, k := range keys {
+		if note := m.noteFor(k); note != "" {
+			notes = append(notes, note)
+		}
+	}
+	return notes
+}
+
+// noteTargetForSelection returns what 'n' annotates: the selected process in
+// the process list, the selected connection's remote IP elsewhere (its process
+// for listeners without a remote).
+func (m Model) noteTargetForSelection() string {
+	view := m.CurrentView()
+	if view == nil || m.snapshot == nil {
+		return ""
+	}
+	if view.Level == LevelProcessList {
+		apps := m.visibleApps()
+		if idx := m.resolveSelectionIndex(); idx >= 0 && idx < len(apps) {
+			return apps[idx].Name
+		}
+		return ""
+	}
+	conn := m.selectedConnection()
+	if conn == nil {
+		return ""
+	}
+	if ip := extractIP(conn.RemoteAddr); ip != "" {
+		return ip
+	}
+	return conn.ProcessName
+}
+
+// startNote opens the note editor for the current selection, prefilled with its note.
+func (m *Model) startNote() tea.Cmd {
+	target := m.noteTargetForSelection()
+	if target == "" {
+		return m.notify(toastWarn, i18n.T("Nothing to annotate"))
+	}
+	m.noteMode = true
+	m.noteTarget = target
+	m.noteText = m.noteFor(target)
+	return nil
+}
+
+// handleNoteKey handles a key press while editing a note.
+func (m *Model) handleNoteKey(msg tea.KeyMsg) tea.Cmd {
+	key := msg.String()
+	switch {
+	case matchKey(key, KeyEnter):
+		return m.saveNote(m.noteTarget, m.noteText)
+	case matchKey(key, KeyEsc):
+		m.closeNote()
+	case matchKey(key, KeyBack):
+		if r := []rune(m.noteText); len(r) > 0 {
+			m.noteText = string(r[:len(r)-1])
+		}
+	default:
+		if r := msg.Runes; len(r) > 0 && r[0] >= 32 {
+			m.noteText += string(r)
+		}
+	}
+	return nil
+}
+
+// closeNote leaves the note editor without saving.
+func (m *Model) closeNote() {
+	m.noteMode = false
+	m.noteTarget = ""
+	m.noteText = ""
+}
+
+// saveNote sets the note for target (an empty note removes it) and persists.
+func (m *Model) saveNote(target, note string) tea.Cmd {
+	m.closeNote()
+	note = strings.TrimSpace(note)
+	if m.notes == nil {
+		m.notes = make(map[string]string)
+	}
+	if note == "" && m.notes[target] == "" {
+		return nil
+	}
+
+	var msg string
+	if note == "" {
+		delete(m.notes, target)
+		msg = fmt.Sprintf(i18n.T("Removed note for %s"), target)
+	} else {
+		m.notes[target] = note
+		msg = fmt.Sprintf(i18n.T("Noted %s: %s"), target, note)
+	}
+	m.rowCache.invalidate()
+	m.pipeline.invalidate()
+
+	config.CurrentSettings.Notes = maps.Clone(m.notes)
+	_ = config.SaveSettings(config.CurrentSettings)
+	return m.notify(toastSuccess, msg)
+}
+
+// renderNoteModalContent returns the note editor content.
+func (m Model) renderNoteModalContent() string {
+	keyStyle := FooterKeyStyle()
+	descStyle := FooterDescStyle()
+	lines := []string{
+		i18n.T("Note for") + " " + HeaderStyle().Render(truncateString(m.noteTarget, noteModalWidth-14)),
+		"",
+		"> " + m.noteText + "█",
+		"",
+		DimmedStyle().Render(i18n.T("Shown next to the name and matched by / search.")),
+		DimmedStyle().Render(i18n.T("Save an empty note to remove it.")),
+		"",
+		keyStyle.Render("Enter") + descStyle.Render(" "+i18n.T("Save")+"  ") + keyStyle.Render("Esc") + descStyle.Render(" "+i18n.T("Cancel")),
+	}
+	return strings.Join(lines, "\n")
+}
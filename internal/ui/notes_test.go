@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/config"
+)
+
+func isolateNotes(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	orig := config.CurrentSettings.Notes
+	t.Cleanup(func() { config.CurrentSettings.Notes = orig })
+}
+
+func TestNote_AnnotateProcess(t *testing.T) {
+	isolateNotes(t)
+	m := chipsTestModel()
+
+	m = pressKey(m, "n")
+	if !m.noteMode || m.noteTarget != "chrome" {
+		t.Fatalf("noteMode = %v, target = %q; want editing chrome", m.noteMode, m.noteTarget)
+	}
+	m = typeKeys(m, "web browser")
+	m = pressSpecial(m, tea.KeyEnter)
+
+	if m.noteMode {
+		t.Error("Enter should close the note editor")
+	}
+	if m.notes["chrome"] != "web browser" {
+		t.Errorf("notes = %v", m.notes)
+	}
+	if config.CurrentSettings.Notes["chrome"] != "web browser" {
+		t.Errorf("persisted notes = %v", config.CurrentSettings.Notes)
+	}
+	if !strings.Contains(toastText(m), "Noted chrome") {
+		t.Errorf("toast = %q", toastText(m))
+	}
+}
+
+func TestNote_AnnotateRemoteIP(t *testing.T) {
+	isolateNotes(t)
+	m := chipsTestModel()
+	m.PushView(ViewState{Level: LevelConnections, ProcessName: "chrome", SortColumn: SortRemote, SortAscending: true})
+
+	m = pressKey(m, "n")
+	if m.noteTarget != "1.1.1.1" {
+		t.Fatalf("target = %q, want the remote IP", m.noteTarget)
+	}
+	m = typeKeys(m, "staging DB")
+	m = pressSpecial(m, tea.KeyEnter)
+
+	if m.notes["1.1.1.1"] != "staging DB" {
+		t.Errorf("notes = %v", m.notes)
+	}
+}
+
+func TestNote_EscCancels(t *testing.T) {
+	isolateNotes(t)
+	m := chipsTestModel()
+	m.notes = map[string]string{"chrome": "browser"}
+
+	m = pressKey(m, "n")
+	if m.noteText != "browser" {
+		t.Errorf("noteText = %q, want existing note prefilled", m.noteText)
+	}
+	m = typeKeys(m, "q!")
+	m = pressSpecial(m, tea.KeyEsc)
+
+	if m.noteMode || m.quitting {
+		t.Error("Esc should close the editor; typed keys should not leak")
+	}
+	if m.notes["chrome"] != "browser" {
+		t.Errorf("notes = %v, want unchanged", m.notes)
+	}
+}
+
+func TestNote_EmptyRemoves(t *testing.T) {
+	isolateNotes(t)
+	m := chipsTestModel()
+	m.notes = map[string]string{"chrome": "browser"}
+
+	m.saveNote("chrome", "  ")
+	if _, ok := m.notes["chrome"]; ok {
+		t.Error("empty note should remove the annotation")
+	}
+	if !strings.Contains(toastText(m), "Removed note") {
+		t.Errorf("toast = %q", toastText(m))
+	}
+}
+
+func TestNote_MatchedByFilter(t *testing.T) {
+	m := chipsTestModel()
+	m.notes = map[string]string{"curl": "health checker", "2.2.2.2": "staging DB"}
+
+	m.filterChips = []string{"health"}
+	if got := appNames(m.filteredApps()); !slices.Equal(got, []string{"curl"}) {
+		t.Errorf("process note filter = %v, want curl", got)
+	}
+	m.filterChips = []string{"staging"}
+	if got := appNames(m.filteredApps()); !slices.Equal(got, []string{"chrome"}) {
+		t.Errorf("host note filter = %v, want chrome", got)
+	}
+	if got := m.filteredAllConnections(); len(got) != 1 || got[0].RemoteAddr != "2.2.2.2:80" {
+		t.Errorf("flat view host note filter = %+v, want the 2.2.2.2 connection", got)
+	}
+}
+
+func TestNote_Rendered(t *testing.T) {
+	m := chipsTestModel()
+	m.width = 160
+	m.notes = map[string]string{"chrome": "browser", "1.1.1.1": "resolver"}
+
+	if out := m.renderProcessList(); !strings.Contains(out, "chrome · browser") {
+		t.Errorf("process list should show the note, got:\n%s", out)
+	}
+	m.PushView(ViewState{Level: LevelAllConnections, SortColumn: SortProcess, SortAscending: true})
+	if out := m.renderAllConnections(); !strings.Contains(out, "resolver") {
+		t.Errorf("connections should show the host note, got:\n%s", out)
+	}
+}
@@ -0,0 +1,227 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kostyay/netmon/internal/filter"
+	"github.com/kostyay/netmon/internal/i18n"
+)
+
+// Command palette: every action available in the current view, by name.
+// Registry commands, sorting by each visible column, toggling each setting
+// and exporting a snapshot; typing fuzzy-filters the list.
+const (
+	paletteModalWidth = 60
+	maxPaletteRows    = 12
+)
+
+// paletteItem is one entry of the command palette.
+type paletteItem struct {
+	title string // what the palette lists and matches against, e.g. "Sort by Conns"
+	key   string // shortcut or current value shown on the right ("" = none)
+	run   func(m Model) (tea.Model, tea.Cmd)
+}
+
+// paletteSkips reports whether a registry command is left out of the
+// palette: the palette itself, cursor movement, and commands that need the
+// key they were pressed with (quick sort digits, tab numbers).
+func paletteSkips(c command) bool {
+	return c.id == "palette" || c.id == "sort-column" || c.section == sectionNavigation || c.match != nil
+}
+
+// paletteItems returns every palette entry available in the current state,
+// in display order: commands in registry order, then sorts, settings and export.
+func (m Model) paletteItems() []paletteItem {
+	var items []paletteItem
+	for _, c := range commandRegistry {
+		if paletteSkips(c) || !c.availableIn(m) {
+			continue
+		}
+		msg, ok := scriptKey(c.keys[0].Key)
+		if !ok {
+			continue
+		}
+		items = append(items, paletteItem{
+			title: i18n.T(c.desc),
+			key:   c.keyLabel(),
+			run:   func(m Model) (tea.Model, tea.Cmd) { return c.run(m, msg) },
+		})
+	}
+
+	if view := m.CurrentView(); view != nil {
+		for i, col := range m.columnDefsForLevel(view.Level) {
+			title := fmt.Sprintf(i18n.T("Sort by %s"), i18n.T(col.label))
+			if col.id == view.SortColumn {
+				title += " " + i18n.T("(reverse)")
+			}
+			key := ""
+			if i < 9 {
+				key = strconv.Itoa(i + 1)
+			}
+			items = append(items, paletteItem{
+				title: title,
+				key:   key,
+				run:   func(m Model) (tea.Model, tea.Cmd) { m.quickSort(i); return m, nil },
+			})
+		}
+	}
+
+	for i, s := range settingItems() {
+		var value string
+		switch {
+		case s.value != nil:
+			value = s.value(&m)
+		case s.get(&m):
+			value = i18n.T("on")
+		default:
+			value = i18n.T("off")
+		}
+		items = append(items, paletteItem{
+			title: fmt.Sprintf(i18n.T("Toggle %s"), i18n.T(s.name)),
+			key:   value,
+			run:   func(m Model) (tea.Model, tea.Cmd) { return m, m.toggleSetting(i) },
+		})
+	}
+
+	items = append(items, paletteItem{
+		title: i18n.T("Export snapshot to JSON"),
+		run:   func(m Model) (tea.Model, tea.Cmd) { return m, m.exportToFile() },
+	})
+	if len(m.tags) > 0 {
+		items = append(items, paletteItem{
+			title: i18n.T("Export incident timeline to Markdown"),
+			key:   fmt.Sprint(len(m.tags)),
+			run:   func(m Model) (tea.Model, tea.Cmd) { return m, m.exportTimeline() },
+		})
+	}
+	if m.allowList != nil {
+		items = append(items, paletteItem{
+			title: i18n.T("Export allowlist violations to JSON"),
+			key:   fmt.Sprint(len(m.violations)),
+			run:   func(m Model) (tea.Model, tea.Cmd) { return m, m.exportViolations() },
+		})
+	}
+	return items
+}
+
+// exportToFile exports the snapshot to a timestamped file in the working directory.
+func (m *Model) exportToFile() tea.Cmd {
+	if m.snapshot == nil {
+		return m.notify(toastWarn, i18n.T("Nothing to export yet"))
+	}
+	path := "netmon-" + time.Now().Format("20060102-150405") + ".json"
+	if err := m.exportSnapshot(path); err != nil {
+		return m.notify(toastError, fmt.Sprintf(i18n.T("Export failed: %v"), err))
+	}
+	return m.notify(toastSuccess, fmt.Sprintf(i18n.T("Exported snapshot to %s"), path))
+}
+
+// paletteMatches returns the palette entries matching the query, best match
+// first (ties keep display order), or all of them for an empty query.
+func (m Model) paletteMatches() []paletteItem {
+	items := m.paletteItems()
+	if m.paletteQuery == "" {
+		return items
+	}
+	type scored struct {
+		item  paletteItem
+		score int
+	}
+	var matches []scored
+	for _, item := range items {
+		if score, _, ok := filter.FuzzyMatch(m.paletteQuery, item.title); ok {
+			matches = append(matches, scored{item, score})
+		}
+	}
+	slices.SortStableFunc(matches, func(a, b scored) int { return cmp.Compare(b.score, a.score) })
+	out := make([]paletteItem, len(matches))
+	for i, s := range matches {
+		out[i] = s.item
+	}
+	return out
+}
+
+// openPalette opens the command palette with an empty query.
+func (m *Model) openPalette() {
+	m.paletteMode = true
+	m.paletteQuery = ""
+	m.paletteCursor = 0
+}
+
+// handlePaletteKey edits the query, moves the selection, or runs the selected
+// entry and closes the palette.
+func (m Model) handlePaletteKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	switch {
+	case matchKey(key, KeyEsc, KeyPalette):
+		m.paletteMode = false
+	case matchKey(key, KeyEnter):
+		matches := m.paletteMatches()
+		m.paletteMode = false
+		if m.paletteCursor < len(matches) {
+			return matches[m.paletteCursor].run(m)
+		}
+	case matchKey(key, KeyUp):
+		m.paletteCursor = max(m.paletteCursor-1, 0)
+	case matchKey(key, KeyDown):
+		m.paletteCursor = min(m.paletteCursor+1, max(len(m.paletteMatches())-1, 0))
+	case matchKey(key, KeyBack):
+		if r := []rune(m.paletteQuery); len(r) > 0 {
+			m.paletteQuery = string(r[:len(r)-1])
+			m.paletteCursor = 0
+		}
+	default:
+		if r := msg.Runes; len(r) > 0 && r[0] >= 32 {
+			m.paletteQuery += string(r)
+			m.paletteCursor = 0
+		}
+	}
+	return m, nil
+}
+
+// renderPaletteModalContent renders the query line and a window of matching
+// entries around the selection.
+func (m Model) renderPaletteModalContent() string {
+	keyStyle := FooterKeyStyle()
+	descStyle := FooterDescStyle()
+	lines := []string{"> " + m.paletteQuery + "█", ""}
+
+	matches := m.paletteMatches()
+	if len(matches) == 0 {
+		lines = append(lines, DimmedStyle().Render(i18n.T("No matching commands")))
+	}
+	start := max(min(m.paletteCursor-maxPaletteRows/2, len(matches)-maxPaletteRows), 0)
+	end := min(start+maxPaletteRows, len(matches))
+	for i := start; i < end; i++ {
+		item := matches[i]
+		cursor := "  "
+		if i == m.paletteCursor {
+			cursor = "▸ "
+		}
+		key := truncateString(item.key, 12)
+		titleWidth := paletteModalWidth - 6 - lipgloss.Width(key)
+		title := cursor + padRight(truncateString(item.title, titleWidth), titleWidth) + " "
+		if i == m.paletteCursor {
+			lines = append(lines, SelectedConnStyle().Render(title+key))
+		} else {
+			lines = append(lines, title+descStyle.Render(key))
+		}
+	}
+	if len(matches) > maxPaletteRows {
+		lines = append(lines, descStyle.Render(fmt.Sprintf("  %d of %d", m.paletteCursor+1, len(matches))))
+	}
+
+	lines = append(lines, "",
+		keyStyle.Render("↑↓")+descStyle.Render(" "+i18n.T("Select")+"  ")+
+			keyStyle.Render("Enter")+descStyle.Render(" "+i18n.T("Run")+"  ")+
+			keyStyle.Render("Esc")+descStyle.Render(" "+i18n.T("Close")))
+	return strings.Join(lines, "\n")
+}
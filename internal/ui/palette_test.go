@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/config"
+)
+
+func openPaletteWith(m Model, query string) Model {
+	m = pressSpecial(m, tea.KeyCtrlP)
+	return typeKeys(m, query)
+}
+
+func TestPalette_RunsCommandByName(t *testing.T) {
+	m := openPaletteWith(manyAppsModel(3), "interface load")
+	if !m.paletteMode {
+		t.Fatal("ctrl+p should open the palette")
+	}
+	if !strings.Contains(stripAnsi(m.View()), "Interface load") {
+		t.Errorf("palette should list the match:\n%s", stripAnsi(m.View()))
+	}
+
+	m = pressSpecial(m, tea.KeyEnter)
+	if m.paletteMode || !m.ifaceMode {
+		t.Errorf("enter should close the palette and open the interface panel (palette %v, iface %v)", m.paletteMode, m.ifaceMode)
+	}
+}
+
+func TestPalette_SortByColumn(t *testing.T) {
+	m := openPaletteWith(manyAppsModel(3), "sort by conns")
+	m = pressSpecial(m, tea.KeyEnter)
+
+	if view := m.CurrentView(); view.SortColumn != SortConns || !view.SortAscending {
+		t.Errorf("sort = %v asc %v, want Conns ascending", view.SortColumn, view.SortAscending)
+	}
+
+	// The current sort column is offered reversed
+	m = openPaletteWith(m, "sort by conns")
+	if got := m.paletteMatches()[0].title; got != "Sort by Conns (reverse)" {
+		t.Errorf("first match = %q", got)
+	}
+	m = pressSpecial(m, tea.KeyEnter)
+	if m.CurrentView().SortAscending {
+		t.Error("second sort should reverse the direction")
+	}
+}
+
+func TestPalette_TogglesSetting(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	orig := config.CurrentSettings.WrapNavigation
+	t.Cleanup(func() { config.CurrentSettings.WrapNavigation = orig })
+
+	m := createTestModel()
+	m.wrapNav = false
+	m = openPaletteWith(m, "wrap")
+	if item := m.paletteMatches()[0]; item.title != "Toggle Wrap Navigation" || item.key != "off" {
+		t.Fatalf("first match = %q (%q)", item.title, item.key)
+	}
+	m = pressSpecial(m, tea.KeyEnter)
+	if !m.wrapNav || !config.CurrentSettings.WrapNavigation {
+		t.Error("Wrap Navigation should be on and saved")
+	}
+}
+
+func TestPalette_SelectAndClose(t *testing.T) {
+	m := openPaletteWith(createTestModel(), "sort by")
+	m = pressSpecial(m, tea.KeyDown)
+	m = pressSpecial(m, tea.KeyDown)
+	if m.paletteCursor != 2 {
+		t.Errorf("cursor = %d, want 2", m.paletteCursor)
+	}
+	m = pressSpecial(m, tea.KeyBackspace)
+	if m.paletteQuery != "sort b" || m.paletteCursor != 0 {
+		t.Errorf("backspace: query %q, cursor %d", m.paletteQuery, m.paletteCursor)
+	}
+
+	// Typing q searches rather than quitting; esc closes without running anything
+	m = typeKeys(m, "q")
+	if m.quitting {
+		t.Fatal("q in the palette must not quit")
+	}
+	m = pressSpecial(m, tea.KeyEsc)
+	if m.paletteMode || len(m.stack) != 1 {
+		t.Errorf("esc should only close the palette (mode %v, views %d)", m.paletteMode, len(m.stack))
+	}
+}
+
+func TestPalette_NoMatches(t *testing.T) {
+	m := openPaletteWith(manyAppsModel(3), "zzzz")
+	if len(m.paletteMatches()) != 0 {
+		t.Fatalf("matches = %d, want none", len(m.paletteMatches()))
+	}
+	if !strings.Contains(stripAnsi(m.View()), "No matching commands") {
+		t.Error("empty result should say so")
+	}
+	m = pressSpecial(m, tea.KeyEnter)
+	if m.paletteMode {
+		t.Error("enter with no match should close the palette")
+	}
+}
+
+func TestPaletteItems_SkipsNavigation(t *testing.T) {
+	for _, item := range createTestModel().paletteItems() {
+		switch item.title {
+		case "Move up", "Go to last row", KeyPalette.Desc, KeyQuickSort.Desc:
+			t.Errorf("palette lists %q", item.title)
+		}
+	}
+}
+
+func TestPalette_ExportSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	m := openPaletteWith(createTestModel(), "export")
+	m = pressSpecial(m, tea.KeyEnter)
+
+	files, _ := filepath.Glob(filepath.Join(dir, "netmon-*.json"))
+	if len(files) != 1 {
+		t.Fatalf("exported files = %v, want one", files)
+	}
+	if data, err := os.ReadFile(files[0]); err != nil || len(data) == 0 {
+		t.Errorf("export is empty: %v", err)
+	}
+	if len(m.toasts) != 1 || !strings.HasPrefix(m.toasts[0].Message, "Exported snapshot to netmon-") {
+		t.Errorf("toasts = %+v", m.toasts)
+	}
+}
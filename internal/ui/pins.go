@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/config"
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// pinMark follows the name of a pinned process.
+const pinMark = " *"
+
+// pinsFromSettings returns the saved pins when Remember Pins is on.
+func pinsFromSettings(s *config.Settings) map[string]bool {
+	pins := make(map[string]bool)
+	if s.RememberPins {
+		for _, name := range s.Pins {
+			pins[name] = true
+		}
+	}
+	return pins
+}
+
+// togglePin pins or unpins the selected process. Pinned processes stay at the
+// top of the process list whatever the sort.
+func (m *Model) togglePin() tea.Cmd {
+	view := m.CurrentView()
+	if view == nil || view.Level != LevelProcessList {
+		return nil
+	}
+	apps := m.visibleApps()
+	idx := m.resolveSelectionIndex()
+	if idx < 0 || idx >= len(apps) {
+		return m.notify(toastWarn, i18n.T("Select a process to pin"))
+	}
+	name := apps[idx].Name
+	if m.pins == nil {
+		m.pins = make(map[string]bool)
+	}
+
+	msg := fmt.Sprintf(i18n.T("Pinned %s"), name)
+	if m.pins[name] {
+		delete(m.pins, name)
+		msg = fmt.Sprintf(i18n.T("Unpinned %s"), name)
+	} else {
+		m.pins[name] = true
+	}
+	m.rowCache.invalidate()
+	m.pipeline.invalidate()
+	m.validateSelection() // follow the process to its new row
+	m.savePins()
+	return m.notify(toastInfo, msg)
+}
+
+// savePins persists the pins when Remember Pins is on.
+func (m *Model) savePins() {
+	if !m.rememberPins {
+		return
+	}
+	config.CurrentSettings.Pins = m.pinNames()
+	_ = config.SaveSettings(config.CurrentSettings)
+}
+
+// pinNames returns the pinned process names, sorted.
+func (m Model) pinNames() []string {
+	names := make([]string, 0, len(m.pins))
+	for name := range m.pins {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// pinFirst moves pinned processes to the front of apps, keeping the sort
+// order within both groups.
+func (m Model) pinFirst(apps []model.Application) []model.Application {
+	if len(m.pins) == 0 {
+		return apps
+	}
+	slices.SortStableFunc(apps, func(a, b model.Application) int {
+		switch pa, pb := m.pins[a.Name], m.pins[b.Name]; {
+		case pa && !pb:
+			return -1
+		case pb && !pa:
+			return 1
+		}
+		return 0
+	})
+	return apps
+}
+
+// withPin appends the pin mark to text when the process name is pinned.
+func (m Model) withPin(text, name string) string {
+	if m.pins[name] {
+		return text + pinMark
+	}
+	return text
+}
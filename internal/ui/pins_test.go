@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/kostyay/netmon/internal/config"
+)
+
+func isolatePins(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	orig, origRemember := config.CurrentSettings.Pins, config.CurrentSettings.RememberPins
+	t.Cleanup(func() { config.CurrentSettings.Pins, config.CurrentSettings.RememberPins = orig, origRemember })
+}
+
+func TestPin_KeepsProcessOnTop(t *testing.T) {
+	isolatePins(t)
+	m := manyAppsModel(5)
+	m = pressKey(m, "j")
+	m = pressKey(m, "j")
+	m = pressKey(m, "j")
+	m = pressKey(m, "*")
+
+	if got := appNames(m.visibleApps()); got[0] != "app003" || got[1] != "app000" {
+		t.Fatalf("order = %v, want app003 pinned first, rest in sort order", got)
+	}
+	if m.CurrentView().Cursor != 0 {
+		t.Errorf("cursor = %d, want it to follow app003 to the top", m.CurrentView().Cursor)
+	}
+	if len(m.toasts) != 1 || m.toasts[0].Message != "Pinned app003" {
+		t.Errorf("toasts = %+v", m.toasts)
+	}
+	if !strings.Contains(stripAnsi(m.View()), "app003"+pinMark) {
+		t.Error("pinned row should show the pin mark")
+	}
+
+	// Reversing the sort keeps the pin on top
+	m.CurrentView().SortAscending = !m.CurrentView().SortAscending
+	m.pipeline.invalidate()
+	if got := appNames(m.visibleApps()); got[0] != "app003" || got[1] != "app004" {
+		t.Errorf("order after reversing sort = %v", got)
+	}
+	if config.CurrentSettings.Pins != nil {
+		t.Errorf("pins saved with Remember Pins off: %v", config.CurrentSettings.Pins)
+	}
+
+	m = pressKey(m, "*")
+	if len(m.pins) != 0 || appNames(m.visibleApps())[0] != "app004" {
+		t.Errorf("unpin: pins = %v, order = %v", m.pins, appNames(m.visibleApps()))
+	}
+}
+
+func TestPin_OnlyInProcessList(t *testing.T) {
+	isolatePins(t)
+	m := manyAppsModel(3)
+	m = pressKey(m, "v") // flat connections view
+	m = pressKey(m, "*")
+	if len(m.pins) != 0 {
+		t.Errorf("pins = %v, want none outside the process list", m.pins)
+	}
+}
+
+func TestPin_RememberedAcrossSessions(t *testing.T) {
+	isolatePins(t)
+	m := manyAppsModel(3)
+	m.rememberPins = true
+	m = pressKey(m, "j")
+	m = pressKey(m, "*")
+	if !slices.Equal(config.CurrentSettings.Pins, []string{"app001"}) {
+		t.Fatalf("saved pins = %v", config.CurrentSettings.Pins)
+	}
+
+	config.CurrentSettings.RememberPins = true
+	if pins := pinsFromSettings(config.CurrentSettings); !pins["app001"] || len(pins) != 1 {
+		t.Errorf("restored pins = %v", pins)
+	}
+	config.CurrentSettings.RememberPins = false
+	if pins := pinsFromSettings(config.CurrentSettings); len(pins) != 0 {
+		t.Errorf("pins restored with Remember Pins off: %v", pins)
+	}
+}
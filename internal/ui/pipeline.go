@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/kostyay/netmon/internal/matchlist"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// Navigation keys, cursor validation and rendering each need the current view's
+// filtered, sorted rows, several times per Update. pipelineCache memoizes those
+// lists so only a new snapshot, filter or sort order pays the O(n log n) cost.
+
+// pipelineKey identifies one filtered, sorted row list.
+type pipelineKey struct {
+	snapshot    *model.NetworkSnapshot
+	kind        ViewLevel
+	processName string // connections view only
+	filters     string // chips joined with NUL
+	cliFilter   string // chip matched as an exact port
+	matchList   *matchlist.List
+	sortColumn  SortColumn
+	sortAsc     bool
+}
+
+// maxPipelineEntries bounds the cache; a handful of keys are live at once
+// (e.g. the process list count while a drill-down is open).
+const maxPipelineEntries = 8
+
+// pipelineCache holds recently computed row lists. It is shared by pointer
+// between Model copies; nil disables caching. Results are shared, so callers
+// must not modify the returned slices.
+type pipelineCache struct {
+	entries map[pipelineKey]any
+}
+
+func newPipelineCache() *pipelineCache {
+	return &pipelineCache{entries: make(map[pipelineKey]any)}
+}
+
+// invalidate drops every entry, e.g. when sort inputs outside the snapshot
+// (I/O counters, security labels, containers) change.
+func (c *pipelineCache) invalidate() {
+	if c == nil {
+		return
+	}
+	clear(c.entries)
+}
+
+// memoize returns the cached value for key, computing and storing it on a miss.
+func memoize[T any](c *pipelineCache, key pipelineKey, compute func() T) T {
+	if c == nil {
+		return compute()
+	}
+	if v, ok := c.entries[key].(T); ok {
+		return v
+	}
+	v := compute()
+	if len(c.entries) >= maxPipelineEntries {
+		clear(c.entries)
+	}
+	c.entries[key] = v
+	return v
+}
+
+func (m Model) pipelineKey(kind ViewLevel, processName string) pipelineKey {
+	key := pipelineKey{
+		snapshot:    m.snapshot,
+		kind:        kind,
+		processName: processName,
+		filters:     strings.Join(m.currentFilters(), "\x00"),
+		cliFilter:   m.cliFilter,
+		matchList:   m.matchList,
+	}
+	if view := m.CurrentView(); view != nil {
+		key.sortColumn = view.SortColumn
+		key.sortAsc = view.SortAscending
+	}
+	return key
+}
+
+// visibleApps returns the filtered, sorted process list rows (without virtual containers).
+func (m Model) visibleApps() []model.Application {
+	return memoize(m.pipeline, m.pipelineKey(LevelProcessList, ""), func() []model.Application {
+		return m.rankFuzzy(m.sortProcessList(m.filteredApps()))
+	})
+}
+
+// visibleConnections returns the filtered, sorted connections of app for the drill-down view.
+func (m Model) visibleConnections(app *model.Application) []model.Connection {
+	return memoize(m.pipeline, m.pipelineKey(LevelConnections, app.Name), func() []model.Connection {
+		return m.sortConnectionsForView(m.filteredConnections(app.Connections))
+	})
+}
+
+// visibleAllConnections returns the filtered, sorted rows of the flat connections view.
+func (m Model) visibleAllConnections() []connectionWithProcess {
+	return memoize(m.pipeline, m.pipelineKey(LevelAllConnections, ""), func() []connectionWithProcess {
+		proxies := m.dockerProxies()
+		conns := m.sortAllConnections(m.collapseDockerProxy(m.filteredAllConnections(), proxies))
+		return m.expandProxyLegs(conns, proxies)
+	})
+}
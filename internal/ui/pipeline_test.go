@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+func TestMemoize_HitsAndMisses(t *testing.T) {
+	c := newPipelineCache()
+	calls := 0
+	compute := func() []int { calls++; return []int{calls} }
+
+	k := pipelineKey{filters: "a"}
+	memoize(c, k, compute)
+	memoize(c, k, compute)
+	if calls != 1 {
+		t.Errorf("calls = %d, want second lookup served from cache", calls)
+	}
+	memoize(c, pipelineKey{filters: "b"}, compute)
+	if calls != 2 {
+		t.Errorf("calls = %d, want recompute for a different key", calls)
+	}
+	c.invalidate()
+	memoize(c, k, compute)
+	if calls != 3 {
+		t.Errorf("calls = %d, want recompute after invalidate", calls)
+	}
+
+	var nilCache *pipelineCache
+	memoize(nilCache, k, compute)
+	memoize(nilCache, k, compute)
+	if calls != 5 {
+		t.Errorf("calls = %d, want nil cache to always compute", calls)
+	}
+}
+
+func TestMemoize_Bounded(t *testing.T) {
+	c := newPipelineCache()
+	for i := 0; i < maxPipelineEntries*3; i++ {
+		memoize(c, pipelineKey{sortColumn: SortColumn(i)}, func() int { return i })
+	}
+	if len(c.entries) > maxPipelineEntries {
+		t.Errorf("entries = %d, want at most %d", len(c.entries), maxPipelineEntries)
+	}
+}
+
+// sameBacking reports whether two non-empty slices share their first element.
+func sameBacking[T any](a, b []T) bool {
+	return len(a) > 0 && len(b) > 0 && &a[0] == &b[0]
+}
+
+func TestVisibleAllConnections_Memoized(t *testing.T) {
+	m := bigSnapshotModel(100)
+	m.pipeline = newPipelineCache()
+
+	first := m.visibleAllConnections()
+	if !sameBacking(first, m.visibleAllConnections()) {
+		t.Error("repeated call with unchanged inputs should reuse the cached result")
+	}
+
+	m.CurrentView().SortAscending = !m.CurrentView().SortAscending
+	flipped := m.visibleAllConnections()
+	if sameBacking(first, flipped) {
+		t.Error("changing sort direction should recompute")
+	}
+	if flipped[0].LocalAddr != first[len(first)-1].LocalAddr {
+		t.Errorf("reversed order first = %s, want %s", flipped[0].LocalAddr, first[len(first)-1].LocalAddr)
+	}
+
+	m.filterChips = []string{"10.0.0.1:10042"}
+	if got := m.visibleAllConnections(); len(got) != 1 {
+		t.Errorf("filtered rows = %d, want 1", len(got))
+	}
+}
+
+func TestVisibleAllConnections_NewSnapshotRecomputes(t *testing.T) {
+	m := bigSnapshotModel(10)
+	before := m.visibleAllConnections()
+
+	snap := *m.snapshot
+	snap.Applications = []model.Application{{Name: "other", PIDs: []int32{7}, Connections: []model.Connection{{PID: 7, Protocol: model.ProtocolUDP, LocalAddr: "0.0.0.0:53"}}}}
+	updated, _ := m.update(DataMsg{Snapshot: &snap})
+	m = updated.(Model)
+
+	after := m.visibleAllConnections()
+	if len(after) != 1 || sameBacking(before, after) {
+		t.Errorf("rows after new snapshot = %d, want 1 freshly computed", len(after))
+	}
+}
+
+func TestVisibleApps_NetIOInvalidates(t *testing.T) {
+	m := createTestModel()
+	m.pipeline = newPipelineCache()
+	m.CurrentView().SortColumn = SortTX
+	m.CurrentView().SortAscending = false
+
+	if got := m.visibleApps()[0].Name; got != "App3" {
+		t.Fatalf("first app = %s, want App3 (descending name tiebreak with no I/O)", got)
+	}
+	updated, _ := m.update(NetIOMsg{Stats: map[int32]*model.NetIOStats{100: {BytesSent: 1 << 20}}})
+	m = updated.(Model)
+	if got := m.visibleApps()[0].Name; got != "App1" {
+		t.Errorf("first app after I/O update = %s, want App1", got)
+	}
+}
+
+func TestNavigation_DoesNotResort(t *testing.T) {
+	m := bigSnapshotModel(1000)
+	m.pipeline = newPipelineCache()
+	rows := m.visibleAllConnections()
+
+	for i := 0; i < 5; i++ {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+		m = updated.(Model)
+	}
+	if !sameBacking(rows, m.visibleAllConnections()) {
+		t.Error("cursor movement should not invalidate the sorted rows")
+	}
+	if m.CurrentView().Cursor != 5 {
+		t.Errorf("cursor = %d, want 5", m.CurrentView().Cursor)
+	}
+}
+
+func benchmarkNavigate(b *testing.B, cached bool) {
+	m := bigSnapshotModel(10000)
+	if !cached {
+		m.pipeline = nil
+	}
+	down := tea.KeyMsg{Type: tea.KeyDown}
+	up := tea.KeyMsg{Type: tea.KeyUp}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		key := down
+		if i%2 == 1 {
+			key = up
+		}
+		updated, _ := m.Update(key)
+		m = updated.(Model)
+	}
+}
+
+// Cursor movement over 10k connections, with and without the pipeline cache.
+func BenchmarkNavigate_Cached(b *testing.B)   { benchmarkNavigate(b, true) }
+func BenchmarkNavigate_Uncached(b *testing.B) { benchmarkNavigate(b, false) }
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kostyay/netmon/internal/config"
+)
+
+// Plain mode (--plain) is for limited terminals, serial consoles and
+// copy-pasting into tickets: ASCII frames, no colors or animations, and
+// reverse video for the selected row. Like the theme it is process-wide, set
+// once before the model is created.
+
+// plainMode reports whether plain rendering is on.
+var plainMode bool
+
+// SetPlain turns plain rendering on or off. Call before NewModel.
+func SetPlain(on bool) {
+	plainMode = on
+}
+
+// themeColor returns the theme color c, or no color in plain mode. Text
+// attributes (bold, reverse) still apply.
+func themeColor(c config.Color) lipgloss.TerminalColor {
+	if plainMode {
+		return lipgloss.NoColor{}
+	}
+	return lipgloss.Color(c)
+}
+
+// frameGlyphs are the characters a box is drawn with.
+type frameGlyphs struct {
+	topLeft, topRight, bottomLeft, bottomRight, horizontal, vertical string
+}
+
+var (
+	doubleFrame = frameGlyphs{"╔", "╗", "╚", "╝", "═", "║"} // header
+	roundFrame  = frameGlyphs{"╭", "╮", "╰", "╯", "─", "│"} // main table
+	heavyFrame  = frameGlyphs{"┏", "┓", "┗", "┛", "━", "┃"} // modals
+	asciiFrame  = frameGlyphs{"+", "+", "+", "+", "-", "|"} // plain mode
+)
+
+// frame returns g, or ASCII glyphs in plain mode.
+func frame(g frameGlyphs) frameGlyphs {
+	if plainMode {
+		return asciiFrame
+	}
+	return g
+}
+
+// scrollGlyphs returns the scrollbar track and thumb characters.
+func scrollGlyphs() (track, thumb string) {
+	if plainMode {
+		return "|", "#"
+	}
+	return scrollTrack, scrollThumb
+}
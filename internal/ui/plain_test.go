@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// withPlain turns plain mode on for the test.
+func withPlain(t *testing.T) {
+	t.Helper()
+	SetPlain(true)
+	t.Cleanup(func() { SetPlain(false) })
+}
+
+func TestPlain_NoBoxDrawing(t *testing.T) {
+	withPlain(t)
+	m := manyAppsModel(100)
+	out := m.View()
+
+	if strings.ContainsAny(out, "╔╗╚╝═║╭╮╰╯─│┏┓┗┛━┃") {
+		t.Errorf("plain view contains box drawing:\n%s", out)
+	}
+	if !strings.Contains(out, "+-") || !strings.Contains(out, "#") {
+		t.Error("plain view should draw ASCII frames and scrollbar")
+	}
+}
+
+func TestPlain_NoColorsReverseSelection(t *testing.T) {
+	withPlain(t)
+	sel := SelectedConnStyle()
+	if !sel.GetReverse() || sel.GetBackground() != (lipgloss.NoColor{}) {
+		t.Error("selected row should be reverse video without colors")
+	}
+	for name, s := range map[string]lipgloss.Style{"warn": WarnStyle(), "header": TableHeaderStyle(), "error": ErrorStyle()} {
+		if s.GetForeground() != (lipgloss.NoColor{}) {
+			t.Errorf("%s style has foreground %v, want none", name, s.GetForeground())
+		}
+	}
+}
+
+func TestPlain_TabBarSeparator(t *testing.T) {
+	withPlain(t)
+	m := chipsTestModel()
+	m = pressKey(m, "T")
+	if bar := stripAnsi(m.renderTabBar()); !strings.Contains(bar, "|") || strings.Contains(bar, "│") {
+		t.Errorf("tab bar = %q, want an ASCII separator", bar)
+	}
+}
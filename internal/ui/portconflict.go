@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/docker"
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// portConflict is a host process bound to a port that a container publishes,
+// or will publish once started. Whichever side bound first wins: the
+// container's start fails with "port is already allocated", or traffic for
+// the published port reaches the host process instead of the container.
+type portConflict struct {
+	container model.ContainerInfo
+	mapping   model.PortMapping
+	created   bool // the container is created but not started
+	process   string
+	conn      model.Connection // the host process's socket
+}
+
+// key identifies the conflict across refreshes, to toast it only once.
+func (c portConflict) key() string {
+	return c.container.ID + "|" + c.process + "|" + strconv.Itoa(c.mapping.HostPort) + "/" + c.mapping.Protocol
+}
+
+// String describes the conflict, e.g. "port 8080/tcp: nginx (PID 812) and
+// container web".
+func (c portConflict) String() string {
+	s := fmt.Sprintf("port %d/%s: %s (PID %d) and container %s", c.mapping.HostPort,
+		strings.ToLower(c.mapping.Protocol), c.process, c.conn.PID, c.container.Name)
+	if c.created {
+		s += " (not started)"
+	}
+	return s
+}
+
+// mappingCollides reports whether the host socket conn is bound where pm
+// publishes: same port and protocol, and overlapping addresses (either side
+// on a wildcard address, or the same address).
+func mappingCollides(pm model.PortMapping, conn model.Connection) bool {
+	if !conn.IsBound() || model.ExtractPort(conn.LocalAddr) != pm.HostPort {
+		return false
+	}
+	if pm.Protocol != "" && !strings.EqualFold(pm.Protocol, string(conn.Protocol)) {
+		return false
+	}
+	switch pm.HostIP {
+	case "", "0.0.0.0", "::":
+		return true
+	}
+	if conn.BindScope() == model.BindWildcard {
+		return true
+	}
+	host := conn.LocalAddr[:strings.LastIndex(conn.LocalAddr, ":")]
+	return strings.Trim(host, "[]") == pm.HostIP
+}
+
+// findPortConflicts matches the bound sockets of host processes against the
+// port mappings of running and created containers. Docker's own processes
+// (docker-proxy, com.docker.backend) hold published ports legitimately.
+func (m Model) findPortConflicts() []portConflict {
+	if m.snapshot == nil || len(m.virtualContainers)+len(m.createdContainers) == 0 {
+		return nil
+	}
+	var conflicts []portConflict
+	check := func(vcs []model.VirtualContainer, created bool) {
+		for _, vc := range vcs {
+			for _, pm := range vc.PortMappings {
+				for _, app := range m.snapshot.Applications {
+					if docker.IsDockerProcess(app.Name) {
+						continue
+					}
+					for _, conn := range app.Connections {
+						if mappingCollides(pm, conn) {
+							conflicts = append(conflicts, portConflict{
+								container: vc.Info, mapping: pm, created: created, process: app.Name, conn: conn,
+							})
+						}
+					}
+				}
+			}
+		}
+	}
+	check(m.virtualContainers, false)
+	check(m.createdContainers, true)
+	return conflicts
+}
+
+// refreshPortConflicts recomputes the port conflicts after new connection or
+// Docker data, and toasts the ones not seen in the previous refresh.
+func (m *Model) refreshPortConflicts() tea.Cmd {
+	conflicts := m.findPortConflicts()
+	seen := make(map[string]bool, len(conflicts))
+	var fresh []portConflict
+	for _, c := range conflicts {
+		if !m.portConflictsSeen[c.key()] && !seen[c.key()] {
+			fresh = append(fresh, c)
+		}
+		seen[c.key()] = true
+	}
+	m.portConflicts = conflicts
+	m.portConflictsSeen = seen
+
+	switch {
+	case len(fresh) == 1:
+		return m.notify(toastWarn, fmt.Sprintf(i18n.T("Docker port conflict: %s"), fresh[0]))
+	case len(fresh) > 1:
+		return m.notify(toastWarn, fmt.Sprintf(i18n.T("%d Docker port conflicts; see the port mappings view (P)"), len(fresh)))
+	}
+	return nil
+}
+
+// conflictingSocket reports whether conn is a host socket in a port conflict.
+func (m Model) conflictingSocket(conn model.Connection) bool {
+	key := KeyFromConnection(conn)
+	return slices.ContainsFunc(m.portConflicts, func(c portConflict) bool {
+		return KeyFromConnection(c.conn) == key
+	})
+}
+
+// hasPortConflict reports whether any of pids holds a conflicting socket.
+func (m Model) hasPortConflict(pids []int32) bool {
+	for _, c := range m.portConflicts {
+		if slices.Contains(pids, c.conn.PID) {
+			return true
+		}
+	}
+	return false
+}
+
+// containerHasConflict reports whether a host process holds a port the
+// container publishes.
+func (m Model) containerHasConflict(id string) bool {
+	return slices.ContainsFunc(m.portConflicts, func(c portConflict) bool { return c.container.ID == id })
+}
+
+// mappingConflict returns the host processes bound where a container's
+// mapping publishes, joined for the Status column.
+func (m Model) mappingConflict(ci model.ContainerInfo, pm model.PortMapping) string {
+	var names []string
+	for _, c := range m.portConflicts {
+		if c.container.ID == ci.ID && c.mapping == pm && !slices.Contains(names, c.process) {
+			names = append(names, c.process)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// portConflictLine returns the conflicts of an app's sockets for the
+// connections view, or "".
+func (m Model) portConflictLine(pids []int32) string {
+	var parts []string
+	for _, c := range m.portConflicts {
+		if slices.Contains(pids, c.conn.PID) {
+			parts = append(parts, c.String())
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "⚠ Docker port conflict: " + strings.Join(parts, "; ")
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// conflictModel adds host processes to dockerPortsModel: nginx on the
+// wildcard 8443 that web publishes on 127.0.0.1, and a dev server on
+// 127.0.0.1:3000 that the created "api" container will publish.
+func conflictModel() Model {
+	m := dockerPortsModel()
+	m.snapshot.Applications = append(m.snapshot.Applications,
+		model.Application{Name: "nginx", PIDs: []int32{812}, Connections: []model.Connection{
+			{PID: 812, Protocol: model.ProtocolTCP, LocalAddr: "0.0.0.0:8443", RemoteAddr: "*:*", State: model.StateListen},
+			{PID: 812, Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.5:8443", RemoteAddr: "10.0.0.9:52000", State: model.StateEstablished},
+		}},
+		model.Application{Name: "node", PIDs: []int32{900}, Connections: []model.Connection{
+			{PID: 900, Protocol: model.ProtocolTCP, LocalAddr: "127.0.0.1:3000", RemoteAddr: "*:*", State: model.StateListen},
+			{PID: 900, Protocol: model.ProtocolUDP, LocalAddr: "127.0.0.1:6379", RemoteAddr: "*", State: model.StateNone},
+		}},
+	)
+	m.createdContainers = []model.VirtualContainer{{
+		Info:         model.ContainerInfo{Name: "api", Image: "api:dev", ID: "ghi"},
+		PortMappings: []model.PortMapping{{HostPort: 3000, ContainerPort: 3000, Protocol: "tcp", HostIP: "0.0.0.0"}},
+	}}
+	return m
+}
+
+func TestMappingCollides(t *testing.T) {
+	listen := func(addr string) model.Connection {
+		return model.Connection{Protocol: model.ProtocolTCP, LocalAddr: addr, RemoteAddr: "*:*", State: model.StateListen}
+	}
+	any8080 := model.PortMapping{HostPort: 8080, Protocol: "tcp", HostIP: "0.0.0.0"}
+	lo8080 := model.PortMapping{HostPort: 8080, Protocol: "tcp", HostIP: "127.0.0.1"}
+	for _, tt := range []struct {
+		pm   model.PortMapping
+		conn model.Connection
+		want bool
+	}{
+		{any8080, listen("127.0.0.1:8080"), true},
+		{lo8080, listen("[::]:8080"), true},
+		{lo8080, listen("127.0.0.1:8080"), true},
+		{lo8080, listen("192.168.1.4:8080"), false},
+		{any8080, listen("0.0.0.0:8081"), false},
+		{model.PortMapping{HostPort: 8080, Protocol: "udp"}, listen("0.0.0.0:8080"), false},
+		{any8080, model.Connection{Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.2:8080", RemoteAddr: "10.0.0.9:4000", State: model.StateEstablished}, false},
+	} {
+		if got := mappingCollides(tt.pm, tt.conn); got != tt.want {
+			t.Errorf("mappingCollides(%+v, %s) = %v, want %v", tt.pm, tt.conn.LocalAddr, got, tt.want)
+		}
+	}
+}
+
+func TestPortConflicts_FlagBothSides(t *testing.T) {
+	m := conflictModel()
+	cmd := m.refreshPortConflicts()
+
+	if len(m.portConflicts) != 2 {
+		t.Fatalf("conflicts = %v, want nginx/web and node/api", m.portConflicts)
+	}
+	if cmd == nil || len(m.toasts) != 1 || !strings.Contains(m.toasts[0].Message, "2 Docker port conflicts") {
+		t.Errorf("new conflicts should be toasted once: %+v", m.toasts)
+	}
+
+	status := map[int]string{}
+	for _, r := range m.portMappingRows() {
+		status[r.mapping.HostPort] = r.status()
+	}
+	if status[8443] != "⚠ nginx" || status[3000] != "⚠ node" || status[8080] != "2 conns" {
+		t.Errorf("status = %v", status)
+	}
+
+	nginx := m.snapshot.Applications[1]
+	if !m.hasPortConflict(nginx.PIDs) || !m.containerHasConflict("abc") || m.containerHasConflict("def") {
+		t.Error("nginx and the web container should both be flagged, cache should not")
+	}
+	if !m.rowMark(nginx.Connections[0]).conflict || m.rowMark(nginx.Connections[1]).conflict {
+		t.Error("only the listening socket is in conflict")
+	}
+	if got := m.portConflictLine([]int32{900}); got != "⚠ Docker port conflict: port 3000/tcp: node (PID 900) and container api (not started)" {
+		t.Errorf("portConflictLine = %q", got)
+	}
+
+	if m.refreshPortConflicts(); len(m.toasts) != 1 {
+		t.Error("known conflicts should not be toasted again")
+	}
+}
+
+func TestPortConflicts_IgnoreDockerProcesses(t *testing.T) {
+	m := dockerPortsModel()
+	if m.refreshPortConflicts(); len(m.portConflicts) != 0 {
+		t.Errorf("docker-proxy holding published ports is no conflict: %v", m.portConflicts)
+	}
+}
@@ -0,0 +1,173 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// portWatch is a port whose listening state raises an alert when it changes,
+// e.g. waiting for a dev server to come up or for a service to release it.
+type portWatch struct {
+	port      int
+	listening bool
+	owner     string // process holding the port while listening
+	known     bool   // state seen in a snapshot; the first one never alerts
+}
+
+// alertTerminal rings the terminal bell and sends an OSC 9 desktop
+// notification (iTerm2, kitty, WezTerm, Windows Terminal; others ignore it).
+// Replaced in tests.
+var alertTerminal = func(msg string) {
+	fmt.Fprint(os.Stderr, "\a\x1b]9;netmon: "+msg+"\a")
+}
+
+// WithWatchedPorts returns a Model that alerts when any of ports starts or
+// stops listening (--watch-port).
+func (m Model) WithWatchedPorts(ports []int) Model {
+	for _, p := range ports {
+		m.addPortWatch(p)
+	}
+	return m
+}
+
+// addPortWatch starts watching port unless it already is.
+func (m *Model) addPortWatch(port int) {
+	if m.watchIndex(port) >= 0 {
+		return
+	}
+	m.portWatches = append(m.portWatches, portWatch{port: port})
+	slices.SortFunc(m.portWatches, func(a, b portWatch) int { return a.port - b.port })
+	if m.snapshot != nil {
+		m.updatePortWatches(m.snapshot) // prime so the current state doesn't alert
+	}
+}
+
+// watchIndex returns the index of port in portWatches, or -1.
+func (m Model) watchIndex(port int) int {
+	return slices.IndexFunc(m.portWatches, func(w portWatch) bool { return w.port == port })
+}
+
+// watchTargetPort returns the port the watch key acts on: the selected bind
+// row or connection's local port, else a port filter such as ":3000" (the
+// way to watch a port nothing holds yet).
+func (m Model) watchTargetPort() (int, bool) {
+	if view := m.CurrentView(); view != nil && view.Level == LevelBinds {
+		rows := m.sortBinds(m.filteredBinds())
+		if view.Cursor >= 0 && view.Cursor < len(rows) {
+			if p := model.ExtractPort(rows[view.Cursor].conn.LocalAddr); p > 0 {
+				return p, true
+			}
+		}
+	}
+	if conn := m.selectedConnection(); conn != nil {
+		if p := model.ExtractPort(conn.LocalAddr); p > 0 {
+			return p, true
+		}
+	}
+	for _, f := range append([]string{m.searchQuery}, m.currentFilters()...) {
+		if p, ok := portQuery(f); ok {
+			return p, true
+		}
+	}
+	return 0, false
+}
+
+// togglePortWatch watches, or stops watching, the port under the cursor.
+func (m Model) togglePortWatch() (tea.Model, tea.Cmd) {
+	port, ok := m.watchTargetPort()
+	if !ok {
+		return m, m.notify(toastWarn, i18n.T("No port to watch: select a socket or filter by port (p 3000)"))
+	}
+	if i := m.watchIndex(port); i >= 0 {
+		m.portWatches = slices.Delete(m.portWatches, i, i+1)
+		return m, m.notify(toastInfo, fmt.Sprintf(i18n.T("Stopped watching port %d"), port))
+	}
+	m.addPortWatch(port)
+	state := i18n.T("free")
+	if w := m.portWatches[m.watchIndex(port)]; w.listening {
+		state = fmt.Sprintf(i18n.T("listening (%s)"), w.owner)
+	}
+	return m, m.notify(toastInfo, fmt.Sprintf(i18n.T("Watching port %d, now %s"), port, state))
+}
+
+// checkPortWatches updates the watched ports from snap and returns toasts and
+// a terminal alert for those that started or stopped listening.
+func (m *Model) checkPortWatches(snap *model.NetworkSnapshot) tea.Cmd {
+	changes := m.updatePortWatches(snap)
+	if len(changes) == 0 {
+		return nil
+	}
+	cmds := make([]tea.Cmd, 0, len(changes)+1)
+	for _, msg := range changes {
+		cmds = append(cmds, m.notify(toastWarn, msg))
+	}
+	return tea.Batch(append(cmds, terminalAlertCmd(strings.Join(changes, "; ")))...)
+}
+
+// updatePortWatches records each watched port's state in snap and describes
+// the changes, e.g. "Port 3000 is now listening (node)".
+func (m *Model) updatePortWatches(snap *model.NetworkSnapshot) []string {
+	if len(m.portWatches) == 0 || snap == nil {
+		return nil
+	}
+	owners := make(map[int]string)
+	for _, app := range snap.Applications {
+		for _, conn := range app.Connections {
+			if !conn.IsBound() {
+				continue
+			}
+			if p := model.ExtractPort(conn.LocalAddr); p > 0 {
+				if _, seen := owners[p]; !seen {
+					owners[p] = app.Name
+				}
+			}
+		}
+	}
+
+	var changes []string
+	for i := range m.portWatches {
+		w := &m.portWatches[i]
+		owner, listening := owners[w.port]
+		changed := w.known && listening != w.listening
+		prevOwner := w.owner
+		w.listening, w.owner, w.known = listening, owner, true
+		switch {
+		case !changed:
+		case listening:
+			changes = append(changes, fmt.Sprintf(i18n.T("Port %d is now listening (%s)"), w.port, owner))
+		default:
+			changes = append(changes, fmt.Sprintf(i18n.T("Port %d is now free (was %s)"), w.port, prevOwner))
+		}
+	}
+	return changes
+}
+
+// terminalAlertCmd returns a command sending msg through alertTerminal.
+func terminalAlertCmd(msg string) tea.Cmd {
+	return func() tea.Msg {
+		alertTerminal(msg)
+		return nil
+	}
+}
+
+// portWatchLabel returns the header summary of watched ports, e.g.
+// ":3000 free  :8080 up", or "" when none are watched.
+func (m Model) portWatchLabel() string {
+	parts := make([]string, 0, len(m.portWatches))
+	for _, w := range m.portWatches {
+		state := i18n.T("free")
+		if w.listening {
+			state = i18n.T("up")
+		}
+		parts = append(parts, ":"+strconv.Itoa(w.port)+" "+state)
+	}
+	return strings.Join(parts, "  ")
+}
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// listenSnapshot returns a snapshot where app listens on each of ports.
+func listenSnapshot(app string, ports ...string) *model.NetworkSnapshot {
+	var conns []model.Connection
+	for _, p := range ports {
+		conns = append(conns, model.Connection{Protocol: model.ProtocolTCP, LocalAddr: "127.0.0.1:" + p, RemoteAddr: "*", State: model.StateListen, PID: 10})
+	}
+	return &model.NetworkSnapshot{Applications: []model.Application{{Name: app, PIDs: []int32{10}, Connections: conns}}}
+}
+
+func TestPortWatch_AlertsOnChange(t *testing.T) {
+	m := createTestModel().WithWatchedPorts([]int{3000})
+
+	// The first snapshot only records the state
+	if got := m.updatePortWatches(listenSnapshot("node")); len(got) != 0 {
+		t.Fatalf("first snapshot changes = %v, want none", got)
+	}
+	if got := m.updatePortWatches(listenSnapshot("node", "3000")); len(got) != 1 || got[0] != "Port 3000 is now listening (node)" {
+		t.Errorf("changes = %v, want port 3000 listening", got)
+	}
+	if got := m.updatePortWatches(listenSnapshot("node", "3000")); len(got) != 0 {
+		t.Errorf("changes = %v, want none without a change", got)
+	}
+	if got := m.updatePortWatches(listenSnapshot("node")); len(got) != 1 || got[0] != "Port 3000 is now free (was node)" {
+		t.Errorf("changes = %v, want port 3000 free", got)
+	}
+}
+
+func TestCheckPortWatches_ToastAndAlert(t *testing.T) {
+	m := createTestModel().WithWatchedPorts([]int{3000, 8080})
+	m.updatePortWatches(listenSnapshot("node", "8080"))
+
+	if cmd := m.checkPortWatches(listenSnapshot("node", "8080")); cmd != nil {
+		t.Error("no change should return no command")
+	}
+	if cmd := m.checkPortWatches(listenSnapshot("node", "3000")); cmd == nil {
+		t.Fatal("changes should return a command")
+	}
+	if len(m.toasts) != 2 {
+		t.Errorf("toasts = %+v, want one per changed port", m.toasts)
+	}
+
+	var alerts []string
+	orig := alertTerminal
+	alertTerminal = func(msg string) { alerts = append(alerts, msg) }
+	t.Cleanup(func() { alertTerminal = orig })
+	terminalAlertCmd("Port 3000 is now listening (node)")()
+	if len(alerts) != 1 {
+		t.Errorf("alerts = %v, want one", alerts)
+	}
+}
+
+func TestPortWatch_ToggleFromPortFilter(t *testing.T) {
+	m := createTestModel()
+	m.snapshot = listenSnapshot("node")
+	m.filterChips = []string{":3000"}
+
+	m = pressKey(m, KeyWatchPort.Key)
+	if len(m.portWatches) != 1 || m.portWatches[0].port != 3000 || !m.portWatches[0].known {
+		t.Fatalf("watches = %+v, want primed watch on 3000", m.portWatches)
+	}
+	if !strings.Contains(m.toasts[0].Message, "Watching port 3000, now free") {
+		t.Errorf("toast = %q", m.toasts[0].Message)
+	}
+
+	m = pressKey(m, KeyWatchPort.Key)
+	if len(m.portWatches) != 0 {
+		t.Errorf("watches = %+v, want toggled off", m.portWatches)
+	}
+}
+
+func TestPortWatch_ToggleSelectedBind(t *testing.T) {
+	m := createTestModel()
+	m.snapshot = listenSnapshot("postgres", "5432")
+	m.stack = []ViewState{m.newViewState(LevelBinds, "")}
+
+	m = pressKey(m, KeyWatchPort.Key)
+	if len(m.portWatches) != 1 || m.portWatches[0].port != 5432 || !m.portWatches[0].listening {
+		t.Errorf("watches = %+v, want listening watch on 5432", m.portWatches)
+	}
+	if got := m.portWatchLabel(); got != ":5432 up" {
+		t.Errorf("label = %q, want \":5432 up\"", got)
+	}
+}
+
+func TestPortWatch_NothingToWatch(t *testing.T) {
+	m := createTestModel()
+	m = pressKey(m, KeyWatchPort.Key)
+	if len(m.portWatches) != 0 || len(m.toasts) != 1 || m.toasts[0].Severity != toastWarn {
+		t.Errorf("watches = %+v toasts = %+v, want warning only", m.portWatches, m.toasts)
+	}
+}
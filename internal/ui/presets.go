@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/kostyay/netmon/internal/config"
+	"github.com/kostyay/netmon/internal/i18n"
+)
+
+// presetsModalWidth is the width of the filter presets picker.
+const presetsModalWidth = 56
+
+// openPresets shows the presets picker, selecting the preset matching the active filter.
+func (m *Model) openPresets() {
+	m.presetsMode = true
+	m.presetNaming = false
+	m.presetName = ""
+	m.presetsCursor = 0
+	for i, p := range m.presets {
+		if slices.Equal(p.Chips(), m.filterChips) {
+			m.presetsCursor = i
+			break
+		}
+	}
+}
+
+// handlePresetsKey handles a key press while the presets picker is open.
+func (m *Model) handlePresetsKey(msg tea.KeyMsg) tea.Cmd {
+	key := msg.String()
+
+	if m.presetNaming {
+		switch {
+		case matchKey(key, KeyEnter):
+			return m.savePreset(m.presetName, m.filterChips)
+		case matchKey(key, KeyEsc):
+			m.presetNaming = false
+			m.presetName = ""
+		case matchKey(key, KeyBack):
+			if len(m.presetName) > 0 {
+				m.presetName = m.presetName[:len(m.presetName)-1]
+			}
+		default:
+			if r := msg.Runes; len(r) == 1 && r[0] >= 32 {
+				m.presetName += string(r)
+			}
+		}
+		return nil
+	}
+
+	switch {
+	case matchKey(key, KeyEsc, KeyQuit, KeyPresets):
+		m.presetsMode = false
+	case matchKey(key, KeyUp, KeyUpAlt):
+		if m.presetsCursor > 0 {
+			m.presetsCursor--
+		}
+	case matchKey(key, KeyDown, KeyDownAlt):
+		if m.presetsCursor < len(m.presets)-1 {
+			m.presetsCursor++
+		}
+	case matchKey(key, KeyEnter, KeySpace):
+		return m.applyPreset(m.presetsCursor)
+	case matchKey(key, KeyPresetSave):
+		if len(m.filterChips) == 0 {
+			return m.notify(toastWarn, i18n.T("No active filter to save (use / first)"))
+		}
+		m.presetNaming = true
+		m.presetName = ""
+	case matchKey(key, KeyPresetDelete):
+		return m.deletePreset(m.presetsCursor)
+	}
+	return nil
+}
+
+// applyPreset sets the preset at idx as the active filter and closes the picker.
+func (m *Model) applyPreset(idx int) tea.Cmd {
+	if idx < 0 || idx >= len(m.presets) {
+		return nil
+	}
+	p := m.presets[idx]
+	m.filterChips = slices.Clone(p.Chips())
+	m.presetsMode = false
+	m.clampCursor()
+	return m.notify(toastInfo, fmt.Sprintf(i18n.T("Filter: %s"), p.Name))
+}
+
+// savePreset stores the filter chips under name, replacing a preset with the same name, and persists.
+func (m *Model) savePreset(name string, filters []string) tea.Cmd {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return m.notify(toastWarn, i18n.T("Preset name cannot be empty"))
+	}
+	m.presetNaming = false
+	m.presetName = ""
+
+	idx := -1
+	for i, p := range m.presets {
+		if p.Name == name {
+			idx = i
+			break
+		}
+	}
+	if idx >= 0 {
+		m.presets[idx] = config.NewFilterPreset(name, filters)
+	} else {
+		m.presets = append(m.presets, config.NewFilterPreset(name, filters))
+		idx = len(m.presets) - 1
+	}
+	m.presetsCursor = idx
+	m.persistPresets()
+	return m.notify(toastSuccess, fmt.Sprintf(i18n.T("Saved preset %q"), name))
+}
+
+// deletePreset removes the preset at idx and persists.
+func (m *Model) deletePreset(idx int) tea.Cmd {
+	if idx < 0 || idx >= len(m.presets) {
+		return nil
+	}
+	name := m.presets[idx].Name
+	m.presets = append(m.presets[:idx], m.presets[idx+1:]...)
+	if m.presetsCursor >= len(m.presets) && m.presetsCursor > 0 {
+		m.presetsCursor--
+	}
+	m.persistPresets()
+	return m.notify(toastInfo, fmt.Sprintf(i18n.T("Deleted preset %q"), name))
+}
+
+// persistPresets writes the presets to settings.yaml.
+func (m *Model) persistPresets() {
+	config.CurrentSettings.FilterPresets = append([]config.FilterPreset(nil), m.presets...)
+	_ = config.SaveSettings(config.CurrentSettings)
+}
+
+// renderPresetsModalContent returns the presets picker content.
+func (m Model) renderPresetsModalContent() string {
+	var lines []string
+
+	if len(m.presets) == 0 {
+		lines = append(lines, DimmedStyle().Render(i18n.T("No saved presets")))
+	}
+	nameWidth := 0
+	for _, p := range m.presets {
+		nameWidth = min(max(nameWidth, lipgloss.Width(p.Name)), 20)
+	}
+	for i, p := range m.presets {
+		cursor := "  "
+		if i == m.presetsCursor {
+			cursor = "▸ "
+		}
+		row := cursor + padRight(truncateString(p.Name, nameWidth), nameWidth) + "  " + truncateString(strings.Join(p.Chips(), " + "), presetsModalWidth-nameWidth-10)
+		if i == m.presetsCursor {
+			row = SelectedConnStyle().Render(row)
+		}
+		lines = append(lines, row)
+	}
+
+	lines = append(lines, "")
+	keyStyle := FooterKeyStyle()
+	descStyle := FooterDescStyle()
+	if m.presetNaming {
+		lines = append(lines,
+			fmt.Sprintf("Save %q as: %s█", truncateString(strings.Join(m.filterChips, " + "), 20), m.presetName),
+			"",
+			keyStyle.Render("Enter")+descStyle.Render(" "+i18n.T("Save")+"  ")+keyStyle.Render("Esc")+descStyle.Render(" "+i18n.T("Cancel")),
+		)
+		return strings.Join(lines, "\n")
+	}
+	lines = append(lines,
+		keyStyle.Render("Enter")+descStyle.Render(" "+i18n.T("Apply")+"  ")+
+			keyStyle.Render(KeyPresetSave.Key)+descStyle.Render(" "+i18n.T("Save current")+"  ")+
+			keyStyle.Render(KeyPresetDelete.Key)+descStyle.Render(" "+i18n.T("Delete")+"  ")+
+			keyStyle.Render("Esc")+descStyle.Render(" "+i18n.T("Close")),
+	)
+	return strings.Join(lines, "\n")
+}
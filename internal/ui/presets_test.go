@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/config"
+)
+
+// isolatePresets keeps SaveSettings away from the real config and restores presets.
+func isolatePresets(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	orig := config.CurrentSettings.FilterPresets
+	t.Cleanup(func() { config.CurrentSettings.FilterPresets = orig })
+}
+
+func typeKeys(m Model, s string) Model {
+	for _, r := range s {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(Model)
+	}
+	return m
+}
+
+func pressSpecial(m Model, k tea.KeyType) Model {
+	updated, _ := m.Update(tea.KeyMsg{Type: k})
+	return updated.(Model)
+}
+
+func TestPresets_SaveCurrentFilter(t *testing.T) {
+	isolatePresets(t)
+	m := createTestModel()
+	m.filterChips = []string{"5432"}
+
+	m = typeKeys(m, "F")
+	if !m.presetsMode {
+		t.Fatal("F should open the presets picker")
+	}
+	m = typeKeys(m, "nprod-db")
+	if !m.presetNaming || m.presetName != "prod-db" {
+		t.Fatalf("naming = %v, name = %q", m.presetNaming, m.presetName)
+	}
+	m = pressSpecial(m, tea.KeyEnter)
+
+	if m.presetNaming {
+		t.Error("Enter should finish naming")
+	}
+	if len(m.presets) != 1 || m.presets[0].Filter != "5432" || m.presets[0].Filters != nil {
+		t.Errorf("presets = %+v", m.presets)
+	}
+	if got := config.CurrentSettings.FilterPresets; len(got) != 1 || got[0].Name != "prod-db" {
+		t.Errorf("persisted presets = %+v", got)
+	}
+}
+
+func TestPresets_SaveReplacesSameName(t *testing.T) {
+	isolatePresets(t)
+	m := createTestModel()
+	m.presets = []config.FilterPreset{{Name: "browsers", Filter: "chrome"}}
+
+	m.savePreset("browsers", []string{"firefox"})
+	if len(m.presets) != 1 || m.presets[0].Filter != "firefox" {
+		t.Errorf("presets = %+v, want browsers replaced", m.presets)
+	}
+}
+
+func TestPresets_SaveWithoutFilter(t *testing.T) {
+	m := createTestModel()
+	m = typeKeys(m, "Fn")
+	if m.presetNaming {
+		t.Error("saving should not start without an active filter")
+	}
+	if !strings.Contains(toastText(m), "No active filter") {
+		t.Errorf("toast = %q", toastText(m))
+	}
+}
+
+func TestPresets_Apply(t *testing.T) {
+	m := createTestModel()
+	m.presets = []config.FilterPreset{{Name: "a", Filter: "App1"}, {Name: "b", Filter: "443"}}
+
+	m = typeKeys(m, "Fj")
+	m = pressSpecial(m, tea.KeyEnter)
+
+	if m.presetsMode {
+		t.Error("applying should close the picker")
+	}
+	if !slices.Equal(m.filterChips, []string{"443"}) {
+		t.Errorf("filterChips = %q, want [443]", m.filterChips)
+	}
+}
+
+func TestPresets_Delete(t *testing.T) {
+	isolatePresets(t)
+	m := createTestModel()
+	m.presets = []config.FilterPreset{{Name: "a", Filter: "x"}, {Name: "b", Filter: "y"}}
+
+	m = typeKeys(m, "Fjd")
+
+	if len(m.presets) != 1 || m.presets[0].Name != "a" {
+		t.Errorf("presets = %+v, want only a", m.presets)
+	}
+	if m.presetsCursor != 0 {
+		t.Errorf("presetsCursor = %d, want 0 after deleting last", m.presetsCursor)
+	}
+}
+
+func TestPresets_OpenSelectsActiveFilter(t *testing.T) {
+	m := createTestModel()
+	m.presets = []config.FilterPreset{{Name: "a", Filter: "x"}, {Name: "b", Filter: "y"}}
+	m.filterChips = []string{"y"}
+	m.openPresets()
+	if m.presetsCursor != 1 {
+		t.Errorf("presetsCursor = %d, want 1", m.presetsCursor)
+	}
+}
+
+func TestPresets_EscCancelsNamingThenCloses(t *testing.T) {
+	m := createTestModel()
+	m.filterChips = []string{"x"}
+	m = typeKeys(m, "Fnab")
+	m = pressSpecial(m, tea.KeyEsc)
+	if m.presetNaming || !m.presetsMode {
+		t.Error("Esc while naming should cancel naming but keep the picker open")
+	}
+	m = pressSpecial(m, tea.KeyEsc)
+	if m.presetsMode {
+		t.Error("second Esc should close the picker")
+	}
+}
+
+func TestRenderPresetsModal(t *testing.T) {
+	m := createTestModel()
+	if !strings.Contains(m.renderPresetsModalContent(), "No saved presets") {
+		t.Error("empty picker should say so")
+	}
+	m.presets = []config.FilterPreset{{Name: "prod-db", Filter: "5432"}}
+	content := m.renderPresetsModalContent()
+	if !strings.Contains(content, "prod-db") || !strings.Contains(content, "5432") {
+		t.Errorf("picker should list preset name and filter, got:\n%s", content)
+	}
+}
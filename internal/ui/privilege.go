@@ -0,0 +1,31 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/privilege"
+)
+
+// WithPrivilege returns a copy of the model aware of what is hidden at the current
+// privilege level. Each limitation is also listed in the diagnostics panel.
+func (m Model) WithPrivilege(r privilege.Report) Model {
+	m.privilege = r
+	for _, l := range r.Limitations {
+		m.recordError(sourcePrivilege, fmt.Errorf("%s: %s (needs %s)", l.Feature, l.Impact, l.Needs))
+	}
+	return m
+}
+
+// partialViewBadge returns the header badge shown when sockets are hidden because
+// netmon is not running as root, or "" when the view is complete.
+func (m Model) partialViewBadge() string {
+	if !m.privilege.Partial() || m.snapshot == nil {
+		return ""
+	}
+	hidden := m.snapshot.HiddenCount + m.snapshot.SkippedCount
+	if hidden == 0 {
+		return ""
+	}
+	return fmt.Sprintf(i18n.T("partial view: %d sockets hidden (run with sudo)"), hidden)
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/privilege"
+)
+
+func partialReport() privilege.Report {
+	return privilege.Report{Limitations: []privilege.Limitation{
+		{Feature: "Socket ownership", Impact: "other users' sockets are hidden", Needs: "root"},
+	}}
+}
+
+func TestWithPrivilege_RecordsLimitations(t *testing.T) {
+	m := createTestModel().WithPrivilege(partialReport())
+	if len(m.diagLog) != 1 || m.diagLog[0].Source != sourcePrivilege {
+		t.Fatalf("diagLog = %+v, want one privilege entry", m.diagLog)
+	}
+	if !strings.Contains(m.diagLog[0].Message, "needs root") {
+		t.Errorf("message = %q, want requirement", m.diagLog[0].Message)
+	}
+}
+
+func TestPartialViewBadge(t *testing.T) {
+	m := createTestModel()
+	m.snapshot.HiddenCount = 120
+	m.snapshot.SkippedCount = 12
+	if badge := m.partialViewBadge(); badge != "" {
+		t.Errorf("badge without limitations = %q, want empty", badge)
+	}
+
+	m = m.WithPrivilege(partialReport())
+	if badge := m.partialViewBadge(); badge != "partial view: 132 sockets hidden (run with sudo)" {
+		t.Errorf("badge = %q", badge)
+	}
+
+	m.snapshot.HiddenCount, m.snapshot.SkippedCount = 0, 0
+	if badge := m.partialViewBadge(); badge != "" {
+		t.Errorf("badge with nothing hidden = %q, want empty", badge)
+	}
+
+	m = m.WithPrivilege(privilege.Report{Privileged: true})
+	m.snapshot.HiddenCount = 5
+	if badge := m.partialViewBadge(); badge != "" {
+		t.Errorf("badge as root = %q, want empty", badge)
+	}
+}
+
+func TestHeader_ShowsPartialViewBadge(t *testing.T) {
+	m := createTestModel().WithPrivilege(partialReport())
+	m.snapshot.HiddenCount = 3
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 30})
+	m = updated.(Model)
+	if out := m.renderHeader(); !strings.Contains(out, "partial view: 3 sockets hidden") {
+		t.Errorf("header should show partial view badge, got:\n%s", out)
+	}
+}
@@ -0,0 +1,271 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"syscall"
+
+	tea "github.com/charmbracelet/bubbletea"
+	gprocess "github.com/shirou/gopsutil/v3/process"
+
+	"github.com/kostyay/netmon/internal/audit"
+	"github.com/kostyay/netmon/internal/i18n"
+)
+
+// procAction is a triage action short of killing a process.
+type procAction int
+
+const (
+	actionRenice procAction = iota
+	actionSuspend
+	actionResume
+)
+
+// Nice values: renice defaults to reniceStep above the current value, within
+// the kernel's range. Raising priority (lower values) needs root.
+const (
+	minNice    = -20
+	maxNice    = 19
+	reniceStep = 10
+)
+
+// Replaced in tests.
+var (
+	// setPriority sets the nice value of a process.
+	setPriority = func(pid, nice int) error {
+		return syscall.Setpriority(syscall.PRIO_PROCESS, pid, nice)
+	}
+	// processNice returns the nice value of a process.
+	processNice = func(pid int32) (int, bool) {
+		p, err := gprocess.NewProcess(pid)
+		if err != nil {
+			return 0, false
+		}
+		nice, err := p.Nice()
+		if err != nil {
+			return 0, false
+		}
+		return int(nice), true
+	}
+	// processStopped reports whether a process is stopped (SIGSTOP).
+	processStopped = func(pid int32) bool {
+		p, err := gprocess.NewProcess(pid)
+		if err != nil {
+			return false
+		}
+		status, err := p.Status()
+		return err == nil && slices.Contains(status, gprocess.Stop)
+	}
+)
+
+// String returns the verb shown in modal titles.
+func (a procAction) String() string {
+	switch a {
+	case actionRenice:
+		return "Renice"
+	case actionSuspend:
+		return "Suspend"
+	case actionResume:
+		return "Resume"
+	}
+	return ""
+}
+
+// pastTense returns the verb shown in result toasts.
+func (a procAction) pastTense() string {
+	if a == actionSuspend {
+		return "Suspended"
+	}
+	return a.String() + "d"
+}
+
+// enterReniceMode opens the renice modal for the selected process, proposing a
+// lower priority than its current one.
+func (m Model) enterReniceMode() (tea.Model, tea.Cmd) {
+	target, cmd := m.selectedActionTarget()
+	if target == nil {
+		return m, cmd
+	}
+	current, _ := processNice(target.PID)
+	m.actionMode = true
+	m.action = actionRenice
+	m.actionTarget = target
+	m.actionNice = min(current+reniceStep, maxNice)
+	return m, nil
+}
+
+// enterSuspendMode opens the suspend modal for the selected process, or the
+// resume modal when it is already stopped.
+func (m Model) enterSuspendMode() (tea.Model, tea.Cmd) {
+	target, cmd := m.selectedActionTarget()
+	if target == nil {
+		return m, cmd
+	}
+	m.actionMode = true
+	m.action = actionSuspend
+	if processStopped(target.PID) {
+		m.action = actionResume
+	}
+	m.actionTarget = target
+	m.confirmTyped = ""
+	return m, nil
+}
+
+// needsConfirm reports whether the open modal stops a critical process, which
+// must be confirmed by typing its name. Renice and resume do not stop it.
+func (m Model) needsConfirm() bool {
+	return m.action == actionSuspend && m.actionTarget != nil && m.actionTarget.Critical
+}
+
+// selectedActionTarget returns the selected process for renice/suspend.
+// Containers are refused with a toast.
+func (m *Model) selectedActionTarget() (*killTargetInfo, tea.Cmd) {
+	target := m.selectedProcessTarget()
+	if target == nil {
+		return nil, nil
+	}
+	if target.ContainerID != "" {
+		return nil, m.notify(toastWarn, i18n.T("Not available for containers (use x to stop)"))
+	}
+	return target, nil
+}
+
+// handleActionKey handles a key press while the renice/suspend modal is open.
+func (m Model) handleActionKey(key string) (tea.Model, tea.Cmd) {
+	switch {
+	case matchKey(key, KeyEnter):
+		return m.executeAction()
+	case matchKey(key, KeyEsc):
+		m.closeAction()
+	case m.action == actionRenice && matchKey(key, KeyUp, KeyUpAlt, KeyRight, KeyRightAlt):
+		m.actionNice = min(m.actionNice+1, maxNice)
+	case m.action == actionRenice && matchKey(key, KeyDown, KeyDownAlt, KeyLeft, KeyLeftAlt):
+		m.actionNice = max(m.actionNice-1, minNice)
+	}
+	return m, nil
+}
+
+// closeAction closes the renice/suspend modal.
+func (m *Model) closeAction() {
+	m.actionMode = false
+	m.actionTarget = nil
+	m.confirmTyped = ""
+}
+
+// executeAction applies the action to every PID of the target and reports the
+// result as a toast.
+func (m Model) executeAction() (tea.Model, tea.Cmd) {
+	target := m.actionTarget
+	action := m.action
+	confirm := m.confirmTyped
+	m.closeAction()
+	if target == nil {
+		return m, nil
+	}
+
+	pids := target.PIDs
+	if len(pids) == 0 {
+		pids = []int32{target.PID}
+	}
+	var done, failed int
+	var lastErr error
+	for _, pid := range pids {
+		var err error
+		switch action {
+		case actionRenice:
+			err = setPriority(int(pid), m.actionNice)
+		case actionSuspend:
+			err = m.signalPID(target, pid, syscall.SIGSTOP, confirm)
+		case actionResume:
+			err = m.signalPID(target, pid, syscall.SIGCONT, confirm)
+		}
+		if err != nil {
+			failed++
+			lastErr = err
+		} else {
+			done++
+		}
+	}
+
+	signal := map[procAction]string{actionSuspend: "SIGSTOP", actionResume: "SIGCONT"}[action]
+	if action == actionRenice {
+		signal = fmt.Sprintf("nice %d", m.actionNice)
+	}
+	m.auditAction(audit.Entry{
+		Action: strings.ToLower(action.String()),
+		Target: target.ProcessName,
+		PIDs:   pids,
+		Signal: signal,
+	}, done, failed, lastErr)
+
+	sev, msg := pidResult(action.pastTense(), strings.ToLower(action.String()), target.ProcessName, pids, done, failed, lastErr)
+	if action == actionRenice && done > 0 {
+		msg += fmt.Sprintf(i18n.T(" to nice %d"), m.actionNice)
+	}
+	return m, m.notify(sev, msg)
+}
+
+// pidResult summarizes applying an action to pids, e.g. "Killed 3 PIDs (nginx)".
+func pidResult(past, verb, name string, pids []int32, done, failed int, lastErr error) (toastSeverity, string) {
+	switch {
+	case failed == 0 && len(pids) == 1:
+		return toastSuccess, fmt.Sprintf(i18n.T("%s PID %d (%s)"), i18n.T(past), pids[0], name)
+	case failed == 0:
+		return toastSuccess, fmt.Sprintf(i18n.T("%s %d PIDs (%s)"), i18n.T(past), done, name)
+	case done == 0:
+		return toastError, fmt.Sprintf(i18n.T("Failed to %s %s: %v"), i18n.T(verb), name, lastErr)
+	default:
+		return toastWarn, fmt.Sprintf(i18n.T("%s %d PIDs, %d failed (%s)"), i18n.T(past), done, failed, name)
+	}
+}
+
+// renderActionModalContent returns the renice/suspend modal content.
+func (m Model) renderActionModalContent() string {
+	if m.actionTarget == nil {
+		return ""
+	}
+	t := m.actionTarget
+	accentStyle := WarnStyle()
+	descStyle := FooterDescStyle()
+	dimStyle := DimmedStyle()
+
+	subject := i18n.T("this process")
+	if len(t.PIDs) > 1 {
+		subject = fmt.Sprintf(i18n.T("%d processes"), len(t.PIDs))
+	}
+	lines := []string{
+		"",
+		accentStyle.Render("  " + fmt.Sprintf(i18n.T("%s %s?"), i18n.T(m.action.String()), subject)),
+		"",
+		descStyle.Render("  " + fmt.Sprintf(i18n.T("Process: %s"), t.ProcessName)),
+	}
+	if t.Exe != "" {
+		lines = append(lines, dimStyle.Render("  "+fmt.Sprintf(i18n.T("Path:    %s"), t.Exe)))
+	}
+	if len(t.PIDs) > 1 {
+		lines = append(lines, descStyle.Render("  "+fmt.Sprintf(i18n.T("PIDs:    %s"), formatPIDList(t.PIDs))))
+	} else {
+		lines = append(lines, descStyle.Render("  "+fmt.Sprintf(i18n.T("PID:     %d"), t.PID)))
+	}
+	lines = append(lines, "")
+
+	footer := accentStyle.Render("↵") + descStyle.Render(" "+i18n.T("Confirm")+"  ") + accentStyle.Render("Esc") + descStyle.Render(" "+i18n.T("Cancel"))
+	switch m.action {
+	case actionRenice:
+		lines = append(lines,
+			"  "+descStyle.Render(i18n.T("Nice: "))+accentStyle.Render(fmt.Sprintf("◂ %d ▸", m.actionNice)),
+			dimStyle.Render("  "+fmt.Sprintf(i18n.T("%d (highest priority) to %d (lowest); going below the current value needs root."), minNice, maxNice)),
+		)
+		footer += accentStyle.Render("  ←→") + descStyle.Render(" "+i18n.T("Nice"))
+	case actionSuspend:
+		lines = append(lines, dimStyle.Render("  "+i18n.T("SIGSTOP: sockets stay open but nothing is processed.")), dimStyle.Render("  "+i18n.T("Press z again to resume.")))
+		if t.Critical {
+			lines = append(lines, m.renderCriticalConfirm(t)...)
+		}
+	case actionResume:
+		lines = append(lines, dimStyle.Render("  "+i18n.T("The process is stopped. SIGCONT resumes it.")))
+	}
+	lines = append(lines, "", "  "+footer)
+	return strings.Join(lines, "\n")
+}
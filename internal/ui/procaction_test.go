@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"syscall"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// stubProcActions replaces the process syscalls and records what was sent.
+func stubProcActions(t *testing.T, nice int, stopped bool, err error) (*[]int, *[]syscall.Signal) {
+	t.Helper()
+	var prios []int
+	var sigs []syscall.Signal
+	origPrio, origNice, origStopped, origSignal := setPriority, processNice, processStopped, sendSignal
+	setPriority = func(pid, n int) error {
+		prios = append(prios, n)
+		return err
+	}
+	processNice = func(int32) (int, bool) { return nice, true }
+	processStopped = func(int32) bool { return stopped }
+	sendSignal = func(pid int, sig syscall.Signal) error {
+		sigs = append(sigs, sig)
+		return err
+	}
+	t.Cleanup(func() {
+		setPriority, processNice, processStopped, sendSignal = origPrio, origNice, origStopped, origSignal
+	})
+	return &prios, &sigs
+}
+
+func TestRenice_AdjustAndApply(t *testing.T) {
+	prios, _ := stubProcActions(t, 0, false, nil)
+	m := chipsTestModel()
+
+	m = pressKey(m, "r")
+	if !m.actionMode || m.action != actionRenice || m.actionNice != reniceStep {
+		t.Fatalf("mode = %v, action = %v, nice = %d; want renice proposing %d", m.actionMode, m.action, m.actionNice, reniceStep)
+	}
+	m = pressSpecial(m, tea.KeyRight)
+	m = pressSpecial(m, tea.KeyRight)
+	m = pressSpecial(m, tea.KeyLeft)
+	m = pressSpecial(m, tea.KeyEnter)
+
+	if m.actionMode {
+		t.Error("Enter should close the modal")
+	}
+	if len(*prios) != 1 || (*prios)[0] != reniceStep+1 {
+		t.Errorf("priorities set = %v, want [%d]", *prios, reniceStep+1)
+	}
+	if got := toastText(m); got != "Reniced PID 10 (chrome) to nice 11" {
+		t.Errorf("toast = %q", got)
+	}
+}
+
+func TestRenice_ClampedToRange(t *testing.T) {
+	stubProcActions(t, 15, false, nil)
+	m := chipsTestModel()
+
+	m = pressKey(m, "r")
+	if m.actionNice != maxNice {
+		t.Errorf("proposed nice = %d, want clamped to %d", m.actionNice, maxNice)
+	}
+	m = pressSpecial(m, tea.KeyRight)
+	if m.actionNice != maxNice {
+		t.Errorf("nice = %d, want %d", m.actionNice, maxNice)
+	}
+}
+
+func TestSuspend_ThenResume(t *testing.T) {
+	_, sigs := stubProcActions(t, 0, false, nil)
+	m := chipsTestModel()
+
+	m = pressKey(m, "z")
+	if m.action != actionSuspend {
+		t.Fatalf("action = %v, want Suspend", m.action)
+	}
+	m = pressSpecial(m, tea.KeyEnter)
+	if len(*sigs) != 1 || (*sigs)[0] != syscall.SIGSTOP {
+		t.Errorf("signals = %v, want SIGSTOP", *sigs)
+	}
+	if got := toastText(m); got != "Suspended PID 10 (chrome)" {
+		t.Errorf("toast = %q", got)
+	}
+
+	processStopped = func(int32) bool { return true }
+	m = pressKey(m, "z")
+	if m.action != actionResume {
+		t.Fatalf("action = %v, want Resume for a stopped process", m.action)
+	}
+	m = pressSpecial(m, tea.KeyEnter)
+	if (*sigs)[len(*sigs)-1] != syscall.SIGCONT {
+		t.Errorf("signals = %v, want SIGCONT last", *sigs)
+	}
+}
+
+func TestProcAction_EscCancels(t *testing.T) {
+	_, sigs := stubProcActions(t, 0, false, nil)
+	m := chipsTestModel()
+
+	m = pressKey(m, "z")
+	m = pressKey(m, "q")
+	m = pressSpecial(m, tea.KeyEsc)
+
+	if m.actionMode || m.quitting || len(*sigs) != 0 {
+		t.Errorf("mode = %v, quitting = %v, signals = %v; want cancelled cleanly", m.actionMode, m.quitting, *sigs)
+	}
+}
+
+func TestProcAction_FailureToast(t *testing.T) {
+	stubProcActions(t, 0, false, errors.New("operation not permitted"))
+	m := chipsTestModel()
+	m.snapshot.Applications[0].PIDs = []int32{10, 11}
+
+	m = pressKey(m, "z")
+	m = pressSpecial(m, tea.KeyEnter)
+
+	if got := toastText(m); got != "Failed to suspend chrome: operation not permitted" {
+		t.Errorf("toast = %q", got)
+	}
+}
+
+func TestProcAction_ContainerRefused(t *testing.T) {
+	stubProcActions(t, 0, false, nil)
+	m := chipsTestModel()
+	m.snapshot.Applications = nil
+	m.dockerContainers = true
+	m.virtualContainers = []model.VirtualContainer{{Info: model.ContainerInfo{ID: "abc", Name: "web"}}}
+
+	m = pressKey(m, "r")
+	if m.actionMode {
+		t.Error("containers should not open the renice modal")
+	}
+	if !strings.Contains(toastText(m), "containers") {
+		t.Errorf("toast = %q", toastText(m))
+	}
+}
+
+func TestRenderActionModal(t *testing.T) {
+	stubProcActions(t, 0, false, nil)
+	m := chipsTestModel()
+	m.width, m.height = 120, 40
+
+	m = pressKey(m, "r")
+	content := m.renderActionModalContent()
+	for _, want := range []string{"Renice this process?", "chrome", "◂ 10 ▸"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("modal missing %q:\n%s", want, content)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/procenv"
+)
+
+// lookupProcEnv reads a process's cwd and whitelisted environment (replaced in tests).
+var lookupProcEnv = procenv.Lookup
+
+// ProcEnvResolvedMsg carries the cwd/environment of a process.
+type ProcEnvResolvedMsg struct {
+	PID  int32
+	Info procenv.Info
+}
+
+// envKeysFromSettings returns the configured whitelist, or the built-in one.
+func envKeysFromSettings(keys []string) []string {
+	if len(keys) == 0 {
+		return procenv.DefaultKeys
+	}
+	return keys
+}
+
+// showProcEnv reports whether the connections detail pane includes Cwd/Env lines.
+func (m Model) showProcEnv() bool {
+	view := m.CurrentView()
+	return m.processEnv && m.envExpanded && view != nil && view.Level == LevelConnections
+}
+
+// toggleProcEnv expands or collapses the Cwd/Env lines of the drill-down process.
+// Collapsing forgets cached results so the next expansion reads fresh values.
+func (m Model) toggleProcEnv() (tea.Model, tea.Cmd) {
+	if !m.processEnv {
+		return m, m.notify(toastInfo, i18n.T("Process Env is off (enable it in Settings, S)"))
+	}
+	m.envExpanded = !m.envExpanded
+	if !m.envExpanded {
+		m.envCache = nil
+		return m, nil
+	}
+	return m, m.queueProcEnvLookup()
+}
+
+// queueProcEnvLookup returns a lookup for the drill-down process's primary PID if
+// the detail pane is expanded and its result is not cached yet.
+func (m Model) queueProcEnvLookup() tea.Cmd {
+	if !m.showProcEnv() {
+		return nil
+	}
+	app := m.findSelectedApp(m.CurrentView().ProcessName)
+	if app == nil || len(app.PIDs) == 0 {
+		return nil
+	}
+	pid := app.PIDs[0]
+	if _, ok := m.envCache[pid]; ok {
+		return nil
+	}
+	keys := m.envKeys
+	return func() tea.Msg {
+		return ProcEnvResolvedMsg{PID: pid, Info: lookupProcEnv(pid, keys)}
+	}
+}
+
+// handleProcEnvResolved caches a lookup result.
+func (m Model) handleProcEnvResolved(msg ProcEnvResolvedMsg) (tea.Model, tea.Cmd) {
+	if !m.processEnv || !m.envExpanded {
+		return m, nil // collapsed or disabled while the lookup ran
+	}
+	if m.envCache == nil {
+		m.envCache = make(map[int32]procenv.Info)
+	}
+	m.envCache[msg.PID] = msg.Info
+	return m, nil
+}
+
+// procEnvLines returns the "Cwd: …" and "Env: …" detail lines for pids.
+func (m Model) procEnvLines(pids []int32) (cwd, env string) {
+	if len(pids) == 0 {
+		return "Cwd: -", "Env: -"
+	}
+	info, ok := m.envCache[pids[0]]
+	if !ok {
+		return "Cwd: …", "Env: …"
+	}
+
+	cwd = "Cwd: " + info.Cwd
+	if info.CwdErr != nil {
+		cwd = "Cwd: " + procenv.Reason(info.CwdErr)
+	}
+	switch {
+	case info.EnvErr != nil:
+		env = "Env: " + procenv.Reason(info.EnvErr)
+	case len(info.Env) == 0:
+		env = "Env: none of " + strings.Join(m.envKeys, ", ") + " set"
+	default:
+		parts := make([]string, len(info.Env))
+		for i, v := range info.Env {
+			parts[i] = v.Key + "=" + v.Value
+		}
+		env = "Env: " + strings.Join(parts, "  ")
+	}
+	return cwd, env
+}
@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/procenv"
+)
+
+func stubProcEnv(t *testing.T, info procenv.Info) *int {
+	t.Helper()
+	calls := 0
+	old := lookupProcEnv
+	lookupProcEnv = func(pid int32, keys []string) procenv.Info {
+		calls++
+		return info
+	}
+	t.Cleanup(func() { lookupProcEnv = old })
+	return &calls
+}
+
+// drilledEnvModel returns a sized model drilled into App1 with Process Env enabled.
+func drilledEnvModel() Model {
+	m := createTestModel()
+	m.width = 160
+	m.processEnv = true
+	m.envKeys = []string{"PORT", "NODE_ENV"}
+	m.PushView(ViewState{Level: LevelConnections, ProcessName: "App1"})
+	return m
+}
+
+func TestProcEnv_DisabledShowsHint(t *testing.T) {
+	m := drilledEnvModel()
+	m.processEnv = false
+	m = pressKey(m, "e")
+	if m.envExpanded {
+		t.Error("e should not expand while the setting is off")
+	}
+	if !strings.Contains(toastText(m), "Settings") {
+		t.Errorf("toast = %q, want hint to enable the setting", toastText(m))
+	}
+}
+
+func TestProcEnv_ExpandLooksUpAndRenders(t *testing.T) {
+	calls := stubProcEnv(t, procenv.Info{Cwd: "/srv/app", Env: []procenv.Var{{Key: "PORT", Value: "8080"}}})
+	m := drilledEnvModel()
+	before := m.frozenHeaderHeight()
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	m = updated.(Model)
+	if !m.envExpanded || cmd == nil {
+		t.Fatal("e should expand and queue a lookup")
+	}
+	if got := m.frozenHeaderHeight(); got != before+2 {
+		t.Errorf("frozenHeaderHeight = %d, want %d (+Cwd/Env lines)", got, before+2)
+	}
+	if !strings.Contains(m.renderFrozenHeader(), "Cwd: …") {
+		t.Error("pending lookup should render a placeholder")
+	}
+
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	header := m.renderFrozenHeader()
+	if !strings.Contains(header, "Cwd: /srv/app") || !strings.Contains(header, "PORT=8080") {
+		t.Errorf("header missing cwd/env:\n%s", header)
+	}
+	if *calls != 1 {
+		t.Errorf("lookups = %d, want 1", *calls)
+	}
+	if m.queueProcEnvLookup() != nil {
+		t.Error("cached PID should not be looked up again")
+	}
+
+	m = pressKey(m, "e")
+	if m.envExpanded || m.envCache != nil {
+		t.Error("second e should collapse and forget cached values")
+	}
+}
+
+func TestProcEnv_PermissionDenied(t *testing.T) {
+	m := drilledEnvModel()
+	m.envExpanded = true
+	m.envCache = map[int32]procenv.Info{100: {
+		Cwd:    "/home/other",
+		EnvErr: fmt.Errorf("open environ: %w", os.ErrPermission),
+	}}
+	cwd, env := m.procEnvLines([]int32{100})
+	if cwd != "Cwd: /home/other" {
+		t.Errorf("cwd line = %q", cwd)
+	}
+	if !strings.Contains(env, "permission denied") {
+		t.Errorf("env line = %q, want permission hint", env)
+	}
+}
+
+func TestProcEnv_NoKeysSet(t *testing.T) {
+	m := drilledEnvModel()
+	m.envCache = map[int32]procenv.Info{100: {Cwd: "/"}}
+	if _, env := m.procEnvLines([]int32{100}); env != "Env: none of PORT, NODE_ENV set" {
+		t.Errorf("env line = %q", env)
+	}
+}
+
+func TestProcEnv_SettingOffClears(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	m := drilledEnvModel()
+	m.envExpanded = true
+	m.envCache = map[int32]procenv.Info{100: {Cwd: "/"}}
+
+	for i, item := range settingItems() {
+		if item.name == "Process Env" {
+			m.toggleSetting(i)
+		}
+	}
+	if m.processEnv || m.envExpanded || m.envCache != nil {
+		t.Error("turning Process Env off should collapse and drop cached values")
+	}
+}
+
+func TestProcEnv_IgnoredOutsideConnections(t *testing.T) {
+	m := createTestModel()
+	m.processEnv = true
+	m = pressKey(m, "e")
+	if m.envExpanded {
+		t.Error("e should only act in the connections view")
+	}
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"github.com/kostyay/netmon/internal/model"
+	"github.com/kostyay/netmon/internal/services"
+)
+
+// Optional connection column for the detected application protocol.
+var protoDetailColumn = columnDef{label: "L7", id: SortProtoDetail, minWidth: 10, flex: 0}
+
+// detectProtocol guesses the application protocol of conn owned by process
+// ("TLS", "HTTP/2", "SSH"), or "" when port and process give no hint.
+func detectProtocol(conn model.Connection, process string) string {
+	return services.Detect(string(conn.Protocol), model.ExtractPort(conn.LocalAddr), model.ExtractPort(conn.RemoteAddr), process)
+}
+
+// connExtraCells renders the optional connection cells: interface, protocol
+// detail, then TCP stats. widths are the widths of the optional columns only.
+func (m Model) connExtraCells(conn model.Connection, process string, widths []int) string {
+	var cells string
+	if m.showIfaces && len(widths) > 0 {
+		label := m.connInterface(conn)
+		if label == "" {
+			label = "—"
+		}
+		cells = " " + padRight(truncateString(label, widths[0]), widths[0])
+		widths = widths[1:]
+	}
+	if m.protoDetail && len(widths) > 0 {
+		label := detectProtocol(conn, process)
+		if label == "" {
+			label = "—"
+		}
+		cells += " " + padRight(truncateString(label, widths[0]), widths[0])
+		widths = widths[1:]
+	}
+	return cells + m.tcpStatsCells(conn, widths)
+}
+
+// withOptionalConnColumns appends the enabled optional connection columns to cols.
+func (m Model) withOptionalConnColumns(cols []columnDef) []columnDef {
+	if m.showIfaces {
+		cols = append(cols, ifaceColumn)
+	}
+	if m.protoDetail {
+		cols = append(cols, protoDetailColumn)
+	}
+	if m.tcpStats {
+		cols = append(cols, tcpStatsColumns...)
+	}
+	return cols
+}
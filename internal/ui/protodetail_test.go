@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// protoDetailSnapshot returns one sshd with an incoming session and an outgoing TLS connection.
+func protoDetailSnapshot() *model.NetworkSnapshot {
+	return &model.NetworkSnapshot{
+		Applications: []model.Application{{
+			Name: "sshd",
+			PIDs: []int32{7},
+			Connections: []model.Connection{
+				{PID: 7, Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.1:22", RemoteAddr: "10.0.0.9:51000", State: model.StateEstablished},
+				{PID: 7, Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.1:51001", RemoteAddr: "1.1.1.1:443", State: model.StateEstablished},
+				{PID: 7, Protocol: model.ProtocolUDP, LocalAddr: "10.0.0.1:5000", RemoteAddr: "*", State: model.StateNone},
+			},
+		}},
+	}
+}
+
+func TestProtoDetailColumn_ConnectionsView(t *testing.T) {
+	m := createTestModel()
+	m.width = 140
+	m.snapshot = protoDetailSnapshot()
+	m.tcpStats = true
+	view := m.newViewState(LevelConnections, "sshd")
+	view.SortColumn, view.SortAscending = SortProtoDetail, false
+	m.stack = append(m.stack, view)
+
+	if cols := m.columnsForLevel(LevelConnections); cols[len(cols)-3] == SortProtoDetail {
+		t.Fatal("L7 column should be hidden when protocol detail is off")
+	}
+	m.protoDetail = true
+	cols := m.columnsForLevel(LevelConnections)
+	if cols[len(cols)-3] != SortProtoDetail {
+		t.Fatalf("columns = %v, want L7 before the TCP stats columns", cols)
+	}
+
+	lines := strings.Split(strings.TrimSpace(m.renderConnectionsListData()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d rows, want 3", len(lines))
+	}
+	for i, want := range []string{"TLS", "SSH", "—"} {
+		if !strings.Contains(lines[i], want) {
+			t.Errorf("row %d = %q, want %q (descending L7 sort)", i, lines[i], want)
+		}
+	}
+}
+
+func TestProtoDetailColumn_AllConnectionsUsesProcess(t *testing.T) {
+	m := createTestModel()
+	m.width = 160
+	m.protoDetail = true
+	m.snapshot = &model.NetworkSnapshot{
+		Applications: []model.Application{{
+			Name:        "firefox",
+			PIDs:        []int32{9},
+			Connections: []model.Connection{{PID: 9, Protocol: model.ProtocolUDP, LocalAddr: "10.0.0.1:5000", RemoteAddr: "1.1.1.1:443", State: model.StateNone}},
+		}},
+	}
+	m.stack = []ViewState{m.newViewState(LevelAllConnections, "")}
+
+	header := m.renderAllConnectionsHeader(calculateColumnWidths(m.activeAllConnectionsColumns(), m.contentWidth()))
+	if !strings.Contains(header, "L7") {
+		t.Errorf("header = %q, want an L7 column", header)
+	}
+	if data := m.renderAllConnectionsData(); !strings.Contains(data, "HTTP/3") {
+		t.Errorf("browser QUIC should show as HTTP/3, got %q", data)
+	}
+}
@@ -0,0 +1,33 @@
+package ui
+
+// quickSortIndex returns the zero-based column index for a quick sort key ("1"-"9").
+func quickSortIndex(key string) (int, bool) {
+	if len(key) != 1 || key[0] < '1' || key[0] > '9' {
+		return 0, false
+	}
+	return int(key[0] - '1'), true
+}
+
+// quickSort sorts the current view by its idx-th visible column, toggling the
+// direction if it is already the sort column. Leaves sort mode if active.
+// Indexes past the last column are ignored.
+func (m *Model) quickSort(idx int) {
+	view := m.CurrentView()
+	if view == nil {
+		return
+	}
+	columns := m.columnsForLevel(view.Level)
+	if idx >= len(columns) {
+		return
+	}
+	col := columns[idx]
+	if view.SortColumn == col {
+		view.SortAscending = !view.SortAscending
+	} else {
+		view.SortColumn = col
+		view.SortAscending = true
+	}
+	view.SelectedColumn = col
+	view.SortMode = false
+	m.rememberSort(view)
+}
@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/config"
+)
+
+func pressKey(m Model, key string) Model {
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	return updated.(Model)
+}
+
+func TestQuickSortIndex(t *testing.T) {
+	for key, want := range map[string]int{"1": 0, "5": 4, "9": 8} {
+		if got, ok := quickSortIndex(key); !ok || got != want {
+			t.Errorf("quickSortIndex(%q) = %d, %v; want %d", key, got, ok, want)
+		}
+	}
+	for _, key := range []string{"0", "a", "10", ""} {
+		if _, ok := quickSortIndex(key); ok {
+			t.Errorf("quickSortIndex(%q) should not match", key)
+		}
+	}
+}
+
+func TestQuickSort_SelectsNthColumnAndToggles(t *testing.T) {
+	m := createTestModel()
+	cols := m.columnsForLevel(LevelProcessList)
+
+	m = pressKey(m, "3")
+	view := m.CurrentView()
+	if view.SortColumn != cols[2] || !view.SortAscending {
+		t.Errorf("after 3: sort = %v asc=%v, want %v ascending", view.SortColumn, view.SortAscending, cols[2])
+	}
+
+	m = pressKey(m, "3")
+	if view := m.CurrentView(); view.SortColumn != cols[2] || view.SortAscending {
+		t.Error("pressing the same number again should reverse the direction")
+	}
+}
+
+func TestQuickSort_IgnoresMissingColumn(t *testing.T) {
+	m := createTestModel()
+	before := *m.CurrentView()
+	m = pressKey(m, "9")
+	if after := *m.CurrentView(); after.SortColumn != before.SortColumn || after.SortAscending != before.SortAscending {
+		t.Error("a number past the last column should not change the sort")
+	}
+}
+
+func TestQuickSort_ExitsSortModeAndRemembers(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	m := createTestModel()
+	m.sortPrefs = make(map[string]config.SortPref)
+	m.CurrentView().SortMode = true
+
+	m = pressKey(m, "2")
+	view := m.CurrentView()
+	if view.SortMode {
+		t.Error("quick sort should leave sort mode")
+	}
+	want := m.columnsForLevel(LevelProcessList)[1]
+	if pref := m.sortPrefs[viewPrefKey(LevelProcessList)]; pref.Column != want.String() {
+		t.Errorf("remembered sort = %q, want %q", pref.Column, want.String())
+	}
+}
+
+func TestQuickSort_IgnoredWhileSearching(t *testing.T) {
+	m := createTestModel()
+	m.searchMode = true
+	before := m.CurrentView().SortColumn
+	m = pressKey(m, "2")
+	if m.CurrentView().SortColumn != before {
+		t.Error("number keys should type into the search query")
+	}
+	if m.searchQuery != "2" {
+		t.Errorf("searchQuery = %q, want %q", m.searchQuery, "2")
+	}
+}
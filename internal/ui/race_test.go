@@ -0,0 +1,7 @@
+//go:build race
+
+package ui
+
+// raceEnabled reports whether tests run under the race detector, which slows
+// code down too much for timing assertions.
+const raceEnabled = true
@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+)
+
+// rateStats accumulates a process's throughput over the session so spikes
+// between refreshes are not lost.
+type rateStats struct {
+	TX, RX  rateSeries
+	samples int
+}
+
+// rateSeries is the min/avg/peak of one direction.
+type rateSeries struct {
+	min, peak float64
+	sum       float64
+	peakAt    time.Time
+}
+
+// add records one rate sample taken at.
+func (s *rateSeries) add(rate float64, at time.Time, first bool) {
+	if first || rate < s.min {
+		s.min = rate
+	}
+	if first || rate > s.peak {
+		s.peak = rate
+		s.peakAt = at
+	}
+	s.sum += rate
+}
+
+// recordRateStats adds each process's current throughput (summed over its
+// PIDs) to its session stats. Processes without a rate for any PID are skipped.
+func (m *Model) recordRateStats(at time.Time) {
+	if m.snapshot == nil {
+		return
+	}
+	if m.rateStats == nil {
+		m.rateStats = make(map[string]*rateStats)
+	}
+	for _, app := range m.snapshot.Applications {
+		var total ioRate
+		var ok bool
+		for _, pid := range app.PIDs {
+			if r, has := m.netIORates[pid]; has {
+				total.TX += r.TX
+				total.RX += r.RX
+				ok = true
+			}
+		}
+		if !ok {
+			continue
+		}
+		st := m.rateStats[app.Name]
+		if st == nil {
+			st = &rateStats{}
+			m.rateStats[app.Name] = st
+		}
+		first := st.samples == 0
+		st.TX.add(total.TX, at, first)
+		st.RX.add(total.RX, at, first)
+		st.samples++
+	}
+}
+
+// rateStatsLine returns the session throughput summary for the process drill-down
+// header, e.g. "Session TX min 0 B/s  avg 1.2 KB/s  peak 12.0 MB/s at 14:03  |  RX …",
+// or "" before the first rate sample.
+func (m Model) rateStatsLine(name string) string {
+	st := m.rateStats[name]
+	if st == nil || st.samples == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Session TX %s  |  RX %s", st.TX.summary(st.samples), st.RX.summary(st.samples))
+}
+
+// summary formats the series as "min 0 B/s  avg 1.2 KB/s  peak 12.0 MB/s at 14:03".
+func (s rateSeries) summary(samples int) string {
+	return fmt.Sprintf("min %s  avg %s  peak %s at %s",
+		formatBytesRate(s.min), formatBytesRate(s.sum/float64(samples)), formatBytesRate(s.peak), s.peakAt.Format("15:04"))
+}
+
+// formatBytesRate formats a throughput in bytes per second, e.g. "12.0 MB/s".
+func formatBytesRate(r float64) string {
+	return formatBytes(uint64(r)) + "/s"
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecordRateStats_MinAvgPeak(t *testing.T) {
+	m := chipsTestModel()
+	start := time.Date(2026, 1, 1, 14, 0, 0, 0, time.Local)
+
+	for i, tx := range []float64{1024, 4096, 0, 3072} {
+		m.netIORates = map[int32]ioRate{10: {TX: tx, RX: 100}}
+		m.recordRateStats(start.Add(time.Duration(i) * time.Minute))
+	}
+
+	st := m.rateStats["chrome"]
+	if st == nil || st.samples != 4 {
+		t.Fatalf("stats = %+v, want 4 samples", st)
+	}
+	if st.TX.min != 0 || st.TX.peak != 4096 || st.TX.sum/4 != 2048 {
+		t.Errorf("TX min/peak/avg = %v/%v/%v, want 0/4096/2048", st.TX.min, st.TX.peak, st.TX.sum/4)
+	}
+	if !st.TX.peakAt.Equal(start.Add(time.Minute)) {
+		t.Errorf("peakAt = %v, want 14:01", st.TX.peakAt)
+	}
+	if _, ok := m.rateStats["curl"]; ok {
+		t.Error("processes without a rate should not get stats")
+	}
+}
+
+func TestRecordRateStats_SumsPIDs(t *testing.T) {
+	m := chipsTestModel()
+	m.snapshot.Applications[0].PIDs = []int32{10, 11}
+	m.netIORates = map[int32]ioRate{10: {TX: 1000}, 11: {TX: 500}}
+
+	m.recordRateStats(time.Now())
+
+	if got := m.rateStats["chrome"].TX.peak; got != 1500 {
+		t.Errorf("peak = %v, want PIDs summed to 1500", got)
+	}
+}
+
+func TestRateStatsLine(t *testing.T) {
+	m := chipsTestModel()
+	if got := m.rateStatsLine("chrome"); got != "" {
+		t.Errorf("line before samples = %q, want empty", got)
+	}
+
+	m.netIORates = map[int32]ioRate{10: {TX: 12 * 1024 * 1024, RX: 2048}}
+	m.recordRateStats(time.Date(2026, 1, 1, 14, 3, 0, 0, time.Local))
+
+	got := m.rateStatsLine("chrome")
+	for _, want := range []string{"peak 12.0 MB/s at 14:03", "RX min 2.0 KB/s"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("line = %q, missing %q", got, want)
+		}
+	}
+}
+
+func TestRateStatsShownInDrillDownHeader(t *testing.T) {
+	m := chipsTestModel()
+	m.width = 200
+	m.PushView(ViewState{Level: LevelConnections, ProcessName: "chrome", SortColumn: SortRemote, SortAscending: true})
+	before := m.frozenHeaderHeight()
+
+	m.netIORates = map[int32]ioRate{10: {TX: 2048}}
+	m.recordRateStats(time.Now())
+
+	if !strings.Contains(m.renderFrozenHeader(), "Session TX") {
+		t.Error("drill-down header should show session rates")
+	}
+	if got := m.frozenHeaderHeight(); got != before+1 {
+		t.Errorf("frozen header height = %d, want %d", got, before+1)
+	}
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/kostyay/netmon/internal/config"
+)
+
+// clampRefresh bounds d to [MinRefreshInterval, MaxRefreshInterval].
+func clampRefresh(d time.Duration) time.Duration {
+	return min(max(d, MinRefreshInterval), MaxRefreshInterval)
+}
+
+// effectiveRefreshInterval returns the refresh interval for the current view:
+// its level's override if set, otherwise the process list interval. Large
+// host mode refreshes no faster than largeHostMinRefresh, idle mode no faster
+// than idleMinRefresh.
+func (m Model) effectiveRefreshInterval() time.Duration {
+	d := m.refreshInterval
+	if view := m.CurrentView(); view != nil && view.Level != LevelProcessList {
+		if override, ok := m.refreshPrefs[viewPrefKey(view.Level)]; ok {
+			d = override
+		}
+	}
+	if m.largeHost {
+		d = max(d, largeHostMinRefresh)
+	}
+	if m.idle {
+		d = max(d, idleMinRefresh)
+	}
+	return d
+}
+
+// adjustRefresh changes the current view's refresh interval by delta and persists it.
+// In the process list this changes the default for all levels without an override;
+// elsewhere it sets an override for the current level. With overrides disabled
+// (refreshPrefs is nil) it always changes the default.
+func (m *Model) adjustRefresh(delta time.Duration) {
+	d := clampRefresh(m.effectiveRefreshInterval() + delta)
+	view := m.CurrentView()
+	if m.refreshPrefs == nil || view == nil || view.Level == LevelProcessList {
+		m.refreshInterval = d
+		if m.refreshPrefs != nil {
+			m.rememberRefresh(viewPrefKey(LevelProcessList), d)
+		}
+		return
+	}
+	if key := viewPrefKey(view.Level); key != "" {
+		m.rememberRefresh(key, d)
+	}
+}
+
+// rememberRefresh stores a level's refresh interval and persists it.
+func (m *Model) rememberRefresh(key string, d time.Duration) {
+	m.refreshPrefs[key] = d
+	if config.CurrentSettings.Refresh == nil {
+		config.CurrentSettings.Refresh = make(map[string]time.Duration)
+	}
+	config.CurrentSettings.Refresh[key] = d
+	_ = config.SaveSettings(config.CurrentSettings)
+}
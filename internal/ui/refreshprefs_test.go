@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/config"
+)
+
+func TestEffectiveRefreshInterval_PerLevel(t *testing.T) {
+	m := createTestModel()
+	m.refreshInterval = 2 * time.Second
+	m.refreshPrefs = map[string]time.Duration{"connections": 500 * time.Millisecond}
+
+	if got := m.effectiveRefreshInterval(); got != 2*time.Second {
+		t.Errorf("process list interval = %v, want 2s", got)
+	}
+	m.PushView(m.newViewState(LevelConnections, "App1"))
+	if got := m.effectiveRefreshInterval(); got != 500*time.Millisecond {
+		t.Errorf("connections interval = %v, want 500ms override", got)
+	}
+	m.PushView(m.newViewState(LevelAllConnections, ""))
+	if got := m.effectiveRefreshInterval(); got != 2*time.Second {
+		t.Errorf("all connections interval = %v, want 2s default", got)
+	}
+}
+
+func TestAdjustRefresh_SetsOverrideForCurrentLevel(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	origRefresh := config.CurrentSettings.Refresh
+	t.Cleanup(func() { config.CurrentSettings.Refresh = origRefresh })
+
+	m := createTestModel()
+	m.refreshPrefs = map[string]time.Duration{}
+	m.PushView(m.newViewState(LevelConnections, "App1"))
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+	m = updated.(Model)
+
+	want := DefaultRefreshInterval - RefreshStep
+	if got := m.refreshPrefs["connections"]; got != want {
+		t.Errorf("refreshPrefs[connections] = %v, want %v", got, want)
+	}
+	if m.refreshInterval != DefaultRefreshInterval {
+		t.Errorf("default interval changed to %v, want unchanged", m.refreshInterval)
+	}
+	if got := config.CurrentSettings.Refresh["connections"]; got != want {
+		t.Errorf("persisted refresh = %v, want %v", got, want)
+	}
+
+	m.PopView()
+	if got := m.effectiveRefreshInterval(); got != DefaultRefreshInterval {
+		t.Errorf("process list interval = %v after going back, want default", got)
+	}
+}
+
+func TestUpdate_IntervalChangeRestartsTick(t *testing.T) {
+	m := createTestModel()
+	m.refreshPrefs = map[string]time.Duration{"connections": 500 * time.Millisecond}
+
+	// Drill down into a level with a faster interval.
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.tickGen != 1 {
+		t.Fatalf("tickGen = %d, want 1 after interval change", m.tickGen)
+	}
+	if cmd == nil {
+		t.Fatal("interval change should schedule a new tick")
+	}
+
+	// The tick scheduled before the change is dropped.
+	if _, cmd := m.Update(TickMsg{Time: time.Now(), Gen: 0}); cmd != nil {
+		t.Error("stale tick should not fetch or reschedule")
+	}
+	if _, cmd := m.Update(TickMsg{Time: time.Now(), Gen: 1}); cmd == nil {
+		t.Error("current tick should fetch and reschedule")
+	}
+}
+
+func TestRenderHeader_ShowsEffectiveRefresh(t *testing.T) {
+	m := createTestModel()
+	m.width = 160
+	m.refreshPrefs = map[string]time.Duration{"connections": 500 * time.Millisecond}
+	m.PushView(m.newViewState(LevelConnections, "App1"))
+
+	if header := m.renderHeader(); !containsText(header, "0.5s") {
+		t.Errorf("header should show the connections view interval, got:\n%s", header)
+	}
+}
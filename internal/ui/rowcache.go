@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// Connection views can hold thousands of rows, but only a viewport's worth is
+// visible. The data renderers therefore style just a window around the visible
+// rows and emit empty lines for the rest, so the viewport's line count, scroll
+// offset and scrollbar behave exactly as if every row were rendered.
+
+// visibleRowRange returns the [start, end) rows to render out of total:
+// the page the viewport will show after syncViewportScroll, plus one page of
+// margin on each side. Without a sized viewport every row is rendered.
+func (m Model) visibleRowRange(total int) (start, end int) {
+	h := m.viewport.Height
+	view := m.CurrentView()
+	if !m.ready || h <= 0 || view == nil {
+		return 0, total
+	}
+	// Predict the scroll position syncViewportScroll will settle on, since it runs
+	// after the content is built.
+	top := m.viewport.YOffset
+	if view.Cursor < top {
+		top = view.Cursor
+	} else if view.Cursor >= top+h {
+		top = view.Cursor - h + 1
+	}
+	top = max(0, min(top, total-h))
+	return max(0, top-h), min(total, top+2*h)
+}
+
+// writeBlankRows writes n empty placeholder lines for rows outside the window.
+func writeBlankRows(b *strings.Builder, n int) {
+	if n > 0 {
+		b.WriteString(strings.Repeat("\n", n))
+	}
+}
+
+// rowStyle is which style a cached row was rendered with.
+type rowStyle struct {
+	selected bool
+	mark     rowMark
+}
+
+var (
+	rowPlain    = rowStyle{}
+	rowSelected = rowStyle{selected: true}
+)
+
+func rowStyleFor(isSelected bool, mark rowMark) rowStyle {
+	if isSelected {
+		return rowSelected
+	}
+	return rowStyle{mark: mark}
+}
+
+// rowLayout is everything besides the connection itself that shapes a row.
+// Any difference drops the whole cache.
+type rowLayout struct {
+	level        ViewLevel
+	width        int
+	serviceNames bool
+	dockerView   bool
+	tcpStats     bool
+	protoDetail  bool
+	showIfaces   bool
+}
+
+type cachedRow struct {
+	conn  model.Connection
+	name  string // process name (all connections view)
+	style rowStyle
+	text  string
+}
+
+// rowCache keeps styled connection rows keyed by ConnectionKey across renders.
+// A row is reused while its connection, process name and style are unchanged;
+// rows not rendered in a pass are dropped, so it stays window-sized.
+// It is shared by pointer between Model copies; nil disables caching.
+type rowCache struct {
+	layout rowLayout
+	rows   map[ConnectionKey]cachedRow
+	next   map[ConnectionKey]cachedRow
+}
+
+func newRowCache() *rowCache {
+	return &rowCache{rows: make(map[ConnectionKey]cachedRow)}
+}
+
+// begin starts a render pass, dropping every row if the layout changed.
+func (c *rowCache) begin(layout rowLayout) {
+	if c == nil {
+		return
+	}
+	if layout != c.layout {
+		c.layout = layout
+		c.rows = make(map[ConnectionKey]cachedRow)
+	}
+	c.next = make(map[ConnectionKey]cachedRow, len(c.rows))
+}
+
+// row returns the cached row for conn, or renders and caches it.
+func (c *rowCache) row(conn model.Connection, name string, style rowStyle, render func() string) string {
+	if c == nil {
+		return render()
+	}
+	key := KeyFromConnection(conn)
+	if r, ok := c.rows[key]; ok && r.conn == conn && r.name == name && r.style == style {
+		c.next[key] = r
+		return r.text
+	}
+	text := render()
+	c.next[key] = cachedRow{conn: conn, name: name, style: style, text: text}
+	return text
+}
+
+// end finishes a render pass, keeping only the rows it used.
+func (c *rowCache) end() {
+	if c == nil {
+		return
+	}
+	c.rows, c.next = c.next, nil
+}
+
+// invalidate drops every row, e.g. when DNS names or container info change.
+func (c *rowCache) invalidate() {
+	if c == nil {
+		return
+	}
+	c.rows = make(map[ConnectionKey]cachedRow)
+}
+
+func (m Model) rowLayout(level ViewLevel) rowLayout {
+	return rowLayout{
+		level:        level,
+		width:        m.contentWidth(),
+		serviceNames: m.serviceNames,
+		dockerView:   m.dockerView,
+		tcpStats:     m.tcpStats,
+		protoDetail:  m.protoDetail,
+		showIfaces:   m.showIfaces,
+	}
+}
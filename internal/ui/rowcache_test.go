@@ -0,0 +1,200 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// bigSnapshotModel returns a sized model in the all-connections view over n connections.
+func bigSnapshotModel(n int) Model {
+	conns := make([]model.Connection, n)
+	for i := range conns {
+		conns[i] = model.Connection{
+			PID:        100,
+			Protocol:   model.ProtocolTCP,
+			LocalAddr:  fmt.Sprintf("10.0.0.1:%d", 10000+i),
+			RemoteAddr: "93.184.216.34:443",
+			State:      model.StateEstablished,
+		}
+	}
+	m := createTestModel()
+	m.snapshot = &model.NetworkSnapshot{Applications: []model.Application{{Name: "bulk", PIDs: []int32{100}, Connections: conns}}}
+	m.rowCache = newRowCache()
+	m.pipeline = newPipelineCache()
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	m = updated.(Model)
+	m.stack = []ViewState{m.newViewState(LevelAllConnections, "")}
+	m.updateViewportContent()
+	return m
+}
+
+func TestVisibleRowRange(t *testing.T) {
+	m := createTestModel()
+	if start, end := m.visibleRowRange(500); start != 0 || end != 500 {
+		t.Errorf("unsized viewport: range = [%d,%d), want all rows", start, end)
+	}
+
+	m.ready = true
+	m.viewport.Height = 10
+	tests := []struct {
+		name               string
+		cursor, yOffset    int
+		total              int
+		wantStart, wantEnd int
+	}{
+		{"top", 0, 0, 500, 0, 20},
+		{"scrolled", 105, 100, 500, 90, 120},
+		{"cursor below window", 300, 0, 500, 281, 311},
+		{"cursor above window", 5, 100, 500, 0, 25},
+		{"near end", 499, 490, 500, 480, 500},
+		{"offset past shrunk content", 3, 400, 8, 0, 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m.stack = []ViewState{{Level: LevelAllConnections, Cursor: tt.cursor}}
+			m.viewport.YOffset = tt.yOffset
+			start, end := m.visibleRowRange(tt.total)
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("range = [%d,%d), want [%d,%d)", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestRenderAllConnectionsData_Virtualized(t *testing.T) {
+	m := bigSnapshotModel(5000)
+
+	// Every row ends in a newline, so the viewport sees one trailing empty line.
+	if got := m.viewport.TotalLineCount(); got != 5001 {
+		t.Fatalf("TotalLineCount = %d, want one line per connection plus the trailing one", got)
+	}
+	lines := strings.Split(m.viewportContent, "\n")
+	if !strings.Contains(lines[0], "10.0.0.1") {
+		t.Errorf("first row should be rendered, got %q", lines[0])
+	}
+	if lines[4000] != "" {
+		t.Errorf("row far outside the viewport should be a blank placeholder, got %q", lines[4000])
+	}
+}
+
+func TestRenderAllConnectionsData_CursorJumpVisible(t *testing.T) {
+	m := bigSnapshotModel(5000)
+
+	// Jump far past the rendered window, as PageDown from deep in the list does.
+	m.CurrentView().Cursor = 4990
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	m = updated.(Model)
+	if m.CurrentView().Cursor != 4999 {
+		t.Fatalf("cursor = %d, want last row", m.CurrentView().Cursor)
+	}
+	view := m.viewport.View()
+	if !strings.Contains(view, "10.0.0.1:14999") {
+		t.Errorf("last row should be visible after jumping to the end, got:\n%s", view)
+	}
+	for i, line := range strings.Split(view, "\n") {
+		if strings.TrimSpace(stripAnsi(line)) == "" {
+			t.Errorf("visible line %d is a blank placeholder", i)
+		}
+	}
+}
+
+func TestRowCache_ReusesUnchangedRows(t *testing.T) {
+	c := newRowCache()
+	conn := model.Connection{PID: 1, Protocol: model.ProtocolTCP, LocalAddr: "a:1", RemoteAddr: "b:2", State: model.StateEstablished}
+	renders := 0
+	render := func() string { renders++; return "row\n" }
+
+	c.begin(rowLayout{width: 80})
+	c.row(conn, "p", rowPlain, render)
+	c.end()
+	c.begin(rowLayout{width: 80})
+	c.row(conn, "p", rowPlain, render)
+	c.end()
+	if renders != 1 {
+		t.Errorf("renders = %d, want cached row reused", renders)
+	}
+
+	c.begin(rowLayout{width: 80})
+	c.row(conn, "p", rowSelected, render)
+	c.end()
+	if renders != 2 {
+		t.Errorf("renders = %d, want re-render when style changes", renders)
+	}
+
+	changed := conn
+	changed.State = model.StateCloseWait
+	c.begin(rowLayout{width: 80})
+	c.row(changed, "p", rowSelected, render)
+	c.end()
+	if renders != 3 {
+		t.Errorf("renders = %d, want re-render when the connection changes", renders)
+	}
+
+	c.begin(rowLayout{width: 100})
+	c.row(changed, "p", rowSelected, render)
+	c.end()
+	if renders != 4 {
+		t.Errorf("renders = %d, want re-render after a layout change", renders)
+	}
+
+	c.invalidate()
+	c.begin(rowLayout{width: 100})
+	c.row(changed, "p", rowSelected, render)
+	c.end()
+	if renders != 5 {
+		t.Errorf("renders = %d, want re-render after invalidate", renders)
+	}
+}
+
+func TestRowCache_DropsUnusedRows(t *testing.T) {
+	c := newRowCache()
+	render := func() string { return "row\n" }
+	c.begin(rowLayout{})
+	for i := 0; i < 10; i++ {
+		c.row(model.Connection{PID: int32(i)}, "", rowPlain, render)
+	}
+	c.end()
+	c.begin(rowLayout{})
+	c.row(model.Connection{PID: 0}, "", rowPlain, render)
+	c.end()
+	if len(c.rows) != 1 {
+		t.Errorf("cached rows = %d, want only rows from the last pass", len(c.rows))
+	}
+}
+
+func TestRowCache_NilSafe(t *testing.T) {
+	var c *rowCache
+	c.begin(rowLayout{})
+	if got := c.row(model.Connection{}, "", rowPlain, func() string { return "x" }); got != "x" {
+		t.Errorf("nil cache row() = %q, want rendered row", got)
+	}
+	c.end()
+	c.invalidate()
+}
+
+func TestDNSResolved_InvalidatesRowCache(t *testing.T) {
+	m := bigSnapshotModel(50)
+	m.dnsCache = make(map[string]string)
+	if len(m.rowCache.rows) == 0 {
+		t.Fatal("expected rows cached after render")
+	}
+	updated, _ := m.update(DNSResolvedMsg{IP: "93.184.216.34", Hostname: "example.com"})
+	m = updated.(Model)
+	if len(m.rowCache.rows) != 0 {
+		t.Error("DNS result should drop cached rows so hostnames show up")
+	}
+}
+
+func BenchmarkRenderAllConnectionsData(b *testing.B) {
+	m := bigSnapshotModel(10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.CurrentView().Cursor = i % 40
+		_ = m.renderAllConnectionsData()
+	}
+}
@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kostyay/netmon/internal/filter"
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// Port scan heuristic: one remote IP opening connections to many distinct
+// listening ports within scanWindow.
+const (
+	scanWindow    = 60 * time.Second
+	scanThreshold = 10 // distinct local ports
+)
+
+// scanAlert is a remote IP flagged by the port scan heuristic.
+type scanAlert struct {
+	IP    string
+	Ports int // distinct local ports touched within scanWindow
+	Since time.Time
+}
+
+// recordScanActivity feeds newly added connections (from the diff layer) into the
+// port scan tracker and raises or clears the scan alert. Only inbound connections
+// count: those whose local port has a LISTEN socket in curr, so outbound
+// connections from ephemeral ports to a busy server don't trip it.
+func (m *Model) recordScanActivity(changes map[ConnectionKey]Change, curr *model.NetworkSnapshot) tea.Cmd {
+	if curr == nil {
+		return nil
+	}
+	if m.scanPorts == nil {
+		m.scanPorts = make(map[string]map[int]time.Time)
+	}
+
+	listening := listeningPorts(curr)
+	for key, change := range changes {
+		if change.Type != ChangeAdded {
+			continue
+		}
+		ip := extractIP(key.RemoteAddr)
+		if !scanCandidate(ip) {
+			continue
+		}
+		ports := filter.Ports(key.LocalAddr)
+		if len(ports) == 0 || !listening[ports[0]] {
+			continue
+		}
+		if m.scanPorts[ip] == nil {
+			m.scanPorts[ip] = make(map[int]time.Time)
+		}
+		m.scanPorts[ip][ports[0]] = change.Timestamp
+	}
+
+	cutoff := curr.Timestamp.Add(-scanWindow)
+	var worst scanAlert
+	for ip, ports := range m.scanPorts {
+		for port, seen := range ports {
+			if seen.Before(cutoff) {
+				delete(ports, port)
+			}
+		}
+		if len(ports) == 0 {
+			delete(m.scanPorts, ip)
+			continue
+		}
+		if len(ports) > worst.Ports || (len(ports) == worst.Ports && ip < worst.IP) {
+			worst = scanAlert{IP: ip, Ports: len(ports)}
+		}
+	}
+
+	if worst.Ports < scanThreshold {
+		m.scanAlert = nil
+		return nil
+	}
+	if m.scanAlert != nil && m.scanAlert.IP == worst.IP {
+		m.scanAlert.Ports = worst.Ports
+		return nil
+	}
+	worst.Since = curr.Timestamp
+	m.scanAlert = &worst
+	return m.notify(toastWarn, fmt.Sprintf(i18n.T("Possible port scan from %s (%d ports) — press %s to filter"), worst.IP, worst.Ports, KeyScanFilter.Key))
+}
+
+// listeningPorts returns the local ports with a LISTEN socket in snap.
+func listeningPorts(snap *model.NetworkSnapshot) map[int]bool {
+	ports := make(map[int]bool)
+	for _, app := range snap.Applications {
+		for _, conn := range app.Connections {
+			if conn.State != model.StateListen {
+				continue
+			}
+			for _, p := range filter.Ports(conn.LocalAddr) {
+				ports[p] = true
+			}
+		}
+	}
+	return ports
+}
+
+// scanCandidate reports whether connections from ip should be tracked.
+// Loopback and unspecified addresses are local tooling, not scans.
+func scanCandidate(ip string) bool {
+	parsed := net.ParseIP(ip)
+	return parsed != nil && !parsed.IsLoopback() && !parsed.IsUnspecified()
+}
+
+// scanBadge returns the header text for the current scan alert, or "".
+func (m Model) scanBadge() string {
+	if m.scanAlert == nil {
+		return ""
+	}
+	return fmt.Sprintf(i18n.T("scan? %s → %d ports (%s: filter)"), m.scanAlert.IP, m.scanAlert.Ports, KeyScanFilter.Key)
+}
+
+// filterScanSource shows the flagged IP's connections in the flat connections view.
+func (m *Model) filterScanSource() tea.Cmd {
+	if m.scanAlert == nil {
+		return m.notify(toastInfo, i18n.T("No port scan detected"))
+	}
+	m.stack = []ViewState{m.newViewState(LevelAllConnections, "")}
+	m.setFilter(m.scanAlert.IP)
+	return nil
+}
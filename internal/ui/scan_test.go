@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// scanSnapshot returns a snapshot with a server listening on ports 8000.. and
+// inbound connections from remote to the first n of them.
+func scanSnapshot(at time.Time, remote string, n int) *model.NetworkSnapshot {
+	conns := []model.Connection{}
+	for i := 0; i < 20; i++ {
+		conns = append(conns, model.Connection{Protocol: model.ProtocolTCP, LocalAddr: fmt.Sprintf("0.0.0.0:%d", 8000+i), RemoteAddr: "*", State: model.StateListen})
+	}
+	for i := 0; i < n; i++ {
+		conns = append(conns, model.Connection{
+			Protocol:   model.ProtocolTCP,
+			LocalAddr:  fmt.Sprintf("10.0.0.5:%d", 8000+i),
+			RemoteAddr: remote + ":40000",
+			State:      model.StateEstablished,
+		})
+	}
+	return &model.NetworkSnapshot{
+		Applications: []model.Application{{Name: "server", PIDs: []int32{10}, Connections: conns}},
+		Timestamp:    at,
+	}
+}
+
+func TestRecordScanActivity_FlagsManyPorts(t *testing.T) {
+	m := createTestModel()
+	start := time.Now()
+	prev := scanSnapshot(start, "203.0.113.9", 0)
+	curr := scanSnapshot(start.Add(time.Second), "203.0.113.9", scanThreshold)
+
+	cmd := m.recordScanActivity(diffConnections(prev, curr), curr)
+
+	if m.scanAlert == nil || m.scanAlert.IP != "203.0.113.9" || m.scanAlert.Ports != scanThreshold {
+		t.Fatalf("scanAlert = %+v, want 203.0.113.9 with %d ports", m.scanAlert, scanThreshold)
+	}
+	if cmd == nil || !strings.Contains(toastText(m), "Possible port scan from 203.0.113.9") {
+		t.Errorf("new alert should toast, got %q", toastText(m))
+	}
+
+	// Same IP again: alert updated, no second toast.
+	m.toasts = nil
+	if cmd := m.recordScanActivity(nil, curr); cmd != nil {
+		t.Error("existing alert should not toast again")
+	}
+}
+
+func TestRecordScanActivity_BelowThreshold(t *testing.T) {
+	m := createTestModel()
+	start := time.Now()
+	prev := scanSnapshot(start, "203.0.113.9", 0)
+	curr := scanSnapshot(start.Add(time.Second), "203.0.113.9", scanThreshold-1)
+
+	if cmd := m.recordScanActivity(diffConnections(prev, curr), curr); cmd != nil || m.scanAlert != nil {
+		t.Errorf("%d ports should not alert", scanThreshold-1)
+	}
+}
+
+func TestRecordScanActivity_IgnoresOutboundAndLoopback(t *testing.T) {
+	m := createTestModel()
+	start := time.Now()
+	prev := &model.NetworkSnapshot{Timestamp: start}
+	var conns []model.Connection
+	for i := 0; i < 2*scanThreshold; i++ {
+		// Outbound: ephemeral local ports, nothing listening on them.
+		conns = append(conns, model.Connection{Protocol: model.ProtocolTCP, LocalAddr: fmt.Sprintf("10.0.0.5:%d", 50000+i), RemoteAddr: "198.51.100.1:443", State: model.StateEstablished})
+	}
+	curr := scanSnapshot(start.Add(time.Second), "127.0.0.1", scanThreshold)
+	curr.Applications = append(curr.Applications, model.Application{Name: "browser", Connections: conns})
+
+	m.recordScanActivity(diffConnections(prev, curr), curr)
+	if m.scanAlert != nil {
+		t.Errorf("outbound and loopback connections should not alert, got %+v", m.scanAlert)
+	}
+}
+
+func TestRecordScanActivity_ExpiresAfterWindow(t *testing.T) {
+	m := createTestModel()
+	start := time.Now()
+	prev := scanSnapshot(start, "203.0.113.9", 0)
+	curr := scanSnapshot(start.Add(time.Second), "203.0.113.9", scanThreshold)
+	m.recordScanActivity(diffConnections(prev, curr), curr)
+
+	later := scanSnapshot(start.Add(time.Second+scanWindow+time.Second), "203.0.113.9", scanThreshold)
+	m.recordScanActivity(nil, later)
+	if m.scanAlert != nil || len(m.scanPorts) != 0 {
+		t.Errorf("alert should clear once activity leaves the window, got %+v", m.scanAlert)
+	}
+}
+
+func TestScanFilterKey(t *testing.T) {
+	m := createTestModel()
+	m.scanAlert = &scanAlert{IP: "203.0.113.9", Ports: 12}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'A'}})
+	m = updated.(Model)
+
+	if !slices.Equal(m.filterChips, []string{"203.0.113.9"}) {
+		t.Errorf("filterChips = %q, want scan source IP", m.filterChips)
+	}
+	if view := m.CurrentView(); view == nil || view.Level != LevelAllConnections {
+		t.Error("scan filter should switch to the all connections view")
+	}
+}
+
+func TestScanFilterKey_NoAlert(t *testing.T) {
+	m := createTestModel()
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'A'}})
+	m = updated.(Model)
+
+	if len(m.filterChips) != 0 {
+		t.Errorf("filterChips = %q, want unchanged", m.filterChips)
+	}
+	if got := toastText(m); got != "No port scan detected" {
+		t.Errorf("toast = %q", got)
+	}
+}
+
+func TestRenderHeader_ShowsScanAlert(t *testing.T) {
+	m := createTestModel()
+	m.width = 200
+	m.scanAlert = &scanAlert{IP: "203.0.113.9", Ports: 12}
+	if header := m.renderHeader(); !containsText(header, "scan? 203.0.113.9 → 12 ports") {
+		t.Errorf("header should show scan alert, got:\n%s", header)
+	}
+}
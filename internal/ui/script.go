@@ -0,0 +1,214 @@
+package ui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/output"
+)
+
+// Script is a list of startup actions loaded with --script, run once the
+// first snapshot arrives. One action per line; blank lines and lines
+// starting with # are ignored:
+//
+//	filter TEXT            add a filter chip
+//	sort COLUMN [asc|desc] sort the current view by a column header
+//	export FILE            write the snapshot as JSON (as --json prints it)
+//	key KEY                press a key, e.g. "key enter" or "key 3"
+//	COMMAND                run a command by id, e.g. "binds" or "dashboard"
+type Script struct {
+	actions []scriptAction
+}
+
+// scriptAction is one parsed script line.
+type scriptAction struct {
+	line int
+	verb string // filter, sort, export or key; commands become key presses
+	arg  string
+	desc bool // sort direction
+}
+
+// namedKeys maps the key names accepted by "key" to their key types. Any
+// other single character is sent as typed.
+var namedKeys = map[string]tea.KeyType{
+	"enter":     tea.KeyEnter,
+	"esc":       tea.KeyEsc,
+	"tab":       tea.KeyTab,
+	"shift+tab": tea.KeyShiftTab,
+	"backspace": tea.KeyBackspace,
+	"delete":    tea.KeyDelete,
+	"up":        tea.KeyUp,
+	"down":      tea.KeyDown,
+	"left":      tea.KeyLeft,
+	"right":     tea.KeyRight,
+	"pgup":      tea.KeyPgUp,
+	"pgdown":    tea.KeyPgDown,
+	"space":     tea.KeySpace,
+	"ctrl+d":    tea.KeyCtrlD,
+	"ctrl+u":    tea.KeyCtrlU,
+}
+
+// LoadScript reads and parses a script file.
+func LoadScript(path string) (*Script, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return ParseScript(f)
+}
+
+// ParseScript parses script actions, rejecting unknown commands and keys so
+// mistakes show up before the TUI starts.
+func ParseScript(r io.Reader) (*Script, error) {
+	s := &Script{}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		arg = strings.TrimSpace(arg)
+		a := scriptAction{line: n, verb: verb, arg: arg}
+		switch verb {
+		case "filter", "export":
+			if arg == "" {
+				return nil, fmt.Errorf("line %d: %s needs an argument", n, verb)
+			}
+		case "sort":
+			col, dir, _ := strings.Cut(arg, " ")
+			switch strings.TrimSpace(dir) {
+			case "", "asc":
+			case "desc":
+				a.desc = true
+			default:
+				return nil, fmt.Errorf("line %d: sort direction must be asc or desc, got %q", n, dir)
+			}
+			if col == "" {
+				return nil, fmt.Errorf("line %d: sort needs a column", n)
+			}
+			a.arg = col
+		case "key":
+			if _, ok := scriptKey(arg); !ok {
+				return nil, fmt.Errorf("line %d: unknown key %q", n, arg)
+			}
+		default:
+			key, err := commandKey(verb)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			a.verb, a.arg = "key", key
+		}
+		s.actions = append(s.actions, a)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// commandKey returns the key that runs the registry command with the given id.
+func commandKey(id string) (string, error) {
+	for _, c := range commandRegistry {
+		if c.id != id {
+			continue
+		}
+		key := c.keys[0].Key
+		if _, ok := scriptKey(key); !ok {
+			return "", fmt.Errorf("command %q takes a key argument: use \"key ...\"", id)
+		}
+		return key, nil
+	}
+	return "", fmt.Errorf("unknown action or command %q", id)
+}
+
+// scriptKey builds the key message for a key name or single character.
+func scriptKey(key string) (tea.KeyMsg, bool) {
+	if t, ok := namedKeys[key]; ok {
+		return tea.KeyMsg{Type: t}, true
+	}
+	if runes := []rune(key); len(runes) == 1 {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: runes}, true
+	}
+	return tea.KeyMsg{}, false
+}
+
+// WithScript returns a copy of the model that runs the script on the first snapshot.
+func (m Model) WithScript(s *Script) Model {
+	m.script = s
+	return m
+}
+
+// runScript runs the pending script once and clears it. Actions that do not
+// apply (an unknown column in the current view, a failed export) are reported
+// as toasts and the rest still run.
+func (m Model) runScript() (Model, tea.Cmd) {
+	if m.script == nil {
+		return m, nil
+	}
+	actions := m.script.actions
+	m.script = nil
+
+	var cmds []tea.Cmd
+	for _, a := range actions {
+		switch a.verb {
+		case "filter":
+			m.addFilterChip(a.arg)
+			m.clampCursor()
+		case "sort":
+			if !m.scriptSort(a.arg, a.desc) {
+				cmds = append(cmds, m.notify(toastWarn, fmt.Sprintf(i18n.T("Script line %d: no column %q in this view"), a.line, a.arg)))
+			}
+		case "export":
+			if err := m.exportSnapshot(a.arg); err != nil {
+				cmds = append(cmds, m.notify(toastError, fmt.Sprintf(i18n.T("Script line %d: export failed: %v"), a.line, err)))
+			} else {
+				cmds = append(cmds, m.notify(toastSuccess, fmt.Sprintf(i18n.T("Exported snapshot to %s"), a.arg)))
+			}
+		case "key":
+			msg, _ := scriptKey(a.arg)
+			updated, cmd := m.Update(msg)
+			m = updated.(Model)
+			cmds = append(cmds, cmd)
+		}
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// scriptSort sorts the current view by the column whose header (or sort
+// name) matches name, ignoring case. Reports false if there is none.
+func (m *Model) scriptSort(name string, desc bool) bool {
+	view := m.CurrentView()
+	if view == nil {
+		return false
+	}
+	for _, col := range m.columnDefsForLevel(view.Level) {
+		if strings.EqualFold(col.label, name) || strings.EqualFold(col.id.String(), name) {
+			view.SortColumn = col.id
+			view.SelectedColumn = col.id
+			view.SortAscending = !desc
+			return true
+		}
+	}
+	return false
+}
+
+// exportSnapshot writes the current snapshot as JSON to path.
+func (m Model) exportSnapshot(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := output.RenderJSON(f, m.snapshot, m.netIOCache); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseScript(t *testing.T) {
+	s, err := ParseScript(strings.NewReader(`
+# open the bind view sorted by address
+binds
+filter 5432
+sort Address desc
+key 3
+`))
+	if err != nil {
+		t.Fatalf("ParseScript: %v", err)
+	}
+	want := []scriptAction{
+		{line: 3, verb: "key", arg: "B"},
+		{line: 4, verb: "filter", arg: "5432"},
+		{line: 5, verb: "sort", arg: "Address", desc: true},
+		{line: 6, verb: "key", arg: "3"},
+	}
+	if len(s.actions) != len(want) {
+		t.Fatalf("actions = %+v, want %+v", s.actions, want)
+	}
+	for i := range want {
+		if s.actions[i] != want[i] {
+			t.Errorf("action %d = %+v, want %+v", i, s.actions[i], want[i])
+		}
+	}
+}
+
+func TestParseScript_Errors(t *testing.T) {
+	for script, want := range map[string]string{
+		"filter":             "line 1: filter needs an argument",
+		"sort PID sideways":  "sort direction",
+		"key nosuchkey":      "unknown key",
+		"\nfly-to-the-moon":  "line 2: unknown action or command",
+		"quick-sort":         "takes a key argument",
+		"export":             "export needs an argument",
+		"sort":               "sort needs a column",
+		"binds\nkey enter\n": "",
+	} {
+		_, err := ParseScript(strings.NewReader(script))
+		if want == "" {
+			if err != nil {
+				t.Errorf("ParseScript(%q) = %v, want no error", script, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseScript(%q) = %v, want error containing %q", script, err, want)
+		}
+	}
+}
+
+func TestScript_RunsOnFirstSnapshot(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "snap.json")
+	s, err := ParseScript(strings.NewReader("filter App2\nsort pid desc\nexport " + out + "\nselect\n"))
+	if err != nil {
+		t.Fatalf("ParseScript: %v", err)
+	}
+
+	m := NewModel().WithScript(s)
+	updated, _ := m.Update(DataMsg{Snapshot: createTestSnapshot()})
+	m = updated.(Model)
+
+	if m.script != nil {
+		t.Error("script should be cleared after running")
+	}
+	// The filter leaves App2 as the only row, so select drills into it
+	// (drilling clears the chips)
+	if view := m.CurrentView(); view.Level != LevelConnections || view.ProcessName != "App2" {
+		t.Errorf("view = %v %q, want App2 connections after select", view.Level, view.ProcessName)
+	}
+	if m.stack[0].SortColumn != SortPID || m.stack[0].SortAscending {
+		t.Errorf("process list sort = %v asc=%v, want PID descending", m.stack[0].SortColumn, m.stack[0].SortAscending)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("export not written: %v", err)
+	}
+	if !json.Valid(data) || !strings.Contains(string(data), "App2") {
+		t.Errorf("export = %s, want JSON snapshot", data)
+	}
+
+	// A second snapshot does not rerun the script
+	updated, _ = m.Update(DataMsg{Snapshot: createTestSnapshot()})
+	if got := len(updated.(Model).stack); got != 2 {
+		t.Errorf("stack depth after second snapshot = %d, want 2", got)
+	}
+}
+
+func TestScript_UnknownColumnWarns(t *testing.T) {
+	s, err := ParseScript(strings.NewReader("sort Nope"))
+	if err != nil {
+		t.Fatalf("ParseScript: %v", err)
+	}
+	m := NewModel().WithScript(s)
+	updated, _ := m.Update(DataMsg{Snapshot: createTestSnapshot()})
+	m = updated.(Model)
+	if len(m.toasts) != 1 || !strings.Contains(m.toasts[0].Message, `no column "Nope"`) {
+		t.Errorf("toasts = %+v, want unknown column warning", m.toasts)
+	}
+}
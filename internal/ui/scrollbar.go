@@ -0,0 +1,39 @@
+package ui
+
+import "fmt"
+
+// Scrollbar glyphs drawn on the frame's right border.
+const (
+	scrollTrack = "│"
+	scrollThumb = "┃"
+)
+
+// scrollbarThumb returns the first row and height of the scrollbar thumb for a
+// viewport of the given height showing total lines starting at offset.
+// Returns size 0 when all content fits (no scrollbar needed).
+func scrollbarThumb(total, height, offset int) (start, size int) {
+	if height <= 0 || total <= height {
+		return 0, 0
+	}
+	size = max(height*height/total, 1)
+	maxOffset := total - height
+	offset = min(max(offset, 0), maxOffset)
+	start = (offset*(height-size) + maxOffset/2) / maxOffset
+	return start, size
+}
+
+// scrollPercentLabel returns a short position marker like "Top", "Bot" or "42%".
+// Returns empty string when all content fits.
+func scrollPercentLabel(total, height, offset int) string {
+	if height <= 0 || total <= height {
+		return ""
+	}
+	switch {
+	case offset <= 0:
+		return "Top"
+	case offset >= total-height:
+		return "Bot"
+	default:
+		return fmt.Sprintf("%d%%", offset*100/(total-height))
+	}
+}
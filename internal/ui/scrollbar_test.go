@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+func TestScrollbarThumb(t *testing.T) {
+	tests := []struct {
+		name                  string
+		total, height, offset int
+		wantStart, wantSize   int
+	}{
+		{"fits", 10, 20, 0, 0, 0},
+		{"exact fit", 20, 20, 0, 0, 0},
+		{"top", 100, 10, 0, 0, 1},
+		{"bottom", 100, 10, 90, 9, 1},
+		{"middle", 40, 20, 10, 5, 10},
+		{"offset clamped", 40, 20, 99, 10, 10},
+		{"zero height", 40, 0, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, size := scrollbarThumb(tt.total, tt.height, tt.offset)
+			if start != tt.wantStart || size != tt.wantSize {
+				t.Errorf("scrollbarThumb(%d, %d, %d) = (%d, %d), want (%d, %d)",
+					tt.total, tt.height, tt.offset, start, size, tt.wantStart, tt.wantSize)
+			}
+		})
+	}
+}
+
+func TestScrollPercentLabel(t *testing.T) {
+	tests := []struct {
+		total, height, offset int
+		want                  string
+	}{
+		{10, 20, 0, ""},
+		{100, 10, 0, "Top"},
+		{100, 10, 90, "Bot"},
+		{100, 10, 45, "50%"},
+	}
+	for _, tt := range tests {
+		if got := scrollPercentLabel(tt.total, tt.height, tt.offset); got != tt.want {
+			t.Errorf("scrollPercentLabel(%d, %d, %d) = %q, want %q", tt.total, tt.height, tt.offset, got, tt.want)
+		}
+	}
+}
+
+// manyAppsModel returns a sized model with n single-connection processes.
+func manyAppsModel(n int) Model {
+	m := createTestModel()
+	apps := make([]model.Application, n)
+	for i := range apps {
+		apps[i] = model.Application{
+			Name:        fmt.Sprintf("app%03d", i),
+			PIDs:        []int32{int32(1000 + i)},
+			Connections: []model.Connection{{PID: int32(1000 + i), Protocol: model.ProtocolTCP}},
+		}
+	}
+	m.snapshot = &model.NetworkSnapshot{Applications: apps}
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	return updated.(Model)
+}
+
+func TestUpdate_HalfPageDownAndUp(t *testing.T) {
+	m := manyAppsModel(100)
+	half := m.viewport.Height / 2
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlD})
+	m = updated.(Model)
+	if m.CurrentView().Cursor != half {
+		t.Errorf("after ctrl+d cursor = %d, want %d", m.CurrentView().Cursor, half)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	m = updated.(Model)
+	if m.CurrentView().Cursor != 0 {
+		t.Errorf("after ctrl+u cursor = %d, want 0", m.CurrentView().Cursor)
+	}
+}
+
+func TestUpdate_HalfPageDown_ClampsAtEnd(t *testing.T) {
+	m := manyAppsModel(5)
+	for i := 0; i < 5; i++ {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlD})
+		m = updated.(Model)
+	}
+	if m.CurrentView().Cursor != 4 {
+		t.Errorf("cursor = %d, want 4 (last row)", m.CurrentView().Cursor)
+	}
+}
+
+func TestUpdate_HomeEndAndVimJumps(t *testing.T) {
+	m := manyAppsModel(50)
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnd})
+	m = updated.(Model)
+	if m.CurrentView().Cursor != 49 {
+		t.Errorf("after end cursor = %d, want 49", m.CurrentView().Cursor)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyHome})
+	m = updated.(Model)
+	if m.CurrentView().Cursor != 0 {
+		t.Errorf("after home cursor = %d, want 0", m.CurrentView().Cursor)
+	}
+
+	m = pressKey(m, "G")
+	if m.CurrentView().Cursor != 49 {
+		t.Errorf("after G cursor = %d, want 49", m.CurrentView().Cursor)
+	}
+	m = pressKey(m, "g")
+	if m.CurrentView().Cursor != 49 {
+		t.Errorf("a single g moved the cursor to %d", m.CurrentView().Cursor)
+	}
+	m = pressKey(m, "g")
+	if m.CurrentView().Cursor != 0 {
+		t.Errorf("after gg cursor = %d, want 0", m.CurrentView().Cursor)
+	}
+
+	// Another key between the two g's cancels the pair
+	m = pressKey(pressKey(pressKey(m, "G"), "g"), "k")
+	m = pressKey(m, "g")
+	if m.CurrentView().Cursor != 48 || !m.pendingG {
+		t.Errorf("g k g: cursor = %d pending = %v, want 48 and a pending g", m.CurrentView().Cursor, m.pendingG)
+	}
+}
+
+func TestUpdate_WrapNavigation(t *testing.T) {
+	m := manyAppsModel(5)
+	m = pressKey(m, "k")
+	if m.CurrentView().Cursor != 0 {
+		t.Fatalf("without wrap, up on the first row moved to %d", m.CurrentView().Cursor)
+	}
+
+	m.wrapNav = true
+	m = pressKey(m, "k")
+	if m.CurrentView().Cursor != 4 {
+		t.Errorf("up on the first row = %d, want 4", m.CurrentView().Cursor)
+	}
+	m = pressKey(m, "j")
+	if m.CurrentView().Cursor != 0 {
+		t.Errorf("down on the last row = %d, want 0", m.CurrentView().Cursor)
+	}
+}
+
+func TestView_ScrollbarShownForLongLists(t *testing.T) {
+	m := manyAppsModel(100)
+	out := m.View()
+	if !strings.Contains(out, scrollThumb) {
+		t.Error("expected scrollbar thumb in frame for long list")
+	}
+	if !strings.Contains(out, " Top ") {
+		t.Error("expected Top position label on bottom border")
+	}
+
+	short := manyAppsModel(3)
+	if strings.Contains(short.View(), scrollThumb) {
+		t.Error("scrollbar should not be shown when content fits")
+	}
+}
@@ -0,0 +1,63 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/model"
+	"github.com/kostyay/netmon/internal/security"
+)
+
+// lookupSecurity resolves a process security context (replaced in tests).
+var lookupSecurity = security.Lookup
+
+// securityColumn is the optional process list column shown when security context is enabled.
+var securityColumn = columnDef{label: "Security", id: SortSecurity, minWidth: 12, flex: 2}
+
+// securityLabel returns the security summary for an app's primary PID,
+// or "…" while the lookup is pending.
+func (m Model) securityLabel(pids []int32) string {
+	if len(pids) == 0 {
+		return "-"
+	}
+	ctx, ok := m.securityCache[pids[0]]
+	if !ok {
+		return "…"
+	}
+	return ctx.String()
+}
+
+// resolveSecurity returns a command that looks up the security context of a process.
+func (m Model) resolveSecurity(pid int32, exe string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, err := lookupSecurity(pid, exe)
+		return SecurityResolvedMsg{PID: pid, Context: ctx, Err: err}
+	}
+}
+
+// queueSecurityLookups returns commands for processes whose security context is not cached.
+// Only the primary PID of each application is looked up.
+func (m Model) queueSecurityLookups(snapshot *model.NetworkSnapshot) tea.Cmd {
+	if !m.securityContext || snapshot == nil {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	for _, app := range snapshot.Applications {
+		if len(app.PIDs) == 0 {
+			continue
+		}
+		if _, ok := m.securityCache[app.PIDs[0]]; ok {
+			continue
+		}
+		// Limit per refresh to avoid flooding (codesign is slow on macOS)
+		if len(cmds) >= 10 {
+			break
+		}
+		cmds = append(cmds, m.resolveSecurity(app.PIDs[0], app.Exe))
+	}
+
+	if len(cmds) == 0 {
+		return nil
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/security"
+)
+
+func TestActiveProcessListColumns_Security(t *testing.T) {
+	m := createTestModel()
+	if n := len(m.activeProcessListColumns()); n != len(processListColumns()) {
+		t.Errorf("columns = %d, want %d when disabled", n, len(processListColumns()))
+	}
+	m.securityContext = true
+	cols := m.activeProcessListColumns()
+	if cols[len(cols)-1].id != SortSecurity {
+		t.Errorf("last column = %v, want Security", cols[len(cols)-1].id)
+	}
+	if got := m.columnsForLevel(LevelProcessList); got[len(got)-1] != SortSecurity {
+		t.Error("sort mode should be able to select the Security column")
+	}
+}
+
+func TestQueueSecurityLookups(t *testing.T) {
+	m := createTestModel()
+	if cmd := m.queueSecurityLookups(m.snapshot); cmd != nil {
+		t.Error("no lookups expected when disabled")
+	}
+
+	m.securityContext = true
+	m.securityCache = map[int32]security.Context{100: {}}
+	var looked []int32
+	orig := lookupSecurity
+	lookupSecurity = func(pid int32, exe string) (security.Context, error) {
+		looked = append(looked, pid)
+		return security.Context{}, nil
+	}
+	t.Cleanup(func() { lookupSecurity = orig })
+
+	cmd := m.queueSecurityLookups(m.snapshot)
+	if cmd == nil {
+		t.Fatal("expected lookups for uncached PIDs")
+	}
+	for _, msg := range cmd().(tea.BatchMsg) {
+		msg()
+	}
+	if len(looked) != 2 || looked[0] != 200 || looked[1] != 300 {
+		t.Errorf("looked up %v, want [200 300]", looked)
+	}
+}
+
+func TestSecurityResolvedMsg_Cached(t *testing.T) {
+	m := createTestModel()
+	m.securityContext = true
+
+	updated, _ := m.Update(SecurityResolvedMsg{PID: 100, Context: security.Context{Kind: "apparmor", Label: "unconfined"}})
+	m = updated.(Model)
+	if got := m.securityLabel([]int32{100}); got != "apparmor:unconfined" {
+		t.Errorf("securityLabel = %q, want apparmor:unconfined", got)
+	}
+
+	// Failures are cached as empty context so they aren't retried every refresh
+	updated, _ = m.Update(SecurityResolvedMsg{PID: 200, Err: errors.New("gone")})
+	m = updated.(Model)
+	if got := m.securityLabel([]int32{200}); got != "-" {
+		t.Errorf("securityLabel after error = %q, want -", got)
+	}
+	if got := m.securityLabel([]int32{300}); got != "…" {
+		t.Errorf("securityLabel pending = %q, want …", got)
+	}
+}
+
+func TestSecurityContext_RenderedInListAndDetail(t *testing.T) {
+	m := createTestModel()
+	m.securityContext = true
+	m.securityCache = map[int32]security.Context{100: {Kind: "selinux", Label: "httpd_t", Sandboxed: true}}
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 30})
+	m = updated.(Model)
+
+	if out := m.View(); !strings.Contains(out, "Security") || !strings.Contains(out, "selinux:httpd_t") {
+		t.Error("process list should show Security column with label")
+	}
+
+	m.stack = append(m.stack, ViewState{Level: LevelConnections, ProcessName: "App1", SortColumn: SortLocal, SelectedColumn: SortLocal})
+	if out := m.renderFrozenHeader(); !strings.Contains(out, "Security: selinux:httpd_t sandboxed") {
+		t.Errorf("connections header should show security line, got:\n%s", out)
+	}
+}
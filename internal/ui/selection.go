@@ -10,8 +10,7 @@ func (m Model) findProcessIndex(name string) int {
 	if name == "" {
 		return -1
 	}
-	apps := m.filteredApps()
-	apps = m.sortProcessList(apps)
+	apps := m.visibleApps()
 	for i, app := range apps {
 		if app.Name == name {
 			return i
@@ -36,8 +35,7 @@ func (m Model) findConnectionIndex(key *model.ConnectionKey) int {
 	case LevelConnections:
 		selectedApp := m.findSelectedApp(view.ProcessName)
 		if selectedApp != nil {
-			conns := m.filteredConnections(selectedApp.Connections)
-			conns = m.sortConnectionsForView(conns)
+			conns := m.visibleConnections(selectedApp)
 			for j, conn := range conns {
 				if m.connectionMatchesKey(conn, key) {
 					return j
@@ -45,11 +43,9 @@ func (m Model) findConnectionIndex(key *model.ConnectionKey) int {
 			}
 		}
 	case LevelAllConnections:
-		conns := m.sortAllConnections(m.filteredAllConnections())
+		conns := m.visibleAllConnections()
 		for i, cwp := range conns {
-			if cwp.ProcessName == key.ProcessName &&
-				cwp.LocalAddr == key.LocalAddr &&
-				cwp.RemoteAddr == key.RemoteAddr {
+			if key.Matches(cwp.ProcessName, cwp.Connection) {
 				return i
 			}
 		}
@@ -63,10 +59,7 @@ func (m Model) connectionMatchesKey(conn model.Connection, key *model.Connection
 		return false
 	}
 	// For process match, we need to find the process name from the connection's PID
-	processName := m.getProcessNameByPID(conn.PID)
-	return processName == key.ProcessName &&
-		conn.LocalAddr == key.LocalAddr &&
-		conn.RemoteAddr == key.RemoteAddr
+	return key.Matches(m.getProcessNameByPID(conn.PID), conn)
 }
 
 // getProcessNameByPID finds the process name for a given PID.
@@ -104,6 +97,12 @@ func (m Model) resolveSelectionIndex() int {
 			return idx
 		}
 	case LevelConnections, LevelAllConnections:
+		// Stay put on an identical socket further down (e.g. SO_REUSEPORT)
+		if key := view.SelectedID.ConnectionKey; key != nil {
+			if c := m.selectedConnection(); c != nil && key.Matches(c.ProcessName, c.Connection) {
+				return view.Cursor
+			}
+		}
 		idx := m.findConnectionIndex(view.SelectedID.ConnectionKey)
 		if idx >= 0 {
 			return idx
@@ -123,16 +122,20 @@ func (m *Model) validateSelection() {
 	var itemCount int
 	switch view.Level {
 	case LevelProcessList:
-		apps := m.filteredApps()
-		itemCount = len(apps) + len(m.filteredVirtualContainers())
+		itemCount = len(m.visibleApps()) + len(m.filteredVirtualContainers())
 	case LevelConnections:
 		selectedApp := m.findSelectedApp(view.ProcessName)
 		if selectedApp != nil {
-			conns := m.filteredConnections(selectedApp.Connections)
-			itemCount = len(conns)
+			itemCount = len(m.visibleConnections(selectedApp))
 		}
 	case LevelAllConnections:
-		itemCount = len(m.filteredAllConnections())
+		itemCount = len(m.visibleAllConnections())
+	case LevelConntrack:
+		itemCount = len(m.filteredConntrack())
+	case LevelDockerPorts:
+		itemCount = len(m.filteredPortMappings())
+	case LevelBinds:
+		itemCount = len(m.filteredBinds())
 	}
 
 	if itemCount == 0 {
@@ -141,19 +144,8 @@ func (m *Model) validateSelection() {
 		return
 	}
 
-	// For connection views, just clamp cursor - don't try ID resolution
-	// (connections can have duplicate keys, so ID tracking doesn't work)
-	if view.Level == LevelConnections || view.Level == LevelAllConnections {
-		if view.Cursor >= itemCount {
-			view.Cursor = itemCount - 1
-		}
-		if view.Cursor < 0 {
-			view.Cursor = 0
-		}
-		return
-	}
-
-	// For process list, try to resolve SelectedID to follow item across re-sorts
+	// Resolve SelectedID to follow the process or connection across re-sorts
+	// (conntrack, Docker port and bind views have no IDs and keep the cursor)
 	if view.SelectedID.ProcessName != "" {
 		resolvedIdx := m.resolveSelectionIndex()
 		if resolvedIdx >= 0 && resolvedIdx < itemCount {
@@ -183,8 +175,7 @@ func (m *Model) updateSelectedIDFromCursor() {
 
 	switch view.Level {
 	case LevelProcessList:
-		apps := m.filteredApps()
-		apps = m.sortProcessList(apps)
+		apps := m.visibleApps()
 		if view.Cursor >= 0 && view.Cursor < len(apps) {
 			view.SelectedID = model.SelectionIDFromProcess(apps[view.Cursor].Name)
 		} else {
@@ -197,19 +188,45 @@ func (m *Model) updateSelectedIDFromCursor() {
 	case LevelConnections:
 		selectedApp := m.findSelectedApp(view.ProcessName)
 		if selectedApp != nil {
-			conns := m.filteredConnections(selectedApp.Connections)
-			conns = m.sortConnectionsForView(conns)
+			conns := m.visibleConnections(selectedApp)
 			if view.Cursor >= 0 && view.Cursor < len(conns) {
 				conn := conns[view.Cursor]
 				processName := m.getProcessNameByPID(conn.PID)
-				view.SelectedID = model.SelectionIDFromConnection(processName, conn.LocalAddr, conn.RemoteAddr)
+				view.SelectedID = model.SelectionIDFromConnection(processName, conn)
 			}
 		}
 	case LevelAllConnections:
-		conns := m.sortAllConnections(m.filteredAllConnections())
+		conns := m.visibleAllConnections()
 		if view.Cursor >= 0 && view.Cursor < len(conns) {
 			cwp := conns[view.Cursor]
-			view.SelectedID = model.SelectionIDFromConnection(cwp.ProcessName, cwp.LocalAddr, cwp.RemoteAddr)
+			view.SelectedID = model.SelectionIDFromConnection(cwp.ProcessName, cwp.Connection)
+		}
+	}
+}
+
+// selectedConnection returns the connection under the cursor in a connection view, or nil.
+func (m Model) selectedConnection() *connectionWithProcess {
+	view := m.CurrentView()
+	if view == nil || m.snapshot == nil {
+		return nil
+	}
+	switch view.Level {
+	case LevelConnections:
+		selectedApp := m.findSelectedApp(view.ProcessName)
+		if selectedApp == nil {
+			return nil
+		}
+		conns := m.visibleConnections(selectedApp)
+		if view.Cursor < 0 || view.Cursor >= len(conns) {
+			return nil
+		}
+		return &connectionWithProcess{Connection: conns[view.Cursor], ProcessName: selectedApp.Name}
+	case LevelAllConnections:
+		conns := m.visibleAllConnections()
+		if view.Cursor < 0 || view.Cursor >= len(conns) {
+			return nil
 		}
+		return &conns[view.Cursor]
 	}
+	return nil
 }
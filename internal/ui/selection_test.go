@@ -40,7 +40,7 @@ func TestFindProcessIndex_EmptyName(t *testing.T) {
 
 func TestFindProcessIndex_WithFilter(t *testing.T) {
 	m := createTestModel()
-	m.activeFilter = "App1" // Only App1 visible
+	m.filterChips = []string{"App1"} // Only App1 visible
 
 	idx := m.findProcessIndex("App1")
 	if idx != 0 {
@@ -541,8 +541,8 @@ func TestUpdateSelectedIDFromCursor_CursorOutOfBounds(t *testing.T) {
 
 func TestValidateSelection_WithFilter(t *testing.T) {
 	m := createTestModel()
-	m.activeFilter = "App1"    // Only App1 visible
-	m.CurrentView().Cursor = 5 // Out of bounds for filtered list
+	m.filterChips = []string{"App1"} // Only App1 visible
+	m.CurrentView().Cursor = 5       // Out of bounds for filtered list
 
 	m.validateSelection()
 
@@ -563,3 +563,27 @@ func TestFindProcessIndex_SortedByPIDDescending(t *testing.T) {
 		t.Errorf("findProcessIndex('App1') with PID desc sort = %d, want 2", idx)
 	}
 }
+
+func TestValidateSelection_FollowsConnectionByProtocol(t *testing.T) {
+	m := createTestModel()
+	m.snapshot = &model.NetworkSnapshot{Applications: []model.Application{{
+		Name: "dnsmasq", PIDs: []int32{53},
+		Connections: []model.Connection{
+			{Protocol: model.ProtocolTCP, PID: 53, LocalAddr: "0.0.0.0:53", RemoteAddr: "*:*", State: model.StateListen},
+			{Protocol: model.ProtocolUDP, PID: 53, LocalAddr: "0.0.0.0:53", RemoteAddr: "*:*"},
+		},
+	}}}
+	m.PushView(ViewState{Level: LevelConnections, ProcessName: "dnsmasq", SortColumn: SortProtocol, SortAscending: true})
+	m.moveDown() // UDP, same addresses as the TCP row
+
+	if c := m.selectedConnection(); c == nil || c.Protocol != model.ProtocolUDP {
+		t.Fatalf("selected %+v, want the UDP socket", c)
+	}
+	m.CurrentView().SortAscending = false // UDP now first
+	m.pipeline.invalidate()
+	m.validateSelection()
+
+	if c := m.selectedConnection(); c == nil || c.Protocol != model.ProtocolUDP || m.CurrentView().Cursor != 0 {
+		t.Errorf("after re-sort selected %+v at %d, want the UDP socket at 0", c, m.CurrentView().Cursor)
+	}
+}
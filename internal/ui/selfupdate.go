@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/release"
+)
+
+// applySelfUpdate downloads, verifies and installs the latest release over the
+// running binary, returning the installed version (replaced in tests).
+var applySelfUpdate = func(ctx context.Context, current string) (string, error) {
+	u, err := release.FindUpdate(ctx, "kostyay", "netmon", current, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return "", err
+	}
+	if u == nil {
+		return "", fmt.Errorf("no newer release found")
+	}
+	exe, err := release.Executable()
+	if err != nil {
+		return "", err
+	}
+	if err := release.Apply(ctx, u, exe); err != nil {
+		return "", err
+	}
+	return u.Version, nil
+}
+
+// SelfUpdateMsg reports the result of a self-update started with 'U'.
+type SelfUpdateMsg struct {
+	Version string // installed release tag
+	Err     error
+}
+
+// enterUpdateMode opens the self-update confirmation when a newer release is known.
+func (m Model) enterUpdateMode() (tea.Model, tea.Cmd) {
+	if m.updateAvailable == "" {
+		return m, nil
+	}
+	if m.updating {
+		return m, m.notify(toastInfo, i18n.T("Update already in progress"))
+	}
+	m.updateMode = true
+	return m, nil
+}
+
+// executeUpdate starts the download in the background; the header shows progress.
+func (m Model) executeUpdate() (tea.Model, tea.Cmd) {
+	m.updateMode = false
+	m.updating = true
+	return m, m.selfUpdateCmd()
+}
+
+func (m Model) selfUpdateCmd() tea.Cmd {
+	current := m.version
+	return func() tea.Msg {
+		v, err := applySelfUpdate(context.Background(), current)
+		return SelfUpdateMsg{Version: v, Err: err}
+	}
+}
+
+// handleSelfUpdate reports the outcome; the new binary takes effect on restart.
+func (m Model) handleSelfUpdate(msg SelfUpdateMsg) (tea.Model, tea.Cmd) {
+	m.updating = false
+	if msg.Err != nil {
+		m.recordError(sourceUpdate, msg.Err)
+		return m, m.notify(toastError, fmt.Sprintf(i18n.T("Update failed: %v"), msg.Err))
+	}
+	m.updateAvailable = ""
+	return m, m.notify(toastSuccess, fmt.Sprintf(i18n.T("Installed %s — restart netmon to use it"), msg.Version))
+}
+
+// renderUpdateModalContent returns the self-update confirmation modal content.
+func (m Model) renderUpdateModalContent() string {
+	keyStyle := HeaderStyle()
+	descStyle := FooterDescStyle()
+	dimStyle := DimmedStyle()
+
+	current := m.version
+	if current == "" {
+		current = "dev"
+	}
+	lines := []string{
+		"",
+		keyStyle.Render("  " + fmt.Sprintf(i18n.T("Update netmon %s → %s?"), current, m.updateAvailable)),
+		"",
+		dimStyle.Render("  " + fmt.Sprintf(i18n.T("Downloads the release for %s, verifies"), runtime.GOOS+"/"+runtime.GOARCH)),
+		dimStyle.Render("  " + i18n.T("its sha256 against checksums.txt and replaces")),
+		dimStyle.Render("  " + i18n.T("this binary. Restart netmon afterwards.")),
+		"",
+		"  " + keyStyle.Render("↵") + descStyle.Render(" "+i18n.T("Update")+"  ") +
+			keyStyle.Render("Esc") + descStyle.Render(" "+i18n.T("Cancel")),
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func stubSelfUpdate(t *testing.T, fn func(ctx context.Context, current string) (string, error)) {
+	t.Helper()
+	old := applySelfUpdate
+	applySelfUpdate = fn
+	t.Cleanup(func() { applySelfUpdate = old })
+}
+
+func TestSelfUpdateKey_NoUpdateIsNoop(t *testing.T) {
+	m := pressKey(createTestModel(), "U")
+	if m.updateMode {
+		t.Error("U without an available update should not open the confirmation")
+	}
+}
+
+func TestSelfUpdateKey_OpensAndCancels(t *testing.T) {
+	m := createTestModel()
+	m.updateAvailable = "v9.9.9"
+
+	m = pressKey(m, "U")
+	if !m.updateMode {
+		t.Fatal("U should open the update confirmation")
+	}
+	if !strings.Contains(m.renderUpdateModalContent(), "v9.9.9") {
+		t.Error("confirmation should name the new version")
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.updateMode || m.updating {
+		t.Error("Esc should cancel without updating")
+	}
+}
+
+func TestSelfUpdate_ConfirmRunsUpdate(t *testing.T) {
+	var gotCurrent string
+	stubSelfUpdate(t, func(ctx context.Context, current string) (string, error) {
+		gotCurrent = current
+		return "v9.9.9", nil
+	})
+	m := createTestModel()
+	m.width = 160
+	m.version = "v1.0.0"
+	m.updateAvailable = "v9.9.9"
+	m = pressKey(m, "U")
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if !m.updating || m.updateMode || cmd == nil {
+		t.Fatal("Enter should close the modal and start updating")
+	}
+	if !strings.Contains(m.renderHeader(), "installing") {
+		t.Error("header should show the update in progress")
+	}
+
+	result := m.selfUpdateCmd()().(SelfUpdateMsg)
+	if gotCurrent != "v1.0.0" || result.Version != "v9.9.9" {
+		t.Fatalf("update ran with current=%q, result=%+v", gotCurrent, result)
+	}
+
+	updated, _ = m.Update(result)
+	m = updated.(Model)
+	if m.updating || m.updateAvailable != "" {
+		t.Error("successful update should clear the update badge")
+	}
+	if !strings.Contains(toastText(m), "restart netmon") {
+		t.Errorf("toast = %q, want restart hint", toastText(m))
+	}
+}
+
+func TestSelfUpdate_Failure(t *testing.T) {
+	m := createTestModel()
+	m.updateAvailable = "v9.9.9"
+	m.updating = true
+
+	updated, _ := m.Update(SelfUpdateMsg{Err: errors.New("checksum mismatch")})
+	m = updated.(Model)
+	if m.updating || m.updateAvailable != "v9.9.9" {
+		t.Error("failed update should keep the badge so it can be retried")
+	}
+	if !strings.Contains(toastText(m), "checksum mismatch") {
+		t.Errorf("toast = %q, want the failure reason", toastText(m))
+	}
+	if len(m.diagLog) != 1 || m.diagLog[0].Source != sourceUpdate {
+		t.Errorf("diagLog = %+v, want an update entry", m.diagLog)
+	}
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// sessionTotals is what a process did since netmon started. OS byte counters
+// belong to a PID and start over when the process restarts, so totals are
+// kept per process name and survive restarts.
+type sessionTotals struct {
+	TX, RX uint64
+	opened int                // connections opened (seen appearing in a snapshot diff)
+	pids   map[int32]struct{} // PIDs seen under this name
+}
+
+// sessionFor returns the totals for a process name, creating them.
+func (m *Model) sessionFor(name string) *sessionTotals {
+	if m.sessionTotals == nil {
+		m.sessionTotals = make(map[string]*sessionTotals)
+	}
+	st := m.sessionTotals[name]
+	if st == nil {
+		st = &sessionTotals{pids: make(map[int32]struct{})}
+		m.sessionTotals[name] = st
+	}
+	return st
+}
+
+// recordSessionIO adds each PID's bytes since the previous netIO sample to
+// its process's session totals. Must run before the sample replaces netIOCache.
+// The first sample is the baseline. A PID first seen later counts its whole
+// counter, since it started during the session, unless its start time is
+// known to be earlier. A counter that went backwards (PID reuse) counts from zero.
+func (m *Model) recordSessionIO(stats map[int32]*model.NetIOStats, at time.Time) {
+	if m.snapshot == nil {
+		return
+	}
+	baseline := m.sessionStart.IsZero()
+	if baseline {
+		m.sessionStart = at
+	}
+	for _, app := range m.snapshot.Applications {
+		for _, pid := range app.PIDs {
+			curr := stats[pid]
+			if curr == nil {
+				continue
+			}
+			st := m.sessionFor(app.Name)
+			st.pids[pid] = struct{}{}
+			if baseline {
+				continue
+			}
+			prev := m.netIOCache[pid]
+			switch {
+			case prev == nil:
+				if start, ok := m.startTimes[pid]; ok && !start.IsZero() && start.Before(m.sessionStart) {
+					continue
+				}
+				st.TX += curr.BytesSent
+				st.RX += curr.BytesRecv
+			default:
+				st.TX += counterDelta(prev.BytesSent, curr.BytesSent)
+				st.RX += counterDelta(prev.BytesRecv, curr.BytesRecv)
+			}
+		}
+	}
+}
+
+// counterDelta returns how far a byte counter moved, treating a decrease
+// as a reset to zero.
+func counterDelta(prev, curr uint64) uint64 {
+	if curr < prev {
+		return curr
+	}
+	return curr - prev
+}
+
+// recordSessionOpened adds a churn sample's opened connections to the session totals.
+func (m *Model) recordSessionOpened(s churnSample) {
+	for name, n := range s.opened {
+		m.sessionFor(name).opened += n
+	}
+}
+
+// sessionTotalsLine returns the session totals for the process drill-down
+// header, e.g. "Session total TX 1.2 MB  RX 3.4 MB  |  57 opened  |  3 PIDs seen",
+// or "" before anything was counted.
+func (m Model) sessionTotalsLine(name string) string {
+	st := m.sessionTotals[name]
+	if st == nil || (st.TX == 0 && st.RX == 0 && st.opened == 0) {
+		return ""
+	}
+	line := fmt.Sprintf(i18n.T("Session total TX %s  RX %s  |  %d opened"), formatBytes(st.TX), formatBytes(st.RX), st.opened)
+	if len(st.pids) > 1 {
+		line += "  |  " + fmt.Sprintf(i18n.T("%d PIDs seen"), len(st.pids))
+	}
+	return line
+}
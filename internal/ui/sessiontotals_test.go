@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// sendNetIO feeds one netIO sample through Update.
+func sendNetIO(m Model, stats map[int32]*model.NetIOStats) Model {
+	updated, _ := m.Update(NetIOMsg{Stats: stats})
+	return updated.(Model)
+}
+
+func TestSessionTotals_SurviveRestart(t *testing.T) {
+	m := createTestModel()
+
+	// The first sample is the baseline: lifetime counters before launch don't count
+	m = sendNetIO(m, map[int32]*model.NetIOStats{100: {BytesSent: 5000, BytesRecv: 9000}})
+	m = sendNetIO(m, map[int32]*model.NetIOStats{100: {BytesSent: 6000, BytesRecv: 9500}})
+
+	// App1 restarts as PID 101; its counters start over
+	m.snapshot.Applications[0].PIDs = []int32{101}
+	m = sendNetIO(m, map[int32]*model.NetIOStats{101: {BytesSent: 300, BytesRecv: 200}})
+	m = sendNetIO(m, map[int32]*model.NetIOStats{101: {BytesSent: 700, BytesRecv: 200}})
+
+	st := m.sessionTotals["App1"]
+	if st == nil {
+		t.Fatal("no session totals for App1")
+	}
+	if st.TX != 1700 || st.RX != 700 {
+		t.Errorf("TX/RX = %d/%d, want 1700/700 across both PIDs", st.TX, st.RX)
+	}
+	if len(st.pids) != 2 {
+		t.Errorf("PIDs seen = %d, want 2", len(st.pids))
+	}
+}
+
+func TestSessionTotals_OldProcessSeenLateIsBaselined(t *testing.T) {
+	m := createTestModel()
+	m = sendNetIO(m, map[int32]*model.NetIOStats{100: {BytesSent: 10}})
+
+	// PID 200 shows up later but started long before netmon
+	m.startTimes = map[int32]time.Time{200: time.Now().Add(-time.Hour)}
+	m = sendNetIO(m, map[int32]*model.NetIOStats{200: {BytesSent: 1 << 20}})
+
+	if st := m.sessionTotals["App2"]; st != nil && st.TX != 0 {
+		t.Errorf("TX = %d, want lifetime bytes before launch excluded", st.TX)
+	}
+}
+
+func TestCounterDelta_Reset(t *testing.T) {
+	if got := counterDelta(100, 150); got != 50 {
+		t.Errorf("counterDelta(100, 150) = %d, want 50", got)
+	}
+	if got := counterDelta(100, 30); got != 30 {
+		t.Errorf("counterDelta(100, 30) = %d, want 30 (reset)", got)
+	}
+}
+
+func TestSessionTotals_ConnectionsOpened(t *testing.T) {
+	m := createTestModel()
+	prev := createTestSnapshot()
+	curr := createTestSnapshot()
+	curr.Timestamp = prev.Timestamp.Add(time.Second)
+	curr.Applications[0].Connections = append(curr.Applications[0].Connections,
+		model.Connection{Protocol: "TCP", LocalAddr: "127.0.0.1:5000", RemoteAddr: "1.1.1.1:443"},
+		model.Connection{Protocol: "TCP", LocalAddr: "127.0.0.1:5001", RemoteAddr: "1.1.1.1:443"})
+
+	m.recordChurn(prev, curr)
+	m.recordChurn(curr, prev) // closing doesn't subtract
+
+	if got := m.sessionTotals["App1"].opened; got != 2 {
+		t.Errorf("opened = %d, want 2", got)
+	}
+}
+
+func TestSessionTotalsLine(t *testing.T) {
+	m := createTestModel()
+	if got := m.sessionTotalsLine("App1"); got != "" {
+		t.Errorf("line before totals = %q, want empty", got)
+	}
+
+	st := m.sessionFor("App1")
+	st.TX, st.RX, st.opened = 2048, 1024*1024, 57
+	st.pids[100], st.pids[101] = struct{}{}, struct{}{}
+
+	got := m.sessionTotalsLine("App1")
+	for _, want := range []string{"TX 2.0 KB", "RX 1.0 MB", "57 opened", "2 PIDs seen"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("line = %q, missing %q", got, want)
+		}
+	}
+}
+
+func TestSessionTotals_ShownInDrillDown(t *testing.T) {
+	m := createTestModel()
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	m = updated.(Model)
+	m.sessionFor("App1").opened = 3
+	m.stack = append(m.stack, ViewState{Level: LevelConnections, ProcessName: "App1"})
+
+	if out := stripAnsi(m.View()); !strings.Contains(out, "Session total") {
+		t.Errorf("drill-down view missing session totals:\n%s", out)
+	}
+}
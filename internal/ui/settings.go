@@ -0,0 +1,304 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kostyay/netmon/internal/collector"
+	"github.com/kostyay/netmon/internal/config"
+	"github.com/kostyay/netmon/internal/security"
+)
+
+// settingItem describes a toggle shown in the settings modal.
+type settingItem struct {
+	name   string
+	desc   string
+	get    func(m *Model) bool
+	value  func(m *Model) string  // multi-choice settings: current choice, shown instead of the checkbox
+	toggle func(m *Model) tea.Cmd // flips the setting (or cycles a choice); may return a follow-up command
+}
+
+// settingItems returns the settings modal entries in display order.
+// New settings should be appended to keep existing cursor positions stable.
+func settingItems() []settingItem {
+	return []settingItem{
+		{
+			name: "DNS Resolution",
+			desc: "Reverse lookup IPs to hostnames",
+			get:  func(m *Model) bool { return m.dnsEnabled },
+			toggle: func(m *Model) tea.Cmd {
+				m.dnsEnabled = !m.dnsEnabled
+				config.CurrentSettings.DNSEnabled = m.dnsEnabled
+				return nil
+			},
+		},
+		{
+			name: "Service Names",
+			desc: "Show http/https instead of 80/443",
+			get:  func(m *Model) bool { return m.serviceNames },
+			toggle: func(m *Model) tea.Cmd {
+				m.serviceNames = !m.serviceNames
+				config.CurrentSettings.ServiceNames = m.serviceNames
+				return nil
+			},
+		},
+		{
+			name: "Highlight Changes",
+			desc: "Mark new and changed connections (see Change Style)",
+			get:  func(m *Model) bool { return m.highlightChanges },
+			toggle: func(m *Model) tea.Cmd {
+				m.highlightChanges = !m.highlightChanges
+				config.CurrentSettings.HighlightChanges = m.highlightChanges
+				return nil
+			},
+		},
+		{
+			name: "Animations",
+			desc: "Enable UI animations (pulse, spinners)",
+			get:  func(m *Model) bool { return m.animations },
+			toggle: func(m *Model) tea.Cmd {
+				m.animations = !m.animations
+				config.CurrentSettings.Animations = m.animations
+				if m.animations {
+					return m.animationTickCmd()
+				}
+				return nil
+			},
+		},
+		{
+			name: "Docker Containers",
+			desc: "Show containers as process rows",
+			get:  func(m *Model) bool { return m.dockerContainers },
+			toggle: func(m *Model) tea.Cmd {
+				m.dockerContainers = !m.dockerContainers
+				config.CurrentSettings.DockerContainers = m.dockerContainers
+				if m.dockerContainers {
+					return m.fetchDockerContainers()
+				}
+				m.virtualContainers = nil
+				m.createdContainers = nil
+				return nil
+			},
+		},
+		{
+			name: "Group By Executable",
+			desc: "Split same-named processes by exe path",
+			get:  func(m *Model) bool { return m.groupByExe },
+			toggle: func(m *Model) tea.Cmd {
+				m.groupByExe = !m.groupByExe
+				config.CurrentSettings.GroupByExe = m.groupByExe
+				m.applyCollectorOptions()
+				return m.fetchData()
+			},
+		},
+		{
+			name: "Security Context",
+			desc: "Show AppArmor/SELinux/codesign info",
+			get:  func(m *Model) bool { return m.securityContext },
+			toggle: func(m *Model) tea.Cmd {
+				m.securityContext = !m.securityContext
+				config.CurrentSettings.SecurityContext = m.securityContext
+				if m.securityContext {
+					return m.queueSecurityLookups(m.snapshot)
+				}
+				m.securityCache = make(map[int32]security.Context)
+				return nil
+			},
+		},
+		{
+			name: "Churn Columns",
+			desc: "Show new/closed connections per second",
+			get:  func(m *Model) bool { return m.churnColumns },
+			toggle: func(m *Model) tea.Cmd {
+				m.churnColumns = !m.churnColumns
+				config.CurrentSettings.ChurnColumns = m.churnColumns
+				return nil
+			},
+		},
+		{
+			name: "Hide Loopback",
+			desc: "Drop 127.0.0.1/::1 connections (count in header)",
+			get:  func(m *Model) bool { return m.hideLoopback },
+			toggle: func(m *Model) tea.Cmd {
+				m.hideLoopback = !m.hideLoopback
+				config.CurrentSettings.HideLoopback = m.hideLoopback
+				m.applyCollectorOptions()
+				return m.fetchData()
+			},
+		},
+		{
+			name: "Instant Refresh",
+			desc: "Refresh on socket changes (Linux)",
+			get:  func(m *Model) bool { return m.instantRefresh },
+			toggle: func(m *Model) tea.Cmd {
+				m.instantRefresh = !m.instantRefresh
+				config.CurrentSettings.InstantRefresh = m.instantRefresh
+				if m.instantRefresh {
+					return startSockWatch
+				}
+				m.stopSockWatch()
+				return nil
+			},
+		},
+		{
+			name: "TCP Stats",
+			desc: "RTT and retransmit columns (Linux)",
+			get:  func(m *Model) bool { return m.tcpStats },
+			toggle: func(m *Model) tea.Cmd {
+				m.tcpStats = !m.tcpStats
+				config.CurrentSettings.TCPStats = m.tcpStats
+				m.applyCollectorOptions()
+				return m.fetchData()
+			},
+		},
+		{
+			name: "Process Env",
+			desc: "Allow 'e' to show cwd and whitelisted env vars",
+			get:  func(m *Model) bool { return m.processEnv },
+			toggle: func(m *Model) tea.Cmd {
+				m.processEnv = !m.processEnv
+				config.CurrentSettings.ProcessEnv = m.processEnv
+				if !m.processEnv {
+					m.envExpanded = false
+					m.envCache = nil
+				}
+				return nil
+			},
+		},
+		{
+			name: "Protocol Detail",
+			desc: "L7 column: TLS, HTTP/2, SSH, ... from port and process",
+			get:  func(m *Model) bool { return m.protoDetail },
+			toggle: func(m *Model) tea.Cmd {
+				m.protoDetail = !m.protoDetail
+				config.CurrentSettings.ProtocolDetail = m.protoDetail
+				return nil
+			},
+		},
+		{
+			name:  "Change Style",
+			desc:  "flash, fade (3s background), gutter (+/-/~) or count (header)",
+			value: func(m *Model) string { return string(m.changeStyle) },
+			toggle: func(m *Model) tea.Cmd {
+				m.changeStyle = m.changeStyle.next()
+				config.CurrentSettings.ChangeStyle = string(m.changeStyle)
+				return nil
+			},
+		},
+		{
+			name: "Process Uptime",
+			desc: "Show how long each process has been running",
+			get:  func(m *Model) bool { return m.showUptime },
+			toggle: func(m *Model) tea.Cmd {
+				m.showUptime = !m.showUptime
+				config.CurrentSettings.ProcessUptime = m.showUptime
+				if m.showUptime {
+					return m.queueStartTimeLookups(m.snapshot)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Interfaces",
+			desc: "Iface column: en0, utun3 (VPN), docker0, ... per connection",
+			get:  func(m *Model) bool { return m.showIfaces },
+			toggle: func(m *Model) tea.Cmd {
+				m.showIfaces = !m.showIfaces
+				config.CurrentSettings.Interfaces = m.showIfaces
+				return nil
+			},
+		},
+		{
+			name: "Wrap Navigation",
+			desc: "Up/down wrap around at the first and last row",
+			get:  func(m *Model) bool { return m.wrapNav },
+			toggle: func(m *Model) tea.Cmd {
+				m.wrapNav = !m.wrapNav
+				config.CurrentSettings.WrapNavigation = m.wrapNav
+				return nil
+			},
+		},
+		{
+			name: "Group Domains",
+			desc: "Remote hosts panel (H): fold *.amazonaws.com etc. into one row (needs DNS)",
+			get:  func(m *Model) bool { return m.groupDomains },
+			toggle: func(m *Model) tea.Cmd {
+				m.groupDomains = !m.groupDomains
+				config.CurrentSettings.GroupDomains = m.groupDomains
+				m.hostsCursor = 0
+				return nil
+			},
+		},
+		{
+			name: "Remember Pins",
+			desc: "Keep pinned processes (*) at the top in the next session too",
+			get:  func(m *Model) bool { return m.rememberPins },
+			toggle: func(m *Model) tea.Cmd {
+				m.rememberPins = !m.rememberPins
+				config.CurrentSettings.RememberPins = m.rememberPins
+				config.CurrentSettings.Pins = nil
+				if m.rememberPins {
+					config.CurrentSettings.Pins = m.pinNames()
+				}
+				return nil
+			},
+		},
+		{
+			name: "Open Files",
+			desc: "FDs column: open files vs the RLIMIT_NOFILE limit (Linux)",
+			get:  func(m *Model) bool { return m.showFDs },
+			toggle: func(m *Model) tea.Cmd {
+				m.showFDs = !m.showFDs
+				config.CurrentSettings.OpenFiles = m.showFDs
+				if m.showFDs {
+					return m.queueFDLookups(m.snapshot)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Cgroups",
+			desc: "Unit column: systemd service or container per process (Linux)",
+			get:  func(m *Model) bool { return m.showCgroups },
+			toggle: func(m *Model) tea.Cmd {
+				m.showCgroups = !m.showCgroups
+				config.CurrentSettings.Cgroups = m.showCgroups
+				if m.showCgroups {
+					return m.queueCgroupLookups(m.snapshot)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Ephemeral Flows",
+			desc: "Log connections opened and closed between refreshes (Linux)",
+			get:  func(m *Model) bool { return m.ephemeralFlows },
+			toggle: func(m *Model) tea.Cmd {
+				m.ephemeralFlows = !m.ephemeralFlows
+				config.CurrentSettings.EphemeralFlows = m.ephemeralFlows
+				if m.ephemeralFlows {
+					return startEphemeralWatch
+				}
+				m.stopEphemeralWatch()
+				return nil
+			},
+		},
+	}
+}
+
+// toggleSetting flips the setting at idx and persists all settings.
+func (m *Model) toggleSetting(idx int) tea.Cmd {
+	items := settingItems()
+	if idx < 0 || idx >= len(items) {
+		return nil
+	}
+	cmd := items[idx].toggle(m)
+	_ = config.SaveSettings(config.CurrentSettings)
+	return cmd
+}
+
+// applyCollectorOptions pushes collection-affecting settings to the collector, if it supports them.
+// Large host mode groups by process name, the coarser aggregation.
+func (m *Model) applyCollectorOptions() {
+	if c, ok := m.collector.(collector.Configurable); ok {
+		c.SetOptions(collector.Options{GroupByExe: m.groupByExe && !m.largeHost, HideLoopback: m.hideLoopback, TCPStats: m.tcpStats, SampleCap: m.sampleCap})
+	}
+}
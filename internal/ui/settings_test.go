@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSettingItems_OrderStable(t *testing.T) {
+	items := settingItems()
+	want := []string{"DNS Resolution", "Service Names", "Highlight Changes", "Animations", "Docker Containers", "Group By Executable", "Security Context", "Churn Columns", "Hide Loopback", "Instant Refresh", "TCP Stats", "Process Env", "Protocol Detail", "Change Style", "Process Uptime", "Interfaces"}
+	if len(items) < len(want) {
+		t.Fatalf("len(settingItems()) = %d, want at least %d", len(items), len(want))
+	}
+	for i, name := range want {
+		if items[i].name != name {
+			t.Errorf("settingItems()[%d].name = %q, want %q", i, items[i].name, name)
+		}
+	}
+}
+
+func TestSettingsMode_CursorBoundedByItems(t *testing.T) {
+	m := createTestModel()
+	m.settingsMode = true
+
+	for i := 0; i < len(settingItems())+3; i++ {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+		m = updated.(Model)
+	}
+	if m.settingsCursor != len(settingItems())-1 {
+		t.Errorf("settingsCursor = %d, want %d", m.settingsCursor, len(settingItems())-1)
+	}
+}
+
+func TestSettingsToggle_GroupByExe(t *testing.T) {
+	m := createTestModel()
+	mc := m.collector.(*mockCollector)
+	m.settingsMode = true
+	m.settingsCursor = 5 // Group By Executable
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	newModel := updated.(Model)
+
+	if !newModel.groupByExe {
+		t.Error("groupByExe should be true after toggle")
+	}
+	if !mc.opts.GroupByExe {
+		t.Error("collector should receive GroupByExe option")
+	}
+	if cmd == nil {
+		t.Error("toggling grouping should trigger a data refresh")
+	}
+
+	// Toggle back
+	newModel.settingsMode = true
+	updated, _ = newModel.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	newModel = updated.(Model)
+	if newModel.groupByExe || mc.opts.GroupByExe {
+		t.Error("groupByExe should be false after second toggle")
+	}
+}
+
+func TestRenderSettingsModal_ShowsGroupByExe(t *testing.T) {
+	m := createTestModel()
+	m.groupByExe = true
+	content := m.renderSettingsModalContent()
+	if !strings.Contains(content, "[■] Group By Executable") {
+		t.Errorf("settings modal should show enabled Group By Executable, got:\n%s", content)
+	}
+}
+
+func TestSettingsToggle_HideLoopback(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	m := createTestModel()
+	mc := m.collector.(*mockCollector)
+	m.settingsMode = true
+	m.settingsCursor = 8 // Hide Loopback
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	newModel := updated.(Model)
+
+	if !newModel.hideLoopback || !mc.opts.HideLoopback {
+		t.Error("hideLoopback should be enabled and pushed to the collector")
+	}
+	if cmd == nil {
+		t.Error("toggling loopback hiding should trigger a data refresh")
+	}
+}
+
+func TestRenderHeader_ShowsLoopbackHidden(t *testing.T) {
+	m := createTestModel()
+	m.width = 160
+	if strings.Contains(m.renderHeader(), "loopback") {
+		t.Error("header should not mention loopback when nothing is hidden")
+	}
+	m.snapshot.LoopbackCount = 42
+	if !strings.Contains(m.renderHeader(), "+42 loopback hidden") {
+		t.Error("header should show the hidden loopback count")
+	}
+}
+
+func TestWithCollectors_PushesOptions(t *testing.T) {
+	m := createTestModel()
+	m.tcpStats = true
+	mc := &mockCollector{}
+	mn := &mockNetIOCollector{}
+
+	m = m.WithCollectors(mc, mn)
+
+	if m.collector != mc || m.netIOCollector != mn {
+		t.Fatal("WithCollectors should replace both collectors")
+	}
+	if !mc.opts.TCPStats {
+		t.Error("current settings should be pushed to the new collector")
+	}
+}
@@ -0,0 +1,18 @@
+package ui
+
+import "fmt"
+
+// socketLine returns the connections view detail line for the selected
+// connection's socket, e.g. "Socket: PID 1234 fd 23  |  lsof -a -p 1234 -d 23",
+// for correlating with lsof/strace. Returns "" without a selection.
+// No thread is shown: threads share their process's descriptor table.
+func (m Model) socketLine() string {
+	conn := m.selectedConnection()
+	if conn == nil {
+		return ""
+	}
+	if conn.FD == 0 {
+		return fmt.Sprintf("Socket: PID %d fd unknown", conn.PID)
+	}
+	return fmt.Sprintf("Socket: PID %d fd %d  |  lsof -a -p %d -d %d", conn.PID, conn.FD, conn.PID, conn.FD)
+}
@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSocketLine_SelectedConnection(t *testing.T) {
+	m := chipsTestModel()
+	m.width = 120
+	m.snapshot.Applications[0].Connections[1].FD = 23
+	m.PushView(ViewState{Level: LevelConnections, ProcessName: "chrome", SortColumn: SortLocal, SortAscending: true})
+
+	if got := m.socketLine(); got != "Socket: PID 10 fd unknown" {
+		t.Errorf("first row = %q, want fd unknown", got)
+	}
+	before := m.frozenHeaderHeight()
+
+	m.CurrentView().Cursor = 1
+	want := "Socket: PID 10 fd 23  |  lsof -a -p 10 -d 23"
+	if got := m.socketLine(); got != want {
+		t.Errorf("second row = %q, want %q", got, want)
+	}
+	if !strings.Contains(stripAnsi(m.renderFrozenHeader()), want) {
+		t.Error("frozen header should show the socket line")
+	}
+	if m.frozenHeaderHeight() != before {
+		t.Error("header height should not change with the selection")
+	}
+}
+
+func TestSocketLine_NoSelection(t *testing.T) {
+	m := chipsTestModel()
+	if m.socketLine() != "" {
+		t.Error("process list has no selected socket")
+	}
+}
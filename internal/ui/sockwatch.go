@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/sockwatch"
+)
+
+// startSockWatch starts a socket table watcher in the background.
+func startSockWatch() tea.Msg {
+	w := sockwatch.New(sockwatch.DefaultInterval)
+	if err := w.Start(); err != nil {
+		return SockWatchStartedMsg{Err: err}
+	}
+	return SockWatchStartedMsg{Watcher: w}
+}
+
+// waitSocketChange blocks until w reports a socket table change.
+func waitSocketChange(w *sockwatch.Watcher) tea.Cmd {
+	return func() tea.Msg {
+		ev, ok := <-w.Events()
+		if !ok {
+			return SocketChangeMsg{Watcher: w, Closed: true}
+		}
+		return SocketChangeMsg{Watcher: w, Err: ev.Err}
+	}
+}
+
+// handleSockWatchStarted adopts a newly started watcher, or reports why instant
+// refresh is unavailable. The tick poll keeps running either way.
+func (m *Model) handleSockWatchStarted(msg SockWatchStartedMsg) tea.Cmd {
+	if msg.Err != nil {
+		m.recordError(sourceSockWatch, msg.Err)
+		return m.notify(toastWarn, fmt.Sprintf(i18n.T("Instant refresh unavailable: %v"), msg.Err))
+	}
+	if !m.instantRefresh {
+		// Setting was turned off while the watcher was starting.
+		msg.Watcher.Stop()
+		return nil
+	}
+	m.stopSockWatch()
+	m.sockWatcher = msg.Watcher
+	return waitSocketChange(m.sockWatcher)
+}
+
+// handleSocketChange refetches connections after a socket table change and
+// waits for the next one. Events from a stopped or replaced watcher are dropped.
+func (m *Model) handleSocketChange(msg SocketChangeMsg) tea.Cmd {
+	if msg.Closed || msg.Watcher != m.sockWatcher {
+		return nil
+	}
+	if msg.Err != nil {
+		m.recordError(sourceSockWatch, msg.Err)
+		return waitSocketChange(m.sockWatcher)
+	}
+	return tea.Batch(m.fetchData(), waitSocketChange(m.sockWatcher))
+}
+
+// stopSockWatch stops the running watcher, if any.
+func (m *Model) stopSockWatch() {
+	if m.sockWatcher != nil {
+		m.sockWatcher.Stop()
+		m.sockWatcher = nil
+	}
+}
@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kostyay/netmon/internal/netlink"
+	"github.com/kostyay/netmon/internal/sockwatch"
+)
+
+func TestHandleSockWatchStarted_Unsupported(t *testing.T) {
+	m := createTestModel()
+	m.instantRefresh = true
+
+	updated, cmd := m.Update(SockWatchStartedMsg{Err: netlink.ErrUnsupported})
+	m = updated.(Model)
+
+	if m.sockWatcher != nil {
+		t.Error("no watcher should be kept when start fails")
+	}
+	if cmd == nil {
+		t.Error("start failure should schedule a toast expiry")
+	}
+	if got := toastText(m); !strings.Contains(got, "Instant refresh unavailable") {
+		t.Errorf("toast = %q, want instant refresh warning", got)
+	}
+	if len(m.diagLog) != 1 || m.diagLog[0].Source != sourceSockWatch {
+		t.Errorf("diagLog = %+v, want one sockwatch entry", m.diagLog)
+	}
+}
+
+func TestHandleSockWatchStarted_AdoptsWatcher(t *testing.T) {
+	m := createTestModel()
+	m.instantRefresh = true
+	w := sockwatch.New(0)
+
+	updated, cmd := m.Update(SockWatchStartedMsg{Watcher: w})
+	m = updated.(Model)
+
+	if m.sockWatcher != w {
+		t.Error("started watcher should be adopted")
+	}
+	if cmd == nil {
+		t.Error("should wait for the first socket change")
+	}
+}
+
+func TestHandleSockWatchStarted_SettingTurnedOff(t *testing.T) {
+	m := createTestModel()
+	m.instantRefresh = false
+
+	updated, cmd := m.Update(SockWatchStartedMsg{Watcher: sockwatch.New(0)})
+	m = updated.(Model)
+
+	if m.sockWatcher != nil || cmd != nil {
+		t.Error("watcher started after the setting was disabled should be dropped")
+	}
+}
+
+func TestHandleSocketChange(t *testing.T) {
+	m := createTestModel()
+	m.instantRefresh = true
+	current := sockwatch.New(0)
+	m.sockWatcher = current
+
+	tests := []struct {
+		name    string
+		msg     SocketChangeMsg
+		wantCmd bool
+		wantErr bool
+	}{
+		{"change refetches", SocketChangeMsg{Watcher: current}, true, false},
+		{"dump error keeps waiting", SocketChangeMsg{Watcher: current, Err: errors.New("netlink recv: EINTR")}, true, true},
+		{"stale watcher dropped", SocketChangeMsg{Watcher: sockwatch.New(0)}, false, false},
+		{"closed watcher dropped", SocketChangeMsg{Watcher: current, Closed: true}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mm := m
+			mm.diagLog = nil
+			cmd := mm.handleSocketChange(tt.msg)
+			if (cmd != nil) != tt.wantCmd {
+				t.Errorf("cmd = %v, want cmd: %v", cmd != nil, tt.wantCmd)
+			}
+			if (len(mm.diagLog) > 0) != tt.wantErr {
+				t.Errorf("diagLog = %+v, want error recorded: %v", mm.diagLog, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSettingsToggle_InstantRefreshOffStopsWatcher(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	m := createTestModel()
+	m.instantRefresh = true
+	w := sockwatch.New(0)
+	m.sockWatcher = w
+	m.settingsMode = true
+	m.settingsCursor = 9 // Instant Refresh
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+
+	if m.instantRefresh || m.sockWatcher != nil {
+		t.Error("disabling instant refresh should stop and drop the watcher")
+	}
+	if cmd := waitSocketChange(w); cmd().(SocketChangeMsg).Closed != true {
+		t.Error("stopped watcher should report closed")
+	}
+}
+
+func TestRenderHeader_ShowsInstantRefresh(t *testing.T) {
+	m := createTestModel()
+	m.width = 160
+	if strings.Contains(m.renderHeader(), "⚡") {
+		t.Error("header should not show instant refresh marker without a watcher")
+	}
+	m.sockWatcher = sockwatch.New(0)
+	if !strings.Contains(m.renderHeader(), "⚡") {
+		t.Error("header should show instant refresh marker while watching")
+	}
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"github.com/kostyay/netmon/internal/config"
+)
+
+// defaultSort is the sort column/direction used when no preference is saved.
+var defaultSort = map[ViewLevel]struct {
+	column    SortColumn
+	ascending bool
+}{
+	LevelProcessList:    {SortAuto, true}, // most interesting first
+	LevelConnections:    {SortLocal, true},
+	LevelAllConnections: {SortProcess, true},
+	LevelConntrack:      {SortNAT, false}, // NATed flows first
+	LevelDockerPorts:    {SortContainer, true},
+	LevelBinds:          {SortBindScope, true}, // wildcard binds first
+}
+
+// viewPrefKey returns the settings key for a view level's sort and refresh preferences.
+func viewPrefKey(level ViewLevel) string {
+	switch level {
+	case LevelProcessList:
+		return "processList"
+	case LevelConnections:
+		return "connections"
+	case LevelAllConnections:
+		return "allConnections"
+	case LevelConntrack:
+		return "conntrack"
+	case LevelDockerPorts:
+		return "dockerPorts"
+	case LevelBinds:
+		return "binds"
+	default:
+		return ""
+	}
+}
+
+// parseSortColumn returns the SortColumn with the given String() name.
+func parseSortColumn(name string) (SortColumn, bool) {
+	for c := SortPID; c <= SortAuto; c++ {
+		if c.String() == name {
+			return c, true
+		}
+	}
+	return 0, false
+}
+
+// newViewState returns a ViewState for level, using the remembered sort if it
+// names a column available in that view (or the auto sort), otherwise the default sort.
+// Set dockerView before calling for connection views (it changes the columns).
+func (m Model) newViewState(level ViewLevel, processName string) ViewState {
+	def := defaultSort[level]
+	col, asc := def.column, def.ascending
+	if pref, ok := m.sortPrefs[viewPrefKey(level)]; ok {
+		if c, ok := parseSortColumn(pref.Column); ok && (containsColumn(m.columnsForLevel(level), c) || c == def.column) {
+			col, asc = c, pref.Ascending
+		}
+	}
+	return ViewState{
+		Level:          level,
+		ProcessName:    processName,
+		SortColumn:     col,
+		SortAscending:  asc,
+		SelectedColumn: col,
+	}
+}
+
+// rememberSort stores the current view's sort as the preference for its level and persists it.
+// No-op when sort persistence is disabled (sortPrefs is nil).
+func (m *Model) rememberSort(view *ViewState) {
+	if m.sortPrefs == nil || view == nil {
+		return
+	}
+	key := viewPrefKey(view.Level)
+	if key == "" {
+		return
+	}
+	pref := config.SortPref{Column: view.SortColumn.String(), Ascending: view.SortAscending}
+	m.sortPrefs[key] = pref
+	if config.CurrentSettings.Sort == nil {
+		config.CurrentSettings.Sort = make(map[string]config.SortPref)
+	}
+	config.CurrentSettings.Sort[key] = pref
+	_ = config.SaveSettings(config.CurrentSettings)
+}
+
+// containsColumn reports whether cols contains c.
+func containsColumn(cols []SortColumn, c SortColumn) bool {
+	for _, col := range cols {
+		if col == c {
+			return true
+		}
+	}
+	return false
+}
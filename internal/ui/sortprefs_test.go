@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/config"
+)
+
+func TestParseSortColumn_RoundTrip(t *testing.T) {
+	for c := SortPID; c <= SortRetrans; c++ {
+		got, ok := parseSortColumn(c.String())
+		if !ok || got != c {
+			t.Errorf("parseSortColumn(%q) = %v, %v; want %v", c.String(), got, ok, c)
+		}
+	}
+	if _, ok := parseSortColumn("Bogus"); ok {
+		t.Error("parseSortColumn should reject unknown names")
+	}
+}
+
+func TestNewViewState_Defaults(t *testing.T) {
+	m := createTestModel()
+	tests := []struct {
+		level ViewLevel
+		col   SortColumn
+		asc   bool
+	}{
+		{LevelProcessList, SortAuto, true},
+		{LevelConnections, SortLocal, true},
+		{LevelAllConnections, SortProcess, true},
+		{LevelConntrack, SortNAT, false},
+	}
+	for _, tt := range tests {
+		v := m.newViewState(tt.level, "")
+		if v.SortColumn != tt.col || v.SortAscending != tt.asc || v.SelectedColumn != tt.col {
+			t.Errorf("%v: got %v/%v, want %v/%v", tt.level, v.SortColumn, v.SortAscending, tt.col, tt.asc)
+		}
+	}
+}
+
+func TestNewViewState_UsesSavedPreference(t *testing.T) {
+	m := createTestModel()
+	m.sortPrefs = map[string]config.SortPref{
+		"processList": {Column: "Established", Ascending: false},
+		"connections": {Column: "Container"}, // only valid in Docker view
+	}
+
+	v := m.newViewState(LevelProcessList, "")
+	if v.SortColumn != SortEstablished || v.SortAscending {
+		t.Errorf("process list sort = %v/%v, want Established desc", v.SortColumn, v.SortAscending)
+	}
+
+	v = m.newViewState(LevelConnections, "App1")
+	if v.SortColumn != SortLocal {
+		t.Errorf("unavailable column should fall back to default, got %v", v.SortColumn)
+	}
+
+	m.dockerView = true
+	v = m.newViewState(LevelConnections, "docker")
+	if v.SortColumn != SortContainer {
+		t.Errorf("docker view sort = %v, want Container", v.SortColumn)
+	}
+}
+
+func TestRememberSort_AppliedOnDrillDown(t *testing.T) {
+	m := createTestModel()
+	m.sortPrefs = map[string]config.SortPref{}
+	t.Setenv("HOME", t.TempDir()) // keep SaveSettings away from the real config
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	origSort := config.CurrentSettings.Sort
+	t.Cleanup(func() { config.CurrentSettings.Sort = origSort })
+
+	// Sort the connections view by State, descending
+	view := ViewState{Level: LevelConnections, ProcessName: "App1", SortColumn: SortState, SortAscending: false}
+	m.rememberSort(&view)
+
+	if got := m.sortPrefs["connections"]; got.Column != "State" || got.Ascending {
+		t.Errorf("sortPrefs[connections] = %+v, want State desc", got)
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if v := m.CurrentView(); v.Level != LevelConnections || v.SortColumn != SortState || v.SortAscending {
+		t.Errorf("drilled view sort = %v/%v, want State desc", v.SortColumn, v.SortAscending)
+	}
+}
+
+func TestRememberSort_DisabledWithoutPrefs(t *testing.T) {
+	m := createTestModel()
+	view := ViewState{Level: LevelProcessList, SortColumn: SortPID}
+	m.rememberSort(&view) // must not panic with nil sortPrefs
+	if m.sortPrefs != nil {
+		t.Error("sortPrefs should remain nil")
+	}
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kostyay/netmon/internal/i18n"
+)
+
+// staleAfterTicks is how many refresh intervals the displayed snapshot may age
+// before the header flags it: one collection in flight plus one missed tick.
+const staleAfterTicks = 2
+
+// snapshotAge returns how long ago the displayed snapshot was collected, or 0
+// before the first one.
+func (m Model) snapshotAge(now time.Time) time.Duration {
+	if m.snapshot == nil || m.snapshotAt.IsZero() {
+		return 0
+	}
+	return now.Sub(m.snapshotAt)
+}
+
+// staleBadge returns "data 6s old" when the snapshot is older than
+// staleAfterTicks refresh intervals (collection failing, lagging or a missed
+// tick), or "" while it is fresh.
+func (m Model) staleBadge(now time.Time) string {
+	age := m.snapshotAge(now)
+	if age <= staleAfterTicks*m.effectiveRefreshInterval() {
+		return ""
+	}
+	return fmt.Sprintf(i18n.T("data %s old"), strings.TrimSuffix(formatAgo(age), " ago"))
+}
+
+// collectionSummary describes the last collection for the diagnostics panel.
+func (m Model) collectionSummary(now time.Time) string {
+	if m.snapshotAt.IsZero() {
+		return i18n.T("No snapshot yet")
+	}
+	return fmt.Sprintf(i18n.T("Last snapshot took %s, collected %s"), formatCollectDuration(m.collectDuration), formatAgo(now.Sub(m.snapshotAt)))
+}
+
+// formatCollectDuration formats a collection time as "85ms" or "1.2s".
+func formatCollectDuration(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+func TestStaleBadge(t *testing.T) {
+	now := time.Now()
+	m := createTestModel()
+	m.refreshInterval = 2 * time.Second
+
+	if badge := m.staleBadge(now); badge != "" {
+		t.Errorf("badge before first snapshot = %q, want none", badge)
+	}
+
+	m.snapshotAt = now.Add(-3 * time.Second)
+	if badge := m.staleBadge(now); badge != "" {
+		t.Errorf("badge within two ticks = %q, want none", badge)
+	}
+
+	m.snapshotAt = now.Add(-6 * time.Second)
+	if badge := m.staleBadge(now); badge != "data 6s old" {
+		t.Errorf("badge = %q, want %q", badge, "data 6s old")
+	}
+}
+
+func TestDataMsg_RecordsTiming(t *testing.T) {
+	m := createTestModel()
+	at := time.Now().Add(-time.Second)
+
+	updated, _ := m.Update(DataMsg{
+		Snapshot:    &model.NetworkSnapshot{},
+		CollectedAt: at,
+		Duration:    120 * time.Millisecond,
+	})
+	m = updated.(Model)
+
+	if !m.snapshotAt.Equal(at) || m.collectDuration != 120*time.Millisecond {
+		t.Errorf("snapshotAt = %v, collectDuration = %v", m.snapshotAt, m.collectDuration)
+	}
+	if got := m.collectionSummary(at.Add(2 * time.Second)); got != "Last snapshot took 120ms, collected 2s ago" {
+		t.Errorf("summary = %q", got)
+	}
+}
+
+func TestDataMsg_ErrorKeepsSnapshotAge(t *testing.T) {
+	m := createTestModel()
+	at := time.Now().Add(-time.Minute)
+	m.snapshotAt = at
+
+	updated, _ := m.Update(DataMsg{Err: errors.New("collect failed")})
+	m = updated.(Model)
+
+	if !m.snapshotAt.Equal(at) {
+		t.Error("a failed collection should not refresh the snapshot time")
+	}
+}
+
+func TestRenderHeader_ShowsStaleness(t *testing.T) {
+	m := createTestModel()
+	m.width = 160
+	m.refreshInterval = time.Second
+
+	m.snapshotAt = time.Now()
+	if strings.Contains(m.renderHeader(), "old") {
+		t.Error("fresh snapshot should not be flagged")
+	}
+	m.snapshotAt = time.Now().Add(-10 * time.Second)
+	if header := m.renderHeader(); !strings.Contains(header, "data 10s old") {
+		t.Errorf("header should flag stale data, got:\n%s", header)
+	}
+}
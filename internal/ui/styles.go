@@ -13,105 +13,109 @@ import (
 func HeaderStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color(config.CurrentTheme.Styles.Header.TitleFg))
+		Foreground(themeColor(config.CurrentTheme.Styles.Header.TitleFg))
 }
 
 // FooterStyle returns the style for footer text.
 func FooterStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color(config.CurrentTheme.Styles.Footer.FgColor))
+		Foreground(themeColor(config.CurrentTheme.Styles.Footer.FgColor))
 }
 
 // FooterKeyStyle returns the style for keyboard shortcut keys in footer.
 func FooterKeyStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color(config.CurrentTheme.Styles.Footer.KeyFgColor))
+		Foreground(themeColor(config.CurrentTheme.Styles.Footer.KeyFgColor))
 }
 
 // FooterDescStyle returns the style for key descriptions in footer.
 func FooterDescStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color(config.CurrentTheme.Styles.Footer.DescFgColor))
+		Foreground(themeColor(config.CurrentTheme.Styles.Footer.DescFgColor))
 }
 
 // StatusStyle returns the style for status bar text.
 func StatusStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color(config.CurrentTheme.Styles.Status.FgColor))
+		Foreground(themeColor(config.CurrentTheme.Styles.Status.FgColor))
 }
 
 // LoadingStyle returns the style for loading indicators.
 func LoadingStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color(config.CurrentTheme.Styles.Status.FgColor)).
+		Foreground(themeColor(config.CurrentTheme.Styles.Status.FgColor)).
 		Italic(true)
 }
 
 // EmptyStyle returns the style for empty state messages.
 func EmptyStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color(config.CurrentTheme.Styles.Status.FgColor)).
+		Foreground(themeColor(config.CurrentTheme.Styles.Status.FgColor)).
 		Italic(true)
 }
 
 // ConnStyle returns the style for connection rows.
 func ConnStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color(config.CurrentTheme.Styles.Table.FgColor))
+		Foreground(themeColor(config.CurrentTheme.Styles.Table.FgColor))
 }
 
 // SelectedConnStyle returns the style for the selected row in table view.
+// Plain mode has no colors, so it uses reverse video.
 func SelectedConnStyle() lipgloss.Style {
+	if plainMode {
+		return lipgloss.NewStyle().Reverse(true)
+	}
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color(config.CurrentTheme.Styles.Table.CursorFgColor)).
-		Background(lipgloss.Color(config.CurrentTheme.Styles.Table.CursorBgColor))
+		Foreground(themeColor(config.CurrentTheme.Styles.Table.CursorFgColor)).
+		Background(themeColor(config.CurrentTheme.Styles.Table.CursorBgColor))
 }
 
 // ErrorStyle returns the style for error messages.
 func ErrorStyle() lipgloss.Style {
 	// Keep error as red for visibility
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#FF5555")).
+		Foreground(themeColor("#FF5555")).
 		Bold(true)
 }
 
 // TableHeaderStyle returns the style for table column headers.
 func TableHeaderStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color(config.CurrentTheme.Styles.Table.HeaderFgColor)).
+		Foreground(themeColor(config.CurrentTheme.Styles.Table.HeaderFgColor)).
 		Bold(true)
 }
 
 // TableHeaderSelectedStyle returns the style for the selected column header.
 func TableHeaderSelectedStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color(config.CurrentTheme.Styles.Table.SelectedColumn)).
+		Foreground(themeColor(config.CurrentTheme.Styles.Table.SelectedColumn)).
 		Bold(true)
 }
 
 // SortIndicatorStyle returns the style for sort direction indicators.
 func SortIndicatorStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color(config.CurrentTheme.Styles.Table.SortIndicator))
+		Foreground(themeColor(config.CurrentTheme.Styles.Table.SortIndicator))
 }
 
 // AddedConnStyle returns the style for newly added connections.
 func AddedConnStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color(config.CurrentTheme.Styles.Table.AddedFgColor))
+		Foreground(themeColor(config.CurrentTheme.Styles.Table.AddedFgColor))
 }
 
 // RemovedConnStyle returns the style for removed connections.
 func RemovedConnStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color(config.CurrentTheme.Styles.Table.RemovedFgColor))
+		Foreground(themeColor(config.CurrentTheme.Styles.Table.RemovedFgColor))
 }
 
 // RenderFrameWithTitle renders content in a frame with a centered title on the top border.
 // Uses heavy box drawing for modal prominence.
 func RenderFrameWithTitle(content string, title string, width, height int) string {
-	borderColor := lipgloss.Color(config.CurrentTheme.Styles.Modal.BorderFgColor)
-	titleColor := lipgloss.Color(config.CurrentTheme.Styles.Modal.AccentFgColor)
+	borderColor := themeColor(config.CurrentTheme.Styles.Modal.BorderFgColor)
+	titleColor := themeColor(config.CurrentTheme.Styles.Modal.AccentFgColor)
 	return renderFrameWithColors(content, title, width, height, borderColor, titleColor)
 }
 
@@ -123,10 +127,9 @@ func splitLines(s string) []string {
 	return strings.Split(s, "\n")
 }
 
-// padRight pads a string to the specified width.
+// padRight pads a string to the specified width in terminal columns.
 func padRight(s string, width int) string {
-	// Use lipgloss to measure visible width (handles ANSI escape codes)
-	visibleWidth := lipgloss.Width(s)
+	visibleWidth := textWidth(s)
 	if visibleWidth >= width {
 		return s
 	}
@@ -134,16 +137,24 @@ func padRight(s string, width int) string {
 	return s + strings.Repeat(" ", padding)
 }
 
+// padLeft right-aligns a string in the specified width in terminal columns.
+func padLeft(s string, width int) string {
+	if pad := width - textWidth(s); pad > 0 {
+		return strings.Repeat(" ", pad) + s
+	}
+	return s
+}
+
 // DimmedStyle returns a style for dimmed background content when modal is visible.
 func DimmedStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color(config.CurrentTheme.Styles.Modal.DimmedFgColor)).
+		Foreground(themeColor(config.CurrentTheme.Styles.Modal.DimmedFgColor)).
 		Faint(true)
 }
 
 // DangerBorderColor returns the red color for danger modals.
-func DangerBorderColor() lipgloss.Color {
-	return lipgloss.Color("#FF5555")
+func DangerBorderColor() lipgloss.TerminalColor {
+	return themeColor("#FF5555")
 }
 
 // RenderDangerFrameWithTitle renders content in a frame with danger/red styling.
@@ -154,14 +165,10 @@ func RenderDangerFrameWithTitle(content string, title string, width, height int)
 }
 
 // renderFrameWithColors renders a frame with specified border and title colors.
-func renderFrameWithColors(content, title string, width, height int, borderColor, titleColor lipgloss.Color) string {
+func renderFrameWithColors(content, title string, width, height int, borderColor, titleColor lipgloss.TerminalColor) string {
 	// Heavy box drawing characters for modal prominence
-	topLeft := "┏"
-	topRight := "┓"
-	bottomLeft := "┗"
-	bottomRight := "┛"
-	horizontal := "━"
-	vertical := "┃"
+	g := frame(heavyFrame)
+	topLeft, topRight, bottomLeft, bottomRight, horizontal, vertical := g.topLeft, g.topRight, g.bottomLeft, g.bottomRight, g.horizontal, g.vertical
 
 	borderStyle := lipgloss.NewStyle().Foreground(borderColor)
 	titleStyle := lipgloss.NewStyle().Foreground(titleColor).Bold(true)
@@ -216,31 +223,31 @@ func renderFrameWithColors(content, title string, width, height int, borderColor
 // LiveIndicatorStyle returns the style for the LIVE indicator (green).
 func LiveIndicatorStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color(config.CurrentTheme.Styles.Header.LiveFg)).
+		Foreground(themeColor(config.CurrentTheme.Styles.Header.LiveFg)).
 		Bold(true)
 }
 
 // WarnStyle returns the style for warning/attention text (amber).
 func WarnStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color(config.CurrentTheme.Styles.Header.WarnFg))
+		Foreground(themeColor(config.CurrentTheme.Styles.Header.WarnFg))
 }
 
 // StatsStyle returns the style for muted stats text.
 func StatsStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color(config.CurrentTheme.Styles.Header.StatsFg))
+		Foreground(themeColor(config.CurrentTheme.Styles.Header.StatsFg))
 }
 
 // FooterGroupStyle returns the style for footer group labels (NAV, ACTION).
 func FooterGroupStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color(config.CurrentTheme.Styles.Footer.GroupFgColor)).
+		Foreground(themeColor(config.CurrentTheme.Styles.Footer.GroupFgColor)).
 		Bold(true)
 }
 
 // BorderStyle returns the style for borders.
 func BorderStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color(config.CurrentTheme.Styles.Border.FgColor))
+		Foreground(themeColor(config.CurrentTheme.Styles.Border.FgColor))
 }
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// Optional connection columns for kernel TCP stats (Linux).
+var tcpStatsColumns = []columnDef{
+	{label: "RTT", id: SortRTT, minWidth: 8, flex: 0, rightAlign: true},
+	{label: "Retrans", id: SortRetrans, minWidth: 7, flex: 0, rightAlign: true},
+}
+
+// tcpStatsCells renders the RTT and retransmit cells for a connection row.
+// widths are the widths of the optional columns only. Connections without
+// stats (UDP, TIME_WAIT, other platforms) show "—".
+func (m Model) tcpStatsCells(conn model.Connection, widths []int) string {
+	if !m.tcpStats || len(widths) < len(tcpStatsColumns) {
+		return ""
+	}
+	rtt, retrans := "—", "—"
+	if conn.TCP != nil {
+		rtt = formatRTT(conn.TCP.RTT)
+		retrans = strconv.FormatUint(uint64(conn.TCP.Retrans), 10)
+	}
+	return " " + padLeft(rtt, widths[0]) + " " + padLeft(retrans, widths[1])
+}
+
+// formatRTT formats a round-trip time compactly ("85µs", "1.2ms", "340ms", "1.5s").
+func formatRTT(d time.Duration) string {
+	switch {
+	case d < time.Millisecond:
+		return fmt.Sprintf("%dµs", d.Microseconds())
+	case d < 10*time.Millisecond:
+		return strings.TrimSuffix(fmt.Sprintf("%.1f", float64(d)/float64(time.Millisecond)), ".0") + "ms"
+	case d < time.Second:
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	default:
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	}
+}
+
+// compareTCPStats compares two connections by RTT or retransmits. Connections
+// without stats sort below any measured value, so a descending sort puts the
+// flakiest connections first.
+func compareTCPStats(col SortColumn, a, b model.Connection) int {
+	if a.TCP == nil || b.TCP == nil {
+		return compareInt(boolInt(a.TCP != nil), boolInt(b.TCP != nil))
+	}
+	if col == SortRetrans {
+		return compareUint64(uint64(a.TCP.Retrans), uint64(b.TCP.Retrans))
+	}
+	return compareInt64(int64(a.TCP.RTT), int64(b.TCP.RTT))
+}
+
+// boolInt returns 1 for true and 0 for false.
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+func TestFormatRTT(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{85 * time.Microsecond, "85µs"},
+		{1200 * time.Microsecond, "1.2ms"},
+		{2 * time.Millisecond, "2ms"},
+		{340 * time.Millisecond, "340ms"},
+		{1500 * time.Millisecond, "1.5s"},
+	}
+	for _, tt := range tests {
+		if got := formatRTT(tt.d); got != tt.want {
+			t.Errorf("formatRTT(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestCompareTCPStats_UnknownSortsLow(t *testing.T) {
+	none := model.Connection{}
+	slow := model.Connection{TCP: &model.TCPStats{RTT: 200 * time.Millisecond, Retrans: 1}}
+	fast := model.Connection{TCP: &model.TCPStats{RTT: time.Millisecond, Retrans: 9}}
+
+	if compareTCPStats(SortRTT, none, fast) >= 0 {
+		t.Error("connection without stats should sort below a measured one")
+	}
+	if compareTCPStats(SortRTT, slow, fast) <= 0 {
+		t.Error("higher RTT should compare greater")
+	}
+	if compareTCPStats(SortRetrans, slow, fast) >= 0 {
+		t.Error("fewer retransmits should compare less")
+	}
+}
+
+// tcpStatsSnapshot returns one app with two TCP connections of differing quality.
+func tcpStatsSnapshot() *model.NetworkSnapshot {
+	return &model.NetworkSnapshot{
+		Applications: []model.Application{{
+			Name: "curl",
+			PIDs: []int32{42},
+			Connections: []model.Connection{
+				{PID: 42, Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.1:5000", RemoteAddr: "1.1.1.1:443", State: model.StateEstablished,
+					TCP: &model.TCPStats{RTT: 12 * time.Millisecond, Retrans: 0}},
+				{PID: 42, Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.1:5001", RemoteAddr: "2.2.2.2:443", State: model.StateEstablished,
+					TCP: &model.TCPStats{RTT: 480 * time.Millisecond, Retrans: 17}},
+			},
+		}},
+	}
+}
+
+func TestTCPStatsColumns_ConnectionsView(t *testing.T) {
+	m := createTestModel()
+	m.width = 140
+	m.snapshot = tcpStatsSnapshot()
+	m.tcpStats = true
+	view := m.newViewState(LevelConnections, "curl")
+	view.SortColumn, view.SortAscending = SortRetrans, false
+	m.stack = append(m.stack, view)
+
+	cols := m.columnsForLevel(LevelConnections)
+	if cols[len(cols)-2] != SortRTT || cols[len(cols)-1] != SortRetrans {
+		t.Fatalf("columns = %v, want RTT and Retrans last", cols)
+	}
+	lines := strings.Split(strings.TrimSpace(m.renderConnectionsListData()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d rows, want 2", len(lines))
+	}
+	if !strings.Contains(lines[0], "480ms") || !strings.Contains(lines[0], "17") {
+		t.Errorf("descending Retrans sort should put the flaky connection first, got %q", lines[0])
+	}
+}
+
+func TestTCPStatsColumns_AllConnectionsView(t *testing.T) {
+	m := createTestModel()
+	m.width = 160
+	m.snapshot = tcpStatsSnapshot()
+	m.stack = []ViewState{m.newViewState(LevelAllConnections, "")}
+
+	if strings.Contains(m.renderAllConnectionsHeader(calculateColumnWidths(m.activeAllConnectionsColumns(), m.contentWidth())), "RTT") {
+		t.Error("RTT column should be hidden when TCP stats are off")
+	}
+
+	m.tcpStats = true
+	m.stack[0].SortColumn, m.stack[0].SortAscending = SortRTT, false
+	header := m.renderAllConnectionsHeader(calculateColumnWidths(m.activeAllConnectionsColumns(), m.contentWidth()))
+	if !strings.Contains(header, "RTT") || !strings.Contains(header, "Retrans") {
+		t.Errorf("header should include TCP stats columns, got %q", header)
+	}
+	data := m.renderAllConnectionsData()
+	if first := strings.Split(data, "\n")[0]; !strings.Contains(first, "480ms") {
+		t.Errorf("descending RTT sort should put the slowest connection first, got %q", first)
+	}
+}
+
+func TestSettingsToggle_TCPStats(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	m := createTestModel()
+	mc := m.collector.(*mockCollector)
+	m.settingsMode = true
+	m.settingsCursor = 10 // TCP Stats
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+
+	if !m.tcpStats || !mc.opts.TCPStats {
+		t.Error("tcpStats should be enabled and pushed to the collector")
+	}
+	if cmd == nil {
+		t.Error("toggling TCP stats should trigger a data refresh")
+	}
+}
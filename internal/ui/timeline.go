@@ -0,0 +1,224 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/model"
+)
+
+const (
+	tagModalWidth      = 64 // width of the tag comment editor
+	tagExcerptMaxConns = 20 // connections kept in a process tag's excerpt
+)
+
+// incidentTag is an observation marked during an incident ('m'): what was
+// selected, when, an optional comment and the matching snapshot rows, which
+// are captured when the tag is opened so later refreshes don't change them.
+type incidentTag struct {
+	At      time.Time
+	Target  string   // process name, or "process: local → remote" for a connection
+	Summary string   // one line about the process at the time
+	Excerpt []string // connection rows from the snapshot
+	Comment string
+}
+
+// startTag opens the tag editor for the selected process or connection.
+func (m *Model) startTag() tea.Cmd {
+	tag, ok := m.tagForSelection(time.Now())
+	if !ok {
+		return m.notify(toastWarn, i18n.T("Nothing to tag"))
+	}
+	m.tagMode = true
+	m.pendingTag = tag
+	m.tagText = ""
+	return nil
+}
+
+// tagForSelection captures the selected process (process list) or connection
+// (connection views) as a tag taken at now.
+func (m Model) tagForSelection(now time.Time) (incidentTag, bool) {
+	view := m.CurrentView()
+	if view == nil || m.snapshot == nil {
+		return incidentTag{}, false
+	}
+	if view.Level == LevelProcessList {
+		apps := m.visibleApps()
+		idx := m.resolveSelectionIndex()
+		if idx < 0 || idx >= len(apps) {
+			return incidentTag{}, false
+		}
+		app := apps[idx]
+		tag := incidentTag{At: now, Target: app.Name, Summary: appSummary(app)}
+		for i, conn := range app.Connections {
+			if i == tagExcerptMaxConns {
+				tag.Excerpt = append(tag.Excerpt, fmt.Sprintf("… %d more", len(app.Connections)-i))
+				break
+			}
+			tag.Excerpt = append(tag.Excerpt, m.excerptRow(conn))
+		}
+		return tag, true
+	}
+
+	conn := m.selectedConnection()
+	if conn == nil {
+		return incidentTag{}, false
+	}
+	tag := incidentTag{
+		At:      now,
+		Target:  fmt.Sprintf("%s: %s → %s", conn.ProcessName, conn.LocalAddr, conn.RemoteAddr),
+		Excerpt: []string{m.excerptRow(conn.Connection)},
+	}
+	if app := m.findSelectedApp(conn.ProcessName); app != nil {
+		tag.Summary = appSummary(*app)
+	}
+	return tag, true
+}
+
+// appSummary describes a process for a tag, e.g.
+// "nginx (PIDs 812, 813): 4 connections, 1 ESTABLISHED, 2 LISTEN, 1 CLOSE_WAIT".
+func appSummary(app model.Application) string {
+	counts := make(map[model.ConnectionState]int)
+	var states []model.ConnectionState
+	for _, c := range app.Connections {
+		if counts[c.State] == 0 {
+			states = append(states, c.State)
+		}
+		counts[c.State]++
+	}
+	slices.Sort(states)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (PIDs %s): %d connections", app.Name, formatPIDList(app.PIDs), app.ConnectionCount())
+	for _, s := range states {
+		if s != model.StateNone {
+			fmt.Fprintf(&b, ", %d %s", counts[s], s)
+		}
+	}
+	return b.String()
+}
+
+// excerptRow formats a connection for a tag excerpt, with the remote's
+// hostname when it has been resolved.
+func (m Model) excerptRow(conn model.Connection) string {
+	remote := conn.RemoteAddr
+	if host := m.dnsCache[extractIP(remote)]; host != "" {
+		remote += " (" + host + ")"
+	}
+	state := m.connState(conn)
+	if q := conn.Accept; q != nil && q.Backlog > 0 {
+		state += fmt.Sprintf(" %d/%d", q.Queued, q.Backlog)
+	}
+	return fmt.Sprintf("%-4s %-22s %-22s %-12s pid %d", conn.Protocol, conn.LocalAddr, remote, state, conn.PID)
+}
+
+// handleTagKey handles a key press while typing a tag comment.
+func (m *Model) handleTagKey(msg tea.KeyMsg) tea.Cmd {
+	key := msg.String()
+	switch {
+	case matchKey(key, KeyEnter):
+		tag := m.pendingTag
+		tag.Comment = strings.TrimSpace(m.tagText)
+		m.closeTag()
+		m.tags = append(m.tags, tag)
+		return m.notify(toastSuccess, fmt.Sprintf(i18n.T("Tagged %s (%d in timeline)"), tag.Target, len(m.tags)))
+	case matchKey(key, KeyEsc):
+		m.closeTag()
+	case matchKey(key, KeyBack):
+		if r := []rune(m.tagText); len(r) > 0 {
+			m.tagText = string(r[:len(r)-1])
+		}
+	default:
+		if r := msg.Runes; len(r) > 0 && r[0] >= 32 {
+			m.tagText += string(r)
+		}
+	}
+	return nil
+}
+
+// closeTag leaves the tag editor.
+func (m *Model) closeTag() {
+	m.tagMode = false
+	m.pendingTag = incidentTag{}
+	m.tagText = ""
+}
+
+// renderTagModalContent returns the tag editor content.
+func (m Model) renderTagModalContent() string {
+	keyStyle := FooterKeyStyle()
+	descStyle := FooterDescStyle()
+	lines := []string{
+		i18n.T("Tag") + " " + HeaderStyle().Render(truncateString(m.pendingTag.Target, tagModalWidth-10)),
+		DimmedStyle().Render(fmt.Sprintf(i18n.T("at %s"), m.pendingTag.At.Format("15:04:05"))),
+		"",
+		"> " + m.tagText + "█",
+		"",
+		DimmedStyle().Render(i18n.T("Comment is optional. Export the timeline from ctrl+p.")),
+		"",
+		keyStyle.Render("Enter") + descStyle.Render(" "+i18n.T("Tag")+"  ") + keyStyle.Render("Esc") + descStyle.Render(" "+i18n.T("Cancel")),
+	}
+	return strings.Join(lines, "\n")
+}
+
+// exportTimeline writes the tags as a Markdown timeline to a timestamped
+// file in the working directory.
+func (m *Model) exportTimeline() tea.Cmd {
+	if len(m.tags) == 0 {
+		return m.notify(toastWarn, i18n.T("Nothing tagged yet (m)"))
+	}
+	now := time.Now()
+	path := "netmon-timeline-" + now.Format("20060102-150405") + ".md"
+	f, err := os.Create(path)
+	if err == nil {
+		err = writeTimeline(f, m.tags, now)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if err != nil {
+		return m.notify(toastError, fmt.Sprintf(i18n.T("Export failed: %v"), err))
+	}
+	return m.notify(toastSuccess, fmt.Sprintf(i18n.T("Exported %d tags to %s"), len(m.tags), path))
+}
+
+// writeTimeline writes tags in chronological order as Markdown, one section
+// per tag with its comment, process summary and snapshot excerpt, ready to
+// paste into a postmortem.
+func writeTimeline(w io.Writer, tags []incidentTag, exported time.Time) error {
+	tags = slices.Clone(tags)
+	slices.SortStableFunc(tags, func(a, b incidentTag) int { return a.At.Compare(b.At) })
+
+	var b strings.Builder
+	b.WriteString("# Incident timeline\n\n")
+	host, _ := os.Hostname()
+	fmt.Fprintf(&b, "Exported by netmon on %s at %s, %d tagged observations", host, exported.Format("2006-01-02 15:04:05 MST"), len(tags))
+	if len(tags) > 0 {
+		fmt.Fprintf(&b, " from %s to %s", tags[0].At.Format("15:04:05"), tags[len(tags)-1].At.Format("15:04:05"))
+	}
+	b.WriteString(".\n")
+
+	for _, tag := range tags {
+		fmt.Fprintf(&b, "\n## %s · %s\n\n", tag.At.Format("15:04:05"), tag.Target)
+		if tag.Comment != "" {
+			b.WriteString(tag.Comment + "\n\n")
+		}
+		if tag.Summary != "" {
+			b.WriteString("- " + tag.Summary + "\n\n")
+		}
+		if len(tag.Excerpt) > 0 {
+			b.WriteString("```text\n")
+			for _, row := range tag.Excerpt {
+				b.WriteString(strings.TrimRight(row, " ") + "\n")
+			}
+			b.WriteString("```\n")
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTag_ProcessWithComment(t *testing.T) {
+	m := chipsTestModel()
+	m = pressKey(m, "m")
+	if !m.tagMode || m.pendingTag.Target != "chrome" {
+		t.Fatalf("tagMode = %v, pending = %+v", m.tagMode, m.pendingTag)
+	}
+	if !strings.Contains(stripAnsi(m.renderTagModalContent()), "Tag chrome") {
+		t.Error("tag editor should name the target")
+	}
+	m = typeKeys(m, "spike to 2.2.2.2")
+	m = pressSpecial(m, tea.KeyEnter)
+
+	if m.tagMode || len(m.tags) != 1 {
+		t.Fatalf("tagMode = %v, tags = %d", m.tagMode, len(m.tags))
+	}
+	tag := m.tags[0]
+	if tag.Comment != "spike to 2.2.2.2" || tag.Summary != "chrome (PIDs 10): 2 connections, 2 ESTABLISHED" || len(tag.Excerpt) != 2 {
+		t.Errorf("tag = %+v", tag)
+	}
+	if !strings.Contains(tag.Excerpt[1], "2.2.2.2:80") || !strings.Contains(tag.Excerpt[1], "pid 10") {
+		t.Errorf("excerpt row = %q", tag.Excerpt[1])
+	}
+}
+
+func TestTag_ConnectionAndCancel(t *testing.T) {
+	m := chipsTestModel()
+	m.PushView(m.newViewState(LevelConnections, "curl"))
+	m = pressKey(m, "m")
+	if got := m.pendingTag.Target; got != "curl: 10.0.0.2:50002 → 3.3.3.3:443" {
+		t.Errorf("target = %q", got)
+	}
+	m = pressSpecial(m, tea.KeyEsc)
+	if m.tagMode || len(m.tags) != 0 {
+		t.Error("Esc should drop the tag")
+	}
+}
+
+func TestWriteTimeline(t *testing.T) {
+	base := time.Date(2026, 10, 15, 14, 0, 0, 0, time.UTC)
+	tags := []incidentTag{
+		{At: base.Add(2 * time.Minute), Target: "nginx", Summary: "nginx (PIDs 812): 2 connections", Excerpt: []string{"TCP  0.0.0.0:443   *   LISTEN 511/511  "}},
+		{At: base, Target: "curl: 10.0.0.2:1 → 3.3.3.3:443", Comment: "first timeouts"},
+	}
+	var b strings.Builder
+	if err := writeTimeline(&b, tags, base.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	first := strings.Index(out, "## 14:00:00 · curl")
+	second := strings.Index(out, "## 14:02:00 · nginx")
+	if first < 0 || second < first {
+		t.Fatalf("tags should be in chronological order:\n%s", out)
+	}
+	for _, want := range []string{
+		"# Incident timeline",
+		"2 tagged observations from 14:00:00 to 14:02:00.",
+		"first timeouts\n",
+		"- nginx (PIDs 812): 2 connections\n",
+		"```text\nTCP  0.0.0.0:443   *   LISTEN 511/511\n```\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("timeline missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestExportTimeline(t *testing.T) {
+	t.Chdir(t.TempDir())
+	m := chipsTestModel()
+	if m.exportTimeline(); len(m.toasts) == 0 || m.toasts[0].Severity != toastWarn {
+		t.Error("export without tags should warn")
+	}
+	m = pressSpecial(pressKey(m, "m"), tea.KeyEnter)
+	m.exportTimeline()
+
+	files, _ := filepath.Glob("netmon-timeline-*.md")
+	if len(files) != 1 {
+		t.Fatalf("files = %v", files)
+	}
+	data, _ := os.ReadFile(files[0])
+	if !strings.Contains(string(data), "· chrome") {
+		t.Errorf("exported timeline:\n%s", data)
+	}
+}
@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// toastSeverity classifies a transient footer notification.
+type toastSeverity int
+
+const (
+	toastInfo toastSeverity = iota
+	toastSuccess
+	toastWarn
+	toastError
+)
+
+// String returns the severity name shown in the diagnostics event history.
+func (s toastSeverity) String() string {
+	switch s {
+	case toastSuccess:
+		return "ok"
+	case toastWarn:
+		return "warn"
+	case toastError:
+		return "error"
+	default:
+		return "info"
+	}
+}
+
+// icon returns the footer prefix for the severity.
+func (s toastSeverity) icon() string {
+	switch s {
+	case toastSuccess:
+		return "✓ "
+	case toastWarn:
+		return "⚠ "
+	case toastError:
+		return "✗ "
+	default:
+		return ""
+	}
+}
+
+// toastDurations is how long each severity stays in the footer by default.
+// Errors linger so they can be read.
+var toastDurations = map[toastSeverity]time.Duration{
+	toastInfo:    2 * time.Second,
+	toastSuccess: 2 * time.Second,
+	toastWarn:    3 * time.Second,
+	toastError:   4 * time.Second,
+}
+
+// maxToastHistory caps the event history shown in the diagnostics panel.
+const maxToastHistory = 50
+
+// toast is a footer notification. Toasts are shown one at a time: Start is
+// fixed when queued, right after the previous toast's display slot ends.
+type toast struct {
+	Message  string
+	Severity toastSeverity
+	Label    string    // event history label instead of the severity name, e.g. "ephemeral"
+	At       time.Time // when the event happened
+	Start    time.Time // when the toast starts showing
+	Duration time.Duration
+}
+
+// end returns when the toast stops showing.
+func (t toast) end() time.Time { return t.Start.Add(t.Duration) }
+
+// toastExpiredMsg fires when the head of the toast queue finishes showing.
+type toastExpiredMsg struct{}
+
+// notify queues a toast with the severity's default duration.
+func (m *Model) notify(sev toastSeverity, msg string) tea.Cmd {
+	return m.notifyFor(sev, msg, toastDurations[sev])
+}
+
+// notifyFor queues a toast shown for d, records it in the event history, and
+// returns a command that re-renders when it expires.
+func (m *Model) notifyFor(sev toastSeverity, msg string, d time.Duration) tea.Cmd {
+	now := time.Now()
+	m.pruneToasts(now)
+
+	start := now
+	if n := len(m.toasts); n > 0 && m.toasts[n-1].end().After(start) {
+		start = m.toasts[n-1].end()
+	}
+	t := toast{Message: msg, Severity: sev, At: now, Start: start, Duration: d}
+	m.toasts = append(m.toasts, t)
+	m.appendEvent(t)
+
+	return tea.Tick(t.end().Sub(now), func(time.Time) tea.Msg { return toastExpiredMsg{} })
+}
+
+// logEvent records msg in the event history under label without showing a
+// toast, for events too frequent for the footer.
+func (m *Model) logEvent(label, msg string) {
+	m.appendEvent(toast{Message: msg, Severity: toastInfo, Label: label, At: time.Now()})
+}
+
+// appendEvent adds t to the event history, dropping the oldest beyond maxToastHistory.
+func (m *Model) appendEvent(t toast) {
+	m.toastLog = append(m.toastLog, t)
+	if len(m.toastLog) > maxToastHistory {
+		m.toastLog = m.toastLog[len(m.toastLog)-maxToastHistory:]
+	}
+}
+
+// pruneToasts drops toasts whose display slot has ended.
+func (m *Model) pruneToasts(now time.Time) {
+	i := 0
+	for i < len(m.toasts) && !m.toasts[i].end().After(now) {
+		i++
+	}
+	m.toasts = m.toasts[i:]
+}
+
+// currentToast returns the toast showing at now, or nil.
+func (m Model) currentToast(now time.Time) *toast {
+	for i := range m.toasts {
+		t := &m.toasts[i]
+		if !now.Before(t.Start) && now.Before(t.end()) {
+			return t
+		}
+	}
+	return nil
+}
+
+// formatAgo renders an elapsed duration as a short relative time ("just now", "42s ago", "3m ago").
+func formatAgo(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return "just now"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds ago", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
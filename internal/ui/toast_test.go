@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// toastText returns the message of the toast currently showing, or "".
+func toastText(m Model) string {
+	if t := m.currentToast(time.Now()); t != nil {
+		return t.Message
+	}
+	return ""
+}
+
+func TestNotify_ShowsAndRecords(t *testing.T) {
+	m := createTestModel()
+	if cmd := m.notify(toastSuccess, "Killed PID 1 (a)"); cmd == nil {
+		t.Error("notify should return an expiry command")
+	}
+	if got := toastText(m); got != "Killed PID 1 (a)" {
+		t.Errorf("current toast = %q", got)
+	}
+	if len(m.toastLog) != 1 || m.toastLog[0].Severity != toastSuccess {
+		t.Errorf("toastLog = %+v, want one success entry", m.toastLog)
+	}
+}
+
+func TestNotify_QueuesSequentially(t *testing.T) {
+	m := createTestModel()
+	m.notifyFor(toastInfo, "first", time.Second)
+	m.notifyFor(toastError, "second", 2*time.Second)
+
+	first, second := m.toasts[0], m.toasts[1]
+	if !second.Start.Equal(first.end()) {
+		t.Errorf("second toast starts %v, want right after first ends %v", second.Start, first.end())
+	}
+	if got := m.currentToast(first.Start); got == nil || got.Message != "first" {
+		t.Error("first toast should show first")
+	}
+	if got := m.currentToast(first.end()); got == nil || got.Message != "second" {
+		t.Error("second toast should show once the first expires")
+	}
+	if got := m.currentToast(second.end()); got != nil {
+		t.Errorf("no toast should show after the queue drains, got %q", got.Message)
+	}
+
+	m.pruneToasts(first.end())
+	if len(m.toasts) != 1 || m.toasts[0].Message != "second" {
+		t.Errorf("pruneToasts left %+v, want only second", m.toasts)
+	}
+}
+
+func TestNotify_HistoryCapped(t *testing.T) {
+	m := createTestModel()
+	for i := 0; i < maxToastHistory+5; i++ {
+		m.notifyFor(toastInfo, "event", time.Millisecond)
+	}
+	if len(m.toastLog) != maxToastHistory {
+		t.Errorf("toastLog = %d entries, want %d", len(m.toastLog), maxToastHistory)
+	}
+}
+
+func TestToastExpiredMsg_Prunes(t *testing.T) {
+	m := createTestModel()
+	m.toasts = []toast{{Message: "old", Start: time.Now().Add(-time.Minute), Duration: time.Second}}
+
+	updated, _ := m.Update(toastExpiredMsg{})
+	if n := len(updated.(Model).toasts); n != 0 {
+		t.Errorf("toasts = %d, want expired toast pruned", n)
+	}
+}
+
+func TestRenderFooter_ToastSeverityIcon(t *testing.T) {
+	m := Model{width: 80, stack: []ViewState{{Level: LevelProcessList}}}
+	m.notify(toastError, "Failed to kill x")
+	if !strings.Contains(m.renderFooter(), "✗ Failed to kill x") {
+		t.Error("error toast should render with its icon")
+	}
+}
+
+func TestFormatAgo(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{200 * time.Millisecond, "just now"},
+		{42 * time.Second, "42s ago"},
+		{3*time.Minute + 10*time.Second, "3m ago"},
+		{5 * time.Hour, "5h ago"},
+		{50 * time.Hour, "2d ago"},
+	}
+	for _, tt := range tests {
+		if got := formatAgo(tt.d); got != tt.want {
+			t.Errorf("formatAgo(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestDiagnosticsModal_ShowsEvents(t *testing.T) {
+	m := createTestModel()
+	m.notify(toastSuccess, "Closed 10.0.0.1:5000 → 1.2.3.4:443 (curl)")
+	content := m.renderDiagnosticsModalContent()
+	if !strings.Contains(content, "Events") || !strings.Contains(content, "Closed 10.0.0.1:5000") {
+		t.Error("diagnostics panel should list recent events")
+	}
+	if !strings.Contains(content, "just now") {
+		t.Error("events should use relative timestamps")
+	}
+}
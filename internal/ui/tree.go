@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kostyay/netmon/internal/filter"
+	"github.com/kostyay/netmon/internal/i18n"
+	"github.com/kostyay/netmon/internal/process"
+)
+
+// loadParents reads the host's parent table (replaced in tests).
+var loadParents = process.Parents
+
+// treeFilterActive reports whether a tree:PID filter is in effect.
+func (m Model) treeFilterActive() bool {
+	return slices.ContainsFunc(m.currentFilters(), func(f string) bool {
+		_, ok := filter.TreeRoot(f)
+		return ok
+	})
+}
+
+// refreshProcessTree rereads the parent table while a tree filter is in
+// effect, and drops it otherwise. The table is only read when needed: it
+// costs a read per process on the host.
+func (m *Model) refreshProcessTree() {
+	if !m.treeFilterActive() {
+		m.parents = nil
+		return
+	}
+	parents, err := loadParents()
+	if err != nil {
+		m.recordError(sourceCollector, err)
+		return
+	}
+	m.parents = parents
+	m.pipeline.invalidate()
+}
+
+// ensureProcessTree reads the parent table if a tree filter was just added.
+func (m *Model) ensureProcessTree() {
+	if m.parents == nil {
+		m.refreshProcessTree()
+	}
+}
+
+// lineage returns pids and all their ancestors, for matching tree:PID
+// filters; nil when no tree filter is in effect.
+func (m Model) lineage(pids []int32) []int32 {
+	if m.parents == nil {
+		return nil
+	}
+	return process.Lineage(m.parents, pids)
+}
+
+// treeRoot returns the PID of pids the others descend from: the first one
+// whose parent is not among them (a master process rather than a worker).
+func (m Model) treeRoot(pids []int32) int32 {
+	for _, pid := range pids {
+		if !slices.Contains(pids, m.parents[pid]) {
+			return pid
+		}
+	}
+	return pids[0]
+}
+
+// filterToTree replaces a tree filter, or adds one, for the selected process
+// and everything it spawned.
+func (m Model) filterToTree() (tea.Model, tea.Cmd) {
+	target := m.selectedProcessTarget()
+	if target == nil {
+		return m, nil
+	}
+	pids := target.PIDs
+	if len(pids) == 0 && target.PID > 0 {
+		pids = []int32{target.PID}
+	}
+	if len(pids) == 0 {
+		return m, m.notify(toastWarn, i18n.T("No process to show the tree of"))
+	}
+	parents, err := loadParents()
+	if err != nil {
+		return m, m.notify(toastError, fmt.Sprintf(i18n.T("Reading processes failed: %v"), err))
+	}
+	m.parents = parents
+	root := m.treeRoot(pids)
+
+	for i := len(m.filterChips) - 1; i >= 0; i-- {
+		if _, ok := filter.TreeRoot(m.filterChips[i]); ok {
+			m.removeFilterChip(i)
+		}
+	}
+	m.addFilterChip(filter.TreePrefix + strconv.Itoa(int(root)))
+	for !m.AtRootLevel() {
+		m.PopView()
+	}
+	m.pipeline.invalidate()
+	m.clampCursor()
+	return m, m.notify(toastInfo, fmt.Sprintf(i18n.T("Showing %s (PID %d) and its descendants"), target.ProcessName, root))
+}
@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// stubParents replaces the host process table: init(1) started a shell(50)
+// that launched chrome(10); curl(20) was started by init directly.
+func stubParents(t *testing.T) {
+	t.Helper()
+	orig := loadParents
+	loadParents = func() (map[int32]int32, error) {
+		return map[int32]int32{1: 0, 50: 1, 10: 50, 20: 1}, nil
+	}
+	t.Cleanup(func() { loadParents = orig })
+}
+
+func TestTreeFilter_KeepsDescendants(t *testing.T) {
+	stubParents(t)
+	m := chipsTestModel()
+	m = pressKey(m, "/")
+	m = typeKeys(m, "tree:50")
+	m = pressSpecial(m, tea.KeyEnter)
+
+	if got := appNames(m.filteredApps()); !slices.Equal(got, []string{"chrome"}) {
+		t.Errorf("tree:50 -> %v, want chrome", got)
+	}
+	m.filterChips = []string{"tree:1"}
+	if got := appNames(m.filteredApps()); !slices.Equal(got, []string{"chrome", "curl"}) {
+		t.Errorf("tree:1 -> %v, want chrome and curl", got)
+	}
+
+	m.filterChips = nil
+	m.refreshProcessTree()
+	if m.parents != nil {
+		t.Error("parent table should be dropped once no tree filter is in effect")
+	}
+}
+
+func TestTreeFilter_SelectedProcess(t *testing.T) {
+	stubParents(t)
+	m := chipsTestModel()
+	m.filterChips = []string{"tree:1"}
+	m.refreshProcessTree()
+	m = pressKey(m, "o")
+
+	if !slices.Equal(m.filterChips, []string{"tree:10"}) {
+		t.Errorf("chips = %v, want [tree:10]", m.filterChips)
+	}
+	if got := appNames(m.filteredApps()); !slices.Equal(got, []string{"chrome"}) {
+		t.Errorf("filtered = %v, want chrome", got)
+	}
+}
+
+func TestTreeRoot_PrefersMasterProcess(t *testing.T) {
+	m := chipsTestModel()
+	m.parents = map[int32]int32{811: 1, 812: 811, 813: 811}
+	if got := m.treeRoot([]int32{812, 811, 813}); got != 811 {
+		t.Errorf("treeRoot = %d, want 811", got)
+	}
+	if got := m.lineage([]int32{813}); !slices.Equal(got, []int32{813, 811, 1}) {
+		t.Errorf("lineage = %v", got)
+	}
+}
@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"maps"
+	"time"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// UDP sockets have no kernel state, so the State column shows a pseudo-state
+// instead of "-": ACTIVE when the socket showed activity within udpActiveWindow,
+// IDLE otherwise. Neither OS reports per-socket UDP counters, so activity is
+// inferred:
+//   - the socket appeared (a new flow, from the diff layer), or
+//   - its process's byte counters moved and the process has no ESTABLISHED TCP
+//     connection the bytes could belong to.
+const udpActiveWindow = 10 * time.Second
+
+// Pseudo-states shown for UDP sockets.
+const (
+	udpStateActive = "ACTIVE"
+	udpStateIdle   = "IDLE"
+)
+
+// udpStateless reports whether conn is a UDP socket that gets a pseudo-state.
+func udpStateless(conn model.Connection) bool {
+	return conn.Protocol == model.ProtocolUDP && conn.State == model.StateNone
+}
+
+// recordUDPAdded marks UDP sockets that just appeared as active.
+func (m *Model) recordUDPAdded(changes map[ConnectionKey]Change) {
+	for key, change := range changes {
+		if change.Type != ChangeAdded || key.Protocol != model.ProtocolUDP {
+			continue
+		}
+		if m.udpLastActive == nil {
+			m.udpLastActive = make(map[ConnectionKey]time.Time)
+		}
+		m.udpLastActive[key] = change.Timestamp
+	}
+}
+
+// recordUDPTraffic marks the UDP sockets of processes whose byte counters
+// moved (netIORates) as active, unless an ESTABLISHED TCP connection of the
+// same PID could account for the bytes.
+func (m *Model) recordUDPTraffic(now time.Time) {
+	if m.snapshot == nil {
+		return
+	}
+	busy := make(map[int32]bool)
+	for pid, r := range m.netIORates {
+		if r.TX > 0 || r.RX > 0 {
+			busy[pid] = true
+		}
+	}
+	if len(busy) == 0 {
+		return
+	}
+	for _, app := range m.snapshot.Applications {
+		for _, conn := range app.Connections {
+			if conn.Protocol == model.ProtocolTCP && conn.State == model.StateEstablished {
+				delete(busy, conn.PID)
+			}
+		}
+	}
+	for _, app := range m.snapshot.Applications {
+		for _, conn := range app.Connections {
+			if udpStateless(conn) && busy[conn.PID] {
+				if m.udpLastActive == nil {
+					m.udpLastActive = make(map[ConnectionKey]time.Time)
+				}
+				m.udpLastActive[KeyFromConnection(conn)] = now
+			}
+		}
+	}
+}
+
+// refreshUDPStates recomputes which UDP sockets are active as of now, forgets
+// activity older than the window, and drops cached rows and sort orders when
+// any socket changed state.
+func (m *Model) refreshUDPStates(now time.Time) {
+	active := make(map[ConnectionKey]bool, len(m.udpActive))
+	for key, at := range m.udpLastActive {
+		if now.Sub(at) > udpActiveWindow {
+			delete(m.udpLastActive, key)
+			continue
+		}
+		active[key] = true
+	}
+	if maps.Equal(active, m.udpActive) {
+		return
+	}
+	m.udpActive = active
+	m.rowCache.invalidate()
+	m.pipeline.invalidate()
+}
+
+// connState returns a connection's state for display, sorting and filtering:
+// the kernel state, or the UDP pseudo-state.
+func (m Model) connState(conn model.Connection) string {
+	if !udpStateless(conn) {
+		return string(conn.State)
+	}
+	if m.udpActive[KeyFromConnection(conn)] {
+		return udpStateActive
+	}
+	return udpStateIdle
+}
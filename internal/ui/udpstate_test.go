@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// udpTestModel has a DNS client with one connected UDP socket (PID 10) and a
+// browser holding both UDP and an established TCP connection (PID 20).
+func udpTestModel() (Model, model.Connection, model.Connection) {
+	dns := model.Connection{PID: 10, Protocol: model.ProtocolUDP, LocalAddr: "10.0.0.2:40000", RemoteAddr: "8.8.8.8:53", State: model.StateNone}
+	quic := model.Connection{PID: 20, Protocol: model.ProtocolUDP, LocalAddr: "10.0.0.2:40001", RemoteAddr: "1.1.1.1:443", State: model.StateNone}
+	m := createTestModel()
+	m.snapshot = &model.NetworkSnapshot{Applications: []model.Application{
+		{Name: "resolver", PIDs: []int32{10}, Connections: []model.Connection{dns}},
+		{Name: "browser", PIDs: []int32{20}, Connections: []model.Connection{quic,
+			{PID: 20, Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.2:50000", RemoteAddr: "1.1.1.1:443", State: model.StateEstablished}}},
+	}}
+	return m, dns, quic
+}
+
+func TestUDPState_IdleByDefault(t *testing.T) {
+	m, dns, _ := udpTestModel()
+	if got := m.stateLabel(dns); got != udpStateIdle {
+		t.Errorf("state = %q, want IDLE", got)
+	}
+}
+
+func TestUDPState_TrafficMarksActiveThenExpires(t *testing.T) {
+	m, dns, quic := udpTestModel()
+	now := time.Now()
+	m.netIORates = map[int32]ioRate{10: {RX: 100}, 20: {RX: 5000}}
+
+	m.recordUDPTraffic(now)
+	m.refreshUDPStates(now)
+
+	if got := m.stateLabel(dns); got != udpStateActive {
+		t.Errorf("dns state = %q, want ACTIVE", got)
+	}
+	// The browser's bytes may all be its TCP connection's
+	if got := m.stateLabel(quic); got != udpStateIdle {
+		t.Errorf("quic state = %q, want IDLE", got)
+	}
+
+	m.refreshUDPStates(now.Add(udpActiveWindow + time.Second))
+	if got := m.stateLabel(dns); got != udpStateIdle {
+		t.Errorf("dns state after window = %q, want IDLE", got)
+	}
+	if len(m.udpLastActive) != 0 {
+		t.Errorf("udpLastActive = %v, want expired entries dropped", m.udpLastActive)
+	}
+}
+
+func TestUDPState_NewSocketIsActive(t *testing.T) {
+	m, _, quic := udpTestModel()
+	now := time.Now()
+	m.recordUDPAdded(map[ConnectionKey]Change{KeyFromConnection(quic): {Type: ChangeAdded, Timestamp: now}})
+	m.refreshUDPStates(now)
+
+	if got := m.stateLabel(quic); got != udpStateActive {
+		t.Errorf("state = %q, want ACTIVE for a new flow", got)
+	}
+	m.filterChips = []string{"active"}
+	if got := m.filteredAllConnections(); len(got) != 1 || got[0].ProcessName != "browser" {
+		t.Errorf("filter active = %+v, want the new UDP flow", got)
+	}
+}
+
+func TestUDPState_TCPUnchanged(t *testing.T) {
+	m := createTestModel()
+	tcp := model.Connection{Protocol: model.ProtocolTCP, State: model.StateTimeWait}
+	if got := m.connState(tcp); got != string(model.StateTimeWait) {
+		t.Errorf("state = %q, want TIME_WAIT", got)
+	}
+}
@@ -2,17 +2,18 @@ package ui
 
 import (
 	"context"
-	"strconv"
+	"maps"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/kostyay/netmon/internal/config"
+	"github.com/kostyay/netmon/internal/cgroup"
 	"github.com/kostyay/netmon/internal/dns"
-	"github.com/kostyay/netmon/internal/docker"
 	"github.com/kostyay/netmon/internal/model"
 	"github.com/kostyay/netmon/internal/release"
+	"github.com/kostyay/netmon/internal/security"
 )
 
 // Animation tick interval (500ms for pulsing effect).
@@ -32,15 +33,33 @@ func (m Model) Init() tea.Cmd {
 	if m.dockerContainers {
 		cmds = append(cmds, m.fetchDockerContainers())
 	}
+	if m.instantRefresh {
+		cmds = append(cmds, startSockWatch)
+	}
+	if m.ephemeralFlows {
+		cmds = append(cmds, startEphemeralWatch)
+	}
+	if cmd := m.loadGeoIPCmd(); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
 	return tea.Batch(cmds...)
 }
 
 // Update handles messages and ensures viewport content/scroll is synced after any state change.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	interval := m.effectiveRefreshInterval()
+	m.trackIdle(msg)
 	result, cmd := m.update(msg)
 	newModel := result.(Model)
+	if newModel.effectiveRefreshInterval() != interval {
+		// View switch or +/-: reschedule now instead of waiting out the old interval.
+		newModel.tickGen++
+		cmd = tea.Batch(cmd, newModel.tickCmd())
+	}
 	newModel.recalcViewportHeight() // Adjust for frozen header (varies by view level)
-	newModel.updateViewportContent()
+	if !newModel.skipsRender(msg) {
+		newModel.updateViewportContent()
+	}
 	newModel.syncViewportScroll()
 	return newModel, cmd
 }
@@ -52,7 +71,7 @@ func (m *Model) recalcViewportHeight() {
 		return
 	}
 	frozenLines := m.frozenHeaderHeight()
-	viewportHeight := m.height - headerHeight - footerHeight - frameHeight - frozenLines
+	viewportHeight := m.height - m.headerLines() - footerHeight - frameHeight - frozenLines
 	if viewportHeight < 1 {
 		viewportHeight = 1
 	}
@@ -69,7 +88,7 @@ func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Calculate viewport height: total - header - footer - frame borders - frozen header
 		// Frozen header varies by view level (1 for ProcessList/AllConns, 4-5 for Connections)
 		frozenLines := m.frozenHeaderHeight()
-		viewportHeight := msg.Height - headerHeight - footerHeight - frameHeight - frozenLines
+		viewportHeight := msg.Height - m.headerLines() - footerHeight - frameHeight - frozenLines
 		if viewportHeight < 1 {
 			viewportHeight = 1
 		}
@@ -82,6 +101,7 @@ func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		if !m.ready {
 			m.viewport = viewport.New(viewportWidth, viewportHeight)
+			m.viewportHash = 0
 			m.ready = true
 		} else {
 			m.viewport.Width = viewportWidth
@@ -94,6 +114,9 @@ func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Kill mode intercepts all keys
 		if m.killMode {
+			if m.killTarget != nil && m.killTarget.Critical {
+				return m.handleCriticalKillKey(msg)
+			}
 			if matchKey(key, KeyEnter) {
 				return m.executeKill()
 			}
@@ -116,343 +139,201 @@ func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil // Ignore other keys in kill mode
 		}
 
-		// Help mode intercepts all keys
-		if m.helpMode {
-			if matchKey(key, KeyEsc, KeyQuit, KeyHelp) {
-				m.helpMode = false
-				return m, nil
+		// Renice/suspend modal intercepts all keys
+		if m.actionMode {
+			if m.needsConfirm() {
+				return m.handleCriticalActionKey(msg)
 			}
-			return m, nil // Ignore other keys in help mode
+			return m.handleActionKey(key)
 		}
 
-		// Settings mode intercepts all keys
-		if m.settingsMode {
-			if matchKey(key, KeyEsc, KeySettings) {
-				m.settingsMode = false
-				return m, nil
-			}
-			if matchKey(key, KeyUp, KeyUpAlt) {
-				if m.settingsCursor > 0 {
-					m.settingsCursor--
-				}
-				return m, nil
-			}
-			if matchKey(key, KeyDown, KeyDownAlt) {
-				maxCursor := 4 // Number of settings - 1
-				if m.settingsCursor < maxCursor {
-					m.settingsCursor++
-				}
-				return m, nil
+		// Close-connection mode intercepts all keys
+		if m.closeMode {
+			if matchKey(key, KeyEnter) {
+				return m.executeClose()
 			}
-			if matchKey(key, KeyEnter, KeySpace) {
-				var cmd tea.Cmd
-				switch m.settingsCursor {
-				case 0: // DNS Resolution
-					m.dnsEnabled = !m.dnsEnabled
-					config.CurrentSettings.DNSEnabled = m.dnsEnabled
-				case 1: // Service Names
-					m.serviceNames = !m.serviceNames
-					config.CurrentSettings.ServiceNames = m.serviceNames
-				case 2: // Highlight Changes
-					m.highlightChanges = !m.highlightChanges
-					config.CurrentSettings.HighlightChanges = m.highlightChanges
-				case 3: // Animations
-					m.animations = !m.animations
-					config.CurrentSettings.Animations = m.animations
-					if m.animations {
-						cmd = m.animationTickCmd()
-					}
-				case 4: // Docker Containers
-					m.dockerContainers = !m.dockerContainers
-					config.CurrentSettings.DockerContainers = m.dockerContainers
-					if m.dockerContainers {
-						cmd = m.fetchDockerContainers()
-					} else {
-						m.virtualContainers = nil
-					}
-				}
-				_ = config.SaveSettings(config.CurrentSettings)
-				return m, cmd
+			if matchKey(key, KeyEsc) {
+				m.closeMode = false
+				m.closeTarget = nil
 			}
-			return m, nil // Ignore other keys in settings mode
+			return m, nil
 		}
 
-		// Search mode intercepts all keys
-		if m.searchMode {
+		// Self-update confirmation intercepts all keys
+		if m.updateMode {
 			if matchKey(key, KeyEnter) {
-				m.activeFilter = m.searchQuery
-				m.searchMode = false
-				m.clampCursor()
-				return m, nil
+				return m.executeUpdate()
 			}
 			if matchKey(key, KeyEsc) {
-				m.searchQuery = m.activeFilter // revert to confirmed
-				m.searchMode = false
-				return m, nil
-			}
-			if matchKey(key, KeyBack) {
-				if len(m.searchQuery) > 0 {
-					m.searchQuery = m.searchQuery[:len(m.searchQuery)-1]
-				}
-				return m, nil
-			}
-			// Append printable characters
-			r := msg.Runes
-			if len(r) == 1 && r[0] >= 32 {
-				m.searchQuery += string(r)
+				m.updateMode = false
 			}
 			return m, nil
 		}
 
-		// Global keybindings
-		if matchKey(key, KeyQuit, KeyQuitAlt) {
-			m.quitting = true
-			return m, tea.Quit
+		// Diagnostics panel intercepts all keys
+		if m.diagMode {
+			if matchKey(key, KeyEsc, KeyQuit, KeyDiagnostics) {
+				m.diagMode = false
+			}
+			return m, nil
 		}
 
-		if matchKey(key, KeyUp, KeyUpAlt) {
-			view := m.CurrentView()
-			if view == nil {
-				return m, nil
-			}
-			// Use cursor directly (not resolveSelectionIndex) to handle duplicate items
-			if view.Cursor > 0 {
-				view.Cursor--
-				m.updateSelectedIDFromCursor()
+		// DNS activity panel intercepts all keys
+		if m.dnsMode {
+			if matchKey(key, KeyEsc, KeyQuit, KeyDNSActivity) {
+				m.dnsMode = false
 			}
 			return m, nil
 		}
 
-		if matchKey(key, KeyDown, KeyDownAlt) {
-			view := m.CurrentView()
-			if view == nil || m.snapshot == nil {
-				return m, nil
-			}
-			maxCursor := m.filteredCount()
-			// Use cursor directly (not resolveSelectionIndex) to handle duplicate items
-			if maxCursor > 0 && view.Cursor < maxCursor-1 {
-				view.Cursor++
-				m.updateSelectedIDFromCursor()
+		// Interface load panel intercepts all keys
+		if m.ifaceMode {
+			if matchKey(key, KeyEsc, KeyQuit, KeyInterfaces) {
+				m.ifaceMode = false
 			}
 			return m, nil
 		}
 
-		if matchKey(key, KeyLeft, KeyLeftAlt) {
-			view := m.CurrentView()
-			if view == nil || !view.SortMode {
-				return m, nil
-			}
-			// Move column selection left (only in sort mode)
-			columns := m.columnsForLevel(view.Level)
-			currentIdx := m.findColumnIndex(columns, view.SelectedColumn)
-			if currentIdx > 0 {
-				view.SelectedColumn = columns[currentIdx-1]
-			}
+		// Country panel intercepts all keys
+		if m.geoMode {
+			return m, m.handleGeoKey(key)
+		}
+
+		// Comparison panel intercepts all keys
+		if m.compareMode {
+			m.handleCompareKey(key)
 			return m, nil
 		}
 
-		if matchKey(key, KeyRight, KeyRightAlt) {
-			view := m.CurrentView()
-			if view == nil || !view.SortMode {
+		// Command palette intercepts all keys
+		if m.paletteMode {
+			return m.handlePaletteKey(msg)
+		}
+
+		// Help mode intercepts all keys
+		if m.helpMode {
+			if matchKey(key, KeyEsc, KeyQuit, KeyHelp) {
+				m.helpMode = false
 				return m, nil
 			}
-			// Move column selection right (only in sort mode)
-			columns := m.columnsForLevel(view.Level)
-			currentIdx := m.findColumnIndex(columns, view.SelectedColumn)
-			if currentIdx < len(columns)-1 {
-				view.SelectedColumn = columns[currentIdx+1]
-			}
-			return m, nil
+			return m, nil // Ignore other keys in help mode
 		}
 
-		if matchKey(key, KeyEnter, KeySpace) {
-			view := m.CurrentView()
-			if view == nil || m.snapshot == nil {
+		// Settings mode intercepts all keys
+		if m.settingsMode {
+			if matchKey(key, KeyEsc, KeySettings) {
+				m.settingsMode = false
 				return m, nil
 			}
-			if view.SortMode {
-				// Apply sort and exit sort mode
-				if view.SortColumn == view.SelectedColumn {
-					view.SortAscending = !view.SortAscending
-				} else {
-					view.SortColumn = view.SelectedColumn
-					view.SortAscending = true
+			if matchKey(key, KeyUp, KeyUpAlt) {
+				if m.settingsCursor > 0 {
+					m.settingsCursor--
 				}
-				view.SortMode = false
 				return m, nil
 			}
-			// Not in sort mode - drill down on process list
-			if view.Level == LevelProcessList {
-				apps := m.sortProcessList(m.filteredApps())
-				vcs := m.filteredVirtualContainers()
-				if view.Cursor >= 0 && view.Cursor < len(apps) {
-					app := apps[view.Cursor]
-					m.activeFilter = ""
-					m.searchQuery = ""
-					m.dockerView = docker.IsDockerProcess(app.Name)
-					m.PushView(ViewState{
-						Level:          LevelConnections,
-						ProcessName:    app.Name,
-						Cursor:         0,
-						SelectedID:     model.SelectionID{},
-						SortColumn:     SortLocal,
-						SortAscending:  true,
-						SelectedColumn: SortLocal,
-					})
-					if m.dockerView {
-						return m, m.fetchDockerContainers()
-					}
-				} else if vcIdx := view.Cursor - len(apps); vcIdx >= 0 && vcIdx < len(vcs) {
-					// Drill into virtual container row
-					vc := vcs[vcIdx]
-					m.activeFilter = ""
-					m.searchQuery = ""
-					m.dockerView = true
-					vcName := containerDisplayName(vc)
-					m.PushView(ViewState{
-						Level:          LevelConnections,
-						ProcessName:    vcName,
-						Cursor:         0,
-						SelectedID:     model.SelectionID{},
-						SortColumn:     SortLocal,
-						SortAscending:  true,
-						SelectedColumn: SortLocal,
-					})
-					return m, m.fetchDockerContainers()
+			if matchKey(key, KeyDown, KeyDownAlt) {
+				if m.settingsCursor < len(settingItems())-1 {
+					m.settingsCursor++
 				}
-			}
-			return m, nil
-		}
-
-		if matchKey(key, KeyEsc, KeyBack) {
-			view := m.CurrentView()
-			if view != nil && view.SortMode {
-				// Exit sort mode without changing sort
-				view.SortMode = false
 				return m, nil
 			}
-			// Pop view (go back)
-			m.PopView()
-			m.dockerView = false
-			return m, nil
-		}
-
-		if matchKey(key, KeyRefreshUp) || key == "=" {
-			// Decrease refresh interval (faster refresh)
-			if m.refreshInterval > MinRefreshInterval {
-				m.refreshInterval -= RefreshStep
+			if matchKey(key, KeyEnter, KeySpace) {
+				return m, m.toggleSetting(m.settingsCursor)
 			}
-			return m, nil
+			return m, nil // Ignore other keys in settings mode
 		}
 
-		if matchKey(key, KeyRefreshDown) || key == "_" {
-			// Increase refresh interval (slower refresh)
-			if m.refreshInterval < MaxRefreshInterval {
-				m.refreshInterval += RefreshStep
-			}
-			return m, nil
+		// Presets picker intercepts all keys
+		if m.presetsMode {
+			return m, m.handlePresetsKey(msg)
 		}
 
-		if matchKey(key, KeySortMode) {
-			// Enter sort mode
-			view := m.CurrentView()
-			if view == nil || view.SortMode {
-				return m, nil
-			}
-			view.SortMode = true
-			view.SelectedColumn = view.SortColumn // Start at current sort column
-			return m, nil
+		// Certificate modal intercepts all keys
+		if m.certMode {
+			return m.handleCertKey(key)
 		}
 
-		if matchKey(key, KeyToggleView) {
-			// Toggle between grouped (process list) and ungrouped (all connections) view
-			view := m.CurrentView()
-			if view == nil {
-				return m, nil
-			}
-			if view.Level == LevelAllConnections {
-				// Toggle back to process list
-				m.stack = []ViewState{{
-					Level:          LevelProcessList,
-					Cursor:         0,
-					SortColumn:     SortProcess,
-					SortAscending:  true,
-					SelectedColumn: SortProcess,
-				}}
-			} else {
-				// Toggle to all connections view
-				m.stack = []ViewState{{
-					Level:          LevelAllConnections,
-					Cursor:         0,
-					SortColumn:     SortProcess,
-					SortAscending:  true,
-					SelectedColumn: SortProcess,
-				}}
-			}
-			return m, nil
+		// Network panel intercepts all keys
+		if m.asnMode {
+			return m, m.handleASNKey(key)
 		}
 
-		if matchKey(key, KeySearch) {
-			// Enter search mode
-			m.searchMode = true
-			m.searchQuery = m.activeFilter // pre-fill with current filter
-			return m, nil
+		// Remote hosts panel intercepts all keys
+		if m.hostsMode {
+			return m, m.handleHostsKey(key)
 		}
 
-		if matchKey(key, KeyKillTerm) {
-			return m.enterKillMode("SIGTERM")
+		// Note editor intercepts all keys
+		if m.noteMode {
+			return m, m.handleNoteKey(msg)
 		}
 
-		if matchKey(key, KeyKillForce) {
-			return m.enterKillMode("SIGKILL")
+		// Tag comment editor intercepts all keys
+		if m.tagMode {
+			return m, m.handleTagKey(msg)
 		}
 
-		if matchKey(key, KeySettings) {
-			// Open settings modal
-			m.settingsMode = true
-			m.settingsCursor = 0
-			return m, nil
+		// Target list prompt intercepts all keys
+		if m.matchMode {
+			return m, m.handleMatchKey(msg)
 		}
 
-		if matchKey(key, KeyHelp) {
-			// Open help modal
-			m.helpMode = true
+		// Filter chip selection intercepts all keys
+		if m.chipMode {
+			m.handleChipKey(key)
 			return m, nil
 		}
 
-		if matchKey(key, KeyPageUp) {
-			view := m.CurrentView()
-			if view == nil {
+		// Search mode intercepts all keys
+		if m.searchMode {
+			if matchKey(key, KeyEnter) {
+				m.addFilterChip(m.searchQuery)
+				m.searchQuery = ""
+				m.searchMode = false
+				m.ensureProcessTree()
+				m.clampCursor()
+				return m, nil
+			}
+			if matchKey(key, KeyEsc) {
+				m.searchQuery = "" // discard, keeping confirmed chips
+				m.searchMode = false
+				return m, nil
+			}
+			if matchKey(key, KeyComplete) {
+				m.acceptSuggestion()
 				return m, nil
 			}
-			pageSize := m.viewport.Height
-			if pageSize < 1 {
-				pageSize = 10
+			if matchKey(key, KeyBack) {
+				if len(m.searchQuery) > 0 {
+					m.searchQuery = m.searchQuery[:len(m.searchQuery)-1]
+				} else if len(m.filterChips) > 0 {
+					// Backspace past the start edits the previous chip
+					m.searchQuery = m.filterChips[len(m.filterChips)-1]
+					m.removeFilterChip(len(m.filterChips) - 1)
+				}
+				return m, nil
 			}
-			view.Cursor -= pageSize
-			if view.Cursor < 0 {
-				view.Cursor = 0
+			// Append printable characters
+			r := msg.Runes
+			if len(r) == 1 && r[0] >= 32 {
+				m.searchQuery += string(r)
+				m.ensureProcessTree() // narrow live once "tree:PID" is typed
 			}
-			m.updateSelectedIDFromCursor()
 			return m, nil
 		}
 
-		if matchKey(key, KeyPageDown) {
-			view := m.CurrentView()
-			if view == nil || m.snapshot == nil {
+		// gg: a g right after g jumps to the first row; any other key cancels the pair
+		if m.pendingG {
+			m.pendingG = false
+			if matchKey(key, KeyTopAlt) {
+				m.moveToTop()
 				return m, nil
 			}
-			pageSize := m.viewport.Height
-			if pageSize < 1 {
-				pageSize = 10
-			}
-			maxCursor := m.filteredCount()
-			view.Cursor += pageSize
-			if maxCursor > 0 && view.Cursor >= maxCursor {
-				view.Cursor = maxCursor - 1
-			}
-			m.updateSelectedIDFromCursor()
-			return m, nil
+		}
+
+		// Normal-mode commands (see commandRegistry)
+		if model, cmd, handled := m.runCommand(msg); handled {
+			return model, cmd
 		}
 
 		// Pass unhandled keys to viewport for page up/down, mouse scroll, etc.
@@ -463,40 +344,84 @@ func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case TickMsg:
-		// Prune expired change highlights (older than 3s)
-		m.pruneExpiredChanges(3 * time.Second)
+		if msg.Gen != m.tickGen {
+			return m, nil // superseded by a tick at the new interval
+		}
+		// Prune expired change highlights
+		m.pruneExpiredChanges(changeHighlightDuration)
 
 		// Schedule next tick and fetch new data
 		cmds := []tea.Cmd{
 			m.tickCmd(),
 			m.fetchData(),
 			m.fetchNetIO(),
+			m.fetchIfaceCounters(),
 		}
 		// Refresh Docker container info when in Docker view or containers enabled
-		if m.dockerView || m.dockerContainers {
+		if m.dockerView || m.dockerContainers || m.CurrentView().Level == LevelDockerPorts {
 			cmds = append(cmds, m.fetchDockerContainers())
 		}
+		if view := m.CurrentView(); view != nil && view.Level == LevelConntrack {
+			cmds = append(cmds, m.fetchConntrack())
+		}
 		return m, tea.Batch(cmds...)
 
+	case SockWatchStartedMsg:
+		return m, m.handleSockWatchStarted(msg)
+
+	case SocketChangeMsg:
+		return m, m.handleSocketChange(msg)
+
+	case EphemeralWatchStartedMsg:
+		return m, m.handleEphemeralWatchStarted(msg)
+
 	case DataMsg:
 		if msg.Err != nil {
 			// Store error for display in UI
 			m.lastError = msg.Err
 			m.lastErrorTime = time.Now()
+			m.recordError(sourceCollector, msg.Err)
 			return m, nil
 		}
 		// Clear error on successful fetch
 		m.lastError = nil
+		largeCmd := m.updateLargeHost(msg.Snapshot.HostConnections())
 
-		// Diff connections and merge new changes
+		// Diff connections and merge new changes (large hosts keep only churn and scan counts;
+		// sampled snapshots have no diff, and a host busy enough to sample never idles)
 		newChanges := diffConnections(m.snapshot, msg.Snapshot)
-		for k, v := range newChanges {
-			m.changes[k] = v
+		if len(newChanges) > 0 || msg.Snapshot.Sample != nil {
+			m.markActive(time.Now())
+		}
+		if !m.largeHost {
+			for k, v := range newChanges {
+				m.changes[k] = v
+			}
 		}
 
+		m.recordChurn(m.snapshot, msg.Snapshot)
+		m.recordUDPAdded(newChanges)
+		m.recordDNSActivity(msg.Snapshot, newChanges, time.Now())
+		m.recordDashboard(msg.Snapshot, newChanges, time.Now())
+		m.refreshInterfaces(time.Now())
+		scanCmd := m.recordScanActivity(newChanges, msg.Snapshot)
+		watchCmd := m.checkPortWatches(msg.Snapshot)
+		proxyCmd := m.refreshLocalProxies(msg.Snapshot)
+		m.refreshSniffed(msg.Snapshot, time.Now())
+		leakCmd := m.checkLeaks(msg.Snapshot, time.Now())
+
 		// Store current as previous for next diff
 		m.prevSnapshot = m.snapshot
 		m.snapshot = msg.Snapshot
+		m.snapshotAt = msg.CollectedAt
+		m.collectDuration = msg.Duration
+		m.pipeline.invalidate() // entries for the old snapshot can never hit again
+		m.refreshUDPStates(time.Now())
+		m.refreshAllowlist()
+		m.refreshProcessTree()
+		m.recordEphemeral(m.prevSnapshot, m.snapshot)
+		followCmd := m.refreshFollow()
+		conflictCmd := m.refreshPortConflicts()
 
 		// Handle --pid: drill into target process on first snapshot
 		if m.targetPID != 0 {
@@ -506,38 +431,116 @@ func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Validate selection using ID-based resolution (handles item reordering)
 		m.validateSelection()
+		m.publishDebugGauges()
+		m.publishWeb()
+		m.publishHistory()
+
+		// Handle --script: run the startup actions on the first snapshot
+		var scriptCmd tea.Cmd
+		m, scriptCmd = m.runScript()
 
-		// Queue DNS lookups for new IPs (if enabled)
-		dnsCmd := m.queueDNSLookups(msg.Snapshot)
-		return m, dnsCmd
+		// Queue DNS and security context lookups (if enabled)
+		return m, tea.Batch(m.queueDNSLookups(msg.Snapshot), m.queueSecurityLookups(msg.Snapshot), m.queueStartTimeLookups(msg.Snapshot), m.queueFDLookups(msg.Snapshot), m.queueCgroupLookups(msg.Snapshot), m.queueProcEnvLookup(), scanCmd, watchCmd, proxyCmd, leakCmd, largeCmd, followCmd, conflictCmd, scriptCmd)
 
 	case NetIOMsg:
 		if msg.Err != nil {
-			// Silently ignore network I/O errors - stats are optional
+			// Network I/O stats are optional - only log for diagnostics
+			m.recordError(sourceNetIO, msg.Err)
 			return m, nil
 		}
 		// Update the netIOCache with new stats
+		m.recordNetIORates(msg.Stats)
+		m.recordRateStats(time.Now())
+		m.recordSessionIO(msg.Stats, time.Now())
 		for pid, stats := range msg.Stats {
 			m.netIOCache[pid] = stats
 		}
+		m.recordUDPTraffic(time.Now())
+		m.refreshUDPStates(time.Now())
+		m.pipeline.invalidate() // TX/RX sort order may change
 		return m, nil
 
+	case IfaceCountersMsg:
+		return m, m.handleIfaceCounters(msg)
+
 	case DNSResolvedMsg:
 		if msg.Err != nil {
 			// Cache failed lookup to avoid repeated attempts
 			m.dnsCache[msg.IP] = ""
+			m.rowCache.invalidate()
+			if !isExpectedDNSError(msg.Err) {
+				m.recordError(sourceDNS, msg.Err)
+			}
 			return m, nil
 		}
 		// Cache successful lookup
 		m.dnsCache[msg.IP] = msg.Hostname
+		m.refreshAllowlist() // a name may match a wildcard domain
+		m.rowCache.invalidate()
+		m.pipeline.invalidate() // a name may match a domain: filter
+		return m, nil
+
+	case SecurityResolvedMsg:
+		// Cache failures too (as empty context) to avoid repeated lookups
+		if m.securityCache == nil {
+			m.securityCache = make(map[int32]security.Context)
+		}
+		m.securityCache[msg.PID] = msg.Context
+		m.pipeline.invalidate()
+		m.recordError(sourceSecurity, msg.Err)
+		return m, nil
+
+	case StartTimesResolvedMsg:
+		if m.startTimes == nil {
+			m.startTimes = make(map[int32]time.Time)
+		}
+		maps.Copy(m.startTimes, msg.Times)
+		m.pipeline.invalidate()
+		return m, nil
+
+	case CgroupsResolvedMsg:
+		if m.cgroups == nil {
+			m.cgroups = make(map[int32]cgroup.Info)
+		}
+		maps.Copy(m.cgroups, msg.Cgroups)
+		m.pipeline.invalidate()
+		return m, nil
+
+	case FDUsageResolvedMsg:
+		m.fdUsage = msg.Usage
+		m.pipeline.invalidate()
 		return m, nil
 
 	case DockerResolvedMsg:
 		if msg.Err != nil {
-			return m, nil // Silently ignore Docker errors
+			m.recordError(sourceDocker, msg.Err) // not shown in header, only in diagnostics
+			return m, nil
+		}
+		if !msg.At.IsZero() && !msg.At.After(m.dockerResolvedAt) {
+			return m, nil // same cached resolution; keep container rates
 		}
+		m.dockerResolvedAt = msg.At
 		m.dockerCache = msg.Containers
+		m.rowCache.invalidate()
+		m.pipeline.invalidate()
+		m.containerRates = containerIORates(m.virtualContainers, msg.VirtualContainers)
 		m.virtualContainers = msg.VirtualContainers
+		m.createdContainers = msg.CreatedContainers
+		return m, m.refreshPortConflicts()
+
+	case GeoIPLoadedMsg:
+		m.handleGeoIPLoaded(msg)
+		return m, nil
+
+	case ConntrackMsg:
+		m.conntrackEntries = msg.Entries
+		m.conntrackErr = msg.Err
+		m.recordError(sourceConntrack, msg.Err)
+		m.clampCursor()
+		return m, nil
+
+	case toastExpiredMsg:
+		m.pruneToasts(time.Now())
 		return m, nil
 
 	case VersionCheckMsg:
@@ -546,12 +549,33 @@ func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case SelfUpdateMsg:
+		return m.handleSelfUpdate(msg)
+
+	case CertPeekMsg:
+		return m.handleCertPeek(msg)
+
+	case ProxyFlowsMsg:
+		return m.handleProxyFlows(msg)
+
+	case ProcEnvResolvedMsg:
+		return m.handleProcEnvResolved(msg)
+
+	case ExeVerifiedMsg:
+		return m.handleExeVerified(msg)
+
+	case spinner.TickMsg:
+		return m.handleVerifySpinnerTick(msg)
+
 	case AnimationTickMsg:
 		if !m.animations {
 			return m, nil
 		}
-		// Advance animation frame (cycles 0-1 for pulse effect)
+		// Advance animation frame (cycles 0-1 for pulse effect); hold still while idle
 		m.animationFrame = (m.animationFrame + 1) % 2
+		if m.idle {
+			m.animationFrame = 0
+		}
 		return m, m.animationTickCmd()
 	}
 
@@ -559,13 +583,14 @@ func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) tickCmd() tea.Cmd {
-	return tea.Tick(m.refreshInterval, func(t time.Time) tea.Msg {
-		return TickMsg(t)
+	gen := m.tickGen
+	return tea.Tick(m.effectiveRefreshInterval(), func(t time.Time) tea.Msg {
+		return TickMsg{Time: t, Gen: gen}
 	})
 }
 
 func (m Model) animationTickCmd() tea.Cmd {
-	return tea.Tick(animationInterval, func(t time.Time) tea.Msg {
+	return tea.Tick(m.animationInterval(), func(t time.Time) tea.Msg {
 		return AnimationTickMsg(t)
 	})
 }
@@ -575,8 +600,10 @@ func (m Model) fetchData() tea.Cmd {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
+		start := time.Now()
+		defer m.observe("collect", start)
 		snapshot, err := m.collector.Collect(ctx)
-		return DataMsg{Snapshot: snapshot, Err: err}
+		return DataMsg{Snapshot: snapshot, Err: err, CollectedAt: start, Duration: time.Since(start)}
 	}
 }
 
@@ -585,6 +612,7 @@ func (m Model) fetchNetIO() tea.Cmd {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
+		defer m.observe("netio", time.Now())
 		stats, err := m.netIOCollector.Collect(ctx)
 		return NetIOMsg{Stats: stats, Err: err}
 	}
@@ -605,14 +633,19 @@ func (m Model) fetchDockerContainers() tea.Cmd {
 		return DockerResolvedMsg{
 			Containers:        result.Ports,
 			VirtualContainers: result.Containers,
+			CreatedContainers: result.Created,
+			At:                result.At,
 			Err:               err,
 		}
 	}
 }
 
+// checkLatest queries GitHub for a newer release. Overridden in tests.
+var checkLatest = release.CheckLatest
+
 func (m Model) checkVersion() tea.Cmd {
 	return func() tea.Msg {
-		latest, err := release.CheckLatest("kostyay", "netmon", m.version)
+		latest, err := checkLatest("kostyay", "netmon", m.version)
 		return VersionCheckMsg{LatestVersion: latest, Err: err}
 	}
 }
@@ -629,8 +662,9 @@ func (m Model) resolveDNS(ip string) tea.Cmd {
 }
 
 // queueDNSLookups returns commands for IPs that need resolution.
+// Large hosts queue none; names already cached are still shown.
 func (m Model) queueDNSLookups(snapshot *model.NetworkSnapshot) tea.Cmd {
-	if !m.dnsEnabled || snapshot == nil {
+	if !m.dnsEnabled || m.largeHost || snapshot == nil {
 		return nil
 	}
 
@@ -696,28 +730,40 @@ func (m Model) maxCursorForLevel(level ViewLevel) int {
 		return 0
 	case LevelAllConnections:
 		return m.snapshot.TotalConnections()
+	case LevelConntrack:
+		return len(m.conntrackEntries)
+	case LevelDockerPorts:
+		return len(m.portMappingRows())
+	case LevelBinds:
+		return len(m.bindRows())
 	default:
 		return 0
 	}
 }
 
-// columnsForLevel returns the SortColumn IDs for the given view level.
-func (m Model) columnsForLevel(level ViewLevel) []SortColumn {
-	var cols []columnDef
+// columnDefsForLevel returns the visible column definitions for the given view level.
+func (m Model) columnDefsForLevel(level ViewLevel) []columnDef {
 	switch level {
 	case LevelProcessList:
-		cols = processListColumns()
+		return m.activeProcessListColumns()
 	case LevelConnections:
-		if m.dockerView {
-			cols = dockerConnectionsColumns()
-		} else {
-			cols = connectionsColumns()
-		}
+		return m.activeConnectionsColumns()
 	case LevelAllConnections:
-		cols = allConnectionsColumns()
+		return m.activeAllConnectionsColumns()
+	case LevelConntrack:
+		return conntrackColumns()
+	case LevelDockerPorts:
+		return dockerPortsColumns()
+	case LevelBinds:
+		return bindsColumns()
 	default:
 		return nil
 	}
+}
+
+// columnsForLevel returns the SortColumn IDs for the given view level.
+func (m Model) columnsForLevel(level ViewLevel) []SortColumn {
+	cols := m.columnDefsForLevel(level)
 	result := make([]SortColumn, len(cols))
 	for i, col := range cols {
 		result[i] = col.id
@@ -736,6 +782,31 @@ func (m Model) findColumnIndex(columns []SortColumn, col SortColumn) int {
 	return 0
 }
 
+// pageSize returns the number of rows moved by a page up/down.
+func (m Model) pageSize() int {
+	if m.viewport.Height < 1 {
+		return 10
+	}
+	return m.viewport.Height
+}
+
+// moveCursor moves the cursor by delta rows, clamped to the filtered list bounds.
+func (m *Model) moveCursor(delta int) {
+	view := m.CurrentView()
+	if view == nil || m.snapshot == nil {
+		return
+	}
+	maxCursor := m.filteredCount()
+	view.Cursor += delta
+	if maxCursor > 0 && view.Cursor >= maxCursor {
+		view.Cursor = maxCursor - 1
+	}
+	if view.Cursor < 0 {
+		view.Cursor = 0
+	}
+	m.updateSelectedIDFromCursor()
+}
+
 // clampCursor ensures cursor is within bounds after filter changes.
 func (m *Model) clampCursor() {
 	view := m.CurrentView()
@@ -763,15 +834,21 @@ func (m *Model) filteredCount() int {
 	view := m.CurrentView()
 	switch view.Level {
 	case LevelProcessList:
-		return len(m.filteredApps()) + len(m.filteredVirtualContainers())
+		return len(m.visibleApps()) + len(m.filteredVirtualContainers())
 	case LevelConnections:
 		selectedApp := m.findSelectedApp(view.ProcessName)
 		if selectedApp != nil {
-			return len(m.filteredConnections(selectedApp.Connections))
+			return len(m.visibleConnections(selectedApp))
 		}
 		return 0
 	case LevelAllConnections:
-		return len(m.filteredAllConnections())
+		return len(m.visibleAllConnections())
+	case LevelConntrack:
+		return len(m.filteredConntrack())
+	case LevelDockerPorts:
+		return len(m.filteredPortMappings())
+	case LevelBinds:
+		return len(m.filteredBinds())
 	default:
 		return m.maxCursorForLevel(view.Level)
 	}
@@ -785,29 +862,9 @@ func drillIntoPID(m *Model, pid int32) {
 	for _, app := range m.snapshot.Applications {
 		for _, p := range app.PIDs {
 			if p == pid {
-				m.PushView(ViewState{
-					Level:          LevelConnections,
-					ProcessName:    app.Name,
-					Cursor:         0,
-					SortColumn:     SortLocal,
-					SortAscending:  true,
-					SelectedColumn: SortLocal,
-				})
+				m.PushView(m.newViewState(LevelConnections, app.Name))
 				return
 			}
 		}
 	}
 }
-
-// extractPortsFromAddrs parses port numbers from address strings like "127.0.0.1:8080".
-func extractPortsFromAddrs(addrs ...string) []int {
-	var ports []int
-	for _, addr := range addrs {
-		if idx := strings.LastIndex(addr, ":"); idx != -1 {
-			if port, err := strconv.Atoi(addr[idx+1:]); err == nil {
-				ports = append(ports, port)
-			}
-		}
-	}
-	return ports
-}
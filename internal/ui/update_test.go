@@ -372,7 +372,7 @@ func TestUpdate_KeyMsg_Minus_AtMaxInterval(t *testing.T) {
 
 func TestUpdate_TickMsg_SchedulesNextTick(t *testing.T) {
 	m := createTestModel()
-	msg := TickMsg(time.Now())
+	msg := TickMsg{Time: time.Now()}
 
 	_, cmd := m.Update(msg)
 
@@ -635,62 +635,6 @@ func TestNetIOMsg_Error(t *testing.T) {
 	}
 }
 
-// Tests for extractPorts functions
-
-func TestExtractPortsFromAddrs_SingleAddr(t *testing.T) {
-	ports := extractPortsFromAddrs("127.0.0.1:8080")
-	if len(ports) != 1 {
-		t.Fatalf("expected 1 port, got %d", len(ports))
-	}
-	if ports[0] != 8080 {
-		t.Errorf("expected port 8080, got %d", ports[0])
-	}
-}
-
-func TestExtractPortsFromAddrs_MultipleAddrs(t *testing.T) {
-	ports := extractPortsFromAddrs("127.0.0.1:8080", "10.0.0.1:443")
-	if len(ports) != 2 {
-		t.Fatalf("expected 2 ports, got %d", len(ports))
-	}
-	if ports[0] != 8080 || ports[1] != 443 {
-		t.Errorf("expected ports [8080, 443], got %v", ports)
-	}
-}
-
-func TestExtractPortsFromAddrs_IPv6(t *testing.T) {
-	ports := extractPortsFromAddrs("[::1]:9090")
-	if len(ports) != 1 {
-		t.Fatalf("expected 1 port, got %d", len(ports))
-	}
-	if ports[0] != 9090 {
-		t.Errorf("expected port 9090, got %d", ports[0])
-	}
-}
-
-func TestExtractPortsFromAddrs_Wildcard(t *testing.T) {
-	ports := extractPortsFromAddrs("*:80")
-	if len(ports) != 1 {
-		t.Fatalf("expected 1 port, got %d", len(ports))
-	}
-	if ports[0] != 80 {
-		t.Errorf("expected port 80, got %d", ports[0])
-	}
-}
-
-func TestExtractPortsFromAddrs_NoPort(t *testing.T) {
-	ports := extractPortsFromAddrs("*")
-	if len(ports) != 0 {
-		t.Errorf("expected 0 ports for '*', got %d", len(ports))
-	}
-}
-
-func TestExtractPortsFromAddrs_Empty(t *testing.T) {
-	ports := extractPortsFromAddrs()
-	if len(ports) != 0 {
-		t.Errorf("expected 0 ports for empty input, got %d", len(ports))
-	}
-}
-
 // Tests for kill mode
 
 func TestKillMode_XEntersKillMode(t *testing.T) {
@@ -793,12 +737,12 @@ func TestKillMode_EnterConfirmsKill(t *testing.T) {
 	if newModel.killTarget != nil {
 		t.Error("killTarget should be nil after kill")
 	}
-	// killResult should be set (either success or failure message)
-	if newModel.killResult == "" {
-		t.Error("killResult should be set after kill attempt")
+	// A result toast should be queued (either success or failure message)
+	if toastText(newModel) == "" {
+		t.Error("kill result toast should be set after kill attempt")
 	}
-	if newModel.killResultAt.IsZero() {
-		t.Error("killResultAt should be set after kill attempt")
+	if len(newModel.toastLog) == 0 {
+		t.Error("kill result should be recorded in the event history")
 	}
 }
 
@@ -1031,8 +975,8 @@ func TestExecuteKill_UnknownSignalFallsBackToSIGTERM(t *testing.T) {
 		t.Error("killMode should be false after kill attempt")
 	}
 	// Should have a result (probably failed since PID doesn't exist)
-	if newModel.killResult == "" {
-		t.Error("killResult should be set")
+	if toastText(newModel) == "" {
+		t.Error("kill result toast should be set")
 	}
 }
 
@@ -1056,8 +1000,8 @@ func TestExecuteKill_MultiplePIDsPartialFailure(t *testing.T) {
 		t.Error("killTarget should be nil after kill")
 	}
 	// Should have failure result
-	if newModel.killResult == "" {
-		t.Error("killResult should be set")
+	if toastText(newModel) == "" {
+		t.Error("kill result toast should be set")
 	}
 }
 
@@ -1078,8 +1022,8 @@ func TestExecuteKill_SinglePIDFallback(t *testing.T) {
 		t.Error("killMode should be false after kill attempt")
 	}
 	// Should use single PID fallback
-	if newModel.killResult == "" {
-		t.Error("killResult should be set")
+	if toastText(newModel) == "" {
+		t.Error("kill result toast should be set")
 	}
 }
 
@@ -1088,7 +1032,7 @@ func TestExecuteKill_SinglePIDFallback(t *testing.T) {
 func TestUpdate_KeyMsg_Down_RespectsFilter(t *testing.T) {
 	m := createTestModel()
 	// Filter to show only App1 (1 item out of 3)
-	m.activeFilter = "App1"
+	m.filterChips = []string{"App1"}
 	m.CurrentView().Cursor = 0
 
 	// Try to move down - should stay at 0 since only 1 filtered item
@@ -1105,7 +1049,7 @@ func TestUpdate_KeyMsg_Down_RespectsFilter(t *testing.T) {
 func TestUpdate_DataMsg_ClampsCursorWithFilter(t *testing.T) {
 	m := createTestModel()
 	// Set filter and cursor beyond filtered bounds
-	m.activeFilter = "App1"
+	m.filterChips = []string{"App1"}
 	m.CurrentView().Cursor = 5 // Invalid: only 1 item matches filter
 
 	// Simulate data refresh
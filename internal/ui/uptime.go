@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	gprocess "github.com/shirou/gopsutil/v3/process"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+// lookupStartTime returns when a process started (replaced in tests).
+var lookupStartTime = func(pid int32) (time.Time, error) {
+	p, err := gprocess.NewProcess(pid)
+	if err != nil {
+		return time.Time{}, err
+	}
+	ms, err := p.CreateTime()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(ms), nil
+}
+
+// uptimeColumn is the optional process list column shown when Process Uptime is enabled.
+var uptimeColumn = columnDef{label: "Uptime", id: SortUptime, minWidth: 7, flex: 0, rightAlign: true}
+
+// processUptime returns how long an app's primary PID has been running, and
+// false while the lookup is pending or if it failed.
+func (m Model) processUptime(pids []int32) (time.Duration, bool) {
+	if len(pids) == 0 {
+		return 0, false
+	}
+	start, ok := m.startTimes[pids[0]]
+	if !ok || start.IsZero() {
+		return 0, false
+	}
+	return time.Since(start), true
+}
+
+// uptimeLabel returns the Uptime cell for an app: "3d4h", "…" while the
+// lookup is pending, "—" if the start time is unknown.
+func (m Model) uptimeLabel(pids []int32) string {
+	if len(pids) > 0 {
+		if _, ok := m.startTimes[pids[0]]; !ok {
+			return "…"
+		}
+	}
+	d, ok := m.processUptime(pids)
+	if !ok {
+		return "—"
+	}
+	return formatUptime(d)
+}
+
+// uptimeSortKey orders apps by uptime; unknown uptimes sort low.
+func (m Model) uptimeSortKey(pids []int32) time.Duration {
+	d, ok := m.processUptime(pids)
+	if !ok {
+		return -1
+	}
+	return d
+}
+
+// formatUptime formats a duration with its two largest units: "42s", "5m12s",
+// "3h4m", "12d6h".
+func formatUptime(d time.Duration) string {
+	d = d.Truncate(time.Second)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%ds", int(d.Minutes()), int(d.Seconds())%60)
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh%dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dd%dh", int(d.Hours())/24, int(d.Hours())%24)
+	}
+}
+
+// queueStartTimeLookups returns a command looking up the start times of
+// primary PIDs not yet cached. Start times never change, so each PID is
+// looked up once.
+func (m Model) queueStartTimeLookups(snapshot *model.NetworkSnapshot) tea.Cmd {
+	if !m.showUptime || snapshot == nil {
+		return nil
+	}
+
+	var pids []int32
+	for _, app := range snapshot.Applications {
+		if len(app.PIDs) == 0 {
+			continue
+		}
+		if _, ok := m.startTimes[app.PIDs[0]]; !ok {
+			pids = append(pids, app.PIDs[0])
+		}
+	}
+	if len(pids) == 0 {
+		return nil
+	}
+
+	return func() tea.Msg {
+		times := make(map[int32]time.Time, len(pids))
+		for _, pid := range pids {
+			// Failures are cached as the zero time to avoid repeated lookups
+			start, _ := lookupStartTime(pid)
+			times[pid] = start
+		}
+		return StartTimesResolvedMsg{Times: times}
+	}
+}
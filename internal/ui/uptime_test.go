@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatUptime(t *testing.T) {
+	for _, tt := range []struct {
+		d    time.Duration
+		want string
+	}{
+		{42 * time.Second, "42s"},
+		{5*time.Minute + 12*time.Second, "5m12s"},
+		{3*time.Hour + 4*time.Minute + 59*time.Second, "3h4m"},
+		{12*24*time.Hour + 6*time.Hour, "12d6h"},
+	} {
+		if got := formatUptime(tt.d); got != tt.want {
+			t.Errorf("formatUptime(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestUptimeLabel(t *testing.T) {
+	m := createTestModel()
+	m.startTimes = map[int32]time.Time{
+		100: time.Now().Add(-2 * time.Hour),
+		200: {}, // lookup failed
+	}
+	for _, tt := range []struct {
+		pids []int32
+		want string
+	}{{[]int32{100}, "2h0m"}, {[]int32{200}, "—"}, {[]int32{300}, "…"}} {
+		if got := m.uptimeLabel(tt.pids); got != tt.want {
+			t.Errorf("uptimeLabel(%v) = %q, want %q", tt.pids, got, tt.want)
+		}
+	}
+}
+
+func TestQueueStartTimeLookups(t *testing.T) {
+	m := createTestModel()
+	if cmd := m.queueStartTimeLookups(m.snapshot); cmd != nil {
+		t.Error("no lookups expected when disabled")
+	}
+
+	m.showUptime = true
+	m.startTimes = map[int32]time.Time{100: time.Now()}
+	var looked []int32
+	orig := lookupStartTime
+	lookupStartTime = func(pid int32) (time.Time, error) {
+		looked = append(looked, pid)
+		if pid == 300 {
+			return time.Time{}, errors.New("no such process")
+		}
+		return time.Now().Add(-time.Minute), nil
+	}
+	t.Cleanup(func() { lookupStartTime = orig })
+
+	cmd := m.queueStartTimeLookups(m.snapshot)
+	if cmd == nil {
+		t.Fatal("expected lookups for uncached PIDs")
+	}
+	updated, _ := m.Update(cmd())
+	m = updated.(Model)
+	if len(looked) != 2 || looked[0] != 200 || looked[1] != 300 {
+		t.Errorf("looked up %v, want [200 300]", looked)
+	}
+	if _, ok := m.startTimes[300]; !ok {
+		t.Error("failed lookups should be cached")
+	}
+	if cmd := m.queueStartTimeLookups(m.snapshot); cmd != nil {
+		t.Error("no lookups expected once every PID is cached")
+	}
+}
+
+func TestSortProcessList_Uptime(t *testing.T) {
+	m := createTestModel()
+	m.showUptime = true
+	now := time.Now()
+	m.startTimes = map[int32]time.Time{100: now.Add(-time.Hour), 200: now.Add(-time.Minute), 300: {}}
+	m.CurrentView().SortColumn = SortUptime
+	m.CurrentView().SortAscending = true
+
+	var got []string
+	for _, app := range m.sortProcessList(m.snapshot.Applications) {
+		got = append(got, app.Name)
+	}
+	if want := "App3,App2,App1"; strings.Join(got, ",") != want {
+		t.Errorf("ascending uptime = %v, want %s (unknown first, newest next)", got, want)
+	}
+}
+
+func TestActiveProcessListColumns_Uptime(t *testing.T) {
+	m := createTestModel()
+	m.showUptime = true
+	m.securityContext = true
+	cols := m.activeProcessListColumns()
+	if cols[len(cols)-2].id != SortUptime || cols[len(cols)-1].id != SortSecurity {
+		t.Errorf("optional columns end with %v, %v; want Uptime, Security", cols[len(cols)-2].id, cols[len(cols)-1].id)
+	}
+}
@@ -6,8 +6,11 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/kostyay/netmon/internal/config"
 	"github.com/kostyay/netmon/internal/docker"
+	"github.com/kostyay/netmon/internal/filter"
+	"github.com/kostyay/netmon/internal/i18n"
 	"github.com/kostyay/netmon/internal/model"
 )
 
@@ -29,12 +32,8 @@ func (m Model) renderHeader() string {
 	innerWidth := m.width - 2
 
 	// Double-line box drawing
-	topLeft := "╔"
-	topRight := "╗"
-	bottomLeft := "╚"
-	bottomRight := "╝"
-	horizontal := "═"
-	vertical := "║"
+	g := frame(doubleFrame)
+	topLeft, topRight, bottomLeft, bottomRight, horizontal, vertical := g.topLeft, g.topRight, g.bottomLeft, g.bottomRight, g.horizontal, g.vertical
 
 	// Build top border with centered NETMON title
 	title := " NETMON "
@@ -58,13 +57,17 @@ func (m Model) renderHeader() string {
 	if m.animations && m.animationFrame == 1 {
 		liveIndicator = "○"
 	}
-	liveText := liveStyle.Render(liveIndicator + " LIVE")
+	liveLabel := " LIVE"
+	if m.idle {
+		liveLabel = " IDLE"
+	}
+	liveText := liveStyle.Render(liveIndicator + liveLabel)
 
 	// Connection count
 	connCount := 0
 	var totalTX, totalRX uint64
 	if m.snapshot != nil {
-		connCount = m.snapshot.TotalConnections()
+		connCount = m.snapshot.HostConnections()
 		// Aggregate TX/RX from cache
 		for _, stats := range m.netIOCache {
 			totalTX += stats.BytesSent
@@ -73,19 +76,62 @@ func (m Model) renderHeader() string {
 	}
 
 	// Format stats
-	statsText := statsStyle.Render(fmt.Sprintf("  %d connections", connCount))
+	statsText := statsStyle.Render("  " + fmt.Sprintf(i18n.T("%d connections"), connCount))
+	if m.snapshot != nil && m.snapshot.LoopbackCount > 0 {
+		statsText += statsStyle.Render(" " + fmt.Sprintf(i18n.T("(+%d loopback hidden)"), m.snapshot.LoopbackCount))
+	}
+	if label := m.matchListLabel(); label != "" {
+		statsText += WarnStyle().Render("   ◎ " + label)
+	}
+	if label := m.allowListLabel(); label != "" {
+		statsText += WarnStyle().Render("   ⚑ " + label)
+	}
+	if label := m.portWatchLabel(); label != "" {
+		statsText += WarnStyle().Render("   ◷ " + label)
+	}
+	if label := m.followLabel(); label != "" {
+		statsText += WarnStyle().Render("   ▶ " + label)
+	}
 	ioText := statsStyle.Render(fmt.Sprintf("   ▲ %s   ▼ %s", formatBytes(totalTX), formatBytes(totalRX)))
-	refreshText := statsStyle.Render(fmt.Sprintf("   %.1fs", m.refreshInterval.Seconds()))
+	churn := m.churnRate("")
+	churnText := statsStyle.Render("   " + fmt.Sprintf(i18n.T("+%s −%s conn/s"), formatRate(churn.Opened), formatRate(churn.Closed)))
+	if counts := m.changeCountText(); counts != "" {
+		churnText += LiveIndicatorStyle().Render("   " + counts)
+	}
+	refreshText := statsStyle.Render(fmt.Sprintf("   %.1fs", m.effectiveRefreshInterval().Seconds()))
+	if m.sockWatcher != nil {
+		refreshText += statsStyle.Render(" ⚡")
+	}
+	if m.capture != nil {
+		refreshText += statsStyle.Render(" pcap")
+	}
+	if m.largeHost {
+		refreshText += warnStyle.Render(" (" + i18n.T("large host") + ")")
+	}
+	if badge := m.sampleBadge(); badge != "" {
+		refreshText += warnStyle.Render(" (" + badge + ")")
+	}
+	if badge := m.staleBadge(time.Now()); badge != "" {
+		refreshText += warnStyle.Render("  ⏱ " + badge)
+	}
 
 	// Error or update indicator
 	rightContent := ""
 	if m.lastError != nil {
-		rightContent = warnStyle.Render(fmt.Sprintf("  ⚠ %s", truncateString(m.lastError.Error(), 30)))
+		rightContent = warnStyle.Render(fmt.Sprintf("  ⚠ %s (!)", truncateString(m.lastError.Error(), 30)))
+	} else if badge := m.scanBadge(); badge != "" {
+		rightContent = warnStyle.Render("  ⚠ " + badge)
+	} else if badge := m.saturationBadge(); badge != "" {
+		rightContent = warnStyle.Render("  ⚠ " + badge)
+	} else if badge := m.partialViewBadge(); badge != "" {
+		rightContent = warnStyle.Render("  ⚠ " + badge)
+	} else if m.updating {
+		rightContent = warnStyle.Render("  ▲ " + fmt.Sprintf(i18n.T("installing %s…"), m.updateAvailable))
 	} else if m.updateAvailable != "" {
-		rightContent = warnStyle.Render(fmt.Sprintf("  ▲ %s", m.updateAvailable))
+		rightContent = warnStyle.Render("  ▲ " + fmt.Sprintf(i18n.T("%s (U to update)"), m.updateAvailable))
 	}
 
-	content := liveText + statsText + ioText + refreshText + rightContent
+	content := liveText + statsText + ioText + churnText + refreshText + rightContent
 
 	// Pad content to fill width
 	contentWidth := lipgloss.Width(content)
@@ -98,6 +144,15 @@ func (m Model) renderHeader() string {
 	contentLine += " " + content + strings.Repeat(" ", padding) + " "
 	contentLine += borderStyle.Render(vertical)
 
+	// Dashboard lines ('D')
+	if m.dashboard {
+		for _, line := range m.dashboardContent(time.Now()) {
+			line = statsStyle.MaxWidth(max(innerWidth-2, 0)).Render(line)
+			pad := max(innerWidth-lipgloss.Width(line)-2, 0)
+			contentLine += "\n" + borderStyle.Render(vertical) + " " + line + strings.Repeat(" ", pad) + " " + borderStyle.Render(vertical)
+		}
+	}
+
 	// Build bottom border
 	bottomBorder := borderStyle.Render(bottomLeft)
 	bottomBorder += borderStyle.Render(strings.Repeat(horizontal, innerWidth))
@@ -119,7 +174,28 @@ func (m Model) frozenHeaderHeight() int {
 		lines := 4
 		selectedApp := m.findSelectedApp(view.ProcessName)
 		if selectedApp != nil && selectedApp.Exe != "" {
-			lines = 5
+			lines++
+			if line, _ := m.exeVerifyLine(selectedApp.Exe); line != "" {
+				lines++
+			}
+		}
+		if m.securityContext {
+			lines++
+		}
+		if selectedApp != nil && m.cgroupLine(selectedApp.PIDs) != "" {
+			lines++
+		}
+		if m.showProcEnv() {
+			lines += 2
+		}
+		if m.rateStatsLine(view.ProcessName) != "" {
+			lines++
+		}
+		if m.sessionTotalsLine(view.ProcessName) != "" {
+			lines++
+		}
+		if m.socketLine() != "" {
+			lines++
 		}
 		return lines
 	default:
@@ -151,7 +227,7 @@ func (m Model) renderFrozenHeader() string {
 
 	switch view.Level {
 	case LevelProcessList:
-		columns := processListColumns()
+		columns := m.activeProcessListColumns()
 		widths := calculateColumnWidths(columns, m.contentWidth())
 		b.WriteString(m.renderProcessListHeader(widths))
 
@@ -169,17 +245,89 @@ func (m Model) renderFrozenHeader() string {
 		if selectedApp.Exe != "" {
 			b.WriteString(StatusStyle().Render(selectedApp.Exe))
 			b.WriteString("\n")
+			// Hash and signature (verified with 'V')
+			if line, warn := m.exeVerifyLine(selectedApp.Exe); line != "" {
+				style := StatusStyle()
+				if warn {
+					style = WarnStyle()
+				}
+				b.WriteString(style.Render(truncateString(line, m.contentWidth())))
+				b.WriteString("\n")
+			}
+		}
+
+		// Start time and uptime (if enabled)
+		if m.showUptime {
+			if d, ok := m.processUptime(selectedApp.PIDs); ok {
+				started := time.Now().Add(-d).Format("2006-01-02 15:04:05")
+				b.WriteString(StatusStyle().Render(fmt.Sprintf(i18n.T("Started: %s (up %s)"), started, formatUptime(d))))
+				b.WriteString("\n")
+			}
+		}
+
+		// Open files against the limit (if enabled)
+		if m.showFDs {
+			if line := m.fdDetailLine(selectedApp.PIDs); line != "" {
+				style := StatusStyle()
+				if m.fdNearLimit(selectedApp.PIDs) {
+					style = WarnStyle()
+				}
+				b.WriteString(style.Render(line))
+				b.WriteString("\n")
+			}
+		}
+
+		// Cgroup path (if enabled)
+		if line := m.cgroupLine(selectedApp.PIDs); line != "" {
+			b.WriteString(StatusStyle().Render(truncateString(line, m.contentWidth())))
+			b.WriteString("\n")
+		}
+
+		// Security context (if enabled)
+		if m.securityContext {
+			b.WriteString(StatusStyle().Render("Security: " + m.securityLabel(selectedApp.PIDs)))
+			b.WriteString("\n")
 		}
 
-		// PIDs and TX/RX stats
-		conns := m.filteredConnections(selectedApp.Connections)
+		// Working directory and whitelisted environment (expanded with 'e')
+		if m.showProcEnv() {
+			cwd, env := m.procEnvLines(selectedApp.PIDs)
+			b.WriteString(StatusStyle().Render(truncateString(cwd, m.contentWidth())))
+			b.WriteString("\n")
+			b.WriteString(StatusStyle().Render(truncateString(env, m.contentWidth())))
+			b.WriteString("\n")
+		}
+
+		// PIDs and TX/RX stats (container network stats for virtual containers)
+		conns := m.visibleConnections(selectedApp)
 		txStr, rxStr := m.getAggregatedNetIO(selectedApp.PIDs)
 		statsLine := fmt.Sprintf("PIDs: %s  |  TX: %s  RX: %s  |  %d connections",
 			formatPIDList(selectedApp.PIDs),
 			txStr, rxStr,
 			len(conns))
+		if vc := m.findVirtualContainer(view.ProcessName); vc != nil {
+			statsLine = fmt.Sprintf("Container: %s  |  %s  |  %d connections",
+				withHealth(vc.Info.ID, vc.Info), m.containerIOSummary(*vc), len(conns))
+		}
 		b.WriteString(StatusStyle().Render(statsLine))
 		b.WriteString("\n")
+		if line := m.rateStatsLine(view.ProcessName); line != "" {
+			b.WriteString(StatusStyle().Render(truncateString(line, m.contentWidth())))
+			b.WriteString("\n")
+		}
+		if line := m.sessionTotalsLine(view.ProcessName); line != "" {
+			b.WriteString(StatusStyle().Render(truncateString(line, m.contentWidth())))
+			b.WriteString("\n")
+		}
+		if line := m.portConflictLine(selectedApp.PIDs); line != "" {
+			b.WriteString(WarnStyle().Render(truncateString(line, m.contentWidth())))
+			b.WriteString("\n")
+		}
+		// Selected connection's socket fd (for lsof/strace)
+		if line := m.socketLine(); line != "" {
+			b.WriteString(StatusStyle().Render(truncateString(line, m.contentWidth())))
+			b.WriteString("\n")
+		}
 
 		// Table header
 		columns := m.activeConnectionsColumns()
@@ -187,9 +335,21 @@ func (m Model) renderFrozenHeader() string {
 		b.WriteString(m.renderConnectionsHeader(widths))
 
 	case LevelAllConnections:
-		columns := allConnectionsColumns()
+		columns := m.activeAllConnectionsColumns()
 		widths := calculateColumnWidths(columns, m.contentWidth())
 		b.WriteString(m.renderAllConnectionsHeader(widths))
+
+	case LevelConntrack:
+		widths := calculateColumnWidths(conntrackColumns(), m.contentWidth())
+		b.WriteString(m.renderConntrackHeader(widths))
+
+	case LevelDockerPorts:
+		widths := calculateColumnWidths(dockerPortsColumns(), m.contentWidth())
+		b.WriteString(m.renderDockerPortsHeader(widths))
+
+	case LevelBinds:
+		widths := calculateColumnWidths(bindsColumns(), m.contentWidth())
+		b.WriteString(m.renderBindsHeader(widths))
 	}
 
 	return b.String()
@@ -200,15 +360,16 @@ func (m Model) View() string {
 	if m.quitting {
 		return ""
 	}
+	defer m.observe("render", time.Now())
 
 	// Wait for viewport to be initialized
 	if !m.ready {
-		return LoadingStyle().Render("Initializing...")
+		return LoadingStyle().Render(i18n.T("Initializing..."))
 	}
 
 	view := m.CurrentView()
 	if view == nil {
-		return LoadingStyle().Render("Initializing...")
+		return LoadingStyle().Render(i18n.T("Initializing..."))
 	}
 
 	// Render base content
@@ -221,6 +382,45 @@ func (m Model) View() string {
 	if m.settingsMode {
 		return m.overlayModal(baseContent, m.renderSettingsModalContent(), "Settings", 44)
 	}
+	if m.diagMode {
+		return m.overlayModal(baseContent, m.renderDiagnosticsModalContent(), "Diagnostics", diagModalWidth)
+	}
+	if m.dnsMode {
+		return m.overlayModal(baseContent, m.renderDNSModalContent(), "DNS Activity", dnsModalWidth)
+	}
+	if m.ifaceMode {
+		return m.overlayModal(baseContent, m.renderIfaceModalContent(), "Interface Load", ifaceModalWidth)
+	}
+	if m.geoMode {
+		return m.overlayModal(baseContent, m.renderGeoModalContent(), "Connections by Country", geoModalWidth)
+	}
+	if m.compareMode {
+		return m.overlayModal(baseContent, m.renderCompareModalContent(), "Compare Processes", compareModalWidth)
+	}
+	if m.paletteMode {
+		return m.overlayModal(baseContent, m.renderPaletteModalContent(), "Commands", paletteModalWidth)
+	}
+	if m.presetsMode {
+		return m.overlayModal(baseContent, m.renderPresetsModalContent(), "Filter Presets", presetsModalWidth)
+	}
+	if m.certMode {
+		return m.overlayModal(baseContent, m.renderCertModalContent(), "TLS Certificate", certModalWidth)
+	}
+	if m.asnMode {
+		return m.overlayModal(baseContent, m.renderASNModalContent(), "Connections by Network", asnModalWidth)
+	}
+	if m.hostsMode {
+		return m.overlayModal(baseContent, m.renderHostsModalContent(), "Connections by Host", hostsModalWidth)
+	}
+	if m.noteMode {
+		return m.overlayModal(baseContent, m.renderNoteModalContent(), "Note", noteModalWidth)
+	}
+	if m.tagMode {
+		return m.overlayModal(baseContent, m.renderTagModalContent(), "Incident Tag", tagModalWidth)
+	}
+	if m.matchMode {
+		return m.overlayModal(baseContent, m.renderMatchModalContent(), "Target List", matchModalWidth)
+	}
 	if m.killMode && m.killTarget != nil {
 		modalWidth := m.killModalWidth()
 		title := "Kill Process"
@@ -229,6 +429,15 @@ func (m Model) View() string {
 		}
 		return m.overlayDangerModal(baseContent, m.renderKillModalContent(), title, modalWidth)
 	}
+	if m.actionMode && m.actionTarget != nil {
+		return m.overlayModal(baseContent, m.renderActionModalContent(), m.action.String()+" Process", m.killModalWidth())
+	}
+	if m.closeMode && m.closeTarget != nil {
+		return m.overlayDangerModal(baseContent, m.renderCloseModalContent(), "Close Connection", 56)
+	}
+	if m.updateMode {
+		return m.overlayModal(baseContent, m.renderUpdateModalContent(), "Self-Update", 56)
+	}
 
 	return baseContent
 }
@@ -242,15 +451,8 @@ func (m Model) renderBaseView() string {
 	b.WriteString("\n")
 
 	// === CONTENT (wrapped in frame with frozen header + scrollable viewport) ===
-	// Calculate connection count for title
-	connCount := 0
-	if m.snapshot != nil {
-		connCount = m.snapshot.TotalConnections()
-	}
-	frameTitle := fmt.Sprintf("connections: %d", connCount)
-
 	// Render frame with frozen header outside viewport
-	framedContent := m.renderFrameWithFrozenHeader(frameTitle)
+	framedContent := m.renderFrameWithFrozenHeader(m.frameTitle())
 	b.WriteString(framedContent)
 	b.WriteString("\n")
 
@@ -262,16 +464,13 @@ func (m Model) renderBaseView() string {
 
 // renderFrameWithFrozenHeader renders the frame with frozen header above scrollable viewport.
 func (m Model) renderFrameWithFrozenHeader(title string) string {
-	borderColor := lipgloss.Color(config.CurrentTheme.Styles.Table.HeaderFgColor)
-	titleColor := lipgloss.Color(config.CurrentTheme.Styles.Header.TitleFg)
+	borderColor := themeColor(config.CurrentTheme.Styles.Table.HeaderFgColor)
+	titleColor := themeColor(config.CurrentTheme.Styles.Header.TitleFg)
 
 	// Border characters
-	topLeft := "╭"
-	topRight := "╮"
-	bottomLeft := "╰"
-	bottomRight := "╯"
-	horizontal := "─"
-	vertical := "│"
+	g := frame(roundFrame)
+	topLeft, topRight, bottomLeft, bottomRight, horizontal, vertical := g.topLeft, g.topRight, g.bottomLeft, g.bottomRight, g.horizontal, g.vertical
+	track, thumb := scrollGlyphs()
 
 	borderStyle := lipgloss.NewStyle().Foreground(borderColor)
 	titleStyle := lipgloss.NewStyle().Foreground(titleColor).Bold(true)
@@ -296,9 +495,21 @@ func (m Model) renderFrameWithFrozenHeader(title string) string {
 	topBorder += borderStyle.Render(strings.Repeat(horizontal, rightPad))
 	topBorder += borderStyle.Render(topRight)
 
+	// Scroll position: thumb on the right border, position label on the bottom border
+	total, height, offset := m.viewport.TotalLineCount(), m.viewport.Height, m.viewport.YOffset
+	thumbStart, thumbSize := scrollbarThumb(total, height, offset)
+	scrollLabel := scrollPercentLabel(total, height, offset)
+
 	// Build bottom border
 	bottomBorder := borderStyle.Render(bottomLeft)
-	bottomBorder += borderStyle.Render(strings.Repeat(horizontal, innerWidth))
+	if scrollLabel != "" && innerWidth > len(scrollLabel)+3 {
+		label := " " + scrollLabel + " "
+		bottomBorder += borderStyle.Render(strings.Repeat(horizontal, innerWidth-len(label)-1))
+		bottomBorder += titleStyle.Render(label)
+		bottomBorder += borderStyle.Render(horizontal)
+	} else {
+		bottomBorder += borderStyle.Render(strings.Repeat(horizontal, innerWidth))
+	}
 	bottomBorder += borderStyle.Render(bottomRight)
 
 	var result strings.Builder
@@ -306,25 +517,33 @@ func (m Model) renderFrameWithFrozenHeader(title string) string {
 	result.WriteString("\n")
 
 	// Helper to render a content line with borders
-	renderLine := func(line string) {
+	renderLine := func(line, right string) {
 		result.WriteString(borderStyle.Render(vertical))
 		result.WriteString(" ")
 		result.WriteString(padRight(line, innerWidth-2))
 		result.WriteString(" ")
-		result.WriteString(borderStyle.Render(vertical))
+		result.WriteString(right)
 		result.WriteString("\n")
 	}
 
 	// Render frozen header lines (outside viewport, won't scroll)
 	if frozenHeader := m.renderFrozenHeader(); frozenHeader != "" {
 		for _, line := range strings.Split(frozenHeader, "\n") {
-			renderLine(line)
+			renderLine(line, borderStyle.Render(vertical))
 		}
 	}
 
 	// Render viewport content (scrollable data rows)
-	for _, line := range strings.Split(m.viewport.View(), "\n") {
-		renderLine(line)
+	for i, line := range strings.Split(m.viewport.View(), "\n") {
+		right := borderStyle.Render(vertical)
+		if thumbSize > 0 {
+			if i >= thumbStart && i < thumbStart+thumbSize {
+				right = titleStyle.Render(thumb)
+			} else {
+				right = borderStyle.Render(track)
+			}
+		}
+		renderLine(line, right)
 	}
 
 	result.WriteString(bottomBorder)
@@ -339,11 +558,20 @@ func (m Model) renderBreadcrumbsText() string {
 	}
 	switch view.Level {
 	case LevelProcessList:
+		if view.SortColumn == SortAuto {
+			return "PROCESSES · auto sort"
+		}
 		return "PROCESSES"
 	case LevelConnections:
 		return "PROCESSES > " + view.ProcessName
 	case LevelAllConnections:
 		return "ALL CONNECTIONS"
+	case LevelConntrack:
+		return "CONNTRACK"
+	case LevelDockerPorts:
+		return "DOCKER PORTS"
+	case LevelBinds:
+		return "BINDS"
 	default:
 		return ""
 	}
@@ -355,15 +583,22 @@ func (m Model) renderFooter() string {
 	statusStyle := StatusStyle()
 
 	// Row 1: Status line (result, search, or breadcrumbs)
-	if m.killResult != "" && time.Since(m.killResultAt) < 2*time.Second {
-		b.WriteString(statusStyle.Width(m.width).Render(m.killResult))
+	if t := m.currentToast(time.Now()); t != nil {
+		b.WriteString(statusStyle.Width(m.width).Render(t.Severity.icon() + t.Message))
 	} else if m.searchMode {
-		b.WriteString(statusStyle.Width(m.width).Render(fmt.Sprintf("/%s█", m.searchQuery)))
+		statusLine := m.renderSearchLine()
+		if chips := m.renderFilterChips(); chips != "" {
+			statusLine = chips + " " + statusLine
+		}
+		b.WriteString(statusStyle.Width(m.width).Render(statusLine))
 	} else {
-		// Breadcrumbs + filter indicator
+		// Tabs + breadcrumbs + filter chips
 		statusLine := m.renderBreadcrumbsText()
-		if m.activeFilter != "" {
-			statusLine = fmt.Sprintf("[%s] %s", m.activeFilter, statusLine)
+		if chips := m.renderFilterChips(); chips != "" {
+			statusLine = chips + " " + statusLine
+		}
+		if tabs := m.renderTabBar(); tabs != "" {
+			statusLine = tabs + "  " + statusLine
 		}
 		b.WriteString(statusStyle.Width(m.width).Render(statusLine))
 	}
@@ -389,7 +624,7 @@ func (m Model) renderKeybindingsText() string {
 
 	// Helper to format a key-label pair
 	btn := func(key, label string) string {
-		return keyStyle.Render(key) + " " + descStyle.Render(label)
+		return keyStyle.Render(key) + " " + descStyle.Render(i18n.T(label))
 	}
 
 	sep := sepStyle.Render("  ·  ")
@@ -402,6 +637,17 @@ func (m Model) renderKeybindingsText() string {
 			btn("↑↓", "signal"),
 			btn("esc", "cancel"),
 		}
+	} else if m.actionMode && m.action == actionRenice {
+		parts = []string{
+			btn("↵", "confirm"),
+			btn("←→", "nice"),
+			btn("esc", "cancel"),
+		}
+	} else if m.closeMode || m.updateMode || m.actionMode {
+		parts = []string{
+			btn("↵", "confirm"),
+			btn("esc", "cancel"),
+		}
 	} else if view.SortMode {
 		parts = []string{
 			btn("←→", "column"),
@@ -409,86 +655,67 @@ func (m Model) renderKeybindingsText() string {
 			btn("esc", "cancel"),
 		}
 	} else if m.searchMode {
+		parts = []string{btn("↵", "add filter")}
+		if len(m.searchSuggestions(m.searchQuery)) > 0 {
+			parts = append(parts, btn(KeyComplete.Key, "complete"))
+		}
+		parts = append(parts, btn("esc", "cancel"))
+	} else if m.chipMode {
 		parts = []string{
-			btn("↵", "apply"),
-			btn("esc", "cancel"),
+			btn("←→", "select"),
+			btn(KeyChipRemove.Key, "remove"),
+			btn(KeyChipClear.Key, "clear all"),
+			btn("esc", "done"),
 		}
 	} else {
-		// Normal mode - contextual keys
-		switch view.Level {
-		case LevelProcessList:
-			parts = []string{
-				btn("↵", "drill"),
-				btn("/", "search"),
-				btn("s", "sort"),
-				btn("v", "flat"),
-				btn("x", "kill"),
-				btn("S", "settings"),
-				btn("?", "help"),
-				btn("q", "quit"),
-			}
-		case LevelConnections:
-			parts = []string{
-				btn("esc", "back"),
-				btn("/", "search"),
-				btn("s", "sort"),
-				btn("v", "flat"),
-				btn("x", "kill"),
-				btn("S", "settings"),
-				btn("?", "help"),
-				btn("q", "quit"),
-			}
-		case LevelAllConnections:
-			parts = []string{
-				btn("/", "search"),
-				btn("s", "sort"),
-				btn("v", "grouped"),
-				btn("x", "kill"),
-				btn("S", "settings"),
-				btn("?", "help"),
-				btn("q", "quit"),
-			}
+		// Normal mode - contextual keys from the command registry
+		for _, hint := range m.footerHints() {
+			parts = append(parts, btn(hint[0], hint[1]))
 		}
 	}
 
 	return strings.Join(parts, sep)
 }
 
-// currentFilter returns the active filter string, preferring searchQuery when in search mode.
-func (m Model) currentFilter() string {
-	if m.searchMode {
-		return m.searchQuery
-	}
-	return m.activeFilter
-}
-
-// filteredApps returns applications matching the current filter.
-// Matches if ANY connection in the app matches the filter.
+// filteredApps returns applications matching the current filters.
+// Matches if the process fields, or the process fields together with ANY one
+// connection, satisfy every filter (so "chrome" + "443" needs a chrome connection on 443).
+// With a target list, that connection (or any one, for a process-level match) must also touch it.
 func (m Model) filteredApps() []model.Application {
 	if m.snapshot == nil {
 		return nil
 	}
-	filter := m.currentFilter()
-	if filter == "" {
+	filters := m.currentFilters()
+	if len(filters) == 0 && m.matchList == nil {
 		return m.snapshot.Applications
 	}
 
 	var result []model.Application
-	exactMatch := m.useExactPortMatch()
 	for _, app := range m.snapshot.Applications {
 		// Check if process-level fields match
-		if matchesFilter(filter, filterFields{ProcessName: app.Name, PIDs: app.PIDs}, exactMatch) {
-			result = append(result, app)
+		if filter.MatchAll(filters, filter.Fields{ProcessName: app.Name, PIDs: app.PIDs, Notes: m.notesFor(app.Name), Lineage: m.lineage(app.PIDs)}, m.cliFilter) {
+			if m.appMatchesTargets(&app) {
+				result = append(result, app)
+			}
 			continue
 		}
 		// Check if any connection matches
 		for _, conn := range app.Connections {
-			if matchesFilter(filter, filterFields{
-				LocalAddr:  conn.LocalAddr,
-				RemoteAddr: conn.RemoteAddr,
-				Protocol:   string(conn.Protocol),
-				State:      string(conn.State),
-			}, exactMatch) {
+			if m.matchesTargets(conn) && filter.MatchAll(filters, filter.Fields{
+				ProcessName: app.Name,
+				PIDs:        app.PIDs,
+				LocalAddr:   conn.LocalAddr,
+				RemoteAddr:  conn.RemoteAddr,
+				Protocol:    string(conn.Protocol),
+				State:       m.stateLabel(conn),
+				Notes:       m.notesFor(app.Name, extractIP(conn.RemoteAddr)),
+				ASN:         m.remoteASN(conn.RemoteAddr),
+				Interface:   m.connInterface(conn),
+				Via:         m.proxyVia(conn),
+				Lineage:     m.lineage(app.PIDs),
+				Country:     m.remoteCountry(conn.RemoteAddr),
+				Host:        m.remoteHost(conn.RemoteAddr),
+			}, m.cliFilter) {
 				result = append(result, app)
 				break
 			}
@@ -552,6 +779,15 @@ func containerDisplayName(vc model.VirtualContainer) string {
 	return "🐳 " + vc.Info.Name + " (" + vc.Info.Image + ")"
 }
 
+// withHealth appends the container's health indicator to text, e.g.
+// "🐳 web (nginx) · unhealthy ×3".
+func withHealth(text string, ci model.ContainerInfo) string {
+	if label := ci.HealthLabel(); label != "" {
+		return text + noteSeparator + label
+	}
+	return text
+}
+
 // findSelectedApp finds the application for the current connections view.
 func (m Model) findSelectedApp(processName string) *model.Application {
 	if isVirtualContainerName(processName) {
@@ -568,70 +804,93 @@ func (m Model) findSelectedApp(processName string) *model.Application {
 	return nil
 }
 
-// filteredVirtualContainers returns virtual containers matching the current filter.
+// filteredVirtualContainers returns virtual containers matching every current filter.
 func (m Model) filteredVirtualContainers() []model.VirtualContainer {
 	if !m.dockerContainers || len(m.virtualContainers) == 0 {
 		return nil
 	}
-	filter := m.currentFilter()
-	if filter == "" {
+	filters := m.currentFilters()
+	if len(filters) == 0 && m.matchList == nil {
 		return m.virtualContainers
 	}
-	filterLower := strings.ToLower(filter)
 	var result []model.VirtualContainer
 	for _, vc := range m.virtualContainers {
-		if strings.Contains(strings.ToLower(vc.Info.Name), filterLower) ||
-			strings.Contains(strings.ToLower(vc.Info.Image), filterLower) ||
-			strings.Contains(strings.ToLower(vc.Info.ID), filterLower) {
+		if !m.appMatchesTargets(m.virtualContainerApp(containerDisplayName(vc))) {
+			continue
+		}
+		matched := true
+		for _, filter := range filters {
+			filterLower := strings.ToLower(filter)
+			if !strings.Contains(strings.ToLower(vc.Info.Name), filterLower) &&
+				!strings.Contains(strings.ToLower(vc.Info.Image), filterLower) &&
+				!strings.Contains(strings.ToLower(vc.Info.ID), filterLower) &&
+				!strings.Contains(vc.Info.HealthLabel(), filterLower) {
+				matched = false
+				break
+			}
+		}
+		if matched {
 			result = append(result, vc)
 		}
 	}
 	return result
 }
 
-// filteredConnections returns connections matching the current filter for a specific process.
+// filteredConnections returns connections matching the current filters for a specific process.
 func (m Model) filteredConnections(conns []model.Connection) []model.Connection {
-	filter := m.currentFilter()
-	if filter == "" {
+	filters := m.currentFilters()
+	if len(filters) == 0 && m.matchList == nil {
 		return conns
 	}
 
 	var result []model.Connection
-	exactMatch := m.useExactPortMatch()
 	for _, conn := range conns {
-		if matchesFilter(filter, filterFields{
+		if m.matchesTargets(conn) && filter.MatchAll(filters, filter.Fields{
 			PIDs:       []int32{conn.PID},
 			LocalAddr:  conn.LocalAddr,
 			RemoteAddr: conn.RemoteAddr,
 			Protocol:   string(conn.Protocol),
-			State:      string(conn.State),
-		}, exactMatch) {
+			State:      m.stateLabel(conn),
+			Notes:      m.notesFor(extractIP(conn.RemoteAddr)),
+			ASN:        m.remoteASN(conn.RemoteAddr),
+			Interface:  m.connInterface(conn),
+			Via:        m.proxyVia(conn),
+			Lineage:    m.lineage([]int32{conn.PID}),
+			Country:    m.remoteCountry(conn.RemoteAddr),
+			Host:       m.remoteHost(conn.RemoteAddr),
+		}, m.cliFilter) {
 			result = append(result, conn)
 		}
 	}
 	return result
 }
 
-// filteredAllConnections returns connections matching the current filter.
+// filteredAllConnections returns connections matching the current filters.
 func (m Model) filteredAllConnections() []connectionWithProcess {
 	if m.snapshot == nil {
 		return nil
 	}
-	filter := m.currentFilter()
+	filters := m.currentFilters()
 
 	var result []connectionWithProcess
-	exactMatch := m.useExactPortMatch()
 	for _, app := range m.snapshot.Applications {
 		for _, conn := range app.Connections {
-			// No filter or matches filter - include connection
-			if filter == "" || matchesFilter(filter, filterFields{
+			// No filter or matches all filters - include connection
+			if m.matchesTargets(conn) && filter.MatchAll(filters, filter.Fields{
 				ProcessName: app.Name,
 				PIDs:        []int32{conn.PID},
 				LocalAddr:   conn.LocalAddr,
 				RemoteAddr:  conn.RemoteAddr,
 				Protocol:    string(conn.Protocol),
-				State:       string(conn.State),
-			}, exactMatch) {
+				State:       m.stateLabel(conn),
+				Notes:       m.notesFor(app.Name, extractIP(conn.RemoteAddr)),
+				ASN:         m.remoteASN(conn.RemoteAddr),
+				Interface:   m.connInterface(conn),
+				Via:         m.proxyVia(conn),
+				Lineage:     m.lineage([]int32{conn.PID}),
+				Country:     m.remoteCountry(conn.RemoteAddr),
+				Host:        m.remoteHost(conn.RemoteAddr),
+			}, m.cliFilter) {
 				result = append(result, connectionWithProcess{
 					Connection:  conn,
 					ProcessName: app.Name,
@@ -645,7 +904,7 @@ func (m Model) filteredAllConnections() []connectionWithProcess {
 // renderProcessList renders the process list table (Level 0).
 func (m Model) renderProcessList() string {
 	if m.snapshot == nil {
-		return LoadingStyle().Render("Loading...")
+		return LoadingStyle().Render(i18n.T("Loading..."))
 	}
 
 	view := m.CurrentView()
@@ -660,15 +919,15 @@ func (m Model) renderProcessList() string {
 	if len(apps) == 0 {
 		filter := m.currentFilter()
 		if filter != "" {
-			return EmptyStyle().Render(fmt.Sprintf("No matches for '%s'", filter))
+			return EmptyStyle().Render(fmt.Sprintf(i18n.T("No matches for '%s'"), filter))
 		}
-		return EmptyStyle().Render("No processes found")
+		return EmptyStyle().Render(i18n.T("No processes found"))
 	}
 
 	var b strings.Builder
 
 	// Calculate column widths
-	columns := processListColumns()
+	columns := m.activeProcessListColumns()
 	widths := calculateColumnWidths(columns, m.contentWidth())
 
 	// Render header
@@ -682,6 +941,7 @@ func (m Model) renderProcessList() string {
 	cursorIdx := view.Cursor
 
 	// Render each process row
+	var w rowWriter
 	for i, app := range apps {
 		isSelected := i == cursorIdx
 
@@ -695,17 +955,23 @@ func (m Model) renderProcessList() string {
 		}
 
 		// Build row content with dynamic widths
-		row := fmt.Sprintf("%*d %-*s %*d %*d %*d %*s %*s",
-			widths[0], primaryPID,
-			widths[1], truncateString(app.Name, widths[1]),
-			widths[2], len(app.Connections),
-			widths[3], app.EstablishedCount,
-			widths[4], app.ListenCount,
-			widths[5], txStr,
-			widths[6], rxStr,
-		)
+		w.reset()
+		w.int(int64(primaryPID), widths[0])
+		nameStart := w.offset()
+		w.left(truncateString(m.withNote(m.withPin(app.Name, app.Name), app.Name), widths[1]), widths[1])
+		w.int(int64(app.ConnectionCount()), widths[2])
+		w.int(int64(app.EstablishedCount), widths[3])
+		w.int(int64(app.ListenCount), widths[4])
+		w.right(txStr, widths[5])
+		w.right(rxStr, widths[6])
+		row := w.String() + m.processListExtraCells(app.Name, app.PIDs, widths[len(processListColumns()):], false)
+		if !isSelected && (m.fdNearLimit(app.PIDs) || m.hasPortConflict(app.PIDs)) {
+			b.WriteString(rowStyles().warn.render("  "+row) + "\n")
+			continue
+		}
 
-		b.WriteString(renderRow(row, isSelected))
+		matches := m.nameCellMatches(app.Name, nameStart, widths[1])
+		b.WriteString(renderRowWithMatches(row, matches, isSelected))
 	}
 
 	return b.String()
@@ -717,14 +983,14 @@ func (m Model) renderProcessListHeader(widths []int) string {
 	if view == nil {
 		return ""
 	}
-	columns := processListColumns()
+	columns := m.activeProcessListColumns()
 	return renderTableHeader(columns, widths, view.SelectedColumn, view.SortColumn, view.SortAscending, true)
 }
 
 // renderConnectionsList renders connections for a specific process (Level 1).
 func (m Model) renderConnectionsList() string {
 	if m.snapshot == nil {
-		return LoadingStyle().Render("Loading...")
+		return LoadingStyle().Render(i18n.T("Loading..."))
 	}
 
 	view := m.CurrentView()
@@ -734,7 +1000,7 @@ func (m Model) renderConnectionsList() string {
 
 	selectedApp := m.findSelectedApp(view.ProcessName)
 	if selectedApp == nil {
-		return EmptyStyle().Render("Process not found")
+		return m.missingProcessMessage(view.ProcessName)
 	}
 
 	// Get filtered connections
@@ -744,9 +1010,9 @@ func (m Model) renderConnectionsList() string {
 	if len(conns) == 0 {
 		filter := m.currentFilter()
 		if filter != "" {
-			return EmptyStyle().Render(fmt.Sprintf("No matches for '%s'", filter))
+			return EmptyStyle().Render(fmt.Sprintf(i18n.T("No matches for '%s'"), filter))
 		}
-		return EmptyStyle().Render("No connections found")
+		return EmptyStyle().Render(i18n.T("No connections found"))
 	}
 
 	var b strings.Builder
@@ -769,7 +1035,16 @@ func (m Model) renderConnectionsList() string {
 		txStr, rxStr,
 		len(conns))
 	b.WriteString(StatusStyle().Render(statsLine))
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+	if line := m.rateStatsLine(selectedApp.Name); line != "" {
+		b.WriteString(StatusStyle().Render(truncateString(line, m.contentWidth())))
+		b.WriteString("\n")
+	}
+	if line := m.sessionTotalsLine(selectedApp.Name); line != "" {
+		b.WriteString(StatusStyle().Render(truncateString(line, m.contentWidth())))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
 
 	// === CONNECTIONS TABLE ===
 	// Calculate column widths
@@ -787,33 +1062,33 @@ func (m Model) renderConnectionsList() string {
 	cursorIdx := view.Cursor
 
 	// Render each connection (no PID column - redundant at this level)
+	var w rowWriter
 	for i, conn := range conns {
 		isSelected := i == cursorIdx
 
 		proto := string(conn.Protocol)
-		remoteAddr := formatRemoteAddr(conn.RemoteAddr, proto, m.dnsCache, m.serviceNames)
+		remoteAddr := m.remoteCell(conn)
 		localAddr := formatAddr(conn.LocalAddr, proto, m.serviceNames)
 		var row string
 		if m.dockerView {
 			containerCol := containerColumnValue(conn, m.dockerCache, widths[4])
-			row = fmt.Sprintf("%-*s %-*s %-*s %-*s %-*s",
-				widths[0], conn.Protocol,
-				widths[1], truncateAddr(localAddr, widths[1]),
-				widths[2], truncateAddr(remoteAddr, widths[2]),
-				widths[3], conn.State,
-				widths[4], containerCol,
-			)
+			w.reset()
+			w.left(string(conn.Protocol), widths[0])
+			w.left(truncateAddr(localAddr, widths[1]), widths[1])
+			w.left(truncateAddr(remoteAddr, widths[2]), widths[2])
+			w.left(m.stateCell(conn, widths[3]), widths[3])
+			w.left(containerCol, widths[4])
+			row = w.String() + m.connExtraCells(conn, view.ProcessName, widths[len(dockerConnectionsColumns()):])
 		} else {
-			row = fmt.Sprintf("%-*s %-*s %-*s %-*s",
-				widths[0], conn.Protocol,
-				widths[1], truncateAddr(localAddr, widths[1]),
-				widths[2], truncateAddr(remoteAddr, widths[2]),
-				widths[3], conn.State,
-			)
+			w.reset()
+			w.left(string(conn.Protocol), widths[0])
+			w.left(truncateAddr(localAddr, widths[1]), widths[1])
+			w.left(truncateAddr(remoteAddr, widths[2]), widths[2])
+			w.left(m.stateCell(conn, widths[3]), widths[3])
+			row = w.String()
 		}
 
-		change := m.GetChange(conn)
-		b.WriteString(renderRowWithHighlight(row, isSelected, change))
+		b.WriteString(renderConnRow(row, conn, isSelected, m.rowMark(conn)))
 	}
 
 	return b.String()
@@ -829,24 +1104,34 @@ func (m Model) renderConnectionsHeader(widths []int) string {
 	return renderTableHeader(columns, widths, view.SelectedColumn, view.SortColumn, view.SortAscending, true)
 }
 
-// activeConnectionsColumns returns the right column set for the current connections view.
+// activeConnectionsColumns returns the right column set for the current connections view,
+// plus protocol detail and TCP stats columns when enabled.
 func (m Model) activeConnectionsColumns() []columnDef {
+	cols := connectionsColumns()
 	if m.dockerView {
-		return dockerConnectionsColumns()
+		cols = dockerConnectionsColumns()
 	}
-	return connectionsColumns()
+	return m.withOptionalConnColumns(cols)
+}
+
+// activeAllConnectionsColumns returns the all-connections columns plus protocol detail
+// and TCP stats columns when enabled.
+func (m Model) activeAllConnectionsColumns() []columnDef {
+	return m.withOptionalConnColumns(allConnectionsColumns())
 }
 
 // connectionWithProcess holds a connection along with its process name for the all-connections view.
 type connectionWithProcess struct {
 	model.Connection
 	ProcessName string
+	Backend     string // docker-proxy client leg: where its hidden backend leg goes, e.g. "web:80"
+	ProxyLeg    bool   // docker-proxy backend leg shown under its client legs
 }
 
 // renderAllConnections renders a flat list of all connections from all processes.
 func (m Model) renderAllConnections() string {
 	if m.snapshot == nil {
-		return LoadingStyle().Render("Loading...")
+		return LoadingStyle().Render(i18n.T("Loading..."))
 	}
 
 	view := m.CurrentView()
@@ -854,53 +1139,50 @@ func (m Model) renderAllConnections() string {
 		return ""
 	}
 
-	// Get filtered connections
-	allConns := m.filteredAllConnections()
+	// Get filtered, sorted connections
+	allConns := m.visibleAllConnections()
 
 	// Handle empty results
 	if len(allConns) == 0 {
 		filter := m.currentFilter()
 		if filter != "" {
-			return EmptyStyle().Render(fmt.Sprintf("No matches for '%s'", filter))
+			return EmptyStyle().Render(fmt.Sprintf(i18n.T("No matches for '%s'"), filter))
 		}
-		return EmptyStyle().Render("No connections found")
+		return EmptyStyle().Render(i18n.T("No connections found"))
 	}
 
 	var b strings.Builder
 
 	// === CONNECTIONS TABLE ===
 	// Calculate column widths
-	columns := allConnectionsColumns()
+	columns := m.activeAllConnectionsColumns()
 	widths := calculateColumnWidths(columns, m.contentWidth())
 
 	// Header
 	b.WriteString(m.renderAllConnectionsHeader(widths))
 	b.WriteString("\n")
 
-	// Sort connections
-	allConns = m.sortAllConnections(allConns)
-
 	// Use view.Cursor directly for selection (view already defined above)
 	cursorIdx := view.Cursor
 
 	// Render each connection
+	var w rowWriter
 	for i, conn := range allConns {
 		isSelected := i == cursorIdx
 
 		proto := string(conn.Protocol)
-		remoteAddr := formatRemoteAddr(conn.RemoteAddr, proto, m.dnsCache, m.serviceNames)
+		remoteAddr := m.remoteCell(conn.Connection)
 		localAddr := formatAddr(conn.LocalAddr, proto, m.serviceNames)
-		row := fmt.Sprintf("%*d %-*s %-*s %-*s %-*s %-*s",
-			widths[0], conn.PID,
-			widths[1], truncateString(conn.ProcessName, widths[1]),
-			widths[2], conn.Protocol,
-			widths[3], truncateAddr(localAddr, widths[3]),
-			widths[4], truncateAddr(remoteAddr, widths[4]),
-			widths[5], conn.State,
-		)
+		w.reset()
+		w.int(int64(conn.PID), widths[0])
+		w.left(truncateString(m.processCell(conn), widths[1]), widths[1])
+		w.left(string(conn.Protocol), widths[2])
+		w.left(truncateAddr(localAddr, widths[3]), widths[3])
+		w.left(truncateAddr(remoteAddr, widths[4]), widths[4])
+		w.left(m.stateCell(conn.Connection, widths[5]), widths[5])
+		row := w.String() + m.connExtraCells(conn.Connection, conn.ProcessName, widths[len(allConnectionsColumns()):])
 
-		change := m.GetChange(conn.Connection)
-		b.WriteString(renderRowWithHighlight(row, isSelected, change))
+		b.WriteString(renderConnRow(row, conn.Connection, isSelected, m.rowMark(conn.Connection)))
 	}
 
 	return b.String()
@@ -912,14 +1194,14 @@ func (m Model) renderAllConnectionsHeader(widths []int) string {
 	if view == nil {
 		return ""
 	}
-	columns := allConnectionsColumns()
+	columns := m.activeAllConnectionsColumns()
 	return renderTableHeader(columns, widths, view.SelectedColumn, view.SortColumn, view.SortAscending, true)
 }
 
 // renderProcessListData renders only the data rows for process list (no header).
 func (m Model) renderProcessListData() string {
 	if m.snapshot == nil {
-		return LoadingStyle().Render("Loading...")
+		return LoadingStyle().Render(i18n.T("Loading..."))
 	}
 
 	view := m.CurrentView()
@@ -927,21 +1209,21 @@ func (m Model) renderProcessListData() string {
 		return ""
 	}
 
-	apps := m.filteredApps()
+	apps := m.visibleApps()
 	if len(apps) == 0 {
 		filter := m.currentFilter()
 		if filter != "" {
-			return EmptyStyle().Render(fmt.Sprintf("No matches for '%s'", filter))
+			return EmptyStyle().Render(fmt.Sprintf(i18n.T("No matches for '%s'"), filter))
 		}
-		return EmptyStyle().Render("No processes found")
+		return EmptyStyle().Render(i18n.T("No processes found"))
 	}
 
 	var b strings.Builder
-	columns := processListColumns()
+	columns := m.activeProcessListColumns()
 	widths := calculateColumnWidths(columns, m.contentWidth())
-	apps = m.sortProcessList(apps)
 	cursorIdx := view.Cursor
 
+	var w rowWriter
 	for i, app := range apps {
 		isSelected := i == cursorIdx
 		txStr, rxStr := m.getAggregatedNetIO(app.PIDs)
@@ -950,16 +1232,22 @@ func (m Model) renderProcessListData() string {
 			primaryPID = app.PIDs[0]
 		}
 
-		row := fmt.Sprintf("%*d %-*s %*d %*d %*d %*s %*s",
-			widths[0], primaryPID,
-			widths[1], truncateString(app.Name, widths[1]),
-			widths[2], len(app.Connections),
-			widths[3], app.EstablishedCount,
-			widths[4], app.ListenCount,
-			widths[5], txStr,
-			widths[6], rxStr,
-		)
-		b.WriteString(renderRow(row, isSelected))
+		w.reset()
+		w.int(int64(primaryPID), widths[0])
+		nameStart := w.offset()
+		w.left(truncateString(m.withNote(m.withPin(app.Name, app.Name), app.Name), widths[1]), widths[1])
+		w.int(int64(app.ConnectionCount()), widths[2])
+		w.int(int64(app.EstablishedCount), widths[3])
+		w.int(int64(app.ListenCount), widths[4])
+		w.right(txStr, widths[5])
+		w.right(rxStr, widths[6])
+		row := w.String() + m.processListExtraCells(app.Name, app.PIDs, widths[len(processListColumns()):], false)
+		if !isSelected && (m.fdNearLimit(app.PIDs) || m.hasPortConflict(app.PIDs)) {
+			b.WriteString(rowStyles().warn.render("  "+row) + "\n")
+			continue
+		}
+		matches := m.nameCellMatches(app.Name, nameStart, widths[1])
+		b.WriteString(renderRowWithMatches(row, matches, isSelected))
 	}
 
 	// Append virtual container rows
@@ -976,15 +1264,20 @@ func (m Model) renderProcessListData() string {
 			estab = vcApp.EstablishedCount
 			listen = vcApp.ListenCount
 		}
-		row := fmt.Sprintf("%-*s %-*s %*d %*d %*d %*s %*s",
-			widths[0], truncateString(vc.Info.ID, widths[0]),
-			widths[1], truncateString(containerDisplayName(vc), widths[1]),
-			widths[2], conns,
-			widths[3], estab,
-			widths[4], listen,
-			widths[5], "—",
-			widths[6], "—",
-		)
+		txStr, rxStr := containerIOCells(vc)
+		w.reset()
+		w.left(truncateString(vc.Info.ID, widths[0]), widths[0])
+		w.left(truncateString(withHealth(containerDisplayName(vc), vc.Info), widths[1]), widths[1])
+		w.int(int64(conns), widths[2])
+		w.int(int64(estab), widths[3])
+		w.int(int64(listen), widths[4])
+		w.right(txStr, widths[5])
+		w.right(rxStr, widths[6])
+		row := w.String() + m.processListExtraCells("", nil, widths[len(processListColumns()):], true)
+		if (vc.Info.Flapping() || m.containerHasConflict(vc.Info.ID)) && !isSelected {
+			b.WriteString(rowStyles().warn.render("  "+row) + "\n")
+			continue
+		}
 		b.WriteString(renderRow(row, isSelected))
 	}
 
@@ -994,7 +1287,7 @@ func (m Model) renderProcessListData() string {
 // renderConnectionsListData renders only the data rows for connections list (no header).
 func (m Model) renderConnectionsListData() string {
 	if m.snapshot == nil {
-		return LoadingStyle().Render("Loading...")
+		return LoadingStyle().Render(i18n.T("Loading..."))
 	}
 
 	view := m.CurrentView()
@@ -1004,50 +1297,59 @@ func (m Model) renderConnectionsListData() string {
 
 	selectedApp := m.findSelectedApp(view.ProcessName)
 	if selectedApp == nil {
-		return EmptyStyle().Render("Process not found")
+		return m.missingProcessMessage(view.ProcessName)
 	}
 
-	conns := m.filteredConnections(selectedApp.Connections)
+	conns := m.visibleConnections(selectedApp)
 	if len(conns) == 0 {
 		filter := m.currentFilter()
 		if filter != "" {
-			return EmptyStyle().Render(fmt.Sprintf("No matches for '%s'", filter))
+			return EmptyStyle().Render(fmt.Sprintf(i18n.T("No matches for '%s'"), filter))
 		}
-		return EmptyStyle().Render("No connections found")
+		return EmptyStyle().Render(i18n.T("No connections found"))
 	}
 
 	var b strings.Builder
 	columns := m.activeConnectionsColumns()
 	widths := calculateColumnWidths(columns, m.contentWidth())
-	conns = m.sortConnectionsForView(conns)
 	cursorIdx := view.Cursor
 
-	for i, conn := range conns {
+	start, end := m.visibleRowRange(len(conns))
+	writeBlankRows(&b, start)
+	m.rowCache.begin(m.rowLayout(LevelConnections))
+	var w rowWriter
+	for i := start; i < end; i++ {
+		conn := conns[i]
 		isSelected := i == cursorIdx
-		proto := string(conn.Protocol)
-		remoteAddr := formatRemoteAddr(conn.RemoteAddr, proto, m.dnsCache, m.serviceNames)
-		localAddr := formatAddr(conn.LocalAddr, proto, m.serviceNames)
-		var row string
-		if m.dockerView {
-			containerCol := containerColumnValue(conn, m.dockerCache, widths[4])
-			row = fmt.Sprintf("%-*s %-*s %-*s %-*s %-*s",
-				widths[0], conn.Protocol,
-				widths[1], truncateAddr(localAddr, widths[1]),
-				widths[2], truncateAddr(remoteAddr, widths[2]),
-				widths[3], conn.State,
-				widths[4], containerCol,
-			)
-		} else {
-			row = fmt.Sprintf("%-*s %-*s %-*s %-*s",
-				widths[0], conn.Protocol,
-				widths[1], truncateAddr(localAddr, widths[1]),
-				widths[2], truncateAddr(remoteAddr, widths[2]),
-				widths[3], conn.State,
-			)
-		}
-		change := m.GetChange(conn)
-		b.WriteString(renderRowWithHighlight(row, isSelected, change))
+		mark := m.rowMark(conn)
+		b.WriteString(m.rowCache.row(conn, "", rowStyleFor(isSelected, mark), func() string {
+			proto := string(conn.Protocol)
+			remoteAddr := m.remoteCell(conn)
+			localAddr := formatAddr(conn.LocalAddr, proto, m.serviceNames)
+			var row string
+			if m.dockerView {
+				containerCol := containerColumnValue(conn, m.dockerCache, widths[4])
+				w.reset()
+				w.left(string(conn.Protocol), widths[0])
+				w.left(truncateAddr(localAddr, widths[1]), widths[1])
+				w.left(truncateAddr(remoteAddr, widths[2]), widths[2])
+				w.left(m.stateCell(conn, widths[3]), widths[3])
+				w.left(containerCol, widths[4])
+				row = w.String() + m.connExtraCells(conn, view.ProcessName, widths[len(dockerConnectionsColumns()):])
+			} else {
+				w.reset()
+				w.left(string(conn.Protocol), widths[0])
+				w.left(truncateAddr(localAddr, widths[1]), widths[1])
+				w.left(truncateAddr(remoteAddr, widths[2]), widths[2])
+				w.left(m.stateCell(conn, widths[3]), widths[3])
+				row = w.String()
+				row += m.connExtraCells(conn, view.ProcessName, widths[len(connectionsColumns()):])
+			}
+			return renderConnRow(row, conn, isSelected, mark)
+		}))
 	}
+	m.rowCache.end()
+	writeBlankRows(&b, len(conns)-end)
 
 	return b.String()
 }
@@ -1055,7 +1357,7 @@ func (m Model) renderConnectionsListData() string {
 // renderAllConnectionsData renders only the data rows for all connections (no header).
 func (m Model) renderAllConnectionsData() string {
 	if m.snapshot == nil {
-		return LoadingStyle().Render("Loading...")
+		return LoadingStyle().Render(i18n.T("Loading..."))
 	}
 
 	view := m.CurrentView()
@@ -1063,37 +1365,46 @@ func (m Model) renderAllConnectionsData() string {
 		return ""
 	}
 
-	allConns := m.filteredAllConnections()
+	allConns := m.visibleAllConnections()
 	if len(allConns) == 0 {
 		filter := m.currentFilter()
 		if filter != "" {
-			return EmptyStyle().Render(fmt.Sprintf("No matches for '%s'", filter))
+			return EmptyStyle().Render(fmt.Sprintf(i18n.T("No matches for '%s'"), filter))
 		}
-		return EmptyStyle().Render("No connections found")
+		return EmptyStyle().Render(i18n.T("No connections found"))
 	}
 
 	var b strings.Builder
-	columns := allConnectionsColumns()
+	columns := m.activeAllConnectionsColumns()
 	widths := calculateColumnWidths(columns, m.contentWidth())
-	allConns = m.sortAllConnections(allConns)
 	cursorIdx := view.Cursor
 
-	for i, conn := range allConns {
+	start, end := m.visibleRowRange(len(allConns))
+	writeBlankRows(&b, start)
+	m.rowCache.begin(m.rowLayout(LevelAllConnections))
+	var w rowWriter
+	for i := start; i < end; i++ {
+		conn := allConns[i]
 		isSelected := i == cursorIdx
-		proto := string(conn.Protocol)
-		remoteAddr := formatRemoteAddr(conn.RemoteAddr, proto, m.dnsCache, m.serviceNames)
-		localAddr := formatAddr(conn.LocalAddr, proto, m.serviceNames)
-		row := fmt.Sprintf("%*d %-*s %-*s %-*s %-*s %-*s",
-			widths[0], conn.PID,
-			widths[1], truncateString(conn.ProcessName, widths[1]),
-			widths[2], conn.Protocol,
-			widths[3], truncateAddr(localAddr, widths[3]),
-			widths[4], truncateAddr(remoteAddr, widths[4]),
-			widths[5], conn.State,
-		)
-		change := m.GetChange(conn.Connection)
-		b.WriteString(renderRowWithHighlight(row, isSelected, change))
-	}
+		mark := m.rowMark(conn.Connection)
+		name := m.processCell(conn)
+		b.WriteString(m.rowCache.row(conn.Connection, name, rowStyleFor(isSelected, mark), func() string {
+			proto := string(conn.Protocol)
+			remoteAddr := m.remoteCell(conn.Connection)
+			localAddr := formatAddr(conn.LocalAddr, proto, m.serviceNames)
+			w.reset()
+			w.int(int64(conn.PID), widths[0])
+			w.left(truncateString(name, widths[1]), widths[1])
+			w.left(string(conn.Protocol), widths[2])
+			w.left(truncateAddr(localAddr, widths[3]), widths[3])
+			w.left(truncateAddr(remoteAddr, widths[4]), widths[4])
+			w.left(m.stateCell(conn.Connection, widths[5]), widths[5])
+			row := w.String() + m.connExtraCells(conn.Connection, conn.ProcessName, widths[len(allConnectionsColumns()):])
+			return renderConnRow(row, conn.Connection, isSelected, mark)
+		}))
+	}
+	m.rowCache.end()
+	writeBlankRows(&b, len(allConns)-end)
 
 	return b.String()
 }
@@ -1134,10 +1445,16 @@ func (m *Model) updateViewportContent() {
 	}
 
 	var content string
-	if m.snapshot == nil {
-		content = LoadingStyle().Render("Loading...")
+	if view.Level == LevelConntrack {
+		content = m.renderConntrackData()
+	} else if view.Level == LevelDockerPorts {
+		content = m.renderDockerPortsData()
+	} else if view.Level == LevelBinds {
+		content = m.renderBindsData()
+	} else if m.snapshot == nil {
+		content = LoadingStyle().Render(i18n.T("Loading..."))
 	} else if len(m.snapshot.Applications) == 0 {
-		content = EmptyStyle().Render("No network connections found")
+		content = EmptyStyle().Render(i18n.T("No network connections found"))
 	} else {
 		// Use data-only methods (headers are rendered separately outside viewport)
 		switch view.Level {
@@ -1151,7 +1468,10 @@ func (m *Model) updateViewportContent() {
 	}
 
 	m.viewportContent = content
-	m.viewport.SetContent(content)
+	if hash := contentHash(content); hash != m.viewportHash {
+		m.viewportHash = hash
+		m.viewport.SetContent(content)
+	}
 }
 
 // syncViewportScroll adjusts the viewport scroll position to keep the cursor visible.
@@ -1206,7 +1526,7 @@ func (m Model) overlayModalWithRenderer(background, content, title string, modal
 	contentLines := strings.Split(content, "\n")
 	modalHeight := len(contentLines) + 4
 
-	framedModal := frameRenderer(content, title, modalWidth, modalHeight)
+	framedModal := frameRenderer(content, i18n.T(title), modalWidth, modalHeight)
 	modalLines := strings.Split(framedModal, "\n")
 
 	leftPad := max((m.width-modalWidth-4)/2, 0)
@@ -1217,9 +1537,9 @@ func (m Model) overlayModalWithRenderer(background, content, title string, modal
 		bgLines = append(bgLines, "")
 	}
 
-	dimStyle := DimmedStyle()
+	dim := codesOf(DimmedStyle())
 	for i := range bgLines {
-		bgLines[i] = dimStyle.Render(stripAnsi(bgLines[i]))
+		bgLines[i] = dim.render(stripAnsi(bgLines[i]))
 	}
 
 	for i, modalLine := range modalLines {
@@ -1227,7 +1547,7 @@ func (m Model) overlayModalWithRenderer(background, content, title string, modal
 		if bgIdx >= 0 && bgIdx < len(bgLines) {
 			leftBg := ""
 			if leftPad > 0 {
-				leftBg = dimStyle.Render(strings.Repeat(" ", leftPad))
+				leftBg = dim.render(strings.Repeat(" ", leftPad))
 			}
 			bgLines[bgIdx] = leftBg + modalLine
 		}
@@ -1269,29 +1589,32 @@ func (m Model) renderKillModalContent() string {
 
 	// Title and target info
 	if m.killTarget.ContainerID != "" {
-		lines = append(lines, dangerStyle.Render("  Stop this container?"))
+		lines = append(lines, dangerStyle.Render("  "+i18n.T("Stop this container?")))
 		lines = append(lines, "")
-		lines = append(lines, descStyle.Render(fmt.Sprintf("  Container: %s", m.killTarget.ProcessName)))
+		lines = append(lines, descStyle.Render("  "+fmt.Sprintf(i18n.T("Container: %s"), m.killTarget.ProcessName)))
 		if m.killTarget.Exe != "" {
-			lines = append(lines, dimStyle.Render(fmt.Sprintf("  Image:     %s", m.killTarget.Exe)))
+			lines = append(lines, dimStyle.Render("  "+fmt.Sprintf(i18n.T("Image:     %s"), m.killTarget.Exe)))
 		}
 		lines = append(lines, descStyle.Render(fmt.Sprintf("  ID:        %s", m.killTarget.ContainerID)))
 	} else {
 		multiPID := len(m.killTarget.PIDs) > 1
 		if multiPID {
-			lines = append(lines, dangerStyle.Render(fmt.Sprintf("  Kill %d processes?", len(m.killTarget.PIDs))))
+			lines = append(lines, dangerStyle.Render("  "+fmt.Sprintf(i18n.T("Kill %d processes?"), len(m.killTarget.PIDs))))
 		} else {
-			lines = append(lines, dangerStyle.Render("  Kill this process?"))
+			lines = append(lines, dangerStyle.Render("  "+i18n.T("Kill this process?")))
 		}
 		lines = append(lines, "")
-		lines = append(lines, descStyle.Render(fmt.Sprintf("  Process: %s", m.killTarget.ProcessName)))
+		lines = append(lines, descStyle.Render("  "+fmt.Sprintf(i18n.T("Process: %s"), m.killTarget.ProcessName)))
 		if m.killTarget.Exe != "" {
-			lines = append(lines, dimStyle.Render(fmt.Sprintf("  Path:    %s", m.killTarget.Exe)))
+			lines = append(lines, dimStyle.Render("  "+fmt.Sprintf(i18n.T("Path:    %s"), m.killTarget.Exe)))
 		}
 		if multiPID {
-			lines = append(lines, descStyle.Render(fmt.Sprintf("  PIDs:    %s", formatPIDList(m.killTarget.PIDs))))
+			lines = append(lines, descStyle.Render("  "+fmt.Sprintf(i18n.T("PIDs:    %s"), formatPIDList(m.killTarget.PIDs))))
 		} else {
-			lines = append(lines, descStyle.Render(fmt.Sprintf("  PID:     %d", m.killTarget.PID)))
+			lines = append(lines, descStyle.Render("  "+fmt.Sprintf(i18n.T("PID:     %d"), m.killTarget.PID)))
+		}
+		if m.killTarget.Critical {
+			lines = append(lines, m.renderCriticalConfirm(m.killTarget)...)
 		}
 	}
 
@@ -1303,9 +1626,9 @@ func (m Model) renderKillModalContent() string {
 
 	// Footer keybindings
 	lines = append(lines, "")
-	footer := dangerStyle.Render("↵") + descStyle.Render(" Confirm  ") +
-		dangerStyle.Render("Esc") + descStyle.Render(" Cancel  ") +
-		dangerStyle.Render("↑↓") + descStyle.Render(" Signal")
+	footer := dangerStyle.Render("↵") + descStyle.Render(" "+i18n.T("Confirm")+"  ") +
+		dangerStyle.Render("Esc") + descStyle.Render(" "+i18n.T("Cancel")+"  ") +
+		dangerStyle.Render("↑↓") + descStyle.Render(" "+i18n.T("Signal"))
 	lines = append(lines, "  "+footer)
 
 	return strings.Join(lines, "\n")
@@ -1321,64 +1644,26 @@ func (m Model) renderSignalOption(signal, desc string, selected bool, dangerStyl
 
 // stripAnsi removes ANSI escape codes from a string.
 func stripAnsi(s string) string {
-	var result strings.Builder
-	inEscape := false
-	for _, r := range s {
-		if r == '\x1b' {
-			inEscape = true
-			continue
-		}
-		if inEscape {
-			if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
-				inEscape = false
-			}
-			continue
-		}
-		result.WriteRune(r)
-	}
-	return result.String()
+	return ansi.Strip(s)
 }
 
-// renderHelpModalContent returns the help modal content.
+// renderHelpModalContent returns the help modal content, listing every
+// registered command by section.
 func (m Model) renderHelpModalContent() string {
 	keyStyle := FooterKeyStyle()
 	descStyle := FooterDescStyle()
 
-	formatKey := func(k Keybinding) string {
-		return keyStyle.Render(k.Key) + descStyle.Render(" "+k.Desc)
-	}
-
-	lines := []string{
-		// Navigation
-		HeaderStyle().Render("Navigation"),
-		formatKey(KeyUp) + ", " + formatKey(KeyUpAlt),
-		formatKey(KeyDown) + ", " + formatKey(KeyDownAlt),
-		formatKey(KeyPageUp) + ", " + formatKey(KeyPageDown),
-		formatKey(KeyEnter) + descStyle.Render(" Select/drill-down"),
-		formatKey(KeyEsc) + ", " + formatKey(KeyBack) + descStyle.Render(" Back/cancel"),
-		"",
-		// Views
-		HeaderStyle().Render("Views"),
-		formatKey(KeyToggleView),
-		formatKey(KeySortMode),
-		keyStyle.Render("←→") + descStyle.Render(" Select column (sort mode)"),
-		"",
-		// Search
-		HeaderStyle().Render("Search"),
-		formatKey(KeySearch),
-		"",
-		// Actions
-		HeaderStyle().Render("Actions"),
-		formatKey(KeyKillTerm),
-		formatKey(KeyKillForce),
-		formatKey(KeyRefreshUp) + ", " + keyStyle.Render("=") + descStyle.Render(" Faster refresh"),
-		formatKey(KeyRefreshDown) + ", " + keyStyle.Render("_") + descStyle.Render(" Slower refresh"),
-		"",
-		// Other
-		HeaderStyle().Render("Other"),
-		formatKey(KeySettings),
-		formatKey(KeyHelp),
-		formatKey(KeyQuit) + ", " + keyStyle.Render("ctrl+c") + descStyle.Render(" Quit"),
+	var lines []string
+	for _, section := range helpSections {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, HeaderStyle().Render(i18n.T(section)))
+		for _, c := range commandRegistry {
+			if c.section == section {
+				lines = append(lines, keyStyle.Render(c.keyLabel())+descStyle.Render(" "+i18n.T(c.desc)))
+			}
+		}
 	}
 
 	return strings.Join(lines, "\n")
@@ -1388,43 +1673,34 @@ func (m Model) renderHelpModalContent() string {
 func (m Model) renderSettingsModalContent() string {
 	var lines []string
 
-	settings := []struct {
-		name    string
-		enabled bool
-		desc    string
-	}{
-		{"DNS Resolution", m.dnsEnabled, "Reverse lookup IPs to hostnames"},
-		{"Service Names", m.serviceNames, "Show http/https instead of 80/443"},
-		{"Highlight Changes", m.highlightChanges, "Flash new/removed connections"},
-		{"Animations", m.animations, "Enable UI animations (pulse, spinners)"},
-		{"Docker Containers", m.dockerContainers, "Show containers as process rows"},
-	}
-
-	for i, s := range settings {
+	for i, s := range settingItems() {
 		cursor := "  "
 		if i == m.settingsCursor {
 			cursor = "▸ "
 		}
 		toggle := "[ ]"
-		if s.enabled {
+		switch {
+		case s.value != nil:
+			toggle = "[" + s.value(&m) + "]"
+		case s.get(&m):
 			toggle = "[■]"
 		}
-		row := fmt.Sprintf("%s%s %s", cursor, toggle, s.name)
+		row := fmt.Sprintf("%s%s %s", cursor, toggle, i18n.T(s.name))
 		if i == m.settingsCursor {
 			row = SelectedConnStyle().Render(row)
 		}
 		lines = append(lines, row)
 		// Description line (dimmed, indented)
-		lines = append(lines, DimmedStyle().Render("      "+s.desc))
+		lines = append(lines, DimmedStyle().Render("      "+i18n.T(s.desc)))
 	}
 
 	// Footer keybindings
 	lines = append(lines, "")
 	keyStyle := FooterKeyStyle()
 	descStyle := FooterDescStyle()
-	footer := keyStyle.Render("↑↓") + descStyle.Render(" Navigate  ") +
-		keyStyle.Render("Space") + descStyle.Render(" Toggle  ") +
-		keyStyle.Render("Esc") + descStyle.Render(" Close")
+	footer := keyStyle.Render("↑↓") + descStyle.Render(" "+i18n.T("Navigate")+"  ") +
+		keyStyle.Render("Space") + descStyle.Render(" "+i18n.T("Toggle")+"  ") +
+		keyStyle.Render("Esc") + descStyle.Render(" "+i18n.T("Close"))
 	lines = append(lines, footer)
 
 	return strings.Join(lines, "\n")
@@ -32,7 +32,13 @@ func (m Model) sortAllConnections(conns []connectionWithProcess) []connectionWit
 		case SortRemote:
 			cmp = compareString(sorted[i].RemoteAddr, sorted[j].RemoteAddr)
 		case SortState:
-			cmp = compareString(string(sorted[i].State), string(sorted[j].State))
+			cmp = compareString(m.connState(sorted[i].Connection), m.connState(sorted[j].Connection))
+		case SortRTT, SortRetrans:
+			cmp = compareTCPStats(view.SortColumn, sorted[i].Connection, sorted[j].Connection)
+		case SortProtoDetail:
+			cmp = compareString(detectProtocol(sorted[i].Connection, sorted[i].ProcessName), detectProtocol(sorted[j].Connection, sorted[j].ProcessName))
+		case SortInterface:
+			cmp = compareString(m.connInterface(sorted[i].Connection), m.connInterface(sorted[j].Connection))
 		default:
 			cmp = compareInt32(sorted[i].PID, sorted[j].PID)
 		}
@@ -68,6 +74,11 @@ func (m Model) sortProcessList(apps []model.Application) []model.Application {
 	sorted := make([]model.Application, len(apps))
 	copy(sorted, apps)
 
+	var scores map[string]float64
+	if view.SortColumn == SortAuto {
+		scores = m.interestScores(sorted)
+	}
+
 	sort.Slice(sorted, func(i, j int) bool {
 		var cmp int // -1: i<j, 0: equal, 1: i>j
 		switch view.SortColumn {
@@ -84,7 +95,7 @@ func (m Model) sortProcessList(apps []model.Application) []model.Application {
 		case SortProcess:
 			cmp = compareString(sorted[i].Name, sorted[j].Name)
 		case SortConns:
-			cmp = compareInt(len(sorted[i].Connections), len(sorted[j].Connections))
+			cmp = compareInt(sorted[i].ConnectionCount(), sorted[j].ConnectionCount())
 		case SortEstablished:
 			cmp = compareInt(sorted[i].EstablishedCount, sorted[j].EstablishedCount)
 		case SortListen:
@@ -93,6 +104,21 @@ func (m Model) sortProcessList(apps []model.Application) []model.Application {
 			cmp = compareUint64(m.getAggregatedBytes(sorted[i].PIDs, true), m.getAggregatedBytes(sorted[j].PIDs, true))
 		case SortRX:
 			cmp = compareUint64(m.getAggregatedBytes(sorted[i].PIDs, false), m.getAggregatedBytes(sorted[j].PIDs, false))
+		case SortSecurity:
+			cmp = compareString(m.securityLabel(sorted[i].PIDs), m.securityLabel(sorted[j].PIDs))
+		case SortNewRate:
+			cmp = compareFloat(m.churnRate(sorted[i].Name).Opened, m.churnRate(sorted[j].Name).Opened)
+		case SortClosedRate:
+			cmp = compareFloat(m.churnRate(sorted[i].Name).Closed, m.churnRate(sorted[j].Name).Closed)
+		case SortUptime:
+			cmp = compareInt64(int64(m.uptimeSortKey(sorted[i].PIDs)), int64(m.uptimeSortKey(sorted[j].PIDs)))
+		case SortFDs:
+			cmp = compareFloat(m.fdSortKey(sorted[i].PIDs), m.fdSortKey(sorted[j].PIDs))
+		case SortCgroup:
+			cmp = compareString(m.cgroupLabel(sorted[i].PIDs), m.cgroupLabel(sorted[j].PIDs))
+		case SortAuto:
+			// Ascending puts the highest score first, so ties stay alphabetical
+			cmp = compareFloat(scores[sorted[j].Name], scores[sorted[i].Name])
 		default:
 			cmp = compareString(sorted[i].Name, sorted[j].Name)
 		}
@@ -108,7 +134,7 @@ func (m Model) sortProcessList(apps []model.Application) []model.Application {
 		return cmp > 0
 	})
 
-	return sorted
+	return m.pinFirst(sorted)
 }
 
 // Compare helpers return -1, 0, or 1
@@ -142,6 +168,26 @@ func compareUint64(a, b uint64) int {
 	return 0
 }
 
+func compareInt64(a, b int64) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
+
+func compareFloat(a, b float64) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
+
 func compareString(a, b string) int {
 	if a < b {
 		return -1
@@ -204,9 +250,15 @@ func (m Model) sortConnectionsForView(conns []model.Connection) []model.Connecti
 		case SortRemote:
 			cmp = compareString(sorted[i].RemoteAddr, sorted[j].RemoteAddr)
 		case SortState:
-			cmp = compareString(string(sorted[i].State), string(sorted[j].State))
+			cmp = compareString(m.connState(sorted[i]), m.connState(sorted[j]))
 		case SortContainer:
 			cmp = compareString(m.containerSortKey(sorted[i]), m.containerSortKey(sorted[j]))
+		case SortRTT, SortRetrans:
+			cmp = compareTCPStats(view.SortColumn, sorted[i], sorted[j])
+		case SortProtoDetail:
+			cmp = compareString(detectProtocol(sorted[i], view.ProcessName), detectProtocol(sorted[j], view.ProcessName))
+		case SortInterface:
+			cmp = compareString(m.connInterface(sorted[i]), m.connInterface(sorted[j]))
 		default:
 			cmp = compareString(sorted[i].LocalAddr, sorted[j].LocalAddr)
 		}
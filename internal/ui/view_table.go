@@ -1,9 +1,11 @@
 package ui
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/kostyay/netmon/internal/docker"
+	"github.com/kostyay/netmon/internal/i18n"
 	"github.com/kostyay/netmon/internal/model"
 )
 
@@ -16,36 +18,80 @@ type columnDef struct {
 	rightAlign bool       // true for right-aligned columns (numbers)
 }
 
+// stateLabel returns the State cell of a connection: "MCAST" or "BCAST" for
+// multicast and broadcast UDP sockets, otherwise connState (ACTIVE or IDLE
+// for other UDP sockets).
+func (m Model) stateLabel(conn model.Connection) string {
+	switch conn.Cast() {
+	case model.CastMulticast:
+		return "MCAST"
+	case model.CastBroadcast:
+		return "BCAST"
+	}
+	return m.connState(conn)
+}
+
+// stateCell returns the State cell shown in connection tables: stateLabel plus,
+// for listening TCP sockets on Linux, the accept queue as "queued/backlog".
+func (m Model) stateCell(conn model.Connection, width int) string {
+	label := m.stateLabel(conn)
+	if q := conn.Accept; q != nil {
+		label = fmt.Sprintf("%s %d/%d", label, q.Queued, q.Backlog)
+	}
+	return truncateString(label, width)
+}
+
+// renderConnRow renders a connection row like renderRowWithHighlight, but in
+// the warning color when a listener's accept queue is near capacity, the
+// connection is outside the allowlist or goes to an unexpected country, or the
+// socket is in a Docker port conflict.
+func renderConnRow(content string, conn model.Connection, isSelected bool, mark rowMark) string {
+	if !isSelected && !mark.changed && (conn.Accept.Saturated() || mark.outside || mark.conflict || mark.region) {
+		return rowStyles().warn.render("  "+content) + "\n"
+	}
+	return renderRowWithHighlight(content, isSelected, mark)
+}
+
 // renderRow renders a table row with selection styling.
 func renderRow(content string, isSelected bool) string {
-	row := "  " + content
+	styles := rowStyles()
 	if isSelected {
-		return SelectedConnStyle().Render(row) + "\n"
+		return styles.selected.render("  "+content) + "\n"
 	}
-	return ConnStyle().Render(row) + "\n"
+	return styles.conn.render("  "+content) + "\n"
 }
 
 // renderRowWithHighlight renders a table row with selection and change highlight styling.
-// changeType: nil=no change, ChangeAdded=green, ChangeRemoved=red
-func renderRowWithHighlight(content string, isSelected bool, change *Change) string {
+// Flash colors the text (added green, removed red, modified amber), fade shades
+// the background, and gutter puts a +/-/~ marker in the row's left margin.
+func renderRowWithHighlight(content string, isSelected bool, mark rowMark) string {
 	row := "  " + content
+	styles := rowStyles()
 
 	// Selection takes priority for foreground
 	if isSelected {
-		return SelectedConnStyle().Render(row) + "\n"
+		return styles.selected.render(row) + "\n"
 	}
 
-	// Apply highlight based on change type
-	if change != nil {
-		switch change.Type {
-		case ChangeAdded:
-			return AddedConnStyle().Render(row) + "\n"
-		case ChangeRemoved:
-			return RemovedConnStyle().Render(row) + "\n"
+	if mark.changed {
+		switch mark.style {
+		case changeFade:
+			return fadeStyle(mark.kind, mark.step).Render(row) + "\n"
+		case changeGutter:
+			return renderGutterMarker(mark.kind) + styles.conn.render(" "+content) + "\n"
+		default:
+			switch mark.kind {
+			case ChangeAdded:
+				return styles.added.render(row) + "\n"
+			case ChangeRemoved:
+				return styles.removed.render(row) + "\n"
+			case ChangeModified:
+				return styles.warn.render(row) + "\n"
+			}
 		}
 	}
 
-	return ConnStyle().Render(row) + "\n"
+	return styles.conn.render(row) + "\n"
 }
 
 // renderTableHeader renders a table header with optional sort indicators.
@@ -68,7 +114,7 @@ func renderTableHeader(columns []columnDef, widths []int, selectedCol, sortCol S
 		isSelected := selectedCol == col.id
 		isSorted := showSort && sortCol == col.id
 
-		header := col.label
+		header := truncateString(i18n.T(col.label), widths[i])
 
 		var sortIndicator string
 		if isSorted {
@@ -79,7 +125,7 @@ func renderTableHeader(columns []columnDef, widths []int, selectedCol, sortCol S
 			}
 		}
 
-		padWidth := widths[i] - len(header)
+		padWidth := widths[i] - textWidth(header)
 		if isSorted {
 			padWidth -= 1
 		}
@@ -153,6 +199,77 @@ func processListColumns() []columnDef {
 	}
 }
 
+// activeProcessListColumns returns the process list columns plus enabled optional
+// columns (churn rates, uptime, open files, cgroup, then security context), in display order.
+func (m Model) activeProcessListColumns() []columnDef {
+	cols := processListColumns()
+	if m.churnColumns {
+		cols = append(cols, churnColumns...)
+	}
+	if m.showUptime {
+		cols = append(cols, uptimeColumn)
+	}
+	if m.showFDs {
+		cols = append(cols, fdColumn)
+	}
+	if m.showCgroups {
+		cols = append(cols, cgroupColumn)
+	}
+	if m.securityContext {
+		cols = append(cols, securityColumn)
+	}
+	return cols
+}
+
+// processListExtraCells renders the optional column cells for a process row.
+// widths are the widths of the optional columns only. Containers have no
+// per-process data and show "—".
+func (m Model) processListExtraCells(name string, pids []int32, widths []int, isContainer bool) string {
+	var b strings.Builder
+	i := 0
+	if m.churnColumns {
+		newRate, closedRate := "—", "—"
+		if !isContainer {
+			r := m.churnRate(name)
+			newRate, closedRate = formatRate(r.Opened), formatRate(r.Closed)
+		}
+		b.WriteString(" " + padLeft(newRate, widths[i]) + " " + padLeft(closedRate, widths[i+1]))
+		i += 2
+	}
+	if m.showUptime {
+		uptime := "—"
+		if !isContainer {
+			uptime = m.uptimeLabel(pids)
+		}
+		b.WriteString(" " + padLeft(uptime, widths[i]))
+		i++
+	}
+	if m.showFDs {
+		fds := "—"
+		if !isContainer {
+			fds = m.fdLabel(pids)
+		}
+		b.WriteString(" " + padLeft(fds, widths[i]))
+		i++
+	}
+	if m.showCgroups {
+		unit := "—"
+		if !isContainer {
+			unit = m.cgroupLabel(pids)
+		}
+		b.WriteString(" " + padRight(truncateString(unit, widths[i]), widths[i]))
+		i++
+	}
+	if m.securityContext {
+		label := "—"
+		if !isContainer {
+			label = m.securityLabel(pids)
+		}
+		b.WriteString(" " + truncateString(label, widths[i]))
+	}
+	return b.String()
+}
+
 // connectionsColumns returns the column definitions for the connections list.
 func connectionsColumns() []columnDef {
 	return []columnDef{
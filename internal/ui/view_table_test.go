@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kostyay/netmon/internal/model"
+)
+
+func TestStateCell_AcceptQueue(t *testing.T) {
+	var m Model
+	listen := model.Connection{Protocol: model.ProtocolTCP, State: model.StateListen}
+	if got := m.stateCell(listen, 20); got != "LISTEN" {
+		t.Errorf("without queue = %q, want LISTEN", got)
+	}
+	listen.Accept = &model.AcceptQueue{Queued: 3, Backlog: 128}
+	if got := m.stateCell(listen, 20); got != "LISTEN 3/128" {
+		t.Errorf("with queue = %q, want LISTEN 3/128", got)
+	}
+	if got := m.stateCell(listen, 11); len([]rune(got)) > 11 {
+		t.Errorf("cell %q exceeds width 11", got)
+	}
+}
+
+func TestRenderConnRow_SaturatedListener(t *testing.T) {
+	conn := model.Connection{Protocol: model.ProtocolTCP, State: model.StateListen, Accept: &model.AcceptQueue{Queued: 127, Backlog: 128}}
+
+	if got, want := renderConnRow("row", conn, false, rowMark{}), WarnStyle().Render("  row")+"\n"; got != want {
+		t.Errorf("saturated row = %q, want warn style %q", got, want)
+	}
+	if got, want := renderConnRow("row", conn, true, rowMark{}), renderRowWithHighlight("row", true, rowMark{}); got != want {
+		t.Error("selection should take priority over the saturation highlight")
+	}
+	conn.Accept.Queued = 1
+	if got, want := renderConnRow("row", conn, false, rowMark{}), renderRowWithHighlight("row", false, rowMark{}); got != want {
+		t.Error("a listener with room should render plainly")
+	}
+}
+
+func TestConnectionsList_ShowsAcceptQueue(t *testing.T) {
+	m := chipsTestModel()
+	m.width = 160
+	m.snapshot.Applications[0].Connections = append(m.snapshot.Applications[0].Connections, model.Connection{
+		Protocol: model.ProtocolTCP, LocalAddr: "0.0.0.0:8080", RemoteAddr: "*", State: model.StateListen, PID: 10,
+		Accept: &model.AcceptQueue{Queued: 5, Backlog: 511},
+	})
+	m.PushView(ViewState{Level: LevelConnections, ProcessName: "chrome", SortColumn: SortRemote, SortAscending: true})
+
+	if out := m.renderConnectionsListData(); !strings.Contains(out, "LISTEN 5/511") {
+		t.Errorf("connections view should show the accept queue, got:\n%s", out)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	"github.com/kostyay/netmon/internal/docker"
 	"github.com/kostyay/netmon/internal/model"
+	"github.com/kostyay/netmon/internal/services"
 )
 
 // initViewport initializes the viewport for testing (simulates WindowSizeMsg).
@@ -314,7 +315,7 @@ func TestRenderConnectionsList_FilteredByLocalAddr(t *testing.T) {
 			Cursor:      0,
 			SortColumn:  SortLocal,
 		}},
-		activeFilter: "192.168",
+		filterChips: []string{"192.168"},
 	}
 
 	result := m.renderConnectionsList()
@@ -353,7 +354,7 @@ func TestRenderConnectionsList_FilteredByRemoteAddr(t *testing.T) {
 			Cursor:      0,
 			SortColumn:  SortLocal,
 		}},
-		activeFilter: "8.8.8.8",
+		filterChips: []string{"8.8.8.8"},
 	}
 
 	result := m.renderConnectionsList()
@@ -387,7 +388,7 @@ func TestRenderConnectionsList_FilterNoMatches(t *testing.T) {
 			Cursor:      0,
 			SortColumn:  SortLocal,
 		}},
-		activeFilter: "nomatchxyz",
+		filterChips: []string{"nomatchxyz"},
 	}
 
 	result := m.renderConnectionsList()
@@ -861,8 +862,7 @@ func TestRenderKillModalContent(t *testing.T) {
 
 func TestRenderFooter_KillResult(t *testing.T) {
 	m := Model{
-		killResult:   "Killed PID 12345 (TestApp)",
-		killResultAt: time.Now(), // Recent
+		toasts: []toast{{Message: "Killed PID 12345 (TestApp)", Start: time.Now(), Duration: 2 * time.Second}},
 		stack: []ViewState{{
 			Level: LevelProcessList,
 		}},
@@ -878,8 +878,7 @@ func TestRenderFooter_KillResult(t *testing.T) {
 
 func TestRenderFooter_KillResultExpired(t *testing.T) {
 	m := Model{
-		killResult:      "Killed PID 12345 (TestApp)",
-		killResultAt:    time.Now().Add(-3 * time.Second), // More than 2s ago
+		toasts:          []toast{{Message: "Killed PID 12345 (TestApp)", Start: time.Now().Add(-3 * time.Second), Duration: 2 * time.Second}}, // Expired
 		refreshInterval: 2 * time.Second,
 		stack: []ViewState{{
 			Level: LevelProcessList,
@@ -1147,8 +1146,8 @@ func TestFilteredAllConnections_ByProcessName(t *testing.T) {
 				},
 			},
 		},
-		activeFilter: "Chrome",
-		stack:        []ViewState{{Level: LevelAllConnections}},
+		filterChips: []string{"Chrome"},
+		stack:       []ViewState{{Level: LevelAllConnections}},
 	}
 
 	conns := m.filteredAllConnections()
@@ -1181,8 +1180,8 @@ func TestFilteredAllConnections_ByPID(t *testing.T) {
 				},
 			},
 		},
-		activeFilter: "12345",
-		stack:        []ViewState{{Level: LevelAllConnections}},
+		filterChips: []string{"12345"},
+		stack:       []ViewState{{Level: LevelAllConnections}},
 	}
 
 	conns := m.filteredAllConnections()
@@ -1209,8 +1208,8 @@ func TestFilteredAllConnections_ByPort(t *testing.T) {
 				},
 			},
 		},
-		activeFilter: "8080",
-		stack:        []ViewState{{Level: LevelAllConnections}},
+		filterChips: []string{"8080"},
+		stack:       []ViewState{{Level: LevelAllConnections}},
 	}
 
 	conns := m.filteredAllConnections()
@@ -1244,8 +1243,7 @@ func TestFilteredAllConnections_NoFilter(t *testing.T) {
 				},
 			},
 		},
-		activeFilter: "",
-		stack:        []ViewState{{Level: LevelAllConnections}},
+		stack: []ViewState{{Level: LevelAllConnections}},
 	}
 
 	conns := m.filteredAllConnections()
@@ -1257,9 +1255,9 @@ func TestFilteredAllConnections_NoFilter(t *testing.T) {
 
 func TestFilteredAllConnections_NilSnapshot(t *testing.T) {
 	m := Model{
-		snapshot:     nil,
-		activeFilter: "test",
-		stack:        []ViewState{{Level: LevelAllConnections}},
+		snapshot:    nil,
+		filterChips: []string{"test"},
+		stack:       []ViewState{{Level: LevelAllConnections}},
 	}
 
 	conns := m.filteredAllConnections()
@@ -1283,8 +1281,8 @@ func TestFilteredAllConnections_ByState(t *testing.T) {
 				},
 			},
 		},
-		activeFilter: "LISTEN",
-		stack:        []ViewState{{Level: LevelAllConnections}},
+		filterChips: []string{"LISTEN"},
+		stack:       []ViewState{{Level: LevelAllConnections}},
 	}
 
 	conns := m.filteredAllConnections()
@@ -1311,8 +1309,8 @@ func TestFilteredAllConnections_ByRemoteAddr(t *testing.T) {
 				},
 			},
 		},
-		activeFilter: "8.8.8",
-		stack:        []ViewState{{Level: LevelAllConnections}},
+		filterChips: []string{"8.8.8"},
+		stack:       []ViewState{{Level: LevelAllConnections}},
 	}
 
 	conns := m.filteredAllConnections()
@@ -1460,8 +1458,7 @@ func TestRenderKillModalContent_MultiPID(t *testing.T) {
 func TestRenderFooter_PriorityResultOverSearch(t *testing.T) {
 	m := Model{
 		killMode:        false,
-		killResult:      "Killed successfully",
-		killResultAt:    time.Now(),
+		toasts:          []toast{{Message: "Killed successfully", Start: time.Now(), Duration: 2 * time.Second}},
 		searchMode:      true,
 		searchQuery:     "chrome",
 		refreshInterval: 2 * time.Second,
@@ -1482,31 +1479,26 @@ func TestRenderFooter_PriorityResultOverSearch(t *testing.T) {
 func TestRenderFooter_PrioritySearchOverFilter(t *testing.T) {
 	m := Model{
 		killMode:        false,
-		killResult:      "",
 		searchMode:      true,
 		searchQuery:     "firefox",
-		activeFilter:    "chrome",
+		filterChips:     []string{"chrome"},
 		refreshInterval: 2 * time.Second,
 		stack:           []ViewState{{Level: LevelProcessList}},
 	}
 
 	result := m.renderFooter()
 
-	// Search mode should take precedence over filter
-	if !strings.Contains(result, "/firefox") {
-		t.Error("Search input should show in search mode")
-	}
-	if strings.Contains(result, "[filter: chrome]") {
-		t.Error("Active filter should not appear when in search mode")
+	// Search input follows the confirmed chips it will be added to
+	if !strings.Contains(result, "[chrome] /firefox") {
+		t.Error("Search input should show after the filter chips in search mode")
 	}
 }
 
 func TestRenderFooter_PriorityFilterOverBreadcrumbs(t *testing.T) {
 	m := Model{
 		killMode:        false,
-		killResult:      "",
 		searchMode:      false,
-		activeFilter:    "ssh",
+		filterChips:     []string{"ssh"},
 		refreshInterval: 2 * time.Second,
 		stack:           []ViewState{{Level: LevelProcessList}},
 	}
@@ -1525,9 +1517,7 @@ func TestRenderFooter_PriorityFilterOverBreadcrumbs(t *testing.T) {
 func TestRenderFooter_BreadcrumbsOnly(t *testing.T) {
 	m := Model{
 		killMode:        false,
-		killResult:      "",
 		searchMode:      false,
-		activeFilter:    "",
 		refreshInterval: 2 * time.Second,
 		stack:           []ViewState{{Level: LevelProcessList}},
 	}
@@ -1599,6 +1589,18 @@ func TestFormatAddr_WithServiceNames_UnknownPort(t *testing.T) {
 	}
 }
 
+func TestFormatAddr_WithPortLabels(t *testing.T) {
+	if err := services.SetCustom(map[string]string{"15432": "pgbouncer-staging"}); err != nil {
+		t.Fatalf("SetCustom: %v", err)
+	}
+	t.Cleanup(func() { _ = services.SetCustom(nil) })
+
+	result := formatAddr("10.0.0.9:15432", "tcp", true)
+	if result != "10.0.0.9:pgbouncer-staging" {
+		t.Errorf("formatAddr with port label = %q, want '10.0.0.9:pgbouncer-staging'", result)
+	}
+}
+
 func TestFormatAddr_WithDNSCache(t *testing.T) {
 	cache := map[string]string{
 		"8.8.8.8": "dns.google",
@@ -1850,3 +1852,29 @@ func createDockerViewTestModel() Model {
 		}},
 	}
 }
+
+func TestStateLabel_MarksGroupSockets(t *testing.T) {
+	mdns := model.Connection{Protocol: model.ProtocolUDP, LocalAddr: "0.0.0.0:5353", RemoteAddr: "*", State: model.StateNone}
+	dhcp := model.Connection{Protocol: model.ProtocolUDP, LocalAddr: "0.0.0.0:68", RemoteAddr: "*", State: model.StateNone}
+	tcp := model.Connection{Protocol: model.ProtocolTCP, LocalAddr: "0.0.0.0:22", RemoteAddr: "*", State: model.StateListen}
+
+	m := createTestModel()
+	if got := m.stateLabel(mdns); got != "MCAST" {
+		t.Errorf("mDNS state = %q, want MCAST", got)
+	}
+	if got := m.stateLabel(dhcp); got != "BCAST" {
+		t.Errorf("DHCP state = %q, want BCAST", got)
+	}
+	if got := m.stateLabel(tcp); got != "LISTEN" {
+		t.Errorf("TCP state = %q, want LISTEN", got)
+	}
+
+	m.snapshot = &model.NetworkSnapshot{Applications: []model.Application{
+		{Name: "avahi", PIDs: []int32{1}, Connections: []model.Connection{mdns}},
+		{Name: "sshd", PIDs: []int32{2}, Connections: []model.Connection{tcp}},
+	}}
+	m.filterChips = []string{"mcast"}
+	if got := m.filteredAllConnections(); len(got) != 1 || got[0].ProcessName != "avahi" {
+		t.Errorf("filter mcast = %+v, want the mDNS socket", got)
+	}
+}
@@ -0,0 +1,163 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kostyay/netmon/internal/i18n"
+)
+
+// Workspace tabs keep independent views over the same snapshot, e.g. LISTEN
+// ports in one tab and a drill-down into chrome in another. The active tab's
+// state lives in the usual Model fields (stack, filterChips, …); tabs holds
+// every tab's saved state and stays empty while there is only one.
+
+// maxTabs is the number of tabs, matching the alt+1…alt+9 jump keys.
+const maxTabs = 9
+
+// workspace is the per-tab state: navigation (with each level's sort and
+// cursor) and filters.
+type workspace struct {
+	stack       []ViewState
+	filterChips []string
+	cliFilter   string
+	dockerView  bool
+}
+
+// saveWorkspace captures the active tab's state.
+func (m Model) saveWorkspace() workspace {
+	return workspace{
+		stack:       slices.Clone(m.stack),
+		filterChips: slices.Clone(m.filterChips),
+		cliFilter:   m.cliFilter,
+		dockerView:  m.dockerView,
+	}
+}
+
+// loadWorkspace makes w the active tab's state.
+func (m *Model) loadWorkspace(w workspace) {
+	m.stack = slices.Clone(w.stack)
+	m.filterChips = slices.Clone(w.filterChips)
+	m.cliFilter = w.cliFilter
+	m.dockerView = w.dockerView
+	m.chipMode = false
+	m.chipCursor = 0
+}
+
+// newTab opens a tab on the process list without filters and switches to it.
+func (m *Model) newTab() tea.Cmd {
+	if len(m.tabs) >= maxTabs {
+		return m.notify(toastWarn, fmt.Sprintf(i18n.T("At most %d tabs"), maxTabs))
+	}
+	if len(m.tabs) == 0 {
+		m.tabs = []workspace{m.saveWorkspace()}
+	}
+	m.tabs[m.activeTab] = m.saveWorkspace()
+	fresh := workspace{stack: []ViewState{m.newViewState(LevelProcessList, "")}}
+	m.tabs = append(m.tabs, fresh)
+	m.activeTab = len(m.tabs) - 1
+	m.loadWorkspace(fresh)
+	return m.notify(toastInfo, fmt.Sprintf(i18n.T("Opened tab %d"), m.activeTab+1))
+}
+
+// switchTab activates tab i (0-based), refreshing data its view needs.
+func (m *Model) switchTab(i int) tea.Cmd {
+	if i < 0 || i >= len(m.tabs) || i == m.activeTab {
+		return nil
+	}
+	m.tabs[m.activeTab] = m.saveWorkspace()
+	m.activeTab = i
+	m.loadWorkspace(m.tabs[i])
+	switch {
+	case m.CurrentView().Level == LevelConntrack:
+		return m.fetchConntrack()
+	case m.dockerView, m.CurrentView().Level == LevelDockerPorts:
+		return m.fetchDockerContainers()
+	}
+	return nil
+}
+
+// cycleTab activates the next (step 1) or previous (step -1) tab, wrapping.
+func (m *Model) cycleTab(step int) tea.Cmd {
+	if len(m.tabs) < 2 {
+		return nil
+	}
+	return m.switchTab((m.activeTab + step + len(m.tabs)) % len(m.tabs))
+}
+
+// closeTab closes the active tab and activates its left neighbor.
+func (m *Model) closeTab() tea.Cmd {
+	if len(m.tabs) < 2 {
+		return m.notify(toastInfo, i18n.T("Only one tab open"))
+	}
+	closed := m.activeTab
+	m.tabs = slices.Delete(m.tabs, closed, closed+1)
+	m.activeTab = max(closed-1, 0)
+	m.loadWorkspace(m.tabs[m.activeTab])
+	if len(m.tabs) == 1 {
+		m.tabs = nil
+		m.activeTab = 0
+	}
+	return m.notify(toastInfo, fmt.Sprintf(i18n.T("Closed tab %d"), closed+1))
+}
+
+// tabIndex returns the tab a jump key selects ("alt+2" -> 1).
+func tabIndex(key string) (int, bool) {
+	n, err := strconv.Atoi(strings.TrimPrefix(key, "alt+"))
+	if !strings.HasPrefix(key, "alt+") || err != nil || n < 1 || n > maxTabs {
+		return 0, false
+	}
+	return n - 1, true
+}
+
+// tabLabel names a tab after its current view and filters, e.g. "chrome [443]".
+func tabLabel(w workspace) string {
+	if len(w.stack) == 0 {
+		return ""
+	}
+	var label string
+	switch view := w.stack[len(w.stack)-1]; view.Level {
+	case LevelProcessList:
+		label = "processes"
+	case LevelConnections:
+		label = view.ProcessName
+	case LevelAllConnections:
+		label = "all"
+	case LevelConntrack:
+		label = "conntrack"
+	case LevelDockerPorts:
+		label = "docker ports"
+	case LevelBinds:
+		label = "binds"
+	}
+	if len(w.filterChips) > 0 {
+		label += " [" + strings.Join(w.filterChips, " + ") + "]"
+	}
+	return truncateString(label, 24)
+}
+
+// renderTabBar returns the tabs for the footer status line, the active one
+// highlighted, or "" with a single tab.
+func (m Model) renderTabBar() string {
+	if len(m.tabs) < 2 {
+		return ""
+	}
+	active := lipgloss.NewStyle().Reverse(true)
+	parts := make([]string, len(m.tabs))
+	for i, w := range m.tabs {
+		if i == m.activeTab {
+			w = m.saveWorkspace()
+		}
+		tab := fmt.Sprintf(" %d %s ", i+1, tabLabel(w))
+		if i == m.activeTab {
+			tab = active.Render(tab)
+		}
+		parts[i] = tab
+	}
+	return strings.Join(parts, frame(roundFrame).vertical)
+}
@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func pressAlt(m Model, r rune) Model {
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}, Alt: true})
+	return updated.(Model)
+}
+
+func TestTabs_IndependentViewsAndFilters(t *testing.T) {
+	m := chipsTestModel()
+	m.PushView(ViewState{Level: LevelConnections, ProcessName: "chrome", SortColumn: SortRemote, SortAscending: true})
+	m.filterChips = []string{"443"}
+
+	m = pressKey(m, "T")
+	if len(m.tabs) != 2 || m.activeTab != 1 {
+		t.Fatalf("tabs = %d, active = %d; want 2 tabs on the new one", len(m.tabs), m.activeTab)
+	}
+	if m.CurrentView().Level != LevelProcessList || len(m.filterChips) != 0 {
+		t.Errorf("new tab = %v with chips %v, want a fresh process list", m.CurrentView().Level, m.filterChips)
+	}
+	m.filterChips = []string{"LISTEN"}
+
+	m = pressAlt(m, '1')
+	if m.activeTab != 0 || m.CurrentView().ProcessName != "chrome" || !slices.Equal(m.filterChips, []string{"443"}) {
+		t.Errorf("tab 1 = %q with chips %v, want chrome [443] restored", m.CurrentView().ProcessName, m.filterChips)
+	}
+
+	m = pressSpecial(m, tea.KeyTab)
+	if m.activeTab != 1 || !slices.Equal(m.filterChips, []string{"LISTEN"}) {
+		t.Errorf("tab cycles to %d with chips %v, want tab 2 [LISTEN]", m.activeTab, m.filterChips)
+	}
+	m = pressSpecial(m, tea.KeyShiftTab)
+	if m.activeTab != 0 {
+		t.Errorf("shift+tab -> %d, want 0", m.activeTab)
+	}
+}
+
+func TestTabs_SortIsPerTab(t *testing.T) {
+	m := chipsTestModel()
+	m = pressKey(m, "T")
+	m.CurrentView().SortColumn = SortPID
+
+	m = pressAlt(m, '1')
+	if m.CurrentView().SortColumn == SortPID {
+		t.Error("sorting tab 2 should not change tab 1")
+	}
+	m = pressAlt(m, '2')
+	if m.CurrentView().SortColumn != SortPID {
+		t.Errorf("tab 2 sort = %v, want SortPID kept", m.CurrentView().SortColumn)
+	}
+}
+
+func TestTabs_Close(t *testing.T) {
+	m := chipsTestModel()
+	m.filterChips = []string{"chrome"}
+	m = pressKey(m, "T")
+	m = pressKey(m, "W")
+
+	if len(m.tabs) != 0 || m.activeTab != 0 {
+		t.Errorf("tabs = %d, active = %d; closing down to one tab should drop the tab list", len(m.tabs), m.activeTab)
+	}
+	if !slices.Equal(m.filterChips, []string{"chrome"}) {
+		t.Errorf("chips = %v, want the remaining tab restored", m.filterChips)
+	}
+
+	m = pressKey(m, "W")
+	if last := m.toasts[len(m.toasts)-1].Message; !strings.Contains(last, "Only one tab") {
+		t.Errorf("toast = %q", last)
+	}
+}
+
+func TestTabs_Limit(t *testing.T) {
+	m := chipsTestModel()
+	for range maxTabs + 1 {
+		m = pressKey(m, "T")
+	}
+	if len(m.tabs) != maxTabs {
+		t.Errorf("tabs = %d, want capped at %d", len(m.tabs), maxTabs)
+	}
+	if _, ok := tabIndex("alt+0"); ok {
+		t.Error("alt+0 should not jump")
+	}
+}
+
+func TestRenderTabBar(t *testing.T) {
+	m := chipsTestModel()
+	if m.renderTabBar() != "" {
+		t.Error("a single tab should not render a tab bar")
+	}
+	m.PushView(ViewState{Level: LevelConnections, ProcessName: "chrome"})
+	m = pressKey(m, "T")
+	m.filterChips = []string{"LISTEN"}
+
+	bar := stripAnsi(m.renderTabBar())
+	for _, want := range []string{"1 chrome", "2 processes [LISTEN]"} {
+		if !strings.Contains(bar, want) {
+			t.Errorf("tab bar %q missing %q", bar, want)
+		}
+	}
+}
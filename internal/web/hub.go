@@ -0,0 +1,92 @@
+// Package web serves a read-only live view of netmon's snapshot over HTTP
+// (--web flag): an HTML page, the current snapshot as JSON and a
+// Server-Sent Events stream pushing each new snapshot, all in the --json
+// output format.
+package web
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/kostyay/netmon/internal/model"
+	"github.com/kostyay/netmon/internal/output"
+)
+
+// Hub holds the latest encoded snapshot and fans it out to SSE subscribers.
+// All methods are safe for concurrent use and no-ops on a nil *Hub, so the UI
+// need not check whether the web server is enabled.
+type Hub struct {
+	mu     sync.Mutex
+	latest []byte
+	subs   map[chan []byte]struct{}
+}
+
+// NewHub returns a Hub with no snapshot yet.
+func NewHub() *Hub {
+	return &Hub{subs: make(map[chan []byte]struct{})}
+}
+
+// Publish encodes the snapshot and sends it to every subscriber. The snapshot
+// is encoded before returning, so the caller may reuse ioStats afterwards.
+func (h *Hub) Publish(snapshot *model.NetworkSnapshot, ioStats map[int32]*model.NetIOStats) error {
+	if h == nil || snapshot == nil {
+		return nil
+	}
+	data, err := json.Marshal(output.BuildJSON(snapshot, ioStats))
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.latest = data
+	for ch := range h.subs {
+		// A slow client only needs the newest snapshot: replace the pending one
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- data
+	}
+	return nil
+}
+
+// Latest returns the last published snapshot, nil before the first.
+func (h *Hub) Latest() []byte {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.latest
+}
+
+// Subscribe returns a channel receiving each published snapshot, primed with
+// the latest one, and a function ending the subscription.
+func (h *Hub) Subscribe() (<-chan []byte, func()) {
+	ch := make(chan []byte, 1)
+	if h == nil {
+		return ch, func() {}
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.latest != nil {
+		ch <- h.latest
+	}
+	h.subs[ch] = struct{}{}
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.subs, ch)
+	}
+}
+
+// Subscribers returns the number of connected event streams.
+func (h *Hub) Subscribers() int {
+	if h == nil {
+		return 0
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subs)
+}
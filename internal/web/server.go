@@ -0,0 +1,93 @@
+package web
+
+import (
+	_ "embed"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// keepAliveInterval is how often an idle event stream gets a comment line,
+// so proxies do not close it between snapshots.
+const keepAliveInterval = 15 * time.Second
+
+//go:embed index.html
+var indexHTML []byte
+
+// Handler returns the web view mux: / (HTML page), /snapshot.json (current
+// snapshot) and /events (SSE stream, one "data:" event per snapshot).
+// Nothing can be changed through it.
+func Handler(hub *Hub) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(indexHTML)
+	})
+	mux.HandleFunc("GET /snapshot.json", func(w http.ResponseWriter, r *http.Request) {
+		data := hub.Latest()
+		if data == nil {
+			http.Error(w, "no snapshot yet", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		_, _ = w.Write(data)
+	})
+	mux.HandleFunc("GET /events", func(w http.ResponseWriter, r *http.Request) {
+		serveEvents(w, r, hub)
+	})
+	return mux
+}
+
+// serveEvents streams each published snapshot until the client goes away.
+func serveEvents(w http.ResponseWriter, r *http.Request, hub *Hub) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	updates, cancel := hub.Subscribe()
+	defer cancel()
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case data := <-updates:
+			// Encoded JSON has no newlines, so it fits in one data line
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// Start listens on addr and serves the web view in the background.
+// Listen errors are returned immediately; the returned server can be closed on exit.
+func Start(addr string, hub *Hub) (*http.Server, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	// No write timeout: event streams stay open for the whole session
+	srv := &http.Server{
+		Handler:           Handler(hub),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() { _ = srv.Serve(ln) }()
+	return srv, nil
+}
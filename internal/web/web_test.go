@@ -0,0 +1,153 @@
+package web
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kostyay/netmon/internal/model"
+	"github.com/kostyay/netmon/internal/output"
+)
+
+func testSnapshot(name string) *model.NetworkSnapshot {
+	return &model.NetworkSnapshot{
+		Timestamp: time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC),
+		Applications: []model.Application{{
+			Name: name,
+			PIDs: []int32{10},
+			Connections: []model.Connection{
+				{PID: 10, Protocol: model.ProtocolTCP, LocalAddr: "10.0.0.1:50000", RemoteAddr: "1.1.1.1:443", State: model.StateEstablished},
+			},
+			EstablishedCount: 1,
+		}},
+	}
+}
+
+func TestHub_NilSafe(t *testing.T) {
+	var h *Hub
+	if err := h.Publish(testSnapshot("chrome"), nil); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if h.Latest() != nil || h.Subscribers() != 0 {
+		t.Error("nil hub should hold nothing")
+	}
+	_, cancel := h.Subscribe()
+	cancel()
+}
+
+func TestHub_SubscriberGetsLatestOnly(t *testing.T) {
+	h := NewHub()
+	_ = h.Publish(testSnapshot("chrome"), nil)
+	updates, cancel := h.Subscribe()
+	_ = h.Publish(testSnapshot("curl"), nil)
+
+	var out output.JSONOutput
+	if err := json.Unmarshal(<-updates, &out); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if out.Applications[0].Name != "curl" {
+		t.Errorf("pending snapshot = %q, want the newest (curl)", out.Applications[0].Name)
+	}
+
+	cancel()
+	if h.Subscribers() != 0 {
+		t.Errorf("Subscribers() = %d after cancel, want 0", h.Subscribers())
+	}
+}
+
+func TestHandler_PageAndSnapshot(t *testing.T) {
+	h := NewHub()
+	srv := httptest.NewServer(Handler(h))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/snapshot.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("before first snapshot: status = %d, want 503", resp.StatusCode)
+	}
+
+	_ = h.Publish(testSnapshot("chrome"), map[int32]*model.NetIOStats{10: {BytesSent: 100}})
+	resp, err = http.Get(srv.URL + "/snapshot.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out output.JSONOutput
+	err = json.NewDecoder(resp.Body).Decode(&out)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if len(out.Applications) != 1 || out.Applications[0].BytesSent != 100 {
+		t.Errorf("snapshot = %+v", out.Applications)
+	}
+
+	resp, err = http.Get(srv.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(body), "EventSource") {
+		t.Error("index page should subscribe to the event stream")
+	}
+
+	resp, err = http.Post(srv.URL+"/snapshot.json", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("POST status = %d, want 405 (read-only)", resp.StatusCode)
+	}
+}
+
+func TestHandler_EventsStreamSnapshots(t *testing.T) {
+	h := NewHub()
+	_ = h.Publish(testSnapshot("chrome"), nil)
+	srv := httptest.NewServer(Handler(h))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/events", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+
+	lines := bufio.NewScanner(resp.Body)
+	next := func() string {
+		for lines.Scan() {
+			if data, ok := strings.CutPrefix(lines.Text(), "data: "); ok {
+				var out output.JSONOutput
+				if err := json.Unmarshal([]byte(data), &out); err != nil {
+					t.Fatalf("event is not a snapshot: %v", err)
+				}
+				return out.Applications[0].Name
+			}
+		}
+		t.Fatalf("stream ended: %v", lines.Err())
+		return ""
+	}
+
+	if got := next(); got != "chrome" {
+		t.Errorf("first event = %q, want the latest snapshot (chrome)", got)
+	}
+	_ = h.Publish(testSnapshot("curl"), nil)
+	if got := next(); got != "curl" {
+		t.Errorf("second event = %q, want curl", got)
+	}
+}